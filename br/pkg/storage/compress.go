@@ -41,6 +41,20 @@ func (w *withCompression) Create(ctx context.Context, name string) (ExternalFile
 	return compressedWriter, nil
 }
 
+func (w *withCompression) createExclusive(ctx context.Context, name string) (ExternalFileWriter, error) {
+	if _, ok := w.ExternalStorage.(exclusiveCreator); !ok {
+		if err := checkFileNotExists(ctx, w.ExternalStorage, name); err != nil {
+			return nil, err
+		}
+		return w.Create(ctx, name)
+	}
+	writer, err := CreateExclusive(ctx, w.ExternalStorage, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newBufferedWriter(writer, hardcodedS3ChunkSize, w.compressType), nil
+}
+
 func (w *withCompression) Open(ctx context.Context, path string) (ExternalFileReader, error) {
 	fileReader, err := w.ExternalStorage.Open(ctx, path)
 	if err != nil {
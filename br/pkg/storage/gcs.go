@@ -5,6 +5,7 @@ package storage
 import (
 	"context"
 	"io"
+	"net/http"
 	"os"
 	"path"
 	"strings"
@@ -17,6 +18,7 @@ import (
 	"github.com/spf13/pflag"
 	"go.uber.org/zap"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
@@ -226,6 +228,29 @@ func (s *gcsStorage) Create(ctx context.Context, name string) (ExternalFileWrite
 	return newFlushStorageWriter(wc, &emptyFlusher{}, wc), nil
 }
 
+func (s *gcsStorage) createExclusive(ctx context.Context, name string) (ExternalFileWriter, error) {
+	object := s.objectName(name)
+	wc := s.bucket.Object(object).If(storage.Conditions{DoesNotExist: true}).NewWriter(ctx)
+	wc.StorageClass = s.gcs.StorageClass
+	wc.PredefinedACL = s.gcs.PredefinedAcl
+	return newFlushStorageWriter(wc, &emptyFlusher{}, &exclusiveGCSCloser{Writer: wc, name: name}), nil
+}
+
+// exclusiveGCSCloser converts the failed precondition of the object, which means the object already exists, to an
+// error satisfying os.IsExist.
+type exclusiveGCSCloser struct {
+	*storage.Writer
+	name string
+}
+
+func (w *exclusiveGCSCloser) Close() error {
+	err := w.Writer.Close()
+	if e, ok := err.(*googleapi.Error); ok && e.Code == http.StatusPreconditionFailed {
+		return errors.Trace(&os.PathError{Op: "create", Path: w.name, Err: os.ErrExist})
+	}
+	return errors.Trace(err)
+}
+
 func newGCSStorage(ctx context.Context, gcs *backuppb.GCS, opts *ExternalStorageOptions) (*gcsStorage, error) {
 	var clientOps []option.ClientOption
 	if opts.NoCredentials {
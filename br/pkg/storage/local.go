@@ -106,6 +106,15 @@ func (l *LocalStorage) Create(ctx context.Context, name string) (ExternalFileWri
 	return newFlushStorageWriter(buf, buf, file), nil
 }
 
+func (l *LocalStorage) createExclusive(ctx context.Context, name string) (ExternalFileWriter, error) {
+	file, err := os.OpenFile(filepath.Join(l.base, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o666)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	buf := bufio.NewWriter(file)
+	return newFlushStorageWriter(buf, buf, file), nil
+}
+
 func pathExists(_path string) (bool, error) {
 	_, err := os.Stat(_path)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"runtime"
 
 	. "github.com/pingcap/check"
+	"github.com/pingcap/errors"
 )
 
 type testLocalSuite struct{}
@@ -42,6 +43,31 @@ func (r *testStorageSuite) TestDeleteFile(c *C) {
 	c.Assert(ret, Equals, false)
 }
 
+func (r *testStorageSuite) TestCreateExclusive(c *C) {
+	dir := c.MkDir()
+	sb, err := ParseBackend("file://"+filepath.ToSlash(dir), &BackendOptions{})
+	c.Assert(err, IsNil)
+	store, err := Create(context.TODO(), sb, true)
+	c.Assert(err, IsNil)
+	ctx := context.Background()
+
+	for _, s := range []ExternalStorage{store, WithCompression(store, Gzip)} {
+		c.Assert(os.WriteFile(filepath.Join(dir, "existing"), []byte("data"), 0o644), IsNil)
+		_, err = CreateExclusive(ctx, s, "existing")
+		c.Assert(os.IsExist(errors.Cause(err)), IsTrue, Commentf("%v", err))
+		data, err := os.ReadFile(filepath.Join(dir, "existing"))
+		c.Assert(err, IsNil)
+		c.Assert(string(data), Equals, "data")
+
+		w, err := CreateExclusive(ctx, s, "new")
+		c.Assert(err, IsNil)
+		_, err = w.Write(ctx, []byte("data"))
+		c.Assert(err, IsNil)
+		c.Assert(w.Close(ctx), IsNil)
+		c.Assert(os.Remove(filepath.Join(dir, "new")), IsNil)
+	}
+}
+
 func (r *testStorageSuite) TestWalkDirWithSoftLinkFile(c *C) {
 	if runtime.GOOS == "windows" {
 		// skip the test on windows. typically windows users don't have symlink permission.
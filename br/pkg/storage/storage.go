@@ -6,6 +6,7 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"os"
 
 	"github.com/pingcap/errors"
 	backuppb "github.com/pingcap/kvproto/pkg/brpb"
@@ -110,6 +111,36 @@ type ExternalFileWriter interface {
 	Close(ctx context.Context) error
 }
 
+// exclusiveCreator is implemented by the storages which can create a file only if it doesn't exist.
+type exclusiveCreator interface {
+	createExclusive(ctx context.Context, name string) (ExternalFileWriter, error)
+}
+
+// CreateExclusive opens a file writer like Create, but it fails with an error satisfying os.IsExist instead of
+// overwriting the file if it already exists. The local storage fails when the file is created, and the GCS storage
+// fails when the writer is closed. The other storages can't create a file conditionally, so the existence is only
+// checked before the file is created.
+func CreateExclusive(ctx context.Context, s ExternalStorage, name string) (ExternalFileWriter, error) {
+	if c, ok := s.(exclusiveCreator); ok {
+		return c.createExclusive(ctx, name)
+	}
+	if err := checkFileNotExists(ctx, s, name); err != nil {
+		return nil, err
+	}
+	return s.Create(ctx, name)
+}
+
+func checkFileNotExists(ctx context.Context, s ExternalStorage, name string) error {
+	exists, err := s.FileExists(ctx, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		return errors.Trace(&os.PathError{Op: "create", Path: name, Err: os.ErrExist})
+	}
+	return nil
+}
+
 // ExternalStorageOptions are backend-independent options provided to New.
 type ExternalStorageOptions struct {
 	// SendCredentials marks whether to send credentials downstream.
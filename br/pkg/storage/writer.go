@@ -6,6 +6,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/pingcap/errors"
 )
 
@@ -17,6 +18,8 @@ const (
 	NoCompression CompressType = iota
 	// Gzip will compress given bytes in gzip format.
 	Gzip
+	// Zstd will compress given bytes in zstd format.
+	Zstd
 )
 
 type flusher interface {
@@ -49,6 +52,12 @@ func newCompressWriter(compressType CompressType, w io.Writer) simpleCompressWri
 	switch compressType {
 	case Gzip:
 		return gzip.NewWriter(w)
+	case Zstd:
+		newWriter, err := zstd.NewWriter(w)
+		if err != nil {
+			return nil
+		}
+		return newWriter
 	default:
 		return nil
 	}
@@ -58,6 +67,12 @@ func newCompressReader(compressType CompressType, r io.Reader) (io.ReadCloser, e
 	switch compressType {
 	case Gzip:
 		return gzip.NewReader(r)
+	case Zstd:
+		newReader, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return newReader.IOReadCloser(), nil
 	default:
 		return nil, nil
 	}
@@ -101,8 +101,12 @@ func (r *testStorageSuite) TestCompressReaderWriter(c *C) {
 		ctx := context.Background()
 		storage, err := Create(ctx, backend, true)
 		c.Assert(err, IsNil)
-		storage = WithCompression(storage, Gzip)
-		fileName := strings.ReplaceAll(test.name, " ", "-") + ".txt.gz"
+		storage = WithCompression(storage, test.compressType)
+		suffix := ".txt.gz"
+		if test.compressType == Zstd {
+			suffix = ".txt.zst"
+		}
+		fileName := strings.ReplaceAll(test.name, " ", "-") + suffix
 		writer, err := storage.Create(ctx, fileName)
 		c.Assert(err, IsNil)
 		for _, str := range test.content {
@@ -134,7 +138,7 @@ func (r *testStorageSuite) TestCompressReaderWriter(c *C) {
 
 		c.Assert(file.Close(), IsNil)
 	}
-	compressTypeArr := []CompressType{Gzip}
+	compressTypeArr := []CompressType{Gzip, Zstd}
 	tests := []testcase{
 		{
 			name: "long text medium chunks",
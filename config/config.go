@@ -536,6 +536,16 @@ type StmtSummary struct {
 	RefreshInterval int `toml:"refresh-interval" json:"refresh-interval"`
 	// The maximum history size of statement summary.
 	HistorySize int `toml:"history-size" json:"history-size"`
+	// Persist the history of statement summary to the files or not.
+	EnablePersistent bool `toml:"enable-persistent" json:"enable-persistent"`
+	// The file to persist the history of statement summary.
+	Filename string `toml:"filename" json:"filename"`
+	// The maximum size of the file in MB before it's rotated.
+	FileMaxSize int `toml:"file-max-size" json:"file-max-size"`
+	// The maximum number of days to retain the rotated files, 0 means no limit.
+	FileMaxDays int `toml:"file-max-days" json:"file-max-days"`
+	// The maximum number of the rotated files to retain, 0 means no limit.
+	FileMaxBackups int `toml:"file-max-backups" json:"file-max-backups"`
 }
 
 // TopSQL is the config for TopSQL.
@@ -673,6 +683,11 @@ var defaultConf = Config{
 		MaxSQLLength:        4096,
 		RefreshInterval:     1800,
 		HistorySize:         24,
+		EnablePersistent:    false,
+		Filename:            "tidb-statements.log",
+		FileMaxSize:         64,
+		FileMaxDays:         3,
+		FileMaxBackups:      0,
 	},
 	IsolationRead: IsolationRead{
 		Engines: []string{"tikv", "tiflash", "tidb"},
@@ -907,6 +922,17 @@ func (c *Config) Valid() error {
 	if c.StmtSummary.RefreshInterval <= 0 {
 		return fmt.Errorf("refresh-interval in [stmt-summary] should be greater than 0")
 	}
+	if c.StmtSummary.EnablePersistent {
+		if len(c.StmtSummary.Filename) == 0 {
+			return fmt.Errorf("filename in [stmt-summary] should not be empty when enable-persistent is true")
+		}
+		if c.StmtSummary.FileMaxSize <= 0 {
+			return fmt.Errorf("file-max-size in [stmt-summary] should be greater than 0")
+		}
+		if c.StmtSummary.FileMaxDays < 0 || c.StmtSummary.FileMaxBackups < 0 {
+			return fmt.Errorf("file-max-days and file-max-backups in [stmt-summary] should be greater than or equal to 0")
+		}
+	}
 
 	if c.PreparedPlanCache.Capacity < 1 {
 		return fmt.Errorf("capacity in [prepared-plan-cache] should be at least 1")
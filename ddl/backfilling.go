@@ -46,15 +46,19 @@ import (
 type backfillWorkerType byte
 
 const (
-	typeAddIndexWorker     backfillWorkerType = 0
-	typeUpdateColumnWorker backfillWorkerType = 1
-	typeCleanUpIndexWorker backfillWorkerType = 2
+	typeAddIndexWorker        backfillWorkerType = 0
+	typeUpdateColumnWorker    backfillWorkerType = 1
+	typeCleanUpIndexWorker    backfillWorkerType = 2
+	typeCheckConstraintWorker backfillWorkerType = 3
+	typeReorgPartitionWorker  backfillWorkerType = 4
 )
 
 // By now the DDL jobs that need backfilling include:
 // 1: add-index
 // 2: modify-column-type
 // 3: clean-up global index
+// 4: add-check-constraint (only verifies the rows existed)
+// 5: reorganize-partition
 //
 // They all have a write reorganization state to back fill data into the rows existed.
 // Backfilling is time consuming, to accelerate this process, TiDB has built some sub
@@ -111,6 +115,10 @@ func (bWT backfillWorkerType) String() string {
 		return "update column"
 	case typeCleanUpIndexWorker:
 		return "clean up index"
+	case typeCheckConstraintWorker:
+		return "check constraint"
+	case typeReorgPartitionWorker:
+		return "reorganize partition"
 	default:
 		return "unknown"
 	}
@@ -394,6 +402,10 @@ func (w *worker) handleReorgTasks(reorgInfo *reorgInfo, totalAddedCount *int64,
 	}
 
 	if err != nil {
+		if errPausedDDLJob.Equal(err) {
+			// The paused job continues from the next key after being resumed.
+			w.reorgCtx.setNextKey(nextKey)
+		}
 		// Update the reorg handle that has been processed.
 		err1 := reorgInfo.UpdateReorgMeta(nextKey)
 		metrics.BatchAddIdxHistogram.WithLabelValues(metrics.LblError).Observe(elapsedTime.Seconds())
@@ -622,6 +634,22 @@ func (w *worker) writePhysicalTableRecord(t table.PhysicalTable, bfWorkerType ba
 				idxWorker.priority = job.Priority
 				backfillWorkers = append(backfillWorkers, idxWorker.backfillWorker)
 				go idxWorker.backfillWorker.run(reorgInfo.d, idxWorker, job)
+			case typeCheckConstraintWorker:
+				checkWorker, err := newCheckConstraintWorker(sessCtx, w, i, t, reorgInfo.currElement, decodeColMap)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				checkWorker.priority = job.Priority
+				backfillWorkers = append(backfillWorkers, checkWorker.backfillWorker)
+				go checkWorker.backfillWorker.run(reorgInfo.d, checkWorker, job)
+			case typeReorgPartitionWorker:
+				partWorker, err := newReorgPartitionWorker(sessCtx, w, i, t, decodeColMap, reorgInfo)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				partWorker.priority = job.Priority
+				backfillWorkers = append(backfillWorkers, partWorker.backfillWorker)
+				go partWorker.backfillWorker.run(reorgInfo.d, partWorker, job)
 			default:
 				return errors.New("unknow backfill type")
 			}
@@ -62,7 +62,9 @@ func adjustColumnInfoInAddColumn(tblInfo *model.TableInfo, offset int) {
 	newCols = append(newCols, oldCols[len(oldCols)-1])
 	newCols = append(newCols, oldCols[offset:len(oldCols)-1]...)
 	// Adjust column offset.
-	offsetChanged := make(map[int]int, len(newCols)-offset-1)
+	offsetChanged := make(map[int]int, len(newCols)-offset)
+	// The indexes on the non-public column may be added in the same multi-schema change.
+	offsetChanged[newCols[offset].Offset] = offset
 	for i := offset + 1; i < len(newCols); i++ {
 		offsetChanged[newCols[i].Offset] = i
 		newCols[i].Offset = i
@@ -145,19 +147,22 @@ func createColumnInfo(tblInfo *model.TableInfo, colInfo *model.ColumnInfo, pos *
 	return colInfo, pos, offset, nil
 }
 
-func checkAddColumn(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.ColumnInfo, *model.ColumnInfo, *ast.ColumnPosition, int, error) {
+// checkAddColumn decodes the add column job. The returned check constraints are defined on the column, they're
+// added to the table with the column.
+func checkAddColumn(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.ColumnInfo, *model.ColumnInfo, *ast.ColumnPosition, int, []*model.ConstraintInfo, error) {
 	schemaID := job.SchemaID
 	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, schemaID)
 	if err != nil {
-		return nil, nil, nil, nil, 0, errors.Trace(err)
+		return nil, nil, nil, nil, 0, nil, errors.Trace(err)
 	}
 	col := &model.ColumnInfo{}
 	pos := &ast.ColumnPosition{}
 	offset := 0
-	err = job.DecodeArgs(col, pos, &offset)
+	var constraints []*model.ConstraintInfo
+	err = job.DecodeArgs(col, pos, &offset, &constraints)
 	if err != nil {
 		job.State = model.JobStateCancelled
-		return nil, nil, nil, nil, 0, errors.Trace(err)
+		return nil, nil, nil, nil, 0, nil, errors.Trace(err)
 	}
 
 	columnInfo := model.FindColumnInfo(tblInfo.Columns, col.Name.L)
@@ -165,13 +170,13 @@ func checkAddColumn(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.Colu
 		if columnInfo.State == model.StatePublic {
 			// We already have a column with the same column name.
 			job.State = model.JobStateCancelled
-			return nil, nil, nil, nil, 0, infoschema.ErrColumnExists.GenWithStackByArgs(col.Name)
+			return nil, nil, nil, nil, 0, nil, infoschema.ErrColumnExists.GenWithStackByArgs(col.Name)
 		}
 	}
-	return tblInfo, columnInfo, col, pos, offset, nil
+	return tblInfo, columnInfo, col, pos, offset, constraints, nil
 }
 
-func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+func (w *worker) onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
 	// Handle the rolling back job.
 	if job.IsRollingback() {
 		ver, err = onDropColumn(t, job)
@@ -187,7 +192,7 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 		}
 	})
 
-	tblInfo, columnInfo, col, pos, offset, err := checkAddColumn(t, job)
+	tblInfo, columnInfo, col, pos, offset, constraints, err := checkAddColumn(t, job)
 	if err != nil {
 		return ver, errors.Trace(err)
 	}
@@ -198,6 +203,7 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 			return ver, errors.Trace(err)
 		}
 		logutil.BgLogger().Info("[ddl] run add column job", zap.String("job", job.String()), zap.Reflect("columnInfo", *columnInfo), zap.Int("offset", offset))
+		addColumnCheckConstraints(tblInfo, []*model.ColumnInfo{columnInfo}, constraints)
 		// Set offset arg to job.
 		if offset != 0 {
 			job.Args = []interface{}{columnInfo, pos, offset}
@@ -213,6 +219,7 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 	case model.StateNone:
 		// none -> delete only
 		columnInfo.State = model.StateDeleteOnly
+		setColumnCheckConstraintsState(tblInfo, []*model.ColumnInfo{columnInfo}, model.StateDeleteOnly)
 		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != columnInfo.State)
 		if err != nil {
 			return ver, errors.Trace(err)
@@ -221,6 +228,7 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 	case model.StateDeleteOnly:
 		// delete only -> write only
 		columnInfo.State = model.StateWriteOnly
+		setColumnCheckConstraintsState(tblInfo, []*model.ColumnInfo{columnInfo}, model.StateWriteOnly)
 		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != columnInfo.State)
 		if err != nil {
 			return ver, errors.Trace(err)
@@ -230,17 +238,27 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 	case model.StateWriteOnly:
 		// write only -> reorganization
 		columnInfo.State = model.StateWriteReorganization
+		setColumnCheckConstraintsState(tblInfo, []*model.ColumnInfo{columnInfo}, model.StateWriteReorganization)
 		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != columnInfo.State)
 		if err != nil {
 			return ver, errors.Trace(err)
 		}
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
 		// Update the job state when all affairs done.
 		job.SchemaState = model.StateWriteReorganization
 	case model.StateWriteReorganization:
 		// reorganization -> public
+		// The existing rows are checked by the check constraints of the column before it becomes public.
+		var done bool
+		done, ver, err = w.verifyColumnCheckConstraints(d, t, job, tblInfo, []*model.ColumnInfo{columnInfo})
+		if err != nil || !done {
+			return ver, errors.Trace(err)
+		}
 		// Adjust table column offset.
 		adjustColumnInfoInAddColumn(tblInfo, offset)
 		columnInfo.State = model.StatePublic
+		setColumnCheckConstraintsState(tblInfo, []*model.ColumnInfo{columnInfo}, model.StatePublic)
 		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != columnInfo.State)
 		if err != nil {
 			return ver, errors.Trace(err)
@@ -256,20 +274,23 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 	return ver, errors.Trace(err)
 }
 
-func checkAddColumns(t *meta.Meta, job *model.Job) (*model.TableInfo, []*model.ColumnInfo, []*model.ColumnInfo, []*ast.ColumnPosition, []int, []bool, error) {
+// checkAddColumns decodes the add columns job. The returned check constraints are defined on the columns, they're
+// added to the table with the columns.
+func checkAddColumns(t *meta.Meta, job *model.Job) (*model.TableInfo, []*model.ColumnInfo, []*model.ColumnInfo, []*ast.ColumnPosition, []int, []bool, []*model.ConstraintInfo, error) {
 	schemaID := job.SchemaID
 	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, schemaID)
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, errors.Trace(err)
+		return nil, nil, nil, nil, nil, nil, nil, errors.Trace(err)
 	}
 	columns := []*model.ColumnInfo{}
 	positions := []*ast.ColumnPosition{}
 	offsets := []int{}
 	ifNotExists := []bool{}
-	err = job.DecodeArgs(&columns, &positions, &offsets, &ifNotExists)
+	var constraints []*model.ConstraintInfo
+	err = job.DecodeArgs(&columns, &positions, &offsets, &ifNotExists, &constraints)
 	if err != nil {
 		job.State = model.JobStateCancelled
-		return nil, nil, nil, nil, nil, nil, errors.Trace(err)
+		return nil, nil, nil, nil, nil, nil, nil, errors.Trace(err)
 	}
 
 	columnInfos := make([]*model.ColumnInfo, 0, len(columns))
@@ -288,7 +309,7 @@ func checkAddColumns(t *meta.Meta, job *model.Job) (*model.TableInfo, []*model.C
 					continue
 				}
 				job.State = model.JobStateCancelled
-				return nil, nil, nil, nil, nil, nil, infoschema.ErrColumnExists.GenWithStackByArgs(col.Name)
+				return nil, nil, nil, nil, nil, nil, nil, infoschema.ErrColumnExists.GenWithStackByArgs(col.Name)
 			}
 			columnInfos = append(columnInfos, columnInfo)
 		}
@@ -297,7 +318,7 @@ func checkAddColumns(t *meta.Meta, job *model.Job) (*model.TableInfo, []*model.C
 		newOffsets = append(newOffsets, offsets[i])
 		newIfNotExists = append(newIfNotExists, ifNotExists[i])
 	}
-	return tblInfo, columnInfos, newColumns, newPositions, newOffsets, newIfNotExists, nil
+	return tblInfo, columnInfos, newColumns, newPositions, newOffsets, newIfNotExists, constraints, nil
 }
 
 func setColumnsState(columnInfos []*model.ColumnInfo, state model.SchemaState) {
@@ -312,7 +333,9 @@ func setIndicesState(indexInfos []*model.IndexInfo, state model.SchemaState) {
 	}
 }
 
-func onAddColumns(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+// onAddColumns runs the add columns job. The check constraints of the columns are verified in the write reorganization
+// state, except for the multi-schema change sub-job, which is verified before it's committed, see runRevertibleSubJob.
+func (w *worker) onAddColumns(d *ddlCtx, t *meta.Meta, job *model.Job, isSubJob bool) (ver int64, err error) {
 	// Handle the rolling back job.
 	if job.IsRollingback() {
 		ver, err = onDropColumns(t, job)
@@ -328,7 +351,7 @@ func onAddColumns(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error
 		}
 	})
 
-	tblInfo, columnInfos, columns, positions, offsets, ifNotExists, err := checkAddColumns(t, job)
+	tblInfo, columnInfos, columns, positions, offsets, ifNotExists, constraints, err := checkAddColumns(t, job)
 	if err != nil {
 		return ver, errors.Trace(err)
 	}
@@ -352,6 +375,7 @@ func onAddColumns(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error
 			}
 			columnInfos = append(columnInfos, columnInfo)
 		}
+		addColumnCheckConstraints(tblInfo, columnInfos, constraints)
 		// Set arg to job.
 		job.Args = []interface{}{columnInfos, positions, offsets, ifNotExists}
 	}
@@ -361,6 +385,7 @@ func onAddColumns(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error
 	case model.StateNone:
 		// none -> delete only
 		setColumnsState(columnInfos, model.StateDeleteOnly)
+		setColumnCheckConstraintsState(tblInfo, columnInfos, model.StateDeleteOnly)
 		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != columnInfos[0].State)
 		if err != nil {
 			return ver, errors.Trace(err)
@@ -369,6 +394,7 @@ func onAddColumns(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error
 	case model.StateDeleteOnly:
 		// delete only -> write only
 		setColumnsState(columnInfos, model.StateWriteOnly)
+		setColumnCheckConstraintsState(tblInfo, columnInfos, model.StateWriteOnly)
 		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != columnInfos[0].State)
 		if err != nil {
 			return ver, errors.Trace(err)
@@ -377,13 +403,23 @@ func onAddColumns(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error
 	case model.StateWriteOnly:
 		// write only -> reorganization
 		setColumnsState(columnInfos, model.StateWriteReorganization)
+		setColumnCheckConstraintsState(tblInfo, columnInfos, model.StateWriteReorganization)
 		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != columnInfos[0].State)
 		if err != nil {
 			return ver, errors.Trace(err)
 		}
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
 		job.SchemaState = model.StateWriteReorganization
 	case model.StateWriteReorganization:
 		// reorganization -> public
+		if !isSubJob {
+			var done bool
+			done, ver, err = w.verifyColumnCheckConstraints(d, t, job, tblInfo, columnInfos)
+			if err != nil || !done {
+				return ver, errors.Trace(err)
+			}
+		}
 		// Adjust table column offsets.
 		oldCols := tblInfo.Columns[:len(tblInfo.Columns)-len(offsets)]
 		newCols := tblInfo.Columns[len(tblInfo.Columns)-len(offsets):]
@@ -405,6 +441,7 @@ func onAddColumns(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error
 			adjustColumnInfoInAddColumn(tblInfo, offsets[i])
 		}
 		setColumnsState(columnInfos, model.StatePublic)
+		setColumnCheckConstraintsState(tblInfo, columnInfos, model.StatePublic)
 		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != columnInfos[0].State)
 		if err != nil {
 			return ver, errors.Trace(err)
@@ -440,6 +477,7 @@ func onDropColumns(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 			if err != nil {
 				return ver, errors.Trace(err)
 			}
+			removeDependentCheckConstraints(tblInfo, colInfo.Name)
 		}
 		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != colInfos[0].State)
 		if err != nil {
@@ -532,6 +570,10 @@ func checkDropColumns(t *meta.Meta, job *model.Job) (*model.TableInfo, []*model.
 			job.State = model.JobStateCancelled
 			return nil, nil, 0, nil, errors.Trace(err)
 		}
+		if err = checkDropColumnWithTTL(tblInfo, colInfo); err != nil {
+			job.State = model.JobStateCancelled
+			return nil, nil, 0, nil, errors.Trace(err)
+		}
 		newColNames = append(newColNames, colName)
 		newIfExists = append(newIfExists, ifExists[i])
 		colInfos = append(colInfos, colInfo)
@@ -580,6 +622,7 @@ func onDropColumn(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 		if err != nil {
 			return ver, errors.Trace(err)
 		}
+		removeDependentCheckConstraints(tblInfo, colInfo.Name)
 		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != colInfo.State)
 		if err != nil {
 			return ver, errors.Trace(err)
@@ -661,6 +704,10 @@ func checkDropColumn(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.Col
 		job.State = model.JobStateCancelled
 		return nil, nil, nil, errors.Trace(err)
 	}
+	if err = checkDropColumnWithTTL(tblInfo, colInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, nil, errors.Trace(err)
+	}
 	idxInfos := listIndicesWithColumn(colName.L, tblInfo.Indices)
 	if len(idxInfos) > 0 {
 		for _, idxInfo := range idxInfos {
@@ -714,6 +761,9 @@ func needChangeColumnData(oldCol, newCol *model.ColumnInfo) bool {
 			if types.IsBinaryStr(&oldCol.FieldType) {
 				return newCol.Flen != oldCol.Flen
 			}
+		case mysql.TypeGeometry:
+			// The values must be checked when the column is narrowed to a specific geometry type.
+			return oldCol.GeomType != newCol.GeomType && newCol.GeomType != mysql.GeomTypeGeometry
 		}
 
 		return needTruncationOrToggleSign()
@@ -860,6 +910,10 @@ func (w *worker) onModifyColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver in
 			return ver, errors.Trace(infoschema.ErrColumnExists.GenWithStackByArgs(jobParam.newCol.Name))
 		}
 	}
+	if err = checkModifyColumnWithTTL(tblInfo, oldCol, jobParam.newCol); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
 
 	failpoint.Inject("uninitializedOffsetAndState", func(val failpoint.Value) {
 		if val.(bool) {
@@ -1589,6 +1643,7 @@ func adjustColumnInfoInModifyColumn(
 			}
 		}
 	}
+	renameTTLColumn(tblInfo, oldCol.Name, newCol.Name)
 	return nil
 }
 
@@ -0,0 +1,783 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/logutil"
+	decoder "github.com/pingcap/tidb/util/rowDecoder"
+	"github.com/pingcap/tidb/util/timeutil"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// setNameForCheckConstraint sets a generated name like `t_chk_1` for the unnamed check constraint.
+func setNameForCheckConstraint(tblName string, constr *ast.Constraint, existed map[string]struct{}) {
+	if constr.Name != "" {
+		return
+	}
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("%s_chk_%d", tblName, i)
+		if _, ok := existed[strings.ToLower(name)]; !ok {
+			constr.Name = name
+			return
+		}
+	}
+}
+
+// checkCheckConstraint checks whether the check constraint expression is valid for the table,
+// it returns the names of the columns depended by the constraint.
+func checkCheckConstraint(ctx sessionctx.Context, tblInfo *model.TableInfo, constr *ast.Constraint) ([]model.CIStr, error) {
+	if err := checkIllegalFn4Generated(constr.Name, typeCheckConstraint, constr.Expr); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	_, autoIncCol := infoschema.HasAutoIncrementColumn(tblInfo)
+	colNames := findColumnNamesInExpr(constr.Expr)
+	dependedCols := make([]model.CIStr, 0, len(colNames))
+	dependedColsMap := make(map[string]struct{}, len(colNames))
+	for _, colName := range colNames {
+		if constr.InColumn && colName.Name.L != strings.ToLower(constr.InColumnName) {
+			return nil, ErrColumnCheckConstraintReferencesOtherColumn.GenWithStackByArgs(constr.Name)
+		}
+		colInfo := model.FindColumnInfo(tblInfo.Columns, colName.Name.L)
+		if colInfo == nil || colInfo.State != model.StatePublic {
+			return nil, ErrCheckConstraintRefersUnknownColumn.GenWithStackByArgs(constr.Name, colName.Name.O)
+		}
+		if colInfo.Name.L == autoIncCol {
+			return nil, ErrCheckConstraintRefersAutoIncrementColumn.GenWithStackByArgs(constr.Name)
+		}
+		if _, ok := dependedColsMap[colInfo.Name.L]; !ok {
+			dependedColsMap[colInfo.Name.L] = struct{}{}
+			dependedCols = append(dependedCols, colInfo.Name)
+		}
+	}
+
+	// Make sure the expression can be evaluated on the table.
+	if _, err := expression.RewriteSimpleExprWithTableInfo(ctx, tblInfo, constr.Expr); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return dependedCols, nil
+}
+
+// buildConstraintInfo builds the check constraint meta data from the ast.Constraint.
+func buildConstraintInfo(tblInfo *model.TableInfo, dependedCols []model.CIStr, constr *ast.Constraint, state model.SchemaState) (*model.ConstraintInfo, error) {
+	var sb strings.Builder
+	restoreFlags := format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase | format.RestoreNameBackQuotes |
+		format.RestoreSpacesAroundBinaryOperation
+	restoreCtx := format.NewRestoreCtx(restoreFlags, &sb)
+	if err := constr.Expr.Restore(restoreCtx); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	constrInfo := &model.ConstraintInfo{
+		Name:           model.NewCIStr(constr.Name),
+		Table:          tblInfo.Name,
+		ConstraintCols: dependedCols,
+		Enforced:       constr.Enforced,
+		InColumn:       constr.InColumn,
+		ExprString:     sb.String(),
+		State:          state,
+	}
+	return constrInfo, nil
+}
+
+// buildCheckConstraints checks and builds the check constraints defined in a CREATE TABLE statement.
+func buildCheckConstraints(ctx sessionctx.Context, tblInfo *model.TableInfo, constraints []*ast.Constraint) error {
+	existed := make(map[string]struct{}, len(constraints))
+	for _, constr := range constraints {
+		if constr.Tp == ast.ConstraintCheck && constr.Name != "" {
+			if _, ok := existed[strings.ToLower(constr.Name)]; ok {
+				return ErrCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+			}
+			existed[strings.ToLower(constr.Name)] = struct{}{}
+		}
+	}
+	for _, constr := range constraints {
+		if constr.Tp != ast.ConstraintCheck {
+			continue
+		}
+		setNameForCheckConstraint(tblInfo.Name.O, constr, existed)
+		existed[strings.ToLower(constr.Name)] = struct{}{}
+		dependedCols, err := checkCheckConstraint(ctx, tblInfo, constr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		constrInfo, err := buildConstraintInfo(tblInfo, dependedCols, constr, model.StatePublic)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tblInfo.MaxConstraintID++
+		constrInfo.ID = tblInfo.MaxConstraintID
+		tblInfo.Constraints = append(tblInfo.Constraints, constrInfo)
+	}
+	return nil
+}
+
+// buildAddingColumnCheckConstraints checks and builds the check constraints defined on the adding columns. The
+// constraints are added with the columns, and the existing rows are verified before the columns become public.
+func buildAddingColumnCheckConstraints(ctx sessionctx.Context, tblInfo *model.TableInfo, cols []*table.Column, constraints []*ast.Constraint) ([]*model.ConstraintInfo, error) {
+	if len(constraints) == 0 {
+		return nil, nil
+	}
+	existed := make(map[string]struct{}, len(tblInfo.Constraints)+len(constraints))
+	for _, constrInfo := range tblInfo.Constraints {
+		existed[constrInfo.Name.L] = struct{}{}
+	}
+	for _, constr := range constraints {
+		if constr.Name == "" {
+			continue
+		}
+		if _, ok := existed[strings.ToLower(constr.Name)]; ok {
+			return nil, ErrCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+		}
+		existed[strings.ToLower(constr.Name)] = struct{}{}
+	}
+
+	// The constraints are checked on the table with the adding columns.
+	newTblInfo := tblInfo.Clone()
+	for _, col := range cols {
+		colInfo := col.ToInfo().Clone()
+		colInfo.Offset = len(newTblInfo.Columns)
+		colInfo.State = model.StatePublic
+		newTblInfo.Columns = append(newTblInfo.Columns, colInfo)
+	}
+	constrInfos := make([]*model.ConstraintInfo, 0, len(constraints))
+	for _, constr := range constraints {
+		setNameForCheckConstraint(tblInfo.Name.O, constr, existed)
+		existed[strings.ToLower(constr.Name)] = struct{}{}
+		dependedCols, err := checkCheckConstraint(ctx, newTblInfo, constr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// The constraint follows the state of its column, even if it doesn't refer to the column.
+		if len(dependedCols) == 0 {
+			dependedCols = []model.CIStr{model.NewCIStr(constr.InColumnName)}
+		}
+		constrInfo, err := buildConstraintInfo(tblInfo, dependedCols, constr, model.StateNone)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		constrInfos = append(constrInfos, constrInfo)
+	}
+	return constrInfos, nil
+}
+
+// addColumnCheckConstraints adds the check constraints of the adding columns to the table. The constraints of
+// the columns which are skipped because they exist are ignored.
+func addColumnCheckConstraints(tblInfo *model.TableInfo, columnInfos []*model.ColumnInfo, constraints []*model.ConstraintInfo) {
+	for _, constrInfo := range constraints {
+		if model.FindColumnInfo(columnInfos, constrInfo.ConstraintCols[0].L) == nil {
+			continue
+		}
+		tblInfo.MaxConstraintID++
+		constrInfo.ID = tblInfo.MaxConstraintID
+		constrInfo.Table = tblInfo.Name
+		constrInfo.State = model.StateNone
+		tblInfo.Constraints = append(tblInfo.Constraints, constrInfo)
+	}
+}
+
+// setColumnCheckConstraintsState keeps the check constraints of the adding columns in the same state as the columns.
+func setColumnCheckConstraintsState(tblInfo *model.TableInfo, columnInfos []*model.ColumnInfo, state model.SchemaState) {
+	for _, colInfo := range columnInfos {
+		for _, constr := range findDependentCheckConstraints(tblInfo, colInfo.Name) {
+			constr.State = state
+		}
+	}
+}
+
+// findDependentCheckConstraints returns the check constraints which refer to the column.
+func findDependentCheckConstraints(tblInfo *model.TableInfo, colName model.CIStr) []*model.ConstraintInfo {
+	var constraints []*model.ConstraintInfo
+	for _, constr := range tblInfo.Constraints {
+		for _, col := range constr.ConstraintCols {
+			if col.L == colName.L {
+				constraints = append(constraints, constr)
+				break
+			}
+		}
+	}
+	return constraints
+}
+
+// checkDropColumnWithCheckConstraint checks whether the column can be dropped. The check constraints
+// which only refer to the dropped column are dropped together with the column, it returns an error
+// if the column is referred by a check constraint which also refers to other columns.
+func checkDropColumnWithCheckConstraint(tblInfo *model.TableInfo, colName model.CIStr) error {
+	for _, constr := range findDependentCheckConstraints(tblInfo, colName) {
+		if len(constr.ConstraintCols) > 1 {
+			return ErrDependentByCheckConstraint.GenWithStackByArgs(constr.Name, colName)
+		}
+	}
+	return nil
+}
+
+// removeDependentCheckConstraints removes the check constraints which only refer to the dropped column.
+func removeDependentCheckConstraints(tblInfo *model.TableInfo, colName model.CIStr) {
+	constraints := tblInfo.Constraints[:0]
+	for _, constr := range tblInfo.Constraints {
+		if len(constr.ConstraintCols) == 1 && constr.ConstraintCols[0].L == colName.L {
+			continue
+		}
+		constraints = append(constraints, constr)
+	}
+	tblInfo.Constraints = constraints
+}
+
+func findConstraintInfoByID(tblInfo *model.TableInfo, id int64) *model.ConstraintInfo {
+	for _, constr := range tblInfo.Constraints {
+		if constr.ID == id {
+			return constr
+		}
+	}
+	return nil
+}
+
+func (w *worker) onAddCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	// Handle the rolling back job.
+	if job.IsRollingback() {
+		return onDropCheckConstraint(t, job)
+	}
+
+	schemaID := job.SchemaID
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	constrInfoInJob := &model.ConstraintInfo{}
+	err = job.DecodeArgs(constrInfoInJob)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	constrInfo := tblInfo.FindConstraintInfoByName(constrInfoInJob.Name.L)
+	if constrInfo != nil && constrInfo.State == model.StatePublic {
+		job.State = model.JobStateCancelled
+		return ver, ErrCheckConstraintDupName.GenWithStackByArgs(constrInfo.Name)
+	}
+	if constrInfo == nil {
+		// The depended columns may be dropped by the previous DDL jobs.
+		for _, colName := range constrInfoInJob.ConstraintCols {
+			colInfo := model.FindColumnInfo(tblInfo.Columns, colName.L)
+			if colInfo == nil || colInfo.State != model.StatePublic {
+				job.State = model.JobStateCancelled
+				return ver, ErrCheckConstraintRefersUnknownColumn.GenWithStackByArgs(constrInfoInJob.Name, colName)
+			}
+		}
+		constrInfo = constrInfoInJob
+		tblInfo.MaxConstraintID++
+		constrInfo.ID = tblInfo.MaxConstraintID
+		constrInfo.Table = tblInfo.Name
+		constrInfo.State = model.StateNone
+		tblInfo.Constraints = append(tblInfo.Constraints, constrInfo)
+		logutil.BgLogger().Info("[ddl] run add check constraint job", zap.String("job", job.String()), zap.Reflect("constraintInfo", constrInfo))
+	}
+
+	originalState := constrInfo.State
+	switch constrInfo.State {
+	case model.StateNone:
+		if !constrInfo.Enforced {
+			// The not enforced constraint doesn't need to check the existing rows.
+			// none -> public
+			constrInfo.State = model.StatePublic
+			ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constrInfo.State)
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
+			job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+			return ver, nil
+		}
+		// none -> write only
+		constrInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != constrInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateWriteOnly
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		constrInfo.State = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constrInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
+		job.SchemaState = model.StateWriteReorganization
+	case model.StateWriteReorganization:
+		// reorganization -> public
+		var done bool
+		done, ver, err = w.verifyCheckConstraint(d, t, job, tblInfo, constrInfo)
+		if err != nil || !done {
+			return ver, errors.Trace(err)
+		}
+
+		constrInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constrInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = ErrInvalidDDLState.GenWithStackByArgs("constraint", constrInfo.State)
+	}
+
+	return ver, errors.Trace(err)
+}
+
+func onDropCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	schemaID := job.SchemaID
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var constrName model.CIStr
+	err = job.DecodeArgs(&constrName)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	constrInfo := tblInfo.FindConstraintInfoByName(constrName.L)
+	if constrInfo == nil {
+		job.State = model.JobStateCancelled
+		return ver, ErrCheckConstraintNotFound.GenWithStackByArgs(constrName)
+	}
+
+	constraints := tblInfo.Constraints[:0]
+	for _, constr := range tblInfo.Constraints {
+		if constr.Name.L != constrName.L {
+			constraints = append(constraints, constr)
+		}
+	}
+	tblInfo.Constraints = constraints
+
+	// Removing a check constraint only relaxes the restriction of the writes, so it's safe to
+	// make the constraint invisible in one step.
+	// public -> none
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if job.IsRollingback() {
+		job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	} else {
+		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	}
+	return ver, nil
+}
+
+func (w *worker) onAlterCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	schemaID := job.SchemaID
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var (
+		constrName model.CIStr
+		enforced   bool
+	)
+	err = job.DecodeArgs(&constrName, &enforced)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	constrInfo := tblInfo.FindConstraintInfoByName(constrName.L)
+	if constrInfo == nil {
+		job.State = model.JobStateCancelled
+		return ver, ErrCheckConstraintNotFound.GenWithStackByArgs(constrName)
+	}
+
+	// Handle the rolling back job, the constraint is restored to not enforced.
+	if job.IsRollingback() {
+		constrInfo.Enforced = false
+		constrInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateRollbackDone, model.StatePublic, ver, tblInfo)
+		return ver, nil
+	}
+
+	if !enforced || (constrInfo.Enforced && constrInfo.State == model.StatePublic) {
+		// Disabling the constraint or enforcing an enforced constraint doesn't need to check the existing rows.
+		constrInfo.Enforced = enforced
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+		return ver, nil
+	}
+
+	originalState := constrInfo.State
+	switch constrInfo.State {
+	case model.StatePublic:
+		// not enforced -> enforced, write only
+		constrInfo.Enforced = true
+		constrInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != constrInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateWriteOnly
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		constrInfo.State = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constrInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
+		job.SchemaState = model.StateWriteReorganization
+	case model.StateWriteReorganization:
+		// reorganization -> public
+		var done bool
+		done, ver, err = w.verifyCheckConstraint(d, t, job, tblInfo, constrInfo)
+		if err != nil || !done {
+			return ver, errors.Trace(err)
+		}
+
+		constrInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constrInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = ErrInvalidDDLState.GenWithStackByArgs("constraint", constrInfo.State)
+	}
+
+	return ver, errors.Trace(err)
+}
+
+// verifyCheckConstraint checks all the existing rows of the table satisfy the check constraint.
+// It returns true if the reorganization is finished.
+func (w *worker) verifyCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, constrInfo *model.ConstraintInfo) (bool, int64, error) {
+	elements := []*meta.Element{{ID: constrInfo.ID, TypeKey: meta.ConstraintElementKey}}
+	return w.runCheckConstraintReorg(d, t, job, tblInfo, elements, func() (int64, error) {
+		convertCheckConstraintJob2RollbackJob(job, constrInfo.Name)
+		return 0, nil
+	})
+}
+
+// verifyColumnCheckConstraints checks all the existing rows of the table satisfy the check constraints of the
+// adding columns. It returns true if the reorganization is finished or there is no constraint to verify.
+func (w *worker) verifyColumnCheckConstraints(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, columnInfos []*model.ColumnInfo) (bool, int64, error) {
+	var checkedCol *model.ColumnInfo
+	for _, colInfo := range columnInfos {
+		for _, constr := range findDependentCheckConstraints(tblInfo, colInfo.Name) {
+			if constr.Enforced {
+				checkedCol = colInfo
+				break
+			}
+		}
+		if checkedCol != nil {
+			break
+		}
+	}
+	if checkedCol == nil {
+		return true, 0, nil
+	}
+
+	// The column element means all the constraints of the adding columns are checked.
+	elements := []*meta.Element{{ID: checkedCol.ID, TypeKey: meta.ColumnElementKey}}
+	return w.runCheckConstraintReorg(d, t, job, tblInfo, elements, func() (int64, error) {
+		if job.Type == model.ActionAddColumn {
+			return convertAddColumnJob2RollbackJob(t, job)
+		}
+		return convertAddColumnsJob2RollbackJob(t, job)
+	})
+}
+
+// runCheckConstraintReorg checks the existing rows of the table for the elements. If a row violates the
+// constraints, the job is converted to roll back by convertJob2Rollback.
+func (w *worker) runCheckConstraintReorg(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, elements []*meta.Element,
+	convertJob2Rollback func() (int64, error)) (bool, int64, error) {
+	var ver int64
+	tbl, err := getTable(d.store, job.SchemaID, tblInfo)
+	if err != nil {
+		return false, ver, errors.Trace(err)
+	}
+
+	reorgInfo, err := getReorgInfo(d, t, job, tbl, elements)
+	if err != nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, ver, errors.Trace(err)
+	}
+
+	err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (checkErr error) {
+		defer util.Recover(metrics.LabelDDL, "verifyCheckConstraint",
+			func() {
+				checkErr = errCancelledDDLJob.GenWithStack("check table `%v` constraint panic", tblInfo.Name)
+			}, false)
+		return w.checkTableConstraint(tbl, reorgInfo)
+	})
+	if err != nil {
+		if errWaitReorgTimeout.Equal(err) {
+			// if timeout, we should return, check for the owner and re-wait job done.
+			return false, ver, nil
+		}
+		if ErrCheckConstraintViolated.Equal(err) || errCancelledDDLJob.Equal(err) || errCantDecodeRecord.Equal(err) {
+			logutil.BgLogger().Warn("[ddl] run check constraint job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+			var err1 error
+			ver, err1 = convertJob2Rollback()
+			if err1 != nil && !errCancelledDDLJob.Equal(err1) {
+				w.reorgCtx.cleanNotifyReorgCancel()
+				return false, ver, errors.Trace(err1)
+			}
+			if err1 = t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+				logutil.BgLogger().Warn("[ddl] run check constraint job failed, convert job to rollback, RemoveDDLReorgHandle failed", zap.String("job", job.String()), zap.Error(err1))
+			}
+		}
+		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+		w.reorgCtx.cleanNotifyReorgCancel()
+		return false, ver, errors.Trace(err)
+	}
+	// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+	w.reorgCtx.cleanNotifyReorgCancel()
+	return true, ver, nil
+}
+
+// checkTableConstraint checks the rows of the table for the check constraint.
+// For a partitioned table, it checks the partitions one by one.
+func (w *worker) checkTableConstraint(t table.Table, reorgInfo *reorgInfo) error {
+	var err error
+	if tbl, ok := t.(table.PartitionedTable); ok {
+		var finish bool
+		for !finish {
+			p := tbl.GetPartition(reorgInfo.PhysicalTableID)
+			if p == nil {
+				return errCancelledDDLJob.GenWithStack("Can not find partition id %d for table %d", reorgInfo.PhysicalTableID, t.Meta().ID)
+			}
+			err = w.checkPhysicalTableConstraint(p, reorgInfo)
+			if err != nil {
+				break
+			}
+			finish, err = w.updateReorgInfo(tbl, reorgInfo)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	} else {
+		err = w.checkPhysicalTableConstraint(t.(table.PhysicalTable), reorgInfo)
+	}
+	return errors.Trace(err)
+}
+
+func (w *worker) checkPhysicalTableConstraint(t table.PhysicalTable, reorgInfo *reorgInfo) error {
+	logutil.BgLogger().Info("[ddl] start to check table constraint", zap.String("job", reorgInfo.Job.String()), zap.String("reorgInfo", reorgInfo.String()))
+	return w.writePhysicalTableRecord(t, typeCheckConstraintWorker, nil, nil, nil, reorgInfo)
+}
+
+type checkConstraintWorker struct {
+	*backfillWorker
+	constraints   []*model.ConstraintInfo
+	exprs         []expression.Expression
+	metricCounter prometheus.Counter
+
+	// addingColVals are the values of the adding columns in the rows written before the columns are writable,
+	// which are the origin default values of the columns.
+	addingColVals map[int64]types.Datum
+
+	// The following attributes are used to reduce memory allocation.
+	rowDecoder *decoder.RowDecoder
+	rowMap     map[int64]types.Datum
+}
+
+// getCheckingConstraints returns the check constraints to check for the reorganization element. For the column
+// element, they're the enforced check constraints of the adding columns.
+func getCheckingConstraints(tblInfo *model.TableInfo, element *meta.Element) ([]*model.ConstraintInfo, error) {
+	if !bytes.Equal(element.TypeKey, meta.ColumnElementKey) {
+		constrInfo := findConstraintInfoByID(tblInfo, element.ID)
+		if constrInfo == nil {
+			return nil, errCancelledDDLJob.GenWithStack("Can not find constraint id %d for table %d", element.ID, tblInfo.ID)
+		}
+		return []*model.ConstraintInfo{constrInfo}, nil
+	}
+	var constraints []*model.ConstraintInfo
+	for _, colInfo := range tblInfo.Columns {
+		if colInfo.State == model.StatePublic {
+			continue
+		}
+		for _, constr := range findDependentCheckConstraints(tblInfo, colInfo.Name) {
+			if constr.Enforced {
+				constraints = append(constraints, constr)
+			}
+		}
+	}
+	return constraints, nil
+}
+
+func newCheckConstraintWorker(sessCtx sessionctx.Context, worker *worker, id int, t table.PhysicalTable, element *meta.Element, decodeColMap map[int64]decoder.Column) (*checkConstraintWorker, error) {
+	constraints, err := getCheckingConstraints(t.Meta(), element)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The constraints of the adding columns refer to the non-public columns, so the expressions are built on the writable columns.
+	writableColInfos := make([]*model.ColumnInfo, 0, len(t.WritableCols()))
+	addingColVals := make(map[int64]types.Datum)
+	for _, col := range t.WritableCols() {
+		writableColInfos = append(writableColInfos, col.ColumnInfo)
+		if col.State == model.StatePublic || col.IsGenerated() {
+			continue
+		}
+		val, err := table.GetColOriginDefaultValue(sessCtx, col.ToInfo())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		addingColVals[col.ID] = val
+	}
+	cols, names, err := expression.ColumnInfos2ColumnsAndNames(sessCtx, model.CIStr{}, t.Meta().Name, writableColInfos, t.Meta())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	schema := expression.NewSchema(cols...)
+	exprs := make([]expression.Expression, 0, len(constraints))
+	for _, constrInfo := range constraints {
+		constrExprs, err := expression.ParseSimpleExprsWithNames(sessCtx, constrInfo.ExprString, schema, names)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		exprs = append(exprs, constrExprs[0])
+	}
+	return &checkConstraintWorker{
+		backfillWorker: newBackfillWorker(sessCtx, worker, id, t),
+		constraints:    constraints,
+		exprs:          exprs,
+		metricCounter:  metrics.BackfillTotalCounter.WithLabelValues("check_constraint_speed"),
+		addingColVals:  addingColVals,
+		rowDecoder:     decoder.NewRowDecoder(t, t.WritableCols(), decodeColMap),
+		rowMap:         make(map[int64]types.Datum, len(decodeColMap)),
+	}, nil
+}
+
+func (w *checkConstraintWorker) AddMetricInfo(cnt float64) {
+	w.metricCounter.Add(cnt)
+}
+
+// checkRow checks whether the row satisfies the constraints. Like MySQL, the row
+// violates a constraint only when the expression is evaluated to false.
+func (w *checkConstraintWorker) checkRow(handle kv.Handle, rawRow []byte) error {
+	for id := range w.rowMap {
+		delete(w.rowMap, id)
+	}
+	// The decoded values overwrite the origin default values if the row has the adding columns.
+	for id, val := range w.addingColVals {
+		w.rowMap[id] = val
+	}
+	_, err := w.rowDecoder.DecodeAndEvalRowWithMap(w.sessCtx, handle, rawRow, time.UTC, timeutil.SystemLocation(), w.rowMap)
+	if err != nil {
+		return errors.Trace(errCantDecodeRecord.GenWithStackByArgs("constraint", err))
+	}
+	row := w.rowDecoder.CurrentRowWithDefaultVal()
+	for i, expr := range w.exprs {
+		// The constraint is only violated when the expression is evaluated to false, NULL is regarded as satisfied.
+		val, err := expr.Eval(row)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if val.IsNull() {
+			continue
+		}
+		ok, err := val.ToBool(w.sessCtx.GetSessionVars().StmtCtx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ok == 0 {
+			return ErrCheckConstraintViolated.GenWithStackByArgs(w.constraints[i].Name)
+		}
+	}
+	return nil
+}
+
+// BackfillDataInTxn checks the rows of the handle range in a transaction, nothing is written.
+func (w *checkConstraintWorker) BackfillDataInTxn(handleRange reorgBackfillTask) (taskCtx backfillTaskContext, errInTxn error) {
+	oprStartTime := time.Now()
+	errInTxn = kv.RunInNewTxn(context.Background(), w.sessCtx.GetStore(), true, func(ctx context.Context, txn kv.Transaction) error {
+		taskCtx.addedCount = 0
+		taskCtx.scanCount = 0
+		txn.SetOption(kv.Priority, w.priority)
+
+		// taskDone means that the checked handle is out of handleRange.endKey.
+		taskDone := false
+		var lastAccessedHandle kv.Key
+		err := iterateSnapshotRows(w.sessCtx.GetStore(), w.priority, w.table, txn.StartTS(), handleRange.startKey, handleRange.endKey,
+			func(handle kv.Handle, recordKey kv.Key, rawRow []byte) (bool, error) {
+				taskDone = recordKey.Cmp(handleRange.endKey) > 0
+				if taskDone || taskCtx.scanCount >= w.batchCnt {
+					return false, nil
+				}
+
+				if err1 := w.checkRow(handle, rawRow); err1 != nil {
+					return false, errors.Trace(err1)
+				}
+				taskCtx.scanCount++
+				taskCtx.addedCount++
+				lastAccessedHandle = recordKey
+				if recordKey.Cmp(handleRange.endKey) == 0 {
+					taskDone = true
+					return false, nil
+				}
+				return true, nil
+			})
+		if err != nil {
+			return errors.Trace(err)
+		}
+
+		if taskCtx.scanCount == 0 {
+			taskDone = true
+		}
+		taskCtx.done = taskDone
+		if taskDone {
+			taskCtx.nextKey = handleRange.endKey.Next()
+		} else {
+			taskCtx.nextKey = lastAccessedHandle.Next()
+		}
+		return nil
+	})
+	logSlowOperations(time.Since(oprStartTime), "checkConstraintBackfillDataInTxn", 3000)
+
+	return
+}
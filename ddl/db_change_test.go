@@ -2053,6 +2053,216 @@ func (s *serialTestStateChangeSuite) TestRestrainDropColumnWithIndex(c *C) {
 	tk.MustQuery("select @@tidb_enable_change_multi_schema").Check(testkit.Rows("0"))
 	tk.MustGetErrCode("alter table t drop column a;", errno.ErrUnsupportedDDLOperation)
 	tk.MustExec("set @@GLOBAL.tidb_enable_change_multi_schema=1")
+	tk.MustQuery("select @@tidb_enable_change_multi_schema").Check(testkit.Rows("1"))
 	tk.MustExec("alter table t drop column a;")
 	tk.MustExec("drop table if exists t;")
 }
+
+func (s *serialTestStateChangeSuite) TestMultiSchemaChange(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec("create table t (a int, b int, c int, index idx_b(b), index idx_c(c));")
+	tk.MustExec("insert into t values (1, 1, 1), (2, 2, 2);")
+	tk.MustExec("set @@GLOBAL.tidb_enable_change_multi_schema=0")
+	tk.MustGetErrCode("alter table t add column d int, add index idx_d(d);", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("set @@GLOBAL.tidb_enable_change_multi_schema=1")
+
+	tk.MustExec("alter table t add column d int default 3, add column e int default 4 after a, add index idx_d(d), drop column b, drop index idx_c;")
+	tk.MustQuery("select * from t order by a;").Check(testkit.Rows("1 4 1 3", "2 4 2 3"))
+	tk.MustQuery("select d from t use index(idx_d) where d = 3;").Check(testkit.Rows("3", "3"))
+	tk.MustExec("admin check table t;")
+	tbl := testGetTableByName(c, tk.Se, "test", "t")
+	c.Assert(tbl.Meta().Indices, HasLen, 1)
+	c.Assert(tbl.Meta().Indices[0].Name.L, Equals, "idx_d")
+
+	// The whole statement is rolled back when any of the sub-jobs fails.
+	tk.MustGetErrCode("alter table t add column f int, add unique index idx_e(e), drop column c;", errno.ErrDupEntry)
+	tk.MustQuery("select * from t order by a;").Check(testkit.Rows("1 4 1 3", "2 4 2 3"))
+	tk.MustExec("admin check table t;")
+	tbl = testGetTableByName(c, tk.Se, "test", "t")
+	c.Assert(tbl.Meta().Columns, HasLen, 4)
+	c.Assert(tbl.Meta().Indices, HasLen, 1)
+
+	// A column or an index can't be changed more than once.
+	tk.MustGetErrCode("alter table t add index idx_c(c), drop column c;", errno.ErrOperateSameColumn)
+	tk.MustGetErrCode("alter table t modify column a bigint, add column f int;", errno.ErrUnsupportedDDLOperation)
+
+	// An index can be dropped along with the column it covers.
+	tk.MustExec("alter table t drop column d, drop index idx_d;")
+	tk.MustQuery("select * from t order by a;").Check(testkit.Rows("1 4 1", "2 4 2"))
+	tk.MustExec("admin check table t;")
+	tbl = testGetTableByName(c, tk.Se, "test", "t")
+	c.Assert(tbl.Meta().Columns, HasLen, 3)
+	c.Assert(tbl.Meta().Indices, HasLen, 0)
+	tk.MustExec("drop table if exists t;")
+}
+
+func (s *serialTestStateChangeSuite) TestMultiSchemaChangeCancel(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec("create table t (a int, b int, c int, d int, index idx_c(c));")
+	tk.MustExec("insert into t values (1, 1, 1, 1), (2, 2, 2, 2);")
+
+	var (
+		step       int
+		cancelStep int
+		cancelled  bool
+		checkErr   error
+	)
+	hook := &ddl.TestDDLCallback{Do: s.dom}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type != model.ActionMultiSchemaChange || checkErr != nil {
+			return
+		}
+		step++
+		if step != cancelStep {
+			return
+		}
+		checkErr = kv.RunInNewTxn(context.Background(), s.store, false, func(ctx context.Context, txn kv.Transaction) error {
+			errs, err := admin.CancelJobs(txn, []int64{job.ID})
+			if err != nil {
+				return err
+			}
+			if errs[0] == nil {
+				cancelled = true
+			} else if !admin.ErrCannotCancelDDLJob.Equal(errs[0]) {
+				return errs[0]
+			}
+			return nil
+		})
+	}
+	originalHook := s.dom.DDL().GetHook()
+	s.dom.DDL().(ddl.DDLForTest).SetHook(hook)
+	defer s.dom.DDL().(ddl.DDLForTest).SetHook(originalHook)
+
+	// Cancel the job before each step, until the job can't be cancelled after its sub-jobs are committed.
+	for cancelStep = 1; ; cancelStep++ {
+		step, cancelled = 0, false
+		_, err := tk.Exec("alter table t add column e int default 5, add index idx_b(b), drop column d, drop index idx_c;")
+		c.Assert(checkErr, IsNil)
+		if !cancelled {
+			c.Assert(err, IsNil)
+			break
+		}
+		c.Assert(err, NotNil, Commentf("cancel step %d", cancelStep))
+		c.Assert(err.Error(), Equals, "[ddl:8214]Cancelled DDL job", Commentf("cancel step %d", cancelStep))
+		tk.MustQuery("select * from t order by a;").Check(testkit.Rows("1 1 1 1", "2 2 2 2"))
+		tk.MustExec("admin check table t;")
+		tbl := testGetTableByName(c, tk.Se, "test", "t")
+		c.Assert(tbl.Meta().Columns, HasLen, 4)
+		c.Assert(tbl.Meta().Indices, HasLen, 1)
+		c.Assert(tbl.Meta().Indices[0].Name.L, Equals, "idx_c")
+	}
+	// The job is cancelled at least in the states of adding the column and the index.
+	c.Assert(cancelStep, Greater, 5)
+	tk.MustQuery("select * from t order by a;").Check(testkit.Rows("1 1 1 5", "2 2 2 5"))
+	tk.MustExec("admin check table t;")
+	tbl := testGetTableByName(c, tk.Se, "test", "t")
+	c.Assert(tbl.Meta().Indices, HasLen, 1)
+	c.Assert(tbl.Meta().Indices[0].Name.L, Equals, "idx_b")
+	tk.MustExec("drop table if exists t;")
+}
+
+func (s *serialTestStateChangeSuite) TestMultiSchemaChangeWithDML(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec("create table t (a int primary key, b int, c int, d int, index idx_c(c));")
+	tk.MustExec("insert into t values (1, 1, 1, 1), (2, 2, 2, 2), (3, 3, 3, 3);")
+
+	tk1 := testkit.NewTestKit(c, s.store)
+	tk1.MustExec("use test")
+	var (
+		checkErr error
+		states   []string
+		i        = 10
+	)
+	hook := &ddl.TestDDLCallback{Do: s.dom}
+	hook.OnJobUpdatedExported = func(job *model.Job) {
+		if job.Type != model.ActionMultiSchemaChange || checkErr != nil || job.IsDone() || job.IsSynced() {
+			return
+		}
+		states = append(states, job.SchemaState.String())
+		// Write the table in each intermediate state, the columns being dropped are not written.
+		for _, sql := range []string{
+			fmt.Sprintf("insert into t (a, b, c) values (%d, %d, %d)", i, i, i),
+			fmt.Sprintf("update t set b = b + 100, c = c + 100 where a = %d", i-1),
+			"delete from t where a = 1",
+			fmt.Sprintf("insert into t (a, b, c) values (%d, %d, %d) on duplicate key update b = values(b) + 1", i, i, i),
+		} {
+			if _, err := tk1.Exec(sql); err != nil {
+				checkErr = errors.Errorf("state: %s, sql: %s, err: %v", job.SchemaState, sql, err)
+				return
+			}
+		}
+		i++
+	}
+	originalHook := s.dom.DDL().GetHook()
+	s.dom.DDL().(ddl.DDLForTest).SetHook(hook)
+	defer s.dom.DDL().(ddl.DDLForTest).SetHook(originalHook)
+
+	tk.MustExec("alter table t add column e int default 5, add index idx_b(b), drop column d, drop index idx_c;")
+	c.Assert(errors.ErrorStack(checkErr), Equals, "")
+	c.Assert(len(states), Greater, 3, Commentf("states: %v", states))
+	tk.MustExec("admin check table t;")
+	tbl := testGetTableByName(c, tk.Se, "test", "t")
+	c.Assert(tbl.Meta().Columns, HasLen, 4)
+	c.Assert(tbl.Meta().Indices, HasLen, 1)
+	c.Assert(tbl.Meta().Indices[0].Name.L, Equals, "idx_b")
+	rows := tk.MustQuery("select a, b, c, e from t use index() order by a;").Rows()
+	tk.MustQuery("select a, b, c, e from t use index(idx_b) order by a;").Check(rows)
+	tk.MustQuery("select count(*) from t where e <> 5;").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from t where a = 1;").Check(testkit.Rows("0"))
+	tk.MustExec("drop table if exists t;")
+}
+
+func (s *serialTestStateChangeSuite) TestMultiSchemaChangeRollback(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec("create table t (a int, b int, c int, index idx_c(c));")
+	tk.MustExec("insert into t values (1, 1, 1), (2, 1, 2), (3, 3, 3);")
+
+	// The last sub-job fails after the other sub-jobs have done all the work before being committed.
+	tk.MustGetErrCode("alter table t add index idx_a(a), add column d int default 4, drop index idx_c, add unique index idx_b(b);", errno.ErrDupEntry)
+	tk.MustQuery("select * from t order by a;").Check(testkit.Rows("1 1 1", "2 1 2", "3 3 3"))
+	tk.MustExec("admin check table t;")
+	tbl := testGetTableByName(c, tk.Se, "test", "t")
+	c.Assert(tbl.Meta().Columns, HasLen, 3)
+	c.Assert(tbl.Meta().Indices, HasLen, 1)
+	c.Assert(tbl.Meta().Indices[0].Name.L, Equals, "idx_c")
+
+	// The sub-job fails because of the data written by the concurrent DML.
+	tk.MustExec("update t set b = 2 where a = 2;")
+	tk1 := testkit.NewTestKit(c, s.store)
+	tk1.MustExec("use test")
+	var (
+		checkErr error
+		inserted bool
+	)
+	hook := &ddl.TestDDLCallback{Do: s.dom}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type != model.ActionMultiSchemaChange || inserted || job.SchemaState != model.StateWriteReorganization {
+			return
+		}
+		inserted = true
+		_, checkErr = tk1.Exec("insert into t values (4, 3, 4);")
+	}
+	originalHook := s.dom.DDL().GetHook()
+	s.dom.DDL().(ddl.DDLForTest).SetHook(hook)
+	tk.MustGetErrCode("alter table t add column d int default 4, add unique index idx_b(b);", errno.ErrDupEntry)
+	s.dom.DDL().(ddl.DDLForTest).SetHook(originalHook)
+	c.Assert(checkErr, IsNil)
+	tk.MustQuery("select * from t order by a;").Check(testkit.Rows("1 1 1", "2 2 2", "3 3 3", "4 3 4"))
+	tk.MustExec("admin check table t;")
+	tbl = testGetTableByName(c, tk.Se, "test", "t")
+	c.Assert(tbl.Meta().Columns, HasLen, 3)
+	c.Assert(tbl.Meta().Indices, HasLen, 1)
+
+	// The rolled back changes can be done again.
+	tk.MustExec("alter table t add index idx_a(a), add column d int default 4, drop index idx_c;")
+	tk.MustExec("admin check table t;")
+	tk.MustExec("drop table if exists t;")
+}
@@ -609,7 +609,7 @@ func (s *testIntegrationSuite5) TestErrnoErrorCode(c *C) {
 	sql = "alter table test_drop_columns drop column c1, drop column c2, drop column c3;"
 	tk.MustGetErrCode(sql, errno.ErrCantRemoveAllFields)
 	sql = "alter table test_drop_columns drop column c1, add column c2 int;"
-	tk.MustGetErrCode(sql, errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode(sql, errno.ErrDupFieldName)
 	sql = "alter table test_drop_columns drop column c1, drop column c1;"
 	tk.MustGetErrCode(sql, errno.ErrCantDropFieldOrKey)
 	// add index
@@ -1867,6 +1867,66 @@ func (s *testIntegrationSuite5) TestFulltextIndexIgnore(c *C) {
 	c.Assert(r.Rows(), HasLen, 0)
 }
 
+func (s *testIntegrationSuite5) TestSpatialIndexIgnore(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_sp")
+	defer tk.MustExec("drop table if exists t_sp")
+	s.assertWarningExec(tk, c, "create table t_sp (id int, g geometry not null, spatial key idx_g (g))", ddl.ErrTableCantHandleSpkeys)
+	// Make sure that creating a spatial index gives the correct warning, and the spatial functions still work
+	s.assertWarningExec(tk, c, "create spatial index idx_g on t_sp (g)", ddl.ErrTableCantHandleSpkeys)
+	s.assertWarningExec(tk, c, "alter table t_sp add spatial index idx_g (g)", ddl.ErrTableCantHandleSpkeys)
+	r := tk.MustQuery("show index from t_sp")
+	c.Assert(r.Rows(), HasLen, 0)
+
+	tk.MustExec("insert into t_sp values (1, st_geomfromtext('POINT(1 1)')), (2, st_geomfromtext('POINT(20 20)'))")
+	tk.MustQuery("select id from t_sp where st_contains(st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))'), g)").Check(testkit.Rows("1"))
+}
+
+func (s *testIntegrationSuite5) TestSpatialColumn(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_sp")
+	defer tk.MustExec("drop table if exists t_sp")
+	tk.MustExec("create table t_sp (id int primary key, g geometry, p point, l linestring, gc geometrycollection)")
+	tk.MustQuery("show create table t_sp").Check(testkit.Rows("t_sp CREATE TABLE `t_sp` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `g` geometry DEFAULT NULL,\n" +
+		"  `p` point DEFAULT NULL,\n" +
+		"  `l` linestring DEFAULT NULL,\n" +
+		"  `gc` geomcollection DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	tk.MustExec("insert into t_sp values (1, st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))'), point(1, 2), " +
+		"st_geomfromtext('LINESTRING(0 0,1 1)'), st_geomfromtext('GEOMETRYCOLLECTION(POINT(1 1))'))")
+	tk.MustExec("insert into t_sp (id, g, p) values (2, point(20, 20), st_geomfromtext('POINT(5 5)'))")
+	tk.MustQuery("select id, st_astext(g), st_astext(p), st_astext(l), st_astext(gc) from t_sp order by id").Check(testkit.Rows(
+		"1 POLYGON((0 0,10 0,10 10,0 10,0 0)) POINT(1 2) LINESTRING(0 0,1 1) GEOMETRYCOLLECTION(POINT(1 1))",
+		"2 POINT(20 20) POINT(5 5) <nil> <nil>"))
+	tk.MustQuery("select b.id from t_sp a join t_sp b on st_contains(a.g, b.p) where a.id = 1").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t_sp where p = point(5, 5)").Check(testkit.Rows("2"))
+
+	// The values must be the geometries of the column types.
+	tk.MustGetErrCode("insert into t_sp (id, p) values (3, st_geomfromtext('LINESTRING(0 0,1 1)'))", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t_sp (id, g) values (3, 'abc')", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t_sp (id, g) values (3, 1)", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("update t_sp set p = g where id = 1", errno.ErrCantCreateGeometryObject)
+	tk.MustExec("update t_sp set g = p where id = 1")
+	tk.MustQuery("select st_astext(g) from t_sp where id = 1").Check(testkit.Rows("POINT(1 2)"))
+
+	tk.MustGetErrCode("create table t_sp1 (g geometry default '')", errno.ErrBlobCantHaveDefault)
+	tk.MustGetErrCode("alter table t_sp add index idx_g (g)", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t_sp modify column g int", errno.ErrUnsupportedDDLOperation)
+
+	// Narrowing the geometry type checks the values.
+	tk.MustExec("alter table t_sp modify column p geometry")
+	tk.MustExec("alter table t_sp modify column g point")
+	tk.MustGetErrCode("alter table t_sp modify column l point", errno.ErrCantCreateGeometryObject)
+	tk.MustExec("alter table t_sp modify column l blob")
+	tk.MustQuery("select st_astext(l) from t_sp where id = 1").Check(testkit.Rows("LINESTRING(0 0,1 1)"))
+}
+
 func (s *testIntegrationSuite1) TestTreatOldVersionUTF8AsUTF8MB4(c *C) {
 	if israce.RaceEnabled {
 		c.Skip("skip race test")
@@ -348,8 +348,14 @@ func (s *testIntegrationSuite2) TestCreateTableWithHashPartition(c *C) {
     store_id INT
 ) PARTITION BY HASH(store_id) PARTITIONS 102400000000;`, tmysql.ErrTooManyPartitions)
 
-	tk.MustExec("CREATE TABLE t_linear (a int, b varchar(128)) PARTITION BY LINEAR HASH(a) PARTITIONS 4")
-	tk.MustGetErrCode("select * from t_linear partition (p0)", tmysql.ErrPartitionClauseOnNonpartitioned)
+	tk.MustExec("CREATE TABLE t_linear (a int, b varchar(128)) PARTITION BY LINEAR HASH(a) PARTITIONS 5")
+	tk.MustExec("insert into t_linear values (1, 'a'), (6, 'b'), (7, 'c')")
+	tk.MustQuery("select a from t_linear partition (p1)").Check(testkit.Rows("1"))
+	tk.MustQuery("select a from t_linear partition (p2)").Check(testkit.Rows("6"))
+	tk.MustQuery("select a from t_linear partition (p3)").Check(testkit.Rows("7"))
+	tbl := testGetTableByName(c, tk.Se, "test", "t_linear")
+	c.Assert(tbl.Meta().Partition.Type, Equals, model.PartitionTypeHash)
+	c.Assert(tbl.Meta().Partition.IsLinear, IsTrue)
 
 	tk.MustExec(`CREATE TABLE t_sub (a int, b varchar(128)) PARTITION BY RANGE( a ) SUBPARTITION BY HASH( a )
                                    SUBPARTITIONS 2 (
@@ -1300,8 +1306,66 @@ func (s *testIntegrationSuite3) TestCreateTableWithKeyPartition(c *C) {
 	)
 	partition by key(s1) partitions 10;`)
 
+	tbl := testGetTableByName(c, tk.Se, "test", "tm1")
+	part := tbl.Meta().Partition
+	c.Assert(part.Type, Equals, model.PartitionTypeKey)
+	c.Assert(part.Columns, DeepEquals, []model.CIStr{model.NewCIStr("s1")})
+	c.Assert(part.Definitions, HasLen, 10)
+
 	tk.MustExec(`drop table if exists tm2`)
 	tk.MustExec(`create table tm2 (a char(5), unique key(a(5))) partition by key() partitions 5;`)
+	tbl = testGetTableByName(c, tk.Se, "test", "tm2")
+	c.Assert(tbl.Meta().Partition.Columns, DeepEquals, []model.CIStr{model.NewCIStr("a")})
+
+	tk.MustExec(`drop table if exists tm3`)
+	tk.MustExec(`create table tm3 (a int, b varchar(10), c datetime) partition by linear key algorithm = 2 (a, b) partitions 3;`)
+	tbl = testGetTableByName(c, tk.Se, "test", "tm3")
+	c.Assert(tbl.Meta().Partition.Type, Equals, model.PartitionTypeKey)
+	c.Assert(tbl.Meta().Partition.IsLinear, IsTrue)
+	tk.MustQuery("show create table tm3").Check(testkit.Rows("tm3 CREATE TABLE `tm3` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` varchar(10) DEFAULT NULL,\n" +
+		"  `c` datetime DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY LINEAR KEY( a,b )\n" +
+		"PARTITIONS 3"))
+	tk.MustQuery("select partition_name, partition_method, partition_expression from information_schema.partitions where table_name = 'tm3'").Check(
+		testkit.Rows("p0 LINEAR KEY a,b", "p1 LINEAR KEY a,b", "p2 LINEAR KEY a,b"))
+	tk.MustGetErrCode("alter table tm3 coalesce partition 1", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table tm3 add partition partitions 1", tmysql.ErrUnsupportedDDLOperation)
+
+	// The rows of KEY partitions can't be validated, they can only be exchanged WITHOUT VALIDATION.
+	tk.MustExec("set @@tidb_enable_exchange_partition = 1")
+	defer tk.MustExec("set @@tidb_enable_exchange_partition = 0")
+	tk.MustExec(`drop table if exists tm_nt, tm_key, tm_linear`)
+	tk.MustExec(`create table tm_nt (a int, b varchar(10), c datetime)`)
+	tk.MustExec(`insert into tm_nt values (1, 'a', null)`)
+	err := tk.ExecToErr("alter table tm3 exchange partition p0 with table tm_nt")
+	c.Assert(err, ErrorMatches, ".*Unsupported partition type of table tm3 when exchanging partition")
+	tk.MustExec("alter table tm3 exchange partition p0 with table tm_nt without validation")
+	tk.MustQuery("select a, b from tm3 partition (p0)").Check(testkit.Rows("1 a"))
+	tk.MustQuery("select count(*) from tm_nt").Check(testkit.Rows("0"))
+	tk.MustExec(`create table tm_key (a int, b varchar(10), c datetime) partition by key(a) partitions 3`)
+	err = tk.ExecToErr("alter table tm_key exchange partition p0 with table tm_nt with validation")
+	c.Assert(err, ErrorMatches, ".*Unsupported partition type of table tm_key when exchanging partition")
+	// The row of 3 is in p1 of the LINEAR HASH partitions, 3 & 3 is not less than 3, so it's 3 & 1.
+	tk.MustExec(`create table tm_linear (a int, b varchar(10), c datetime) partition by linear hash(a) partitions 3`)
+	tk.MustExec(`insert into tm_nt values (3, 'b', null)`)
+	tk.MustGetErrCode("alter table tm_linear exchange partition p0 with table tm_nt", tmysql.ErrRowDoesNotMatchPartition)
+	tk.MustGetErrCode("alter table tm_linear exchange partition p2 with table tm_nt", tmysql.ErrRowDoesNotMatchPartition)
+	tk.MustExec("alter table tm_linear exchange partition p1 with table tm_nt")
+	tk.MustQuery("select a, b from tm_linear partition (p1)").Check(testkit.Rows("3 b"))
+
+	tk.MustExec(`drop table if exists tm4`)
+	tk.MustGetErrCode(`create table tm4 (a int, b int) partition by key() partitions 2`, tmysql.ErrFieldNotFoundPart)
+	tk.MustGetErrCode(`create table tm4 (a int, b int) partition by key(c) partitions 2`, tmysql.ErrFieldNotFoundPart)
+	tk.MustGetErrCode(`create table tm4 (a int, b int) partition by key(a, a) partitions 2`, tmysql.ErrSameNamePartitionField)
+	tk.MustGetErrCode(`create table tm4 (a int, b text) partition by key(b) partitions 2`, tmysql.ErrBlobFieldInPartFunc)
+	tk.MustGetErrCode(`create table tm4 (a int, b json) partition by key(b) partitions 2`, tmysql.ErrBlobFieldInPartFunc)
+	tk.MustGetErrCode(`create table tm4 (a int, b int, primary key(a)) partition by key(b) partitions 2`, tmysql.ErrUniqueKeyNeedAllFieldsInPf)
+	tk.MustExec(`create table tm4 (a int, b int, primary key(a, b)) partition by key() partitions 2`)
+	tbl = testGetTableByName(c, tk.Se, "test", "tm4")
+	c.Assert(tbl.Meta().Partition.Columns, DeepEquals, []model.CIStr{model.NewCIStr("a"), model.NewCIStr("b")})
 }
 
 func (s *testIntegrationSuite5) TestAlterTableAddPartition(c *C) {
@@ -1590,6 +1654,105 @@ func (s *testIntegrationSuite5) TestAlterTableDropPartition(c *C) {
 	tk.MustGetErrCode("alter table t1 drop partition p2", tmysql.ErrOnlyOnRangeListPartition)
 }
 
+func (s *testIntegrationSuite5) TestAlterTableReorganizePartition(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test;")
+	tk.MustExec("drop table if exists t, t_hash, t_normal;")
+	tk.MustExec(`create table t (a int, b varchar(10), key idx_b(b)) partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition pMax values less than (maxvalue));`)
+	tk.MustExec(`insert into t values (1, "a"), (5, "b"), (12, "c"), (18, "d"), (25, "e"), (35, "f"), (100, "g")`)
+
+	// Split the MAXVALUE partition.
+	tk.MustExec(`alter table t reorganize partition pMax into (
+		partition p2 values less than (30),
+		partition p3 values less than (40),
+		partition pMax values less than (maxvalue))`)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p2)").Check(testkit.Rows("25 e"))
+	tk.MustQuery("select * from t partition (p3)").Check(testkit.Rows("35 f"))
+	tk.MustQuery("select * from t partition (pMax)").Check(testkit.Rows("100 g"))
+	tk.MustQuery("select a from t use index(idx_b) where b >= 'c' order by a").Check(testkit.Rows("12", "18", "25", "35", "100"))
+
+	// Merge the adjacent partitions.
+	tk.MustExec(`alter table t reorganize partition p0, p1 into (partition p01 values less than (20))`)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p01) order by a").Check(testkit.Rows("1 a", "5 b", "12 c", "18 d"))
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` varchar(10) DEFAULT NULL,\n" +
+		"  KEY `idx_b` (`b`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY RANGE ( `a` ) (\n" +
+		"  PARTITION `p01` VALUES LESS THAN (20),\n" +
+		"  PARTITION `p2` VALUES LESS THAN (30),\n" +
+		"  PARTITION `p3` VALUES LESS THAN (40),\n" +
+		"  PARTITION `pMax` VALUES LESS THAN (MAXVALUE)\n" +
+		")"))
+	tk.MustExec("insert into t values (15, 'h'), (38, 'i')")
+	tk.MustQuery("select * from t partition (p01) where a > 10 order by a").Check(testkit.Rows("12 c", "15 h", "18 d"))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("9"))
+
+	tk.MustGetErrCode("alter table t reorganize partition p01, p3 into (partition p4 values less than (40))", tmysql.ErrConsecutiveReorgPartitions)
+	tk.MustGetErrCode("alter table t reorganize partition p2 into (partition p4 values less than (35))", tmysql.ErrReorgOutsideRange)
+	tk.MustGetErrCode("alter table t reorganize partition p3, pMax into (partition p4 values less than (100))", tmysql.ErrReorgOutsideRange)
+	tk.MustGetErrCode("alter table t reorganize partition p2, p5 into (partition p4 values less than (40))", tmysql.ErrDropPartitionNonExistent)
+	tk.MustGetErrCode("alter table t reorganize partition p2, p2 into (partition p4 values less than (30))", tmysql.ErrDropPartitionNonExistent)
+	tk.MustGetErrCode("alter table t reorganize partition p2 into (partition p3 values less than (30))", tmysql.ErrSameNamePartition)
+	tk.MustGetErrCode("alter table t reorganize partition p2 into (partition p4 values less than (25), partition p5 values less than (22))", tmysql.ErrRangeNotIncreasing)
+
+	// The last partition can be extended.
+	tk.MustExec("drop table t")
+	tk.MustExec(`create table t (a int primary key) partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20))`)
+	tk.MustExec("insert into t values (1), (11), (19)")
+	tk.MustExec("alter table t reorganize partition p1 into (partition p1 values less than (15), partition p2 values less than (maxvalue))")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p2)").Check(testkit.Rows("19"))
+	tk.MustExec("insert into t values (100)")
+	tk.MustGetErrCode("insert into t values (19)", tmysql.ErrDupEntry)
+
+	tk.MustExec("create table t_hash (a int) partition by hash(a) partitions 4")
+	tk.MustGetErrCode("alter table t_hash reorganize partition p0 into (partition p4)", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustExec("create table t_normal (a int)")
+	tk.MustGetErrCode("alter table t_normal reorganize partition p0 into (partition p1 values less than (10))", tmysql.ErrPartitionMgmtOnNonpartitioned)
+}
+
+func (s *testIntegrationSuite5) TestAlterTableReorganizePartitionByList(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test;")
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec("set @@session.tidb_enable_list_partition = ON")
+	tk.MustExec(`create table t (id int, name varchar(10), unique key idx_id(id)) partition by list (id) (
+		partition p0 values in (1, 2),
+		partition p1 values in (3, 4),
+		partition p2 values in (5, null))`)
+	tk.MustExec(`insert into t values (1, "a"), (2, "b"), (3, "c"), (4, "d"), (5, "e"), (null, "f")`)
+
+	tk.MustExec("alter table t reorganize partition p0, p2 into (partition p02 values in (1, 2, 5, 6, null))")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p02) order by name").Check(testkit.Rows("1 a", "2 b", "5 e", "<nil> f"))
+	tk.MustQuery("select * from t partition (p1) order by name").Check(testkit.Rows("3 c", "4 d"))
+	tk.MustExec("insert into t values (6, 'g')")
+	tk.MustGetErrCode("insert into t values (7, 'h')", tmysql.ErrNoPartitionForGivenValue)
+
+	// The rows which can't be placed in the new partitions make the job rolled back.
+	tk.MustGetErrCode("alter table t reorganize partition p1 into (partition p3 values in (3), partition p4 values in (7))", tmysql.ErrNoPartitionForGivenValue)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p1) order by name").Check(testkit.Rows("3 c", "4 d"))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("7"))
+	tk.MustGetErrCode("insert into t values (7, 'h')", tmysql.ErrNoPartitionForGivenValue)
+
+	tk.MustExec("alter table t reorganize partition p1 into (partition p3 values in (3), partition p4 values in (4, 7))")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p4) order by name").Check(testkit.Rows("4 d"))
+	tk.MustExec("insert into t values (7, 'h')")
+	tk.MustGetErrCode("insert into t values (3, 'i')", tmysql.ErrDupEntry)
+	tk.MustGetErrCode("alter table t reorganize partition p3 into (partition p5 values in (3, 4))", tmysql.ErrMultipleDefConstInListPart)
+}
+
 func (s *testIntegrationSuite5) TestMultiPartitionDropAndTruncate(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use test")
@@ -1653,6 +1816,47 @@ func (s *testSerialDBSuite1) TestDropPartitionWithGlobalIndex(c *C) {
 	})
 }
 
+func (s *testSerialDBSuite1) TestTruncatePartitionWithGlobalIndex(c *C) {
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.EnableGlobalIndex = true
+	})
+	defer config.UpdateGlobal(func(conf *config.Config) {
+		conf.EnableGlobalIndex = false
+	})
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists test_global, test_global_nt")
+	tk.MustExec(`create table test_global ( a int, b int, c int, unique key idx_b (b))
+	partition by range( a ) (
+		partition p1 values less than (10),
+		partition p2 values less than (20)
+	);`)
+	t := testGetTableByName(c, s.ctx, "test", "test_global")
+	idxInfo := t.Meta().FindIndexByName("idx_b")
+	c.Assert(idxInfo, NotNil)
+	c.Assert(idxInfo.Global, IsTrue)
+	pid := t.Meta().Partition.Definitions[1].ID
+
+	tk.MustExec(`INSERT INTO test_global VALUES (1, 1, 1), (2, 2, 2), (11, 3, 3), (12, 4, 4)`)
+	tk.MustGetErrCode("insert into test_global values (13, 1, 5)", tmysql.ErrDupEntry)
+
+	tk.MustExec("alter table test_global truncate partition p2;")
+	tk.MustQuery("select * from test_global;").Sort().Check(testkit.Rows(`1 1 1`, `2 2 2`))
+	t = testGetTableByName(c, s.ctx, "test", "test_global")
+	c.Assert(t.Meta().Partition.DroppingDefinitions, HasLen, 0)
+	cnt := checkGlobalIndexCleanUpDone(c, s.ctx, t.Meta(), idxInfo, pid)
+	c.Assert(cnt, Equals, 2)
+
+	// The values of the truncated rows can be inserted again.
+	tk.MustExec(`INSERT INTO test_global VALUES (13, 3, 3), (4, 4, 4)`)
+	tk.MustExec("admin check table test_global")
+
+	tk.MustExec("create table test_global_nt (a int, b int, c int, unique key idx_b (b))")
+	tk.MustExec("set @@tidb_enable_exchange_partition=1")
+	defer tk.MustExec("set @@tidb_enable_exchange_partition=0")
+	tk.MustGetErrCode("alter table test_global exchange partition p1 with table test_global_nt", tmysql.ErrUnsupportedDDLOperation)
+}
+
 func (s *testSerialDBSuite1) TestAlterTableExchangePartition(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use test")
@@ -3055,7 +3259,7 @@ func (s *testIntegrationSuite3) TestPartitionErrorCode(c *C) {
 	_, err = tk.Exec("alter table t_part coalesce partition 4;")
 	c.Assert(ddl.ErrCoalesceOnlyOnHashPartition.Equal(err), IsTrue)
 
-	tk.MustGetErrCode(`alter table t_part reorganize partition p0, p1 into (
+	tk.MustGetErrCode(`alter table employees reorganize partition p0, p1 into (
 			partition p0 values less than (1980));`, tmysql.ErrUnsupportedDDLOperation)
 
 	tk.MustGetErrCode("alter table t_part check partition p0, p1;", tmysql.ErrUnsupportedDDLOperation)
@@ -3487,6 +3691,64 @@ func (s *testSerialDBSuite1) TestTruncatePartitionMultipleTimes(c *C) {
 	c.Assert(errCount, LessEqual, int32(1))
 }
 
+func (s *testSerialDBSuite1) TestReorganizePartitionWithDML(c *C) {
+	tk := testkit.NewTestKitWithInit(c, s.store)
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec(`create table t (a int, b int, key idx_b(b)) partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (maxvalue));`)
+	tk.MustExec("insert into t values (1, 1), (11, 11), (12, 12), (21, 21), (31, 31)")
+
+	tk1 := testkit.NewTestKitWithInit(c, s.store)
+	dom := domain.GetDomain(tk.Se)
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &ddl.TestDDLCallback{}
+	var states []model.SchemaState
+	var checkErr error
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type != model.ActionReorganizePartition || checkErr != nil {
+			return
+		}
+		if len(states) > 0 && states[len(states)-1] == job.SchemaState {
+			return
+		}
+		states = append(states, job.SchemaState)
+		sqls := []string{
+			fmt.Sprintf("insert into t values (%d, %d)", 40+len(states), 40+len(states)),
+			"update t set b = b + 1 where a = 12",
+		}
+		switch len(states) {
+		case 2:
+			sqls = append(sqls, "delete from t where a = 31")
+		case 3:
+			sqls = append(sqls, "update t set a = 2 where a = 21")
+		case 4:
+			sqls = append(sqls, "update t set a = 25 where a = 11")
+		}
+		for _, sql := range sqls {
+			if _, checkErr = tk1.Exec(sql); checkErr != nil {
+				return
+			}
+		}
+	}
+	dom.DDL().SetHook(hook)
+	tk.MustExec(`alter table t reorganize partition p1 into (
+		partition p1 values less than (20),
+		partition p2 values less than (30),
+		partition p3 values less than (maxvalue))`)
+	c.Assert(checkErr, IsNil)
+	c.Assert(states, DeepEquals, []model.SchemaState{model.StateNone, model.StateDeleteOnly, model.StateWriteOnly,
+		model.StateWriteReorganization, model.StateDeleteReorganization})
+
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p0) order by a").Check(testkit.Rows("1 1", "2 21"))
+	tk.MustQuery("select * from t partition (p1) order by a").Check(testkit.Rows("12 17"))
+	tk.MustQuery("select * from t partition (p2) order by a").Check(testkit.Rows("25 11"))
+	tk.MustQuery("select * from t partition (p3) order by a").Check(testkit.Rows("41 41", "42 42", "43 43", "44 44", "45 45"))
+	tk.MustQuery("select a from t use index(idx_b) where b > 15 order by b").Check(testkit.Rows("12", "2", "41", "42", "43", "44", "45"))
+}
+
 func (s *testSerialDBSuite1) TestAddPartitionReplicaBiggerThanTiFlashStores(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("create database if not exists test_partition2")
@@ -6456,30 +6456,44 @@ func (s *testDBSuite4) TestColumnCheck(c *C) {
 	tk.MustExec("drop table if exists column_check")
 	tk.MustExec("create table column_check (pk int primary key, a int check (a > 1))")
 	defer tk.MustExec("drop table if exists column_check")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|CONSTRAINT CHECK is not supported"))
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(0))
+	tk.MustExec("insert into column_check values (1, 2)")
+	tk.MustGetErrCode("insert into column_check values (2, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("create table column_check_other (a int, b int check (a > 1))", errno.ErrColumnCheckConstraintReferencesOtherColumn)
+	tk.MustGetErrCode("create table column_check_other (a int auto_increment primary key, check (a > 1))", errno.ErrCheckConstraintRefersAutoIncrementColumn)
+	tk.MustGetErrCode("create table column_check_other (a int, check (b > 1))", errno.ErrCheckConstraintRefersUnknownColumn)
+	tk.MustGetErrCode("create table column_check_other (a int, check (a > rand()))", errno.ErrCheckConstraintNamedFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table column_check_other (a int, check (a > @x))", errno.ErrCheckConstraintVariables)
+	tk.MustGetErrCode("create table column_check_other (a int, constraint c1 check (a > 1), constraint c1 check (a < 10))", errno.ErrCheckConstraintDupName)
 }
 
 func (s *testDBSuite5) TestAlterCheck(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use " + s.schemaName)
 	tk.MustExec("drop table if exists alter_check")
-	tk.MustExec("create table alter_check (pk int primary key)")
+	tk.MustExec("create table alter_check (pk int primary key, a int, constraint crcn check (a > 1))")
 	defer tk.MustExec("drop table if exists alter_check")
+	tk.MustGetErrCode("alter table alter_check alter check unknown_check ENFORCED", errno.ErrCheckConstraintNotFound)
+	tk.MustExec("alter table alter_check alter check crcn NOT ENFORCED")
+	tk.MustExec("insert into alter_check values (1, 0)")
+	// The existing rows are validated when the constraint is enforced again.
+	tk.MustGetErrCode("alter table alter_check alter check crcn ENFORCED", errno.ErrCheckConstraintViolated)
+	tk.MustExec("insert into alter_check values (2, 0)")
+	tk.MustExec("delete from alter_check")
 	tk.MustExec("alter table alter_check alter check crcn ENFORCED")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|ALTER CHECK is not supported"))
+	tk.MustGetErrCode("insert into alter_check values (3, 0)", errno.ErrCheckConstraintViolated)
 }
 
 func (s *testDBSuite6) TestDropCheck(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use " + s.schemaName)
 	tk.MustExec("drop table if exists drop_check")
-	tk.MustExec("create table drop_check (pk int primary key)")
+	tk.MustExec("create table drop_check (pk int primary key, a int, constraint crcn check (a > 1))")
 	defer tk.MustExec("drop table if exists drop_check")
+	tk.MustGetErrCode("insert into drop_check values (1, 0)", errno.ErrCheckConstraintViolated)
 	tk.MustExec("alter table drop_check drop check crcn")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|DROP CHECK is not supported"))
+	tk.MustExec("insert into drop_check values (1, 0)")
+	tk.MustGetErrCode("alter table drop_check drop check crcn", errno.ErrCheckConstraintNotFound)
 }
 
 func (s *testDBSuite7) TestAddConstraintCheck(c *C) {
@@ -6488,22 +6502,58 @@ func (s *testDBSuite7) TestAddConstraintCheck(c *C) {
 	tk.MustExec("drop table if exists add_constraint_check")
 	tk.MustExec("create table add_constraint_check (pk int primary key, a int)")
 	defer tk.MustExec("drop table if exists add_constraint_check")
-	tk.MustExec("alter table add_constraint_check add constraint crn check (a > 1)")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|ADD CONSTRAINT CHECK is not supported"))
-}
-
-func (s *testDBSuite7) TestCreateTableIngoreCheckConstraint(c *C) {
+	tk.MustExec("insert into add_constraint_check values (1, 0), (2, 2)")
+	tk.MustGetErrCode("alter table add_constraint_check add constraint crn check (a > 1)", errno.ErrCheckConstraintViolated)
+	c.Assert(testGetTableByName(c, tk.Se, s.schemaName, "add_constraint_check").Meta().Constraints, HasLen, 0)
+	tk.MustExec("alter table add_constraint_check add constraint crn check (a > 0) not enforced")
+	tk.MustExec("delete from add_constraint_check where a = 0")
+	tk.MustExec("alter table add_constraint_check add constraint crn1 check (a > 1)")
+	tk.MustGetErrCode("alter table add_constraint_check add constraint crn1 check (a > 1)", errno.ErrCheckConstraintDupName)
+	tk.MustGetErrCode("insert into add_constraint_check values (3, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("update add_constraint_check set a = 1", errno.ErrCheckConstraintViolated)
+	tk.MustQuery("select * from add_constraint_check").Check(testkit.Rows("2 2"))
+	// The check constraints can't be dropped implicitly with the columns they depend on.
+	tk.MustExec("alter table add_constraint_check add column b int")
+	tk.MustExec("alter table add_constraint_check add constraint crn2 check (a < b)")
+	tk.MustGetErrCode("alter table add_constraint_check drop column b", errno.ErrDependentByCheckConstraint)
+	tk.MustGetErrCode("alter table add_constraint_check rename column a to c", errno.ErrDependentByCheckConstraint)
+
+	// The check constraint of the added column verifies the existing rows, the column isn't added if it's violated.
+	tk.MustGetErrCode("alter table add_constraint_check add column c int default 0 check (c > 0)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("select c from add_constraint_check", errno.ErrBadField)
+	tk.MustExec("alter table add_constraint_check add column c int default 1 constraint crn3 check (c > 0)")
+	tk.MustGetErrCode("insert into add_constraint_check values (3, 2, 3, 0)", errno.ErrCheckConstraintViolated)
+	c.Assert(testGetTableByName(c, tk.Se, s.schemaName, "add_constraint_check").Meta().FindConstraintInfoByName("crn3"), NotNil)
+	c.Assert(testGetTableByName(c, tk.Se, s.schemaName, "add_constraint_check").Meta().Constraints, HasLen, 4)
+
+	// The check constraints of the columns added by a multi-schema change are verified before the change is committed.
+	tk.MustGetErrCode("alter table add_constraint_check add column d int default 0 check (d > 0), add index idx_a(a)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("select d from add_constraint_check", errno.ErrBadField)
+	tbl := testGetTableByName(c, tk.Se, s.schemaName, "add_constraint_check")
+	c.Assert(tbl.Meta().FindIndexByName("idx_a"), IsNil)
+	c.Assert(tbl.Meta().Constraints, HasLen, 4)
+	tk.MustExec("alter table add_constraint_check add column d int default 1 constraint crn4 check (d > 0), add column e int default 1 check (e < 2), add index idx_a(a)")
+	tk.MustGetErrCode("insert into add_constraint_check values (3, 2, 3, 1, 0, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("insert into add_constraint_check values (3, 2, 3, 1, 1, 2)", errno.ErrCheckConstraintViolated)
+	tk.MustExec("insert into add_constraint_check values (3, 2, 3, 1, 1, 1)")
+	tk.MustQuery("select * from add_constraint_check").Check(testkit.Rows("2 2 <nil> 1 1 1", "3 2 3 1 1 1"))
+	c.Assert(testGetTableByName(c, tk.Se, s.schemaName, "add_constraint_check").Meta().Constraints, HasLen, 6)
+}
+
+func (s *testDBSuite7) TestCreateTableWithCheckConstraint(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use " + s.schemaName)
-	tk.MustExec("drop table if exists table_constraint_check")
+	tk.MustExec("drop table if exists admin_user")
 	tk.MustExec("CREATE TABLE admin_user (enable bool, CHECK (enable IN (0, 1)));")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|CONSTRAINT CHECK is not supported"))
+	defer tk.MustExec("drop table if exists admin_user")
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(0))
 	tk.MustQuery("show create table admin_user").Check(testutil.RowsWithSep("|", ""+
 		"admin_user CREATE TABLE `admin_user` (\n"+
-		"  `enable` tinyint(1) DEFAULT NULL\n"+
+		"  `enable` tinyint(1) DEFAULT NULL,\n"+
+		"  CONSTRAINT `admin_user_chk_1` CHECK ((`enable` in (0,1)))\n"+
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec("insert into admin_user values (1), (null)")
+	tk.MustGetErrCode("insert into admin_user values (2)", errno.ErrCheckConstraintViolated)
 }
 
 func (s *testDBSuite6) TestAlterOrderBy(c *C) {
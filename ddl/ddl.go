@@ -119,6 +119,18 @@ type DDL interface {
 	CreatePlacementPolicy(ctx sessionctx.Context, stmt *ast.CreatePlacementPolicyStmt) error
 	DropPlacementPolicy(ctx sessionctx.Context, stmt *ast.DropPlacementPolicyStmt) error
 	AlterPlacementPolicy(ctx sessionctx.Context, stmt *ast.AlterPlacementPolicyStmt) error
+	// AlterTableTTL sets the TTL definition of the table, or removes it if the definition is nil.
+	AlterTableTTL(ctx sessionctx.Context, tableIdent ast.Ident, ttlInfo *model.TTLInfo) error
+	// AlterTableCache caches the data of the table in the memory of TiDBs.
+	AlterTableCache(ctx sessionctx.Context, tableIdent ast.Ident) error
+	// AlterTableNoCache stops caching the data of the table.
+	AlterTableNoCache(ctx sessionctx.Context, tableIdent ast.Ident) error
+	// CreateResourceGroup creates a resource group.
+	CreateResourceGroup(ctx sessionctx.Context, stmt *ast.CreateResourceGroupStmt) error
+	// AlterResourceGroup changes the quota of a resource group.
+	AlterResourceGroup(ctx sessionctx.Context, stmt *ast.AlterResourceGroupStmt) error
+	// DropResourceGroup drops a resource group.
+	DropResourceGroup(ctx sessionctx.Context, stmt *ast.DropResourceGroupStmt) error
 
 	// CreateSchemaWithInfo creates a database (schema) given its database info.
 	//
@@ -176,6 +188,8 @@ type DDL interface {
 	GetHook() Callback
 	// SetHook sets the hook.
 	SetHook(h Callback)
+	// MetadataLock gets the metadata lock manager of the transactions on this instance.
+	MetadataLock() *MetadataLockManager
 }
 
 type limitJobTask struct {
@@ -211,6 +225,7 @@ type ddlCtx struct {
 	statsHandle  *handle.Handle
 	tableLockCkr util.DeadTableLockChecker
 	etcdCli      *clientv3.Client
+	mdl          *MetadataLockManager
 
 	// hook may be modified.
 	mu struct {
@@ -307,6 +322,7 @@ func newDDL(ctx context.Context, options ...Option) *ddl {
 		infoCache:    opt.InfoCache,
 		tableLockCkr: deadLockCkr,
 		etcdCli:      opt.EtcdCli,
+		mdl:          newMetadataLockManager(syncer),
 	}
 	ddlCtx.mu.hook = opt.Hook
 	ddlCtx.mu.interceptor = &BaseInterceptor{}
@@ -350,6 +366,9 @@ func (d *ddl) Start(ctxPool *pools.ResourcePool) error {
 	d.wg.Add(1)
 	go d.limitDDLJobs()
 
+	d.wg.Add(1)
+	go d.startPublishMetadataLocks()
+
 	// If RunWorker is true, we need campaign owner and do DDL job.
 	// Otherwise, we needn't do that.
 	if RunWorker {
@@ -495,7 +514,8 @@ func getIntervalFromPolicy(policy []time.Duration, i int) (time.Duration, bool)
 
 func getJobCheckInterval(job *model.Job, i int) (time.Duration, bool) {
 	switch job.Type {
-	case model.ActionAddIndex, model.ActionAddPrimaryKey, model.ActionModifyColumn:
+	case model.ActionAddIndex, model.ActionAddPrimaryKey, model.ActionModifyColumn,
+		model.ActionAddCheckConstraint, model.ActionAlterCheckConstraint, model.ActionReorganizePartition:
 		return getIntervalFromPolicy(slowDDLIntervalPolicy, i)
 	case model.ActionCreateTable, model.ActionCreateSchema:
 		return getIntervalFromPolicy(fastDDLIntervalPolicy, i)
@@ -663,6 +683,11 @@ func (d *ddl) SetHook(h Callback) {
 	d.mu.hook = h
 }
 
+// MetadataLock implements DDL.MetadataLock interface.
+func (d *ddl) MetadataLock() *MetadataLockManager {
+	return d.mdl
+}
+
 func (d *ddl) startCleanDeadTableLock() {
 	defer func() {
 		goutil.Recover(metrics.LabelDDL, "startCleanDeadTableLock", nil, false)
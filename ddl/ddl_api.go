@@ -45,6 +45,7 @@ import (
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/resourcegroup"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/table"
@@ -476,17 +477,17 @@ func buildColumnAndConstraint(
 
 // checkColumnDefaultValue checks the default value of the column.
 // In non-strict SQL mode, if the default value of the column is an empty string, the default value can be ignored.
-// In strict SQL mode, TEXT/BLOB/JSON can't have not null default values.
+// In strict SQL mode, TEXT/BLOB/JSON/GEOMETRY can't have not null default values.
 // In NO_ZERO_DATE SQL mode, TIMESTAMP/DATE/DATETIME type can't have zero date like '0000-00-00' or '0000-00-00 00:00:00'.
 func checkColumnDefaultValue(ctx sessionctx.Context, col *table.Column, value interface{}) (bool, interface{}, error) {
 	hasDefaultValue := true
-	if value != nil && (col.Tp == mysql.TypeJSON ||
+	if value != nil && (col.Tp == mysql.TypeJSON || col.Tp == mysql.TypeGeometry ||
 		col.Tp == mysql.TypeTinyBlob || col.Tp == mysql.TypeMediumBlob ||
 		col.Tp == mysql.TypeLongBlob || col.Tp == mysql.TypeBlob) {
 		// In non-strict SQL mode.
 		if !ctx.GetSessionVars().SQLMode.HasStrictMode() && value == "" {
-			if col.Tp == mysql.TypeBlob || col.Tp == mysql.TypeLongBlob {
-				// The TEXT/BLOB default value can be ignored.
+			if col.Tp == mysql.TypeBlob || col.Tp == mysql.TypeLongBlob || col.Tp == mysql.TypeGeometry {
+				// The TEXT/BLOB/GEOMETRY default value can be ignored.
 				hasDefaultValue = false
 			}
 			// In non-strict SQL mode, if the column type is json and the default value is null, it is initialized to an empty array.
@@ -710,7 +711,15 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 			case ast.ColumnOptionFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleFt.GenWithStackByArgs())
 			case ast.ColumnOptionCheck:
-				ctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("CONSTRAINT CHECK"))
+				constraint := &ast.Constraint{
+					Tp:           ast.ConstraintCheck,
+					Name:         v.ConstraintName,
+					Expr:         v.Expr,
+					Enforced:     v.Enforced,
+					InColumn:     true,
+					InColumnName: colDef.Name.Name.O,
+				}
+				constraints = append(constraints, constraint)
 			}
 		}
 	}
@@ -1239,7 +1248,11 @@ func checkConstraintNames(constraints []*ast.Constraint) error {
 	fkNames := map[string]bool{}
 
 	// Check not empty constraint name whether is duplicated.
+	// The names of check constraints are checked by buildCheckConstraints.
 	for _, constr := range constraints {
+		if constr.Tp == ast.ConstraintCheck {
+			continue
+		}
 		if constr.Tp == ast.ConstraintForeignKey {
 			err := checkDuplicateConstraint(fkNames, constr.Name, true)
 			if err != nil {
@@ -1255,6 +1268,9 @@ func checkConstraintNames(constraints []*ast.Constraint) error {
 
 	// Set empty constraint names.
 	for _, constr := range constraints {
+		if constr.Tp == ast.ConstraintCheck {
+			continue
+		}
 		if constr.Tp == ast.ConstraintForeignKey {
 			setEmptyConstraintName(fkNames, constr, true)
 		} else {
@@ -1412,6 +1428,10 @@ func buildTableInfo(
 		if err != nil {
 			return nil, err
 		}
+		unique := constr.Tp == ast.ConstraintUniq || constr.Tp == ast.ConstraintUniqKey || constr.Tp == ast.ConstraintUniqIndex
+		if err = checkMultiValuedIndex(unique, hiddenCols); err != nil {
+			return nil, errors.Trace(err)
+		}
 		for _, hiddenCol := range hiddenCols {
 			hiddenCol.State = model.StatePublic
 			hiddenCol.ID = allocateColumnID(tbInfo)
@@ -1468,8 +1488,13 @@ func buildTableInfo(
 			ctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleFt.GenWithStackByArgs())
 			continue
 		}
+		if constr.Tp == ast.ConstraintSpatial {
+			// The SPATIAL index is ignored like the one created by CREATE SPATIAL INDEX.
+			ctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleSpkeys)
+			continue
+		}
 		if constr.Tp == ast.ConstraintCheck {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("CONSTRAINT CHECK"))
+			// The check constraints are built after all the columns are decided.
 			continue
 		}
 		// build index info.
@@ -1511,6 +1536,9 @@ func buildTableInfo(
 		idxInfo.ID = allocateIndexID(tbInfo)
 		tbInfo.Indices = append(tbInfo.Indices, idxInfo)
 	}
+	if err = buildCheckConstraints(ctx, tbInfo, constraints); err != nil {
+		return nil, errors.Trace(err)
+	}
 	if tbInfo.IsCommonHandle {
 		// Ensure tblInfo's each non-unique secondary-index's len + primary-key's len <= MaxIndexLength for clustered index table.
 		var pkLen, idxLen int
@@ -1672,7 +1700,7 @@ func checkPartitionDefinitionConstraints(ctx sessionctx.Context, tbInfo *model.T
 	switch tbInfo.Partition.Type {
 	case model.PartitionTypeRange:
 		err = checkPartitionByRange(ctx, tbInfo)
-	case model.PartitionTypeHash:
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 		err = checkPartitionByHash(ctx, tbInfo)
 	case model.PartitionTypeList:
 		err = checkPartitionByList(ctx, tbInfo)
@@ -1706,14 +1734,24 @@ func buildTableInfoWithLike(ctx sessionctx.Context, ident ast.Ident, referTblInf
 			newIndices = append(newIndices, idx)
 		}
 	}
+	newConstraints := make([]*model.ConstraintInfo, 0, len(tblInfo.Constraints))
+	for _, constr := range tblInfo.Constraints {
+		if constr.State == model.StatePublic {
+			newConstr := constr.Clone()
+			newConstr.Table = ident.Name
+			newConstraints = append(newConstraints, newConstr)
+		}
+	}
 	tblInfo.Columns = newColumns
 	tblInfo.Indices = newIndices
+	tblInfo.Constraints = newConstraints
 	tblInfo.Name = ident.Name
 	tblInfo.AutoIncID = 0
 	tblInfo.ForeignKeys = nil
-	// Ignore TiFlash replicas for temporary tables.
+	// Ignore TiFlash replicas and TTL for temporary tables.
 	if s.TemporaryKeyword != ast.TemporaryNone {
 		tblInfo.TiFlashReplica = nil
+		tblInfo.TTLInfo = nil
 	} else if tblInfo.TiFlashReplica != nil {
 		replica := *tblInfo.TiFlashReplica
 		// Keep the tiflash replica setting, remove the replica available status.
@@ -1721,6 +1759,9 @@ func buildTableInfoWithLike(ctx sessionctx.Context, ident ast.Ident, referTblInf
 		replica.Available = false
 		tblInfo.TiFlashReplica = &replica
 	}
+	if tblInfo.TTLInfo != nil {
+		tblInfo.TTLInfo = tblInfo.TTLInfo.Clone()
+	}
 	if referTblInfo.Partition != nil {
 		pi := *referTblInfo.Partition
 		pi.Definitions = make([]model.PartitionDefinition, len(referTblInfo.Partition.Definitions))
@@ -1895,6 +1936,15 @@ func (d *ddl) CreateTable(ctx sessionctx.Context, s *ast.CreateTableStmt) (err e
 		return err
 	}
 
+	if tbInfo.TTLInfo, err = buildTTLInfo(tbInfo.TTLInfo, s.Options); err != nil {
+		return errors.Trace(err)
+	}
+	if tbInfo.TTLInfo != nil {
+		if err = checkTTLColumn(tbInfo, tbInfo.TTLInfo.ColumnName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	onExist := OnExistError
 	if s.IfNotExists {
 		onExist = OnExistIgnore
@@ -2354,6 +2404,11 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 				return errors.Trace(ErrOptOnTemporaryTable.GenWithStackByArgs("pre split regions"))
 			}
 			tbInfo.PreSplitRegions = op.UintValue
+		case ast.TableOptionTTL, ast.TableOptionTTLEnable:
+			// The TTL definition is built after the columns, see `buildTTLInfo`.
+			if tbInfo.TempTableType != model.TempTableNone {
+				return errors.Trace(ErrOptOnTemporaryTable.GenWithStackByArgs("ttl"))
+			}
 		case ast.TableOptionCharset, ast.TableOptionCollate:
 			// We don't handle charset and collate here since they're handled in `getCharsetAndCollateInTableOption`.
 		case ast.TableOptionEngine:
@@ -2514,10 +2569,13 @@ func resolveAlterTableSpec(ctx sessionctx.Context, specs []*ast.AlterTableSpec)
 }
 
 func isSameTypeMultiSpecs(specs []*ast.AlterTableSpec) bool {
+	isDropIndex := func(tp ast.AlterTableType) bool {
+		return tp == ast.AlterTableDropPrimaryKey || tp == ast.AlterTableDropIndex
+	}
 	specType := specs[0].Tp
 	for _, spec := range specs {
 		// We think AlterTableDropPrimaryKey and AlterTableDropIndex are the same types.
-		if spec.Tp == ast.AlterTableDropPrimaryKey || spec.Tp == ast.AlterTableDropIndex {
+		if isDropIndex(specType) && isDropIndex(spec.Tp) {
 			continue
 		}
 		if spec.Tp != specType {
@@ -2527,6 +2585,168 @@ func isSameTypeMultiSpecs(specs []*ast.AlterTableSpec) bool {
 	return true
 }
 
+// multiSchemaChange runs the specs adding or dropping columns and indexes as one multi-schema change job.
+// The specs are checked as if they were run in the order of adding columns, adding indexes, dropping columns
+// and dropping indexes, and a column or an index can't be changed by more than one spec, except that an index can be
+// dropped along with the column it covers.
+func (d *ddl) multiSchemaChange(ctx sessionctx.Context, ti ast.Ident, specs []*ast.AlterTableSpec) error {
+	var addColumnSpecs, addIndexSpecs, dropColumnSpecs, dropIndexSpecs []*ast.AlterTableSpec
+	for _, spec := range specs {
+		switch spec.Tp {
+		case ast.AlterTableAddColumns:
+			addColumnSpecs = append(addColumnSpecs, spec)
+		case ast.AlterTableDropColumn:
+			dropColumnSpecs = append(dropColumnSpecs, spec)
+		case ast.AlterTableDropIndex:
+			dropIndexSpecs = append(dropIndexSpecs, spec)
+		case ast.AlterTableAddConstraint:
+			switch spec.Constraint.Tp {
+			case ast.ConstraintKey, ast.ConstraintIndex, ast.ConstraintUniq, ast.ConstraintUniqIndex, ast.ConstraintUniqKey:
+			default:
+				return errRunMultiSchemaChanges
+			}
+			for _, key := range spec.Constraint.Keys {
+				// The hidden columns of the expression indexes can't be added along with other columns.
+				if key.Expr != nil {
+					return errRunMultiSchemaChanges
+				}
+			}
+			addIndexSpecs = append(addIndexSpecs, spec)
+		default:
+			return errRunMultiSchemaChanges
+		}
+	}
+
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := t.Meta()
+	// newTblInfo is the table with the added columns and indexes, which are used to check the later specs.
+	newTblInfo := tblInfo.Clone()
+	var jobs []*model.Job
+	addedColumns := make(map[string]struct{})
+	if len(addColumnSpecs) > 0 {
+		job, err := newAddColumnsJob(ctx, ti, schema, t, addColumnSpecs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if job != nil {
+			jobs = append(jobs, job)
+			for _, col := range job.Args[0].([]*table.Column) {
+				colInfo := col.ToInfo().Clone()
+				colInfo.Offset = len(newTblInfo.Columns)
+				colInfo.State = model.StatePublic
+				newTblInfo.Columns = append(newTblInfo.Columns, colInfo)
+				addedColumns[colInfo.Name.L] = struct{}{}
+			}
+		}
+	}
+	addedIndexes := make(map[string]struct{})
+	indexedColumns := make(map[string]struct{})
+	for _, spec := range addIndexSpecs {
+		constr := spec.Constraint
+		keyType, ifNotExists := ast.IndexKeyTypeNone, constr.IfNotExists
+		if constr.Tp == ast.ConstraintUniq || constr.Tp == ast.ConstraintUniqIndex || constr.Tp == ast.ConstraintUniqKey {
+			// IfNotExists should be not applied to the unique index.
+			keyType, ifNotExists = ast.IndexKeyTypeUnique, false
+		}
+		job, err := newCreateIndexJob(ctx, schema, tables.MockTableFromMeta(newTblInfo), keyType, model.NewCIStr(constr.Name),
+			constr.Keys, constr.Option, ifNotExists)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if job == nil {
+			continue
+		}
+		jobs = append(jobs, job)
+		indexName := job.Args[1].(model.CIStr)
+		newTblInfo.Indices = append(newTblInfo.Indices, &model.IndexInfo{Name: indexName, State: model.StatePublic})
+		addedIndexes[indexName.L] = struct{}{}
+		for _, key := range constr.Keys {
+			indexedColumns[key.Column.Name.L] = struct{}{}
+		}
+	}
+	droppedIndexes := make(map[string]struct{})
+	if len(dropColumnSpecs) > 0 {
+		job, err := newDropColumnsJob(ctx, schema, t, dropColumnSpecs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if job != nil {
+			jobs = append(jobs, job)
+			for _, colName := range job.Args[0].([]model.CIStr) {
+				_, added := addedColumns[colName.L]
+				_, indexed := indexedColumns[colName.L]
+				if added || indexed {
+					return ErrOperateSameColumn.GenWithStackByArgs(colName.O)
+				}
+				// The indexes covering the dropped columns are dropped too.
+				for _, idxInfo := range tblInfo.Indices {
+					if findColumnInIndexCols(colName.L, idxInfo.Columns) != nil {
+						droppedIndexes[idxInfo.Name.L] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+	// The indexes dropped along with the columns don't need to be dropped again.
+	remainingDropIndexSpecs := dropIndexSpecs[:0]
+	for _, spec := range dropIndexSpecs {
+		if _, ok := droppedIndexes[strings.ToLower(spec.Name)]; !ok {
+			remainingDropIndexSpecs = append(remainingDropIndexSpecs, spec)
+		}
+	}
+	if len(remainingDropIndexSpecs) > 0 {
+		job, err := newDropIndexesJob(schema, t, remainingDropIndexSpecs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		jobs = append(jobs, job)
+		for _, indexName := range job.Args[0].([]model.CIStr) {
+			if _, added := addedIndexes[indexName.L]; added {
+				return ErrOperateSameIndex.GenWithStackByArgs(indexName.O)
+			}
+		}
+	}
+
+	if len(jobs) == 0 {
+		return nil
+	}
+	if len(jobs) == 1 {
+		err = d.doDDLJob(ctx, jobs[0])
+		err = d.callHookOnChanged(err)
+		return errors.Trace(err)
+	}
+	subJobs := make([]*subJob, 0, len(jobs))
+	for _, job := range jobs {
+		sub, err := newSubJob(job)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		subJobs = append(subJobs, sub)
+	}
+	job := &model.Job{
+		SchemaID:        schema.ID,
+		TableID:         tblInfo.ID,
+		SchemaName:      schema.Name.L,
+		Type:            model.ActionMultiSchemaChange,
+		BinlogInfo:      &model.HistoryInfo{},
+		MultiSchemaInfo: &model.MultiSchemaInfo{},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Args:     []interface{}{subJobs},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast.Ident, specs []*ast.AlterTableSpec) (err error) {
 	validSpecs, err := resolveAlterTableSpec(sctx, specs)
 	if err != nil {
@@ -2537,6 +2757,20 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 	if is.TableIsView(ident.Schema, ident.Name) || is.TableIsSequence(ident.Schema, ident.Name) {
 		return ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "BASE TABLE")
 	}
+	// The cache status is the only thing that can be altered on the cached tables.
+	if len(validSpecs) == 1 {
+		switch validSpecs[0].Tp {
+		case ast.AlterTableCache:
+			return errors.Trace(d.AlterTableCache(sctx, ident))
+		case ast.AlterTableNoCache:
+			return errors.Trace(d.AlterTableNoCache(sctx, ident))
+		}
+	}
+	if tb, err := is.TableByName(ident.Schema, ident.Name); err == nil {
+		if _, ok := tb.(table.CachedTable); ok {
+			return ErrOptOnCacheTable.GenWithStackByArgs("Alter Table")
+		}
+	}
 
 	if len(validSpecs) > 1 {
 		if !sctx.GetSessionVars().EnableChangeMultiSchema {
@@ -2546,25 +2780,19 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		if isSameTypeMultiSpecs(validSpecs) {
 			switch validSpecs[0].Tp {
 			case ast.AlterTableAddColumns:
-				err = d.AddColumns(sctx, ident, validSpecs)
+				return errors.Trace(d.AddColumns(sctx, ident, validSpecs))
 			case ast.AlterTableDropColumn:
-				err = d.DropColumns(sctx, ident, validSpecs)
+				return errors.Trace(d.DropColumns(sctx, ident, validSpecs))
 			case ast.AlterTableDropPrimaryKey, ast.AlterTableDropIndex:
-				err = d.DropIndexes(sctx, ident, validSpecs)
-			default:
-				return errRunMultiSchemaChanges
-			}
-			if err != nil {
-				return errors.Trace(err)
+				return errors.Trace(d.DropIndexes(sctx, ident, validSpecs))
 			}
-			return nil
 		}
 
-		return errRunMultiSchemaChanges
+		return errors.Trace(d.multiSchemaChange(sctx, ident, validSpecs))
 	}
 
 	for _, spec := range validSpecs {
-		var handledCharsetOrCollate bool
+		var handledCharsetOrCollate, handledTTL bool
 		switch spec.Tp {
 		case ast.AlterTableAddColumns:
 			if len(spec.NewColumns) != 1 {
@@ -2577,7 +2805,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		case ast.AlterTableCoalescePartitions:
 			err = d.CoalescePartitions(sctx, ident, spec)
 		case ast.AlterTableReorganizePartition:
-			err = errors.Trace(errUnsupportedReorganizePartition)
+			err = d.ReorganizePartitions(sctx, ident, spec)
 		case ast.AlterTableCheckPartitions:
 			err = errors.Trace(errUnsupportedCheckPartition)
 		case ast.AlterTableRebuildPartition:
@@ -2637,8 +2865,10 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 				err = d.CreatePrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintFulltext:
 				sctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleFt)
+			case ast.ConstraintSpatial:
+				sctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleSpkeys)
 			case ast.ConstraintCheck:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("ADD CONSTRAINT CHECK"))
+				err = d.CreateCheckConstraint(sctx, ident, constr)
 			default:
 				// Nothing to do now.
 			}
@@ -2701,6 +2931,13 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 					needsOverwriteCols := needToOverwriteColCharset(spec.Options)
 					err = d.AlterTableCharsetAndCollate(sctx, ident, toCharset, toCollate, needsOverwriteCols)
 					handledCharsetOrCollate = true
+				case ast.TableOptionTTL, ast.TableOptionTTLEnable:
+					// The TTL options are applied together, so they should be handled only once.
+					if handledTTL {
+						continue
+					}
+					err = d.alterTableTTLOptions(sctx, ident, spec.Options)
+					handledTTL = true
 				case ast.TableOptionEngine:
 				default:
 					err = errUnsupportedAlterTableOption
@@ -2717,9 +2954,9 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		case ast.AlterTableIndexInvisible:
 			err = d.AlterIndexVisibility(sctx, ident, spec.IndexName, spec.Visibility)
 		case ast.AlterTableAlterCheck:
-			sctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("ALTER CHECK"))
+			err = d.AlterCheckConstraint(sctx, ident, model.NewCIStr(spec.Constraint.Name), spec.Constraint.Enforced)
 		case ast.AlterTableDropCheck:
-			sctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("DROP CHECK"))
+			err = d.DropCheckConstraint(sctx, ident, model.NewCIStr(spec.Constraint.Name))
 		case ast.AlterTableWithValidation:
 			sctx.GetSessionVars().StmtCtx.AppendWarning(errUnsupportedAlterTableWithValidation)
 		case ast.AlterTableWithoutValidation:
@@ -2730,6 +2967,8 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 			err = d.AlterTableDropStatistics(sctx, ident, spec.Statistics, spec.IfExists)
 		case ast.AlterTableAttributes:
 			err = d.AlterTableAttributes(sctx, ident, spec)
+		case ast.AlterTableRemoveTTL:
+			err = d.AlterTableTTL(sctx, ident, nil)
 		case ast.AlterTablePartitionAttributes:
 			err = d.AlterTablePartitionAttributes(sctx, ident, spec)
 		default:
@@ -2873,10 +3112,12 @@ func checkUnsupportedColumnConstraint(col *ast.ColumnDef, ti ast.Ident) error {
 	return nil
 }
 
-func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model.DBInfo, spec *ast.AlterTableSpec, t table.Table, specNewColumn *ast.ColumnDef) (*table.Column, error) {
+// checkAndCreateNewColumn checks and builds the adding column, it also returns the check constraints defined on the
+// column, which are built with the other adding columns of the statement.
+func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model.DBInfo, spec *ast.AlterTableSpec, t table.Table, specNewColumn *ast.ColumnDef) (*table.Column, []*ast.Constraint, error) {
 	err := checkUnsupportedColumnConstraint(specNewColumn, ti)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 
 	colName := specNewColumn.Name.Name.O
@@ -2886,15 +3127,15 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 		err = infoschema.ErrColumnExists.GenWithStackByArgs(colName)
 		if spec.IfNotExists {
 			ctx.GetSessionVars().StmtCtx.AppendNote(err)
-			return nil, nil
+			return nil, nil, nil
 		}
-		return nil, err
+		return nil, nil, err
 	}
 	if err = checkColumnAttributes(colName, specNewColumn.Tp); err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	if utf8.RuneCountInString(colName) > mysql.MaxColumnNameLength {
-		return nil, ErrTooLongIdent.GenWithStackByArgs(colName)
+		return nil, nil, ErrTooLongIdent.GenWithStackByArgs(colName)
 	}
 
 	// If new column is a generated column, do validation.
@@ -2903,17 +3144,17 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 	for _, option := range specNewColumn.Options {
 		if option.Tp == ast.ColumnOptionGenerated {
 			if err := checkIllegalFn4Generated(specNewColumn.Name.Name.L, typeColumn, option.Expr); err != nil {
-				return nil, errors.Trace(err)
+				return nil, nil, errors.Trace(err)
 			}
 
 			if option.Stored {
-				return nil, ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Adding generated stored column through ALTER TABLE")
+				return nil, nil, ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Adding generated stored column through ALTER TABLE")
 			}
 
 			_, dependColNames := findDependedColumnNames(specNewColumn)
 			if !ctx.GetSessionVars().EnableAutoIncrementInGenerated {
 				if err = checkAutoIncrementRef(specNewColumn.Name.Name.L, dependColNames, t.Meta()); err != nil {
-					return nil, errors.Trace(err)
+					return nil, nil, errors.Trace(err)
 				}
 			}
 			duplicateColNames := make(map[string]struct{}, len(dependColNames))
@@ -2923,11 +3164,11 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 			cols := t.Cols()
 
 			if err = checkDependedColExist(dependColNames, cols); err != nil {
-				return nil, errors.Trace(err)
+				return nil, nil, errors.Trace(err)
 			}
 
 			if err = verifyColumnGenerationSingle(duplicateColNames, cols, spec.Position); err != nil {
-				return nil, errors.Trace(err)
+				return nil, nil, errors.Trace(err)
 			}
 		}
 		// Specially, since sequence has been supported, if a newly added column has a
@@ -2937,10 +3178,10 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 		if option.Tp == ast.ColumnOptionDefaultValue {
 			_, isSeqExpr, err := tryToGetSequenceDefaultValue(option)
 			if err != nil {
-				return nil, errors.Trace(err)
+				return nil, nil, errors.Trace(err)
 			}
 			if isSeqExpr {
-				return nil, errors.Trace(ErrAddColumnWithSequenceAsDefault.GenWithStackByArgs(specNewColumn.Name.Name.O))
+				return nil, nil, errors.Trace(ErrAddColumnWithSequenceAsDefault.GenWithStackByArgs(specNewColumn.Name.Name.O))
 			}
 		}
 	}
@@ -2950,11 +3191,11 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 		ast.CharsetOpt{Chs: schema.Charset, Col: schema.Collate},
 	)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	// Ignore table constraints now, they will be checked later.
 	// We use length(t.Cols()) as the default offset firstly, we will change the column's offset later.
-	col, _, err = buildColumnAndConstraint(
+	col, constraints, err := buildColumnAndConstraint(
 		ctx,
 		len(t.Cols()),
 		specNewColumn,
@@ -2963,16 +3204,23 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 		tableCollate,
 	)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
+	}
+	// The check constraints are built after all the adding columns are built, so their generated names don't conflict.
+	checks := make([]*ast.Constraint, 0, len(constraints))
+	for _, constr := range constraints {
+		if constr.Tp == ast.ConstraintCheck {
+			checks = append(checks, constr)
+		}
 	}
 
 	originDefVal, err := generateOriginDefaultValue(col.ToInfo())
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 
 	err = col.SetOriginDefaultValue(originDefVal)
-	return col, err
+	return col, checks, err
 }
 
 // AddColumn will add a new column to the table.
@@ -2985,7 +3233,7 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 	if err = checkAddColumnTooManyColumns(len(t.Cols()) + 1); err != nil {
 		return errors.Trace(err)
 	}
-	col, err := checkAndCreateNewColumn(ctx, ti, schema, spec, t, specNewColumn)
+	col, checks, err := checkAndCreateNewColumn(ctx, ti, schema, spec, t, specNewColumn)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -2994,13 +3242,24 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 		return nil
 	}
 
+	constraints, err := buildAddingColumnCheckConstraints(ctx, t.Meta(), []*table.Column{col}, checks)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	job := &model.Job{
 		SchemaID:   schema.ID,
 		TableID:    t.Meta().ID,
 		SchemaName: schema.Name.L,
 		Type:       model.ActionAddColumn,
 		BinlogInfo: &model.HistoryInfo{},
-		Args:       []interface{}{col, spec.Position, 0},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Args:     []interface{}{col, spec.Position, 0, constraints},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
 	}
 
 	err = d.doDDLJob(ctx, job)
@@ -3019,7 +3278,21 @@ func (d *ddl) AddColumns(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alte
 	if err != nil {
 		return errors.Trace(err)
 	}
+	job, err := newAddColumnsJob(ctx, ti, schema, t, specs)
+	if err != nil || job == nil {
+		return errors.Trace(err)
+	}
+
+	err = d.doDDLJob(ctx, job)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
 
+// newAddColumnsJob builds the job to add the columns of the specs. It returns nil if there is no column to add.
+func newAddColumnsJob(ctx sessionctx.Context, ti ast.Ident, schema *model.DBInfo, t table.Table, specs []*ast.AlterTableSpec) (*model.Job, error) {
 	// Check all the columns at once.
 	addingColumnNames := make(map[string]bool)
 	dupColumnNames := make(map[string]bool)
@@ -3030,7 +3303,7 @@ func (d *ddl) AddColumns(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alte
 				continue
 			}
 			if !spec.IfNotExists {
-				return errors.Trace(infoschema.ErrColumnExists.GenWithStackByArgs(specNewColumn.Name.Name.O))
+				return nil, errors.Trace(infoschema.ErrColumnExists.GenWithStackByArgs(specNewColumn.Name.Name.O))
 			}
 			dupColumnNames[specNewColumn.Name.Name.L] = true
 		}
@@ -3039,24 +3312,26 @@ func (d *ddl) AddColumns(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alte
 	positions := make([]*ast.ColumnPosition, 0, len(addingColumnNames))
 	offsets := make([]int, 0, len(addingColumnNames))
 	ifNotExists := make([]bool, 0, len(addingColumnNames))
+	var checks []*ast.Constraint
 	newColumnsCount := 0
 	// Check the columns one by one.
 	for _, spec := range specs {
 		for _, specNewColumn := range spec.NewColumns {
 			if spec.IfNotExists && dupColumnNames[specNewColumn.Name.Name.L] {
-				err = infoschema.ErrColumnExists.GenWithStackByArgs(specNewColumn.Name.Name.O)
+				err := infoschema.ErrColumnExists.GenWithStackByArgs(specNewColumn.Name.Name.O)
 				ctx.GetSessionVars().StmtCtx.AppendNote(err)
 				continue
 			}
-			col, err := checkAndCreateNewColumn(ctx, ti, schema, spec, t, specNewColumn)
+			col, colChecks, err := checkAndCreateNewColumn(ctx, ti, schema, spec, t, specNewColumn)
 			if err != nil {
-				return errors.Trace(err)
+				return nil, errors.Trace(err)
 			}
 			// Added column has existed and if_not_exists flag is true.
 			if col == nil && spec.IfNotExists {
 				continue
 			}
 			columns = append(columns, col)
+			checks = append(checks, colChecks...)
 			positions = append(positions, spec.Position)
 			offsets = append(offsets, 0)
 			ifNotExists = append(ifNotExists, spec.IfNotExists)
@@ -3064,10 +3339,15 @@ func (d *ddl) AddColumns(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alte
 		}
 	}
 	if newColumnsCount == 0 {
-		return nil
+		return nil, nil
 	}
-	if err = checkAddColumnTooManyColumns(len(t.Cols()) + newColumnsCount); err != nil {
-		return errors.Trace(err)
+	if err := checkAddColumnTooManyColumns(len(t.Cols()) + newColumnsCount); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	constraints, err := buildAddingColumnCheckConstraints(ctx, t.Meta(), columns, checks)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	job := &model.Job{
@@ -3076,15 +3356,15 @@ func (d *ddl) AddColumns(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alte
 		SchemaName: schema.Name.L,
 		Type:       model.ActionAddColumns,
 		BinlogInfo: &model.HistoryInfo{},
-		Args:       []interface{}{columns, positions, offsets, ifNotExists},
-	}
-
-	err = d.doDDLJob(ctx, job)
-	if err != nil {
-		return errors.Trace(err)
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Args:     []interface{}{columns, positions, offsets, ifNotExists, constraints},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
 	}
-	err = d.callHookOnChanged(err)
-	return errors.Trace(err)
+	return job, nil
 }
 
 // AddTablePartitions will add a new partition to the table.
@@ -3148,6 +3428,87 @@ func (d *ddl) AddTablePartitions(ctx sessionctx.Context, ident ast.Ident, spec *
 	return errors.Trace(err)
 }
 
+// ReorganizePartitions splits or merges the adjacent partitions of a RANGE or LIST partitioned table.
+// The rows in the reorganized partitions are copied into the new partitions by an online DDL job.
+func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return errors.Trace(infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema))
+	}
+	t, err := is.TableByName(ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+
+	tblInfo := t.Meta()
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	if pi.Type != model.PartitionTypeRange && pi.Type != model.PartitionTypeList {
+		return errors.Trace(errUnsupportedReorganizePartition)
+	}
+	if hasGlobalIndex(tblInfo) {
+		return errors.Trace(errUnsupportedReorganizePartition)
+	}
+	if len(spec.PartitionNames) == 0 {
+		return errors.Trace(ErrReorgNoParam)
+	}
+
+	partNames := make([]string, len(spec.PartitionNames))
+	for i, partCIName := range spec.PartitionNames {
+		partNames[i] = partCIName.L
+	}
+	if err = checkReorganizePartition(tblInfo, partNames); err != nil {
+		return errors.Trace(err)
+	}
+
+	partInfo, err := buildAddedPartitionInfo(ctx, tblInfo, spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := d.assignPartitionIDs(partInfo.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+
+	// partInfo contains only the new partitions, we have to replace the reorganized partitions
+	// with them to check all the partitions.
+	droppingDefs := getReorganizedPartitionDefinitions(pi, partNames)
+	clonedMeta := tblInfo.Clone()
+	tmp := *partInfo
+	tmp.Definitions = tables.ReplacePartitionDefinitions(pi.Definitions, droppingDefs, partInfo.Definitions)
+	clonedMeta.Partition = &tmp
+	if err := checkPartitionDefinitionConstraints(ctx, clonedMeta); err != nil {
+		return errors.Trace(err)
+	}
+	if pi.Type == model.PartitionTypeRange {
+		isLast := droppingDefs[len(droppingDefs)-1].ID == pi.Definitions[len(pi.Definitions)-1].ID
+		if err := checkReorganizeRangeBound(ctx, clonedMeta, droppingDefs, partInfo.Definitions, isLast); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionReorganizePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Args:     []interface{}{partNames, partInfo},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 // CoalescePartitions coalesce partitions can be used with a table that is partitioned by hash or key to reduce the number of partitions by number.
 func (d *ddl) CoalescePartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
 	is := d.infoCache.GetLatest()
@@ -3166,19 +3527,14 @@ func (d *ddl) CoalescePartitions(ctx sessionctx.Context, ident ast.Ident, spec *
 	}
 
 	switch meta.Partition.Type {
-	// We don't support coalesce partitions hash type partition now.
-	case model.PartitionTypeHash:
+	// We don't support coalesce partitions hash and key type partition now.
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 		return errors.Trace(ErrUnsupportedCoalescePartition)
 
-	// Key type partition cannot be constructed currently, ignoring it for now.
-	case model.PartitionTypeKey:
-
 	// Coalesce partition can only be used on hash/key partitions.
 	default:
 		return errors.Trace(ErrCoalesceOnlyOnHashPartition)
 	}
-
-	return errors.Trace(err)
 }
 
 func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
@@ -3328,7 +3684,8 @@ func checkTableDefCompatible(source *model.TableInfo, target *model.TableInfo) e
 		source.Collate != target.Collate ||
 		source.ShardRowIDBits != target.ShardRowIDBits ||
 		source.MaxShardRowIDBits != target.MaxShardRowIDBits ||
-		!checkTiFlashReplicaCompatible(source.TiFlashReplica, target.TiFlashReplica) {
+		!checkTiFlashReplicaCompatible(source.TiFlashReplica, target.TiFlashReplica) ||
+		!checkTTLInfoCompatible(source.TTLInfo, target.TTLInfo) {
 		return errors.Trace(ErrTablesDifferentMetadata)
 	}
 	if len(source.Cols()) != len(target.Cols()) {
@@ -3408,6 +3765,10 @@ func checkExchangePartition(pt *model.TableInfo, nt *model.TableInfo) error {
 	if nt.ForeignKeys != nil {
 		return errors.Trace(ErrPartitionExchangeForeignKey.GenWithStackByArgs(nt.Name))
 	}
+	// The rows of the exchanged table are not in the global indexes of the partitioned table.
+	if hasGlobalIndex(pt) {
+		return errors.Trace(errUnsupportedExchangeGlobalIndex)
+	}
 
 	// NOTE: if nt is temporary table, it should be checked
 	return nil
@@ -3437,6 +3798,11 @@ func (d *ddl) ExchangeTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// The rows of KEY partitions are located by hashing the columns, there is no SQL expression to validate
+	// the rows of the table against the partition, so KEY partitions can only be exchanged WITHOUT VALIDATION.
+	if spec.WithValidation && ptMeta.Partition.Type == model.PartitionTypeKey {
+		return errors.Trace(errUnsupportedPartitionType.GenWithStackByArgs(ptMeta.Name.O))
+	}
 
 	partName := spec.PartitionNames[0].L
 
@@ -3519,6 +3885,21 @@ func (d *ddl) DropColumns(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alt
 	if err != nil {
 		return errors.Trace(err)
 	}
+	job, err := newDropColumnsJob(ctx, schema, t, specs)
+	if err != nil || job == nil {
+		return errors.Trace(err)
+	}
+
+	err = d.doDDLJob(ctx, job)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// newDropColumnsJob builds the job to drop the columns of the specs. It returns nil if there is no column to drop.
+func newDropColumnsJob(ctx sessionctx.Context, schema *model.DBInfo, t table.Table, specs []*ast.AlterTableSpec) (*model.Job, error) {
 	tblInfo := t.Meta()
 
 	dropingColumnNames := make(map[string]bool)
@@ -3531,7 +3912,7 @@ func (d *ddl) DropColumns(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alt
 				dupColumnNames[spec.OldColumnName.Name.L] = true
 				continue
 			}
-			return errors.Trace(ErrCantDropFieldOrKey.GenWithStack("column %s doesn't exist", spec.OldColumnName.Name.O))
+			return nil, errors.Trace(ErrCantDropFieldOrKey.GenWithStack("column %s doesn't exist", spec.OldColumnName.Name.O))
 		}
 	}
 
@@ -3539,13 +3920,13 @@ func (d *ddl) DropColumns(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alt
 	colNames := make([]model.CIStr, 0, len(specs))
 	for _, spec := range specs {
 		if spec.IfExists && dupColumnNames[spec.OldColumnName.Name.L] {
-			err = ErrCantDropFieldOrKey.GenWithStack("column %s doesn't exist", spec.OldColumnName.Name.L)
+			err := ErrCantDropFieldOrKey.GenWithStack("column %s doesn't exist", spec.OldColumnName.Name.L)
 			ctx.GetSessionVars().StmtCtx.AppendNote(err)
 			continue
 		}
 		isDropable, err := checkIsDroppableColumn(ctx, t, spec)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		// Column can't drop and if_exists flag is true.
 		if !isDropable && spec.IfExists {
@@ -3555,15 +3936,14 @@ func (d *ddl) DropColumns(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alt
 		ifExists = append(ifExists, spec.IfExists)
 	}
 	if len(colNames) == 0 {
-		return nil
+		return nil, nil
 	}
 	if len(tblInfo.Columns) == len(colNames) {
-		return ErrCantRemoveAllFields.GenWithStack("can't drop all columns in table %s",
+		return nil, ErrCantRemoveAllFields.GenWithStack("can't drop all columns in table %s",
 			tblInfo.Name)
 	}
-	err = checkDropVisibleColumnCnt(t, len(colNames))
-	if err != nil {
-		return err
+	if err := checkDropVisibleColumnCnt(t, len(colNames)); err != nil {
+		return nil, err
 	}
 	var multiSchemaInfo *model.MultiSchemaInfo
 	if ctx.GetSessionVars().EnableChangeMultiSchema {
@@ -3579,13 +3959,7 @@ func (d *ddl) DropColumns(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alt
 		MultiSchemaInfo: multiSchemaInfo,
 		Args:            []interface{}{colNames, ifExists},
 	}
-
-	err = d.doDDLJob(ctx, job)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	err = d.callHookOnChanged(err)
-	return errors.Trace(err)
+	return job, nil
 }
 
 func checkIsDroppableColumn(ctx sessionctx.Context, t table.Table, spec *ast.AlterTableSpec) (isDrapable bool, err error) {
@@ -3682,6 +4056,12 @@ func CheckModifyTypeCompatible(origin *types.FieldType, to *types.FieldType) (ca
 			}
 		}
 
+		if origin.Tp == mysql.TypeGeometry && origin.GeomType != to.GeomType && to.GeomType != mysql.GeomTypeGeometry {
+			// The values are checked against the new geometry type by reorg.
+			msg := fmt.Sprintf("geometry type change from %s to %s", origin.CompactStr(), to.CompactStr())
+			return true, errUnsupportedModifyColumn.GenWithStackByArgs(msg)
+		}
+
 		if origin.Tp == mysql.TypeNewDecimal {
 			// Floating-point and fixed-point types also can be UNSIGNED. As with integer types, this attribute prevents
 			// negative values from being stored in the column. Unlike the integer types, the upper range of column values
@@ -3755,6 +4135,11 @@ func needReorgToChange(origin *types.FieldType, to *types.FieldType) (needReorg
 }
 
 func checkTypeChangeSupported(origin *types.FieldType, to *types.FieldType) bool {
+	if (origin.Tp == mysql.TypeGeometry && !types.IsString(to.Tp)) || (to.Tp == mysql.TypeGeometry && !types.IsString(origin.Tp)) {
+		// The geometries are only converted from and to the strings.
+		return false
+	}
+
 	if (types.IsTypeTime(origin.Tp) || origin.Tp == mysql.TypeDuration || origin.Tp == mysql.TypeYear ||
 		types.IsString(origin.Tp) || origin.Tp == mysql.TypeJSON) &&
 		to.Tp == mysql.TypeBit {
@@ -3984,6 +4369,9 @@ func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Contex
 		if c != nil {
 			return nil, infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
 		}
+		if constrs := findDependentCheckConstraints(t.Meta(), originalColName); len(constrs) > 0 {
+			return nil, ErrDependentByCheckConstraint.GenWithStackByArgs(constrs[0].Name, originalColName)
+		}
 	}
 
 	// Constraints in the new column means adding new constraints. Errors should thrown,
@@ -4347,6 +4735,9 @@ func (d *ddl) RenameColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.Al
 	if fkInfo := getColumnForeignKeyInfo(oldColName.L, tbl.Meta().ForeignKeys); fkInfo != nil {
 		return errFKIncompatibleColumns.GenWithStackByArgs(oldColName, fkInfo.Name)
 	}
+	if constrs := findDependentCheckConstraints(tbl.Meta(), oldColName); len(constrs) > 0 {
+		return ErrDependentByCheckConstraint.GenWithStackByArgs(constrs[0].Name, oldColName)
+	}
 
 	// Check generated expression.
 	for _, col := range allCols {
@@ -4496,6 +4887,157 @@ func (d *ddl) AlterTableComment(ctx sessionctx.Context, ident ast.Ident, spec *a
 	return errors.Trace(err)
 }
 
+// AlterTableTTL sets the TTL definition of the table, or removes it if the definition is nil.
+func (d *ddl) AlterTableTTL(ctx sessionctx.Context, ident ast.Ident, ttlInfo *model.TTLInfo) error {
+	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := tb.Meta()
+	if tblInfo.IsView() || tblInfo.IsSequence() {
+		return ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "BASE TABLE")
+	}
+	if tblInfo.TempTableType != model.TempTableNone {
+		return ErrOptOnTemporaryTable.GenWithStackByArgs("ttl")
+	}
+	if ttlInfo != nil {
+		if err = checkTTLColumn(tblInfo, ttlInfo.ColumnName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAlterTTLInfo,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{ttlInfo},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// alterTableTTLOptions applies the TTL and TTL_ENABLE options to the current TTL definition of the table.
+func (d *ddl) alterTableTTLOptions(ctx sessionctx.Context, ident ast.Ident, options []*ast.TableOption) error {
+	_, tb, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ttlInfo, err := buildTTLInfo(tb.Meta().TTLInfo, options)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return d.AlterTableTTL(ctx, ident, ttlInfo)
+}
+
+// AlterTableCache caches the data of the table in the memory of TiDBs.
+func (d *ddl) AlterTableCache(ctx sessionctx.Context, ident ast.Ident) error {
+	return d.alterTableCacheStatus(ctx, ident, model.ActionAlterCacheTable)
+}
+
+// AlterTableNoCache stops caching the data of the table.
+func (d *ddl) AlterTableNoCache(ctx sessionctx.Context, ident ast.Ident) error {
+	return d.alterTableCacheStatus(ctx, ident, model.ActionAlterNoCacheTable)
+}
+
+func (d *ddl) alterTableCacheStatus(ctx sessionctx.Context, ident ast.Ident, tp model.ActionType) error {
+	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := tb.Meta()
+	if tblInfo.IsView() || tblInfo.IsSequence() {
+		return ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "BASE TABLE")
+	}
+	if tp == model.ActionAlterCacheTable {
+		if err = checkCacheTable(tblInfo); err != nil {
+			return errors.Trace(err)
+		}
+	} else if _, ok := tb.(table.CachedTable); !ok {
+		return nil
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       tp,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// CreateResourceGroup creates a resource group.
+func (d *ddl) CreateResourceGroup(ctx sessionctx.Context, stmt *ast.CreateResourceGroupStmt) error {
+	if err := checkResourceGroupName(stmt.ResourceGroupName); err != nil {
+		return errors.Trace(err)
+	}
+	group := buildResourceGroup(&resourcegroup.GroupInfo{Name: stmt.ResourceGroupName}, stmt.ResourceGroupOptions)
+	job := &model.Job{
+		SchemaName: group.Name.L,
+		Type:       model.ActionCreateResourceGroup,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{group},
+	}
+	err := d.doDDLJob(ctx, job)
+	if stmt.IfNotExists && ErrResourceGroupExists.Equal(err) {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	if err == nil {
+		appendPerInstanceQuotaNote(ctx, stmt.ResourceGroupOptions)
+	}
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// AlterResourceGroup changes the quota of a resource group, the options which aren't specified are kept.
+func (d *ddl) AlterResourceGroup(ctx sessionctx.Context, stmt *ast.AlterResourceGroupStmt) error {
+	if err := checkResourceGroupName(stmt.ResourceGroupName); err != nil {
+		return errors.Trace(err)
+	}
+	job := &model.Job{
+		SchemaName: stmt.ResourceGroupName.L,
+		Type:       model.ActionAlterResourceGroup,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{stmt.ResourceGroupName, stmt.ResourceGroupOptions},
+	}
+	err := d.doDDLJob(ctx, job)
+	if err == nil {
+		appendPerInstanceQuotaNote(ctx, stmt.ResourceGroupOptions)
+	}
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// DropResourceGroup drops a resource group, the sessions bound to it are moved to the default group.
+func (d *ddl) DropResourceGroup(ctx sessionctx.Context, stmt *ast.DropResourceGroupStmt) error {
+	name := stmt.ResourceGroupName
+	if err := checkResourceGroupName(name); err != nil {
+		return errors.Trace(err)
+	}
+	job := &model.Job{
+		SchemaName: name.L,
+		Type:       model.ActionDropResourceGroup,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{name},
+	}
+	err := d.doDDLJob(ctx, job)
+	if stmt.IfExists && ErrResourceGroupNotExists.Equal(err) {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 // AlterTableAutoIDCache updates the table comment information.
 func (d *ddl) AlterTableAutoIDCache(ctx sessionctx.Context, ident ast.Ident, newCache int64) error {
 	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ident)
@@ -4894,6 +5436,9 @@ func (d *ddl) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	if tb.Meta().IsView() || tb.Meta().IsSequence() {
 		return infoschema.ErrTableNotExists.GenWithStackByArgs(schema.Name.O, tb.Meta().Name.O)
 	}
+	if _, ok := tb.(table.CachedTable); ok {
+		return ErrOptOnCacheTable.GenWithStackByArgs("Truncate Table")
+	}
 	genIDs, err := d.genGlobalIDs(1)
 	if err != nil {
 		return errors.Trace(err)
@@ -5263,15 +5808,44 @@ func buildHiddenColumnInfo(ctx sessionctx.Context, indexPartSpecifications []*as
 
 func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
-	// not support Spatial and FullText index
-	if keyType == ast.IndexKeyTypeFullText || keyType == ast.IndexKeyTypeSpatial {
-		return errUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
-	}
-	unique := keyType == ast.IndexKeyTypeUnique
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if _, ok := t.(table.CachedTable); ok {
+		return ErrOptOnCacheTable.GenWithStackByArgs("Create Index")
+	}
+	job, err := newCreateIndexJob(ctx, schema, t, keyType, indexName, indexPartSpecifications, indexOption, ifNotExists)
+	if err != nil || job == nil {
+		return err
+	}
+
+	err = d.doDDLJob(ctx, job)
+	// key exists, but if_not_exists flags is true, so we ignore this error.
+	if ErrDupKeyName.Equal(err) && ifNotExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// newCreateIndexJob builds the job to add the index. It returns nil if the index exists and ifNotExists is true,
+// or the index is a SPATIAL index.
+func newCreateIndexJob(ctx sessionctx.Context, schema *model.DBInfo, t table.Table, keyType ast.IndexKeyType, indexName model.CIStr,
+	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) (*model.Job, error) {
+	// not support FullText index
+	if keyType == ast.IndexKeyTypeFullText {
+		return nil, errUnsupportedIndexType.GenWithStack("FULLTEXT index is not supported")
+	}
+	// The SPATIAL index is ignored with a warning, so the schemas of MySQL can be imported. The spatial functions
+	// scan the rows instead.
+	if keyType == ast.IndexKeyTypeSpatial {
+		ctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleSpkeys)
+		return nil, nil
+	}
+	unique := keyType == ast.IndexKeyTypeUnique
+	var err error
 
 	// Deal with anonymous index.
 	if len(indexName.L) == 0 {
@@ -5293,13 +5867,13 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 		}
 		if ifNotExists {
 			ctx.GetSessionVars().StmtCtx.AppendNote(err)
-			return nil
+			return nil, nil
 		}
-		return err
+		return nil, err
 	}
 
 	if err = checkTooLongIndex(indexName); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	tblInfo := t.Meta()
@@ -5307,10 +5881,13 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	// Build hidden columns if necessary.
 	hiddenCols, err := buildHiddenColumnInfo(ctx, indexPartSpecifications, indexName, t.Meta(), t.Cols())
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err = checkMultiValuedIndex(unique, hiddenCols); err != nil {
+		return nil, errors.Trace(err)
 	}
 	if err = checkAddColumnTooManyColumns(len(t.Cols()) + len(hiddenCols)); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	finalColumns := make([]*model.ColumnInfo, len(tblInfo.Columns), len(tblInfo.Columns)+len(hiddenCols))
@@ -5324,7 +5901,7 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	// For same reason, decide whether index is global here.
 	indexColumns, err := buildIndexColumns(finalColumns, indexPartSpecifications)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	if !unique && tblInfo.IsCommonHandle {
@@ -5332,14 +5909,14 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 		var pkLen, idxLen int
 		pkLen, err = indexColumnsLen(tblInfo.Columns, tables.FindPrimaryIndex(tblInfo).Columns)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		idxLen, err = indexColumnsLen(finalColumns, indexColumns)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if pkLen+idxLen > config.GetGlobalConfig().MaxIndexLength {
-			return errTooLongKey.GenWithStackByArgs(config.GetGlobalConfig().MaxIndexLength)
+			return nil, errTooLongKey.GenWithStackByArgs(config.GetGlobalConfig().MaxIndexLength)
 		}
 	}
 
@@ -5347,11 +5924,11 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	if unique && tblInfo.GetPartitionInfo() != nil {
 		ck, err := checkPartitionKeysConstraint(tblInfo.GetPartitionInfo(), indexColumns, tblInfo)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if !ck {
 			if !config.GetGlobalConfig().EnableGlobalIndex {
-				return ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("UNIQUE INDEX")
+				return nil, ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("UNIQUE INDEX")
 			}
 			// index columns does not contain all partition columns, must set global
 			global = true
@@ -5359,7 +5936,7 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	}
 	// May be truncate comment here, when index comment too long and sql_mode is't strict.
 	if _, err = validateCommentLength(ctx.GetSessionVars(), indexName.String(), indexOption); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 	job := &model.Job{
 		SchemaID:   schema.ID,
@@ -5375,15 +5952,7 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 		Args:     []interface{}{unique, indexName, indexPartSpecifications, indexOption, hiddenCols, global},
 		Priority: ctx.GetSessionVars().DDLReorgPriority,
 	}
-
-	err = d.doDDLJob(ctx, job)
-	// key exists, but if_not_exists flags is true, so we ignore this error.
-	if ErrDupKeyName.Equal(err) && ifNotExists {
-		ctx.GetSessionVars().StmtCtx.AppendNote(err)
-		return nil
-	}
-	err = d.callHookOnChanged(err)
-	return errors.Trace(err)
+	return job, nil
 }
 
 func buildFKInfo(fkName model.CIStr, keys []*ast.IndexPartSpecification, refer *ast.ReferenceDef, cols []*table.Column, tbInfo *model.TableInfo) (*model.FKInfo, error) {
@@ -5527,6 +6096,108 @@ func (d *ddl) DropForeignKey(ctx sessionctx.Context, ti ast.Ident, fkName model.
 	return errors.Trace(err)
 }
 
+func (d *ddl) CreateCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constr *ast.Constraint) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := t.Meta()
+
+	existed := make(map[string]struct{}, len(tblInfo.Constraints))
+	for _, constrInfo := range tblInfo.Constraints {
+		if constrInfo.Name.L == strings.ToLower(constr.Name) {
+			return ErrCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+		}
+		existed[constrInfo.Name.L] = struct{}{}
+	}
+	setNameForCheckConstraint(tblInfo.Name.O, constr, existed)
+
+	dependedCols, err := checkCheckConstraint(ctx, tblInfo, constr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	constrInfo, err := buildConstraintInfo(tblInfo, dependedCols, constr, model.StateNone)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAddCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Args:     []interface{}{constrInfo},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) DropCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if t.Meta().FindConstraintInfoByName(constrName.L) == nil {
+		return ErrCheckConstraintNotFound.GenWithStackByArgs(constrName)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constrName},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) AlterCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr, enforced bool) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	constrInfo := t.Meta().FindConstraintInfoByName(constrName.L)
+	if constrInfo == nil {
+		return ErrCheckConstraintNotFound.GenWithStackByArgs(constrName)
+	}
+	if constrInfo.Enforced == enforced {
+		// Nothing to do.
+		return nil
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAlterCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Args:     []interface{}{constrName, enforced},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) DropIndex(ctx sessionctx.Context, ti ast.Ident, indexName model.CIStr, ifExists bool) error {
 	is := d.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ti.Schema)
@@ -5537,6 +6208,9 @@ func (d *ddl) DropIndex(ctx sessionctx.Context, ti ast.Ident, indexName model.CI
 	if err != nil {
 		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ti.Schema, ti.Name))
 	}
+	if _, ok := t.(table.CachedTable); ok {
+		return ErrOptOnCacheTable.GenWithStackByArgs("Drop Index")
+	}
 
 	indexInfo := t.Meta().FindIndexByName(indexName.L)
 
@@ -5589,7 +6263,18 @@ func (d *ddl) DropIndexes(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alt
 	if err != nil {
 		return err
 	}
+	job, err := newDropIndexesJob(schema, t, specs)
+	if err != nil {
+		return err
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
 
+// newDropIndexesJob builds the job to drop the indexes of the specs.
+func newDropIndexesJob(schema *model.DBInfo, t table.Table, specs []*ast.AlterTableSpec) (*model.Job, error) {
 	indexNames := make([]model.CIStr, 0, len(specs))
 	ifExists := make([]bool, 0, len(specs))
 	for _, spec := range specs {
@@ -5604,10 +6289,10 @@ func (d *ddl) DropIndexes(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alt
 		if indexInfo != nil {
 			_, err := checkIsDropPrimaryKey(indexName, indexInfo, t)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			if err := checkDropIndexOnAutoIncrementColumn(t.Meta(), indexInfo); err != nil {
-				return errors.Trace(err)
+				return nil, errors.Trace(err)
 			}
 		}
 
@@ -5623,10 +6308,7 @@ func (d *ddl) DropIndexes(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alt
 		BinlogInfo: &model.HistoryInfo{},
 		Args:       []interface{}{indexNames, ifExists},
 	}
-
-	err = d.doDDLJob(ctx, job)
-	err = d.callHookOnChanged(err)
-	return errors.Trace(err)
+	return job, nil
 }
 
 func checkIsDropPrimaryKey(indexName model.CIStr, indexInfo *model.IndexInfo, t table.Table) (bool, error) {
@@ -5672,7 +6354,7 @@ func isDroppableColumn(multiSchemaChange bool, tblInfo *model.TableInfo, colName
 	if fkInfo := getColumnForeignKeyInfo(colName.L, tblInfo.ForeignKeys); fkInfo != nil {
 		return errFkColumnCannotDrop.GenWithStackByArgs(colName, fkInfo.Name)
 	}
-	return nil
+	return checkDropColumnWithCheckConstraint(tblInfo, colName)
 }
 
 // validateCommentLength checks comment length of table, column, index and partition.
@@ -327,14 +327,80 @@ func (d *ddl) getHistoryDDLJob(id int64) (*model.Job, error) {
 	return job, errors.Trace(err)
 }
 
-// getFirstDDLJob gets the first DDL job form DDL queue.
-func (w *worker) getFirstDDLJob(t *meta.Meta) (*model.Job, error) {
-	job, err := t.GetDDLJobByIdx(0)
-	return job, errors.Trace(err)
+// getRunnableDDLJob gets the first runnable job in the DDL queue and its index in the queue. The jobs which are
+// skipped don't block the jobs behind them, except the ones depending on them, see isSkippedDDLJob. The job whose
+// reorganization is running in the worker goes first, since the worker runs one reorganization at a time. If that
+// job is paused, it's returned as pausedJob without any job to run, its reorganization should be stopped first.
+func (w *worker) getRunnableDDLJob(t *meta.Meta) (job *model.Job, idx int64, pausedJob *model.Job, err error) {
+	jobs, err := t.GetAllDDLJobsInQueue()
+	if err != nil {
+		return nil, 0, nil, errors.Trace(err)
+	}
+	skippedJobs := make([]*model.Job, 0, len(jobs))
+	// The jobs are got from the tail to the head of the queue.
+	for i := len(jobs) - 1; i >= 0; i-- {
+		queueJob := jobs[i]
+		paused, err := t.IsDDLJobPaused(queueJob.ID)
+		if err != nil {
+			return nil, 0, nil, errors.Trace(err)
+		}
+		isReorgJob := w.reorgCtx.doneCh != nil && w.reorgCtx.jobID == queueJob.ID
+		if paused && isReorgJob {
+			return nil, 0, queueJob, nil
+		}
+		skipped, err := isSkippedDDLJob(t, queueJob, paused, skippedJobs)
+		if err != nil {
+			return nil, 0, nil, errors.Trace(err)
+		}
+		if skipped {
+			skippedJobs = append(skippedJobs, queueJob)
+			continue
+		}
+		if job == nil || isReorgJob {
+			job, idx = queueJob, int64(len(jobs)-1-i)
+		}
+		// Keep looking for the job whose reorganization is running behind the first runnable job.
+		if w.reorgCtx.doneCh == nil || isReorgJob {
+			break
+		}
+	}
+	return job, idx, nil, nil
+}
+
+// isSkippedDDLJob checks whether the job can't run now, because it's paused, it's waiting for the job in the other
+// queue, or it depends on the job ahead of it which is skipped, so the jobs of the same table still run in order.
+func isSkippedDDLJob(t *meta.Meta, job *model.Job, paused bool, skippedJobs []*model.Job) (bool, error) {
+	if paused {
+		return true, nil
+	}
+	if isDone, err := isDependencyJobDone(t, job); err != nil || !isDone {
+		return true, errors.Trace(err)
+	}
+	for _, skippedJob := range skippedJobs {
+		if dependent, err := job.IsDependentOn(skippedJob); err != nil || dependent {
+			return true, errors.Trace(err)
+		}
+	}
+	return false, nil
+}
+
+// getDDLJobByID gets the job in the DDL queue and its index in the queue, it returns nil if the job isn't found.
+func getDDLJobByID(t *meta.Meta, id int64) (*model.Job, int64, error) {
+	jobs, err := t.GetAllDDLJobsInQueue()
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	// The jobs are got from the tail to the head of the queue.
+	for i, job := range jobs {
+		if job.ID == id {
+			return job, int64(len(jobs) - 1 - i), nil
+		}
+	}
+	return nil, 0, nil
 }
 
 // handleUpdateJobError handles the too large DDL job.
-func (w *worker) handleUpdateJobError(t *meta.Meta, job *model.Job, err error) error {
+func (w *worker) handleUpdateJobError(t *meta.Meta, idx int64, job *model.Job, err error) error {
 	if err == nil {
 		return nil
 	}
@@ -346,14 +412,14 @@ func (w *worker) handleUpdateJobError(t *meta.Meta, job *model.Job, err error) e
 		job.ErrorCount++
 		job.SchemaState = model.StateNone
 		job.State = model.JobStateCancelled
-		err = w.finishDDLJob(t, job)
+		err = w.finishDDLJob(t, idx, job)
 	}
 	return errors.Trace(err)
 }
 
-// updateDDLJob updates the DDL job information.
+// updateDDLJob updates the DDL job information, idx is the index of the job in the DDL queue.
 // Every time we enter another state except final state, we must call this function.
-func (w *worker) updateDDLJob(t *meta.Meta, job *model.Job, meetErr bool) error {
+func (w *worker) updateDDLJob(t *meta.Meta, idx int64, job *model.Job, meetErr bool) error {
 	failpoint.Inject("mockErrEntrySizeTooLarge", func(val failpoint.Value) {
 		if val.(bool) {
 			failpoint.Return(kv.ErrEntryTooLarge)
@@ -367,7 +433,7 @@ func (w *worker) updateDDLJob(t *meta.Meta, job *model.Job, meetErr bool) error
 			zap.String("job", job.String()))
 		updateRawArgs = false
 	}
-	return errors.Trace(t.UpdateDDLJob(0, job, updateRawArgs))
+	return errors.Trace(t.UpdateDDLJob(idx, job, updateRawArgs))
 }
 
 func (w *worker) deleteRange(ctx context.Context, job *model.Job) error {
@@ -380,9 +446,10 @@ func (w *worker) deleteRange(ctx context.Context, job *model.Job) error {
 	return errors.Trace(err)
 }
 
-// finishDDLJob deletes the finished DDL job in the ddl queue and puts it to history queue.
+// finishDDLJob deletes the finished DDL job in the ddl queue and puts it to history queue, idx is the index of
+// the job in the DDL queue.
 // If the DDL job need to handle in background, it will prepare a background job.
-func (w *worker) finishDDLJob(t *meta.Meta, job *model.Job) (err error) {
+func (w *worker) finishDDLJob(t *meta.Meta, idx int64, job *model.Job) (err error) {
 	startTime := time.Now()
 	defer func() {
 		metrics.DDLWorkerHistogram.WithLabelValues(metrics.WorkerFinishDDLJob, job.Type.String(), metrics.RetLabel(err)).Observe(time.Since(startTime).Seconds())
@@ -398,7 +465,8 @@ func (w *worker) finishDDLJob(t *meta.Meta, job *model.Job) (err error) {
 			// After rolling back an AddIndex operation, we need to use delete-range to delete the half-done index data.
 			err = w.deleteRange(w.ddlJobCtx, job)
 		case model.ActionDropSchema, model.ActionDropTable, model.ActionTruncateTable, model.ActionDropIndex, model.ActionDropPrimaryKey,
-			model.ActionDropTablePartition, model.ActionTruncateTablePartition, model.ActionDropColumn, model.ActionDropColumns, model.ActionModifyColumn, model.ActionDropIndexes:
+			model.ActionDropTablePartition, model.ActionTruncateTablePartition, model.ActionDropColumn, model.ActionDropColumns, model.ActionModifyColumn, model.ActionDropIndexes,
+			model.ActionReorganizePartition, model.ActionMultiSchemaChange:
 			err = w.deleteRange(w.ddlJobCtx, job)
 		}
 	}
@@ -409,8 +477,10 @@ func (w *worker) finishDDLJob(t *meta.Meta, job *model.Job) (err error) {
 		return errors.Trace(err)
 	}
 
-	_, err = t.DeQueueDDLJob()
-	if err != nil {
+	if err = t.RemoveDDLJobByIdx(idx); err != nil {
+		return errors.Trace(err)
+	}
+	if err = t.RemoveDDLJobPaused(job.ID); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -491,10 +561,14 @@ func (w *worker) handleDDLJobQueue(d *ddlCtx) error {
 
 		var (
 			job       *model.Job
+			jobIdx    int64
+			pausedJob *model.Job
 			schemaVer int64
 			runJobErr error
 		)
 		waitTime := 2 * d.lease
+		// Wait for the transactions using the tables before changing their schema.
+		w.waitMetadataLock(d)
 		err := kv.RunInNewTxn(context.Background(), d.store, false, func(ctx context.Context, txn kv.Transaction) error {
 			// We are not owner, return and retry checking later.
 			if !d.isOwner() {
@@ -503,8 +577,8 @@ func (w *worker) handleDDLJobQueue(d *ddlCtx) error {
 
 			var err error
 			t := newMetaWithQueueTp(txn, w.tp)
-			// We become the owner. Get the first job and run it.
-			job, err = w.getFirstDDLJob(t)
+			// We become the owner. Get the first runnable job and run it.
+			job, jobIdx, pausedJob, err = w.getRunnableDDLJob(t)
 			if job == nil || err != nil {
 				return errors.Trace(err)
 			}
@@ -515,9 +589,6 @@ func (w *worker) handleDDLJobQueue(d *ddlCtx) error {
 			}
 
 			w.setDDLLabelForTopSQL(job)
-			if isDone, err1 := isDependencyJobDone(t, job); err1 != nil || !isDone {
-				return errors.Trace(err1)
-			}
 
 			if once {
 				w.waitSchemaSynced(d, job, waitTime)
@@ -529,7 +600,7 @@ func (w *worker) handleDDLJobQueue(d *ddlCtx) error {
 				if !job.IsRollbackDone() {
 					job.State = model.JobStateSynced
 				}
-				err = w.finishDDLJob(t, job)
+				err = w.finishDDLJob(t, jobIdx, job)
 				return errors.Trace(err)
 			}
 
@@ -542,7 +613,7 @@ func (w *worker) handleDDLJobQueue(d *ddlCtx) error {
 			schemaVer, runJobErr = w.runDDLJob(d, t, job)
 			if job.IsCancelled() {
 				txn.Reset()
-				err = w.finishDDLJob(t, job)
+				err = w.finishDDLJob(t, jobIdx, job)
 				return errors.Trace(err)
 			}
 			if runJobErr != nil && !job.IsRollingback() && !job.IsRollbackDone() {
@@ -557,13 +628,16 @@ func (w *worker) handleDDLJobQueue(d *ddlCtx) error {
 				// Result in the retry duration is up to 2 * lease.
 				schemaVer = 0
 			}
-			err = w.updateDDLJob(t, job, runJobErr != nil)
-			if err = w.handleUpdateJobError(t, job, err); err != nil {
+			err = w.updateDDLJob(t, jobIdx, job, runJobErr != nil)
+			if err = w.handleUpdateJobError(t, jobIdx, job, err); err != nil {
 				return errors.Trace(err)
 			}
 			writeBinlog(d.binlogCli, txn, job)
 			return nil
 		})
+		if err == nil && pausedJob != nil {
+			err = w.stopPausedReorg(d, pausedJob)
+		}
 
 		if runJobErr != nil {
 			// wait a while to retry again. If we don't wait here, DDL will retry this job immediately,
@@ -755,13 +829,13 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 	case model.ActionDropTablePartition:
 		ver, err = w.onDropTablePartition(d, t, job)
 	case model.ActionTruncateTablePartition:
-		ver, err = onTruncateTablePartition(d, t, job)
+		ver, err = w.onTruncateTablePartition(d, t, job)
 	case model.ActionExchangeTablePartition:
 		ver, err = w.onExchangeTablePartition(d, t, job)
 	case model.ActionAddColumn:
-		ver, err = onAddColumn(d, t, job)
+		ver, err = w.onAddColumn(d, t, job)
 	case model.ActionAddColumns:
-		ver, err = onAddColumns(d, t, job)
+		ver, err = w.onAddColumns(d, t, job, false)
 	case model.ActionDropColumn:
 		ver, err = onDropColumn(t, job)
 	case model.ActionDropColumns:
@@ -784,6 +858,12 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onCreateForeignKey(t, job)
 	case model.ActionDropForeignKey:
 		ver, err = onDropForeignKey(t, job)
+	case model.ActionAddCheckConstraint:
+		ver, err = w.onAddCheckConstraint(d, t, job)
+	case model.ActionDropCheckConstraint:
+		ver, err = onDropCheckConstraint(t, job)
+	case model.ActionAlterCheckConstraint:
+		ver, err = w.onAlterCheckConstraint(d, t, job)
 	case model.ActionTruncateTable:
 		ver, err = onTruncateTable(d, t, job)
 	case model.ActionRebaseAutoID:
@@ -800,6 +880,22 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onModifyTableAutoIDCache(t, job)
 	case model.ActionAddTablePartition:
 		ver, err = w.onAddTablePartition(d, t, job)
+	case model.ActionReorganizePartition:
+		ver, err = w.onReorganizePartition(d, t, job)
+	case model.ActionMultiSchemaChange:
+		ver, err = w.onMultiSchemaChange(d, t, job)
+	case model.ActionAlterTTLInfo:
+		ver, err = onAlterTableTTL(t, job)
+	case model.ActionAlterCacheTable:
+		ver, err = onAlterCacheTable(t, job)
+	case model.ActionAlterNoCacheTable:
+		ver, err = onAlterNoCacheTable(t, job)
+	case model.ActionCreateResourceGroup:
+		ver, err = onCreateResourceGroup(t, job)
+	case model.ActionAlterResourceGroup:
+		ver, err = onAlterResourceGroup(t, job)
+	case model.ActionDropResourceGroup:
+		ver, err = onDropResourceGroup(t, job)
 	case model.ActionModifyTableCharsetAndCollate:
 		ver, err = onModifyTableCharsetAndCollate(t, job)
 	case model.ActionRecoverTable:
@@ -292,12 +292,12 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, sctx sessionctx.Context,
 					return errors.Trace(err)
 				}
 			}
-			return nil
+			// The global indexes of a partitioned table are stored under the logical table ID.
 		}
 		startKey = tablecodec.EncodeTablePrefix(tableID)
 		endKey := tablecodec.EncodeTablePrefix(tableID + 1)
 		return doInsert(ctx, s, job.ID, tableID, startKey, endKey, now)
-	case model.ActionDropTablePartition, model.ActionTruncateTablePartition:
+	case model.ActionDropTablePartition, model.ActionTruncateTablePartition, model.ActionReorganizePartition:
 		var physicalTableIDs []int64
 		if err := job.DecodeArgs(&physicalTableIDs); err != nil {
 			return errors.Trace(err)
@@ -406,6 +406,22 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, sctx sessionctx.Context,
 				return doBatchDeleteIndiceRange(ctx, s, job.ID, job.TableID, indexIDs, now)
 			}
 		}
+	case model.ActionMultiSchemaChange:
+		var subJobs []*subJob
+		if err := job.DecodeArgs(&subJobs); err != nil {
+			return errors.Trace(err)
+		}
+		for _, sub := range subJobs {
+			// Only the dropped columns and indexes, and the indexes which are rolled back, need to be deleted.
+			dropped := (sub.Type == model.ActionDropColumns || sub.Type == model.ActionDropIndexes) && sub.State == model.JobStateDone
+			rolledBack := sub.Type == model.ActionAddIndex && sub.State == model.JobStateRollbackDone
+			if !dropped && !rolledBack {
+				continue
+			}
+			if err := insertJobIntoDeleteRangeTable(ctx, sctx, sub.toProxyJob(job)); err != nil {
+				return errors.Trace(err)
+			}
+		}
 	case model.ActionModifyColumn:
 		var indexIDs []int64
 		var partitionIDs []int64
@@ -30,6 +30,7 @@ var (
 	errCantDecodeRecord      = dbterror.ClassDDL.NewStd(mysql.ErrCantDecodeRecord)
 	errInvalidDDLJob         = dbterror.ClassDDL.NewStd(mysql.ErrInvalidDDLJob)
 	errCancelledDDLJob       = dbterror.ClassDDL.NewStd(mysql.ErrCancelledDDLJob)
+	errPausedDDLJob          = dbterror.ClassDDL.NewStd(mysql.ErrPausedDDLJob)
 	errFileNotFound          = dbterror.ClassDDL.NewStd(mysql.ErrFileNotFound)
 	errRunMultiSchemaChanges = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "multi schema change"), nil))
 	errWaitReorgTimeout      = dbterror.ClassDDL.NewStdErr(mysql.ErrLockWaitTimeout, mysql.MySQLErrName[mysql.ErrWaitReorgTimeout])
@@ -98,6 +99,7 @@ var (
 	errUnsupportedRebuildPartition    = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "rebuild partition"), nil))
 	errUnsupportedRemovePartition     = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "remove partitioning"), nil))
 	errUnsupportedRepairPartition     = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "repair partition"), nil))
+	errUnsupportedExchangeGlobalIndex = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "exchange partition of a table with global indexes"), nil))
 	// ErrGeneratedColumnFunctionIsNotAllowed returns for unsupported functions for generated columns.
 	ErrGeneratedColumnFunctionIsNotAllowed = dbterror.ClassDDL.NewStd(mysql.ErrGeneratedColumnFunctionIsNotAllowed)
 	// ErrGeneratedColumnRowValueIsNotAllowed returns for generated columns referring to row values.
@@ -176,6 +178,12 @@ var (
 	ErrRangeNotIncreasing = dbterror.ClassDDL.NewStd(mysql.ErrRangeNotIncreasing)
 	// ErrPartitionMaxvalue returns maxvalue can only be used in last partition definition.
 	ErrPartitionMaxvalue = dbterror.ClassDDL.NewStd(mysql.ErrPartitionMaxvalue)
+	// ErrReorgNoParam returns reorganize partition without parameters can only be used on hash partitioned tables.
+	ErrReorgNoParam = dbterror.ClassDDL.NewStd(mysql.ErrReorgNoParam)
+	// ErrConsecutiveReorgPartitions returns the reorganized range partitions are not consecutive.
+	ErrConsecutiveReorgPartitions = dbterror.ClassDDL.NewStd(mysql.ErrConsecutiveReorgPartitions)
+	// ErrReorgOutsideRange returns the reorganized range partitions change the total range.
+	ErrReorgOutsideRange = dbterror.ClassDDL.NewStd(mysql.ErrReorgOutsideRange)
 	// ErrDropLastPartition returns cannot remove all partitions, use drop table instead.
 	ErrDropLastPartition = dbterror.ClassDDL.NewStd(mysql.ErrDropLastPartition)
 	// ErrTooManyPartitions returns too many partitions were defined.
@@ -202,8 +210,14 @@ var (
 	ErrWrongObject = dbterror.ClassDDL.NewStd(mysql.ErrWrongObject)
 	// ErrTableCantHandleFt returns FULLTEXT keys are not supported by table type
 	ErrTableCantHandleFt = dbterror.ClassDDL.NewStd(mysql.ErrTableCantHandleFt)
+	// ErrTableCantHandleSpkeys returns SPATIAL keys are not supported by table type
+	ErrTableCantHandleSpkeys = dbterror.ClassDDL.NewStd(mysql.ErrTableCantHandleSpkeys)
 	// ErrFieldNotFoundPart returns an error when 'partition by columns' are not found in table columns.
 	ErrFieldNotFoundPart = dbterror.ClassDDL.NewStd(mysql.ErrFieldNotFoundPart)
+	// ErrBlobFieldInPartFunc returns an error when a BLOB, TEXT or JSON column is used in 'partition by key'.
+	ErrBlobFieldInPartFunc = dbterror.ClassDDL.NewStd(mysql.ErrBlobFieldInPartFunc)
+	// ErrSameNamePartitionField returns duplicate partition field name error.
+	ErrSameNamePartitionField = dbterror.ClassDDL.NewStd(mysql.ErrSameNamePartitionField)
 	// ErrWrongTypeColumnValue returns 'Partition column values of incorrect type'
 	ErrWrongTypeColumnValue = dbterror.ClassDDL.NewStd(mysql.ErrWrongTypeColumnValue)
 	// ErrValuesIsNotIntType returns 'VALUES value for partition '%-.64s' must have type INT'
@@ -216,6 +230,28 @@ var (
 	ErrInvalidAutoRandom = dbterror.ClassDDL.NewStd(mysql.ErrInvalidAutoRandom)
 	// ErrUnsupportedConstraintCheck returns when use ADD CONSTRAINT CHECK
 	ErrUnsupportedConstraintCheck = dbterror.ClassDDL.NewStd(mysql.ErrUnsupportedConstraintCheck)
+	// ErrColumnCheckConstraintReferencesOtherColumn returns when a column check constraint refers to other columns.
+	ErrColumnCheckConstraintReferencesOtherColumn = dbterror.ClassDDL.NewStd(mysql.ErrColumnCheckConstraintReferencesOtherColumn)
+	// ErrCheckConstraintNamedFunctionIsNotAllowed returns when a check constraint uses a disallowed function.
+	ErrCheckConstraintNamedFunctionIsNotAllowed = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintNamedFunctionIsNotAllowed)
+	// ErrCheckConstraintFunctionIsNotAllowed returns when a check constraint uses a disallowed expression, e.g. a subquery.
+	ErrCheckConstraintFunctionIsNotAllowed = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintFunctionIsNotAllowed)
+	// ErrCheckConstraintVariables returns when a check constraint refers to a user or system variable.
+	ErrCheckConstraintVariables = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintVariables)
+	// ErrCheckConstraintRowValue returns when a check constraint refers to a row value.
+	ErrCheckConstraintRowValue = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintRowValue)
+	// ErrCheckConstraintRefersAutoIncrementColumn returns when a check constraint refers to an auto-increment column.
+	ErrCheckConstraintRefersAutoIncrementColumn = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintRefersAutoIncrementColumn)
+	// ErrCheckConstraintViolated returns when the existing rows violate the added check constraint.
+	ErrCheckConstraintViolated = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintViolated)
+	// ErrCheckConstraintRefersUnknownColumn returns when a check constraint refers to a non-existing column.
+	ErrCheckConstraintRefersUnknownColumn = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintRefersUnknownColumn)
+	// ErrCheckConstraintNotFound returns when the check constraint to drop or alter doesn't exist.
+	ErrCheckConstraintNotFound = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintNotFound)
+	// ErrCheckConstraintDupName returns when the check constraint name is duplicated.
+	ErrCheckConstraintDupName = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintDupName)
+	// ErrDependentByCheckConstraint returns when the dropped or renamed column is used by a check constraint.
+	ErrDependentByCheckConstraint = dbterror.ClassDDL.NewStd(mysql.ErrDependentByCheckConstraint)
 	// ErrDerivedMustHaveAlias returns when a sub select statement does not have a table alias.
 	ErrDerivedMustHaveAlias = dbterror.ClassDDL.NewStd(mysql.ErrDerivedMustHaveAlias)
 
@@ -265,6 +301,26 @@ var (
 	// ErrPlacementPolicyInUse is returned when placement policy is in use in drop/alter.
 	ErrPlacementPolicyInUse = dbterror.ClassDDL.NewStd(mysql.ErrPlacementPolicyInUse)
 
+	// ErrOperateSameColumn is returned when a column is changed by more than one spec of a multi-schema change.
+	ErrOperateSameColumn = dbterror.ClassDDL.NewStd(mysql.ErrOperateSameColumn)
+	// ErrOperateSameIndex is returned when an index is changed by more than one spec of a multi-schema change.
+	ErrOperateSameIndex = dbterror.ClassDDL.NewStd(mysql.ErrOperateSameIndex)
+
+	// ErrUnsupportedColumnInTTLConfig is returned when the TTL column isn't a date or time column.
+	ErrUnsupportedColumnInTTLConfig = dbterror.ClassDDL.NewStd(mysql.ErrUnsupportedColumnInTTLConfig)
+	// ErrTTLColumnCannotDrop is returned when dropping the column used in the TTL definition.
+	ErrTTLColumnCannotDrop = dbterror.ClassDDL.NewStd(mysql.ErrTTLColumnCannotDrop)
+	// ErrOptOnCacheTable is returned when the operation is unsupported on the cached tables.
+	ErrOptOnCacheTable = dbterror.ClassDDL.NewStd(mysql.ErrOptOnCacheTable)
+	// ErrSetTTLOptionForNonTTLTable is returned when setting TTL_ENABLE without the TTL definition.
+	ErrSetTTLOptionForNonTTLTable = dbterror.ClassDDL.NewStd(mysql.ErrSetTTLOptionForNonTTLTable)
+	// ErrInvalidTTLDefinition is returned when the interval or the unit of the TTL definition is unsupported.
+	ErrInvalidTTLDefinition = dbterror.ClassDDL.NewStd(mysql.ErrInvalidTTLDefinition)
+	// ErrResourceGroupExists is returned when creating a resource group which already exists.
+	ErrResourceGroupExists = dbterror.ClassDDL.NewStd(mysql.ErrResourceGroupExists)
+	// ErrResourceGroupNotExists is returned when the resource group doesn't exist.
+	ErrResourceGroupNotExists = dbterror.ClassDDL.NewStd(mysql.ErrResourceGroupNotExists)
+
 	// ErrMultipleDefConstInListPart returns multiple definition of same constant in list partitioning.
 	ErrMultipleDefConstInListPart = dbterror.ClassDDL.NewStd(mysql.ErrMultipleDefConstInListPart)
 
@@ -303,4 +359,8 @@ var (
 	errDependentByFunctionalIndex = dbterror.ClassDDL.NewStd(mysql.ErrDependentByFunctionalIndex)
 	// errFunctionalIndexOnBlob when the expression of expression index returns blob or text.
 	errFunctionalIndexOnBlob = dbterror.ClassDDL.NewStd(mysql.ErrFunctionalIndexOnBlob)
+	// errUnsupportedGeometryIndex returns when a non-SPATIAL index is created on a geometry column.
+	errUnsupportedGeometryIndex = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "index on the geometry column %s"), nil))
+	// errUnsupportedMultiValuedIndex returns when a multi-valued index can't be created.
+	errUnsupportedMultiValuedIndex = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "%s"), nil))
 )
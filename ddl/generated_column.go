@@ -266,6 +266,8 @@ func checkModifyGeneratedColumn(sctx sessionctx.Context, tbl table.Table, oldCol
 
 type illegalFunctionChecker struct {
 	hasIllegalFunc bool
+	illegalFnName  string // illegalFnName records the name of the first blocked built-in function.
+	hasVariable    bool
 	hasAggFunc     bool
 	hasRowVal      bool // hasRowVal checks whether the functional index refers to a row value
 	hasWindowFunc  bool
@@ -279,6 +281,9 @@ func (c *illegalFunctionChecker) Enter(inNode ast.Node) (outNode ast.Node, skipC
 		_, IsFunctionBlocked := expression.IllegalFunctions4GeneratedColumns[node.FnName.L]
 		if IsFunctionBlocked || !expression.IsFunctionSupported(node.FnName.L) {
 			c.hasIllegalFunc = true
+			if IsFunctionBlocked && c.illegalFnName == "" {
+				c.illegalFnName = node.FnName.O
+			}
 			return inNode, true
 		}
 		err := expression.VerifyArgsWrapper(node.FnName.L, len(node.Args))
@@ -286,9 +291,14 @@ func (c *illegalFunctionChecker) Enter(inNode ast.Node) (outNode ast.Node, skipC
 			c.otherErr = err
 			return inNode, true
 		}
-	case *ast.SubqueryExpr, *ast.ValuesExpr, *ast.VariableExpr:
-		// Subquery & `values(x)` & variable is not allowed
+	case *ast.SubqueryExpr, *ast.ValuesExpr:
+		// Subquery & `values(x)` is not allowed
+		c.hasIllegalFunc = true
+		return inNode, true
+	case *ast.VariableExpr:
+		// Variable is not allowed
 		c.hasIllegalFunc = true
+		c.hasVariable = true
 		return inNode, true
 	case *ast.AggregateFuncExpr:
 		// Aggregate function is not allowed
@@ -311,6 +321,7 @@ func (c *illegalFunctionChecker) Leave(inNode ast.Node) (node ast.Node, ok bool)
 const (
 	typeColumn = iota
 	typeIndex
+	typeCheckConstraint
 )
 
 func checkIllegalFn4Generated(name string, genType int, expr ast.ExprNode) error {
@@ -321,6 +332,14 @@ func checkIllegalFn4Generated(name string, genType int, expr ast.ExprNode) error
 	expr.Accept(&c)
 	if c.hasIllegalFunc {
 		switch genType {
+		case typeCheckConstraint:
+			if c.hasVariable {
+				return ErrCheckConstraintVariables.GenWithStackByArgs(name)
+			}
+			if c.illegalFnName != "" {
+				return ErrCheckConstraintNamedFunctionIsNotAllowed.GenWithStackByArgs(name, c.illegalFnName)
+			}
+			return ErrCheckConstraintFunctionIsNotAllowed.GenWithStackByArgs(name)
 		case typeColumn:
 			return ErrGeneratedColumnFunctionIsNotAllowed.GenWithStackByArgs(name)
 		case typeIndex:
@@ -336,6 +355,8 @@ func checkIllegalFn4Generated(name string, genType int, expr ast.ExprNode) error
 			return ErrGeneratedColumnRowValueIsNotAllowed.GenWithStackByArgs(name)
 		case typeIndex:
 			return ErrFunctionalIndexRowValueIsNotAllowed.GenWithStackByArgs(name)
+		case typeCheckConstraint:
+			return ErrCheckConstraintRowValue.GenWithStackByArgs(name)
 		}
 	}
 	if c.hasWindowFunc {
@@ -91,6 +91,24 @@ func buildIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*a
 	return idxParts, nil
 }
 
+// checkMultiValuedIndex checks the index whose hidden columns are hiddenCols. An index is a multi-valued index if
+// one of its key parts is CAST(... AS ... ARRAY).
+func checkMultiValuedIndex(unique bool, hiddenCols []*model.ColumnInfo) error {
+	arrayParts := 0
+	for _, col := range hiddenCols {
+		if col.FieldType.Array {
+			arrayParts++
+		}
+	}
+	if arrayParts > 1 {
+		return errUnsupportedMultiValuedIndex.GenWithStackByArgs("more than one multi-valued key part per index")
+	}
+	if arrayParts == 1 && unique {
+		return errUnsupportedMultiValuedIndex.GenWithStackByArgs("unique multi-valued index")
+	}
+	return nil
+}
+
 func checkPKOnGeneratedColumn(tblInfo *model.TableInfo, indexPartSpecifications []*ast.IndexPartSpecification) (*model.ColumnInfo, error) {
 	var lastCol *model.ColumnInfo
 	for _, colName := range indexPartSpecifications {
@@ -137,6 +155,14 @@ func checkIndexColumn(col *model.ColumnInfo, indexColumnLen int) error {
 		return errors.Trace(errJSONUsedAsKey.GenWithStackByArgs(col.Name.O))
 	}
 
+	// The geometries are only indexed by SPATIAL indexes, which are ignored.
+	if col.FieldType.Tp == mysql.TypeGeometry {
+		if col.Hidden {
+			return errFunctionalIndexOnJSONOrGeometryFunction
+		}
+		return errors.Trace(errUnsupportedGeometryIndex.GenWithStackByArgs(col.Name.O))
+	}
+
 	// Length must be specified and non-zero for BLOB and TEXT column indexes.
 	if types.IsTypeBlob(col.FieldType.Tp) {
 		if indexColumnLen == types.UnspecifiedLength {
@@ -237,6 +263,11 @@ func buildIndexInfo(tblInfo *model.TableInfo, indexName model.CIStr, indexPartSp
 		Columns: idxColumns,
 		State:   state,
 	}
+	for _, idxCol := range idxColumns {
+		if tblInfo.Columns[idxCol.Offset].FieldType.Array {
+			idxInfo.MVIndex = true
+		}
+	}
 	return idxInfo, nil
 }
 
@@ -546,64 +577,81 @@ func (w *worker) onCreateIndex(d *ddlCtx, t *meta.Meta, job *model.Job, isPK boo
 		job.SchemaState = model.StateWriteReorganization
 	case model.StateWriteReorganization:
 		// reorganization -> public
-		tbl, err := getTable(d.store, schemaID, tblInfo)
-		if err != nil {
-			return ver, errors.Trace(err)
+		var done bool
+		done, ver, err = w.doReorgWorkForCreateIndex(d, t, job, tblInfo, indexInfo)
+		if !done {
+			return ver, err
 		}
+		ver, err = finishCreateIndex(t, job, tblInfo, indexInfo, isPK)
+	default:
+		err = ErrInvalidDDLState.GenWithStackByArgs("index", tblInfo.State)
+	}
 
-		elements := []*meta.Element{{ID: indexInfo.ID, TypeKey: meta.IndexElementKey}}
-		reorgInfo, err := getReorgInfo(d, t, job, tbl, elements)
-		if err != nil || reorgInfo.first {
-			// If we run reorg firstly, we should update the job snapshot version
-			// and then run the reorg next time.
-			return ver, errors.Trace(err)
-		}
+	return ver, errors.Trace(err)
+}
 
-		err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (addIndexErr error) {
-			defer util.Recover(metrics.LabelDDL, "onCreateIndex",
-				func() {
-					addIndexErr = errCancelledDDLJob.GenWithStack("add table `%v` index `%v` panic", tblInfo.Name, indexInfo.Name)
-				}, false)
-			return w.addTableIndex(tbl, indexInfo, reorgInfo)
-		})
-		if err != nil {
-			if errWaitReorgTimeout.Equal(err) {
-				// if timeout, we should return, check for the owner and re-wait job done.
-				return ver, nil
-			}
-			if kv.ErrKeyExists.Equal(err) || errCancelledDDLJob.Equal(err) || errCantDecodeRecord.Equal(err) {
-				logutil.BgLogger().Warn("[ddl] run add index job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
-				ver, err = convertAddIdxJob2RollbackJob(t, job, tblInfo, indexInfo, err)
-				if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
-					logutil.BgLogger().Warn("[ddl] run add index job failed, convert job to rollback, RemoveDDLReorgHandle failed", zap.String("job", job.String()), zap.Error(err1))
-				}
+// doReorgWorkForCreateIndex backfills the index in the write reorganization state. The returned bool is true
+// if the backfilling is done. If it fails with an error which can't be retried, the job is converted to rollback.
+func (w *worker) doReorgWorkForCreateIndex(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo,
+	indexInfo *model.IndexInfo) (done bool, ver int64, err error) {
+	tbl, err := getTable(d.store, job.SchemaID, tblInfo)
+	if err != nil {
+		return false, ver, errors.Trace(err)
+	}
+
+	elements := []*meta.Element{{ID: indexInfo.ID, TypeKey: meta.IndexElementKey}}
+	reorgInfo, err := getReorgInfo(d, t, job, tbl, elements)
+	if err != nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, ver, errors.Trace(err)
+	}
+
+	err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (addIndexErr error) {
+		defer util.Recover(metrics.LabelDDL, "onCreateIndex",
+			func() {
+				addIndexErr = errCancelledDDLJob.GenWithStack("add table `%v` index `%v` panic", tblInfo.Name, indexInfo.Name)
+			}, false)
+		return w.addTableIndex(tbl, indexInfo, reorgInfo)
+	})
+	if err != nil {
+		if errWaitReorgTimeout.Equal(err) {
+			// if timeout, we should return, check for the owner and re-wait job done.
+			return false, ver, nil
+		}
+		if kv.ErrKeyExists.Equal(err) || errCancelledDDLJob.Equal(err) || errCantDecodeRecord.Equal(err) {
+			logutil.BgLogger().Warn("[ddl] run add index job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+			ver, err = convertAddIdxJob2RollbackJob(t, job, tblInfo, indexInfo, err)
+			if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+				logutil.BgLogger().Warn("[ddl] run add index job failed, convert job to rollback, RemoveDDLReorgHandle failed", zap.String("job", job.String()), zap.Error(err1))
 			}
-			// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
-			w.reorgCtx.cleanNotifyReorgCancel()
-			return ver, errors.Trace(err)
 		}
 		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
 		w.reorgCtx.cleanNotifyReorgCancel()
+		return false, ver, errors.Trace(err)
+	}
+	// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+	w.reorgCtx.cleanNotifyReorgCancel()
+	return true, ver, nil
+}
 
-		indexInfo.State = model.StatePublic
-		// Set column index flag.
-		addIndexColumnFlag(tblInfo, indexInfo)
-		if isPK {
-			if err = updateColsNull2NotNull(tblInfo, indexInfo); err != nil {
-				return ver, errors.Trace(err)
-			}
-		}
-		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != indexInfo.State)
-		if err != nil {
+// finishCreateIndex makes the backfilled index public and finishes the job.
+func finishCreateIndex(t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, indexInfo *model.IndexInfo, isPK bool) (ver int64, err error) {
+	indexInfo.State = model.StatePublic
+	// Set column index flag.
+	addIndexColumnFlag(tblInfo, indexInfo)
+	if isPK {
+		if err = updateColsNull2NotNull(tblInfo, indexInfo); err != nil {
 			return ver, errors.Trace(err)
 		}
-		// Finish this job.
-		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
-	default:
-		err = ErrInvalidDDLState.GenWithStackByArgs("index", tblInfo.State)
 	}
-
-	return ver, errors.Trace(err)
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
 }
 
 func onDropIndex(t *meta.Meta, job *model.Job) (ver int64, _ error) {
@@ -1077,7 +1125,12 @@ func (w *baseIndexWorker) getIndexRecord(idxInfo *model.IndexInfo, handle kv.Han
 			idxVal[j] = idxColumnVal
 			continue
 		}
-		idxColumnVal, err = tables.GetColDefaultValue(w.sessCtx, col, w.defaultVals)
+		if col.State != model.StatePublic {
+			// The column is added in the same multi-schema change, the old rows take its origin default value.
+			idxColumnVal, err = table.GetColOriginDefaultValue(w.sessCtx, col.ToInfo())
+		} else {
+			idxColumnVal, err = tables.GetColDefaultValue(w.sessCtx, col, w.defaultVals)
+		}
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -1580,8 +1633,9 @@ func (w *worker) updateReorgInfoForPartitions(t table.PartitionedTable, reorg *r
 			if i == len(partitionIDs)-1 {
 				return true, nil
 			}
+			pid = partitionIDs[i+1]
+			break
 		}
-		pid = partitionIDs[i+1]
 	}
 
 	currentVer, err := getValidCurrentVersion(reorg.d.store)
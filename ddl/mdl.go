@@ -0,0 +1,348 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/ddl/util"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	goutil "github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+// mdlCheckInterval is the interval to check whether the transactions blocking a DDL job are finished,
+// and to publish the released metadata locks of this instance.
+var mdlCheckInterval = 50 * time.Millisecond
+
+// mdlHolder is a transaction holding the metadata locks of some tables.
+type mdlHolder struct {
+	connID  uint64
+	startTS uint64
+	sqls    []string
+	// tables maps the table ID to the schema version with which the table is used.
+	tables map[int64]int64
+}
+
+// MDLWait describes a transaction which blocks a DDL job.
+type MDLWait struct {
+	Job     *model.Job
+	ConnID  uint64
+	StartTS uint64
+	SQLs    []string
+}
+
+// MetadataLockManager keeps the tables used by the in-flight transactions of this TiDB instance, and
+// the schema versions with which they are used. It publishes them to the other instances through the
+// schema syncer. Before the DDL owner moves a job to the next schema state, it waits for the transactions
+// of all instances which use the tables of the job with a previous schema version to finish. So a
+// transaction is at most one schema state behind the latest one, which it can commit with.
+type MetadataLockManager struct {
+	syncer util.SchemaSyncer
+	// publishMu makes the metadata locks published in order.
+	publishMu sync.Mutex
+	mu        sync.Mutex
+	holders   map[sessionctx.Context]*mdlHolder
+	// published is the metadata locks seen by the other instances.
+	published util.MDLs
+	// dirty means the holders are changed after the last publishing.
+	dirty bool
+	// waiting is the DDL job waiting for the metadata locks if this instance is the owner.
+	waiting *util.MDLWaitInfo
+}
+
+func newMetadataLockManager(syncer util.SchemaSyncer) *MetadataLockManager {
+	return &MetadataLockManager{
+		syncer:  syncer,
+		holders: make(map[sessionctx.Context]*mdlHolder),
+	}
+}
+
+// Acquire records that the transaction of the session uses the tables with the schema version.
+// If the published metadata locks don't cover the transaction, they are published before the statement
+// runs, so that the owner doesn't miss the transaction. The statement fails if the publishing fails.
+func (m *MetadataLockManager) Acquire(sctx sessionctx.Context, tableIDs map[int64]struct{}, schemaVer int64) error {
+	sessVars := sctx.GetSessionVars()
+	m.mu.Lock()
+	holder, ok := m.holders[sctx]
+	if !ok {
+		holder = &mdlHolder{
+			connID:  sessVars.ConnectionID,
+			startTS: sessVars.TxnCtx.StartTS,
+			tables:  make(map[int64]int64, len(tableIDs)),
+		}
+		m.holders[sctx] = holder
+	}
+	needPublish := false
+	for id := range tableIDs {
+		ver, ok := holder.tables[id]
+		if !ok {
+			ver = schemaVer
+			holder.tables[id] = ver
+			m.dirty = true
+		}
+		if publishedVer, ok := m.published[id]; !ok || publishedVer > ver {
+			needPublish = true
+		}
+	}
+	holder.sqls = append(holder.sqls, sessVars.StmtCtx.OriginalSQL)
+	m.mu.Unlock()
+
+	if needPublish {
+		return errors.Trace(m.publish(context.Background()))
+	}
+	return nil
+}
+
+// Release releases the metadata locks held by the transaction of the session. The other instances
+// see the release after the next publishing.
+func (m *MetadataLockManager) Release(sctx sessionctx.Context) {
+	m.mu.Lock()
+	if _, ok := m.holders[sctx]; ok {
+		delete(m.holders, sctx)
+		m.dirty = true
+	}
+	m.mu.Unlock()
+}
+
+// publish publishes the metadata locks of this instance if they are changed.
+func (m *MetadataLockManager) publish(ctx context.Context) error {
+	m.publishMu.Lock()
+	defer m.publishMu.Unlock()
+	m.mu.Lock()
+	if !m.dirty {
+		m.mu.Unlock()
+		return nil
+	}
+	mdls := make(util.MDLs)
+	for _, holder := range m.holders {
+		for id, ver := range holder.tables {
+			if minVer, ok := mdls[id]; !ok || ver < minVer {
+				mdls[id] = ver
+			}
+		}
+	}
+	m.dirty = false
+	m.mu.Unlock()
+
+	err := m.syncer.UpdateSelfMDLs(ctx, mdls)
+	m.mu.Lock()
+	if err != nil {
+		m.dirty = true
+	} else {
+		m.published = mdls
+	}
+	m.mu.Unlock()
+	return errors.Trace(err)
+}
+
+// Waits returns the transactions of this instance which block the DDL job.
+func (m *MetadataLockManager) Waits() []MDLWait {
+	m.mu.Lock()
+	wait := m.waiting
+	m.mu.Unlock()
+	if wait == nil {
+		var err error
+		wait, err = m.syncer.GetMDLWait(context.Background())
+		if err != nil {
+			logutil.BgLogger().Warn("[ddl] get the DDL job waiting for metadata locks failed", zap.Error(err))
+		}
+		if wait == nil {
+			return nil
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var waits []MDLWait
+	for _, holder := range m.holders {
+		for _, id := range wait.TableIDs {
+			if ver, ok := holder.tables[id]; ok && ver < wait.SchemaVersion {
+				waits = append(waits, MDLWait{
+					Job:     wait.Job,
+					ConnID:  holder.connID,
+					StartTS: holder.startTS,
+					SQLs:    append([]string(nil), holder.sqls...),
+				})
+				break
+			}
+		}
+	}
+	sort.Slice(waits, func(i, j int) bool {
+		return waits[i].ConnID < waits[j].ConnID
+	})
+	return waits
+}
+
+// isHeld checks whether any instance holds the metadata locks blocking the wait.
+func (m *MetadataLockManager) isHeld(ctx context.Context, wait *util.MDLWaitInfo) bool {
+	allMDLs, err := m.syncer.OwnerGetAllMDLs(ctx)
+	if err != nil {
+		// Keep waiting, the locks may be held by the instances we can't see.
+		logutil.BgLogger().Warn("[ddl] get all metadata locks failed", zap.Error(err))
+		return true
+	}
+	for _, mdls := range allMDLs {
+		if wait.IsHeldBy(mdls) {
+			return true
+		}
+	}
+	return false
+}
+
+// wait blocks until no transaction uses the tables with a schema version older than the wait, or the
+// context is done. The transactions using the latest schema version are not waited for, otherwise busy
+// tables may block the job forever. They are waited for before the job moves to the state after the next.
+func (m *MetadataLockManager) wait(ctx context.Context, wait *util.MDLWaitInfo) {
+	// Publish the local changes first, the local transactions may be finished.
+	if err := m.publish(ctx); err != nil {
+		logutil.BgLogger().Warn("[ddl] publish metadata locks failed", zap.Error(err))
+	}
+	if !m.isHeld(ctx, wait) {
+		return
+	}
+
+	m.mu.Lock()
+	m.waiting = wait
+	m.mu.Unlock()
+	if err := m.syncer.OwnerUpdateMDLWait(ctx, wait); err != nil {
+		logutil.BgLogger().Warn("[ddl] update the DDL job waiting for metadata locks failed", zap.Error(err))
+	}
+	defer func() {
+		m.mu.Lock()
+		m.waiting = nil
+		m.mu.Unlock()
+		if err := m.syncer.OwnerUpdateMDLWait(context.Background(), nil); err != nil {
+			logutil.BgLogger().Warn("[ddl] update the DDL job waiting for metadata locks failed", zap.Error(err))
+		}
+	}()
+
+	logutil.BgLogger().Info("[ddl] wait for the transactions using the tables", zap.String("job", wait.Job.String()),
+		zap.Int64s("tableIDs", wait.TableIDs), zap.Int64("schemaVersion", wait.SchemaVersion))
+	ticker := time.NewTicker(mdlCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := m.publish(ctx); err != nil {
+			logutil.BgLogger().Warn("[ddl] publish metadata locks failed", zap.Error(err))
+		}
+		if !m.isHeld(ctx, wait) {
+			return
+		}
+	}
+}
+
+// startPublishMetadataLocks publishes the released metadata locks of this instance periodically.
+func (d *ddl) startPublishMetadataLocks() {
+	defer func() {
+		goutil.Recover(metrics.LabelDDL, "startPublishMetadataLocks", nil, false)
+		d.wg.Done()
+	}()
+	ticker := time.NewTicker(mdlCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := d.mdl.publish(d.ctx); err != nil {
+			logutil.BgLogger().Warn("[ddl] publish metadata locks failed", zap.Error(err))
+		}
+	}
+}
+
+// jobTableIDs returns the IDs of the tables whose schema is changed by the job.
+func jobTableIDs(d *ddlCtx, job *model.Job) []int64 {
+	var tableIDs []int64
+	if job.TableID != 0 {
+		tableIDs = append(tableIDs, job.TableID)
+	}
+	switch job.Type {
+	case model.ActionExchangeTablePartition:
+		var (
+			defID      int64
+			ptSchemaID int64
+			ptID       int64
+		)
+		if err := job.DecodeArgs(&defID, &ptSchemaID, &ptID); err == nil {
+			tableIDs = append(tableIDs, ptID)
+		}
+	case model.ActionRenameTables:
+		var (
+			oldSchemaIDs []int64
+			newSchemaIDs []int64
+			tableNames   []*model.CIStr
+			ids          []int64
+		)
+		if err := job.DecodeArgs(&oldSchemaIDs, &newSchemaIDs, &tableNames, &ids); err == nil {
+			tableIDs = append(tableIDs, ids...)
+		}
+	case model.ActionDropSchema:
+		is := d.infoCache.GetLatest()
+		if is == nil {
+			break
+		}
+		if db, ok := is.SchemaByID(job.SchemaID); ok {
+			for _, tbl := range is.SchemaTables(db.Name) {
+				tableIDs = append(tableIDs, tbl.Meta().ID)
+			}
+		}
+	}
+	return tableIDs
+}
+
+// waitMetadataLock waits for the transactions of all instances which use the tables of the first runnable job
+// in the queue with a previous schema version, before the job runs.
+func (w *worker) waitMetadataLock(d *ddlCtx) {
+	if !variable.EnableMDL.Load() || !d.isOwner() {
+		return
+	}
+	var (
+		job       *model.Job
+		schemaVer int64
+	)
+	err := kv.RunInNewTxn(context.Background(), d.store, false, func(ctx context.Context, txn kv.Transaction) error {
+		var err error
+		t := newMetaWithQueueTp(txn, w.tp)
+		job, _, _, err = w.getRunnableDDLJob(t)
+		if err != nil || job == nil {
+			return errors.Trace(err)
+		}
+		schemaVer, err = t.GetSchemaVersion()
+		return errors.Trace(err)
+	})
+	if err != nil || job == nil || job.IsDone() || job.IsRollbackDone() || job.IsCancelled() {
+		return
+	}
+	tableIDs := jobTableIDs(d, job)
+	if len(tableIDs) == 0 {
+		return
+	}
+	d.mdl.wait(w.ctx, &util.MDLWaitInfo{Job: job, TableIDs: tableIDs, SchemaVersion: schemaVer})
+}
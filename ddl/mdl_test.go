@@ -0,0 +1,134 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"crypto/tls"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/store/mockstore"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/integration"
+)
+
+// mdlTestStore shares the storage between the domains, each of them is a TiDB instance.
+type mdlTestStore struct {
+	kv.Storage
+	uuid      string
+	etcdAddrs []string
+}
+
+func (s *mdlTestStore) UUID() string { return s.uuid }
+
+func (s *mdlTestStore) EtcdAddrs() ([]string, error) { return s.etcdAddrs, nil }
+
+func (s *mdlTestStore) TLSConfig() *tls.Config { return nil }
+
+func (s *mdlTestStore) StartGCWorker() error { return nil }
+
+func TestMetadataLockMultiDomain(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("integration.NewClusterV3 will create file contains a colon which is not allowed on Windows")
+	}
+	if l, err := net.Listen("unix", "127.0.0.1:0"); err != nil {
+		t.Skip("ETCD use ip:port as unix socket address, skip when it is unavailable.")
+	} else {
+		require.NoError(t, l.Close())
+	}
+
+	// There is no PD to serve the placement rules.
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/domain/infosync/FailPlacement", `return(true)`))
+	defer func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/domain/infosync/FailPlacement"))
+	}()
+
+	cluster := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Close()) }()
+
+	// The domains reload the schema changed by each other.
+	session.SetSchemaLease(100 * time.Millisecond)
+	defer session.SetSchemaLease(0)
+	session.DisableStats4Test()
+	addrs := []string{cluster.Members[0].GRPCAddr()}
+	stores := []kv.Storage{
+		&mdlTestStore{Storage: store, uuid: store.UUID() + "-1", etcdAddrs: addrs},
+		&mdlTestStore{Storage: store, uuid: store.UUID() + "-2", etcdAddrs: addrs},
+	}
+	doms := make([]*domain.Domain, 0, len(stores))
+	for _, s := range stores {
+		dom, err := session.BootstrapSession(s)
+		require.NoError(t, err)
+		defer dom.Close()
+		doms = append(doms, dom)
+	}
+
+	// The transaction runs on the instance which isn't the DDL owner.
+	require.Eventually(t, func() bool {
+		return doms[0].DDL().OwnerManager().IsOwner() != doms[1].DDL().OwnerManager().IsOwner()
+	}, 10*time.Second, 10*time.Millisecond)
+	ownerStore, holderStore := stores[0], stores[1]
+	if doms[1].DDL().OwnerManager().IsOwner() {
+		ownerStore, holderStore = stores[1], stores[0]
+	}
+
+	tk := testkit.NewTestKit(t, ownerStore)
+	tk.MustExec("use test")
+	tk.MustExec("set @@global.tidb_enable_metadata_lock = 1")
+	defer tk.MustExec("set @@global.tidb_enable_metadata_lock = 0")
+	tk.MustExec("create table t_mdl (a int primary key, b int)")
+	tk.MustExec("insert into t_mdl values (1, 1)")
+
+	tk1 := testkit.NewTestKit(t, holderStore)
+	tk1.MustExec("use test")
+	tk2 := testkit.NewTestKit(t, holderStore)
+	require.Eventually(t, func() bool {
+		return tk1.ExecToErr("select * from t_mdl") == nil
+	}, 10*time.Second, 100*time.Millisecond)
+	tk1.MustExec("begin")
+	tk1.MustExec("insert into t_mdl values (2, 2)")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- tk.ExecToErr("alter table t_mdl add index idx(b)")
+	}()
+
+	// The DDL job waits for the transaction on the other instance.
+	time.Sleep(time.Second)
+	select {
+	case err := <-done:
+		t.Fatalf("the DDL job should wait for the transaction, err: %v", err)
+	default:
+	}
+	tk2.MustQuery("select db_name, table_name, query, sql_text from information_schema.tidb_mdl_view").Check(testkit.Rows(
+		"test t_mdl alter table t_mdl add index idx(b) insert into t_mdl values (2, 2)"))
+
+	tk1.MustExec("insert into t_mdl values (3, 3)")
+	tk1.MustExec("commit")
+	require.NoError(t, <-done)
+	tk2.MustQuery("select count(*) from information_schema.tidb_mdl_view").Check(testkit.Rows("0"))
+	tk.MustQuery("select * from t_mdl use index(idx) order by b").Check(testkit.Rows("1 1", "2 2", "3 3"))
+	tk.MustExec("admin check table t_mdl")
+}
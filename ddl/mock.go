@@ -16,6 +16,7 @@ package ddl
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -38,6 +39,11 @@ type MockSchemaSyncer struct {
 	selfSchemaVersion int64
 	globalVerCh       chan clientv3.WatchResponse
 	mockSession       chan struct{}
+	mu                struct {
+		sync.Mutex
+		selfMDLs util.MDLs
+		mdlWait  *util.MDLWaitInfo
+	}
 }
 
 // NewMockSchemaSyncer creates a new mock SchemaSyncer.
@@ -119,6 +125,36 @@ func (s *MockSchemaSyncer) OwnerCheckAllVersions(ctx context.Context, latestVer
 	}
 }
 
+// UpdateSelfMDLs implements SchemaSyncer.UpdateSelfMDLs interface.
+func (s *MockSchemaSyncer) UpdateSelfMDLs(ctx context.Context, mdls util.MDLs) error {
+	s.mu.Lock()
+	s.mu.selfMDLs = mdls
+	s.mu.Unlock()
+	return nil
+}
+
+// OwnerGetAllMDLs implements SchemaSyncer.OwnerGetAllMDLs interface.
+func (s *MockSchemaSyncer) OwnerGetAllMDLs(ctx context.Context) ([]util.MDLs, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return []util.MDLs{s.mu.selfMDLs}, nil
+}
+
+// OwnerUpdateMDLWait implements SchemaSyncer.OwnerUpdateMDLWait interface.
+func (s *MockSchemaSyncer) OwnerUpdateMDLWait(ctx context.Context, wait *util.MDLWaitInfo) error {
+	s.mu.Lock()
+	s.mu.mdlWait = wait
+	s.mu.Unlock()
+	return nil
+}
+
+// GetMDLWait implements SchemaSyncer.GetMDLWait interface.
+func (s *MockSchemaSyncer) GetMDLWait(ctx context.Context) (*util.MDLWaitInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mu.mdlWait, nil
+}
+
 // NotifyCleanExpiredPaths implements SchemaSyncer.NotifyCleanExpiredPaths interface.
 func (s *MockSchemaSyncer) NotifyCleanExpiredPaths() bool { return true }
 
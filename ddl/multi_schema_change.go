@@ -0,0 +1,417 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+// A multi-schema change job runs the schema changes of an ALTER TABLE statement as its sub-jobs.
+// It's done in three phases:
+//  1. The sub-jobs which add columns or indexes run one by one, until each of them reaches the state
+//     before public, and the indexes are backfilled. Any failure in this phase rolls back all the sub-jobs.
+//  2. All the sub-jobs are committed in one step. The added columns and indexes become public, and the
+//     dropped columns and indexes become invisible at the same time.
+//  3. The sub-jobs which drop columns or indexes run to the end together. They can't be rolled back anymore.
+// The state of the job is public since phase 2.
+
+// subJob is a schema change of a multi-schema change job. It's run as a job of its own type by the
+// handler of the type, and the states of the job are kept in the sub-job between the steps.
+type subJob struct {
+	Type        model.ActionType  `json:"type"`
+	RawArgs     json.RawMessage   `json:"raw_args"`
+	SchemaState model.SchemaState `json:"schema_state"`
+	SnapshotVer uint64            `json:"snapshot_ver"`
+	RowCount    int64             `json:"row_count"`
+	State       model.JobState    `json:"state"`
+	// Revertible is false once the sub-job has done all the work before being committed.
+	Revertible bool `json:"revertible"`
+}
+
+func newSubJob(job *model.Job) (*subJob, error) {
+	rawArgs, err := json.Marshal(job.Args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &subJob{
+		Type:       job.Type,
+		RawArgs:    rawArgs,
+		Revertible: job.Type == model.ActionAddColumns || job.Type == model.ActionAddIndex,
+	}, nil
+}
+
+// toProxyJob builds the job to run the sub-job with the handler of its type.
+func (sub *subJob) toProxyJob(parent *model.Job) *model.Job {
+	return &model.Job{
+		ID:              parent.ID,
+		Type:            sub.Type,
+		SchemaID:        parent.SchemaID,
+		TableID:         parent.TableID,
+		SchemaName:      parent.SchemaName,
+		State:           sub.State,
+		SchemaState:     sub.SchemaState,
+		SnapshotVer:     sub.SnapshotVer,
+		RowCount:        sub.RowCount,
+		RawArgs:         sub.RawArgs,
+		StartTS:         parent.StartTS,
+		DependencyID:    parent.DependencyID,
+		Query:           parent.Query,
+		BinlogInfo:      &model.HistoryInfo{},
+		Version:         parent.Version,
+		ReorgMeta:       parent.ReorgMeta,
+		MultiSchemaInfo: parent.MultiSchemaInfo,
+		Priority:        parent.Priority,
+	}
+}
+
+// updateFromProxyJob keeps the states of the proxy job after it runs.
+func (sub *subJob) updateFromProxyJob(proxy *model.Job) error {
+	if proxy.Args != nil {
+		rawArgs, err := json.Marshal(proxy.Args)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sub.RawArgs = rawArgs
+	}
+	sub.State = proxy.State
+	sub.SchemaState = proxy.SchemaState
+	sub.SnapshotVer = proxy.SnapshotVer
+	sub.RowCount = proxy.GetRowCount()
+	return nil
+}
+
+func (sub *subJob) isFinished() bool {
+	return sub.State == model.JobStateDone || sub.State == model.JobStateRollbackDone || sub.State == model.JobStateCancelled
+}
+
+// decodeSubJobs decodes the sub-jobs of the multi-schema change job. If the job meets an error which makes the
+// changes of the table discarded, the returned function puts back the sub-jobs, so their changes are discarded too.
+func decodeSubJobs(job *model.Job) ([]*subJob, func(error), error) {
+	rawArgs := job.RawArgs
+	var subJobs []*subJob
+	if err := job.DecodeArgs(&subJobs); err != nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, errors.Trace(err)
+	}
+	restore := func(err error) {
+		if err != nil && !job.IsRollingback() && !job.IsCancelled() {
+			job.Args = nil
+			job.RawArgs = rawArgs
+		}
+	}
+	return subJobs, restore, nil
+}
+
+func (w *worker) onMultiSchemaChange(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	subJobs, restore, err := decodeSubJobs(job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	defer func() {
+		restore(err)
+	}()
+
+	if job.IsRollingback() {
+		return rollbackMultiSchemaChangeStep(w, d, t, job, subJobs)
+	}
+	if job.SchemaState == model.StatePublic {
+		return w.runNonRevertibleSubJobs(d, t, job, subJobs)
+	}
+
+	for _, sub := range subJobs {
+		if !sub.Revertible {
+			continue
+		}
+		proxy := sub.toProxyJob(job)
+		var done bool
+		done, ver, err = w.runRevertibleSubJob(d, t, proxy)
+		if err1 := sub.updateFromProxyJob(proxy); err1 != nil {
+			return ver, errors.Trace(err1)
+		}
+		if proxy.IsCancelled() || proxy.IsRollingback() {
+			logutil.Logger(w.logCtx).Warn("[ddl] run multi-schema change sub-job failed, convert job to rollback",
+				zap.String("job", job.String()), zap.String("subJob", sub.Type.String()), zap.Error(err))
+			return w.convertMultiSchemaChange2RollbackJob(d, t, job, subJobs, err)
+		}
+		if err != nil || !done {
+			job.SchemaState = proxy.SchemaState
+			return ver, errors.Trace(err)
+		}
+		sub.Revertible = false
+	}
+	return w.commitMultiSchemaChange(d, t, job, subJobs)
+}
+
+// runRevertibleSubJob runs a step of the sub-job before it's committed. The returned bool is true if the
+// sub-job has done all the work before being committed.
+func (w *worker) runRevertibleSubJob(d *ddlCtx, t *meta.Meta, job *model.Job) (done bool, ver int64, err error) {
+	if job.SchemaState != model.StateWriteReorganization {
+		ver, err = w.runSubJob(d, t, job)
+		return false, ver, errors.Trace(err)
+	}
+	if job.Type == model.ActionAddColumns {
+		// The existing rows are checked by the check constraints of the added columns.
+		tblInfo, columnInfos, _, _, _, _, _, err := checkAddColumns(t, job)
+		if err != nil {
+			return false, ver, errors.Trace(err)
+		}
+		return w.verifyColumnCheckConstraints(d, t, job, tblInfo, columnInfos)
+	}
+	tblInfo, indexInfo, err := getAddIndexSubJobInfo(t, job)
+	if err != nil {
+		return false, ver, errors.Trace(err)
+	}
+	return w.doReorgWorkForCreateIndex(d, t, job, tblInfo, indexInfo)
+}
+
+func (w *worker) runSubJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if job.State == model.JobStateNone {
+		job.State = model.JobStateRunning
+	}
+	switch job.Type {
+	case model.ActionAddColumns:
+		// The check constraints of the added columns are verified before the sub-job is committed.
+		ver, err = w.onAddColumns(d, t, job, true)
+	case model.ActionAddIndex:
+		ver, err = w.onCreateIndex(d, t, job, false)
+	case model.ActionDropColumns:
+		ver, err = onDropColumns(t, job)
+	case model.ActionDropIndexes:
+		ver, err = onDropIndexes(t, job)
+	default:
+		job.State = model.JobStateCancelled
+		err = errInvalidDDLJob.GenWithStack("invalid sub-job type: %v", job.Type)
+	}
+	return ver, errors.Trace(err)
+}
+
+func getAddIndexSubJobInfo(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.IndexInfo, error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var (
+		unique                  bool
+		global                  bool
+		indexName               model.CIStr
+		indexPartSpecifications []*ast.IndexPartSpecification
+		indexOption             *ast.IndexOption
+		hiddenCols              []*model.ColumnInfo
+	)
+	if err = job.DecodeArgs(&unique, &indexName, &indexPartSpecifications, &indexOption, &hiddenCols, &global); err != nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, errors.Trace(err)
+	}
+	indexInfo := tblInfo.FindIndexByName(indexName.L)
+	if indexInfo == nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, errors.Trace(ErrCantDropFieldOrKey.GenWithStack("index %s doesn't exist", indexName))
+	}
+	return tblInfo, indexInfo, nil
+}
+
+// commitMultiSchemaChange makes the added columns and indexes public, and starts to drop the columns and indexes.
+func (w *worker) commitMultiSchemaChange(d *ddlCtx, t *meta.Meta, job *model.Job, subJobs []*subJob) (ver int64, err error) {
+	// Check the dropped columns and indexes before making any change, so the job can still be rolled back.
+	for _, sub := range subJobs {
+		proxy := sub.toProxyJob(job)
+		switch sub.Type {
+		case model.ActionDropColumns:
+			_, _, _, _, err = checkDropColumns(t, proxy)
+		case model.ActionDropIndexes:
+			tblInfo, indexNames, ifExists, err1 := getSchemaInfos(t, proxy)
+			if err1 == nil {
+				_, err1 = checkDropIndexes(tblInfo, proxy, indexNames, ifExists)
+			}
+			err = err1
+		}
+		if err == nil {
+			continue
+		}
+		if proxy.IsCancelled() {
+			sub.State = model.JobStateCancelled
+			return w.convertMultiSchemaChange2RollbackJob(d, t, job, subJobs, err)
+		}
+		return ver, errors.Trace(err)
+	}
+
+	for _, sub := range subJobs {
+		proxy := sub.toProxyJob(job)
+		var subVer int64
+		if sub.Type == model.ActionAddIndex {
+			tblInfo, indexInfo, err := getAddIndexSubJobInfo(t, proxy)
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
+			subVer, err = finishCreateIndex(t, proxy, tblInfo, indexInfo, false)
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
+		} else {
+			subVer, err = w.runSubJob(d, t, proxy)
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
+		}
+		if err = sub.updateFromProxyJob(proxy); err != nil {
+			return ver, errors.Trace(err)
+		}
+		ver = subVer
+	}
+	job.SchemaState = model.StatePublic
+	return finishMultiSchemaChangeIfDone(t, job, subJobs, ver, model.JobStateDone)
+}
+
+// runNonRevertibleSubJobs runs a step of each committed sub-job which hasn't finished.
+func (w *worker) runNonRevertibleSubJobs(d *ddlCtx, t *meta.Meta, job *model.Job, subJobs []*subJob) (ver int64, err error) {
+	for _, sub := range subJobs {
+		if sub.isFinished() {
+			continue
+		}
+		proxy := sub.toProxyJob(job)
+		subVer, err := w.runSubJob(d, t, proxy)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		if err = sub.updateFromProxyJob(proxy); err != nil {
+			return ver, errors.Trace(err)
+		}
+		ver = subVer
+	}
+	return finishMultiSchemaChangeIfDone(t, job, subJobs, ver, model.JobStateDone)
+}
+
+// rollbackMultiSchemaChangeStep runs a step of the rolling back sub-jobs in the reverse order.
+func rollbackMultiSchemaChangeStep(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, subJobs []*subJob) (ver int64, err error) {
+	for i := len(subJobs) - 1; i >= 0; i-- {
+		sub := subJobs[i]
+		if sub.State != model.JobStateRollingback {
+			continue
+		}
+		proxy := sub.toProxyJob(job)
+		ver, err = w.runSubJob(d, t, proxy)
+		if err1 := sub.updateFromProxyJob(proxy); err1 != nil {
+			return ver, errors.Trace(err1)
+		}
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		break
+	}
+	return finishMultiSchemaChangeIfDone(t, job, subJobs, ver, model.JobStateRollbackDone)
+}
+
+func finishMultiSchemaChangeIfDone(t *meta.Meta, job *model.Job, subJobs []*subJob, ver int64, state model.JobState) (int64, error) {
+	for _, sub := range subJobs {
+		if !sub.isFinished() {
+			return ver, nil
+		}
+	}
+	tblInfo, err := getTableInfo(t, job.TableID, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	schemaState := model.StatePublic
+	if state == model.JobStateRollbackDone {
+		schemaState = model.StateNone
+	}
+	job.FinishTableJob(state, schemaState, ver, tblInfo)
+	return ver, nil
+}
+
+// convertMultiSchemaChange2RollbackJob rolls back the started sub-jobs in the reverse order, and cancels the others.
+// The job stays cancelling if a sub-job can't be converted yet, e.g. its backfilling is stopping.
+func (w *worker) convertMultiSchemaChange2RollbackJob(d *ddlCtx, t *meta.Meta, job *model.Job, subJobs []*subJob, occurredErr error) (ver int64, err error) {
+	for i := len(subJobs) - 1; i >= 0; i-- {
+		sub := subJobs[i]
+		if sub.isFinished() || sub.State == model.JobStateRollingback {
+			continue
+		}
+		if sub.SchemaState == model.StateNone {
+			sub.State = model.JobStateCancelled
+			continue
+		}
+		proxy := sub.toProxyJob(job)
+		proxy.State = model.JobStateCancelling
+		var subVer int64
+		switch sub.Type {
+		case model.ActionAddColumns:
+			if sub.Revertible {
+				subVer, err = rollingbackAddColumns(w, d, t, proxy)
+			} else {
+				// The rows are checked, there is no running check to stop.
+				subVer, err = convertAddColumnsJob2RollbackJob(t, proxy)
+			}
+		case model.ActionAddIndex:
+			if sub.Revertible {
+				subVer, err = rollingbackAddIndex(w, d, t, proxy, false)
+			} else {
+				// The index is backfilled, there is no running backfilling to stop.
+				subVer, err = convertNotStartAddIdxJob2RollbackJob(t, proxy, errCancelledDDLJob)
+			}
+		default:
+			proxy.State = model.JobStateCancelled
+		}
+		if err != nil && !errCancelledDDLJob.Equal(err) {
+			return ver, errors.Trace(err)
+		}
+		if subVer != 0 {
+			ver = subVer
+		}
+		if err = sub.updateFromProxyJob(proxy); err != nil {
+			return ver, errors.Trace(err)
+		}
+		if proxy.State != model.JobStateRollingback && proxy.State != model.JobStateCancelled {
+			// Try to convert the sub-job again later.
+			job.State = model.JobStateCancelling
+			return ver, nil
+		}
+	}
+
+	job.State = model.JobStateCancelled
+	for _, sub := range subJobs {
+		if sub.State == model.JobStateRollingback || sub.State == model.JobStateRollbackDone {
+			job.State = model.JobStateRollingback
+			break
+		}
+	}
+	if occurredErr == nil {
+		occurredErr = errCancelledDDLJob
+	}
+	return ver, errors.Trace(occurredErr)
+}
+
+func rollingbackMultiSchemaChange(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if job.SchemaState == model.StatePublic {
+		// The sub-jobs are committed, they can't be rolled back anymore.
+		job.State = model.JobStateRunning
+		return ver, nil
+	}
+	subJobs, restore, err := decodeSubJobs(job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	defer func() {
+		restore(err)
+	}()
+	return w.convertMultiSchemaChange2RollbackJob(d, t, job, subJobs, errCancelledDDLJob)
+}
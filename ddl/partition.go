@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -38,10 +39,12 @@ import (
 	"github.com/pingcap/tidb/domain/infosync"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	driver "github.com/pingcap/tidb/types/parser_driver"
@@ -50,8 +53,11 @@ import (
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/logutil"
+	decoder "github.com/pingcap/tidb/util/rowDecoder"
 	"github.com/pingcap/tidb/util/slice"
 	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/timeutil"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
 )
@@ -323,10 +329,9 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 				enable = true
 			}
 		}
-	case model.PartitionTypeHash:
-		// Partition by hash is enabled by default.
-		// Note that linear hash is not enabled.
-		if !s.Linear && s.Sub == nil {
+	case model.PartitionTypeHash, model.PartitionTypeKey:
+		// Partition by [linear] hash and [linear] key is enabled by default.
+		if s.Sub == nil {
 			enable = true
 		}
 	case model.PartitionTypeList:
@@ -340,12 +345,17 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	}
 
 	pi := &model.PartitionInfo{
-		Type:   s.Tp,
-		Enable: enable,
-		Num:    s.Num,
+		Type:     s.Tp,
+		Enable:   enable,
+		Num:      s.Num,
+		IsLinear: s.Linear,
 	}
 	tbInfo.Partition = pi
-	if s.Expr != nil {
+	if s.Tp == model.PartitionTypeKey {
+		if err := buildKeyPartitionColumns(s.ColumnNames, tbInfo); err != nil {
+			return errors.Trace(err)
+		}
+	} else if s.Expr != nil {
 		if err := checkPartitionFuncValid(ctx, tbInfo, s.Expr); err != nil {
 			return errors.Trace(err)
 		}
@@ -379,7 +389,7 @@ func buildPartitionDefinitionsInfo(ctx sessionctx.Context, defs []*ast.Partition
 	switch tbInfo.Partition.Type {
 	case model.PartitionTypeRange:
 		return buildRangePartitionDefinitions(ctx, defs, tbInfo)
-	case model.PartitionTypeHash:
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 		return buildHashPartitionDefinitions(ctx, defs, tbInfo)
 	case model.PartitionTypeList:
 		return buildListPartitionDefinitions(ctx, defs, tbInfo)
@@ -387,6 +397,76 @@ func buildPartitionDefinitionsInfo(ctx sessionctx.Context, defs []*ast.Partition
 	return nil, nil
 }
 
+// buildKeyPartitionColumns builds the columns of `PARTITION BY [LINEAR] KEY`. Like MySQL, if no column is
+// specified, the columns of the primary key are used, or the columns of the first unique key if there is no
+// primary key.
+func buildKeyPartitionColumns(colNames []*ast.ColumnName, tbInfo *model.TableInfo) error {
+	pi := tbInfo.Partition
+	if len(colNames) > 0 {
+		pi.Columns = make([]model.CIStr, 0, len(colNames))
+		for _, cn := range colNames {
+			pi.Columns = append(pi.Columns, cn.Name)
+		}
+	} else {
+		pi.Columns = getDefaultKeyPartitionColumns(tbInfo)
+		if len(pi.Columns) == 0 {
+			return errors.Trace(ErrFieldNotFoundPart)
+		}
+	}
+	return checkKeyPartitionColumns(tbInfo)
+}
+
+func getDefaultKeyPartitionColumns(tbInfo *model.TableInfo) []model.CIStr {
+	if tbInfo.PKIsHandle {
+		return []model.CIStr{tbInfo.GetPkName()}
+	}
+	uniqueIdx := getDefaultKeyPartitionIndex(tbInfo)
+	if uniqueIdx == nil {
+		return nil
+	}
+	cols := make([]model.CIStr, 0, len(uniqueIdx.Columns))
+	for _, idxCol := range uniqueIdx.Columns {
+		cols = append(cols, idxCol.Name)
+	}
+	return cols
+}
+
+// getDefaultKeyPartitionIndex returns the primary key, or the first unique key if there is no primary key.
+func getDefaultKeyPartitionIndex(tbInfo *model.TableInfo) *model.IndexInfo {
+	var uniqueIdx *model.IndexInfo
+	for _, idx := range tbInfo.Indices {
+		if idx.Primary {
+			return idx
+		}
+		if idx.Unique && uniqueIdx == nil {
+			uniqueIdx = idx
+		}
+	}
+	return uniqueIdx
+}
+
+// checkKeyPartitionColumns checks the columns of `PARTITION BY [LINEAR] KEY`. Columns of any type except
+// BLOB, TEXT and JSON can be used.
+func checkKeyPartitionColumns(tbInfo *model.TableInfo) error {
+	pi := tbInfo.Partition
+	for i, col := range pi.Columns {
+		for j := 0; j < i; j++ {
+			if pi.Columns[j].L == col.L {
+				return ErrSameNamePartitionField.GenWithStackByArgs(col.O)
+			}
+		}
+		colInfo := getColumnInfoByName(tbInfo, col.L)
+		if colInfo == nil {
+			return errors.Trace(ErrFieldNotFoundPart)
+		}
+		switch colInfo.Tp {
+		case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob, mysql.TypeJSON, mysql.TypeGeometry:
+			return errors.Trace(ErrBlobFieldInPartFunc)
+		}
+	}
+	return nil
+}
+
 func buildHashPartitionDefinitions(_ sessionctx.Context, defs []*ast.PartitionDefinition, tbInfo *model.TableInfo) ([]model.PartitionDefinition, error) {
 	if err := checkAddPartitionTooManyPartitions(tbInfo.Partition.Num); err != nil {
 		return nil, err
@@ -585,8 +665,8 @@ func checkAndOverridePartitionID(newTableInfo, oldTableInfo *model.TableInfo) er
 	if newTableInfo.Partition.Type != oldTableInfo.Partition.Type {
 		return ErrRepairTableFail.GenWithStackByArgs("Partition type should be the same")
 	}
-	// Check whether partitionType is hash partition.
-	if newTableInfo.Partition.Type == model.PartitionTypeHash {
+	// Check whether partitionType is hash or key partition.
+	if tp := newTableInfo.Partition.Type; tp == model.PartitionTypeHash || tp == model.PartitionTypeKey {
 		if newTableInfo.Partition.Num != oldTableInfo.Partition.Num {
 			return ErrRepairTableFail.GenWithStackByArgs("Hash partition num should be the same")
 		}
@@ -1006,47 +1086,11 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 		job.SchemaState = model.StateDeleteReorganization
 		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != job.SchemaState)
 	case model.StateDeleteReorganization:
-		oldTblInfo := getTableInfoWithDroppingPartitions(tblInfo)
 		physicalTableIDs = getPartitionIDsFromDefinitions(tblInfo.Partition.DroppingDefinitions)
-		tbl, err := getTable(d.store, job.SchemaID, oldTblInfo)
-		if err != nil {
+		done, err := w.cleanupDroppingPartitionsGlobalIndexes(d, t, job, tblInfo)
+		if err != nil || !done {
 			return ver, errors.Trace(err)
 		}
-		// If table has global indexes, we need reorg to clean up them.
-		if pt, ok := tbl.(table.PartitionedTable); ok && hasGlobalIndex(tblInfo) {
-			// Build elements for compatible with modify column type. elements will not be used when reorganizing.
-			elements := make([]*meta.Element, 0, len(tblInfo.Indices))
-			for _, idxInfo := range tblInfo.Indices {
-				if idxInfo.Global {
-					elements = append(elements, &meta.Element{ID: idxInfo.ID, TypeKey: meta.IndexElementKey})
-				}
-			}
-			reorgInfo, err := getReorgInfoFromPartitions(d, t, job, tbl, physicalTableIDs, elements)
-
-			if err != nil || reorgInfo.first {
-				// If we run reorg firstly, we should update the job snapshot version
-				// and then run the reorg next time.
-				return ver, errors.Trace(err)
-			}
-			err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (dropIndexErr error) {
-				defer tidbutil.Recover(metrics.LabelDDL, "onDropTablePartition",
-					func() {
-						dropIndexErr = errCancelledDDLJob.GenWithStack("drop partition panic")
-					}, false)
-				return w.cleanupGlobalIndexes(pt, physicalTableIDs, reorgInfo)
-			})
-			if err != nil {
-				if errWaitReorgTimeout.Equal(err) {
-					// if timeout, we should return, check for the owner and re-wait job done.
-					return ver, nil
-				}
-				// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
-				w.reorgCtx.cleanNotifyReorgCancel()
-				return ver, errors.Trace(err)
-			}
-			// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
-			w.reorgCtx.cleanNotifyReorgCancel()
-		}
 		tblInfo.Partition.DroppingDefinitions = nil
 		// used by ApplyDiff in updateSchemaVersion
 		job.CtxVars = []interface{}{physicalTableIDs}
@@ -1064,8 +1108,55 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 	return ver, errors.Trace(err)
 }
 
+// cleanupDroppingPartitionsGlobalIndexes runs a reorg job to remove the global index entries of the rows
+// in the dropping partitions. It returns false if the reorg job is not finished yet.
+func (w *worker) cleanupDroppingPartitionsGlobalIndexes(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo) (bool, error) {
+	oldTblInfo := getTableInfoWithDroppingPartitions(tblInfo)
+	physicalTableIDs := getPartitionIDsFromDefinitions(tblInfo.Partition.DroppingDefinitions)
+	tbl, err := getTable(d.store, job.SchemaID, oldTblInfo)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	pt, ok := tbl.(table.PartitionedTable)
+	if !ok || !hasGlobalIndex(tblInfo) {
+		return true, nil
+	}
+	// Build elements for compatible with modify column type. elements will not be used when reorganizing.
+	elements := make([]*meta.Element, 0, len(tblInfo.Indices))
+	for _, idxInfo := range tblInfo.Indices {
+		if idxInfo.Global {
+			elements = append(elements, &meta.Element{ID: idxInfo.ID, TypeKey: meta.IndexElementKey})
+		}
+	}
+	reorgInfo, err := getReorgInfoFromPartitions(d, t, job, tbl, physicalTableIDs, elements)
+	if err != nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, errors.Trace(err)
+	}
+	err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (cleanupErr error) {
+		defer tidbutil.Recover(metrics.LabelDDL, "cleanupDroppingPartitionsGlobalIndexes",
+			func() {
+				cleanupErr = errCancelledDDLJob.GenWithStack("clean up global indexes panic")
+			}, false)
+		return w.cleanupGlobalIndexes(pt, physicalTableIDs, reorgInfo)
+	})
+	if err != nil {
+		if errWaitReorgTimeout.Equal(err) {
+			// if timeout, we should return, check for the owner and re-wait job done.
+			return false, nil
+		}
+		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+		w.reorgCtx.cleanNotifyReorgCancel()
+		return false, errors.Trace(err)
+	}
+	// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+	w.reorgCtx.cleanNotifyReorgCancel()
+	return true, nil
+}
+
 // onTruncateTablePartition truncates old partition meta.
-func onTruncateTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (int64, error) {
+func (w *worker) onTruncateTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (int64, error) {
 	var ver int64
 	var oldIDs []int64
 	if err := job.DecodeArgs(&oldIDs); err != nil {
@@ -1081,7 +1172,36 @@ func onTruncateTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (int64, e
 		return ver, errors.Trace(ErrPartitionMgmtOnNonpartitioned)
 	}
 
+	if job.SchemaState == model.StateDeleteReorganization {
+		// The truncated partitions have got new IDs, the old ones are kept in the dropping definitions
+		// until the global index entries of their rows are removed.
+		done, err := w.cleanupDroppingPartitionsGlobalIndexes(d, t, job, tblInfo)
+		if err != nil || !done {
+			return ver, errors.Trace(err)
+		}
+		newPartitions := make([]model.PartitionDefinition, 0, len(oldIDs))
+		for _, def := range pi.Definitions {
+			for _, oldDef := range pi.DroppingDefinitions {
+				if def.Name.L == oldDef.Name.L {
+					newPartitions = append(newPartitions, def)
+					break
+				}
+			}
+		}
+		pi.DroppingDefinitions = nil
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+		asyncNotifyEvent(d, &util.Event{Tp: model.ActionTruncateTablePartition, TableInfo: tblInfo, PartInfo: &model.PartitionInfo{Definitions: newPartitions}})
+		// A background job will be created to delete old partition data.
+		job.Args = []interface{}{oldIDs}
+		return ver, nil
+	}
+
 	newPartitions := make([]model.PartitionDefinition, 0, len(oldIDs))
+	oldPartitions := make([]model.PartitionDefinition, 0, len(oldIDs))
 	for _, oldID := range oldIDs {
 		for i := 0; i < len(pi.Definitions); i++ {
 			def := &pi.Definitions[i]
@@ -1090,6 +1210,7 @@ func onTruncateTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (int64, e
 				if err1 != nil {
 					return ver, errors.Trace(err1)
 				}
+				oldPartitions = append(oldPartitions, *def)
 				def.ID = pid
 				// Shallow copy only use the def.ID in event handle.
 				newPartitions = append(newPartitions, *def)
@@ -1171,6 +1292,13 @@ func onTruncateTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (int64, e
 		newIDs[i] = newPartitions[i].ID
 	}
 	job.CtxVars = []interface{}{oldIDs, newIDs}
+	// If table has global indexes, the entries of the old partitions are cleaned up in the next state.
+	if hasGlobalIndex(tblInfo) {
+		pi.DroppingDefinitions = oldPartitions
+		job.SchemaState = model.StateDeleteReorganization
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		return ver, errors.Trace(err)
+	}
 	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
 	if err != nil {
 		return ver, errors.Trace(err)
@@ -1184,6 +1312,490 @@ func onTruncateTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (int64, e
 	return ver, nil
 }
 
+// checkReorganizePartition checks the partitions to be reorganized exist, and they are consecutive for RANGE partitions.
+func checkReorganizePartition(tblInfo *model.TableInfo, partLowerNames []string) error {
+	pi := tblInfo.Partition
+	if pi.Type != model.PartitionTypeRange && pi.Type != model.PartitionTypeList {
+		return errors.Trace(errUnsupportedReorganizePartition)
+	}
+	offsets := make([]int, 0, len(partLowerNames))
+	for _, pn := range partLowerNames {
+		offset := -1
+		for i, def := range pi.Definitions {
+			if def.Name.L == pn {
+				offset = i
+				break
+			}
+		}
+		if offset < 0 || slice.AnyOf(offsets, func(i int) bool { return offsets[i] == offset }) {
+			return errors.Trace(ErrDropPartitionNonExistent.GenWithStackByArgs("REORGANIZE"))
+		}
+		offsets = append(offsets, offset)
+	}
+	if pi.Type == model.PartitionTypeRange {
+		sort.Ints(offsets)
+		if offsets[len(offsets)-1]-offsets[0] != len(offsets)-1 {
+			return errors.Trace(ErrConsecutiveReorgPartitions)
+		}
+	}
+	return nil
+}
+
+// getReorganizedPartitionDefinitions returns the definitions of the reorganized partitions in the order of the table.
+func getReorganizedPartitionDefinitions(pi *model.PartitionInfo, partLowerNames []string) []model.PartitionDefinition {
+	defs := make([]model.PartitionDefinition, 0, len(partLowerNames))
+	for _, def := range pi.Definitions {
+		for _, pn := range partLowerNames {
+			if def.Name.L == pn {
+				defs = append(defs, def)
+				break
+			}
+		}
+	}
+	return defs
+}
+
+// checkReorganizeRangeBound checks the new RANGE partitions cover the same range as the reorganized ones.
+// Only the range of the last partition of the table can be extended.
+func checkReorganizeRangeBound(ctx sessionctx.Context, tblInfo *model.TableInfo, oldDefs, newDefs []model.PartitionDefinition, isLast bool) error {
+	cmp, err := compareRangePartitionBound(ctx, tblInfo, &newDefs[len(newDefs)-1], &oldDefs[len(oldDefs)-1])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cmp == 0 || (cmp > 0 && isLast) {
+		return nil
+	}
+	return errors.Trace(ErrReorgOutsideRange)
+}
+
+// compareRangePartitionBound compares the `VALUES LESS THAN` bounds of two RANGE partitions.
+func compareRangePartitionBound(ctx sessionctx.Context, tblInfo *model.TableInfo, a, b *model.PartitionDefinition) (int, error) {
+	pi := tblInfo.Partition
+	equal := len(a.LessThan) == len(b.LessThan)
+	for i := 0; equal && i < len(a.LessThan); i++ {
+		equal = strings.EqualFold(a.LessThan[i], b.LessThan[i])
+	}
+	if equal {
+		return 0, nil
+	}
+	if len(pi.Columns) > 0 {
+		greater, err := checkTwoRangeColumns(ctx, a, b, pi, tblInfo)
+		if err != nil || greater {
+			return 1, errors.Trace(err)
+		}
+		less, err := checkTwoRangeColumns(ctx, b, a, pi, tblInfo)
+		if err != nil || less {
+			return -1, errors.Trace(err)
+		}
+		return 0, nil
+	}
+
+	if strings.EqualFold(a.LessThan[0], partitionMaxValue) {
+		return 1, nil
+	}
+	if strings.EqualFold(b.LessThan[0], partitionMaxValue) {
+		return -1, nil
+	}
+	isUnsigned := isColUnsigned(tblInfo.Columns, pi)
+	av, _, err := getRangeValue(ctx, a.LessThan[0], isUnsigned)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	bv, _, err := getRangeValue(ctx, b.LessThan[0], isUnsigned)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if isUnsigned {
+		return types.CompareUint64(av.(uint64), bv.(uint64)), nil
+	}
+	return types.CompareInt64(av.(int64), bv.(int64)), nil
+}
+
+// setReorgPartitionState sets the state of the partitions being added by the reorganize partition job.
+func setReorgPartitionState(pi *model.PartitionInfo, state model.SchemaState) {
+	pi.States = make([]model.PartitionState, 0, len(pi.AddingDefinitions))
+	for _, def := range pi.AddingDefinitions {
+		pi.States = append(pi.States, model.PartitionState{ID: def.ID, State: state})
+	}
+}
+
+// onReorganizePartition reorganizes some partitions of the table into the new partitions.
+// The new partitions are kept in AddingDefinitions and the reorganized ones in DroppingDefinitions until the job is done,
+// and the states of the new partitions are kept in PartitionInfo.States:
+// delete only: the rows deleted from the reorganized partitions are deleted from the new partitions too.
+// write only: the rows written into the reorganized partitions are written into the new partitions too.
+// write reorganization: the existing rows are copied into the new partitions, then the new partitions replace the old ones.
+// delete reorganization: the rows written into the new partitions are still written into the old partitions, for the
+// TiDB servers that read the old partitions.
+func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var partNames []string
+	partInfo := &model.PartitionInfo{}
+	if err := job.DecodeArgs(&partNames, &partInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+
+	if job.IsRollingback() {
+		// Remove the new partitions, the reorganized partitions are still in the definitions.
+		physicalTableIDs := getPartitionIDsFromDefinitions(pi.AddingDefinitions)
+		pi.AddingDefinitions = nil
+		pi.DroppingDefinitions = nil
+		pi.States = nil
+		err = dropRuleBundles(d, physicalTableIDs)
+		if err != nil {
+			return ver, errors.Wrapf(err, "failed to notify PD the placement rules")
+		}
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+		// A background job will be created to delete the data copied into the new partitions.
+		job.Args = []interface{}{physicalTableIDs}
+		return ver, nil
+	}
+
+	originalState := job.SchemaState
+	switch job.SchemaState {
+	case model.StateNone:
+		// none -> delete only
+		err = checkReorganizePartition(tblInfo, partNames)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		pi.AddingDefinitions = partInfo.Definitions
+		pi.DroppingDefinitions = getReorganizedPartitionDefinitions(pi, partNames)
+		setReorgPartitionState(pi, model.StateDeleteOnly)
+		job.SchemaState = model.StateDeleteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != job.SchemaState)
+	case model.StateDeleteOnly:
+		// delete only -> write only
+		setReorgPartitionState(pi, model.StateWriteOnly)
+		job.SchemaState = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != job.SchemaState)
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		setReorgPartitionState(pi, model.StateWriteReorganization)
+		job.SchemaState = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != job.SchemaState)
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
+	case model.StateWriteReorganization:
+		// reorganization -> delete reorganization
+		var done bool
+		done, ver, err = w.reorgPartitionData(d, t, job, tblInfo)
+		if err != nil || !done {
+			return ver, errors.Trace(err)
+		}
+		// Replace the reorganized partitions with the new ones, and keep writing the rows into the old ones until
+		// all the TiDB servers use the new partitions.
+		pi.Definitions = tables.ReplacePartitionDefinitions(pi.Definitions, pi.DroppingDefinitions, pi.AddingDefinitions)
+		pi.States = nil
+		job.SchemaState = model.StateDeleteReorganization
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != job.SchemaState)
+	case model.StateDeleteReorganization:
+		// delete reorganization -> none
+		newDefs := pi.AddingDefinitions
+		physicalTableIDs := getPartitionIDsFromDefinitions(pi.DroppingDefinitions)
+		droppedNames := make([]string, 0, len(pi.DroppingDefinitions))
+		for _, def := range pi.DroppingDefinitions {
+			if _, _, err := getPartitionDef(tblInfo, def.Name.L); err != nil {
+				droppedNames = append(droppedNames, def.Name.L)
+			}
+		}
+		pi.AddingDefinitions = nil
+		pi.DroppingDefinitions = nil
+		err = dropRuleBundles(d, physicalTableIDs)
+		if err != nil {
+			return ver, errors.Wrapf(err, "failed to notify PD the placement rules")
+		}
+		err = dropLabelRules(d, job.SchemaName, tblInfo.Name.L, droppedNames)
+		if err != nil {
+			return ver, errors.Wrapf(err, "failed to notify PD the label rules")
+		}
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+		asyncNotifyEvent(d, &util.Event{Tp: model.ActionReorganizePartition, TableInfo: tblInfo, PartInfo: &model.PartitionInfo{Definitions: newDefs}})
+		// A background job will be created to delete the data of the reorganized partitions.
+		job.Args = []interface{}{physicalTableIDs}
+	default:
+		err = ErrInvalidDDLState.GenWithStackByArgs("partition", job.SchemaState)
+	}
+
+	return ver, errors.Trace(err)
+}
+
+// reorgPartitionData copies the rows of the reorganized partitions into the new partitions.
+// It returns true if the reorganization is finished.
+func (w *worker) reorgPartitionData(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo) (bool, int64, error) {
+	var ver int64
+	tbl, err := getTable(d.store, job.SchemaID, tblInfo)
+	if err != nil {
+		return false, ver, errors.Trace(err)
+	}
+	pt, ok := tbl.(table.PartitionedTable)
+	if !ok {
+		job.State = model.JobStateCancelled
+		return false, ver, errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+
+	physicalTableIDs := getPartitionIDsFromDefinitions(tblInfo.Partition.DroppingDefinitions)
+	elements := []*meta.Element{{ID: tblInfo.ID, TypeKey: meta.PartitionElementKey}}
+	reorgInfo, err := getReorgInfoFromPartitions(d, t, job, tbl, physicalTableIDs, elements)
+	if err != nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, ver, errors.Trace(err)
+	}
+
+	err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (reorgErr error) {
+		defer tidbutil.Recover(metrics.LabelDDL, "reorgPartitionData",
+			func() {
+				reorgErr = errCancelledDDLJob.GenWithStack("reorganize table `%v` partitions panic", tblInfo.Name)
+			}, false)
+		return w.reorgPartitionRecords(pt, physicalTableIDs, reorgInfo)
+	})
+	if err != nil {
+		if errWaitReorgTimeout.Equal(err) {
+			// if timeout, we should return, check for the owner and re-wait job done.
+			return false, ver, nil
+		}
+		if table.ErrNoPartitionForGivenValue.Equal(err) || kv.ErrKeyExists.Equal(err) || errCancelledDDLJob.Equal(err) || errCantDecodeRecord.Equal(err) {
+			logutil.BgLogger().Warn("[ddl] run reorganize partition job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+			job.State = model.JobStateRollingback
+			if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+				logutil.BgLogger().Warn("[ddl] run reorganize partition job failed, convert job to rollback, RemoveDDLReorgHandle failed", zap.String("job", job.String()), zap.Error(err1))
+			}
+		}
+		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+		w.reorgCtx.cleanNotifyReorgCancel()
+		return false, ver, errors.Trace(err)
+	}
+	// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+	w.reorgCtx.cleanNotifyReorgCancel()
+	return true, ver, nil
+}
+
+// reorgPartitionRecords copies the rows of the reorganized partitions one by one.
+func (w *worker) reorgPartitionRecords(t table.PartitionedTable, physicalTableIDs []int64, reorgInfo *reorgInfo) error {
+	var err error
+	var finish bool
+	for !finish {
+		p := t.GetPartition(reorgInfo.PhysicalTableID)
+		if p == nil {
+			return errCancelledDDLJob.GenWithStack("Can not find partition id %d for table %d", reorgInfo.PhysicalTableID, t.Meta().ID)
+		}
+		logutil.BgLogger().Info("[ddl] start to reorganize partition", zap.String("job", reorgInfo.Job.String()), zap.String("reorgInfo", reorgInfo.String()))
+		err = w.writePhysicalTableRecord(p, typeReorgPartitionWorker, nil, nil, nil, reorgInfo)
+		if err != nil {
+			break
+		}
+		finish, err = w.updateReorgInfoForPartitions(t, reorgInfo, physicalTableIDs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(err)
+}
+
+// getReorganizedTableInfo builds the table info with the reorganized partitions replaced by the new ones.
+func getReorganizedTableInfo(t *model.TableInfo) *model.TableInfo {
+	p := t.Partition
+	nt := t.Clone()
+	np := *p
+	np.Definitions = tables.ReplacePartitionDefinitions(p.Definitions, p.DroppingDefinitions, p.AddingDefinitions)
+	np.AddingDefinitions = nil
+	np.DroppingDefinitions = nil
+	np.States = nil
+	nt.Partition = &np
+	return nt
+}
+
+type reorgPartitionRecord struct {
+	key    kv.Key // It's used to lock the record in the reorganized partition.
+	newKey kv.Key // It's the record key in the new partition.
+	vals   []byte
+	handle kv.Handle
+	row    []types.Datum
+	part   table.PhysicalTable
+}
+
+type reorgPartitionWorker struct {
+	*backfillWorker
+	reorgedTbl    table.PartitionedTable
+	metricCounter prometheus.Counter
+
+	// The following attributes are used to reduce memory allocation.
+	rowDecoder *decoder.RowDecoder
+	rowMap     map[int64]types.Datum
+	fieldTypes []*types.FieldType
+	rowRecords []*reorgPartitionRecord
+}
+
+func newReorgPartitionWorker(sessCtx sessionctx.Context, worker *worker, id int, t table.PhysicalTable, decodeColMap map[int64]decoder.Column, reorgInfo *reorgInfo) (*reorgPartitionWorker, error) {
+	reorgedTbl, err := getTable(reorgInfo.d.store, reorgInfo.Job.SchemaID, getReorganizedTableInfo(t.Meta()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The rows are decoded in UTC, locate the partitions in the same time zone.
+	sessCtx.GetSessionVars().TimeZone = time.UTC
+	cols := t.WritableCols()
+	fieldTypes := make([]*types.FieldType, 0, len(cols))
+	for _, col := range cols {
+		fieldTypes = append(fieldTypes, &col.FieldType)
+	}
+	return &reorgPartitionWorker{
+		backfillWorker: newBackfillWorker(sessCtx, worker, id, t),
+		reorgedTbl:     reorgedTbl.(table.PartitionedTable),
+		metricCounter:  metrics.BackfillTotalCounter.WithLabelValues("reorg_partition_speed"),
+		rowDecoder:     decoder.NewRowDecoder(t, cols, decodeColMap),
+		rowMap:         make(map[int64]types.Datum, len(decodeColMap)),
+		fieldTypes:     fieldTypes,
+	}, nil
+}
+
+func (w *reorgPartitionWorker) AddMetricInfo(cnt float64) {
+	w.metricCounter.Add(cnt)
+}
+
+func (w *reorgPartitionWorker) getRowRecord(handle kv.Handle, recordKey kv.Key, rawRow []byte) error {
+	for id := range w.rowMap {
+		delete(w.rowMap, id)
+	}
+	_, err := w.rowDecoder.DecodeAndEvalRowWithMap(w.sessCtx, handle, rawRow, time.UTC, timeutil.SystemLocation(), w.rowMap)
+	if err != nil {
+		return errors.Trace(errCantDecodeRecord.GenWithStackByArgs("partition", err))
+	}
+	row := types.CloneRow(w.rowDecoder.CurrentRowWithDefaultVal().GetDatumRow(w.fieldTypes))
+	part, err := w.reorgedTbl.GetPartitionByRow(w.sessCtx, row)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newKey := tablecodec.EncodeRecordKey(part.RecordPrefix(), handle)
+	w.rowRecords = append(w.rowRecords, &reorgPartitionRecord{key: recordKey, newKey: newKey, vals: rawRow, handle: handle, row: row, part: part})
+	return nil
+}
+
+func (w *reorgPartitionWorker) fetchRowColVals(txn kv.Transaction, taskRange reorgBackfillTask) ([]*reorgPartitionRecord, kv.Key, bool, error) {
+	w.rowRecords = w.rowRecords[:0]
+	startTime := time.Now()
+
+	// taskDone means that the reorganized handle is out of taskRange.endHandle.
+	taskDone := false
+	var lastAccessedHandle kv.Key
+	err := iterateSnapshotRows(w.sessCtx.GetStore(), w.priority, w.table, txn.StartTS(), taskRange.startKey, taskRange.endKey,
+		func(handle kv.Handle, recordKey kv.Key, rawRow []byte) (bool, error) {
+			taskDone = recordKey.Cmp(taskRange.endKey) > 0
+			if taskDone || len(w.rowRecords) >= w.batchCnt {
+				return false, nil
+			}
+
+			if err1 := w.getRowRecord(handle, recordKey, rawRow); err1 != nil {
+				return false, errors.Trace(err1)
+			}
+			lastAccessedHandle = recordKey
+			if recordKey.Cmp(taskRange.endKey) == 0 {
+				taskDone = true
+				return false, nil
+			}
+			return true, nil
+		})
+
+	if len(w.rowRecords) == 0 {
+		taskDone = true
+	}
+
+	logutil.BgLogger().Debug("[ddl] txn fetches handle info", zap.Uint64("txnStartTS", txn.StartTS()), zap.String("taskRange", taskRange.String()), zap.Duration("takeTime", time.Since(startTime)))
+	var nextKey kv.Key
+	if taskDone {
+		nextKey = taskRange.endKey.Next()
+	} else {
+		nextKey = lastAccessedHandle.Next()
+	}
+	return w.rowRecords, nextKey, taskDone, errors.Trace(err)
+}
+
+// BackfillDataInTxn copies the rows of the handle range into the new partitions in a transaction, the rows written
+// into the new partitions by the DML statements are skipped.
+func (w *reorgPartitionWorker) BackfillDataInTxn(handleRange reorgBackfillTask) (taskCtx backfillTaskContext, errInTxn error) {
+	oprStartTime := time.Now()
+	errInTxn = kv.RunInNewTxn(context.Background(), w.sessCtx.GetStore(), true, func(ctx context.Context, txn kv.Transaction) error {
+		taskCtx.addedCount = 0
+		taskCtx.scanCount = 0
+		txn.SetOption(kv.Priority, w.priority)
+
+		rowRecords, nextKey, taskDone, err := w.fetchRowColVals(txn, handleRange)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		taskCtx.nextKey = nextKey
+		taskCtx.done = taskDone
+
+		newKeys := make([]kv.Key, 0, len(rowRecords))
+		for _, record := range rowRecords {
+			newKeys = append(newKeys, record.newKey)
+		}
+		existed, err := txn.BatchGet(ctx, newKeys)
+		if err != nil {
+			return errors.Trace(err)
+		}
+
+		for _, record := range rowRecords {
+			taskCtx.scanCount++
+			if _, ok := existed[string(record.newKey)]; ok {
+				continue
+			}
+
+			// Lock the row in the reorganized partition, the DML statements changing it at the same time conflict with the backfill.
+			err = txn.LockKeys(context.Background(), new(kv.LockCtx), record.key)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			err = txn.Set(record.newKey, record.vals)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for _, idx := range record.part.Indices() {
+				idxInfo := idx.Meta()
+				if w.table.Meta().IsCommonHandle && idxInfo.Primary {
+					continue
+				}
+				idxVals, err := idx.FetchValues(record.row, nil)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				rsData := tables.TryGetHandleRestoredDataWrapper(w.table, record.row, nil, idxInfo)
+				handle, err := idx.Create(w.sessCtx, txn, idxVals, record.handle, rsData)
+				if err != nil {
+					if kv.ErrKeyExists.Equal(err) && record.handle.Equal(handle) {
+						// Index already exists, skip it.
+						continue
+					}
+					return errors.Trace(err)
+				}
+			}
+			taskCtx.addedCount++
+		}
+		return nil
+	})
+	logSlowOperations(time.Since(oprStartTime), "reorgPartitionBackfillDataInTxn", 3000)
+
+	return
+}
+
 // onExchangeTablePartition exchange partition data
 func (w *worker) onExchangeTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	var (
@@ -1403,6 +2015,21 @@ func checkExchangePartitionRecordValidation(w *worker, pt *model.TableInfo, inde
 			return nil
 		}
 		var buf strings.Builder
+		if pi.IsLinear {
+			// The same as tables.HashPartitionIdx: take the value under the mask, and take it under the half mask if
+			// the partition doesn't exist.
+			mask := tables.LinearHashMask(pi.Num)
+			buf.WriteString("select 1 from %n.%n where if((")
+			buf.WriteString(pi.Expr)
+			buf.WriteString(") & %? >= %?, (")
+			buf.WriteString(pi.Expr)
+			buf.WriteString(") & %?, (")
+			buf.WriteString(pi.Expr)
+			buf.WriteString(") & %?) != %? limit 1")
+			sql = buf.String()
+			paramList = append(paramList, schemaName.L, tableName.L, mask, pi.Num, mask>>1, mask, index)
+			break
+		}
 		buf.WriteString("select 1 from %n.%n where mod(")
 		buf.WriteString(pi.Expr)
 		buf.WriteString(", %?) != %? limit 1")
@@ -1426,6 +2053,7 @@ func checkExchangePartitionRecordValidation(w *worker, pt *model.TableInfo, inde
 			sql, paramList = buildCheckSQLForListColumnsPartition(pi, index, schemaName, tableName)
 		}
 	default:
+		// KEY partitions are rejected by ExchangeTablePartition unless it's WITHOUT VALIDATION.
 		return errUnsupportedPartitionType.GenWithStackByArgs(pt.Name.O)
 	}
 
@@ -1579,7 +2207,11 @@ func checkPartitioningKeysConstraints(sctx sessionctx.Context, s *ast.CreateTabl
 		return nil
 	}
 
-	var partCols stringSlice
+	var (
+		partCols stringSlice
+		// keyIdx is the unique key used by `PARTITION BY KEY()`, it always satisfies the constraint.
+		keyIdx *model.IndexInfo
+	)
 	if s.Partition.Expr != nil {
 		extractCols := newPartitionExprChecker(sctx, tblInfo)
 		s.Partition.Expr.Accept(extractCols)
@@ -1590,8 +2222,16 @@ func checkPartitioningKeysConstraints(sctx sessionctx.Context, s *ast.CreateTabl
 		partCols = columnInfoSlice(partColumns)
 	} else if len(s.Partition.ColumnNames) > 0 {
 		partCols = columnNameSlice(s.Partition.ColumnNames)
+	} else if tblInfo.Partition.Type == model.PartitionTypeKey {
+		// The columns of `PARTITION BY KEY()` are taken from the primary key or an unique key.
+		partColumns := make([]*model.ColumnInfo, 0, len(tblInfo.Partition.Columns))
+		for _, col := range tblInfo.Partition.Columns {
+			partColumns = append(partColumns, getColumnInfoByName(tblInfo, col.L))
+		}
+		partCols = columnInfoSlice(partColumns)
+		keyIdx = getDefaultKeyPartitionIndex(tblInfo)
 	} else {
-		// TODO: Check keys constraints for list, key partition type and so on.
+		// TODO: Check keys constraints for list partition type and so on.
 		return nil
 	}
 
@@ -1599,13 +2239,15 @@ func checkPartitioningKeysConstraints(sctx sessionctx.Context, s *ast.CreateTabl
 	// Every unique key on the table must use every column in the table's partitioning expression.
 	// See https://dev.mysql.com/doc/refman/5.7/en/partitioning-limitations-partitioning-keys-unique-keys.html
 	for _, index := range tblInfo.Indices {
-		if index.Unique && !checkUniqueKeyIncludePartKey(partCols, index.Columns) {
+		if index.Unique && index != keyIdx && !checkUniqueKeyIncludePartKey(partCols, index.Columns) {
 			if index.Primary {
 				return ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("PRIMARY KEY")
 			}
 			if !config.GetGlobalConfig().EnableGlobalIndex {
 				return ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("UNIQUE INDEX")
 			}
+			// index columns does not contain all partition columns, must set global
+			index.Global = true
 		}
 	}
 	// when PKIsHandle, tblInfo.Indices will not contain the primary key.
@@ -55,12 +55,18 @@ type reorgCtx struct {
 	// TODO: Now we use goroutine to simulate reorganization jobs, later we may
 	// use a persistent job list.
 	doneCh chan error
+	// jobID is the ID of the job whose reorganization is running, it's valid if doneCh isn't nil.
+	jobID int64
 	// rowCount is used to simulate a job's row count.
 	rowCount int64
 	// notifyCancelReorgJob is used to notify the backfilling goroutine if the DDL job is cancelled.
 	// 0: job is not canceled.
 	// 1: job is canceled.
 	notifyCancelReorgJob int32
+	// notifyPauseReorgJob is used to notify the backfilling goroutine if the DDL job is paused.
+	// 0: job is not paused.
+	// 1: job is paused.
+	notifyPauseReorgJob int32
 	// doneHandle is used to simulate the handle that has been processed.
 
 	doneKey atomic.Value // nullable kv.Key
@@ -110,6 +116,18 @@ func (rc *reorgCtx) isReorgCanceled() bool {
 	return atomic.LoadInt32(&rc.notifyCancelReorgJob) == 1
 }
 
+func (rc *reorgCtx) notifyReorgPause() {
+	atomic.StoreInt32(&rc.notifyPauseReorgJob, 1)
+}
+
+func (rc *reorgCtx) cleanNotifyReorgPause() {
+	atomic.StoreInt32(&rc.notifyPauseReorgJob, 0)
+}
+
+func (rc *reorgCtx) isReorgPaused() bool {
+	return atomic.LoadInt32(&rc.notifyPauseReorgJob) == 1
+}
+
 func (rc *reorgCtx) setRowCount(count int64) {
 	atomic.StoreInt64(&rc.rowCount, count)
 }
@@ -154,6 +172,7 @@ func (rc *reorgCtx) clean() {
 	rc.setNextKey(nil)
 	rc.resetWarnings()
 	rc.doneCh = nil
+	rc.jobID = 0
 }
 
 // runReorgJob is used as a portal to do the reorganization work.
@@ -211,6 +230,7 @@ func (w *worker) runReorgJob(t *meta.Meta, reorgInfo *reorgInfo, tblInfo *model.
 		// start a reorganization job
 		w.wg.Add(1)
 		w.reorgCtx.doneCh = make(chan error, 1)
+		w.reorgCtx.jobID = job.ID
 		// initial reorgCtx
 		w.reorgCtx.setRowCount(job.GetRowCount())
 		w.reorgCtx.setNextKey(reorgInfo.StartKey)
@@ -295,6 +315,36 @@ func (w *worker) runReorgJob(t *meta.Meta, reorgInfo *reorgInfo, tblInfo *model.
 	return nil
 }
 
+// stopPausedReorg stops the background reorganization of the paused job, and saves its progress, so that
+// the reorganization continues from where it stops after the job is resumed, even on another DDL owner.
+func (w *worker) stopPausedReorg(d *ddlCtx, job *model.Job) error {
+	if w.reorgCtx.doneCh == nil {
+		return nil
+	}
+	w.reorgCtx.notifyReorgPause()
+	err := <-w.reorgCtx.doneCh
+	rowCount, doneKey, currentElement := w.reorgCtx.getRowCountAndKey()
+	w.reorgCtx.clean()
+	w.reorgCtx.cleanNotifyReorgPause()
+	logutil.BgLogger().Info("[ddl] reorg job is paused", zap.Int64("jobID", job.ID), zap.Int64("handled rows", rowCount),
+		zap.String("doneKey", tryDecodeToHandleString(doneKey)), zap.Error(err))
+	if currentElement == nil {
+		return nil
+	}
+	return kv.RunInNewTxn(w.ctx, d.store, false, func(ctx context.Context, txn kv.Transaction) error {
+		t := newMetaWithQueueTp(txn, w.tp)
+		pausedJob, idx, err := getDDLJobByID(t, job.ID)
+		if err != nil || pausedJob == nil {
+			return errors.Trace(err)
+		}
+		pausedJob.SetRowCount(rowCount)
+		if err = t.UpdateDDLJob(idx, pausedJob, true); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(t.UpdateDDLReorgStartHandle(pausedJob, currentElement, doneKey))
+	})
+}
+
 func (w *worker) mergeWarningsIntoJob(job *model.Job) {
 	w.reorgCtx.mu.Lock()
 	partWarnings := w.reorgCtx.mu.warnings
@@ -365,6 +415,11 @@ func (w *worker) isReorgRunnable(d *ddlCtx) error {
 		return errCancelledDDLJob
 	}
 
+	if w.reorgCtx.isReorgPaused() {
+		// Job is paused. It continues from the saved reorg handle after being resumed.
+		return errPausedDDLJob
+	}
+
 	if !d.isOwner() {
 		// If it's not the owner, we will try later, so here just returns an error.
 		logutil.BgLogger().Info("[ddl] DDL worker is not the DDL owner", zap.String("ID", d.uuid))
@@ -0,0 +1,133 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/resourcegroup"
+	"github.com/pingcap/tidb/sessionctx"
+)
+
+// checkResourceGroupName checks the name of the resource group which is created or dropped.
+func checkResourceGroupName(name model.CIStr) error {
+	if name.L == "" {
+		return errors.Errorf("the name of the resource group is empty")
+	}
+	if name.L == resourcegroup.DefaultGroupName {
+		return errors.Errorf("resource group '%s' is reserved", name.O)
+	}
+	return nil
+}
+
+// appendPerInstanceQuotaNote reminds the user that the quota is per TiDB instance when a non-zero quota is set.
+func appendPerInstanceQuotaNote(ctx sessionctx.Context, options []*ast.ResourceGroupOption) {
+	for _, op := range options {
+		if op.Tp == ast.ResourceGroupRUPerSec && op.UintValue > 0 {
+			ctx.GetSessionVars().StmtCtx.AppendNote(errors.Errorf(resourcegroup.PerInstanceQuotaNote))
+			return
+		}
+	}
+}
+
+// buildResourceGroup returns a copy of the resource group with the options applied.
+func buildResourceGroup(group *resourcegroup.GroupInfo, options []*ast.ResourceGroupOption) *resourcegroup.GroupInfo {
+	newGroup := *group
+	for _, op := range options {
+		switch op.Tp {
+		case ast.ResourceGroupRUPerSec:
+			newGroup.RUPerSec = op.UintValue
+		case ast.ResourceGroupBurstable:
+			newGroup.Burstable = op.BoolValue
+		}
+	}
+	return &newGroup
+}
+
+func onCreateResourceGroup(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	group := &resourcegroup.GroupInfo{}
+	if err := job.DecodeArgs(group); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	old, err := resourcegroup.GetResourceGroup(t, group.Name.L)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if old != nil {
+		job.State = model.JobStateCancelled
+		return ver, ErrResourceGroupExists.GenWithStackByArgs(group.Name.O)
+	}
+	return setResourceGroup(t, job, group)
+}
+
+func onAlterResourceGroup(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var (
+		name    model.CIStr
+		options []*ast.ResourceGroupOption
+	)
+	if err := job.DecodeArgs(&name, &options); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	old, err := resourcegroup.GetResourceGroup(t, name.L)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if old == nil {
+		job.State = model.JobStateCancelled
+		return ver, ErrResourceGroupNotExists.GenWithStackByArgs(name.O)
+	}
+	return setResourceGroup(t, job, buildResourceGroup(old, options))
+}
+
+func setResourceGroup(t *meta.Meta, job *model.Job, group *resourcegroup.GroupInfo) (ver int64, _ error) {
+	if err := resourcegroup.SetResourceGroup(t, group); err != nil {
+		return ver, errors.Trace(err)
+	}
+	ver, err := updateSchemaVersion(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, nil)
+	return ver, nil
+}
+
+func onDropResourceGroup(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var name model.CIStr
+	if err := job.DecodeArgs(&name); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	old, err := resourcegroup.GetResourceGroup(t, name.L)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if old == nil {
+		job.State = model.JobStateCancelled
+		return ver, ErrResourceGroupNotExists.GenWithStackByArgs(name.O)
+	}
+	if err = t.DropResourceGroup(name.L); err != nil {
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishDBJob(model.JobStateDone, model.StateNone, ver, nil)
+	return ver, nil
+}
@@ -150,8 +150,21 @@ func rollingbackModifyColumn(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 	return ver, errCancelledDDLJob
 }
 
-func rollingbackAddColumn(t *meta.Meta, job *model.Job) (ver int64, err error) {
-	tblInfo, columnInfo, col, _, _, err := checkAddColumn(t, job)
+func rollingbackAddColumn(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	// If the value of SnapshotVer isn't zero, it means the work is checking the rows for the check constraints.
+	if job.SchemaState == model.StateWriteReorganization && job.SnapshotVer != 0 {
+		// check constraint workers are started. need to ask them to exit.
+		logutil.Logger(w.logCtx).Info("[ddl] run the cancelling DDL job", zap.String("job", job.String()))
+		w.reorgCtx.notifyReorgCancel()
+		return w.onAddColumn(d, t, job)
+	}
+	return convertAddColumnJob2RollbackJob(t, job)
+}
+
+// convertAddColumnJob2RollbackJob converts the add column job to drop the column, the check constraints of
+// the column are removed with it.
+func convertAddColumnJob2RollbackJob(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, columnInfo, col, _, _, _, err := checkAddColumn(t, job)
 	if err != nil {
 		return ver, errors.Trace(err)
 	}
@@ -162,6 +175,7 @@ func rollingbackAddColumn(t *meta.Meta, job *model.Job) (ver int64, err error) {
 
 	originalState := columnInfo.State
 	columnInfo.State = model.StateDeleteOnly
+	removeDependentCheckConstraints(tblInfo, columnInfo.Name)
 	job.SchemaState = model.StateDeleteOnly
 
 	job.Args = []interface{}{col.Name}
@@ -174,8 +188,21 @@ func rollingbackAddColumn(t *meta.Meta, job *model.Job) (ver int64, err error) {
 	return ver, errCancelledDDLJob
 }
 
-func rollingbackAddColumns(t *meta.Meta, job *model.Job) (ver int64, err error) {
-	tblInfo, columnInfos, _, _, _, _, err := checkAddColumns(t, job)
+func rollingbackAddColumns(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	// If the value of SnapshotVer isn't zero, it means the work is checking the rows for the check constraints.
+	if job.SchemaState == model.StateWriteReorganization && job.SnapshotVer != 0 {
+		// check constraint workers are started. need to ask them to exit.
+		logutil.Logger(w.logCtx).Info("[ddl] run the cancelling DDL job", zap.String("job", job.String()))
+		w.reorgCtx.notifyReorgCancel()
+		return w.onAddColumns(d, t, job, false)
+	}
+	return convertAddColumnsJob2RollbackJob(t, job)
+}
+
+// convertAddColumnsJob2RollbackJob converts the add columns job to drop the columns, the check constraints of
+// the columns are removed with them.
+func convertAddColumnsJob2RollbackJob(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, columnInfos, _, _, _, _, _, err := checkAddColumns(t, job)
 	if err != nil {
 		return ver, errors.Trace(err)
 	}
@@ -188,6 +215,7 @@ func rollingbackAddColumns(t *meta.Meta, job *model.Job) (ver int64, err error)
 	originalState := columnInfos[0].State
 	for i, columnInfo := range columnInfos {
 		columnInfos[i].State = model.StateDeleteOnly
+		removeDependentCheckConstraints(tblInfo, columnInfo.Name)
 		colNames[i] = columnInfo.Name
 	}
 	ifExists := make([]bool, len(columnInfos))
@@ -337,6 +365,73 @@ func rollingbackAddIndex(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, isP
 	return
 }
 
+// convertCheckConstraintJob2RollbackJob converts the add or alter check constraint job to rollingbackJob.
+// The rolling back add check constraint job drops the constraint, and the rolling back alter check constraint
+// job makes the constraint not enforced.
+func convertCheckConstraintJob2RollbackJob(job *model.Job, constrName model.CIStr) {
+	if job.Type == model.ActionAddCheckConstraint {
+		job.Args = []interface{}{constrName}
+	} else {
+		job.Args = []interface{}{constrName, false}
+	}
+	job.State = model.JobStateRollingback
+}
+
+func rollingbackCheckConstraint(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	// If the value of SnapshotVer isn't zero, it means the work is checking the rows.
+	if job.SchemaState == model.StateWriteReorganization && job.SnapshotVer != 0 {
+		// check constraint workers are started. need to ask them to exit.
+		logutil.Logger(w.logCtx).Info("[ddl] run the cancelling DDL job", zap.String("job", job.String()))
+		w.reorgCtx.notifyReorgCancel()
+		if job.Type == model.ActionAddCheckConstraint {
+			return w.onAddCheckConstraint(d, t, job)
+		}
+		return w.onAlterCheckConstraint(d, t, job)
+	}
+	if job.SchemaState == model.StateNone {
+		job.State = model.JobStateCancelled
+		return ver, errCancelledDDLJob
+	}
+
+	// check constraint workers are not started, drop the constraint or make it not enforced.
+	var constrName model.CIStr
+	if job.Type == model.ActionAddCheckConstraint {
+		constrInfo := &model.ConstraintInfo{}
+		err = job.DecodeArgs(constrInfo)
+		constrName = constrInfo.Name
+	} else {
+		err = job.DecodeArgs(&constrName)
+	}
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	convertCheckConstraintJob2RollbackJob(job, constrName)
+	return ver, errCancelledDDLJob
+}
+
+func rollingbackReorganizePartition(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	// If the value of SnapshotVer isn't zero, it means the work is copying the rows.
+	if job.SchemaState == model.StateWriteReorganization && job.SnapshotVer != 0 {
+		// reorganize partition workers are started. need to ask them to exit.
+		logutil.Logger(w.logCtx).Info("[ddl] run the cancelling DDL job", zap.String("job", job.String()))
+		w.reorgCtx.notifyReorgCancel()
+		return w.onReorganizePartition(d, t, job)
+	}
+	switch job.SchemaState {
+	case model.StateNone:
+		job.State = model.JobStateCancelled
+		return ver, errCancelledDDLJob
+	case model.StateDeleteOnly, model.StateWriteOnly, model.StateWriteReorganization:
+		// reorganize partition workers are not started, remove the new partitions.
+		job.State = model.JobStateRollingback
+		return ver, errCancelledDDLJob
+	}
+	// The new partitions have replaced the old ones, the job can't be rolled back.
+	job.State = model.JobStateRunning
+	return ver, nil
+}
+
 func convertAddTablePartitionJob2RollbackJob(t *meta.Meta, job *model.Job, otherwiseErr error, tblInfo *model.TableInfo) (ver int64, err error) {
 	addingDefinitions := tblInfo.Partition.AddingDefinitions
 	partNames := make([]string, 0, len(addingDefinitions))
@@ -442,9 +537,9 @@ func rollingbackTruncateTable(t *meta.Meta, job *model.Job) (ver int64, err erro
 func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
 	switch job.Type {
 	case model.ActionAddColumn:
-		ver, err = rollingbackAddColumn(t, job)
+		ver, err = rollingbackAddColumn(w, d, t, job)
 	case model.ActionAddColumns:
-		ver, err = rollingbackAddColumns(t, job)
+		ver, err = rollingbackAddColumns(w, d, t, job)
 	case model.ActionAddIndex:
 		ver, err = rollingbackAddIndex(w, d, t, job, false)
 	case model.ActionAddPrimaryKey:
@@ -471,12 +566,18 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		ver, err = rollingbackTruncateTable(t, job)
 	case model.ActionModifyColumn:
 		ver, err = rollingbackModifyColumn(w, d, t, job)
+	case model.ActionAddCheckConstraint, model.ActionAlterCheckConstraint:
+		ver, err = rollingbackCheckConstraint(w, d, t, job)
+	case model.ActionReorganizePartition:
+		ver, err = rollingbackReorganizePartition(w, d, t, job)
+	case model.ActionMultiSchemaChange:
+		ver, err = rollingbackMultiSchemaChange(w, d, t, job)
 	case model.ActionRebaseAutoID, model.ActionShardRowID, model.ActionAddForeignKey,
-		model.ActionDropForeignKey, model.ActionRenameTable, model.ActionRenameTables,
+		model.ActionDropForeignKey, model.ActionDropCheckConstraint, model.ActionRenameTable, model.ActionRenameTables,
 		model.ActionModifyTableCharsetAndCollate, model.ActionTruncateTablePartition,
 		model.ActionModifySchemaCharsetAndCollate, model.ActionRepairTable,
 		model.ActionModifyTableAutoIdCache, model.ActionAlterIndexVisibility,
-		model.ActionExchangeTablePartition:
+		model.ActionExchangeTablePartition, model.ActionAlterCacheTable, model.ActionAlterNoCacheTable:
 		ver, err = cancelOnlyNotHandledJob(job)
 	default:
 		job.State = model.JobStateCancelled
@@ -27,6 +27,7 @@ import (
 	. "github.com/pingcap/check"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/parser/auth"
 	"github.com/pingcap/parser/model"
 	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/tidb/config"
@@ -1829,3 +1830,158 @@ func (s *testSerialDBSuite) TestLocalTemporaryTableBlockedDDL(c *C) {
 	err = tk.ExecToErr("admin cleanup table lock tmp1")
 	c.Assert(ddl.ErrUnsupportedLocalTempTableDDL.Equal(err), IsTrue)
 }
+
+func (s *testSerialSuite) TestMetadataLock(c *C) {
+	tk := testkit.NewTestKitWithInit(c, s.store)
+	tk.MustExec("set @@global.tidb_enable_metadata_lock = 1")
+	defer tk.MustExec("set @@global.tidb_enable_metadata_lock = 0")
+	tk.MustExec("drop table if exists t_mdl")
+	tk.MustExec("create table t_mdl (a int primary key, b int)")
+	tk.MustExec("insert into t_mdl values (1, 1)")
+
+	tk1 := testkit.NewTestKitWithInit(c, s.store)
+	tk2 := testkit.NewTestKitWithInit(c, s.store)
+	c.Assert(tk2.Se.Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil), IsTrue)
+	tk.Se.SetConnectionID(1)
+
+	tk.MustExec("begin")
+	tk.MustExec("insert into t_mdl values (2, 2)")
+	done := make(chan error, 1)
+	go func() {
+		done <- tk1.ExecToErr("alter table t_mdl add index idx(b)")
+	}()
+
+	// The DDL job waits for the transaction using the table.
+	time.Sleep(500 * time.Millisecond)
+	select {
+	case err := <-done:
+		c.Fatalf("the DDL job should wait for the transaction, err: %v", err)
+	default:
+	}
+	tk2.MustQuery("select db_name, table_name, query, session_id, sql_text from information_schema.tidb_mdl_view").Check(testkit.Rows(
+		"test t_mdl alter table t_mdl add index idx(b) 1 insert into t_mdl values (2, 2)"))
+
+	tk.MustExec("insert into t_mdl values (3, 3)")
+	tk.MustExec("commit")
+	c.Assert(<-done, IsNil)
+	tk2.MustQuery("select count(*) from information_schema.tidb_mdl_view").Check(testkit.Rows("0"))
+	tk.MustQuery("select * from t_mdl use index(idx) order by b").Check(testkit.Rows("1 1", "2 2", "3 3"))
+	tk.MustExec("admin check table t_mdl")
+
+	// The transaction can't lock the table which is changed after the schema version of the transaction.
+	tk.MustExec("drop table if exists t_mdl_other")
+	tk.MustExec("create table t_mdl_other (a int primary key)")
+	tk.MustExec("begin")
+	tk.MustExec("insert into t_mdl_other values (1)")
+	tk1.MustExec("alter table t_mdl add column c int")
+	_, err := tk.Exec("insert into t_mdl (a, b) values (4, 4)")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Matches, ".*Information schema is changed.*")
+	tk.MustExec("rollback")
+	tk2.MustQuery("select count(*) from information_schema.tidb_mdl_view").Check(testkit.Rows("0"))
+
+	// The transaction which doesn't hold the metadata lock is not blocked.
+	tk.MustExec("set @@global.tidb_enable_metadata_lock = 0")
+	tk.MustExec("begin")
+	tk.MustExec("insert into t_mdl (a, b) values (4, 4)")
+	tk1.MustExec("alter table t_mdl drop index idx")
+	_, err = tk.Exec("commit")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Matches, ".*Information schema is changed.*")
+}
+
+func (s *testSerialSuite) TestPauseAndResumeDDLJob(c *C) {
+	tk := testkit.NewTestKitWithInit(c, s.store)
+	tk.MustExec("drop table if exists t_pause")
+	tk.MustExec("create table t_pause (a int primary key, b int)")
+	for i := 0; i < 10; i++ {
+		tk.MustExec("insert into t_pause values (?, ?)", i, i)
+	}
+
+	controlJob := func(control func(kv.Transaction, []int64) ([]error, error), jobID int64) error {
+		return kv.RunInNewTxn(context.Background(), s.store, true, func(ctx context.Context, txn kv.Transaction) error {
+			errs, err := control(txn, []int64{jobID})
+			if err != nil {
+				return err
+			}
+			return errs[0]
+		})
+	}
+
+	var jobID int64
+	var checkErr error
+	hook := &ddl.TestDDLCallback{}
+	hook.OnJobUpdatedExported = func(job *model.Job) {
+		if job.Type == model.ActionAddIndex && job.SchemaState == model.StateWriteReorganization && atomic.LoadInt64(&jobID) == 0 {
+			checkErr = controlJob(admin.PauseJobs, job.ID)
+			atomic.StoreInt64(&jobID, job.ID)
+		}
+	}
+	origHook := s.dom.DDL().GetHook()
+	defer s.dom.DDL().(ddl.DDLForTest).SetHook(origHook)
+	s.dom.DDL().(ddl.DDLForTest).SetHook(hook)
+
+	done := make(chan error, 1)
+	go func() {
+		tk1 := testkit.NewTestKitWithInit(c, s.store)
+		done <- tk1.ExecToErr("alter table t_pause add index idx(b)")
+	}()
+
+	// The paused job stays in write reorganization state.
+	for atomic.LoadInt64(&jobID) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Assert(checkErr, IsNil)
+	time.Sleep(500 * time.Millisecond)
+	select {
+	case err := <-done:
+		c.Fatalf("the paused DDL job shouldn't be finished, err: %v", err)
+	default:
+	}
+	id := strconv.FormatInt(atomic.LoadInt64(&jobID), 10)
+	jobRow := func(id string) []interface{} {
+		for _, row := range tk.MustQuery("admin show ddl jobs").Rows() {
+			if row[0] == id {
+				return row
+			}
+		}
+		c.Fatalf("DDL job %s not found", id)
+		return nil
+	}
+	row := jobRow(id)
+	c.Assert(row[4], Equals, "write reorganization")
+	c.Assert(row[10], Equals, "paused")
+	tk.MustExec("insert into t_pause values (10, 10)")
+
+	// The jobs of the other tables behind the paused job still run, while the jobs of the same table wait.
+	tk.MustExec("drop table if exists t_pause_other")
+	tk.MustExec("create table t_pause_other (a int primary key, b int)")
+	tk.MustExec("insert into t_pause_other values (1, 1)")
+	tk.MustExec("alter table t_pause_other add index idx(b)")
+	tk.MustExec("admin check table t_pause_other")
+	sameTableDone := make(chan error, 1)
+	go func() {
+		tk1 := testkit.NewTestKitWithInit(c, s.store)
+		sameTableDone <- tk1.ExecToErr("alter table t_pause add index idx_ab(a, b)")
+	}()
+	time.Sleep(500 * time.Millisecond)
+	select {
+	case err := <-sameTableDone:
+		c.Fatalf("the DDL job of the paused table shouldn't be finished, err: %v", err)
+	default:
+	}
+	c.Assert(jobRow(id)[10], Equals, "paused")
+
+	tk.MustQuery("admin resume ddl jobs " + id).Check(testkit.Rows(id + " successful"))
+	c.Assert(<-done, IsNil)
+	c.Assert(<-sameTableDone, IsNil)
+	c.Assert(jobRow(id)[10], Equals, "synced")
+	tk.MustExec("admin check table t_pause")
+	tk.MustQuery("select count(*) from t_pause use index(idx)").Check(testkit.Rows("11"))
+	tk.MustQuery("select count(*) from t_pause use index(idx_ab)").Check(testkit.Rows("11"))
+
+	rows := tk.MustQuery("admin resume ddl jobs " + id).Rows()
+	c.Assert(rows[0][1], Matches, ".*DDL Job:"+id+" not found")
+	rows = tk.MustQuery("admin pause ddl jobs " + id).Rows()
+	c.Assert(rows[0][1], Matches, ".*DDL Job:"+id+" not found")
+}
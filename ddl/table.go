@@ -925,6 +925,71 @@ func onModifyTableComment(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	return ver, nil
 }
 
+// checkCacheTable checks whether the table can be cached.
+func checkCacheTable(tblInfo *model.TableInfo) error {
+	if tblInfo.TempTableType != model.TempTableNone {
+		return ErrOptOnTemporaryTable.GenWithStackByArgs("alter temporary table cache")
+	}
+	if tblInfo.Partition != nil {
+		return ErrOptOnCacheTable.GenWithStackByArgs("partition mode")
+	}
+	return nil
+}
+
+func onAlterCacheTable(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	return onAlterTableCacheStatus(t, job, meta.TableCacheStatusEnable)
+}
+
+func onAlterNoCacheTable(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	return onAlterTableCacheStatus(t, job, meta.TableCacheStatusDisable)
+}
+
+// onAlterTableCacheStatus switches the cache status of the table in two steps. In the switching status, the writes
+// acquire the lock of the table while the reads aren't served from the cache, so the TiDBs with either of the
+// adjacent schema versions never read the stale data from the cache.
+func onAlterTableCacheStatus(t *meta.Meta, job *model.Job, target meta.TableCacheStatusType) (ver int64, _ error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if target == meta.TableCacheStatusEnable {
+		if err = checkCacheTable(tblInfo); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+	}
+	status, err := t.GetTableCacheStatus(tblInfo.ID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if status == target {
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+		return ver, nil
+	}
+
+	switch job.SchemaState {
+	case model.StateNone:
+		if err = t.SetTableCacheStatus(tblInfo.ID, meta.TableCacheStatusSwitching); err != nil {
+			return ver, errors.Trace(err)
+		}
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateWriteOnly
+	default:
+		if err = t.SetTableCacheStatus(tblInfo.ID, target); err != nil {
+			return ver, errors.Trace(err)
+		}
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	}
+	return ver, nil
+}
+
 func onModifyTableCharsetAndCollate(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	var toCharset, toCollate string
 	var needsOverwriteCols bool
@@ -0,0 +1,140 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/ttl"
+)
+
+// checkTTLColumn checks whether the column can be used in the TTL definition of the table.
+func checkTTLColumn(tblInfo *model.TableInfo, colName model.CIStr) error {
+	col := model.FindColumnInfo(tblInfo.Columns, colName.L)
+	if col == nil || col.State != model.StatePublic || col.Hidden {
+		return errors.Trace(errKeyColumnDoesNotExits.GenWithStackByArgs(colName.O))
+	}
+	if !ttl.IsValidColumn(col) {
+		return errors.Trace(ErrUnsupportedColumnInTTLConfig.GenWithStackByArgs(colName.O))
+	}
+	return nil
+}
+
+// buildTTLInfo applies the TTL and TTL_ENABLE table options to the TTL definition of the table.
+// It returns the original definition if neither option is set.
+func buildTTLInfo(ttlInfo *model.TTLInfo, options []*ast.TableOption) (*model.TTLInfo, error) {
+	for _, op := range options {
+		if op.Tp != ast.TableOptionTTL {
+			continue
+		}
+		unit, validUnit := op.TimeUnitValue.Unit, false
+		switch unit {
+		case ast.TimeUnitSecond, ast.TimeUnitMinute, ast.TimeUnitHour, ast.TimeUnitDay, ast.TimeUnitWeek,
+			ast.TimeUnitMonth, ast.TimeUnitQuarter, ast.TimeUnitYear:
+			validUnit = true
+		}
+		if !validUnit || op.UintValue == 0 || op.UintValue > math.MaxInt64 {
+			def := fmt.Sprintf("%s + INTERVAL %d %s", op.ColumnName.Name.O, op.UintValue, unit)
+			return nil, errors.Trace(ErrInvalidTTLDefinition.GenWithStackByArgs(def))
+		}
+		enable := true
+		if ttlInfo != nil {
+			enable = ttlInfo.Enable
+		}
+		ttlInfo = &model.TTLInfo{ColumnName: op.ColumnName.Name, Interval: int64(op.UintValue), IntervalTimeUnit: int(unit), Enable: enable}
+	}
+	for _, op := range options {
+		if op.Tp != ast.TableOptionTTLEnable {
+			continue
+		}
+		if ttlInfo == nil {
+			return nil, errors.Trace(ErrSetTTLOptionForNonTTLTable.GenWithStackByArgs("TTL_ENABLE"))
+		}
+		ttlInfo = ttlInfo.Clone()
+		ttlInfo.Enable = op.BoolValue
+	}
+	return ttlInfo, nil
+}
+
+// onAlterTableTTL sets the TTL definition of the table, or removes it if the definition is nil.
+func onAlterTableTTL(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var ttlInfo *model.TTLInfo
+	if err := job.DecodeArgs(&ttlInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	if ttlInfo != nil {
+		if err = checkTTLColumn(tblInfo, ttlInfo.ColumnName); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+	}
+	tblInfo.TTLInfo = ttlInfo
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// isTTLColumn checks whether the column is used in the TTL definition of the table.
+func isTTLColumn(tblInfo *model.TableInfo, colName model.CIStr) bool {
+	return tblInfo.TTLInfo != nil && tblInfo.TTLInfo.ColumnName.L == colName.L
+}
+
+// checkDropColumnWithTTL checks whether the dropped column is used in the TTL definition of the table.
+func checkDropColumnWithTTL(tblInfo *model.TableInfo, colInfo *model.ColumnInfo) error {
+	if isTTLColumn(tblInfo, colInfo.Name) {
+		return ErrTTLColumnCannotDrop.GenWithStackByArgs(colInfo.Name.O)
+	}
+	return nil
+}
+
+// checkModifyColumnWithTTL checks whether the modified column can still be used in the TTL definition of the table.
+func checkModifyColumnWithTTL(tblInfo *model.TableInfo, oldCol, newCol *model.ColumnInfo) error {
+	if isTTLColumn(tblInfo, oldCol.Name) && !ttl.IsValidColumn(newCol) {
+		return errors.Trace(ErrUnsupportedColumnInTTLConfig.GenWithStackByArgs(newCol.Name.O))
+	}
+	return nil
+}
+
+// renameTTLColumn makes the TTL definition of the table follow the renamed column.
+func renameTTLColumn(tblInfo *model.TableInfo, oldName, newName model.CIStr) {
+	if isTTLColumn(tblInfo, oldName) {
+		tblInfo.TTLInfo.ColumnName = newName
+	}
+}
+
+// checkTTLInfoCompatible checks whether the rows can be exchanged between the tables without changing when they expire.
+func checkTTLInfoCompatible(source, target *model.TTLInfo) bool {
+	if source == nil || target == nil {
+		return source == target
+	}
+	return source.ColumnName.L == target.ColumnName.L &&
+		source.Interval == target.Interval &&
+		source.IntervalTimeUnit == target.IntervalTimeUnit &&
+		source.Enable == target.Enable
+}
@@ -16,6 +16,7 @@ package util
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"strconv"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/parser/model"
 	"github.com/pingcap/parser/terror"
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/owner"
@@ -44,6 +46,12 @@ const (
 	// DDLGlobalSchemaVersion is the path on etcd that is used to store the latest schema versions.
 	// It's exported for testing.
 	DDLGlobalSchemaVersion = "/tidb/ddl/global_schema_version"
+	// DDLAllMetadataLocks is the path on etcd that is used to store the metadata locks held by all servers.
+	// It's exported for testing.
+	DDLAllMetadataLocks = "/tidb/ddl/all_mdls"
+	// DDLMetadataLockWait is the path on etcd that is used to store the DDL job waiting for the metadata locks.
+	// It's exported for testing.
+	DDLMetadataLockWait = "/tidb/ddl/mdl_wait"
 	// InitialVersion is the initial schema version for every server.
 	// It's exported for testing.
 	InitialVersion       = "0"
@@ -89,6 +97,14 @@ type SchemaSyncer interface {
 	// the latest schema version. If the result is false, wait for a while and check again util the processing time reach 2 * lease.
 	// It returns until all servers' versions are equal to the latest version or the ctx is done.
 	OwnerCheckAllVersions(ctx context.Context, latestVer int64) error
+	// UpdateSelfMDLs updates the metadata locks held by the transactions of this server to the self path on etcd.
+	UpdateSelfMDLs(ctx context.Context, mdls MDLs) error
+	// OwnerGetAllMDLs gets the metadata locks held by the transactions of all servers.
+	OwnerGetAllMDLs(ctx context.Context) ([]MDLs, error)
+	// OwnerUpdateMDLWait updates the DDL job waiting for the metadata locks to etcd, nil means no job is waiting.
+	OwnerUpdateMDLWait(ctx context.Context, wait *MDLWaitInfo) error
+	// GetMDLWait gets the DDL job waiting for the metadata locks, it returns nil if no job is waiting.
+	GetMDLWait(ctx context.Context) (*MDLWaitInfo, error)
 	// NotifyCleanExpiredPaths informs to clean up expired paths.
 	// The returned value is used for testing.
 	NotifyCleanExpiredPaths() bool
@@ -98,17 +114,42 @@ type SchemaSyncer interface {
 	Close()
 }
 
+// MDLs are the metadata locks held by the transactions of a server. It maps the table ID to the
+// smallest schema version with which the transactions use the table.
+type MDLs map[int64]int64
+
+// MDLWaitInfo is the DDL job waiting for the transactions which use the tables with a schema version
+// older than SchemaVersion to release the metadata locks.
+type MDLWaitInfo struct {
+	Job           *model.Job `json:"job"`
+	TableIDs      []int64    `json:"table_ids"`
+	SchemaVersion int64      `json:"schema_version"`
+}
+
+// IsHeldBy checks whether the metadata locks are blocking the wait.
+func (w *MDLWaitInfo) IsHeldBy(mdls MDLs) bool {
+	for _, id := range w.TableIDs {
+		if ver, ok := mdls[id]; ok && ver < w.SchemaVersion {
+			return true
+		}
+	}
+	return false
+}
+
 type ownerChecker interface {
 	IsOwner() bool
 }
 
 type schemaVersionSyncer struct {
 	selfSchemaVerPath string
+	selfMDLPath       string
 	etcdCli           *clientv3.Client
 	session           unsafe.Pointer
 	mu                struct {
 		sync.RWMutex
 		globalVerCh clientv3.WatchChan
+		// selfMDLs is the value of selfMDLPath, it's put again after the session is restarted.
+		selfMDLs string
 	}
 
 	// for clean worker
@@ -125,6 +166,7 @@ func NewSchemaSyncer(ctx context.Context, etcdCli *clientv3.Client, id string, o
 	return &schemaVersionSyncer{
 		etcdCli:                   etcdCli,
 		selfSchemaVerPath:         fmt.Sprintf("%s/%s", DDLAllSchemaVersions, id),
+		selfMDLPath:               fmt.Sprintf("%s/%s", DDLAllMetadataLocks, id),
 		ownerChecker:              oc,
 		notifyCleanExpiredPathsCh: make(chan struct{}, 1),
 		ctx:                       childCtx,
@@ -227,7 +269,18 @@ func (s *schemaVersionSyncer) Restart(ctx context.Context) error {
 	defer cancel()
 	err = PutKVToEtcd(childCtx, s.etcdCli, putKeyRetryUnlimited, s.selfSchemaVerPath, InitialVersion,
 		clientv3.WithLease(s.loadSession().Lease()))
+	if err != nil {
+		return errors.Trace(err)
+	}
 
+	// The metadata locks are removed with the expired session, put them back.
+	s.mu.RLock()
+	mdls := s.mu.selfMDLs
+	s.mu.RUnlock()
+	if len(mdls) > 0 {
+		err = PutKVToEtcd(ctx, s.etcdCli, keyOpDefaultRetryCnt, s.selfMDLPath, mdls,
+			clientv3.WithLease(s.loadSession().Lease()))
+	}
 	return errors.Trace(err)
 }
 
@@ -281,6 +334,75 @@ func (s *schemaVersionSyncer) OwnerUpdateGlobalVersion(ctx context.Context, vers
 	return errors.Trace(err)
 }
 
+// UpdateSelfMDLs implements SchemaSyncer.UpdateSelfMDLs interface.
+func (s *schemaVersionSyncer) UpdateSelfMDLs(ctx context.Context, mdls MDLs) error {
+	session := s.loadSession()
+	if session == nil {
+		return errors.New("the schema syncer isn't initialized")
+	}
+	val, err := json.Marshal(mdls)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.mu.Lock()
+	s.mu.selfMDLs = string(val)
+	s.mu.Unlock()
+	err = PutKVToEtcd(ctx, s.etcdCli, keyOpDefaultRetryCnt, s.selfMDLPath, string(val),
+		clientv3.WithLease(session.Lease()))
+	return errors.Trace(err)
+}
+
+// OwnerGetAllMDLs implements SchemaSyncer.OwnerGetAllMDLs interface.
+func (s *schemaVersionSyncer) OwnerGetAllMDLs(ctx context.Context) ([]MDLs, error) {
+	childCtx, cancel := context.WithTimeout(ctx, keyOpDefaultTimeout)
+	resp, err := s.etcdCli.Get(childCtx, DDLAllMetadataLocks, clientv3.WithPrefix())
+	cancel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	allMDLs := make([]MDLs, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var mdls MDLs
+		if err = json.Unmarshal(kv.Value, &mdls); err != nil {
+			return nil, errors.Trace(err)
+		}
+		allMDLs = append(allMDLs, mdls)
+	}
+	return allMDLs, nil
+}
+
+// OwnerUpdateMDLWait implements SchemaSyncer.OwnerUpdateMDLWait interface.
+func (s *schemaVersionSyncer) OwnerUpdateMDLWait(ctx context.Context, wait *MDLWaitInfo) error {
+	if wait == nil {
+		return errors.Trace(DeleteKeyFromEtcd(DDLMetadataLockWait, s.etcdCli, keyOpDefaultRetryCnt, keyOpDefaultTimeout))
+	}
+	session := s.loadSession()
+	if session == nil {
+		return errors.New("the schema syncer isn't initialized")
+	}
+	val, err := json.Marshal(wait)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The wait is removed with the session if the owner is down.
+	err = PutKVToEtcd(ctx, s.etcdCli, keyOpDefaultRetryCnt, DDLMetadataLockWait, string(val),
+		clientv3.WithLease(session.Lease()))
+	return errors.Trace(err)
+}
+
+// GetMDLWait implements SchemaSyncer.GetMDLWait interface.
+func (s *schemaVersionSyncer) GetMDLWait(ctx context.Context) (*MDLWaitInfo, error) {
+	childCtx, cancel := context.WithTimeout(ctx, keyOpDefaultTimeout)
+	resp, err := s.etcdCli.Get(childCtx, DDLMetadataLockWait)
+	cancel()
+	if err != nil || len(resp.Kvs) == 0 {
+		return nil, errors.Trace(err)
+	}
+	wait := &MDLWaitInfo{}
+	err = json.Unmarshal(resp.Kvs[0].Value, wait)
+	return wait, errors.Trace(err)
+}
+
 // removeSelfVersionPath remove the self path from etcd.
 func (s *schemaVersionSyncer) removeSelfVersionPath() error {
 	startTime := time.Now()
@@ -290,6 +412,10 @@ func (s *schemaVersionSyncer) removeSelfVersionPath() error {
 	}()
 
 	err = DeleteKeyFromEtcd(s.selfSchemaVerPath, s.etcdCli, keyOpDefaultRetryCnt, keyOpDefaultTimeout)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = DeleteKeyFromEtcd(s.selfMDLPath, s.etcdCli, keyOpDefaultRetryCnt, keyOpDefaultTimeout)
 	return errors.Trace(err)
 }
 
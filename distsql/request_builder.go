@@ -223,6 +223,9 @@ func (builder *RequestBuilder) getIsolationLevel() kv.IsoLevel {
 }
 
 func (builder *RequestBuilder) getKVPriority(sv *variable.SessionVars) int {
+	if sv.StmtCtx.RunawayCooldown.Load() {
+		return kv.PriorityLow
+	}
 	switch sv.StmtCtx.Priority {
 	case mysql.NoPriority, mysql.DelayedPriority:
 		return kv.PriorityNormal
@@ -247,6 +250,8 @@ func (builder *RequestBuilder) SetFromSessionVars(sv *variable.SessionVars) *Req
 	builder.Request.Priority = builder.getKVPriority(sv)
 	builder.Request.ReplicaRead = sv.GetReplicaRead()
 	builder.SetResourceGroupTag(sv.StmtCtx)
+	builder.Request.ResourceGroupLimiter = sv.ResourceGroupLimiter
+	builder.Request.RunawayCooldown = &sv.StmtCtx.RunawayCooldown
 	return builder
 }
 
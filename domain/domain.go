@@ -45,10 +45,12 @@ import (
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/owner"
 	"github.com/pingcap/tidb/privilege/privileges"
+	"github.com/pingcap/tidb/resourcegroup"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/statistics/handle"
 	"github.com/pingcap/tidb/telemetry"
+	"github.com/pingcap/tidb/ttl"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/pingcap/tidb/util/domainutil"
@@ -91,6 +93,11 @@ type Domain struct {
 	serverIDSession      *concurrency.Session
 	isLostConnectionToPD sync2.AtomicInt32 // !0: true, 0: false.
 
+	resourceGroupManager *resourcegroup.Manager
+	// resourceGroupVersion is the schema version which the resource groups are loaded from.
+	resourceGroupVersion int64
+	runawayManager       *resourcegroup.RunawayManager
+
 	onClose func()
 }
 
@@ -156,7 +163,12 @@ func (do *Domain) loadInfoSchema(startTS uint64) (infoschema.InfoSchema, bool, i
 		return nil, false, currentSchemaVersion, nil, err
 	}
 
-	newISBuilder, err := infoschema.NewBuilder(do.Store()).InitWithDBInfos(schemas, bundles, policies, neededSchemaVersion)
+	cachedTables, err := m.ListTableCacheStatus()
+	if err != nil {
+		return nil, false, currentSchemaVersion, nil, err
+	}
+
+	newISBuilder, err := infoschema.NewBuilder(do.Store(), do.sysSessionPool).InitWithDBInfos(schemas, bundles, policies, cachedTables, neededSchemaVersion)
 	if err != nil {
 		return nil, false, currentSchemaVersion, nil, err
 	}
@@ -268,7 +280,7 @@ func (do *Domain) tryLoadSchemaDiffs(m *meta.Meta, usedVersion, newVersion int64
 		}
 		diffs = append(diffs, diff)
 	}
-	builder := infoschema.NewBuilder(do.Store()).InitWithOldInfoSchema(do.infoCache.GetLatest())
+	builder := infoschema.NewBuilder(do.Store(), do.sysSessionPool).InitWithOldInfoSchema(do.infoCache.GetLatest())
 	phyTblIDs := make([]int64, 0, len(diffs))
 	actions := make([]uint64, 0, len(diffs))
 	for _, diff := range diffs {
@@ -368,6 +380,15 @@ func (do *Domain) Reload() error {
 	}
 	metrics.LoadSchemaCounter.WithLabelValues("succ").Inc()
 
+	// The resource groups are changed along with the schema version, and the schema may be loaded into the cache
+	// by others, so they are reloaded whenever the schema version is changed.
+	if is.SchemaMetaVersion() != do.resourceGroupVersion {
+		if err = do.loadResourceGroups(ver.Ver); err != nil {
+			return err
+		}
+		do.resourceGroupVersion = is.SchemaMetaVersion()
+	}
+
 	// only update if it is not from cache
 	if !hitCache {
 		// loaded newer schema
@@ -402,6 +423,27 @@ func (do *Domain) Reload() error {
 	return nil
 }
 
+// loadResourceGroups loads the resource groups at startTS into the resource group manager.
+func (do *Domain) loadResourceGroups(startTS uint64) error {
+	m := meta.NewSnapshotMeta(do.store.GetSnapshot(kv.NewVersion(startTS)))
+	groups, err := resourcegroup.ListResourceGroups(m)
+	if err != nil {
+		return err
+	}
+	do.resourceGroupManager.Update(groups)
+	return nil
+}
+
+// ResourceGroupManager returns the manager of the resource groups.
+func (do *Domain) ResourceGroupManager() *resourcegroup.Manager {
+	return do.resourceGroupManager
+}
+
+// RunawayManager returns the manager of the runaway watch list.
+func (do *Domain) RunawayManager() *resourcegroup.RunawayManager {
+	return do.runawayManager
+}
+
 // LogSlowQuery keeps topN recent slow queries in domain.
 func (do *Domain) LogSlowQuery(query *SlowQueryInfo) {
 	do.slowQuery.mu.RLock()
@@ -667,6 +709,9 @@ func NewDomain(store kv.Storage, ddlLease time.Duration, statsLease time.Duratio
 
 	do.SchemaValidator = NewSchemaValidator(ddlLease, do)
 	do.expensiveQueryHandle = expensivequery.NewExpensiveQueryHandle(do.exit)
+	do.resourceGroupManager = resourcegroup.NewManager()
+	do.runawayManager = resourcegroup.NewRunawayManager()
+	do.expensiveQueryHandle.SetRunawayManager(do.runawayManager)
 	return do
 }
 
@@ -1140,6 +1185,130 @@ func (do *Domain) TelemetryRotateSubWindowLoop(ctx sessionctx.Context) {
 	}()
 }
 
+// TTLJobLoop creates a goroutine that runs the TTL jobs in a loop on the TTL owner, it should be called only once
+// in BootstrapSession.
+func (do *Domain) TTLJobLoop() {
+	ctx, cancel := context.WithCancel(context.Background())
+	do.wg.Add(1)
+	go func() {
+		defer do.wg.Done()
+		// Cancel the running TTL jobs when the domain is closed.
+		<-do.exit
+		cancel()
+	}()
+
+	do.wg.Add(1)
+	go func() {
+		defer func() {
+			do.wg.Done()
+			logutil.BgLogger().Info("TTLJobLoop exited.")
+			util.Recover(metrics.LabelDomain, "TTLJobLoop", nil, false)
+		}()
+		owner := do.newOwnerManager(ttl.Prompt, ttl.OwnerKey)
+		manager := ttl.NewJobManager(do.store, owner, do.sysSessionPool, do.InfoSchema)
+		for {
+			select {
+			case <-do.exit:
+				owner.Cancel()
+				return
+			case <-time.After(ttl.JobInterval):
+				if !owner.IsOwner() || !variable.EnableTTLJob.Load() {
+					continue
+				}
+				if err := manager.RunJobs(ctx); err != nil {
+					logutil.BgLogger().Warn("TTLJobLoop run TTL jobs failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// RunawayWatchLoop creates a goroutine that syncs the runaway watch list with mysql.tidb_runaway_watch in a loop,
+// it should be called only once in BootstrapSession.
+func (do *Domain) RunawayWatchLoop() {
+	do.wg.Add(1)
+	go func() {
+		defer func() {
+			do.wg.Done()
+			logutil.BgLogger().Info("RunawayWatchLoop exited.")
+			util.Recover(metrics.LabelDomain, "RunawayWatchLoop", nil, false)
+		}()
+		for {
+			select {
+			case <-do.exit:
+				return
+			case <-time.After(resourcegroup.RunawayWatchSyncInterval):
+				if err := do.syncRunawayWatches(); err != nil {
+					logutil.BgLogger().Warn("RunawayWatchLoop sync runaway watch list failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// syncRunawayWatches persists the records added by this TiDB, removes the expired records, and then reloads the
+// runaway watch list from mysql.tidb_runaway_watch.
+func (do *Domain) syncRunawayWatches() error {
+	pending := do.runawayManager.TakePendingWatches()
+	persisted := 0
+	defer func() {
+		// Retry the records which aren't persisted in the next round.
+		do.runawayManager.RequeueWatches(pending[persisted:])
+	}()
+	res, err := do.sysSessionPool.Get()
+	if err != nil {
+		return err
+	}
+	defer do.sysSessionPool.Put(res)
+	exec := res.(sqlexec.SQLExecutor)
+	ctx := context.Background()
+	const timeFormat = "2006-01-02 15:04:05"
+	for _, r := range pending {
+		var endTime interface{}
+		if !r.EndTime.IsZero() {
+			endTime = r.EndTime.Format(timeFormat)
+		}
+		if _, err = exec.ExecuteInternal(ctx, `INSERT INTO mysql.tidb_runaway_watch (sql_digest, sample_sql, start_time, end_time, source)
+			VALUES (%?, %?, %?, %?, %?)`, r.SQLDigest, r.SampleSQL, r.StartTime.Format(timeFormat), endTime, r.Source); err != nil {
+			return err
+		}
+		persisted++
+	}
+	if _, err = exec.ExecuteInternal(ctx, "DELETE FROM mysql.tidb_runaway_watch WHERE end_time <= NOW()"); err != nil {
+		return err
+	}
+	rs, err := exec.ExecuteInternal(ctx, `SELECT id, sql_digest, sample_sql, start_time, end_time, source
+		FROM mysql.tidb_runaway_watch WHERE end_time IS NULL OR end_time > NOW()`)
+	if err != nil {
+		return err
+	}
+	defer terror.Call(rs.Close)
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, 8)
+	if err != nil {
+		return err
+	}
+	records := make([]*resourcegroup.WatchRecord, 0, len(rows))
+	for _, row := range rows {
+		record := &resourcegroup.WatchRecord{
+			ID:        row.GetInt64(0),
+			SQLDigest: row.GetString(1),
+			SampleSQL: row.GetString(2),
+			Source:    row.GetString(5),
+		}
+		if record.StartTime, err = row.GetTime(3).GoTime(time.Local); err != nil {
+			return err
+		}
+		if !row.IsNull(4) {
+			if record.EndTime, err = row.GetTime(4).GoTime(time.Local); err != nil {
+				return err
+			}
+		}
+		records = append(records, record)
+	}
+	do.runawayManager.ResetWatches(records)
+	return nil
+}
+
 // StatsHandle returns the statistic handle.
 func (do *Domain) StatsHandle() *handle.Handle {
 	return (*handle.Handle)(atomic.LoadPointer(&do.statsHandle))
@@ -210,6 +210,97 @@ func checkEnableServerGlobalVar(name, sVal string) {
 		variable.TopSQLVariable.ReportIntervalSeconds.Store(val)
 	case variable.TiDBRestrictedReadOnly:
 		variable.RestrictedReadOnly.Store(variable.TiDBOptOn(sVal))
+	case variable.TiDBEnableMDL:
+		variable.EnableMDL.Store(variable.TiDBOptOn(sVal))
+	case variable.TiDBTTLJobEnable:
+		variable.EnableTTLJob.Store(variable.TiDBOptOn(sVal))
+	case variable.TiDBTTLScanWorkerCount:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.TTLScanWorkerCount.Store(val)
+	case variable.TiDBTTLDeleteBatchSize:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.TTLDeleteBatchSize.Store(val)
+	case variable.TiDBTTLDeleteRateLimit:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.TTLDeleteRateLimit.Store(val)
+	case variable.TiDBTableCacheLease:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.TableCacheLease.Store(val)
+	case variable.TiDBRunawayExecElapsedTime:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.RunawayRules.ExecElapsedTime.Store(val)
+	case variable.TiDBRunawayProcessedKeys:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.RunawayRules.ProcessedKeys.Store(val)
+	case variable.TiDBRunawayMemQuota:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.RunawayRules.MemQuota.Store(val)
+	case variable.TiDBRunawayAction:
+		variable.RunawayRules.Action.Store(sVal)
+	case variable.TiDBRunawayWatchDuration:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.RunawayRules.WatchDuration.Store(val)
+	case variable.DefaultPasswordLifetime:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.PasswordManagement.DefaultLifetime.Store(val)
+	case variable.PasswordHistory:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.PasswordManagement.History.Store(val)
+	case variable.PasswordReuseInterval:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.PasswordManagement.ReuseInterval.Store(val)
+	case variable.AuthenticationLDAPSimpleServerHost, variable.AuthenticationLDAPSimpleServerPort, variable.AuthenticationLDAPSimpleTLS,
+		variable.AuthenticationLDAPSimpleCAPath, variable.AuthenticationLDAPSimpleBindBaseDN, variable.AuthenticationLDAPSimpleBindRootDN,
+		variable.AuthenticationLDAPSimpleBindRootPWD, variable.AuthenticationLDAPSimpleUserSearchAttr, variable.AuthenticationLDAPSASLServerHost,
+		variable.AuthenticationLDAPSASLServerPort, variable.AuthenticationLDAPSASLTLS, variable.AuthenticationLDAPSASLCAPath,
+		variable.AuthenticationLDAPSASLBindBaseDN, variable.AuthenticationLDAPSASLBindRootDN, variable.AuthenticationLDAPSASLBindRootPWD,
+		variable.AuthenticationLDAPSASLUserSearchAttr, variable.AuthenticationLDAPSASLAuthMethodName:
+		// The settings of the LDAP authentication plugins are kept by the plugins.
+		err = variable.GetSysVar(name).SetGlobal(nil, sVal)
 	case variable.TiDBStoreLimit:
 		var val int64
 		val, err = strconv.ParseInt(sVal, 10, 64)
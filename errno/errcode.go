@@ -822,9 +822,11 @@ const (
 	ErrRowInWrongPartition                                   = 1863
 	ErrErrorLast                                             = 1863
 	ErrMaxExecTimeExceeded                                   = 1907
+	ErrForeignKeyCascadeDepthExceeded                        = 3008
 	ErrInvalidFieldSize                                      = 3013
 	ErrInvalidArgumentForLogarithm                           = 3020
 	ErrAggregateOrderNonAggQuery                             = 3029
+	ErrGISInvalidData                                        = 3037
 	ErrIncorrectType                                         = 3064
 	ErrFieldInOrderNotSelect                                 = 3065
 	ErrAggregateInOrderNotSelect                             = 3066
@@ -855,6 +857,7 @@ const (
 	ErrPKIndexCantBeInvisible                                = 3522
 	ErrGrantRole                                             = 3523
 	ErrRoleNotGranted                                        = 3530
+	ErrSRSNotFound                                           = 3548
 	ErrLockAcquireFailAndNoWaitSet                           = 3572
 	ErrCTERecursiveRequiresUnion                             = 3573
 	ErrCTERecursiveRequiresNonRecursiveFirst                 = 3574
@@ -885,6 +888,12 @@ const (
 	ErrIllegalPrivilegeLevel                                 = 3619
 	ErrCTEMaxRecursionDepth                                  = 3636
 	ErrNotHintUpdatable                                      = 3637
+	ErrCredentialsContradictToHistory                        = 3638
+	ErrResourceGroupExists                                   = 3650
+	ErrResourceGroupNotExists                                = 3651
+	ErrMissingJSONTableValue                                 = 3665
+	ErrWrongJSONTableValue                                   = 3666
+	ErrTFForbiddenJoinType                                   = 3668
 	ErrDataTruncatedFunctionalIndex                          = 3751
 	ErrDataOutOfRangeFunctionalIndex                         = 3752
 	ErrFunctionalIndexOnJSONOrGeometryFunction               = 3753
@@ -900,12 +909,24 @@ const (
 	ErrGeneratedColumnRowValueIsNotAllowed                   = 3764
 	ErrFKIncompatibleColumns                                 = 3780
 	ErrFunctionalIndexRowValueIsNotAllowed                   = 3800
+	ErrColumnCheckConstraintReferencesOtherColumn            = 3813
+	ErrCheckConstraintNamedFunctionIsNotAllowed              = 3814
+	ErrCheckConstraintFunctionIsNotAllowed                   = 3815
+	ErrCheckConstraintVariables                              = 3816
+	ErrCheckConstraintRowValue                               = 3817
+	ErrCheckConstraintRefersAutoIncrementColumn              = 3818
+	ErrCheckConstraintViolated                               = 3819
+	ErrCheckConstraintRefersUnknownColumn                    = 3820
+	ErrCheckConstraintNotFound                               = 3821
+	ErrCheckConstraintDupName                                = 3822
 	ErrDependentByFunctionalIndex                            = 3837
 	ErrInvalidJSONValueForFuncIndex                          = 3903
 	ErrJSONValueOutOfRangeForFuncIndex                       = 3904
 	ErrFunctionalIndexDataIsTooLong                          = 3907
 	ErrFunctionalIndexNotApplicable                          = 3909
 	ErrDynamicPrivilegeNotRegistered                         = 3929
+	ErrUserAccessDeniedForUserAccountBlockedByPasswordLock   = 3955
+	ErrDependentByCheckConstraint                            = 3959
 	// MariaDB errors.
 	ErrOnlyOneDefaultPartionAllowed         = 4030
 	ErrWrongPartitionTypeExpectedSystemTime = 4113
@@ -1052,6 +1073,17 @@ const (
 	ErrPlacementPolicyNotExists           = 8239
 	ErrPlacementPolicyWithDirectOption    = 8240
 	ErrPlacementPolicyInUse               = 8241
+	ErrCannotPauseDDLJob                  = 8242
+	ErrCannotResumeDDLJob                 = 8243
+	ErrPausedDDLJob                       = 8244
+	ErrOperateSameColumn                  = 8245
+	ErrOperateSameIndex                   = 8246
+	ErrUnsupportedColumnInTTLConfig       = 8247
+	ErrTTLColumnCannotDrop                = 8248
+	ErrOptOnCacheTable                    = 8249
+	ErrRunawayQueryQuarantined            = 8250
+	ErrSetTTLOptionForNonTTLTable         = 8251
+	ErrInvalidTTLDefinition               = 8252
 
 	// TiKV/PD/TiFlash errors.
 	ErrPDServerTimeout           = 9001
@@ -835,9 +835,11 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrGeneratedColumnRefAutoInc:                             mysql.Message("Generated column '%s' cannot refer to auto-increment column.", nil),
 	ErrWarnConflictingHint:                                   mysql.Message("Hint %s is ignored as conflicting/duplicated.", nil),
 	ErrUnresolvedHintName:                                    mysql.Message("Unresolved name '%s' for %s hint", nil),
+	ErrForeignKeyCascadeDepthExceeded:                        mysql.Message("Foreign key cascade delete/update exceeds max depth of %v.", nil),
 	ErrInvalidFieldSize:                                      mysql.Message("Invalid size for column '%s'.", nil),
 	ErrInvalidArgumentForLogarithm:                           mysql.Message("Invalid argument for logarithm", nil),
 	ErrAggregateOrderNonAggQuery:                             mysql.Message("Expression #%d of ORDER BY contains aggregate function and applies to the result of a non-aggregated query", nil),
+	ErrGISInvalidData:                                        mysql.Message("Invalid GIS data provided to function %s.", nil),
 	ErrIncorrectType:                                         mysql.Message("Incorrect type for argument %s in function %s.", nil),
 	ErrFieldInOrderNotSelect:                                 mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, references column '%s' which is not in SELECT list; this is incompatible with %s", nil),
 	ErrAggregateInOrderNotSelect:                             mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, contains aggregate function; this is incompatible with %s", nil),
@@ -878,9 +880,16 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrWindowExplainJSON:                                     mysql.Message("To get information about window functions use EXPLAIN FORMAT=JSON", nil),
 	ErrWindowFunctionIgnoresFrame:                            mysql.Message("Window function '%s' ignores the frame clause of window '%s' and aggregates over the whole partition", nil),
 	ErrRoleNotGranted:                                        mysql.Message("%s is is not granted to %s", nil),
+	ErrSRSNotFound:                                           mysql.Message("There's no spatial reference system with SRID %d.", nil),
 	ErrMaxExecTimeExceeded:                                   mysql.Message("Query execution was interrupted, max_execution_time exceeded.", nil),
 	ErrLockAcquireFailAndNoWaitSet:                           mysql.Message("Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.", nil),
 	ErrNotHintUpdatable:                                      mysql.Message("Variable '%s' cannot be set using SET_VAR hint.", nil),
+	ErrCredentialsContradictToHistory:                        mysql.Message("Cannot use these credentials for '%s@%s' because they contradict the password history policy", nil),
+	ErrResourceGroupExists:                                   mysql.Message("Resource Group '%s' already exists.", nil),
+	ErrResourceGroupNotExists:                                mysql.Message("Resource Group '%s' does not exist.", nil),
+	ErrMissingJSONTableValue:                                 mysql.Message("Missing value for JSON_TABLE column '%s'", nil),
+	ErrWrongJSONTableValue:                                   mysql.Message("Can't store an array or an object in the scalar JSON_TABLE column '%s'", nil),
+	ErrTFForbiddenJoinType:                                   mysql.Message("INNER or LEFT JOIN must be used for LATERAL references made by '%s'", nil),
 	ErrDataTruncatedFunctionalIndex:                          mysql.Message("Data truncated for expression index '%s' at row %d", nil),
 	ErrDataOutOfRangeFunctionalIndex:                         mysql.Message("Value is out of range for expression index '%s' at row %d", nil),
 	ErrFunctionalIndexOnJSONOrGeometryFunction:               mysql.Message("Cannot create an expression index on a function that returns a JSON or GEOMETRY value", nil),
@@ -896,12 +905,24 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrFKIncompatibleColumns:                                 mysql.Message("Referencing column '%s' in foreign key constraint '%s' are incompatible", nil),
 	ErrFunctionalIndexRowValueIsNotAllowed:                   mysql.Message("Expression of expression index '%s' cannot refer to a row value", nil),
 	ErrDependentByFunctionalIndex:                            mysql.Message("Column '%s' has an expression index dependency and cannot be dropped or renamed", nil),
+	ErrColumnCheckConstraintReferencesOtherColumn:            mysql.Message("Column check constraint '%-.192s' references other column.", nil),
+	ErrCheckConstraintNamedFunctionIsNotAllowed:              mysql.Message("An expression of a check constraint '%-.192s' contains disallowed function: %s.", nil),
+	ErrCheckConstraintFunctionIsNotAllowed:                   mysql.Message("An expression of a check constraint '%-.192s' contains disallowed function.", nil),
+	ErrCheckConstraintVariables:                              mysql.Message("An expression of a check constraint '%-.192s' cannot refer to a user or system variable.", nil),
+	ErrCheckConstraintRowValue:                               mysql.Message("Check constraint '%-.192s' cannot refer to a row value.", nil),
+	ErrCheckConstraintRefersAutoIncrementColumn:              mysql.Message("Check constraint '%-.192s' cannot refer to an auto-increment column.", nil),
+	ErrCheckConstraintViolated:                               mysql.Message("Check constraint '%-.192s' is violated.", nil),
+	ErrCheckConstraintRefersUnknownColumn:                    mysql.Message("Check constraint '%-.192s' refers to non-existing column '%-.192s'.", nil),
+	ErrCheckConstraintNotFound:                               mysql.Message("Check constraint '%-.192s' is not found in the table.", nil),
+	ErrCheckConstraintDupName:                                mysql.Message("Duplicate check constraint name '%-.192s'.", nil),
+	ErrDependentByCheckConstraint:                            mysql.Message("Check constraint '%-.192s' uses column '%-.192s', hence column cannot be dropped or renamed.", nil),
 	ErrInvalidJSONValueForFuncIndex:                          mysql.Message("Invalid JSON value for CAST for expression index '%s'", nil),
 	ErrJSONValueOutOfRangeForFuncIndex:                       mysql.Message("Out of range JSON value for CAST for expression index '%s'", nil),
 	ErrFunctionalIndexDataIsTooLong:                          mysql.Message("Data too long for expression index '%s'", nil),
 	ErrFunctionalIndexNotApplicable:                          mysql.Message("Cannot use expression index '%s' due to type or collation conversion", nil),
 	ErrUnsupportedConstraintCheck:                            mysql.Message("%s is not supported", nil),
 	ErrDynamicPrivilegeNotRegistered:                         mysql.Message("Dynamic privilege '%s' is not registered with the server.", nil),
+	ErrUserAccessDeniedForUserAccountBlockedByPasswordLock:   mysql.Message("Access denied for user '%s'@'%s'. Account is blocked for %s day(s) (%s day(s) remaining) due to %d consecutive failed logins.", nil),
 	ErrIllegalPrivilegeLevel:                                 mysql.Message("Illegal privilege level specified for %s", nil),
 	ErrCTERecursiveRequiresUnion:                             mysql.Message("Recursive Common Table Expression '%s' should contain a UNION", nil),
 	ErrCTERecursiveRequiresNonRecursiveFirst:                 mysql.Message("Recursive Common Table Expression '%s' should have one or more non-recursive query blocks followed by one or more recursive ones", nil),
@@ -1003,6 +1024,17 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrDDLJobNotFound:                mysql.Message("DDL Job:%v not found", nil),
 	ErrCancelFinishedDDLJob:          mysql.Message("This job:%v is finished, so can't be cancelled", nil),
 	ErrCannotCancelDDLJob:            mysql.Message("This job:%v is almost finished, can't be cancelled now", nil),
+	ErrCannotPauseDDLJob:             mysql.Message("This job:%v is finished or rolling back, so can't be paused", nil),
+	ErrCannotResumeDDLJob:            mysql.Message("This job:%v isn't paused, so can't be resumed", nil),
+	ErrPausedDDLJob:                  mysql.Message("Paused DDL job", nil),
+	ErrOperateSameColumn:             mysql.Message("Column '%s' is changed more than once in the multi-schema change", nil),
+	ErrOperateSameIndex:              mysql.Message("Index '%s' is changed more than once in the multi-schema change", nil),
+	ErrUnsupportedColumnInTTLConfig:  mysql.Message("Field '%-.192s' is of a not supported type for TTL config, expect DATETIME, DATE or TIMESTAMP", nil),
+	ErrTTLColumnCannotDrop:           mysql.Message("Cannot drop column '%-.192s': needed in TTL config", nil),
+	ErrOptOnCacheTable:               mysql.Message("'%s' is unsupported on cache tables.", nil),
+	ErrRunawayQueryQuarantined:       mysql.Message("Quarantined and interrupted because of being in runaway watch list", nil),
+	ErrSetTTLOptionForNonTTLTable:    mysql.Message("Cannot set %s on a table without TTL config", nil),
+	ErrInvalidTTLDefinition:          mysql.Message("Invalid TTL definition '%-.192s', the interval should be positive and the unit should be one of SECOND, MINUTE, HOUR, DAY, WEEK, MONTH, QUARTER and YEAR", nil),
 	ErrUnknownAllocatorType:          mysql.Message("Invalid allocator type", nil),
 	ErrAutoRandReadFailed:            mysql.Message("Failed to read auto-random value from storage engine", nil),
 	ErrInvalidIncrementAndOffset:     mysql.Message("Invalid auto_increment settings: auto_increment_increment: %d, auto_increment_offset: %d, both of them must be in range [1..65535]", nil),
@@ -97,6 +97,10 @@ type HashAggPartialWorker struct {
 	// chk stores the input data from child,
 	// and is reused by childExec and partial worker.
 	chk *chunk.Chunk
+	// spillHelper is nil if the HashAggExec can't spill to disk.
+	spillHelper *parallelHashAggSpillHelper
+	// spillChks buffers the rows to be spilled, spillChks[i] stores the rows of the i-th final worker.
+	spillChks []*chunk.Chunk
 }
 
 // HashAggFinalWorker indicates the final workers of parallel hash agg execution,
@@ -112,6 +116,13 @@ type HashAggFinalWorker struct {
 	outputCh            chan *AfFinalResult
 	finalResultHolderCh chan *chunk.Chunk
 	groupKeys           [][]byte
+
+	// The following fields are used to re-aggregate the rows spilled to disk.
+	spillHelper     *parallelHashAggSpillHelper
+	partitionIdx    int
+	groupByItems    []expression.Expression
+	partialAggFuncs []aggfuncs.AggFunc
+	spilledGroupKey [][]byte
 }
 
 // AfFinalResult indicates aggregation functions final result.
@@ -218,6 +229,8 @@ type HashAggExec struct {
 	spillAction *AggSpillDiskAction
 	// isChildDrained indicates whether the all data from child has been taken out.
 	isChildDrained bool
+	// spillHelper manages the data spilled by the parallel execution.
+	spillHelper *parallelHashAggSpillHelper
 }
 
 // HashAggInput indicates the input of hash agg exec.
@@ -294,6 +307,10 @@ func (e *HashAggExec) Close() error {
 		if e.memTracker != nil {
 			e.memTracker.ReplaceBytesUsed(0)
 		}
+		if e.spillHelper != nil {
+			e.spillHelper.close()
+			e.spillHelper, e.spillAction = nil, nil
+		}
 	}
 	return e.baseExecutor.Close()
 }
@@ -380,10 +397,23 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 	e.finalWorkers = make([]HashAggFinalWorker, finalConcurrency)
 	e.initRuntimeStats()
 
+	e.spillHelper = nil
+	if e.ctx.GetSessionVars().TrackAggregateMemoryUsage && config.GetGlobalConfig().OOMUseTmpStorage {
+		e.diskTracker = disk.NewTracker(e.id, -1)
+		e.diskTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.DiskTracker)
+		atomic.StoreUint32(&e.inSpillMode, 0)
+		e.spillHelper = newParallelHashAggSpillHelper(&e.inSpillMode, retTypes(e.children[0]), e.maxChunkSize, finalConcurrency, e.diskTracker)
+		e.ctx.GetSessionVars().StmtCtx.MemTracker.FallbackOldAndSetNewActionForSoftLimit(e.ActionSpill())
+	}
+
 	// Init partial workers.
 	for i := 0; i < partialConcurrency; i++ {
+		// Every worker has its own memory tracker, so its memory usage can be released
+		// when its hash table is no longer used.
+		memTracker := memory.NewTracker(e.id, -1)
+		memTracker.AttachTo(e.memTracker)
 		w := HashAggPartialWorker{
-			baseHashAggWorker: newBaseHashAggWorker(e.ctx, e.finishCh, e.PartialAggFuncs, e.maxChunkSize, e.memTracker),
+			baseHashAggWorker: newBaseHashAggWorker(e.ctx, e.finishCh, e.PartialAggFuncs, e.maxChunkSize, memTracker),
 			inputCh:           e.partialInputChs[i],
 			outputChs:         e.partialOutputChs,
 			giveBackCh:        e.inputCh,
@@ -392,15 +422,20 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 			groupByItems:      e.GroupByItems,
 			chk:               newFirstChunk(e.children[0]),
 			groupKey:          make([][]byte, 0, 8),
+			spillHelper:       e.spillHelper,
+		}
+		if e.spillHelper != nil {
+			w.spillChks = make([]*chunk.Chunk, finalConcurrency)
+			e.spillHelper.partialMemTrackers = append(e.spillHelper.partialMemTrackers, memTracker)
 		}
 		// There is a bucket in the empty partialResultsMap.
 		failpoint.Inject("ConsumeRandomPanic", nil)
-		e.memTracker.Consume(defBucketMemoryUsage * (1 << w.BInMap))
+		w.memTracker.Consume(defBucketMemoryUsage * (1 << w.BInMap))
 		if e.stats != nil {
 			w.stats = &AggWorkerStat{}
 			e.stats.PartialStats = append(e.stats.PartialStats, w.stats)
 		}
-		e.memTracker.Consume(w.chk.MemoryUsage())
+		w.memTracker.Consume(w.chk.MemoryUsage())
 		e.partialWorkers[i] = w
 		input := &HashAggInput{
 			chk:        newFirstChunk(e.children[0]),
@@ -413,8 +448,10 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 	// Init final workers.
 	for i := 0; i < finalConcurrency; i++ {
 		groupSet, setSize := set.NewStringSetWithMemoryUsage()
+		memTracker := memory.NewTracker(e.id, -1)
+		memTracker.AttachTo(e.memTracker)
 		w := HashAggFinalWorker{
-			baseHashAggWorker:   newBaseHashAggWorker(e.ctx, e.finishCh, e.FinalAggFuncs, e.maxChunkSize, e.memTracker),
+			baseHashAggWorker:   newBaseHashAggWorker(e.ctx, e.finishCh, e.FinalAggFuncs, e.maxChunkSize, memTracker),
 			partialResultMap:    make(aggPartialResultMapper),
 			groupSet:            groupSet,
 			inputCh:             e.partialOutputChs[i],
@@ -423,9 +460,13 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 			rowBuffer:           make([]types.Datum, 0, e.Schema().Len()),
 			mutableRow:          chunk.MutRowFromTypes(retTypes(e)),
 			groupKeys:           make([][]byte, 0, 8),
+			spillHelper:         e.spillHelper,
+			partitionIdx:        i,
+			groupByItems:        e.GroupByItems,
+			partialAggFuncs:     e.PartialAggFuncs,
 		}
 		// There is a bucket in the empty partialResultsMap.
-		e.memTracker.Consume(defBucketMemoryUsage*(1<<w.BInMap) + setSize)
+		w.memTracker.Consume(defBucketMemoryUsage*(1<<w.BInMap) + setSize)
 		if e.stats != nil {
 			w.stats = &AggWorkerStat{}
 			e.stats.FinalStats = append(e.stats.FinalStats, w.stats)
@@ -467,9 +508,16 @@ func (w *HashAggPartialWorker) run(ctx sessionctx.Context, waitGroup *sync.WaitG
 		if r := recover(); r != nil {
 			recoveryHashAgg(w.globalOutputCh, r)
 		}
+		if w.spillHelper != nil {
+			if err := w.flushSpilledRows(); err != nil {
+				w.globalOutputCh <- &AfFinalResult{err: err}
+			}
+		}
 		if needShuffle {
 			w.shuffleIntermData(sc, finalConcurrency)
 		}
+		// The partial results have been sent to the final workers.
+		w.partialResultsMap = nil
 		w.memTracker.Consume(-w.chk.MemoryUsage())
 		if w.stats != nil {
 			w.stats.WorkerTime += int64(time.Since(start))
@@ -517,6 +565,9 @@ func (w *HashAggPartialWorker) updatePartialResult(ctx sessionctx.Context, sc *s
 	if err != nil {
 		return err
 	}
+	if w.spillHelper != nil && w.spillHelper.isInSpillMode() {
+		return w.updatePartialResultInSpillMode(ctx, chk)
+	}
 
 	partialResults := w.getPartialResult(sc, w.groupKey, w.partialResultsMap)
 	numRows := chk.NumRows()
@@ -536,6 +587,62 @@ func (w *HashAggPartialWorker) updatePartialResult(ctx sessionctx.Context, sc *s
 	return nil
 }
 
+// updatePartialResultInSpillMode only updates the partial results of the existing groups,
+// the rows of the other groups are spilled to disk and re-aggregated by the final workers.
+func (w *HashAggPartialWorker) updatePartialResultInSpillMode(ctx sessionctx.Context, chk *chunk.Chunk) error {
+	numRows := chk.NumRows()
+	rows := make([]chunk.Row, 1)
+	allMemDelta := int64(0)
+	for i := 0; i < numRows; i++ {
+		partialResults, ok := w.partialResultsMap[string(w.groupKey[i])]
+		if !ok {
+			if err := w.spillRow(chk.GetRow(i), w.groupKey[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		rows[0] = chk.GetRow(i)
+		for j, af := range w.aggFuncs {
+			memDelta, err := af.UpdatePartialResult(ctx, rows, partialResults[j])
+			if err != nil {
+				return err
+			}
+			allMemDelta += memDelta
+		}
+	}
+	w.memTracker.Consume(allMemDelta)
+	return nil
+}
+
+// spillRow spills the row to the partition of the final worker which the group key is shuffled to.
+func (w *HashAggPartialWorker) spillRow(row chunk.Row, groupKey []byte) error {
+	idx := int(murmur3.Sum32(groupKey)) % len(w.outputChs)
+	if w.spillChks[idx] == nil {
+		w.spillChks[idx] = w.spillHelper.newSpillChunk()
+	}
+	w.spillChks[idx].AppendRow(row)
+	if !w.spillChks[idx].IsFull() {
+		return nil
+	}
+	err := w.spillHelper.spill(idx, w.spillChks[idx])
+	w.spillChks[idx].Reset()
+	return err
+}
+
+// flushSpilledRows spills the rows left in spillChks.
+func (w *HashAggPartialWorker) flushSpilledRows() error {
+	for idx, chk := range w.spillChks {
+		if chk == nil || chk.NumRows() == 0 {
+			continue
+		}
+		if err := w.spillHelper.spill(idx, chk); err != nil {
+			return err
+		}
+		chk.Reset()
+	}
+	return nil
+}
+
 // shuffleIntermData shuffles the intermediate data of partial workers to corresponded final workers.
 // We only support parallel execution for single-machine, so process of encode and decode can be skipped.
 func (w *HashAggPartialWorker) shuffleIntermData(sc *stmtctx.StatementContext, finalConcurrency int) {
@@ -641,8 +748,6 @@ func (w *HashAggFinalWorker) consumeIntermData(sctx sessionctx.Context) (err err
 		input            *HashAggIntermData
 		ok               bool
 		intermDataBuffer [][]aggfuncs.PartialResult
-		groupKeys        []string
-		sc               = sctx.GetSessionVars().StmtCtx
 	)
 	for {
 		waitStart := time.Now()
@@ -654,52 +759,183 @@ func (w *HashAggFinalWorker) consumeIntermData(sctx sessionctx.Context) (err err
 			return nil
 		}
 		execStart := time.Now()
-		if intermDataBuffer == nil {
-			intermDataBuffer = make([][]aggfuncs.PartialResult, 0, w.maxChunkSize)
-		}
-		// Consume input in batches, size of every batch is less than w.maxChunkSize.
-		for reachEnd := false; !reachEnd; {
-			intermDataBuffer, groupKeys, reachEnd = input.getPartialResultBatch(sc, intermDataBuffer[:0], w.aggFuncs, w.maxChunkSize)
-			groupKeysLen := len(groupKeys)
-			memSize := getGroupKeyMemUsage(w.groupKeys)
-			w.groupKeys = w.groupKeys[:0]
-			for i := 0; i < groupKeysLen; i++ {
-				w.groupKeys = append(w.groupKeys, []byte(groupKeys[i]))
+		if intermDataBuffer, err = w.mergeIntermData(sctx, input, intermDataBuffer); err != nil {
+			return err
+		}
+		if w.stats != nil {
+			w.stats.ExecTime += int64(time.Since(execStart))
+			w.stats.TaskNum += 1
+		}
+	}
+}
+
+// mergeIntermData merges the partial results in input into partialResultMap.
+func (w *HashAggFinalWorker) mergeIntermData(sctx sessionctx.Context, input *HashAggIntermData, intermDataBuffer [][]aggfuncs.PartialResult) (_ [][]aggfuncs.PartialResult, err error) {
+	var (
+		groupKeys []string
+		sc        = sctx.GetSessionVars().StmtCtx
+	)
+	if intermDataBuffer == nil {
+		intermDataBuffer = make([][]aggfuncs.PartialResult, 0, w.maxChunkSize)
+	}
+	// Consume input in batches, size of every batch is less than w.maxChunkSize.
+	for reachEnd := false; !reachEnd; {
+		intermDataBuffer, groupKeys, reachEnd = input.getPartialResultBatch(sc, intermDataBuffer[:0], w.aggFuncs, w.maxChunkSize)
+		groupKeysLen := len(groupKeys)
+		memSize := getGroupKeyMemUsage(w.groupKeys)
+		w.groupKeys = w.groupKeys[:0]
+		for i := 0; i < groupKeysLen; i++ {
+			w.groupKeys = append(w.groupKeys, []byte(groupKeys[i]))
+		}
+		failpoint.Inject("ConsumeRandomPanic", nil)
+		w.memTracker.Consume(getGroupKeyMemUsage(w.groupKeys) - memSize)
+		finalPartialResults := w.getPartialResult(sc, w.groupKeys, w.partialResultMap)
+		allMemDelta := int64(0)
+		for i, groupKey := range groupKeys {
+			if !w.groupSet.Exist(groupKey) {
+				allMemDelta += w.groupSet.Insert(groupKey)
 			}
-			failpoint.Inject("ConsumeRandomPanic", nil)
-			w.memTracker.Consume(getGroupKeyMemUsage(w.groupKeys) - memSize)
-			finalPartialResults := w.getPartialResult(sc, w.groupKeys, w.partialResultMap)
-			allMemDelta := int64(0)
-			for i, groupKey := range groupKeys {
-				if !w.groupSet.Exist(groupKey) {
-					allMemDelta += w.groupSet.Insert(groupKey)
+			prs := intermDataBuffer[i]
+			for j, af := range w.aggFuncs {
+				memDelta, err := af.MergePartialResult(sctx, prs[j], finalPartialResults[i][j])
+				if err != nil {
+					return intermDataBuffer, err
 				}
-				prs := intermDataBuffer[i]
-				for j, af := range w.aggFuncs {
-					memDelta, err := af.MergePartialResult(sctx, prs[j], finalPartialResults[i][j])
-					if err != nil {
-						return err
+				allMemDelta += memDelta
+			}
+		}
+		w.memTracker.Consume(allMemDelta)
+	}
+	return intermDataBuffer, nil
+}
+
+// restoreAndGetFinalResult re-aggregates the rows spilled to the partition of this worker in rounds.
+// In every round, the rows of the groups which can't be held in memory are spilled again and left
+// to the next round, and the final results of the other groups are sent to the main thread.
+func (w *HashAggFinalWorker) restoreAndGetFinalResult(sctx sessionctx.Context) error {
+	defer w.spillHelper.finishRestoring(w.partitionIdx)
+	input := w.spillHelper.getPartition(w.partitionIdx)
+	for {
+		var (
+			spilled *chunk.ListInDisk
+			err     error
+		)
+		if input != nil {
+			spilled, err = w.reAggregateSpilledRows(sctx, input)
+			w.spillHelper.release(input)
+			if err != nil {
+				if spilled != nil {
+					w.spillHelper.release(spilled)
+				}
+				return err
+			}
+		}
+		if finished := w.getFinalResult(sctx); finished || spilled == nil {
+			if spilled != nil {
+				w.spillHelper.release(spilled)
+			}
+			return nil
+		}
+		w.resetForNextRound()
+		input = spilled
+	}
+}
+
+// reAggregateSpilledRows aggregates the rows in input and merges them into partialResultMap.
+// After the spill mode is triggered, the rows of the new groups are spilled to the returned list.
+func (w *HashAggFinalWorker) reAggregateSpilledRows(sctx sessionctx.Context, input *chunk.ListInDisk) (spilled *chunk.ListInDisk, err error) {
+	var (
+		inSpillMode      bool
+		spillChk         *chunk.Chunk
+		intermDataBuffer [][]aggfuncs.PartialResult
+		rows             = make([]chunk.Row, 1)
+	)
+	spillRow := func(row chunk.Row) error {
+		if spillChk == nil {
+			spillChk = w.spillHelper.newSpillChunk()
+			spilled = w.spillHelper.newList()
+		}
+		spillChk.AppendRow(row)
+		if !spillChk.IsFull() {
+			return nil
+		}
+		err := spilled.Add(spillChk)
+		spillChk.Reset()
+		return err
+	}
+	for i := 0; i < input.NumChunks(); i++ {
+		select {
+		case <-w.finishCh:
+			return spilled, nil
+		default:
+		}
+		chk, err := input.GetChunk(i)
+		if err != nil {
+			return spilled, err
+		}
+		w.spilledGroupKey, err = getGroupKey(sctx, chk, w.spilledGroupKey, w.groupByItems)
+		if err != nil {
+			return spilled, err
+		}
+		intermData := &HashAggIntermData{partialResultMap: make(aggPartialResultMapper)}
+		for j := 0; j < chk.NumRows(); j++ {
+			groupKey := string(w.spilledGroupKey[j])
+			partialResults, ok := intermData.partialResultMap[groupKey]
+			if !ok {
+				if !w.groupSet.Exist(groupKey) {
+					// Once a group is spilled, it can't be added in this round any more.
+					inSpillMode = inSpillMode || w.spillHelper.isFinalWorkerInSpillMode(w.partitionIdx)
+					if inSpillMode && w.groupSet.Count() > 0 {
+						if err = spillRow(chk.GetRow(j)); err != nil {
+							return spilled, err
+						}
+						continue
 					}
-					allMemDelta += memDelta
+				}
+				partialResults = make([]aggfuncs.PartialResult, 0, len(w.partialAggFuncs))
+				for _, af := range w.partialAggFuncs {
+					partialResult, _ := af.AllocPartialResult()
+					partialResults = append(partialResults, partialResult)
+				}
+				intermData.partialResultMap[groupKey] = partialResults
+				intermData.groupKeys = append(intermData.groupKeys, groupKey)
+			}
+			rows[0] = chk.GetRow(j)
+			for k, af := range w.partialAggFuncs {
+				if _, err = af.UpdatePartialResult(sctx, rows, partialResults[k]); err != nil {
+					return spilled, err
 				}
 			}
-			w.memTracker.Consume(allMemDelta)
 		}
-		if w.stats != nil {
-			w.stats.ExecTime += int64(time.Since(execStart))
-			w.stats.TaskNum += 1
+		if intermDataBuffer, err = w.mergeIntermData(sctx, intermData, intermDataBuffer); err != nil {
+			return spilled, err
 		}
 	}
+	if spillChk != nil && spillChk.NumRows() > 0 {
+		err = spilled.Add(spillChk)
+	}
+	return spilled, err
 }
 
-func (w *HashAggFinalWorker) getFinalResult(sctx sessionctx.Context) {
+// resetForNextRound releases the hash table of the last round.
+func (w *HashAggFinalWorker) resetForNextRound() {
+	var setSize int64
+	w.groupSet, setSize = set.NewStringSetWithMemoryUsage()
+	w.partialResultMap = make(aggPartialResultMapper)
+	w.BInMap = 0
+	w.memTracker.ReplaceBytesUsed(defBucketMemoryUsage*(1<<w.BInMap) + setSize + getGroupKeyMemUsage(w.groupKeys))
+	w.spillHelper.resetFinalWorkerSpillMode(w.partitionIdx)
+}
+
+// getFinalResult sends the final results to the main thread, it returns true if the HashAggExec is finished.
+func (w *HashAggFinalWorker) getFinalResult(sctx sessionctx.Context) bool {
 	waitStart := time.Now()
 	result, finished := w.receiveFinalResultHolder()
 	if w.stats != nil {
 		w.stats.WaitTime += int64(time.Since(waitStart))
 	}
 	if finished {
-		return
+		return true
 	}
 	execStart := time.Now()
 	memSize := getGroupKeyMemUsage(w.groupKeys)
@@ -723,7 +959,7 @@ func (w *HashAggFinalWorker) getFinalResult(sctx sessionctx.Context) {
 			w.outputCh <- &AfFinalResult{chk: result, giveBackCh: w.finalResultHolderCh}
 			result, finished = w.receiveFinalResultHolder()
 			if finished {
-				return
+				return true
 			}
 		}
 	}
@@ -731,6 +967,7 @@ func (w *HashAggFinalWorker) getFinalResult(sctx sessionctx.Context) {
 	if w.stats != nil {
 		w.stats.ExecTime += int64(time.Since(execStart))
 	}
+	return false
 }
 
 func (w *HashAggFinalWorker) receiveFinalResultHolder() (*chunk.Chunk, bool) {
@@ -756,6 +993,13 @@ func (w *HashAggFinalWorker) run(ctx sessionctx.Context, waitGroup *sync.WaitGro
 	if err := w.consumeIntermData(ctx); err != nil {
 		w.outputCh <- &AfFinalResult{err: err}
 	}
+	if w.spillHelper != nil {
+		w.spillHelper.finishMerging()
+		if err := w.restoreAndGetFinalResult(ctx); err != nil {
+			w.outputCh <- &AfFinalResult{err: err}
+		}
+		return
+	}
 	w.getFinalResult(ctx)
 }
 
@@ -869,6 +1113,9 @@ func (e *HashAggExec) prepare4ParallelExec(ctx context.Context) {
 // 1. input reader reads data from child executor and send them to partial workers.
 // 2. partial worker receives the input data, updates the partial results, and shuffle the partial results to the final workers.
 // 3. final worker receives partial results from all the partial workers, evaluates the final results and sends the final results to the main thread.
+// If the memory quota is exceeded, the partial workers stop creating new groups and spill the rows of these groups
+// to disk, partitioned in the same way as the partial results are shuffled. After merging the partial results, every
+// final worker re-aggregates the rows of its own partition from disk in rounds, just like the `spill mode` of unparallelExec.
 func (e *HashAggExec) parallelExec(ctx context.Context, chk *chunk.Chunk) error {
 	if !e.prepared {
 		e.prepare4ParallelExec(ctx)
@@ -1893,7 +2140,7 @@ func (e *HashAggExec) ActionSpill() *AggSpillDiskAction {
 // maxSpillTimes indicates how many times the data can spill at most.
 const maxSpillTimes = 10
 
-// AggSpillDiskAction implements memory.ActionOnExceed for unparalleled HashAgg.
+// AggSpillDiskAction implements memory.ActionOnExceed for HashAgg.
 // If the memory quota of a query is exceeded, AggSpillDiskAction.Action is
 // triggered.
 type AggSpillDiskAction struct {
@@ -1905,14 +2152,25 @@ type AggSpillDiskAction struct {
 // Action set HashAggExec spill mode.
 func (a *AggSpillDiskAction) Action(t *memory.Tracker) {
 	// Guarantee that processed data is at least 20% of the threshold, to avoid spilling too frequently.
-	if atomic.LoadUint32(&a.e.inSpillMode) == 0 && a.spillTimes < maxSpillTimes && a.e.memTracker.BytesConsumed() >= t.GetBytesLimit()/5 {
-		a.spillTimes++
-		logutil.BgLogger().Info("memory exceeds quota, set aggregate mode to spill-mode",
-			zap.Uint32("spillTimes", a.spillTimes),
-			zap.Int64("consumed", t.BytesConsumed()),
-			zap.Int64("quota", t.GetBytesLimit()))
-		atomic.StoreUint32(&a.e.inSpillMode, 1)
-		return
+	if a.e.memTracker.BytesConsumed() >= t.GetBytesLimit()/5 {
+		if h := a.e.spillHelper; h != nil {
+			// The parallel HashAggExec can always make progress by spilling, so the times are not limited.
+			// The spill mode is kept by every final worker and reset at the end of its own round.
+			if h.setSpillMode() {
+				logutil.BgLogger().Info("memory exceeds quota, set parallel aggregate mode to spill-mode",
+					zap.Int64("consumed", t.BytesConsumed()),
+					zap.Int64("quota", t.GetBytesLimit()))
+				return
+			}
+		} else if atomic.LoadUint32(&a.e.inSpillMode) == 0 && a.spillTimes < maxSpillTimes {
+			a.spillTimes++
+			logutil.BgLogger().Info("memory exceeds quota, set aggregate mode to spill-mode",
+				zap.Uint32("spillTimes", a.spillTimes),
+				zap.Int64("consumed", t.BytesConsumed()),
+				zap.Int64("quota", t.GetBytesLimit()))
+			atomic.StoreUint32(&a.e.inSpillMode, 1)
+			return
+		}
 	}
 	if fallback := a.GetFallback(); fallback != nil {
 		fallback.Action(t)
@@ -1926,3 +2184,153 @@ func (a *AggSpillDiskAction) GetPriority() int64 {
 
 // SetLogHook sets the hook, it does nothing just to form the memory.ActionOnExceed interface.
 func (a *AggSpillDiskAction) SetLogHook(hook func(uint64)) {}
+
+// The spill modes of the final workers of the parallel HashAggExec.
+const (
+	finalWorkerNotSpilling uint32 = iota
+	finalWorkerSpilling
+	finalWorkerFinished
+)
+
+// parallelHashAggSpillHelper manages the rows spilled by the parallel HashAggExec.
+type parallelHashAggSpillHelper struct {
+	// inSpillMode points to HashAggExec.inSpillMode, which is the spill mode shared by the partial workers.
+	// Once it is set by AggSpillDiskAction, the partial workers spill the rows of the new groups until they finish.
+	inSpillMode *uint32
+	// finalSpillModes[i] is the spill mode of the i-th final worker, which is reset at the end of every round
+	// of the worker, so the final workers don't interfere with each other when they re-aggregate in rounds.
+	finalSpillModes []uint32
+
+	fieldTypes   []*types.FieldType
+	maxChunkSize int
+	diskTracker  *disk.Tracker
+
+	// partitions stores the rows spilled by the partial workers, partitions[i] is re-aggregated
+	// by the i-th final worker.
+	partitions     []*chunk.ListInDisk
+	partitionLocks []sync.Mutex
+
+	// partialMemTrackers tracks the memory usage of the partial results of the partial workers,
+	// which is released after all the final workers merge them.
+	partialMemTrackers []*memory.Tracker
+	// mergingWorkers is the number of the final workers which are still merging the partial results.
+	mergingWorkers int32
+
+	mu struct {
+		sync.Mutex
+		// lists records the lists in disk which are not released yet.
+		lists map[*chunk.ListInDisk]struct{}
+	}
+}
+
+func newParallelHashAggSpillHelper(inSpillMode *uint32, fieldTypes []*types.FieldType, maxChunkSize, finalConcurrency int,
+	diskTracker *disk.Tracker) *parallelHashAggSpillHelper {
+	h := &parallelHashAggSpillHelper{
+		inSpillMode:     inSpillMode,
+		finalSpillModes: make([]uint32, finalConcurrency),
+		fieldTypes:      fieldTypes,
+		maxChunkSize:    maxChunkSize,
+		diskTracker:     diskTracker,
+		partitions:      make([]*chunk.ListInDisk, finalConcurrency),
+		partitionLocks:  make([]sync.Mutex, finalConcurrency),
+		mergingWorkers:  int32(finalConcurrency),
+	}
+	h.mu.lists = make(map[*chunk.ListInDisk]struct{})
+	return h
+}
+
+func (h *parallelHashAggSpillHelper) isInSpillMode() bool {
+	return atomic.LoadUint32(h.inSpillMode) == 1
+}
+
+func (h *parallelHashAggSpillHelper) isFinalWorkerInSpillMode(idx int) bool {
+	return atomic.LoadUint32(&h.finalSpillModes[idx]) == finalWorkerSpilling
+}
+
+// setSpillMode sets the spill mode of the partial workers and all the running final workers, it returns false
+// if none of them enters the spill mode, which means spilling doesn't help to release more memory.
+func (h *parallelHashAggSpillHelper) setSpillMode() bool {
+	triggered := false
+	if atomic.LoadInt32(&h.mergingWorkers) > 0 {
+		triggered = atomic.CompareAndSwapUint32(h.inSpillMode, 0, 1)
+	}
+	for i := range h.finalSpillModes {
+		if atomic.CompareAndSwapUint32(&h.finalSpillModes[i], finalWorkerNotSpilling, finalWorkerSpilling) {
+			triggered = true
+		}
+	}
+	return triggered
+}
+
+// resetFinalWorkerSpillMode resets the spill mode of the idx-th final worker after it releases the groups of a round.
+func (h *parallelHashAggSpillHelper) resetFinalWorkerSpillMode(idx int) {
+	atomic.StoreUint32(&h.finalSpillModes[idx], finalWorkerNotSpilling)
+}
+
+// finishRestoring is called after the idx-th final worker sends all its final results.
+func (h *parallelHashAggSpillHelper) finishRestoring(idx int) {
+	atomic.StoreUint32(&h.finalSpillModes[idx], finalWorkerFinished)
+}
+
+func (h *parallelHashAggSpillHelper) newSpillChunk() *chunk.Chunk {
+	return chunk.New(h.fieldTypes, h.maxChunkSize, h.maxChunkSize)
+}
+
+// newList creates a list in disk, which should be released by release.
+func (h *parallelHashAggSpillHelper) newList() *chunk.ListInDisk {
+	l := chunk.NewListInDisk(h.fieldTypes)
+	l.GetDiskTracker().AttachTo(h.diskTracker)
+	h.mu.Lock()
+	h.mu.lists[l] = struct{}{}
+	h.mu.Unlock()
+	return l
+}
+
+// spill appends chk to the idx-th partition, it's safe to be called by multiple partial workers.
+func (h *parallelHashAggSpillHelper) spill(idx int, chk *chunk.Chunk) error {
+	h.partitionLocks[idx].Lock()
+	defer h.partitionLocks[idx].Unlock()
+	if h.partitions[idx] == nil {
+		h.partitions[idx] = h.newList()
+	}
+	return h.partitions[idx].Add(chk)
+}
+
+// getPartition returns the rows spilled to the idx-th partition, it returns nil if no row is spilled.
+func (h *parallelHashAggSpillHelper) getPartition(idx int) *chunk.ListInDisk {
+	h.partitionLocks[idx].Lock()
+	defer h.partitionLocks[idx].Unlock()
+	return h.partitions[idx]
+}
+
+// finishMerging is called after a final worker merges all the partial results. The memory usage
+// of the partial results is released after all the final workers finish merging.
+func (h *parallelHashAggSpillHelper) finishMerging() {
+	if atomic.AddInt32(&h.mergingWorkers, -1) > 0 {
+		return
+	}
+	for _, tracker := range h.partialMemTrackers {
+		tracker.ReplaceBytesUsed(0)
+	}
+}
+
+// release closes the list and removes its file.
+func (h *parallelHashAggSpillHelper) release(l *chunk.ListInDisk) {
+	h.mu.Lock()
+	_, ok := h.mu.lists[l]
+	delete(h.mu.lists, l)
+	h.mu.Unlock()
+	if ok {
+		terror.Log(l.Close())
+	}
+}
+
+// close releases all the lists which are not released yet.
+func (h *parallelHashAggSpillHelper) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for l := range h.mu.lists {
+		terror.Log(l.Close())
+	}
+	h.mu.lists = make(map[*chunk.ListInDisk]struct{})
+}
@@ -1510,3 +1510,62 @@ func (s *testSerialSuite) TestAggInDisk(c *C) {
 	tk.MustQuery("select /*+ HASH_AGG() */ count(c) from t;").Check(testkit.Rows("0"))
 	tk.MustQuery("select /*+ HASH_AGG() */ count(c) from t group by c1;").Check(testkit.Rows())
 }
+
+func (s *testSerialSuite) TestParallelAggInDisk(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("set tidb_hashagg_final_concurrency = 4;")
+	tk.MustExec("set tidb_hashagg_partial_concurrency = 4;")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int)")
+	sql := "insert into t values (0)"
+	for i := 1; i <= 200; i++ {
+		sql += fmt.Sprintf(",(%v)", i)
+	}
+	sql += ";"
+	tk.MustExec(sql)
+	query := "select /*+ HASH_AGG() */ t1.a, t2.a, count(*), avg(t1.a + t2.a) from t t1 join t t2 group by t1.a, t2.a"
+	expected := tk.MustQuery(query).Sort().Rows()
+
+	tk.MustExec("set tidb_mem_quota_query = 4194304")
+	rows := tk.MustQuery("explain analyze " + query).Rows()
+	for _, row := range rows {
+		length := len(row)
+		line := fmt.Sprintf("%v", row)
+		disk := fmt.Sprintf("%v", row[length-1])
+		if strings.Contains(line, "HashAgg") {
+			c.Assert(strings.Contains(disk, "0 Bytes"), IsFalse)
+			c.Assert(strings.Contains(disk, "MB") ||
+				strings.Contains(disk, "KB") ||
+				strings.Contains(disk, "Bytes"), IsTrue)
+		}
+	}
+	// The results are the same as the ones aggregated in memory.
+	tk.MustQuery(query).Sort().Check(expected)
+	tk.MustQuery("select sum(tt.b) from ( select /*+ HASH_AGG() */ avg(t1.a) as b from t t1 join t t2 group by t1.a, t2.a) as tt").Check(
+		testkit.Rows("4040100.0000"))
+}
+
+func (s *testSerialSuite) TestParallelAggSpillInRounds(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("set tidb_hashagg_final_concurrency = 4;")
+	tk.MustExec("set tidb_hashagg_partial_concurrency = 4;")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int)")
+	sql := "insert into t values (0)"
+	for i := 1; i < 150; i++ {
+		sql += fmt.Sprintf(",(%v)", i)
+	}
+	tk.MustExec(sql)
+	query := "select /*+ HASH_AGG() */ t1.a, t2.a, count(*), sum(t1.a * t2.a) from t t1 join t t2 group by t1.a, t2.a"
+	expected := tk.MustQuery(query).Sort().Rows()
+	c.Assert(expected, HasLen, 22500)
+
+	// Every final worker re-aggregates its partition in several rounds and spills in most of them,
+	// the workers spill more than maxSpillTimes times in total.
+	tk.MustExec("set tidb_mem_quota_query = 1048576")
+	tk.MustQuery(query).Sort().Check(expected)
+	tk.MustQuery("select sum(tt.c) from (select /*+ HASH_AGG() */ count(*) as c from t t1 join t t2 group by t1.a, t2.a) as tt").Check(
+		testkit.Rows("22500"))
+}
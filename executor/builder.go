@@ -172,6 +172,10 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 		return b.buildSelectLock(v)
 	case *plannercore.CancelDDLJobs:
 		return b.buildCancelDDLJobs(v)
+	case *plannercore.PauseDDLJobs:
+		return b.buildPauseDDLJobs(v)
+	case *plannercore.ResumeDDLJobs:
+		return b.buildResumeDDLJobs(v)
 	case *plannercore.ShowNextRowID:
 		return b.buildShowNextRowID(v)
 	case *plannercore.ShowDDL:
@@ -224,6 +228,8 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
@@ -270,10 +276,11 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 	}
 }
 
-func (b *executorBuilder) buildCancelDDLJobs(v *plannercore.CancelDDLJobs) Executor {
-	e := &CancelDDLJobsExec{
-		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
-		jobIDs:       v.JobIDs,
+func (b *executorBuilder) buildCommandDDLJobs(schema *expression.Schema, id int, jobIDs []int64,
+	command func(kv.Transaction, []int64) ([]error, error)) *CommandDDLJobsExec {
+	e := &CommandDDLJobsExec{
+		baseExecutor: newBaseExecutor(b.ctx, schema, id),
+		jobIDs:       jobIDs,
 	}
 	txn, err := e.ctx.Txn(true)
 	if err != nil {
@@ -281,13 +288,37 @@ func (b *executorBuilder) buildCancelDDLJobs(v *plannercore.CancelDDLJobs) Execu
 		return nil
 	}
 
-	e.errs, b.err = admin.CancelJobs(txn, e.jobIDs)
+	e.errs, b.err = command(txn, e.jobIDs)
 	if b.err != nil {
 		return nil
 	}
 	return e
 }
 
+func (b *executorBuilder) buildCancelDDLJobs(v *plannercore.CancelDDLJobs) Executor {
+	e := b.buildCommandDDLJobs(v.Schema(), v.ID(), v.JobIDs, admin.CancelJobs)
+	if e == nil {
+		return nil
+	}
+	return &CancelDDLJobsExec{e}
+}
+
+func (b *executorBuilder) buildPauseDDLJobs(v *plannercore.PauseDDLJobs) Executor {
+	e := b.buildCommandDDLJobs(v.Schema(), v.ID(), v.JobIDs, admin.PauseJobs)
+	if e == nil {
+		return nil
+	}
+	return &PauseDDLJobsExec{e}
+}
+
+func (b *executorBuilder) buildResumeDDLJobs(v *plannercore.ResumeDDLJobs) Executor {
+	e := b.buildCommandDDLJobs(v.Schema(), v.ID(), v.JobIDs, admin.ResumeJobs)
+	if e == nil {
+		return nil
+	}
+	return &ResumeDDLJobsExec{e}
+}
+
 func (b *executorBuilder) buildChange(v *plannercore.Change) Executor {
 	return &ChangeExec{
 		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
@@ -819,19 +850,25 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) Executor {
 		hasRefCols:                v.NeedFillDefaultValue,
 		SelectExec:                selectExec,
 		rowLen:                    v.RowLen,
+		fkTriggers:                newFKTriggerExec(b.ctx, v.FKTriggers),
 	}
 	err := ivs.initInsertColumns()
 	if err != nil {
 		b.err = err
 		return nil
 	}
+	ivs.checkConstraints, b.err = buildCheckConstraintExec(b.ctx, v.Table)
+	if b.err != nil {
+		return nil
+	}
 
 	if v.IsReplace {
-		return b.buildReplace(ivs)
+		return b.buildReplace(ivs, newFKTriggerExec(b.ctx, v.OnDeleteFKTriggers))
 	}
 	insert := &InsertExec{
-		InsertValues: ivs,
-		OnDuplicate:  append(v.OnDuplicate, v.GenCols.OnDuplicates...),
+		InsertValues:    ivs,
+		OnDuplicate:     append(v.OnDuplicate, v.GenCols.OnDuplicates...),
+		onDupFKTriggers: newFKTriggerExec(b.ctx, v.OnUpdateFKTriggers),
 	}
 	return insert
 }
@@ -850,6 +887,18 @@ func (b *executorBuilder) buildLoadData(v *plannercore.LoadData) Executor {
 		isLoadData:   true,
 		txnInUse:     sync.Mutex{},
 	}
+	if b.ctx.GetSessionVars().ForeignKeyChecks {
+		checks, err := plannercore.BuildOnInsertFKTriggers(b.ctx, b.is, v.Table.Schema, tbl)
+		if err != nil {
+			b.err = err
+			return nil
+		}
+		insertVal.fkTriggers = newFKTriggerExec(b.ctx, &plannercore.FKTriggers{Checks: checks})
+	}
+	insertVal.checkConstraints, b.err = buildCheckConstraintExec(b.ctx, tbl)
+	if b.err != nil {
+		return nil
+	}
 	loadDataInfo := &LoadDataInfo{
 		row:                make([]types.Datum, 0, len(insertVal.insertColumns)),
 		InsertValues:       insertVal,
@@ -912,9 +961,10 @@ func (b *executorBuilder) buildPlanRecreatorSingle(v *plannercore.PlanRecreatorS
 	return e
 }
 
-func (b *executorBuilder) buildReplace(vals *InsertValues) Executor {
+func (b *executorBuilder) buildReplace(vals *InsertValues, deleteFKTriggers *fkTriggerExec) Executor {
 	replaceExec := &ReplaceExec{
-		InsertValues: vals,
+		InsertValues:     vals,
+		deleteFKTriggers: deleteFKTriggers,
 	}
 	return replaceExec
 }
@@ -1003,6 +1053,7 @@ func (b *executorBuilder) buildSelectInto(v *plannercore.SelectInto) Executor {
 	return &SelectIntoExec{
 		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID(), child),
 		intoOpt:      v.IntoOpt,
+		names:        v.TargetNames,
 	}
 }
 
@@ -1047,6 +1098,8 @@ func (b *executorBuilder) buildUnionScanFromReader(reader Executor, v *plannerco
 		us.columns = x.columns
 		us.table = x.table
 		us.virtualColumnIndex = x.virtualColumnIndex
+		us.cacheTable = b.getCacheTable(x.table, x.startTS)
+		x.dummy = us.cacheTable != nil
 	case *IndexReaderExecutor:
 		us.desc = x.desc
 		for _, ic := range x.index.Columns {
@@ -1060,6 +1113,8 @@ func (b *executorBuilder) buildUnionScanFromReader(reader Executor, v *plannerco
 		us.conditions, us.conditionsWithVirCol = plannercore.SplitSelCondsWithVirtualColumn(v.Conditions)
 		us.columns = x.columns
 		us.table = x.table
+		us.cacheTable = b.getCacheTable(x.table, x.startTS)
+		x.dummy = us.cacheTable != nil
 	case *IndexLookUpExecutor:
 		us.desc = x.desc
 		for _, ic := range x.index.Columns {
@@ -1074,6 +1129,8 @@ func (b *executorBuilder) buildUnionScanFromReader(reader Executor, v *plannerco
 		us.columns = x.columns
 		us.table = x.table
 		us.virtualColumnIndex = buildVirtualColumnIndex(us.Schema(), us.columns)
+		us.cacheTable = b.getCacheTable(x.table, x.startTS)
+		x.dummy = us.cacheTable != nil
 	default:
 		// The mem table will not be written by sql directly, so we can omit the union scan to avoid err reporting.
 		return originReader
@@ -1081,6 +1138,25 @@ func (b *executorBuilder) buildUnionScanFromReader(reader Executor, v *plannerco
 	return us
 }
 
+// getCacheTable returns the data of the cached table if it can serve the read at startTS. Otherwise, it tries to
+// load the data in the background, so the following reads may be served from the cache.
+func (b *executorBuilder) getCacheTable(tbl table.Table, startTS uint64) kv.MemBuffer {
+	cachedTable, ok := tbl.(table.CachedTable)
+	if !ok {
+		return nil
+	}
+	sessVars := b.ctx.GetSessionVars()
+	if cacheData := cachedTable.TryReadFromCache(startTS); cacheData != nil {
+		sessVars.StmtCtx.ReadFromTableCache = true
+		return cacheData
+	}
+	// The write statements are going to acquire the write lock, don't compete with them by the read lock.
+	if !sessVars.StmtCtx.InUpdateStmt && !sessVars.StmtCtx.InDeleteStmt {
+		cachedTable.UpdateLockForRead(b.ctx.GetStore())
+	}
+	return nil
+}
+
 // buildMergeJoin builds MergeJoinExec executor.
 func (b *executorBuilder) buildMergeJoin(v *plannercore.PhysicalMergeJoin) Executor {
 	leftExec := b.build(v.Children()[0])
@@ -1421,6 +1497,15 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) Execu
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) Executor {
+	return &JSONTableExec{
+		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		doc:          v.Expr,
+		root:         v.Root,
+		sc:           plannercore.NewJSONTableStmtCtx(b.ctx),
+	}
+}
+
 // `getSnapshotTS` returns the timestamp of the snapshot that a reader should read.
 func (b *executorBuilder) getSnapshotTS() (uint64, error) {
 	// `refreshForUpdateTSForRC` should always be invoked before returning the cached value to
@@ -1588,6 +1673,10 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 			strings.ToLower(infoschema.TableTiDBHotRegions),
 			strings.ToLower(infoschema.TableSessionVar),
 			strings.ToLower(infoschema.TableConstraints),
+			strings.ToLower(infoschema.TableCheckConstraints),
+			strings.ToLower(infoschema.TableTiDBMDLView),
+			strings.ToLower(infoschema.TableRunawayWatches),
+			strings.ToLower(infoschema.TableResourceGroups),
 			strings.ToLower(infoschema.TableTiFlashReplica),
 			strings.ToLower(infoschema.TableTiDBServersInfo),
 			strings.ToLower(infoschema.TableTiKVStoreStatus),
@@ -1961,6 +2050,11 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) Executor {
 		tblID2table:               tblID2table,
 		tblColPosInfos:            v.TblColPosInfos,
 		assignFlag:                assignFlag,
+		fkTriggers:                b.buildFKTriggersByTableID(v.FKTriggers),
+	}
+	updateExec.checkConstraints, b.err = buildCheckConstraintsByTableID(b.ctx, tblID2table)
+	if b.err != nil {
+		return nil
 	}
 	return updateExec
 }
@@ -2003,10 +2097,41 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) Executor {
 		tblID2Table:    tblID2table,
 		IsMultiTable:   v.IsMultiTable,
 		tblColPosInfos: v.TblColPosInfos,
+		fkTriggers:     b.buildFKTriggersByTableID(v.FKTriggers),
 	}
 	return deleteExec
 }
 
+func buildCheckConstraintsByTableID(ctx sessionctx.Context, tblID2table map[int64]table.Table) (map[int64]*checkConstraintExec, error) {
+	var checkConstraints map[int64]*checkConstraintExec
+	for tid, tbl := range tblID2table {
+		e, err := buildCheckConstraintExec(ctx, tbl)
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			if checkConstraints == nil {
+				checkConstraints = make(map[int64]*checkConstraintExec, len(tblID2table))
+			}
+			checkConstraints[tid] = e
+		}
+	}
+	return checkConstraints, nil
+}
+
+func (b *executorBuilder) buildFKTriggersByTableID(tblID2Triggers map[int64]*plannercore.FKTriggers) map[int64]*fkTriggerExec {
+	var fkTriggers map[int64]*fkTriggerExec
+	for tid, triggers := range tblID2Triggers {
+		if fkt := newFKTriggerExec(b.ctx, triggers); fkt != nil {
+			if fkTriggers == nil {
+				fkTriggers = make(map[int64]*fkTriggerExec, len(tblID2Triggers))
+			}
+			fkTriggers[tid] = fkt
+		}
+	}
+	return fkTriggers
+}
+
 // updateForUpdateTSIfNeeded updates the ForUpdateTS for a pessimistic transaction if needed.
 // PointGet executor will get conflict error if the ForUpdateTS is older than the latest commitTS,
 // so we don't need to update now for better latency.
@@ -4268,6 +4393,8 @@ func (b *executorBuilder) buildBatchPointGet(plan *plannercore.BatchPointGetPlan
 
 	if e.lock {
 		b.hasLock = true
+	} else if tbl, ok := b.is.TableByID(plan.TblInfo.ID); ok {
+		e.cacheTable = b.getCacheTable(tbl, startTS)
 	}
 	var capacity int
 	if plan.IndexInfo != nil && !isCommonHandleRead(plan.TblInfo, plan.IndexInfo) {
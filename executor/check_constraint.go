@@ -0,0 +1,155 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// checkConstraintExec evaluates the enforced check constraints on the rows written into a table.
+// A nil *checkConstraintExec does nothing.
+type checkConstraintExec struct {
+	ctx         sessionctx.Context
+	constraints []*model.ConstraintInfo
+	exprs       []expression.Expression
+
+	// The expressions are built on the writable columns, since the constraints of the adding columns
+	// refer to the non-public columns. addingCols are the non-public writable columns, addingVals are
+	// their values in the rows without them, which are the values written by table.AddRecord.
+	addingCols []*expression.Column
+	addingVals []types.Datum
+}
+
+// buildCheckConstraintExec builds the executor of the check constraints of the table, it returns nil
+// if the table has no enforced check constraint.
+// The constraints being added are also checked, so the rows written during the validation of the
+// existing rows can't break them.
+func buildCheckConstraintExec(ctx sessionctx.Context, tbl table.Table) (*checkConstraintExec, error) {
+	tblInfo := tbl.Meta()
+	var (
+		e      *checkConstraintExec
+		schema *expression.Schema
+		names  types.NameSlice
+	)
+	for _, constr := range tblInfo.Constraints {
+		if !constr.Enforced {
+			continue
+		}
+		switch constr.State {
+		case model.StateWriteOnly, model.StateWriteReorganization, model.StatePublic:
+		default:
+			continue
+		}
+		if e == nil {
+			var err error
+			e, schema, names, err = newCheckConstraintExec(ctx, tbl)
+			if err != nil {
+				return nil, err
+			}
+		}
+		exprs, err := expression.ParseSimpleExprsWithNames(ctx, constr.ExprString, schema, names)
+		if err != nil {
+			return nil, err
+		}
+		e.constraints = append(e.constraints, constr)
+		e.exprs = append(e.exprs, exprs[0])
+	}
+	return e, nil
+}
+
+func newCheckConstraintExec(ctx sessionctx.Context, tbl table.Table) (*checkConstraintExec, *expression.Schema, types.NameSlice, error) {
+	writableCols := tbl.WritableCols()
+	colInfos := make([]*model.ColumnInfo, 0, len(writableCols))
+	for _, col := range writableCols {
+		colInfos = append(colInfos, col.ColumnInfo)
+	}
+	cols, names, err := expression.ColumnInfos2ColumnsAndNames(ctx, model.CIStr{}, tbl.Meta().Name, colInfos, tbl.Meta())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	e := &checkConstraintExec{ctx: ctx}
+	for i, col := range writableCols {
+		if col.State == model.StatePublic {
+			continue
+		}
+		var val types.Datum
+		if !col.IsGenerated() {
+			val, err = table.GetColOriginDefaultValue(ctx, col.ToInfo())
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		e.addingCols = append(e.addingCols, cols[i])
+		e.addingVals = append(e.addingVals, val)
+	}
+	return e, expression.NewSchema(cols...), names, nil
+}
+
+// check returns an error if the row violates any check constraint. Like MySQL, the constraint
+// is violated only when the expression is evaluated to false, NULL is regarded as satisfied.
+func (e *checkConstraintExec) check(row []types.Datum) error {
+	if e == nil {
+		return nil
+	}
+	r, err := e.fillAddingColumns(row)
+	if err != nil {
+		return err
+	}
+	for i, expr := range e.exprs {
+		val, err := expr.Eval(r)
+		if err != nil {
+			return err
+		}
+		if val.IsNull() {
+			continue
+		}
+		ok, err := val.ToBool(e.ctx.GetSessionVars().StmtCtx)
+		if err != nil {
+			return err
+		}
+		if ok == 0 {
+			return ErrCheckConstraintViolated.GenWithStackByArgs(e.constraints[i].Name.O)
+		}
+	}
+	return nil
+}
+
+// fillAddingColumns converts the row to evaluate the expressions. The inserted rows only have the public
+// columns, the values of the adding columns are filled like table.AddRecord does.
+func (e *checkConstraintExec) fillAddingColumns(row []types.Datum) (chunk.Row, error) {
+	if len(e.addingCols) == 0 || len(row) > e.addingCols[0].Index {
+		return chunk.MutRowFromDatums(row).ToRow(), nil
+	}
+	fullRow := make([]types.Datum, len(row), len(row)+len(e.addingCols))
+	copy(fullRow, row)
+	fullRow = append(fullRow, e.addingVals...)
+	mutRow := chunk.MutRowFromDatums(fullRow)
+	for _, col := range e.addingCols {
+		if col.VirtualExpr == nil {
+			continue
+		}
+		val, err := col.VirtualExpr.Eval(mutRow.ToRow())
+		if err != nil {
+			return chunk.Row{}, err
+		}
+		mutRow.SetDatum(col.Index, val)
+	}
+	return mutRow.ToRow(), nil
+}
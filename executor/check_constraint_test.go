@@ -0,0 +1,78 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/util/testkit"
+)
+
+func (s *testSuite8) TestCheckConstraint(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, a int check (a > 0), b int, constraint t_ab check (a < b))")
+
+	// NULL is regarded as satisfied.
+	tk.MustExec("insert into t values (1, 1, 2), (2, null, 1), (3, 1, null)")
+	tk.MustGetErrCode("insert into t values (4, 0, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("insert into t values (4, 2, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("replace into t values (1, 2, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("insert into t values (1, 1, 2) on duplicate key update b = 0", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("update t set a = 5 where id = 1", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("update t set a = 0 where id = 2", errno.ErrCheckConstraintViolated)
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 1 2", "2 <nil> 1", "3 1 <nil>"))
+
+	// The violations are reported as warnings with IGNORE.
+	tk.MustExec("insert ignore into t values (4, 0, 1), (5, 1, 2)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 3819 Check constraint 't_chk_1' is violated."))
+	tk.MustExec("update ignore t set b = 0 where id = 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 3819 Check constraint 't_ab' is violated."))
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 1 2", "2 <nil> 1", "3 1 <nil>", "5 1 2"))
+
+	// The constraint isn't checked when it is not enforced.
+	tk.MustExec("alter table t alter check t_ab not enforced")
+	tk.MustExec("insert into t values (6, 3, 1)")
+	tk.MustGetErrCode("alter table t alter check t_ab enforced", errno.ErrCheckConstraintViolated)
+	tk.MustQuery("select constraint_name, enforced from information_schema.table_constraints where table_schema = 'test' and table_name = 't' order by constraint_name").
+		Check(testkit.Rows("PRIMARY YES", "t_ab NO", "t_chk_1 YES"))
+	tk.MustExec("delete from t where id = 6")
+	tk.MustExec("alter table t alter check t_ab enforced")
+	tk.MustGetErrCode("insert into t values (6, 3, 1)", errno.ErrCheckConstraintViolated)
+
+	// Add a constraint with the existing rows violating it.
+	tk.MustGetErrCode("alter table t add constraint t_b check (b > 1)", errno.ErrCheckConstraintViolated)
+	tk.MustExec("alter table t add constraint t_b check (b > 0)")
+	tk.MustGetErrCode("insert into t values (6, null, 0)", errno.ErrCheckConstraintViolated)
+	tk.MustQuery("select * from information_schema.check_constraints where constraint_schema = 'test' order by constraint_name").
+		Check(testkit.Rows("def test t_ab (`a` < `b`)", "def test t_b (`b` > 0)", "def test t_chk_1 (`a` > 0)"))
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  CONSTRAINT `t_ab` CHECK ((`a` < `b`)),\n" +
+		"  CONSTRAINT `t_chk_1` CHECK ((`a` > 0)),\n" +
+		"  CONSTRAINT `t_b` CHECK ((`b` > 0))\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	// The column constraint is dropped with the column, but the constraint referencing several columns isn't.
+	tk.MustGetErrCode("alter table t drop column a", errno.ErrDependentByCheckConstraint)
+	tk.MustExec("alter table t drop check t_ab")
+	tk.MustExec("alter table t drop column a")
+	tk.MustQuery("select constraint_name from information_schema.check_constraints where constraint_schema = 'test'").Check(testkit.Rows("t_b"))
+	tk.MustGetErrCode("alter table t drop check t_ab", errno.ErrCheckConstraintNotFound)
+}
@@ -172,6 +172,8 @@ type IndexReaderExecutor struct {
 	startTS          uint64
 	readReplicaScope string
 	isStaleness      bool
+	// dummy means the data is read from the cache of the cached table by union scan, no distsql request is sent.
+	dummy bool
 	// result returns one or more distsql.PartialResult and each PartialResult is returned by one region.
 	result distsql.SelectResult
 	// columns are only required by union scan.
@@ -198,7 +200,7 @@ type IndexReaderExecutor struct {
 
 // Close clears all resources hold by current object.
 func (e *IndexReaderExecutor) Close() error {
-	if e.table != nil && e.table.Meta().TempTableType != model.TempTableNone {
+	if e.dummy || e.table != nil && e.table.Meta().TempTableType != model.TempTableNone {
 		return nil
 	}
 
@@ -210,7 +212,7 @@ func (e *IndexReaderExecutor) Close() error {
 
 // Next implements the Executor Next interface.
 func (e *IndexReaderExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
-	if e.table != nil && e.table.Meta().TempTableType != model.TempTableNone {
+	if e.dummy || e.table != nil && e.table.Meta().TempTableType != model.TempTableNone {
 		req.Reset()
 		return nil
 	}
@@ -277,9 +279,9 @@ func (e *IndexReaderExecutor) open(ctx context.Context, kvRanges []kv.KeyRange)
 		e.dagPB.CollectExecutionSummaries = &collExec
 	}
 	e.kvRanges = kvRanges
-	// Treat temporary table as dummy table, avoid sending distsql request to TiKV.
+	// Treat temporary table and cached table as dummy table, avoid sending distsql request to TiKV.
 	// In a test case IndexReaderExecutor is mocked and e.table is nil.
-	if e.table != nil && e.table.Meta().TempTableType != model.TempTableNone {
+	if e.dummy || e.table != nil && e.table.Meta().TempTableType != model.TempTableNone {
 		return nil
 	}
 
@@ -334,6 +336,9 @@ type IndexLookUpExecutor struct {
 	partitionRangeMap  map[int64][]*ranger.Range
 	partitionKVRanges  [][]kv.KeyRange // kvRanges of each partition table
 
+	// dummy means the data is read from the cache of the cached table by union scan, no distsql request is sent.
+	dummy bool
+
 	// All fields above are immutable.
 
 	idxWorkerWg sync.WaitGroup
@@ -403,8 +408,8 @@ func (e *IndexLookUpExecutor) Open(ctx context.Context) error {
 		return err
 	}
 
-	// Treat temporary table as dummy table, avoid sending distsql request to TiKV.
-	if e.table.Meta().TempTableType != model.TempTableNone {
+	// Treat temporary table and cached table as dummy table, avoid sending distsql request to TiKV.
+	if e.dummy || e.table.Meta().TempTableType != model.TempTableNone {
 		return nil
 	}
 
@@ -667,7 +672,7 @@ func (e *IndexLookUpExecutor) buildTableReader(ctx context.Context, task *lookup
 
 // Close implements Exec Close interface.
 func (e *IndexLookUpExecutor) Close() error {
-	if e.table.Meta().TempTableType != model.TempTableNone {
+	if e.dummy || e.table.Meta().TempTableType != model.TempTableNone {
 		return nil
 	}
 
@@ -691,7 +696,7 @@ func (e *IndexLookUpExecutor) Close() error {
 
 // Next implements Exec Next interface.
 func (e *IndexLookUpExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
-	if e.table.Meta().TempTableType != model.TempTableNone {
+	if e.dummy || e.table.Meta().TempTableType != model.TempTableNone {
 		req.Reset()
 		return nil
 	}
@@ -62,6 +62,17 @@ var (
 	ErrDataInConsistentExtraIndex    = dbterror.ClassExecutor.NewStd(mysql.ErrDataInConsistentExtraIndex)
 	ErrDataInConsistentMisMatchIndex = dbterror.ClassExecutor.NewStd(mysql.ErrDataInConsistentMisMatchIndex)
 
+	ErrNoReferencedRow2               = dbterror.ClassExecutor.NewStd(mysql.ErrNoReferencedRow2)
+	ErrRowIsReferenced2               = dbterror.ClassExecutor.NewStd(mysql.ErrRowIsReferenced2)
+	ErrForeignKeyCascadeDepthExceeded = dbterror.ClassExecutor.NewStd(mysql.ErrForeignKeyCascadeDepthExceeded)
+	ErrCheckConstraintViolated        = dbterror.ClassExecutor.NewStd(mysql.ErrCheckConstraintViolated)
+
+	ErrNotValidPassword               = dbterror.ClassExecutor.NewStd(mysql.ErrNotValidPassword)
+	ErrCredentialsContradictToHistory = dbterror.ClassExecutor.NewStd(mysql.ErrCredentialsContradictToHistory)
+	ErrSetPasswordAuthPlugin          = dbterror.ClassExecutor.NewStd(mysql.ErrSetPasswordAuthPlugin)
+
+	ErrMissingJSONTableValue = dbterror.ClassExecutor.NewStd(mysql.ErrMissingJSONTableValue)
+
 	errUnsupportedFlashbackTmpTable = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("Recover/flashback table is not supported on temporary tables", nil))
 	errTruncateWrongInsertValue     = dbterror.ClassTable.NewStdErr(mysql.ErrTruncatedWrongValue, parser_mysql.Message("Incorrect %-.32s value: '%-.128s' for column '%.192s' at row %d", nil))
 )
@@ -295,8 +295,10 @@ func Next(ctx context.Context, e Executor, req *chunk.Chunk) error {
 	return err
 }
 
-// CancelDDLJobsExec represents a cancel DDL jobs executor.
-type CancelDDLJobsExec struct {
+// CommandDDLJobsExec is the general command executor for the DDL jobs, like canceling, pausing
+// and resuming the jobs. The command is run when the executor is built, it outputs the result of
+// every job.
+type CommandDDLJobsExec struct {
 	baseExecutor
 
 	cursor int
@@ -305,7 +307,7 @@ type CancelDDLJobsExec struct {
 }
 
 // Next implements the Executor Next interface.
-func (e *CancelDDLJobsExec) Next(ctx context.Context, req *chunk.Chunk) error {
+func (e *CommandDDLJobsExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	req.GrowAndReset(e.maxChunkSize)
 	if e.cursor >= len(e.jobIDs) {
 		return nil
@@ -323,6 +325,21 @@ func (e *CancelDDLJobsExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return nil
 }
 
+// CancelDDLJobsExec represents a cancel DDL jobs executor.
+type CancelDDLJobsExec struct {
+	*CommandDDLJobsExec
+}
+
+// PauseDDLJobsExec represents a pause DDL jobs executor.
+type PauseDDLJobsExec struct {
+	*CommandDDLJobsExec
+}
+
+// ResumeDDLJobsExec represents a resume DDL jobs executor.
+type ResumeDDLJobsExec struct {
+	*CommandDDLJobsExec
+}
+
 // ShowNextRowIDExec represents a show the next row ID executor.
 type ShowNextRowIDExec struct {
 	baseExecutor
@@ -446,6 +463,7 @@ type DDLJobRetriever struct {
 	is             infoschema.InfoSchema
 	activeRoles    []*auth.RoleIdentity
 	cacheJobs      []*model.Job
+	pausedJobIDs   map[int64]struct{}
 }
 
 func (e *DDLJobRetriever) initial(txn kv.Transaction) error {
@@ -458,6 +476,16 @@ func (e *DDLJobRetriever) initial(txn kv.Transaction) error {
 	if err != nil {
 		return err
 	}
+	e.pausedJobIDs = make(map[int64]struct{})
+	for _, job := range jobs {
+		paused, err := m.IsDDLJobPaused(job.ID)
+		if err != nil {
+			return err
+		}
+		if paused {
+			e.pausedJobIDs[job.ID] = struct{}{}
+		}
+	}
 	e.runningJobs = jobs
 	e.cursor = 0
 	return nil
@@ -506,7 +534,11 @@ func (e *DDLJobRetriever) appendJobToChunk(req *chunk.Chunk, job *model.Job, che
 	} else {
 		req.AppendNull(9)
 	}
-	req.AppendString(10, job.State.String())
+	state := job.State.String()
+	if _, ok := e.pausedJobIDs[job.ID]; ok {
+		state = "paused"
+	}
+	req.AppendString(10, state)
 }
 
 func ts2Time(timestamp uint64) types.Time {
@@ -1883,3 +1915,10 @@ func setResourceGroupTagForTxn(sc *stmtctx.StatementContext, snapshot kv.Snapsho
 		snapshot.SetOption(kv.ResourceGroupTag, sc.GetResourceGroupTag())
 	}
 }
+
+// setResourceGroupLimiterForSnapshot makes the point gets of the snapshot throttled by the resource group.
+func setResourceGroupLimiterForSnapshot(vars *variable.SessionVars, snapshot kv.Snapshot) {
+	if snapshot != nil && vars.ResourceGroupLimiter != nil {
+		snapshot.SetOption(kv.ResourceGroup, vars.ResourceGroupLimiter)
+	}
+}
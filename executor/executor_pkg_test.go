@@ -554,6 +554,44 @@ func getGrowing(m aggPartialResultMapper) bool {
 	return value.oldbuckets != nil
 }
 
+func (s *pkgTestSuite) TestParallelHashAggSpillMode(c *C) {
+	var inSpillMode uint32
+	h := newParallelHashAggSpillHelper(&inSpillMode, nil, 32, 3, nil)
+	c.Assert(h.isInSpillMode(), IsFalse)
+	c.Assert(h.setSpillMode(), IsTrue)
+	c.Assert(h.isInSpillMode(), IsTrue)
+	for i := 0; i < 3; i++ {
+		c.Assert(h.isFinalWorkerInSpillMode(i), IsTrue)
+	}
+	// Spilling doesn't release more memory if all the workers are in spill mode.
+	c.Assert(h.setSpillMode(), IsFalse)
+
+	// Finishing merging doesn't reset the spill mode of the final workers.
+	for i := 0; i < 3; i++ {
+		h.finishMerging()
+	}
+	c.Assert(h.isFinalWorkerInSpillMode(0), IsTrue)
+
+	// A final worker finishing its round doesn't reset the spill mode of the others.
+	h.resetFinalWorkerSpillMode(0)
+	c.Assert(h.isFinalWorkerInSpillMode(0), IsFalse)
+	c.Assert(h.isFinalWorkerInSpillMode(1), IsTrue)
+	c.Assert(h.isFinalWorkerInSpillMode(2), IsTrue)
+	c.Assert(h.setSpillMode(), IsTrue)
+	c.Assert(h.isFinalWorkerInSpillMode(0), IsTrue)
+
+	// The workers can spill in any number of rounds.
+	for round := 0; round < 2*maxSpillTimes; round++ {
+		h.resetFinalWorkerSpillMode(1)
+		c.Assert(h.setSpillMode(), IsTrue)
+	}
+
+	// The finished workers never enter the spill mode.
+	h.finishRestoring(2)
+	c.Assert(h.setSpillMode(), IsFalse)
+	c.Assert(h.isFinalWorkerInSpillMode(2), IsFalse)
+}
+
 func (s *pkgTestSuite) TestFilterTemporaryTableKeys(c *C) {
 	vars := variable.NewSessionVars()
 	const tableID int64 = 3
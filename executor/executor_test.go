@@ -354,6 +354,9 @@ func (s *testSuiteP1) TestShow(c *C) {
 		"RESTRICTED_USER_ADMIN Server Admin ",
 		"RESTRICTED_CONNECTION_ADMIN Server Admin ",
 		"RESTRICTED_REPLICA_WRITER_ADMIN Server Admin ",
+		"RESOURCE_GROUP_ADMIN Server Admin ",
+		"DDL_ADMIN Server Admin ",
+		"EXTERNAL_STORAGE_ADMIN Server Admin ",
 	))
 	c.Assert(len(tk.MustQuery("show table status").Rows()), Equals, 1)
 }
@@ -377,6 +380,10 @@ func (s *testSuite3) TestAdmin(c *C) {
 	c.Assert(row.GetString(0), Equals, "1")
 	c.Assert(row.GetString(1), Matches, "*DDL Job:1 not found")
 
+	// pause and resume DDL jobs test
+	tk.MustQuery("admin pause ddl jobs 1").Check(testkit.Rows("1 error: [admin:8224]DDL Job:1 not found"))
+	tk.MustQuery("admin resume ddl jobs 1").Check(testkit.Rows("1 error: [admin:8224]DDL Job:1 not found"))
+
 	// show ddl test;
 	r, err = tk.Exec("admin show ddl")
 	c.Assert(err, IsNil)
@@ -5655,6 +5662,9 @@ func (s *testSuiteWithCliBaseCharset) TestCharsetFeature(c *C) {
 		"utf8_bin utf8 83 Yes Yes 1",
 		"utf8_general_ci utf8 33  Yes 1",
 		"utf8_unicode_ci utf8 192  Yes 1",
+		"utf8mb4_0900_ai_ci utf8mb4 255  Yes 1",
+		"utf8mb4_0900_as_cs utf8mb4 278  Yes 1",
+		"utf8mb4_0900_bin utf8mb4 309  Yes 1",
 		"utf8mb4_bin utf8mb4 46 Yes Yes 1",
 		"utf8mb4_general_ci utf8mb4 45  Yes 1",
 		"utf8mb4_unicode_ci utf8mb4 224  Yes 1",
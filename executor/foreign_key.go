@@ -0,0 +1,548 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/charset"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/codec"
+)
+
+// maxForeignKeyCascadeDepth is the max depth of the cascaded referential actions, it's the same as MySQL.
+const maxForeignKeyCascadeDepth = 15
+
+// fkTriggerExec checks the foreign key constraints and executes the referential actions for the rows
+// written into a table. A nil *fkTriggerExec does nothing.
+type fkTriggerExec struct {
+	ctx      sessionctx.Context
+	checks   []*plannercore.FKCheck
+	cascades []*plannercore.FKCascade
+	depth    int
+
+	// childTriggers caches the triggers of the child tables modified by the referential actions.
+	childTriggers map[*plannercore.FKCascade]*fkTriggerExec
+	// childConstraints caches the check constraints of the child tables updated by the referential actions.
+	childConstraints map[*plannercore.FKCascade]*checkConstraintExec
+}
+
+// newFKTriggerExec builds the executor of the foreign key triggers, it returns nil if there is nothing to do,
+// or the foreign key checks are disabled by foreign_key_checks.
+func newFKTriggerExec(ctx sessionctx.Context, triggers *plannercore.FKTriggers) *fkTriggerExec {
+	if triggers.Empty() || !ctx.GetSessionVars().ForeignKeyChecks {
+		return nil
+	}
+	return &fkTriggerExec{
+		ctx:      ctx,
+		checks:   triggers.Checks,
+		cascades: triggers.Cascades,
+	}
+}
+
+// onInsertRow checks the parent rows referenced by the inserted row exist.
+func (e *fkTriggerExec) onInsertRow(ctx context.Context, row []types.Datum) error {
+	if e == nil {
+		return nil
+	}
+	for _, check := range e.checks {
+		if check.CheckExist {
+			if err := e.checkRow(ctx, check, row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// onUpdateRow checks the constraints and executes the referential actions
+// for the foreign key columns changed by the update.
+func (e *fkTriggerExec) onUpdateRow(ctx context.Context, oldRow, newRow []types.Datum) error {
+	if err := e.checkUpdateRow(ctx, oldRow, newRow); err != nil {
+		return err
+	}
+	return e.cascadeUpdateRow(ctx, oldRow, newRow)
+}
+
+// checkUpdateRow only checks the constraints for the foreign key columns changed by the update.
+func (e *fkTriggerExec) checkUpdateRow(ctx context.Context, oldRow, newRow []types.Datum) error {
+	if e == nil {
+		return nil
+	}
+	for _, check := range e.checks {
+		changed, err := fkValuesChanged(e.ctx.GetSessionVars().StmtCtx, oldRow, newRow, check.ColOffsets)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+		row := oldRow
+		if check.CheckExist {
+			row = newRow
+		}
+		if err = e.checkRow(ctx, check, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cascadeUpdateRow only executes the referential actions for the foreign key columns changed by the update.
+func (e *fkTriggerExec) cascadeUpdateRow(ctx context.Context, oldRow, newRow []types.Datum) error {
+	if e == nil {
+		return nil
+	}
+	for _, cascade := range e.cascades {
+		changed, err := fkValuesChanged(e.ctx.GetSessionVars().StmtCtx, oldRow, newRow, cascade.ColOffsets)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+		if err = e.cascadeRow(ctx, cascade, oldRow, newRow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onDeleteRow checks no child row references the deleted row, or executes the referential actions.
+func (e *fkTriggerExec) onDeleteRow(ctx context.Context, row []types.Datum) error {
+	if e == nil {
+		return nil
+	}
+	for _, check := range e.checks {
+		if err := e.checkRow(ctx, check, row); err != nil {
+			return err
+		}
+	}
+	for _, cascade := range e.cascades {
+		if err := e.cascadeRow(ctx, cascade, row, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *fkTriggerExec) checkRow(ctx context.Context, check *plannercore.FKCheck, row []types.Datum) error {
+	if hasNullValue(row, check.ColOffsets) {
+		// A NULL value never references any row.
+		return nil
+	}
+	exist := false
+	if check.Tbl != nil {
+		vals, ok := e.lookupValues(&check.FKLookup, row, check.ColOffsets)
+		if ok {
+			rows, err := e.findRows(ctx, &check.FKLookup, vals, 1, false)
+			if err != nil {
+				return err
+			}
+			exist = len(rows) > 0
+			if exist && check.CheckExist {
+				// Lock the parent row, so it can't be deleted by other transactions before this one commits.
+				if err = e.lockRows(ctx, rows); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if check.CheckExist && !exist {
+		return ErrNoReferencedRow2.GenWithStackByArgs(check.Constraint)
+	}
+	if !check.CheckExist && exist {
+		return ErrRowIsReferenced2.GenWithStackByArgs(check.Constraint)
+	}
+	return nil
+}
+
+// cascadeRow deletes or updates the child rows which reference oldRow of the parent table.
+// newRow is nil if the parent row is deleted.
+func (e *fkTriggerExec) cascadeRow(ctx context.Context, cascade *plannercore.FKCascade, oldRow, newRow []types.Datum) error {
+	if hasNullValue(oldRow, cascade.ColOffsets) {
+		return nil
+	}
+	vals, ok := e.lookupValues(&cascade.FKLookup, oldRow, cascade.ColOffsets)
+	if !ok {
+		return nil
+	}
+	// Collect all the child rows before modifying them, so the iteration isn't affected by the modification.
+	rows, err := e.findRows(ctx, &cascade.FKLookup, vals, -1, true)
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+	if e.depth >= maxForeignKeyCascadeDepth {
+		return ErrForeignKeyCascadeDepthExceeded.GenWithStackByArgs(maxForeignKeyCascadeDepth)
+	}
+	child, err := e.getChildTriggers(cascade)
+	if err != nil {
+		return err
+	}
+	tbl := cascade.Tbl
+	for _, r := range rows {
+		if cascade.Tp == plannercore.FKCascadeOnDelete && cascade.Action == ast.ReferOptionCascade {
+			if err = tbl.RemoveRecord(e.ctx, r.handle, r.data); err != nil {
+				return err
+			}
+			if err = child.onDeleteRow(ctx, r.data); err != nil {
+				return err
+			}
+			continue
+		}
+		newChildRow, err := e.updateChildRow(ctx, tbl, cascade, r, newRow)
+		if err != nil {
+			return err
+		}
+		if err = child.onUpdateRow(ctx, r.data, newChildRow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateChildRow sets the foreign key columns of the child row to the new values of the parent row,
+// or to NULL for the SET NULL action.
+func (e *fkTriggerExec) updateChildRow(ctx context.Context, tbl table.Table, cascade *plannercore.FKCascade, r fkRow, parentRow []types.Datum) ([]types.Datum, error) {
+	sc := e.ctx.GetSessionVars().StmtCtx
+	newData := make([]types.Datum, len(r.data))
+	copy(newData, r.data)
+	touched := make([]bool, len(r.data))
+	handleChanged := false
+	for i, col := range cascade.Cols {
+		var v types.Datum
+		if cascade.Action == ast.ReferOptionCascade {
+			var err error
+			v, err = table.CastValue(e.ctx, parentRow[cascade.ColOffsets[i]], col.ToInfo(), false, false)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := col.HandleBadNull(&v, sc); err != nil {
+			return nil, err
+		}
+		newData[col.Offset] = v
+		touched[col.Offset] = true
+		if col.IsPKHandleColumn(tbl.Meta()) || col.IsCommonHandleColumn(tbl.Meta()) {
+			handleChanged = true
+		}
+	}
+	checkConstraints, err := e.getChildCheckConstraints(cascade)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkConstraints.check(newData); err != nil {
+		return nil, err
+	}
+	if handleChanged {
+		if err = tbl.RemoveRecord(e.ctx, r.handle, r.data); err != nil {
+			return nil, err
+		}
+		_, err = tbl.AddRecord(e.ctx, newData, table.IsUpdate, table.WithCtx(ctx))
+	} else {
+		err = tbl.UpdateRecord(ctx, e.ctx, r.handle, r.data, newData, touched)
+	}
+	return newData, err
+}
+
+func (e *fkTriggerExec) getChildTriggers(cascade *plannercore.FKCascade) (*fkTriggerExec, error) {
+	if child, ok := e.childTriggers[cascade]; ok {
+		return child, nil
+	}
+	is := e.ctx.GetInfoSchema().(infoschema.InfoSchema)
+	triggers := &plannercore.FKTriggers{}
+	var err error
+	if cascade.Tp == plannercore.FKCascadeOnDelete && cascade.Action == ast.ReferOptionCascade {
+		triggers.Checks, triggers.Cascades, err = plannercore.BuildOnDeleteFKTriggers(e.ctx, is, cascade.DBName, cascade.Tbl)
+	} else {
+		triggers.Checks, triggers.Cascades, err = plannercore.BuildOnUpdateFKTriggers(e.ctx, is, cascade.DBName, cascade.Tbl)
+	}
+	if err != nil {
+		return nil, err
+	}
+	child := newFKTriggerExec(e.ctx, triggers)
+	if child != nil {
+		child.depth = e.depth + 1
+	}
+	if e.childTriggers == nil {
+		e.childTriggers = make(map[*plannercore.FKCascade]*fkTriggerExec)
+	}
+	e.childTriggers[cascade] = child
+	return child, nil
+}
+
+func (e *fkTriggerExec) getChildCheckConstraints(cascade *plannercore.FKCascade) (*checkConstraintExec, error) {
+	if checkConstraints, ok := e.childConstraints[cascade]; ok {
+		return checkConstraints, nil
+	}
+	checkConstraints, err := buildCheckConstraintExec(e.ctx, cascade.Tbl)
+	if err != nil {
+		return nil, err
+	}
+	if e.childConstraints == nil {
+		e.childConstraints = make(map[*plannercore.FKCascade]*checkConstraintExec)
+	}
+	e.childConstraints[cascade] = checkConstraints
+	return checkConstraints, nil
+}
+
+func (e *fkTriggerExec) lockRows(ctx context.Context, rows []fkRow) error {
+	if !e.ctx.GetSessionVars().TxnCtx.IsPessimistic {
+		return nil
+	}
+	keys := make([]kv.Key, 0, len(rows))
+	for _, r := range rows {
+		keys = append(keys, r.key)
+	}
+	return LockKeys(ctx, e.ctx, e.ctx.GetSessionVars().LockWaitTimeout, keys...)
+}
+
+// runIgnorableFKTriggers runs fn, which writes a row and fires its foreign key triggers. If the statement
+// ignores errors, e.g. INSERT IGNORE, the row failing the foreign key constraints is discarded with a warning.
+func runIgnorableFKTriggers(sctx sessionctx.Context, triggers *fkTriggerExec, fn func() error) (ignored bool, err error) {
+	sc := sctx.GetSessionVars().StmtCtx
+	if triggers == nil || !sc.DupKeyAsWarning {
+		return false, fn()
+	}
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return false, err
+	}
+	memBuffer := txn.GetMemBuffer()
+	sh := memBuffer.Staging()
+	defer memBuffer.Cleanup(sh)
+	if err = fn(); err != nil {
+		if ErrNoReferencedRow2.Equal(err) || ErrRowIsReferenced2.Equal(err) {
+			sc.AppendWarning(err)
+			return true, nil
+		}
+		return false, err
+	}
+	memBuffer.Release(sh)
+	return false, nil
+}
+
+// fkRow is a row found by the foreign key lookup.
+type fkRow struct {
+	key    kv.Key
+	handle kv.Handle
+	// data is the row of the writable columns, it's only decoded when required.
+	data []types.Datum
+}
+
+func hasNullValue(row []types.Datum, offsets []int) bool {
+	for _, offset := range offsets {
+		if row[offset].IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+func fkValuesChanged(sc *stmtctx.StatementContext, oldRow, newRow []types.Datum, offsets []int) (bool, error) {
+	for _, offset := range offsets {
+		// Compare the values in binary collation like updateRecord does, so changing 'a' to 'A' is cascaded.
+		oldVal := oldRow[offset]
+		oldVal.SetCollation(charset.CollationBin)
+		cmp, err := oldVal.CompareDatum(sc, &newRow[offset])
+		if err != nil {
+			return false, err
+		}
+		if cmp != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// lookupValues converts the values in the row to the types of the looked-up columns.
+// It returns false if any value can't be converted, then no row can match it.
+func (e *fkTriggerExec) lookupValues(lookup *plannercore.FKLookup, row []types.Datum, offsets []int) ([]types.Datum, bool) {
+	sc := &stmtctx.StatementContext{TimeZone: e.ctx.GetSessionVars().Location()}
+	vals := make([]types.Datum, 0, len(offsets))
+	for i, offset := range offsets {
+		v, err := row[offset].ConvertTo(sc, &lookup.Cols[i].FieldType)
+		if err != nil {
+			return nil, false
+		}
+		vals = append(vals, v)
+	}
+	return vals, true
+}
+
+func physicalTables(tbl table.Table) []table.PhysicalTable {
+	pt, ok := tbl.(table.PartitionedTable)
+	if !ok {
+		return []table.PhysicalTable{tbl.(table.PhysicalTable)}
+	}
+	defs := tbl.Meta().Partition.Definitions
+	tbls := make([]table.PhysicalTable, 0, len(defs))
+	for _, def := range defs {
+		tbls = append(tbls, pt.GetPartition(def.ID))
+	}
+	return tbls
+}
+
+// findRows finds at most limit rows whose looked-up columns equal to vals, limit < 0 means no limit.
+func (e *fkTriggerExec) findRows(ctx context.Context, lookup *plannercore.FKLookup, vals []types.Datum, limit int, decode bool) ([]fkRow, error) {
+	txn, err := e.ctx.Txn(true)
+	if err != nil {
+		return nil, err
+	}
+	var rows []fkRow
+	for _, tbl := range physicalTables(lookup.Tbl) {
+		pid := tbl.GetPhysicalID()
+		var handles []kv.Handle
+		switch {
+		case lookup.IsHandle && lookup.Tbl.Meta().PKIsHandle:
+			handles = []kv.Handle{kv.IntHandle(vals[0].GetInt64())}
+		case lookup.IsHandle:
+			handles, err = e.findCommonHandles(txn, pid, vals, limit-len(rows))
+		case lookup.Idx != nil:
+			handles, err = e.findIndexHandles(txn, lookup, pid, vals, limit-len(rows))
+		default:
+			rows, err = e.scanRows(ctx, txn, lookup, tbl, vals, limit, rows)
+			if err != nil {
+				return nil, err
+			}
+			if limit >= 0 && len(rows) >= limit {
+				return rows, nil
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range handles {
+			key := tablecodec.EncodeRowKeyWithHandle(pid, h)
+			value, err := txn.Get(ctx, key)
+			if kv.IsErrNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			r := fkRow{key: key, handle: h}
+			if decode {
+				r.data, _, err = tables.DecodeRawRowData(e.ctx, lookup.Tbl.Meta(), h, lookup.Tbl.WritableCols(), value)
+				if err != nil {
+					return nil, err
+				}
+			}
+			rows = append(rows, r)
+			if limit >= 0 && len(rows) >= limit {
+				return rows, nil
+			}
+		}
+	}
+	return rows, nil
+}
+
+func (e *fkTriggerExec) findCommonHandles(txn kv.Transaction, pid int64, vals []types.Datum, limit int) ([]kv.Handle, error) {
+	prefix := tablecodec.GenTableRecordPrefix(pid)
+	prefix, err := codec.EncodeKey(e.ctx.GetSessionVars().StmtCtx, prefix, vals...)
+	if err != nil {
+		return nil, err
+	}
+	return iterHandles(txn, prefix, limit, func(key, _ []byte) (kv.Handle, error) {
+		return tablecodec.DecodeRowKey(key)
+	})
+}
+
+func (e *fkTriggerExec) findIndexHandles(txn kv.Transaction, lookup *plannercore.FKLookup, pid int64, vals []types.Datum, limit int) ([]kv.Handle, error) {
+	// GenIndexKey may truncate the values, so pass a copy.
+	idxVals := make([]types.Datum, len(vals))
+	copy(idxVals, vals)
+	prefix, _, err := tablecodec.GenIndexKey(e.ctx.GetSessionVars().StmtCtx, lookup.Tbl.Meta(), lookup.Idx, pid, idxVals, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	colsLen := len(lookup.Idx.Columns)
+	return iterHandles(txn, prefix, limit, func(key, value []byte) (kv.Handle, error) {
+		return tablecodec.DecodeIndexHandle(key, value, colsLen)
+	})
+}
+
+func iterHandles(txn kv.Transaction, prefix kv.Key, limit int, decode func(key, value []byte) (kv.Handle, error)) ([]kv.Handle, error) {
+	iter, err := txn.Iter(prefix, prefix.PrefixNext())
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var handles []kv.Handle
+	for ; iter.Valid() && (limit < 0 || len(handles) < limit); err = iter.Next() {
+		if err != nil {
+			return nil, err
+		}
+		h, err := decode(iter.Key(), iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		handles = append(handles, h)
+	}
+	return handles, errors.Trace(err)
+}
+
+// scanRows scans the whole physical table to find the rows, it's used when there is no index on the looked-up columns.
+func (e *fkTriggerExec) scanRows(ctx context.Context, txn kv.Transaction, lookup *plannercore.FKLookup, tbl table.PhysicalTable,
+	vals []types.Datum, limit int, rows []fkRow) ([]fkRow, error) {
+	sc := e.ctx.GetSessionVars().StmtCtx
+	tblInfo := lookup.Tbl.Meta()
+	cols := lookup.Tbl.WritableCols()
+	prefix := tablecodec.GenTableRecordPrefix(tbl.GetPhysicalID())
+	iter, err := txn.Iter(prefix, prefix.PrefixNext())
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	for ; iter.Valid() && (limit < 0 || len(rows) < limit); err = iter.Next() {
+		if err != nil {
+			return nil, err
+		}
+		h, err := tablecodec.DecodeRowKey(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		data, _, err := tables.DecodeRawRowData(e.ctx, tblInfo, h, cols, iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		match := true
+		for i, col := range lookup.Cols {
+			cmp, err := data[col.Offset].CompareDatum(sc, &vals[i])
+			if err != nil {
+				return nil, err
+			}
+			if cmp != 0 {
+				match = false
+				break
+			}
+		}
+		if match {
+			rows = append(rows, fkRow{key: iter.Key().Clone(), handle: h, data: data})
+		}
+	}
+	return rows, errors.Trace(err)
+}
@@ -0,0 +1,168 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/util/testkit"
+)
+
+func (s *testSuite8) TestForeignKeyCheck(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists fk_child, fk_parent")
+	tk.MustExec("create table fk_parent (id int primary key, name varchar(10), unique key(name))")
+	tk.MustExec("create table fk_child (id int primary key, pid int, pname varchar(10), key(pid), " +
+		"constraint fk_1 foreign key (pid) references fk_parent(id), " +
+		"constraint fk_2 foreign key (pname) references fk_parent(name))")
+	tk.MustExec("insert into fk_parent values (1, 'a'), (2, 'b')")
+
+	// The constraints are not enforced when foreign_key_checks is off.
+	tk.MustExec("set @@foreign_key_checks = 0")
+	tk.MustExec("insert into fk_child values (100, 10, 'z')")
+	tk.MustExec("delete from fk_child where id = 100")
+
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("insert into fk_child values (1, 1, 'a'), (2, null, 'b'), (3, null, null)")
+	tk.MustGetErrCode("insert into fk_child values (4, 3, null)", errno.ErrNoReferencedRow2)
+	tk.MustGetErrCode("insert into fk_child values (4, null, 'c')", errno.ErrNoReferencedRow2)
+	err := tk.ExecToErr("insert into fk_child values (4, 3, 'a')")
+	c.Assert(err.Error(), Equals, "[executor:1452]Cannot add or update a child row: a foreign key constraint fails "+
+		"(`test`.`fk_child`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `fk_parent` (`id`))")
+	tk.MustExec("insert ignore into fk_child values (4, 3, 'a'), (5, 2, 'a')")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1452 Cannot add or update a child row: a foreign key constraint fails " +
+		"(`test`.`fk_child`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `fk_parent` (`id`))"))
+	tk.MustQuery("select * from fk_child order by id").Check(testkit.Rows("1 1 a", "2 <nil> b", "3 <nil> <nil>", "5 2 a"))
+
+	tk.MustGetErrCode("update fk_child set pid = 3 where id = 1", errno.ErrNoReferencedRow2)
+	tk.MustExec("update fk_child set pid = 2 where id = 1")
+	tk.MustExec("update fk_child set pid = pid where id = 1")
+
+	// The referenced parent rows can't be deleted or updated.
+	tk.MustGetErrCode("delete from fk_parent where id = 2", errno.ErrRowIsReferenced2)
+	tk.MustGetErrCode("update fk_parent set name = 'c' where id = 2", errno.ErrRowIsReferenced2)
+	tk.MustGetErrCode("replace into fk_parent values (2, 'c')", errno.ErrRowIsReferenced2)
+	tk.MustExec("update fk_parent set id = 3 where id = 1")
+	tk.MustExec("delete from fk_child where id in (1, 2, 5)")
+	tk.MustExec("delete from fk_parent where id = 2")
+	tk.MustQuery("select * from fk_parent").Check(testkit.Rows("3 a"))
+
+	// The checks see the rows written in the same transaction.
+	tk.MustExec("begin")
+	tk.MustExec("insert into fk_parent values (4, 'd')")
+	tk.MustExec("insert into fk_child values (6, 4, 'd')")
+	tk.MustExec("commit")
+	tk.MustQuery("select * from fk_child where id = 6").Check(testkit.Rows("6 4 d"))
+}
+
+func (s *testSuite8) TestForeignKeyUpdateIgnore(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("drop table if exists fk_child, fk_parent")
+	tk.MustExec("create table fk_parent (id int primary key)")
+	tk.MustExec("create table fk_child (id int primary key, pid int, v int, unique key(v), " +
+		"constraint fk_1 foreign key (pid) references fk_parent(id))")
+	tk.MustExec("insert into fk_parent values (1), (2)")
+	tk.MustExec("insert into fk_child values (1, 1, 1), (2, 1, 2), (3, 2, 3)")
+
+	// The rows failing the constraint are skipped with a warning, the others are updated.
+	tk.MustExec("update ignore fk_child set pid = pid + 1")
+	c.Assert(tk.Se.AffectedRows(), Equals, uint64(2))
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1452 Cannot add or update a child row: a foreign key constraint fails " +
+		"(`test`.`fk_child`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `fk_parent` (`id`))"))
+	tk.MustQuery("select * from fk_child order by id").Check(testkit.Rows("1 2 1", "2 2 2", "3 2 3"))
+	tk.MustQuery("select * from fk_child use index(v) where v > 0 order by v").Check(testkit.Rows("1 2 1", "2 2 2", "3 2 3"))
+
+	// The skipped row is never written, its index entries are kept too.
+	tk.MustExec("update ignore fk_child set pid = 3, v = v + 10 where id = 3")
+	c.Assert(tk.Se.AffectedRows(), Equals, uint64(0))
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1452 Cannot add or update a child row: a foreign key constraint fails " +
+		"(`test`.`fk_child`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `fk_parent` (`id`))"))
+	tk.MustQuery("select * from fk_child use index(v) where v = 3").Check(testkit.Rows("3 2 3"))
+	tk.MustQuery("select * from fk_child use index(v) where v = 13").Check(testkit.Rows())
+	tk.MustExec("admin check table fk_child")
+
+	// The referenced parent rows are skipped too.
+	tk.MustExec("update ignore fk_parent set id = id + 10")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1451 Cannot delete or update a parent row: a foreign key constraint fails " +
+		"(`test`.`fk_child`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `fk_parent` (`id`))"))
+	tk.MustQuery("select * from fk_parent order by id").Check(testkit.Rows("2", "11"))
+}
+
+func (s *testSuite8) TestForeignKeyCascade(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("drop table if exists fk_t3, fk_t2, fk_t1")
+	tk.MustExec("create table fk_t1 (id int primary key, a int, unique key(a))")
+	tk.MustExec("create table fk_t2 (id int primary key, a int, foreign key (a) references fk_t1(a) on delete cascade on update cascade)")
+	tk.MustExec("create table fk_t3 (id int primary key, a int, foreign key (a) references fk_t2(id) on delete set null on update cascade)")
+	tk.MustExec("insert into fk_t1 values (1, 10), (2, 20)")
+	tk.MustExec("insert into fk_t2 values (1, 10), (2, 10), (3, 20)")
+	tk.MustExec("insert into fk_t3 values (1, 1), (2, 2), (3, 3)")
+
+	tk.MustExec("update fk_t1 set a = 11 where id = 1")
+	// The rows modified by the referential actions are not counted.
+	c.Assert(tk.Se.AffectedRows(), Equals, uint64(1))
+	tk.MustQuery("select * from fk_t2 order by id").Check(testkit.Rows("1 11", "2 11", "3 20"))
+
+	tk.MustExec("update fk_t2 set id = 4 where id = 3")
+	tk.MustQuery("select * from fk_t3 order by id").Check(testkit.Rows("1 1", "2 2", "3 4"))
+
+	tk.MustExec("delete from fk_t1 where id = 1")
+	tk.MustQuery("select * from fk_t2 order by id").Check(testkit.Rows("4 20"))
+	tk.MustQuery("select * from fk_t3 order by id").Check(testkit.Rows("1 <nil>", "2 <nil>", "3 4"))
+
+	// Self-referencing table.
+	tk.MustExec("drop table if exists fk_tree")
+	tk.MustExec("create table fk_tree (id int primary key, pid int, foreign key (pid) references fk_tree(id) on delete cascade)")
+	tk.MustExec("insert into fk_tree values (1, null), (2, 1), (3, 2), (4, 1), (5, null)")
+	tk.MustExec("delete from fk_tree where id = 1")
+	tk.MustQuery("select * from fk_tree").Check(testkit.Rows("5 <nil>"))
+	tk.MustExec("insert into fk_tree values (11, null)")
+	for i := 12; i <= 30; i++ {
+		tk.MustExec("insert into fk_tree values (?, ?)", i, i-1)
+	}
+	tk.MustGetErrCode("delete from fk_tree where id = 11", errno.ErrForeignKeyCascadeDepthExceeded)
+	tk.MustExec("delete from fk_tree where id = 20")
+	tk.MustQuery("select count(*) from fk_tree").Check(testkit.Rows("10"))
+}
+
+func (s *testSuite8) TestForeignKeyExplain(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists fk_child, fk_parent")
+	tk.MustExec("create table fk_parent (id int primary key, a int, key(a))")
+	tk.MustExec("create table fk_child (id int primary key, pid int, foreign key fk_1 (pid) references fk_parent(id) on delete cascade)")
+
+	tk.MustExec("set @@foreign_key_checks = 0")
+	tk.MustQuery("explain format = 'brief' insert into fk_child values (1, 1)").Check(testkit.Rows(
+		"Insert N/A root  N/A"))
+
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustQuery("explain format = 'brief' insert into fk_child values (1, 1)").Check(testkit.Rows(
+		"Insert N/A root  N/A",
+		"└─Foreign_Key_Check N/A root table:fk_parent, handle:id foreign_key:fk_1, check_exist"))
+	tk.MustQuery("explain format = 'brief' delete from fk_parent where id = 1").Check(testkit.Rows(
+		"Delete N/A root  N/A",
+		"├─Point_Get 1.00 root table:fk_parent handle:1",
+		"└─Foreign_Key_Cascade N/A root table:fk_child foreign_key:fk_1, on_delete:CASCADE"))
+	tk.MustQuery("explain format = 'brief' update fk_parent set a = 1").Check(testkit.Rows(
+		"Update N/A root  N/A",
+		"└─TableReader 10000.00 root  data:TableFullScan",
+		"  └─TableFullScan 10000.00 cop[tikv] table:fk_parent keep order:false, stats:pseudo"))
+}
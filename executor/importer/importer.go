@@ -0,0 +1,467 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package importer enables LOAD DATA from the external storage, it's imported by the binaries loading the files
+// in the external storage.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/docker/go-units"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	lightningcfg "github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/br/pkg/lightning/worker"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+func init() {
+	executor.NewExternalDataLoader = newExternalDataLoader
+}
+
+const (
+	loadDataFormatCSV     = "csv"
+	loadDataFormatParquet = "parquet"
+
+	// The query parameters of the URL which are the options of LOAD DATA, the others are passed to the storage.
+	loadDataParamFormat        = "format"
+	loadDataParamThreads       = "threads"
+	loadDataParamStrictFormat  = "strict-format"
+	loadDataParamMaxRegionSize = "max-region-size"
+)
+
+// loadDataOptions are the options of loading the files from the external storage.
+type loadDataOptions struct {
+	// format is the format of all the files, the format of each file is inferred from its extension if it's empty.
+	format string
+	// threads is the number of the goroutines which parse the files concurrently.
+	threads int
+	// strictFormat means no field contains the line terminator, so a large csv file can be split into regions
+	// at the line terminators and parsed concurrently.
+	strictFormat  bool
+	maxRegionSize int64
+}
+
+// parseLoadDataURL parses the URL of LOAD DATA INFILE, e.g.
+// `s3://bucket/prefix/*.csv?threads=8&strict-format=true&region=us-west-2`.
+// It returns the storage backend of the directory, the pattern of the file names and the options of loading.
+func parseLoadDataURL(rawURL string) (_ string, _ string, opts loadDataOptions, err error) {
+	u, err := storage.ParseRawURL(rawURL)
+	if err != nil {
+		return "", "", opts, err
+	}
+	query := u.Query()
+	opts.format = strings.ToLower(query.Get(loadDataParamFormat))
+	switch opts.format {
+	case "", loadDataFormatCSV, loadDataFormatParquet:
+	default:
+		return "", "", opts, errors.Errorf("unsupported format '%s' of LOAD DATA", opts.format)
+	}
+	if threads := query.Get(loadDataParamThreads); threads != "" {
+		if opts.threads, err = strconv.Atoi(threads); err != nil || opts.threads <= 0 {
+			return "", "", opts, errors.Errorf("invalid %s '%s' of LOAD DATA", loadDataParamThreads, threads)
+		}
+	}
+	if strict := query.Get(loadDataParamStrictFormat); strict != "" {
+		if opts.strictFormat, err = strconv.ParseBool(strict); err != nil {
+			return "", "", opts, errors.Errorf("invalid %s '%s' of LOAD DATA", loadDataParamStrictFormat, strict)
+		}
+	}
+	opts.maxRegionSize = int64(lightningcfg.MaxRegionSize)
+	if size := query.Get(loadDataParamMaxRegionSize); size != "" {
+		if opts.maxRegionSize, err = units.RAMInBytes(size); err != nil || opts.maxRegionSize <= 0 {
+			return "", "", opts, errors.Errorf("invalid %s '%s' of LOAD DATA", loadDataParamMaxRegionSize, size)
+		}
+	}
+	for _, param := range []string{loadDataParamFormat, loadDataParamThreads, loadDataParamStrictFormat, loadDataParamMaxRegionSize} {
+		query.Del(param)
+	}
+
+	dir, pattern := path.Split(u.Path)
+	if pattern == "" {
+		return "", "", opts, errors.Errorf("the file name is missing in the path '%s' of LOAD DATA", u.Path)
+	}
+	if _, err = path.Match(pattern, ""); err != nil {
+		return "", "", opts, errors.Errorf("invalid file name pattern '%s' of LOAD DATA", pattern)
+	}
+	u.Path = dir
+	u.RawQuery = query.Encode()
+	return u.String(), pattern, opts, nil
+}
+
+// externalDataFile is a file matched by the pattern of LOAD DATA.
+type externalDataFile struct {
+	path        string
+	size        int64
+	format      string
+	compression storage.CompressType
+	// regions is the number of the regions of the file which are not parsed yet.
+	regions int32
+	// rows and readBytes are the number of rows and the size of the data parsed from the file. The size is only
+	// meaningful for the uncompressed csv files.
+	rows      int64
+	readBytes int64
+}
+
+// externalDataRegion is a part of the file which is parsed by one goroutine.
+type externalDataRegion struct {
+	file   *externalDataFile
+	offset int64
+	// endOffset is the offset where the region ends, 0 means the region ends at the end of the file.
+	endOffset int64
+	// ignoreLines is the number of lines skipped at the start of the region, only the first region of a file
+	// skips the lines of IGNORE n LINES.
+	ignoreLines uint64
+}
+
+// externalDataLoader lists, splits and parses the files of LOAD DATA in the external storage.
+type externalDataLoader struct {
+	store storage.ExternalStorage
+	// url is the redacted URL of the directory, it's used in the error messages.
+	url       string
+	pattern   string
+	opts      loadDataOptions
+	csvConfig *lightningcfg.CSVConfig
+	ioWorkers *worker.Pool
+	// batchRows is the number of rows sent from the parsing goroutines at a time.
+	batchRows int
+
+	files   []*externalDataFile
+	regions []*externalDataRegion
+}
+
+// csvConfig converts the FIELDS and LINES clauses to the config of the csv parser.
+func csvConfig(fields *ast.FieldsClause, lines *ast.LinesClause) (*lightningcfg.CSVConfig, error) {
+	if lines.Starting != "" {
+		return nil, errors.New("Load Data: don't support lines starting by when loading from the external storage")
+	}
+	if fields.Terminated == "" {
+		return nil, errors.New("Load Data: don't support fields terminated is nil when loading from the external storage")
+	}
+	cfg := &lightningcfg.CSVConfig{
+		Separator:  fields.Terminated,
+		Terminator: lines.Terminated,
+	}
+	if fields.Enclosed != 0 {
+		cfg.Delimiter = string([]byte{fields.Enclosed})
+	}
+	switch fields.Escaped {
+	case '\\':
+		// The field with only "\N" in it is handled as NULL, the same as the LOCAL files.
+		cfg.BackslashEscape = true
+		cfg.Null = `\N`
+	case 0:
+		// See https://dev.mysql.com/doc/refman/5.7/en/load-data.html, NULL is written as the word NULL if the
+		// escape character is empty.
+		cfg.Null = "NULL"
+	default:
+		return nil, errors.Errorf("Load Data: don't support fields escaped by '%c' when loading from the external storage",
+			fields.Escaped)
+	}
+	return cfg, nil
+}
+
+func newExternalDataLoader(ctx context.Context, info *executor.ExternalLoadDataInfo) (executor.ExternalDataLoader, error) {
+	storeURL, pattern, opts, err := parseLoadDataURL(info.Path)
+	if err != nil {
+		return nil, err
+	}
+	csvConfig, err := csvConfig(info.FieldsInfo, info.LinesInfo)
+	if err != nil {
+		return nil, err
+	}
+	backend, err := storage.ParseBackend(storeURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = executor.CheckExternalStorageCredentials(backend, info.AllowServerCredentials); err != nil {
+		return nil, err
+	}
+	store, err := storage.New(ctx, backend, &storage.ExternalStorageOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if opts.threads == 0 {
+		opts.threads = info.Threads
+	}
+	redactedURL := storage.FormatBackendURL(backend)
+	l := &externalDataLoader{
+		store:     store,
+		url:       redactedURL.String(),
+		pattern:   pattern,
+		opts:      opts,
+		csvConfig: csvConfig,
+		ioWorkers: worker.NewPool(ctx, opts.threads, "load-data"),
+		batchRows: info.BatchRows,
+	}
+	if err = l.listFiles(ctx); err != nil {
+		return nil, err
+	}
+	if err = l.makeRegions(ctx, info.IgnoreLines, info.Columns); err != nil {
+		return nil, err
+	}
+	logutil.Logger(ctx).Info("load data from the external storage", zap.String("url", l.url),
+		zap.String("pattern", l.pattern), zap.Int("files", len(l.files)),
+		zap.Int("regions", len(l.regions)), zap.Int("threads", l.opts.threads))
+	return l, nil
+}
+
+// listFiles lists the files matched by the pattern in the directory, the files in the sub-directories are not
+// matched since the wildcards don't match the separator '/'.
+func (l *externalDataLoader) listFiles(ctx context.Context) error {
+	err := l.store.WalkDir(ctx, &storage.WalkOption{}, func(name string, size int64) error {
+		name = strings.TrimPrefix(name, "/")
+		if ok, _ := path.Match(l.pattern, name); !ok {
+			return nil
+		}
+		file := &externalDataFile{
+			path:   name,
+			size:   size,
+			format: l.opts.format,
+		}
+		lowerName := strings.ToLower(name)
+		if file.format == "" {
+			file.format = loadDataFormatCSV
+			if strings.HasSuffix(lowerName, ".parquet") {
+				file.format = loadDataFormatParquet
+			}
+		}
+		if file.format == loadDataFormatCSV {
+			switch {
+			case strings.HasSuffix(lowerName, ".gz"), strings.HasSuffix(lowerName, ".gzip"):
+				file.compression = storage.Gzip
+			case strings.HasSuffix(lowerName, ".zst"), strings.HasSuffix(lowerName, ".zstd"):
+				file.compression = storage.Zstd
+			}
+		}
+		l.files = append(l.files, file)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(l.files) == 0 {
+		return errors.Errorf("Load Data: no file matches '%s' in %s", l.pattern, l.url)
+	}
+	sort.Slice(l.files, func(i, j int) bool {
+		return l.files[i].path < l.files[j].path
+	})
+	return nil
+}
+
+// makeRegions splits the files into regions. Only the uncompressed csv files in the strict format are split,
+// the others are parsed as a whole.
+func (l *externalDataLoader) makeRegions(ctx context.Context, ignoreLines uint64, columns int) error {
+	cfg := lightningcfg.NewConfig()
+	cfg.Mydumper.CSV = *l.csvConfig
+	cfg.Mydumper.MaxRegionSize = lightningcfg.ByteSize(l.opts.maxRegionSize)
+	cfg.Mydumper.ReadBlockSize = lightningcfg.ReadBlockSize
+	meta := &mydump.MDTableMeta{}
+	for _, file := range l.files {
+		if !l.opts.strictFormat || file.format != loadDataFormatCSV || file.compression != storage.NoCompression ||
+			file.size <= l.opts.maxRegionSize {
+			file.regions = 1
+			l.regions = append(l.regions, &externalDataRegion{file: file, ignoreLines: ignoreLines})
+			continue
+		}
+		fileInfo := mydump.FileInfo{FileMeta: mydump.SourceFileMeta{
+			Path:     file.path,
+			Type:     mydump.SourceTypeCSV,
+			FileSize: file.size,
+		}}
+		_, regions, _, err := mydump.SplitLargeFile(ctx, meta, cfg, fileInfo, int64(columns), 0, l.ioWorkers, l.store)
+		if err != nil {
+			return err
+		}
+		file.regions = int32(len(regions))
+		for i, region := range regions {
+			r := &externalDataRegion{file: file, offset: region.Chunk.Offset, endOffset: region.Chunk.EndOffset}
+			if i == 0 {
+				r.ignoreLines = ignoreLines
+			}
+			l.regions = append(l.regions, r)
+		}
+	}
+	return nil
+}
+
+func (l *externalDataLoader) openParser(ctx context.Context, region *externalDataRegion) (mydump.Parser, error) {
+	file := region.file
+	if file.format == loadDataFormatParquet {
+		r, err := mydump.OpenParquetReader(ctx, l.store, file.path, file.size)
+		if err != nil {
+			return nil, err
+		}
+		return mydump.NewParquetParser(ctx, l.store, r, file.path)
+	}
+	r, err := storage.WithCompression(l.store, file.compression).Open(ctx, file.path)
+	if err != nil {
+		return nil, err
+	}
+	charsetConvertor, err := mydump.NewCharsetConvertor("binary", "")
+	if err != nil {
+		return nil, err
+	}
+	parser, err := mydump.NewCSVParser(l.csvConfig, r, int64(lightningcfg.ReadBlockSize), l.ioWorkers, false, charsetConvertor)
+	if err != nil {
+		return nil, err
+	}
+	if region.offset > 0 {
+		if err = parser.SetPos(region.offset, 0); err != nil {
+			parser.Close()
+			return nil, err
+		}
+	}
+	return parser, nil
+}
+
+// parseRegion parses the rows of the region and sends them in batches.
+func (l *externalDataLoader) parseRegion(ctx context.Context, region *externalDataRegion, rowsCh chan<- [][]types.Datum) error {
+	parser, err := l.openParser(ctx, region)
+	if err != nil {
+		return err
+	}
+	defer parser.Close()
+
+	file := region.file
+	lastPos := region.offset
+	send := func(rows [][]types.Datum) error {
+		select {
+		case rowsCh <- rows:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		atomic.AddInt64(&file.rows, int64(len(rows)))
+		if pos, _ := parser.Pos(); file.format == loadDataFormatCSV {
+			atomic.AddInt64(&file.readBytes, pos-lastPos)
+			lastPos = pos
+		}
+		return nil
+	}
+	ignoreLines := region.ignoreLines
+	rows := make([][]types.Datum, 0, l.batchRows)
+	for {
+		if region.endOffset > 0 {
+			if pos, _ := parser.Pos(); pos >= region.endOffset {
+				break
+			}
+		}
+		err = parser.ReadRow()
+		if errors.Cause(err) == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Annotatef(err, "failed to parse file %s in %s", file.path, l.url)
+		}
+		lastRow := parser.LastRow()
+		if ignoreLines > 0 {
+			ignoreLines--
+			parser.RecycleRow(lastRow)
+			continue
+		}
+		// The datums are copied since the parsers may reuse them, e.g. the parquet parser.
+		rows = append(rows, append(make([]types.Datum, 0, len(lastRow.Row)), lastRow.Row...))
+		parser.RecycleRow(lastRow)
+		if len(rows) >= l.batchRows {
+			if err = send(rows); err != nil {
+				return err
+			}
+			rows = make([][]types.Datum, 0, l.batchRows)
+		}
+	}
+	if err = send(rows); err != nil {
+		return err
+	}
+	atomic.AddInt32(&file.regions, -1)
+	return nil
+}
+
+// Parse implements the executor.ExternalDataLoader interface, the regions are parsed concurrently.
+func (l *externalDataLoader) Parse(ctx context.Context, rowsCh chan<- [][]types.Datum) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	regionCh := make(chan *externalDataRegion, len(l.regions))
+	for _, region := range l.regions {
+		regionCh <- region
+	}
+	close(regionCh)
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, l.opts.threads)
+	for i := 0; i < l.opts.threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for region := range regionCh {
+				if err := l.parseRegion(ctx, region, rowsCh); err != nil {
+					errCh <- err
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(rowsCh)
+	close(errCh)
+	return <-errCh
+}
+
+// Progress implements the executor.ExternalDataLoader interface, e.g.
+// `loading data: 1/3 files finished, a.csv 1.5MiB/4MiB, b.parquet 1024 rows`.
+func (l *externalDataLoader) Progress() string {
+	var finished int
+	var loading []string
+	for _, file := range l.files {
+		if atomic.LoadInt32(&file.regions) == 0 {
+			finished++
+			continue
+		}
+		rows := atomic.LoadInt64(&file.rows)
+		if rows == 0 {
+			continue
+		}
+		if file.format == loadDataFormatCSV && file.compression == storage.NoCompression {
+			readBytes := atomic.LoadInt64(&file.readBytes)
+			loading = append(loading, fmt.Sprintf("%s %s/%s", file.path, units.BytesSize(float64(readBytes)),
+				units.BytesSize(float64(file.size))))
+		} else {
+			loading = append(loading, fmt.Sprintf("%s %d rows", file.path, rows))
+		}
+	}
+	progress := fmt.Sprintf("loading data: %d/%d files finished", finished, len(l.files))
+	if len(loading) > 0 {
+		progress += ", " + strings.Join(loading, ", ")
+	}
+	return progress
+}
+
+// Threads implements the executor.ExternalDataLoader interface.
+func (l *externalDataLoader) Threads() int {
+	return l.opts.threads
+}
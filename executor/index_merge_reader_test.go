@@ -170,3 +170,52 @@ func (s *testSuite1) TestPartitionTableRandomIndexMerge(c *C) {
 		tk.MustQuery("select /*+ USE_INDEX_MERGE(tpk, a, b) */ * from tpk where " + cond).Sort().Check(result)
 	}
 }
+
+func (s *testSuite1) TestIndexMergeMultiValuedIndex(c *C) {
+	tk := testkit.NewTestKitWithInit(c, s.store)
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int primary key, j json, index idx((cast(j->'$' as unsigned array))))")
+	tk.MustExec("insert into t values(1, '[1, 2, 3]'), (2, '[3, 4]'), (3, '[]'), (4, null), (5, '5')")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("explain format='brief' select * from t where 3 member of (j->'$')").Check(testkit.Rows(
+		"Selection 8000.00 root  json_memberof(cast(3, json BINARY), json_extract(test.t.j, \"$\"))",
+		"└─IndexMerge 10.00 root  ",
+		"  ├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:idx(cast(json_extract(`j`, _utf8mb4'$') as unsigned array)) range:[3,3], keep order:false, stats:pseudo",
+		"  └─TableRowIDScan(Probe) 10.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("explain format='brief' select * from t where json_overlaps(j->'$', '[4, 5]')").Check(testkit.Rows(
+		"Selection 8000.00 root  json_overlaps(json_extract(test.t.j, \"$\"), cast(\"[4, 5]\", json BINARY))",
+		"└─IndexMerge 20.00 root  ",
+		"  ├─IndexRangeScan(Build) 20.00 cop[tikv] table:t, index:idx(cast(json_extract(`j`, _utf8mb4'$') as unsigned array)) range:[4,4], [5,5], keep order:false, stats:pseudo",
+		"  └─TableRowIDScan(Probe) 20.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("explain format='brief' select * from t where json_contains(j->'$', '[2, 3]')").Check(testkit.Rows(
+		"Selection 8000.00 root  json_contains(json_extract(test.t.j, \"$\"), cast(\"[2, 3]\", json BINARY))",
+		"└─IndexMerge 10.00 root  ",
+		"  ├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:idx(cast(json_extract(`j`, _utf8mb4'$') as unsigned array)) range:[2,2], keep order:false, stats:pseudo",
+		"  └─TableRowIDScan(Probe) 10.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("select a from t where 3 member of (j->'$') order by a").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select a from t where json_contains(j->'$', '[2, 3]') order by a").Check(testkit.Rows("1"))
+	tk.MustQuery("select a from t where json_overlaps(j->'$', '[4, 5]') order by a").Check(testkit.Rows("2", "5"))
+	tk.MustQuery("select a from t where json_overlaps(j->'$', '[1, 3]') order by a").Check(testkit.Rows("1", "2"))
+
+	tk.MustExec("update t set j = '[6, 7]' where a = 1")
+	tk.MustExec("delete from t where a = 2")
+	tk.MustExec("insert into t values(6, '[3, 3, 6]')")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select a from t where 3 member of (j->'$') order by a").Check(testkit.Rows("6"))
+	tk.MustQuery("select a from t where 6 member of (j->'$') order by a").Check(testkit.Rows("1", "6"))
+	tk.MustQuery("select a from t where json_overlaps('[4, 7]', j->'$') order by a").Check(testkit.Rows("1"))
+
+	err := tk.ExecToErr("insert into t values(7, '[1, -1]')")
+	c.Assert(err.Error(), Equals, "[table:3903]Invalid JSON value for CAST for expression index 'idx'")
+	err = tk.ExecToErr("insert into t values(7, '[\"a\"]')")
+	c.Assert(err.Error(), Equals, "[table:3903]Invalid JSON value for CAST for expression index 'idx'")
+	err = tk.ExecToErr("create unique index uidx on t((cast(j->'$' as unsigned array)))")
+	c.Assert(err.Error(), Equals, "[ddl:8200]Unsupported unique multi-valued index")
+	err = tk.ExecToErr("create index idx2 on t((cast(j->'$.a' as unsigned array)), (cast(j->'$.b' as unsigned array)))")
+	c.Assert(err.Error(), Equals, "[ddl:8200]Unsupported more than one multi-valued key part per index")
+	err = tk.ExecToErr("select cast(j as unsigned array) from t")
+	c.Assert(err.Error(), Equals, "[planner:1235]This version of TiDB doesn't yet support 'Use of CAST( .. AS .. ARRAY) outside of functional index in CREATE(non-SELECT)/ALTER TABLE or in general expressions'")
+
+	tk.MustExec("analyze table t")
+	tk.MustQuery("select /*+ use_index_merge(t, idx) */ a from t where 6 member of (j->'$') and a > 1").Check(testkit.Rows("6"))
+}
@@ -45,15 +45,18 @@ import (
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/meta/autoid"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/resourcegroup"
 	"github.com/pingcap/tidb/session/txninfo"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/store/helper"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/types"
 	binaryJson "github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util"
@@ -69,6 +72,7 @@ import (
 	"github.com/pingcap/tidb/util/sqlexec"
 	"github.com/pingcap/tidb/util/stmtsummary"
 	"github.com/pingcap/tidb/util/stringutil"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.etcd.io/etcd/clientv3"
 	"go.uber.org/zap"
 )
@@ -147,6 +151,14 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataForTiDBHotRegions(sctx)
 		case infoschema.TableConstraints:
 			e.setDataFromTableConstraints(sctx, dbs)
+		case infoschema.TableCheckConstraints:
+			e.setDataFromCheckConstraints(sctx, dbs)
+		case infoschema.TableTiDBMDLView:
+			e.setDataForMDLView(sctx, is)
+		case infoschema.TableRunawayWatches:
+			e.setDataForRunawayWatches(sctx)
+		case infoschema.TableResourceGroups:
+			err = e.setDataForResourceGroups(sctx)
 		case infoschema.TableSessionVar:
 			err = e.setDataFromSessionVar(sctx)
 		case infoschema.TableTiDBServersInfo:
@@ -845,9 +857,18 @@ func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sess
 						}
 					}
 
-					partitionMethod := table.Partition.Type.String()
+					partitionMethod := tables.PartitionTypeString(table.Partition)
 					partitionExpr := table.Partition.Expr
-					if table.Partition.Type == model.PartitionTypeRange && len(table.Partition.Columns) > 0 {
+					if table.Partition.Type == model.PartitionTypeKey {
+						buf := bytes.NewBuffer(nil)
+						for i, col := range table.Partition.Columns {
+							if i > 0 {
+								buf.WriteString(",")
+							}
+							buf.WriteString(col.String())
+						}
+						partitionExpr = buf.String()
+					} else if table.Partition.Type == model.PartitionTypeRange && len(table.Partition.Columns) > 0 {
 						partitionMethod = "RANGE COLUMNS"
 						partitionExpr = table.Partition.Columns[0].String()
 					} else if table.Partition.Type == model.PartitionTypeList && len(table.Partition.Columns) > 0 {
@@ -1587,6 +1608,7 @@ func (e *memtableRetriever) setDataFromTableConstraints(ctx sessionctx.Context,
 					schema.Name.O,             // TABLE_SCHEMA
 					tbl.Name.O,                // TABLE_NAME
 					infoschema.PrimaryKeyType, // CONSTRAINT_TYPE
+					"YES",                     // ENFORCED
 				)
 				rows = append(rows, record)
 			}
@@ -1610,6 +1632,27 @@ func (e *memtableRetriever) setDataFromTableConstraints(ctx sessionctx.Context,
 					schema.Name.O,         // TABLE_SCHEMA
 					tbl.Name.O,            // TABLE_NAME
 					ctype,                 // CONSTRAINT_TYPE
+					"YES",                 // ENFORCED
+				)
+				rows = append(rows, record)
+			}
+
+			for _, constr := range tbl.Constraints {
+				if constr.State != model.StatePublic {
+					continue
+				}
+				enforced := "NO"
+				if constr.Enforced {
+					enforced = "YES"
+				}
+				record := types.MakeDatums(
+					infoschema.CatalogVal,          // CONSTRAINT_CATALOG
+					schema.Name.O,                  // CONSTRAINT_SCHEMA
+					constr.Name.O,                  // CONSTRAINT_NAME
+					schema.Name.O,                  // TABLE_SCHEMA
+					tbl.Name.O,                     // TABLE_NAME
+					infoschema.CheckConstraintType, // CONSTRAINT_TYPE
+					enforced,                       // ENFORCED
 				)
 				rows = append(rows, record)
 			}
@@ -1618,6 +1661,136 @@ func (e *memtableRetriever) setDataFromTableConstraints(ctx sessionctx.Context,
 	e.rows = rows
 }
 
+// setDataFromCheckConstraints constructs data for table information_schema.check_constraints.
+// See https://dev.mysql.com/doc/refman/8.0/en/information-schema-check-constraints-table.html
+func (e *memtableRetriever) setDataFromCheckConstraints(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, tbl := range schema.Tables {
+			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, tbl.Name.L, "", mysql.AllPrivMask) {
+				continue
+			}
+			for _, constr := range tbl.Constraints {
+				if constr.State != model.StatePublic {
+					continue
+				}
+				record := types.MakeDatums(
+					infoschema.CatalogVal,     // CONSTRAINT_CATALOG
+					schema.Name.O,             // CONSTRAINT_SCHEMA
+					constr.Name.O,             // CONSTRAINT_NAME
+					"("+constr.ExprString+")", // CHECK_CLAUSE
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+}
+
+// setDataForMDLView constructs data for table information_schema.tidb_mdl_view, which shows the transactions
+// blocking the DDL jobs on this TiDB instance.
+func (e *memtableRetriever) setDataForMDLView(ctx sessionctx.Context, is infoschema.InfoSchema) {
+	if !hasPriv(ctx, mysql.ProcessPriv) {
+		return
+	}
+	dom := domain.GetDomain(ctx)
+	if dom == nil || dom.DDL() == nil {
+		return
+	}
+	waits := dom.DDL().MetadataLock().Waits()
+	rows := make([][]types.Datum, 0, len(waits))
+	for _, w := range waits {
+		var dbName, tableName string
+		if tbl, ok := is.TableByID(w.Job.TableID); ok {
+			tableName = tbl.Meta().Name.O
+		}
+		if db, ok := is.SchemaByID(w.Job.SchemaID); ok {
+			dbName = db.Name.O
+		}
+		txnStart := types.NewTime(types.FromGoTime(oracle.GetTimeFromTS(w.StartTS)), mysql.TypeTimestamp, types.MaxFsp)
+		record := types.MakeDatums(
+			w.Job.ID,                   // JOB_ID
+			dbName,                     // DB_NAME
+			tableName,                  // TABLE_NAME
+			w.Job.Query,                // QUERY
+			w.ConnID,                   // SESSION_ID
+			txnStart,                   // TXN_START
+			strings.Join(w.SQLs, "; "), // SQL_TEXT
+		)
+		rows = append(rows, record)
+	}
+	e.rows = rows
+}
+
+// setDataForResourceGroups constructs data for table information_schema.resource_groups, the consumed request units
+// are the ones consumed on this TiDB instance.
+func (e *memtableRetriever) setDataForResourceGroups(ctx sessionctx.Context) error {
+	txn, err := ctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	groups, err := resourcegroup.ListResourceGroups(meta.NewMeta(txn))
+	if err != nil {
+		return err
+	}
+	var manager *resourcegroup.Manager
+	if dom := domain.GetDomain(ctx); dom != nil {
+		manager = dom.ResourceGroupManager()
+	}
+	rows := make([][]types.Datum, 0, len(groups))
+	for _, group := range groups {
+		burstable := "NO"
+		if group.Burstable {
+			burstable = "YES"
+		}
+		var consumedRU float64
+		if manager != nil {
+			consumedRU = manager.GetLimiter(group.Name.L).ConsumedRU()
+		}
+		record := types.MakeDatums(
+			group.Name.O,   // NAME
+			group.RUPerSec, // RU_PER_SEC
+			burstable,      // BURSTABLE
+			consumedRU,     // CONSUMED_RU
+		)
+		rows = append(rows, record)
+	}
+	e.rows = rows
+	return nil
+}
+
+// setDataForRunawayWatches constructs data for table information_schema.runaway_watches, which shows the runaway
+// watch list of this TiDB instance.
+func (e *memtableRetriever) setDataForRunawayWatches(ctx sessionctx.Context) {
+	if !hasPriv(ctx, mysql.ProcessPriv) {
+		return
+	}
+	dom := domain.GetDomain(ctx)
+	if dom == nil {
+		return
+	}
+	watches := dom.RunawayManager().Watches()
+	rows := make([][]types.Datum, 0, len(watches))
+	for _, w := range watches {
+		startTime := types.NewTime(types.FromGoTime(w.StartTime), mysql.TypeDatetime, 0)
+		var endTime interface{}
+		if !w.EndTime.IsZero() {
+			endTime = types.NewTime(types.FromGoTime(w.EndTime), mysql.TypeDatetime, 0)
+		}
+		record := types.MakeDatums(
+			w.ID,        // ID
+			w.SQLDigest, // SQL_DIGEST
+			w.SampleSQL, // SAMPLE_SQL
+			startTime,   // START_TIME
+			endTime,     // END_TIME
+			w.Source,    // SOURCE
+		)
+		rows = append(rows, record)
+	}
+	e.rows = rows
+}
+
 // tableStorageStatsRetriever is used to read slow log data.
 type tableStorageStatsRetriever struct {
 	dummyCloser
@@ -2120,6 +2293,9 @@ func (e *stmtSummaryTableRetriever) retrieve(ctx context.Context, sctx sessionct
 		checker := stmtsummary.NewStmtSummaryChecker(e.extractor.Digests)
 		reader.SetChecker(checker)
 	}
+	if e.extractor.CoarseTimeRange != nil {
+		reader.SetTimeRange(e.extractor.CoarseTimeRange.StartTime, e.extractor.CoarseTimeRange.EndTime)
+	}
 	var rows [][]types.Datum
 	switch e.table.Name.O {
 	case infoschema.TableStatementsSummary,
@@ -551,7 +551,7 @@ func (s *testInfoschemaTableSuite) TestMetricTables(c *C) {
 
 func (s *testInfoschemaTableSuite) TestTableConstraintsTable(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
-	tk.MustQuery("select * from information_schema.TABLE_CONSTRAINTS where TABLE_NAME='gc_delete_range';").Check(testkit.Rows("def mysql delete_range_index mysql gc_delete_range UNIQUE"))
+	tk.MustQuery("select * from information_schema.TABLE_CONSTRAINTS where TABLE_NAME='gc_delete_range';").Check(testkit.Rows("def mysql delete_range_index mysql gc_delete_range UNIQUE YES"))
 }
 
 func (s *testInfoschemaTableSuite) TestTableSessionVar(c *C) {
@@ -45,6 +45,10 @@ type InsertExec struct {
 	row4Update     []types.Datum
 
 	Priority mysql.PriorityEnum
+
+	// onDupFKTriggers checks the foreign key constraints and executes the referential actions
+	// for the rows updated by ON DUPLICATE KEY UPDATE.
+	onDupFKTriggers *fkTriggerExec
 }
 
 func (e *InsertExec) exec(ctx context.Context, rows [][]types.Datum) error {
@@ -198,8 +202,10 @@ func (e *InsertExec) updateDupRow(ctx context.Context, idxInBatch int, txn kv.Tr
 		oldRow = append(oldRow, extraCols...)
 	}
 
-	err = e.doDupRowUpdate(ctx, handle, oldRow, row.row, e.OnDuplicate)
-	if e.ctx.GetSessionVars().StmtCtx.DupKeyAsWarning && kv.ErrKeyExists.Equal(err) {
+	_, err = runIgnorableFKTriggers(e.ctx, e.onDupFKTriggers, func() error {
+		return e.doDupRowUpdate(ctx, handle, oldRow, row.row, e.OnDuplicate)
+	})
+	if e.ctx.GetSessionVars().StmtCtx.DupKeyAsWarning && (kv.ErrKeyExists.Equal(err) || ErrCheckConstraintViolated.Equal(err)) {
 		e.ctx.GetSessionVars().StmtCtx.AppendWarning(err)
 		return nil
 	}
@@ -397,11 +403,8 @@ func (e *InsertExec) doDupRowUpdate(ctx context.Context, handle kv.Handle, oldRo
 	}
 
 	newData := e.row4Update[:len(oldRow)]
-	_, err := updateRecord(ctx, e.ctx, handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker)
-	if err != nil {
-		return err
-	}
-	return nil
+	_, err := updateRecord(ctx, e.ctx, handle, oldRow, newData, assignFlag, e.Table, true, e.checkConstraints, e.onDupFKTriggers, e.memTracker)
+	return err
 }
 
 // setMessage sets info message(ERR_INSERT_INFO) generated by INSERT statement
@@ -87,6 +87,11 @@ type InsertValues struct {
 
 	stats *InsertRuntimeStat
 
+	// fkTriggers checks the foreign key constraints of the inserted rows.
+	fkTriggers *fkTriggerExec
+	// checkConstraints checks the check constraints of the inserted rows.
+	checkConstraints *checkConstraintExec
+
 	// isLoadData indicates whatever current goroutine is use for generating batch data. LoadData use two goroutines. One for generate batch data,
 	// The other one for commit task, which will invalid txn.
 	// We use mutex to protect routine from using invalid txn.
@@ -1117,16 +1122,30 @@ func (e *InsertValues) addRecord(ctx context.Context, row []types.Datum) error {
 
 func (e *InsertValues) addRecordWithAutoIDHint(ctx context.Context, row []types.Datum, reserveAutoIDCount int) (err error) {
 	vars := e.ctx.GetSessionVars()
-	if !vars.ConstraintCheckInPlace {
-		vars.PresumeKeyNotExists = true
-	}
-	if reserveAutoIDCount > 0 {
-		_, err = e.Table.AddRecord(e.ctx, row, table.WithCtx(ctx), table.WithReserveAutoIDHint(reserveAutoIDCount))
-	} else {
-		_, err = e.Table.AddRecord(e.ctx, row, table.WithCtx(ctx))
+	if err = e.checkConstraints.check(row); err != nil {
+		if vars.StmtCtx.DupKeyAsWarning && ErrCheckConstraintViolated.Equal(err) {
+			vars.StmtCtx.AppendWarning(err)
+			return nil
+		}
+		return err
 	}
-	vars.PresumeKeyNotExists = false
-	if err != nil {
+	ignored, err := runIgnorableFKTriggers(e.ctx, e.fkTriggers, func() error {
+		if !vars.ConstraintCheckInPlace {
+			vars.PresumeKeyNotExists = true
+		}
+		var err error
+		if reserveAutoIDCount > 0 {
+			_, err = e.Table.AddRecord(e.ctx, row, table.WithCtx(ctx), table.WithReserveAutoIDHint(reserveAutoIDCount))
+		} else {
+			_, err = e.Table.AddRecord(e.ctx, row, table.WithCtx(ctx))
+		}
+		vars.PresumeKeyNotExists = false
+		if err != nil {
+			return err
+		}
+		return e.fkTriggers.onInsertRow(ctx, row)
+	})
+	if err != nil || ignored {
 		return err
 	}
 	vars.StmtCtx.AddAffectedRows(1)
@@ -0,0 +1,160 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/tidb/expression"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// JSONTableExec produces the rows of the JSON_TABLE table function. The document is evaluated
+// once in Open; when JSON_TABLE is the inner side of an apply, Open is called for every outer row.
+type JSONTableExec struct {
+	baseExecutor
+
+	doc  expression.Expression
+	root *plannercore.JSONTableNestedPath
+	sc   *stmtctx.StatementContext
+
+	// matches are the values matched by the row path. matchIdx is the next one to expand.
+	matches  []json.BinaryJSON
+	matchIdx int
+	// rows are the expanded rows of the current match which are not returned yet.
+	rows [][]types.Datum
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(ctx context.Context) error {
+	e.matches, e.matchIdx, e.rows = nil, 0, nil
+	bj, isNull, err := e.doc.EvalJSON(e.ctx, chunk.Row{})
+	if err != nil || isNull {
+		return err
+	}
+	e.matches = bj.ExtractAll(e.root.Path)
+	return nil
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	for !req.IsFull() {
+		if len(e.rows) == 0 {
+			if e.matchIdx >= len(e.matches) {
+				return nil
+			}
+			rows, err := e.matchRows(e.root, e.matchIdx, e.matches[e.matchIdx])
+			if err != nil {
+				return err
+			}
+			e.rows = rows
+			e.matchIdx++
+			continue
+		}
+		for i := range e.rows[0] {
+			req.AppendDatum(i, &e.rows[0][i])
+		}
+		e.rows = e.rows[1:]
+	}
+	return nil
+}
+
+// nestedRows returns the rows produced by node for every value its path matches in value.
+func (e *JSONTableExec) nestedRows(node *plannercore.JSONTableNestedPath, value json.BinaryJSON) ([][]types.Datum, error) {
+	var rows [][]types.Datum
+	for i, match := range value.ExtractAll(node.Path) {
+		matchRows, err := e.matchRows(node, i, match)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, matchRows...)
+	}
+	return rows, nil
+}
+
+// matchRows returns the rows produced by the idx-th value matched by node. Sibling NESTED PATHs are
+// expanded one after another, the columns of the other siblings are NULL in their rows. If none of
+// them produces a row, a single row with all the nested columns NULL is returned.
+func (e *JSONTableExec) matchRows(node *plannercore.JSONTableNestedPath, idx int, match json.BinaryJSON) ([][]types.Datum, error) {
+	row := make([]types.Datum, e.schema.Len())
+	for _, col := range node.Columns {
+		d, err := e.evalColumn(col, idx, match)
+		if err != nil {
+			return nil, err
+		}
+		row[col.Offset] = d
+	}
+	var rows [][]types.Datum
+	for _, nested := range node.Nested {
+		nestedRows, err := e.nestedRows(nested, match)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, nestedRows...)
+	}
+	if len(rows) == 0 {
+		return [][]types.Datum{row}, nil
+	}
+	for _, r := range rows {
+		for _, col := range node.Columns {
+			r[col.Offset] = row[col.Offset]
+		}
+	}
+	return rows, nil
+}
+
+func (e *JSONTableExec) evalColumn(col *plannercore.JSONTableColumn, idx int, match json.BinaryJSON) (types.Datum, error) {
+	switch col.Tp {
+	case ast.JSONTableColumnOrdinality:
+		return types.NewUintDatum(uint64(idx + 1)), nil
+	case ast.JSONTableColumnExistsPath:
+		exists := int64(0)
+		if len(match.ExtractAll(col.Path)) > 0 {
+			exists = 1
+		}
+		d := types.NewIntDatum(exists)
+		return d.ConvertTo(e.sc, col.RetType)
+	}
+	values := match.ExtractAll(col.Path)
+	if len(values) == 0 {
+		return e.onResponse(col.OnEmpty, ErrMissingJSONTableValue.GenWithStackByArgs(col.Name.O))
+	}
+	if len(values) > 1 {
+		return e.onResponse(col.OnError, plannercore.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O))
+	}
+	d, err := col.Convert(e.sc, values[0])
+	if err != nil {
+		return e.onResponse(col.OnError, err)
+	}
+	return d, nil
+}
+
+// onResponse returns the value given by an ON EMPTY or ON ERROR clause, err is returned
+// for ERROR.
+func (e *JSONTableExec) onResponse(resp plannercore.JSONTableOnResponse, err error) (types.Datum, error) {
+	switch resp.Tp {
+	case ast.JSONTableOnResponseError:
+		return types.Datum{}, err
+	case ast.JSONTableOnResponseDefault:
+		return resp.Default, nil
+	}
+	return types.Datum{}, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONTable(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": "x"}, {"a": "2", "c": [1]}, {}]', '$[*]' columns (` +
+		`id for ordinality, a int path '$.a', b varchar(10) path '$.b', c json path '$.c', ` +
+		`has_c int exists path '$.c')) as t`).Check(testkit.Rows(
+		`1 1 x <nil> 0`,
+		`2 2 <nil> [1] 1`,
+		`3 <nil> <nil> <nil> 0`))
+
+	// The row path matches nothing, or the document is NULL.
+	tk.MustQuery(`select * from json_table('{"a": 1}', '$.b[*]' columns (a int path '$')) t`).Check(testkit.Rows())
+	tk.MustQuery(`select * from json_table(null, '$[*]' columns (a int path '$')) t`).Check(testkit.Rows())
+	tk.MustQuery(`select * from json_table('[]', '$[*]' columns (a int path '$')) t`).Check(testkit.Rows())
+
+	// Booleans and nulls.
+	tk.MustQuery(`select * from json_table('[true, false, null]', '$[*]' columns (` +
+		`i int path '$', s varchar(10) path '$')) t`).Check(testkit.Rows("1 true", "0 false", "<nil> <nil>"))
+
+	// ON EMPTY and ON ERROR.
+	tk.MustQuery(`select * from json_table('[{"a": "x"}, {}]', '$[*]' columns (` +
+		`a int path '$.a' default '7' on empty default '8' on error, ` +
+		`b varchar(10) path '$.b' default '"y"' on empty)) t`).Check(testkit.Rows("8 y", "7 y"))
+	tk.MustQuery(`select * from json_table('[{"a": [1, 2]}, {"a": {"b": 1}}]', '$[*]' columns (` +
+		`a int path '$.a' null on error)) t`).Check(testkit.Rows("<nil>", "<nil>"))
+	err := tk.QueryToErr(`select * from json_table('[{}]', '$[*]' columns (a int path '$.a' error on empty)) t`)
+	require.EqualError(t, err, "[executor:3665]Missing value for JSON_TABLE column 'a'")
+	err = tk.QueryToErr(`select * from json_table('[{"a": [1]}]', '$[*]' columns (a int path '$.a' error on error)) t`)
+	require.EqualError(t, err, "[planner:3666]Can't store an array or an object in the scalar JSON_TABLE column 'a'")
+	err = tk.QueryToErr(`select * from json_table('[{"a": [1, 2]}]', '$[*]' columns (a int path '$.a[*]' error on error)) t`)
+	require.EqualError(t, err, "[planner:3666]Can't store an array or an object in the scalar JSON_TABLE column 'a'")
+	tk.MustQuery(`select * from json_table('[{"a": [1]}]', '$[*]' columns (a int path '$.a')) t`).Check(testkit.Rows("<nil>"))
+
+	// Duplicate column names.
+	tk.MustGetErrCode(`select * from json_table('[]', '$[*]' columns (a int path '$', a int path '$')) t`, errno.ErrDupFieldName)
+}
+
+func TestJSONTableNestedPath(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	doc := `'[{"a": 1, "b": [11, 12], "c": ["x"]}, {"a": 2, "b": [], "c": []}, {"a": 3, "b": [31]}]'`
+	tk.MustQuery(`select * from json_table(` + doc + `, '$[*]' columns (` +
+		`a int path '$.a', ` +
+		`nested path '$.b[*]' columns (bid for ordinality, b int path '$'), ` +
+		`nested path '$.c[*]' columns (c varchar(10) path '$'))) t`).Check(testkit.Rows(
+		"1 1 11 <nil>",
+		"1 2 12 <nil>",
+		"1 <nil> <nil> x",
+		"2 <nil> <nil> <nil>",
+		"3 1 31 <nil>"))
+
+	// Deep nesting.
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": [{"c": [1, 2]}, {"c": []}]}]', '$[*]' columns (` +
+		`a int path '$.a', nested '$.b[*]' columns (bid for ordinality, ` +
+		`nested path '$.c[*]' columns (c int path '$')))) t`).Check(testkit.Rows(
+		"1 1 1",
+		"1 1 2",
+		"1 2 <nil>"))
+}
+
+func TestJSONTableLateral(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int, j json)")
+	tk.MustExec(`insert into t values (1, '[1, 2]'), (2, '[]'), (3, '[3]'), (4, null)`)
+
+	tk.MustQuery(`select t.id, jt.v from t, json_table(t.j, '$[*]' columns (v int path '$')) as jt order by t.id, jt.v`).
+		Check(testkit.Rows("1 1", "1 2", "3 3"))
+	tk.MustQuery(`select t.id, jt.v from t join json_table(t.j, '$[*]' columns (v int path '$')) as jt on jt.v > 1 order by t.id, jt.v`).
+		Check(testkit.Rows("1 2", "3 3"))
+	tk.MustQuery(`select t.id, jt.v from t left join json_table(t.j, '$[*]' columns (v int path '$')) as jt on true order by t.id, jt.v`).
+		Check(testkit.Rows("1 1", "1 2", "2 <nil>", "3 3", "4 <nil>"))
+	tk.MustQuery(`select t.id, sum(jt.v) from t, json_table(t.j, '$[*]' columns (v int path '$')) as jt group by t.id order by t.id`).
+		Check(testkit.Rows("1 3", "3 3"))
+	tk.MustGetErrCode(`select * from t right join json_table(t.j, '$[*]' columns (v int path '$')) as jt on true`,
+		errno.ErrTFForbiddenJoinType)
+
+	tk.MustQuery(`explain format = 'brief' select t.id, jt.v from t, json_table(t.j, '$[*]' columns (v int path '$')) as jt`).Check(testkit.Rows(
+		"Projection 10000.00 root  test.t.id, jt.v",
+		"└─Apply 10000.00 root  CARTESIAN inner join",
+		"  ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+		"  │ └─TableFullScan 10000.00 cop[tikv] table:t keep order:false, stats:pseudo",
+		"  └─JSONTable(Probe) 10.00 root  doc:test.t.j, path:$[*]"))
+
+	// A JSON_TABLE which does not reference the left side is an ordinary join.
+	tk.MustQuery(`select t.id, jt.v from t, json_table('[5]', '$[*]' columns (v int path '$')) as jt order by t.id`).
+		Check(testkit.Rows("1 5", "2 5", "3 5", "4 5"))
+}
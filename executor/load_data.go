@@ -54,8 +54,8 @@ type LoadDataExec struct {
 // Next implements the Executor Next interface.
 func (e *LoadDataExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	req.GrowAndReset(e.maxChunkSize)
-	// TODO: support load data without local field.
-	if !e.IsLocal {
+	// TODO: support load data without local field from the local disk of the TiDB server.
+	if !e.IsLocal && !isExternalStorageURL(e.loadDataInfo.Path) {
 		return errors.New("Load Data: don't support load data without local field")
 	}
 	// TODO: support load data with replace field.
@@ -67,6 +67,11 @@ func (e *LoadDataExec) Next(ctx context.Context, req *chunk.Chunk) error {
 		return errors.New("Load Data: don't support load data terminated is nil")
 	}
 
+	// The files in the external storage are loaded by TiDB directly instead of being sent by the client.
+	if !e.IsLocal {
+		return e.loadDataInfo.loadExternalData(ctx)
+	}
+
 	sctx := e.loadDataInfo.ctx
 	val := sctx.Value(LoadDataVarKey)
 	if val != nil {
@@ -718,6 +723,66 @@ func (e *LoadDataInfo) colsToRow(ctx context.Context, cols []field) []types.Datu
 	return newRow
 }
 
+// datumsToRow is like colsToRow, but the fields are the datums parsed from the files in the external storage.
+func (e *LoadDataInfo) datumsToRow(ctx context.Context, vals []types.Datum) []types.Datum {
+	row := make([]types.Datum, 0, len(e.insertColumns))
+	sessionVars := e.Ctx.GetSessionVars()
+	for i := 0; i < len(e.FieldMappings); i++ {
+		if i >= len(vals) {
+			if e.FieldMappings[i].Column == nil {
+				sessionVars.SetUserVar(e.FieldMappings[i].UserVar.Name, "", mysql.DefaultCollationName)
+				continue
+			}
+
+			// If some columns is missing and their type is time and has not null flag, they should be set as current time.
+			if types.IsTypeTime(e.FieldMappings[i].Column.Tp) && mysql.HasNotNullFlag(e.FieldMappings[i].Column.Flag) {
+				row = append(row, types.NewTimeDatum(types.CurrentTime(e.FieldMappings[i].Column.Tp)))
+				continue
+			}
+
+			row = append(row, types.NewDatum(nil))
+			continue
+		}
+
+		if e.FieldMappings[i].Column == nil {
+			name := e.FieldMappings[i].UserVar.Name
+			if vals[i].IsNull() {
+				sessionVars.UsersLock.Lock()
+				delete(sessionVars.Users, name)
+				sessionVars.UsersLock.Unlock()
+				continue
+			}
+			str, err := vals[i].ToString()
+			if err != nil {
+				e.handleWarning(err)
+				return nil
+			}
+			sessionVars.SetUserVar(name, str, mysql.DefaultCollationName)
+			continue
+		}
+
+		row = append(row, vals[i])
+	}
+	for i := 0; i < len(e.ColumnAssignments); i++ {
+		// eval expression of `SET` clause
+		d, err := expression.EvalAstExpr(e.Ctx, e.ColumnAssignments[i].Expr)
+		if err != nil {
+			e.handleWarning(err)
+			return nil
+		}
+		row = append(row, d)
+	}
+
+	// a new row buffer will be allocated in getRow
+	newRow, err := e.getRow(ctx, row)
+	if err != nil {
+		e.handleWarning(err)
+		return nil
+	}
+
+	return newRow
+}
+
 func (e *LoadDataInfo) addRecordLD(ctx context.Context, row []types.Datum) error {
 	if row == nil {
 		return nil
@@ -0,0 +1,152 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/tidb/types"
+)
+
+// ExternalLoadDataInfo is the information of LOAD DATA from the external storage.
+type ExternalLoadDataInfo struct {
+	// Path is the URL of the files, e.g. `s3://bucket/prefix/*.csv?threads=8&region=us-west-2`.
+	Path        string
+	FieldsInfo  *ast.FieldsClause
+	LinesInfo   *ast.LinesClause
+	IgnoreLines uint64
+	// Columns is the number of the fields of a row.
+	Columns int
+	// Threads is the number of the parsing goroutines if it's not set in the URL.
+	Threads int
+	// BatchRows is the number of rows sent from the parsing goroutines at a time.
+	BatchRows int
+	// AllowServerCredentials is true if the cloud storage can be accessed with the credentials of the server.
+	AllowServerCredentials bool
+}
+
+// ExternalDataLoader parses the files of LOAD DATA in the external storage.
+type ExternalDataLoader interface {
+	// Parse parses the files, the rows are sent to rowsCh which is closed after all the files are parsed or any
+	// error occurs.
+	Parse(ctx context.Context, rowsCh chan<- [][]types.Datum) error
+	// Progress describes the parsed files and the files being parsed.
+	Progress() string
+	// Threads returns the number of the parsing goroutines.
+	Threads() int
+}
+
+// NewExternalDataLoader lists and splits the files of LOAD DATA in the external storage. It's set by the package
+// executor/importer, so the parsers of lightning, which link the zstd codec starting goroutines when it's
+// initialized, are only linked into the binaries loading the files, such as tidb-server.
+var NewExternalDataLoader func(ctx context.Context, info *ExternalLoadDataInfo) (ExternalDataLoader, error)
+
+// loadExternalData loads the files in the external storage. The files are parsed concurrently, and the rows are
+// inserted and committed in batches the same as LOAD DATA LOCAL.
+func (e *LoadDataInfo) loadExternalData(ctx context.Context) error {
+	if !e.Table.Meta().IsBaseTable() {
+		return errors.New("can only load data into base tables")
+	}
+	if NewExternalDataLoader == nil {
+		return errors.New("Load Data: loading from the external storage isn't supported by this binary")
+	}
+	columns := len(e.FieldMappings)
+	if columns == 0 {
+		columns = 1
+	}
+	loader, err := NewExternalDataLoader(ctx, &ExternalLoadDataInfo{
+		Path:        e.Path,
+		FieldsInfo:  e.FieldsInfo,
+		LinesInfo:   e.LinesInfo,
+		IgnoreLines: e.IgnoreLines,
+		Columns:     columns,
+		Threads:     e.ctx.GetSessionVars().ExecutorConcurrency,
+		BatchRows:   e.maxChunkSize,
+
+		AllowServerCredentials: HasExternalStorageAdmin(e.ctx),
+	})
+	if err != nil {
+		return err
+	}
+
+	if batchSize := e.ctx.GetSessionVars().DMLBatchSize; batchSize > 0 {
+		e.SetMaxRowsInBatch(uint64(batchSize))
+	}
+	stmtCtx := e.ctx.GetSessionVars().StmtCtx
+	stmtCtx.SetProgress(loader.Progress())
+	defer stmtCtx.SetProgress("")
+	e.StartStopWatcher()
+	commitErrCh := make(chan error, 1)
+	go func() {
+		commitErrCh <- e.CommitWork(ctx)
+	}()
+
+	parseCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	rowsCh := make(chan [][]types.Datum, loader.Threads())
+	parseErrCh := make(chan error, 1)
+	go func() {
+		parseErrCh <- loader.Parse(parseCtx, rowsCh)
+	}()
+	err = e.insertExternalRows(ctx, rowsCh, loader)
+	// Stop the parsing goroutines if the inserting fails, and wait for them to quit.
+	cancel()
+	for range rowsCh {
+	}
+	if parseErr := <-parseErrCh; err == nil {
+		err = parseErr
+	}
+	if err == nil {
+		err = e.EnqOneTask(ctx)
+	}
+	if err != nil {
+		e.ForceQuit()
+	} else {
+		e.CloseTaskQueue()
+	}
+	commitErr := <-commitErrCh
+	if err == nil && commitErr == nil {
+		// Let the stop watcher goroutine quit.
+		e.ForceQuit()
+	}
+	if err == nil {
+		err = commitErr
+	}
+	e.SetMessage()
+	return err
+}
+
+// insertExternalRows converts the parsed rows and enqueues the commit tasks until rowsCh is closed.
+func (e *LoadDataInfo) insertExternalRows(ctx context.Context, rowsCh <-chan [][]types.Datum, loader ExternalDataLoader) error {
+	stmtCtx := e.ctx.GetSessionVars().StmtCtx
+	for rows := range rowsCh {
+		for _, row := range rows {
+			// rowCount will be used in fillRow(), last insert ID will be assigned according to the rowCount = 1.
+			// So should add first here.
+			e.rowCount++
+			e.rows = append(e.rows, e.datumsToRow(ctx, row))
+			e.curBatchCnt++
+			if e.maxRowsInBatch != 0 && e.rowCount%e.maxRowsInBatch == 0 {
+				if err := e.EnqOneTask(ctx); err != nil {
+					return err
+				}
+			}
+		}
+		stmtCtx.SetProgress(loader.Progress())
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/parser/auth"
+	_ "github.com/pingcap/tidb/executor/importer"
+	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/util/testkit"
+)
+
+func (s *testSuite4) TestLoadDataFromExternalStorage(c *C) {
+	dir := c.MkDir()
+	writeFile := func(name, content string) {
+		c.Assert(os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755), IsNil)
+		c.Assert(os.WriteFile(filepath.Join(dir, name), []byte(content), 0644), IsNil)
+	}
+	writeFile("a.csv", "id,name\n1,a\n2,\"b,c\"\n")
+	writeFile("b.csv", "id,name\n3,\\N\n4,d\n")
+	writeFile("b.txt", "5,e\n")
+	writeFile("sub/c.csv", "6,f\n")
+	url := func(name string) string {
+		return fmt.Sprintf("local://%s/%s", filepath.ToSlash(dir), name)
+	}
+
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, name varchar(10))")
+
+	// The files matched by the pattern are loaded, and the lines are ignored for each file.
+	tk.MustExec(fmt.Sprintf("load data infile '%s' into table t fields terminated by ',' enclosed by '\"' ignore 1 lines", url("*.csv")))
+	c.Assert(tk.Se.LastMessage(), Equals, "Records: 4  Deleted: 0  Skipped: 0  Warnings: 0")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 a", "2 b,c", "3 <nil>", "4 d"))
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.GetProgress(), Equals, "")
+
+	// The duplicated rows are skipped with IGNORE, and the fields can be assigned to the user variables.
+	tk.MustExec(fmt.Sprintf("load data infile '%s?threads=1' ignore into table t fields terminated by ',' (id, @name) set name = upper(@name)", url("b.txt")))
+	c.Assert(tk.Se.LastMessage(), Equals, "Records: 1  Deleted: 0  Skipped: 0  Warnings: 0")
+	tk.MustQuery("select * from t where id = 5").Check(testkit.Rows("5 E"))
+
+	// The large files in the strict format are split into regions and parsed concurrently.
+	var sb strings.Builder
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&sb, "%d\tname%d\n", i, i)
+	}
+	writeFile("large.tsv", sb.String())
+	tk.MustExec("truncate table t")
+	tk.MustExec(fmt.Sprintf("load data infile '%s?strict-format=true&max-region-size=1KiB&threads=4' into table t", url("large.tsv")))
+	tk.MustQuery("select count(*), sum(id), count(distinct name) from t").Check(testkit.Rows("1000 499500 1000"))
+
+	// The compressed csv files and the parquet files exported by SELECT INTO OUTFILE can be loaded.
+	tk.MustExec("drop table if exists t1")
+	tk.MustExec("create table t1 (id int primary key, name varchar(10))")
+	tk.MustExec(fmt.Sprintf("select * from t where id < 10 into outfile '%s?compression=gzip'", url("export.csv")))
+	tk.MustExec(fmt.Sprintf("load data infile '%s' into table t1", url("export.csv.gz")))
+	tk.MustQuery("select count(*), sum(id) from t1").Check(testkit.Rows("10 45"))
+	tk.MustExec("truncate table t1")
+	tk.MustExec(fmt.Sprintf("select * from t where id < 10 into outfile '%s?format=parquet'", url("export.parquet")))
+	tk.MustExec(fmt.Sprintf("load data infile '%s' into table t1", url("export.parquet")))
+	tk.MustQuery("select count(*), sum(id) from t1").Check(testkit.Rows("10 45"))
+	tk.MustQuery("select * from t1 where id in (0, 9)").Check(testkit.Rows("0 name0", "9 name9"))
+
+	err := tk.ExecToErr(fmt.Sprintf("load data infile '%s' into table t1", url("*.json")))
+	c.Assert(err, ErrorMatches, ".*no file matches '\\*.json'.*")
+	err = tk.ExecToErr(fmt.Sprintf("load data infile '%s?format=json' into table t1", url("*.csv")))
+	c.Assert(err, ErrorMatches, ".*unsupported format 'json' of LOAD DATA.*")
+	err = tk.ExecToErr(fmt.Sprintf("load data infile '%s?threads=0' into table t1", url("*.csv")))
+	c.Assert(err, ErrorMatches, ".*invalid threads '0' of LOAD DATA.*")
+	err = tk.ExecToErr(fmt.Sprintf("load data infile '%s' into table t1 lines starting by 'x'", url("*.csv")))
+	c.Assert(err, ErrorMatches, ".*don't support lines starting by.*")
+	err = tk.ExecToErr("load data infile '/tmp/nonexistence.csv' into table t1")
+	c.Assert(err, ErrorMatches, ".*don't support load data without local field.*")
+
+	// The FILE privilege isn't enough to access the cloud storage with the credentials of the server.
+	tk.MustExec("drop user if exists 'loader'@'%'")
+	tk.MustExec("create user 'loader'@'%'")
+	defer tk.MustExec("drop user 'loader'@'%'")
+	tk.MustExec("grant file on *.* to 'loader'@'%'")
+	tk.MustExec("grant insert on test.* to 'loader'@'%'")
+	tk1 := testkit.NewTestKit(c, s.store)
+	se, err := session.CreateSession4Test(s.store)
+	c.Assert(err, IsNil)
+	c.Assert(se.Auth(&auth.UserIdentity{Username: "loader", Hostname: "%"}, nil, nil), IsTrue)
+	tk1.Se = se
+	tk1.MustExec("use test")
+	err = tk1.ExecToErr("load data infile 's3://bucket/prefix/*.csv' into table t1")
+	c.Assert(err.Error(), Equals, "[planner:1227]Access denied; you need (at least one of) the EXTERNAL_STORAGE_ADMIN privilege(s) for this operation")
+	tk1.MustExec(fmt.Sprintf("load data infile '%s' ignore into table t1", url("export.parquet")))
+}
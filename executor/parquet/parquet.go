@@ -0,0 +1,70 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parquet enables the parquet format of SELECT INTO OUTFILE, it's imported by the binaries exporting
+// the parquet files.
+package parquet
+
+import (
+	"io"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/executor"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+func init() {
+	executor.NewOutfileParquetWriter = newWriter
+}
+
+// csvWriter writes the rows, whose values are the Go values of the columns, by the parquet-go library.
+type csvWriter struct {
+	w *writer.CSVWriter
+}
+
+func newWriter(w io.Writer, metadata []string, compression string, rowGroupSize int64) (executor.OutfileParquetWriter, error) {
+	cw, err := writer.NewCSVWriterFromWriter(metadata, w, 1)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The rows are compressed by snappy by default.
+	switch compression {
+	case "none":
+		cw.CompressionType = parquet.CompressionCodec_UNCOMPRESSED
+	case "gzip":
+		cw.CompressionType = parquet.CompressionCodec_GZIP
+	case "zstd":
+		cw.CompressionType = parquet.CompressionCodec_ZSTD
+	}
+	if rowGroupSize > 0 && rowGroupSize < cw.RowGroupSize {
+		cw.RowGroupSize = rowGroupSize
+	}
+	return &csvWriter{w: cw}, nil
+}
+
+// Write implements the executor.OutfileParquetWriter interface.
+func (w *csvWriter) Write(values []interface{}) error {
+	return errors.Trace(w.w.Write(values))
+}
+
+// Size implements the executor.OutfileParquetWriter interface.
+func (w *csvWriter) Size() int64 {
+	return w.w.Offset + w.w.ObjsSize
+}
+
+// Close implements the executor.OutfileParquetWriter interface.
+func (w *csvWriter) Close() error {
+	return errors.Trace(w.w.WriteStop())
+}
@@ -2709,6 +2709,44 @@ partition p2 values less than (10))`)
 	tk.MustQuery("select * from p use index (idx)").Check(testkit.Rows("1 3", "3 4", "5 6", "7 9"))
 }
 
+func (s *globalIndexSuite) TestGlobalIndexMaintenance(c *C) {
+	tk := testkit.NewTestKitWithInit(c, s.store)
+	tk.MustExec("set @@tidb_partition_prune_mode='dynamic'")
+	defer tk.MustExec("set @@tidb_partition_prune_mode='static'")
+	tk.MustExec("drop table if exists p")
+	tk.MustExec(`create table p (id int, c int, unique key idx(id)) partition by range (c) (
+partition p0 values less than (4),
+partition p1 values less than (7),
+partition p2 values less than (10))`)
+	tk.MustExec("insert into p values (1,3), (3,4), (5,6), (7,9)")
+
+	// Uniqueness is checked across partitions.
+	tk.MustGetErrMsg("insert into p values (3, 8)", "[kv:1062]Duplicate entry '3' for key 'idx'")
+
+	// Moving a row to another partition keeps the global index consistent.
+	tk.MustExec("update p set c = 8 where id = 3")
+	tk.MustQuery("select * from p use index(idx) order by id").Check(testkit.Rows("1 3", "3 8", "5 6", "7 9"))
+	tk.MustQuery("select * from p partition(p2) order by id").Check(testkit.Rows("3 8", "7 9"))
+	tk.MustQuery("select * from p where id in (3, 5) order by id").Check(testkit.Rows("3 8", "5 6"))
+	tk.MustExec("admin check table p")
+
+	// Entries of truncated and dropped partitions are removed from the global index.
+	tk.MustExec("alter table p truncate partition p2")
+	tk.MustQuery("select * from p use index(idx) order by id").Check(testkit.Rows("1 3", "5 6"))
+	tk.MustExec("insert into p values (3, 5)")
+	tk.MustExec("admin check table p")
+	tk.MustExec("alter table p drop partition p1")
+	tk.MustQuery("select * from p use index(idx) order by id").Check(testkit.Rows("1 3"))
+	tk.MustExec("insert into p values (3, 8)")
+	tk.MustQuery("select * from p use index(idx) order by id").Check(testkit.Rows("1 3", "3 8"))
+	tk.MustExec("admin check table p")
+
+	// The static prune mode reads every partition separately and can't use a global index.
+	tk.MustExec("set @@tidb_partition_prune_mode='static'")
+	c.Assert(tk.HasPlan("select * from p use index(idx) where id = 3", "Point_Get"), IsFalse)
+	tk.MustQuery("select * from p where id = 3").Check(testkit.Rows("3 8"))
+}
+
 func (s *partitionTableSuite) TestIssue20028(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use test")
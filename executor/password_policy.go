@@ -0,0 +1,415 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/auth"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/privilege/conn"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+// passwordHistoryTable is the table that stores the previous passwords of the accounts.
+const passwordHistoryTable = "password_history"
+
+// validatePasswordComplexity checks the plain text password against the `validate_password_*` variables. The
+// dictionary file of the STRONG policy isn't supported, so STRONG is the same as MEDIUM.
+func validatePasswordComplexity(sctx sessionctx.Context, user, pwd string) error {
+	globalVars := sctx.GetSessionVars().GlobalVarsAccessor
+	getInt := func(name string) (int, error) {
+		val, err := globalVars.GetGlobalSysVar(name)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.Atoi(val)
+	}
+	enable, err := globalVars.GetGlobalSysVar(variable.ValidatePasswordEnable)
+	if err != nil || !variable.TiDBOptOn(enable) {
+		return err
+	}
+	checkUserName, err := globalVars.GetGlobalSysVar(variable.ValidatePasswordCheckUserName)
+	if err != nil {
+		return err
+	}
+	if variable.TiDBOptOn(checkUserName) && user != "" && (pwd == user || pwd == reverseString(user)) {
+		return ErrNotValidPassword.GenWithStackByArgs()
+	}
+
+	length, err := getInt(variable.ValidatePasswordLength)
+	if err != nil {
+		return err
+	}
+	policy, err := globalVars.GetGlobalSysVar(variable.ValidatePasswordPolicy)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(policy, "LOW") {
+		if len([]rune(pwd)) < length {
+			return ErrNotValidPassword.GenWithStackByArgs()
+		}
+		return nil
+	}
+
+	mixedCaseCount, err := getInt(variable.ValidatePasswordMixedCaseCount)
+	if err != nil {
+		return err
+	}
+	numberCount, err := getInt(variable.ValidatePasswordNumberCount)
+	if err != nil {
+		return err
+	}
+	specialCharCount, err := getInt(variable.ValidatePasswordSpecialCharCount)
+	if err != nil {
+		return err
+	}
+	// Like MySQL, the length can't be less than the number of the required characters.
+	if minLength := numberCount + specialCharCount + 2*mixedCaseCount; length < minLength {
+		length = minLength
+	}
+	var upper, lower, number, special, total int
+	for _, r := range pwd {
+		total++
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsDigit(r):
+			number++
+		default:
+			special++
+		}
+	}
+	if total < length || upper < mixedCaseCount || lower < mixedCaseCount || number < numberCount || special < specialCharCount {
+		return ErrNotValidPassword.GenWithStackByArgs()
+	}
+	return nil
+}
+
+func reverseString(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// encodedPassword returns the authentication string of the user spec like ast.UserSpec.EncodedPassword. The
+// authentication strings of the LDAP plugins are the DNs of the users, which are stored as they are.
+func encodedPassword(spec *ast.UserSpec) (string, bool) {
+	if spec.AuthOpt != nil && conn.IsLDAPAuthPlugin(spec.AuthOpt.AuthPlugin) {
+		if spec.AuthOpt.ByAuthString {
+			return spec.AuthOpt.AuthString, true
+		}
+		return spec.AuthOpt.HashString, true
+	}
+	return spec.EncodedPassword()
+}
+
+// plainPassword returns the plain text password of the user spec, false is returned if the password is specified
+// by its hash or the user is authenticated by LDAP. The password is empty if it's not specified.
+func plainPassword(spec *ast.UserSpec) (string, bool) {
+	if spec.AuthOpt == nil {
+		return "", true
+	}
+	if conn.IsLDAPAuthPlugin(spec.AuthOpt.AuthPlugin) {
+		return "", false
+	}
+	if spec.AuthOpt.ByAuthString {
+		return spec.AuthOpt.AuthString, true
+	}
+	return "", spec.AuthOpt.HashString == ""
+}
+
+// passwordOrLockOptions are the password management and `ACCOUNT LOCK/UNLOCK` options of CREATE/ALTER USER, the
+// last one of each kind takes effect.
+type passwordOrLockOptions struct {
+	expire      bool
+	setLifetime bool
+	// lifetime is nil for `PASSWORD EXPIRE DEFAULT`, 0 for `PASSWORD EXPIRE NEVER`.
+	lifetime  interface{}
+	setLocked bool
+	locked    bool
+	// history and reuseInterval are nil for `DEFAULT`, which means the global variables are used.
+	setHistory       bool
+	history          interface{}
+	setReuseInterval bool
+	reuseInterval    interface{}
+	// passwordLocking is the `Password_locking` attribute of the user, which has the `failed_login_attempts` and
+	// `password_lock_time_days` options that are set. The lock time is -1 for `PASSWORD_LOCK_TIME UNBOUNDED`.
+	passwordLocking map[string]int64
+}
+
+func newPasswordOrLockOptions(opts []*ast.PasswordOrLockOption) *passwordOrLockOptions {
+	o := &passwordOrLockOptions{}
+	for _, opt := range opts {
+		switch opt.Type {
+		case ast.PasswordExpire:
+			o.expire = true
+		case ast.PasswordExpireDefault:
+			o.setLifetime, o.lifetime = true, nil
+		case ast.PasswordExpireNever:
+			o.setLifetime, o.lifetime = true, 0
+		case ast.PasswordExpireInterval:
+			o.setLifetime, o.lifetime = true, opt.Count
+		case ast.Lock:
+			o.setLocked, o.locked = true, true
+		case ast.Unlock:
+			o.setLocked, o.locked = true, false
+		case ast.PasswordHistory:
+			o.setHistory, o.history = true, opt.Count
+		case ast.PasswordHistoryDefault:
+			o.setHistory, o.history = true, nil
+		case ast.PasswordReuseInterval:
+			o.setReuseInterval, o.reuseInterval = true, opt.Count
+		case ast.PasswordReuseDefault:
+			o.setReuseInterval, o.reuseInterval = true, nil
+		case ast.FailedLoginAttempts:
+			o.setPasswordLocking("failed_login_attempts", opt.Count)
+		case ast.PasswordLockTime:
+			o.setPasswordLocking("password_lock_time_days", opt.Count)
+		case ast.PasswordLockTimeUnbounded:
+			o.setPasswordLocking("password_lock_time_days", -1)
+		}
+	}
+	return o
+}
+
+func (o *passwordOrLockOptions) setPasswordLocking(key string, val int64) {
+	if o.passwordLocking == nil {
+		o.passwordLocking = make(map[string]int64, 2)
+	}
+	o.passwordLocking[key] = val
+}
+
+// userAttributes returns the `User_attributes` of a new user, it's nil if the failed-login tracking isn't set.
+// Like MySQL, the option which isn't specified is 0.
+func (o *passwordOrLockOptions) userAttributes() (interface{}, error) {
+	if o.passwordLocking == nil {
+		return nil, nil
+	}
+	passwordLocking := map[string]int64{"failed_login_attempts": 0, "password_lock_time_days": 0}
+	for k, v := range o.passwordLocking {
+		passwordLocking[k] = v
+	}
+	attrs, err := json.Marshal(map[string]interface{}{"Password_locking": passwordLocking})
+	return string(attrs), err
+}
+
+// reusePolicy returns the password reuse policy of a new user.
+func (o *passwordOrLockOptions) reusePolicy() passwordReusePolicy {
+	policy := globalPasswordReusePolicy()
+	if history, ok := o.history.(int64); ok {
+		policy.history = history
+	}
+	if reuseInterval, ok := o.reuseInterval.(int64); ok {
+		policy.reuseInterval = reuseInterval
+	}
+	return policy
+}
+
+// formatPolicyAssignments writes the assignments of the password reuse and failed-login tracking options for
+// UPDATE mysql.user, false is returned if there are no such options. They're applied before the password is
+// changed, so the new policy takes effect on the change.
+func (o *passwordOrLockOptions) formatPolicyAssignments(sql *strings.Builder) (bool, error) {
+	assignments := 0
+	sep := func() {
+		if assignments > 0 {
+			sqlexec.MustFormatSQL(sql, ", ")
+		}
+		assignments++
+	}
+	if o.setHistory {
+		sep()
+		sqlexec.MustFormatSQL(sql, "Password_reuse_history = %?", o.history)
+	}
+	if o.setReuseInterval {
+		sep()
+		sqlexec.MustFormatSQL(sql, "Password_reuse_time = %?", o.reuseInterval)
+	}
+	if o.passwordLocking != nil {
+		patch, err := json.Marshal(map[string]interface{}{"Password_locking": o.passwordLocking})
+		if err != nil {
+			return false, err
+		}
+		sep()
+		sqlexec.MustFormatSQL(sql, "User_attributes = JSON_MERGE_PATCH(COALESCE(User_attributes, JSON_OBJECT()), CAST(%? AS JSON))", string(patch))
+	}
+	return assignments > 0, nil
+}
+
+// formatAssignments writes the assignments of the expiration and locking options for UPDATE mysql.user, false is
+// returned if there are no such options.
+func (o *passwordOrLockOptions) formatAssignments(sql *strings.Builder) (bool, error) {
+	assignments := 0
+	sep := func() {
+		if assignments > 0 {
+			sqlexec.MustFormatSQL(sql, ", ")
+		}
+		assignments++
+	}
+	if o.expire {
+		sep()
+		sqlexec.MustFormatSQL(sql, "password_expired = 'Y'")
+	}
+	if o.setLifetime {
+		sep()
+		sqlexec.MustFormatSQL(sql, "password_lifetime = %?", o.lifetime)
+	}
+	if o.setLocked {
+		sep()
+		sqlexec.MustFormatSQL(sql, "account_locked = %?", boolToYN(o.locked))
+	}
+	return assignments > 0, nil
+}
+
+func boolToYN(b bool) string {
+	if b {
+		return "Y"
+	}
+	return "N"
+}
+
+// passwordReusePolicy is the password reuse policy of an account, the `Password_reuse_history` and
+// `Password_reuse_time` of the account override the global `password_history` and `password_reuse_interval`.
+type passwordReusePolicy struct {
+	history       int64
+	reuseInterval int64
+}
+
+func (p passwordReusePolicy) enabled() bool {
+	return p.history > 0 || p.reuseInterval > 0
+}
+
+// retained returns whether the password, which is the i-th most recent one set at ts, is kept in the history.
+func (p passwordReusePolicy) retained(i int, ts, now time.Time) bool {
+	return int64(i) < p.history || (p.reuseInterval > 0 && now.Before(ts.AddDate(0, 0, int(p.reuseInterval))))
+}
+
+// globalPasswordReusePolicy returns the policy of the `password_history` and `password_reuse_interval` variables.
+func globalPasswordReusePolicy() passwordReusePolicy {
+	return passwordReusePolicy{
+		history:       variable.PasswordManagement.History.Load(),
+		reuseInterval: variable.PasswordManagement.ReuseInterval.Load(),
+	}
+}
+
+func loadPasswordReusePolicy(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, user, host string) (passwordReusePolicy, error) {
+	policy := globalPasswordReusePolicy()
+	stmt, err := exec.ParseWithParams(ctx, `SELECT Password_reuse_history, Password_reuse_time FROM %n.%n WHERE Host=%? AND User=%?`,
+		mysql.SystemDB, mysql.UserTable, host, user)
+	if err != nil {
+		return policy, err
+	}
+	rows, _, err := exec.ExecRestrictedStmt(ctx, stmt)
+	if err != nil || len(rows) == 0 {
+		return policy, err
+	}
+	if !rows[0].IsNull(0) {
+		policy.history = rows[0].GetInt64(0)
+	}
+	if !rows[0].IsNull(1) {
+		policy.reuseInterval = rows[0].GetInt64(1)
+	}
+	return policy, nil
+}
+
+// passwordMatches checks whether the new password is the same as the password in the history. The plain text
+// password is empty if the new password is specified by its hash.
+func passwordMatches(stored, plainPwd, pwd, authPlugin string) bool {
+	if plainPwd == "" {
+		return stored == pwd
+	}
+	if authPlugin == mysql.AuthCachingSha2Password {
+		ok, err := auth.CheckShaPassword([]byte(stored), plainPwd)
+		return err == nil && ok
+	}
+	return stored == auth.EncodePassword(plainPwd)
+}
+
+// changePassword checks the new password against the password reuse policy of the account, then updates the
+// password, resets its expiration and records it in mysql.password_history. pwd is the encoded password, and
+// plainPwd is its plain text, which is empty if the password is specified by its hash.
+func changePassword(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, user, host, authPlugin, plainPwd, pwd string) error {
+	policy, err := loadPasswordReusePolicy(ctx, exec, user, host)
+	if err != nil {
+		return err
+	}
+	// Like MySQL, the empty passwords and the DNs of the LDAP users aren't restricted by the reuse policy.
+	recordHistory := policy.enabled() && pwd != "" && !conn.IsLDAPAuthPlugin(authPlugin)
+	var prunedTime interface{}
+	if recordHistory {
+		stmt, err := exec.ParseWithParams(ctx, `SELECT Password_timestamp, Password FROM %n.%n WHERE Host=%? AND User=%? ORDER BY Password_timestamp DESC`,
+			mysql.SystemDB, passwordHistoryTable, host, user)
+		if err != nil {
+			return err
+		}
+		rows, _, err := exec.ExecRestrictedStmt(ctx, stmt)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for i, row := range rows {
+			ts, err := row.GetTime(0).GoTime(time.Local)
+			if err != nil {
+				return err
+			}
+			if policy.retained(i, ts, now) && passwordMatches(row.GetString(1), plainPwd, pwd, authPlugin) {
+				return ErrCredentialsContradictToHistory.GenWithStackByArgs(user, host)
+			}
+			// The new password takes the place of the most recent one, so the passwords out of the policy after
+			// the change are pruned.
+			if prunedTime == nil && !policy.retained(i+1, ts, now) {
+				prunedTime = row.GetTime(0).String()
+			}
+		}
+	}
+
+	stmt, err := exec.ParseWithParams(ctx, `UPDATE %n.%n SET authentication_string=%?, password_expired='N', password_last_changed=current_timestamp() WHERE Host=%? AND User=%?`,
+		mysql.SystemDB, mysql.UserTable, pwd, host, user)
+	if err != nil {
+		return err
+	}
+	if _, _, err = exec.ExecRestrictedStmt(ctx, stmt); err != nil || !recordHistory {
+		return err
+	}
+	if prunedTime != nil {
+		stmt, err = exec.ParseWithParams(ctx, `DELETE FROM %n.%n WHERE Host=%? AND User=%? AND Password_timestamp <= %?`,
+			mysql.SystemDB, passwordHistoryTable, host, user, prunedTime)
+		if err != nil {
+			return err
+		}
+		if _, _, err = exec.ExecRestrictedStmt(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	stmt, err = exec.ParseWithParams(ctx, `INSERT INTO %n.%n (Host, User, Password) VALUES (%?, %?, %?)`,
+		mysql.SystemDB, passwordHistoryTable, host, user, pwd)
+	if err != nil {
+		return err
+	}
+	_, _, err = exec.ExecRestrictedStmt(ctx, stmt)
+	return err
+}
@@ -63,6 +63,8 @@ func (b *executorBuilder) buildPointGet(p *plannercore.PointGetPlan) Executor {
 	e.Init(p, startTS)
 	if e.lock {
 		b.hasLock = true
+	} else if tbl, ok := b.is.TableByID(p.TblInfo.ID); ok {
+		e.cacheTable = b.getCacheTable(tbl, startTS)
 	}
 	return e
 }
@@ -97,6 +99,9 @@ type PointGetExecutor struct {
 	virtualColumnRetFieldTypes []*types.FieldType
 
 	stats *runtimeStatsWithSnapshot
+
+	// cacheTable is the data of the cached table, the snapshot reads are served from it if it's not nil.
+	cacheTable kv.MemBuffer
 }
 
 // Init set fields needed for PointGetExecutor reuse, this does NOT change baseExecutor field
@@ -119,6 +124,7 @@ func (e *PointGetExecutor) Init(p *plannercore.PointGetPlan, startTs uint64) {
 	e.rowDecoder = decoder
 	e.partInfo = p.PartitionInfo
 	e.columns = p.Columns
+	e.cacheTable = nil
 	e.buildVirtualColumnInfo()
 }
 
@@ -150,6 +156,9 @@ func (e *PointGetExecutor) Open(context.Context) error {
 	} else {
 		e.snapshot = e.ctx.GetSnapshotWithTS(snapshotTS)
 	}
+	if e.cacheTable != nil {
+		e.snapshot = cacheTableSnapshot{Snapshot: e.snapshot, memBuffer: e.cacheTable}
+	}
 	if err := e.verifyTxnScope(); err != nil {
 		return err
 	}
@@ -183,6 +192,7 @@ func (e *PointGetExecutor) Open(context.Context) error {
 		}
 	})
 	setResourceGroupTagForTxn(e.ctx.GetSessionVars().StmtCtx, e.snapshot)
+	setResourceGroupLimiterForSnapshot(e.ctx.GetSessionVars(), e.snapshot)
 	return nil
 }
 
@@ -651,3 +661,30 @@ func (e *runtimeStatsWithSnapshot) Merge(other execdetails.RuntimeStats) {
 func (e *runtimeStatsWithSnapshot) Tp() int {
 	return execdetails.TpRuntimeStatsWithSnapshot
 }
+
+// cacheTableSnapshot serves the reads of the cached table from its cache instead of TiKV.
+type cacheTableSnapshot struct {
+	kv.Snapshot
+	memBuffer kv.MemBuffer
+}
+
+// Get implements the kv.Snapshot interface.
+func (s cacheTableSnapshot) Get(ctx context.Context, key kv.Key) ([]byte, error) {
+	return s.memBuffer.Get(ctx, key)
+}
+
+// BatchGet implements the kv.Snapshot interface.
+func (s cacheTableSnapshot) BatchGet(ctx context.Context, keys []kv.Key) (map[string][]byte, error) {
+	values := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		val, err := s.memBuffer.Get(ctx, key)
+		if kv.ErrNotExist.Equal(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[string(key)] = val
+	}
+	return values, nil
+}
@@ -107,6 +107,10 @@ var funcName2Alias = map[string]string{
 	"like":                       ast.Like,
 	"case":                       ast.Case,
 	"regexp":                     ast.Regexp,
+	"regexp_like":                ast.RegexpLike,
+	"regexp_instr":               ast.RegexpInStr,
+	"regexp_substr":              ast.RegexpSubstr,
+	"regexp_replace":             ast.RegexpReplace,
 	"is null":                    ast.IsNull,
 	"is true":                    ast.IsTruthWithoutNull,
 	"is false":                   ast.IsFalsity,
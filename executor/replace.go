@@ -37,6 +37,10 @@ import (
 type ReplaceExec struct {
 	*InsertValues
 	Priority int
+
+	// deleteFKTriggers checks the foreign key constraints and executes the referential actions
+	// for the rows deleted by the replacement.
+	deleteFKTriggers *fkTriggerExec
 }
 
 // Close implements the Executor Close interface.
@@ -88,6 +92,10 @@ func (e *ReplaceExec) removeRow(ctx context.Context, txn kv.Transaction, handle
 	if err != nil {
 		return false, err
 	}
+	err = e.deleteFKTriggers.onDeleteRow(ctx, oldRow)
+	if err != nil {
+		return false, err
+	}
 	e.ctx.GetSessionVars().StmtCtx.AddAffectedRows(1)
 	return false, nil
 }
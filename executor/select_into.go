@@ -33,6 +33,7 @@ import (
 type SelectIntoExec struct {
 	baseExecutor
 	intoOpt *ast.SelectIntoOption
+	names   types.NameSlice
 
 	lineBuf   []byte
 	realBuf   []byte
@@ -41,6 +42,8 @@ type SelectIntoExec struct {
 	enclosed  bool
 	writer    *bufio.Writer
 	dstFile   *os.File
+	// extWriter writes the rows to the external storage if the file name is a URL.
+	extWriter *externalOutfileWriter
 	chk       *chunk.Chunk
 	started   bool
 }
@@ -52,13 +55,25 @@ func (s *SelectIntoExec) Open(ctx context.Context) error {
 		return errors.New("unsupported SelectInto type")
 	}
 
-	f, err := os.OpenFile(s.intoOpt.FileName, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666)
-	if err != nil {
-		return errors.Trace(err)
+	if isExternalStorageURL(s.intoOpt.FileName) {
+		names := make([]string, 0, len(s.names))
+		for _, name := range s.names {
+			names = append(names, name.ColName.O)
+		}
+		w, err := newExternalOutfileWriter(ctx, s.ctx, s.intoOpt.FileName, names, s.children[0].Schema().Columns)
+		if err != nil {
+			return err
+		}
+		s.extWriter = w
+	} else {
+		f, err := os.OpenFile(s.intoOpt.FileName, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		s.dstFile = f
+		s.writer = bufio.NewWriter(s.dstFile)
 	}
 	s.started = true
-	s.dstFile = f
-	s.writer = bufio.NewWriter(s.dstFile)
 	s.chk = newFirstChunk(s.children[0])
 	s.lineBuf = make([]byte, 0, 1024)
 	s.fieldBuf = make([]byte, 0, 64)
@@ -75,7 +90,7 @@ func (s *SelectIntoExec) Next(ctx context.Context, req *chunk.Chunk) error {
 		if s.chk.NumRows() == 0 {
 			break
 		}
-		if err := s.dumpToOutfile(); err != nil {
+		if err := s.dumpToOutfile(ctx); err != nil {
 			return err
 		}
 	}
@@ -117,7 +132,10 @@ func (s *SelectIntoExec) escapeField(f []byte) []byte {
 	return s.escapeBuf
 }
 
-func (s *SelectIntoExec) dumpToOutfile() error {
+func (s *SelectIntoExec) dumpToOutfile(ctx context.Context) error {
+	if s.extWriter != nil && s.extWriter.opts.format == outfileFormatParquet {
+		return s.extWriter.writeParquetRows(ctx, s.chk)
+	}
 	lineTerm := "\n"
 	if s.intoOpt.LinesInfo.Terminated != "" {
 		lineTerm = s.intoOpt.LinesInfo.Terminated
@@ -178,7 +196,7 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			case mysql.TypeNewDecimal:
 				s.fieldBuf = append(s.fieldBuf, row.GetMyDecimal(j).String()...)
 			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
-				mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+				mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeGeometry:
 				s.fieldBuf = append(s.fieldBuf, row.GetBytes(j)...)
 			case mysql.TypeBit:
 				// bit value won't be escaped anyway (verified on MySQL, test case added)
@@ -207,6 +225,12 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			}
 		}
 		s.lineBuf = append(s.lineBuf, lineTerm...)
+		if s.extWriter != nil {
+			if err := s.extWriter.writeLine(ctx, s.lineBuf); err != nil {
+				return err
+			}
+			continue
+		}
 		if _, err := s.writer.Write(s.lineBuf); err != nil {
 			return errors.Trace(err)
 		}
@@ -219,6 +243,14 @@ func (s *SelectIntoExec) Close() error {
 	if !s.started {
 		return nil
 	}
+	if s.extWriter != nil {
+		err1 := s.extWriter.closeFile(context.Background())
+		err2 := s.baseExecutor.Close()
+		if err1 != nil {
+			return err1
+		}
+		return err2
+	}
 	err1 := s.writer.Flush()
 	err2 := s.dstFile.Close()
 	err3 := s.baseExecutor.Close()
@@ -0,0 +1,421 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/parser/charset"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/expression"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+const (
+	outfileFormatCSV     = "csv"
+	outfileFormatParquet = "parquet"
+
+	outfileCompressionNone   = "none"
+	outfileCompressionGzip   = "gzip"
+	outfileCompressionZstd   = "zstd"
+	outfileCompressionSnappy = "snappy"
+
+	// The query parameters of the URL which are the options of SELECT INTO OUTFILE, the others are passed to the storage.
+	outfileParamFormat      = "format"
+	outfileParamCompression = "compression"
+	outfileParamMaxFileSize = "max-file-size"
+)
+
+// OutfileParquetWriter writes the rows of SELECT INTO OUTFILE in the parquet format.
+type OutfileParquetWriter interface {
+	// Write buffers a row, the values are nil, int64, float32, float64 or string.
+	Write(values []interface{}) error
+	// Size returns the size of the rows written and buffered.
+	Size() int64
+	// Close flushes the buffered rows and writes the footer of the file.
+	Close() error
+}
+
+// NewOutfileParquetWriter creates an OutfileParquetWriter over w, rowGroupSize is the max size of a row group, 0 means
+// the default one. It's set by the package executor/parquet, so the parquet library, whose zstd codec starts
+// goroutines when it's initialized, is only linked into the binaries exporting the parquet files, such as tidb-server.
+var NewOutfileParquetWriter func(w io.Writer, metadata []string, compression string, rowGroupSize int64) (OutfileParquetWriter, error)
+
+// isExternalStorageURL checks whether the file name is a URL of the external storage, such as `s3://bucket/prefix`
+// and `local:///path/to/dir`. The plain file names are still read and written on the local disk directly.
+func isExternalStorageURL(fileName string) bool {
+	return strings.Contains(fileName, "://")
+}
+
+// HasExternalStorageAdmin checks whether the user can access the cloud storage with the credentials of the server.
+func HasExternalStorageAdmin(sctx sessionctx.Context) bool {
+	checker := privilege.GetPrivilegeManager(sctx)
+	return checker == nil || checker.RequestDynamicVerification(sctx.GetSessionVars().ActiveRoles, "EXTERNAL_STORAGE_ADMIN", false)
+}
+
+// CheckExternalStorageCredentials checks the credentials of the cloud storage are given in the URL. Otherwise the
+// storage is accessed with the credentials of the server, such as the environment variables and the instance profile,
+// which needs the EXTERNAL_STORAGE_ADMIN privilege besides FILE.
+func CheckExternalStorageCredentials(backend *backuppb.StorageBackend, allowServerCredentials bool) error {
+	switch b := backend.Backend.(type) {
+	case *backuppb.StorageBackend_S3:
+		if b.S3.AccessKey != "" && b.S3.SecretAccessKey != "" {
+			return nil
+		}
+	case *backuppb.StorageBackend_Gcs:
+		if b.Gcs.CredentialsBlob != "" {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if allowServerCredentials {
+		return nil
+	}
+	return plannercore.ErrSpecificAccessDenied.GenWithStackByArgs("EXTERNAL_STORAGE_ADMIN")
+}
+
+// outfileOptions are the options of exporting the rows to the external storage.
+type outfileOptions struct {
+	format      string
+	compression string
+	// maxFileSize is the size of the rows written to a file before it's split, 0 means never splitting the file.
+	maxFileSize int64
+}
+
+// parseOutfileURL parses the URL of SELECT INTO OUTFILE, e.g.
+// `s3://bucket/prefix/result.csv?format=csv&compression=gzip&max-file-size=256MiB&region=us-west-2`.
+// It returns the storage backend of the directory, the name of the file and the options of exporting.
+func parseOutfileURL(rawURL string) (_ string, _ string, opts outfileOptions, err error) {
+	u, err := storage.ParseRawURL(rawURL)
+	if err != nil {
+		return "", "", opts, err
+	}
+	query := u.Query()
+	opts.format = strings.ToLower(query.Get(outfileParamFormat))
+	opts.compression = strings.ToLower(query.Get(outfileParamCompression))
+	if size := query.Get(outfileParamMaxFileSize); size != "" {
+		if opts.maxFileSize, err = units.RAMInBytes(size); err != nil || opts.maxFileSize < 0 {
+			return "", "", opts, errors.Errorf("invalid %s '%s' of SELECT INTO OUTFILE", outfileParamMaxFileSize, size)
+		}
+	}
+	for _, param := range []string{outfileParamFormat, outfileParamCompression, outfileParamMaxFileSize} {
+		query.Del(param)
+	}
+	switch opts.format {
+	case "", outfileFormatCSV:
+		opts.format = outfileFormatCSV
+		switch opts.compression {
+		case "", outfileCompressionNone, outfileCompressionGzip, outfileCompressionZstd:
+		default:
+			return "", "", opts, errors.Errorf("unsupported compression '%s' of the csv format", opts.compression)
+		}
+	case outfileFormatParquet:
+		if NewOutfileParquetWriter == nil {
+			return "", "", opts, errors.Errorf("the parquet format of SELECT INTO OUTFILE isn't supported by this binary")
+		}
+		switch opts.compression {
+		case "", outfileCompressionNone, outfileCompressionGzip, outfileCompressionZstd, outfileCompressionSnappy:
+		default:
+			return "", "", opts, errors.Errorf("unsupported compression '%s' of the parquet format", opts.compression)
+		}
+	default:
+		return "", "", opts, errors.Errorf("unsupported format '%s' of SELECT INTO OUTFILE", opts.format)
+	}
+
+	dir, name := path.Split(u.Path)
+	if name == "" {
+		return "", "", opts, errors.Errorf("the file name is missing in the path '%s' of SELECT INTO OUTFILE", u.Path)
+	}
+	u.Path = dir
+	u.RawQuery = query.Encode()
+	return u.String(), name, opts, nil
+}
+
+// externalOutfileWriter writes the rows of SELECT INTO OUTFILE to the external storage. The file is split into
+// `name.0.csv`, `name.1.csv`... if maxFileSize is set, and the compressed csv files are suffixed with `.gz` or `.zst`.
+type externalOutfileWriter struct {
+	store storage.ExternalStorage
+	// url is the redacted URL of the directory, it's used in the error messages.
+	url  string
+	name string
+	opts outfileOptions
+	// names and cols are the names and the types of the parquet columns.
+	names []string
+	cols  []*expression.Column
+
+	fileIdx int
+	// size is the size of the rows written to the current file, for csv it's the size before compression.
+	size          int64
+	writer        storage.ExternalFileWriter
+	parquetWriter OutfileParquetWriter
+}
+
+func newExternalOutfileWriter(ctx context.Context, sctx sessionctx.Context, rawURL string, names []string, cols []*expression.Column) (*externalOutfileWriter, error) {
+	storeURL, name, opts, err := parseOutfileURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	backend, err := storage.ParseBackend(storeURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = CheckExternalStorageCredentials(backend, HasExternalStorageAdmin(sctx)); err != nil {
+		return nil, err
+	}
+	store, err := storage.New(ctx, backend, &storage.ExternalStorageOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if opts.format == outfileFormatCSV {
+		switch opts.compression {
+		case outfileCompressionGzip:
+			store = storage.WithCompression(store, storage.Gzip)
+		case outfileCompressionZstd:
+			store = storage.WithCompression(store, storage.Zstd)
+		}
+	}
+	redactedURL := storage.FormatBackendURL(backend)
+	w := &externalOutfileWriter{
+		store: store,
+		url:   redactedURL.String(),
+		name:  name,
+		opts:  opts,
+		names: names,
+		cols:  cols,
+	}
+	if err = w.createFile(ctx); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *externalOutfileWriter) fileName() string {
+	name := w.name
+	if w.opts.maxFileSize > 0 {
+		ext := path.Ext(name)
+		name = fmt.Sprintf("%s.%d%s", strings.TrimSuffix(name, ext), w.fileIdx, ext)
+	}
+	if w.opts.format == outfileFormatCSV {
+		switch w.opts.compression {
+		case outfileCompressionGzip:
+			name += ".gz"
+		case outfileCompressionZstd:
+			name += ".zst"
+		}
+	}
+	return name
+}
+
+func (w *externalOutfileWriter) createFile(ctx context.Context) error {
+	var err error
+	if w.writer, err = storage.CreateExclusive(ctx, w.store, w.fileName()); err != nil {
+		return w.fileExistsErr(err)
+	}
+	w.size = 0
+	if w.opts.format != outfileFormatParquet {
+		return nil
+	}
+	w.parquetWriter, err = NewOutfileParquetWriter(&parquetFileWriter{ctx: ctx, writer: w.writer}, w.parquetMetadata(), w.opts.compression, w.opts.maxFileSize)
+	return err
+}
+
+// closeFile finishes writing the current file.
+func (w *externalOutfileWriter) closeFile(ctx context.Context) error {
+	if w.writer == nil {
+		return nil
+	}
+	var err error
+	if w.parquetWriter != nil {
+		err = w.parquetWriter.Close()
+		w.parquetWriter = nil
+	}
+	if err1 := w.writer.Close(ctx); err == nil {
+		// The GCS storage reports the existing file when the writer is closed.
+		err = w.fileExistsErr(err1)
+	}
+	w.writer = nil
+	return err
+}
+
+// fileExistsErr converts the error of creating an existing file, the other errors are returned unchanged.
+func (w *externalOutfileWriter) fileExistsErr(err error) error {
+	if err != nil && os.IsExist(errors.Cause(err)) {
+		return errors.Errorf("file %s already exists in %s", w.fileName(), w.url)
+	}
+	return err
+}
+
+// splitIfNeeded closes the current file and creates the next one if writing n more bytes exceeds the max file size.
+func (w *externalOutfileWriter) splitIfNeeded(ctx context.Context, n int64) error {
+	if w.opts.maxFileSize <= 0 || w.size == 0 || w.size+n <= w.opts.maxFileSize {
+		return nil
+	}
+	if err := w.closeFile(ctx); err != nil {
+		return err
+	}
+	w.fileIdx++
+	return w.createFile(ctx)
+}
+
+// writeLine writes a line of the csv format.
+func (w *externalOutfileWriter) writeLine(ctx context.Context, line []byte) error {
+	if err := w.splitIfNeeded(ctx, int64(len(line))); err != nil {
+		return err
+	}
+	n, err := w.writer.Write(ctx, line)
+	w.size += int64(n)
+	return errors.Trace(err)
+}
+
+// writeParquetRows writes the rows of the chunk in the parquet format.
+func (w *externalOutfileWriter) writeParquetRows(ctx context.Context, chk *chunk.Chunk) error {
+	for i := 0; i < chk.NumRows(); i++ {
+		// The encoded size is unknown until the row group is flushed, so the size is estimated by the buffered rows.
+		if err := w.splitIfNeeded(ctx, 0); err != nil {
+			return err
+		}
+		row := chk.GetRow(i)
+		// The values are buffered by the parquet writer until the row group is flushed, so they can't be reused.
+		values := make([]interface{}, 0, len(w.cols))
+		for j, col := range w.cols {
+			values = append(values, parquetValue(row, j, col.RetType))
+		}
+		if err := w.parquetWriter.Write(values); err != nil {
+			return err
+		}
+		w.size = w.parquetWriter.Size()
+	}
+	return nil
+}
+
+// parquetMetadata returns the schema of the parquet file. All the columns are nullable, the integers are INT64,
+// the floats are FLOAT or DOUBLE, and the others are converted to strings the same as the csv format.
+func (w *externalOutfileWriter) parquetMetadata() []string {
+	md := make([]string, 0, len(w.cols))
+	used := make(map[string]struct{}, len(w.cols))
+	for i, col := range w.cols {
+		name := fmt.Sprintf("col_%d", i)
+		if i < len(w.names) {
+			name = parquetColumnName(w.names[i])
+		}
+		// The names of the parquet columns must be unique.
+		for j, base := 1, name; ; j++ {
+			if _, ok := used[strings.ToLower(name)]; !ok {
+				break
+			}
+			name = fmt.Sprintf("%s_%d", base, j)
+		}
+		used[strings.ToLower(name)] = struct{}{}
+
+		var tp string
+		switch col.RetType.Tp {
+		case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+			tp = "type=INT64"
+		case mysql.TypeLonglong:
+			tp = "type=INT64"
+			if mysql.HasUnsignedFlag(col.RetType.Flag) {
+				tp = "type=UINT_64"
+			}
+		case mysql.TypeFloat:
+			tp = "type=FLOAT"
+		case mysql.TypeDouble:
+			tp = "type=DOUBLE"
+		case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+			mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeBit:
+			tp = "type=BYTE_ARRAY"
+			if col.RetType.Tp != mysql.TypeBit && col.RetType.Charset != charset.CharsetBin {
+				tp = "type=UTF8"
+			}
+		default:
+			tp = "type=UTF8"
+		}
+		md = append(md, fmt.Sprintf("name=%s, %s, repetitiontype=OPTIONAL", name, tp))
+	}
+	return md
+}
+
+// parquetColumnName replaces the characters which are not allowed in the parquet metadata.
+func parquetColumnName(name string) string {
+	if name == "" {
+		return "col"
+	}
+	return strings.Map(func(r rune) rune {
+		if r == ',' || r == '=' || r == ' ' || r == '.' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// parquetValue returns the value of the parquet column, nil means NULL.
+func parquetValue(row chunk.Row, idx int, tp *types.FieldType) interface{} {
+	if row.IsNull(idx) {
+		return nil
+	}
+	switch tp.Tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+		return row.GetInt64(idx)
+	case mysql.TypeLonglong:
+		if mysql.HasUnsignedFlag(tp.Flag) {
+			return int64(row.GetUint64(idx))
+		}
+		return row.GetInt64(idx)
+	case mysql.TypeFloat:
+		return row.GetFloat32(idx)
+	case mysql.TypeDouble:
+		return row.GetFloat64(idx)
+	case mysql.TypeNewDecimal:
+		return row.GetMyDecimal(idx).String()
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeBit, mysql.TypeGeometry:
+		return string(row.GetBytes(idx))
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		return row.GetTime(idx).String()
+	case mysql.TypeDuration:
+		return row.GetDuration(idx, tp.Decimal).String()
+	case mysql.TypeEnum:
+		return row.GetEnum(idx).String()
+	case mysql.TypeSet:
+		return row.GetSet(idx).String()
+	case mysql.TypeJSON:
+		return row.GetJSON(idx).String()
+	}
+	return nil
+}
+
+// parquetFileWriter is the io.Writer over the ExternalFileWriter which the parquet file is written to.
+type parquetFileWriter struct {
+	ctx    context.Context
+	writer storage.ExternalFileWriter
+}
+
+// Write implements the io.Writer interface.
+func (w *parquetFileWriter) Write(p []byte) (int, error) {
+	return w.writer.Write(w.ctx, p)
+}
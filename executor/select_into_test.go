@@ -15,17 +15,26 @@
 package executor_test
 
 import (
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	. "github.com/pingcap/check"
+	"github.com/pingcap/parser/auth"
 	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/tidb/executor"
+	_ "github.com/pingcap/tidb/executor/parquet"
+	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/testkit"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
 )
 
 func cmpAndRm(expected, outfile string, c *C) {
@@ -279,3 +288,102 @@ func (s *testSuite1) TestYearType(c *C) {
 	tk.MustExec(fmt.Sprintf("select * from t into outfile '%v' fields terminated by ',' optionally enclosed by '\"' lines terminated by '\\n';", outfile))
 	cmpAndRm("2010\n2011\n2012\n2030\n", outfile, c)
 }
+
+func (s *testSuite1) TestSelectIntoExternalStorageCredentials(c *C) {
+	dir := c.MkDir()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (i int)")
+	tk.MustExec("insert into t values (1)")
+	tk.MustExec("drop user if exists 'outfile'@'%'")
+	tk.MustExec("create user 'outfile'@'%'")
+	defer tk.MustExec("drop user 'outfile'@'%'")
+	tk.MustExec("grant file on *.* to 'outfile'@'%'")
+	tk.MustExec("grant select on test.* to 'outfile'@'%'")
+
+	// The FILE privilege isn't enough to access the cloud storage with the credentials of the server.
+	tk1 := testkit.NewTestKit(c, s.store)
+	se, err := session.CreateSession4Test(s.store)
+	c.Assert(err, IsNil)
+	c.Assert(se.Auth(&auth.UserIdentity{Username: "outfile", Hostname: "%"}, nil, nil), IsTrue)
+	tk1.Se = se
+	tk1.MustExec("use test")
+	err = tk1.ExecToErr("select * from t into outfile 's3://bucket/prefix/result.csv'")
+	c.Assert(err.Error(), Equals, "[planner:1227]Access denied; you need (at least one of) the EXTERNAL_STORAGE_ADMIN privilege(s) for this operation")
+	err = tk1.ExecToErr("select * from t into outfile 'gcs://bucket/prefix/result.csv'")
+	c.Assert(err.Error(), Equals, "[planner:1227]Access denied; you need (at least one of) the EXTERNAL_STORAGE_ADMIN privilege(s) for this operation")
+	tk1.MustExec(fmt.Sprintf("select * from t into outfile 'local://%s/result.csv'", filepath.ToSlash(dir)))
+}
+
+func (s *testSuite1) TestSelectIntoExternalStorage(c *C) {
+	dir := c.MkDir()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (i int, s varchar(10), d decimal(5, 2), b bigint unsigned)")
+	tk.MustExec("insert into t values (1, 'a', 1.1, 18446744073709551615), (2, 'b', 2.2, 0), (3, null, null, null)")
+
+	readFile := func(name string, read func(r io.Reader) (io.Reader, error)) string {
+		f, err := os.Open(filepath.Join(dir, name))
+		c.Assert(err, IsNil)
+		defer func() {
+			c.Assert(f.Close(), IsNil)
+		}()
+		r, err := read(f)
+		c.Assert(err, IsNil)
+		content, err := io.ReadAll(r)
+		c.Assert(err, IsNil)
+		return string(content)
+	}
+	plain := func(r io.Reader) (io.Reader, error) { return r, nil }
+	expected := "1\ta\t1.10\t18446744073709551615\n2\tb\t2.20\t0\n3\t\\N\t\\N\t\\N\n"
+
+	// The options are passed as the query parameters, and the file exists check is the same as the local file.
+	url := fmt.Sprintf("local://%s/result.csv", filepath.ToSlash(dir))
+	tk.MustExec(fmt.Sprintf("select * from t into outfile '%s'", url))
+	c.Assert(readFile("result.csv", plain), Equals, expected)
+	err := tk.ExecToErr(fmt.Sprintf("select * from t into outfile '%s'", url))
+	c.Assert(err, ErrorMatches, ".*file result.csv already exists.*")
+	err = tk.ExecToErr(fmt.Sprintf("select * from t into outfile '%s?format=json'", url))
+	c.Assert(err, ErrorMatches, ".*unsupported format 'json'.*")
+	err = tk.ExecToErr(fmt.Sprintf("select * from t into outfile '%s?compression=snappy'", url))
+	c.Assert(err, ErrorMatches, ".*unsupported compression 'snappy' of the csv format.*")
+	err = tk.ExecToErr(fmt.Sprintf("select * from t into outfile '%s?max-file-size=abc'", url))
+	c.Assert(err, ErrorMatches, ".*invalid max-file-size 'abc'.*")
+
+	// The files are split by the size of the rows, and are compressed.
+	url = fmt.Sprintf("local://%s/split.csv?max-file-size=30&compression=gzip", filepath.ToSlash(dir))
+	tk.MustExec(fmt.Sprintf("select * from t order by i into outfile '%s' fields terminated by ','", url))
+	gzipReader := func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }
+	c.Assert(readFile("split.0.csv.gz", gzipReader), Equals, "1,a,1.10,18446744073709551615\n")
+	c.Assert(readFile("split.1.csv.gz", gzipReader), Equals, "2,b,2.20,0\n3,\\N,\\N,\\N\n")
+	url = fmt.Sprintf("local://%s/result.csv?compression=zstd", filepath.ToSlash(dir))
+	tk.MustExec(fmt.Sprintf("select * from t into outfile '%s'", url))
+	dec, err := zstd.NewReader(nil)
+	c.Assert(err, IsNil)
+	defer dec.Close()
+	zstdReader := func(r io.Reader) (io.Reader, error) { return dec, dec.Reset(r) }
+	c.Assert(readFile("result.csv.zst", zstdReader), Equals, expected)
+
+	// Export the rows in the parquet format.
+	for _, compression := range []string{"", "none", "gzip", "zstd", "snappy"} {
+		name := fmt.Sprintf("result-%s.parquet", compression)
+		url = fmt.Sprintf("local://%s/%s?format=parquet&compression=%s", filepath.ToSlash(dir), name, compression)
+		tk.MustExec(fmt.Sprintf("select i, s as `s,1`, d, b, i + 1 as i from t order by i into outfile '%s'", url))
+		pf, err := local.NewLocalFileReader(filepath.Join(dir, name))
+		c.Assert(err, IsNil)
+		pr, err := reader.NewParquetReader(pf, nil, 1)
+		c.Assert(err, IsNil)
+		c.Assert(pr.GetNumRows(), Equals, int64(3))
+		rows, err := pr.ReadByNumber(3)
+		c.Assert(err, IsNil)
+		content, err := json.Marshal(rows)
+		c.Assert(err, IsNil)
+		c.Assert(string(content), Equals, `[{"I":1,"S_1":"a","D":"1.10","B":18446744073709551615,"I_1":2},`+
+			`{"I":2,"S_1":"b","D":"2.20","B":0,"I_1":3},`+
+			`{"I":3,"S_1":null,"D":null,"B":null,"I_1":4}]`)
+		pr.ReadStop()
+		c.Assert(pf.Close(), IsNil)
+	}
+}
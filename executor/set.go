@@ -22,9 +22,13 @@ import (
 	"github.com/pingcap/parser/ast"
 	"github.com/pingcap/parser/charset"
 	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/ddl"
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/expression"
+	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/plugin"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/resourcegroup"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/table/temptable"
@@ -158,6 +162,11 @@ func (e *SetExecutor) setSysVariable(ctx context.Context, name string, v *expres
 			return errors.Trace(ErrCantChangeTxCharacteristics)
 		}
 	}
+	if name == variable.TiDBResourceGroup {
+		if err = e.checkResourceGroup(valStr); err != nil {
+			return err
+		}
+	}
 	err = variable.SetSessionSystemVar(sessionVars, name, valStr)
 	if err != nil {
 		return err
@@ -255,6 +264,33 @@ func (e *SetExecutor) getVarValue(v *expression.VarAssignment, sysVar *variable.
 	return nativeVal.ToString()
 }
 
+// checkResourceGroup checks whether the session can switch to the resource group. The group must exist, and only
+// the users with SUPER or RESOURCE_GROUP_ADMIN can switch away from the group bound to them, which is the default
+// group if the bound one doesn't exist.
+func (e *SetExecutor) checkResourceGroup(name string) error {
+	normalize := func(name string) string {
+		if name == "" {
+			return resourcegroup.DefaultGroupName
+		}
+		return strings.ToLower(name)
+	}
+	manager := domain.GetDomain(e.ctx).ResourceGroupManager()
+	vars := e.ctx.GetSessionVars()
+	if checker := privilege.GetPrivilegeManager(e.ctx); checker != nil && vars.User != nil {
+		bound := checker.GetResourceGroup(vars.User.AuthUsername, vars.User.AuthHostname)
+		if !manager.Exists(normalize(bound)) {
+			bound = ""
+		}
+		if normalize(name) != normalize(bound) && !checker.RequestDynamicVerification(vars.ActiveRoles, "RESOURCE_GROUP_ADMIN", false) {
+			return plannercore.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
+		}
+	}
+	if !manager.Exists(normalize(name)) {
+		return ddl.ErrResourceGroupNotExists.GenWithStackByArgs(name)
+	}
+	return nil
+}
+
 func (e *SetExecutor) loadSnapshotInfoSchemaIfNeeded(snapshotTS uint64) error {
 	vars := e.ctx.GetSessionVars()
 	if snapshotTS == 0 {
@@ -44,6 +44,7 @@ import (
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/meta/autoid"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/plugin"
@@ -55,6 +56,7 @@ import (
 	"github.com/pingcap/tidb/store/helper"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/ttl"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util"
@@ -999,6 +1001,16 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 		}
 	}
 
+	for _, constr := range tableInfo.Constraints {
+		if constr.State != model.StatePublic {
+			continue
+		}
+		buf.WriteString(fmt.Sprintf(",\n  CONSTRAINT %s CHECK ((%s))", stringutil.Escape(constr.Name.O, sqlMode), constr.ExprString))
+		if !constr.Enforced {
+			buf.WriteString(" /*!80016 NOT ENFORCED */")
+		}
+	}
+
 	buf.WriteString("\n")
 
 	switch tableInfo.TempTableType {
@@ -1070,6 +1082,29 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 		fmt.Fprintf(buf, " ON COMMIT DELETE ROWS")
 	}
 
+	if tableInfo.TTLInfo != nil {
+		enable := "ON"
+		if !tableInfo.TTLInfo.Enable {
+			enable = "OFF"
+		}
+		fmt.Fprintf(buf, " /*T![ttl] TTL=%s */ /*T![ttl] TTL_ENABLE='%s' */", ttl.DefinitionString(tableInfo.TTLInfo), enable)
+	}
+
+	if tableInfo.TempTableType == model.TempTableNone {
+		txn, err := ctx.Txn(true)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		m := meta.NewMeta(txn)
+		cacheStatus, err := m.GetTableCacheStatus(tableInfo.ID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if cacheStatus == meta.TableCacheStatusEnable {
+			buf.WriteString(" /* CACHED ON */")
+		}
+	}
+
 	if tableInfo.PlacementPolicyRef != nil {
 		fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=`%s` */", tableInfo.PlacementPolicyRef.Name.String())
 	}
@@ -1233,7 +1268,16 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer)
 		return
 	}
 	if partitionInfo.Type == model.PartitionTypeHash {
-		fmt.Fprintf(buf, "\nPARTITION BY HASH( %s )", partitionInfo.Expr)
+		fmt.Fprintf(buf, "\nPARTITION BY %s( %s )", tables.PartitionTypeString(partitionInfo), partitionInfo.Expr)
+		fmt.Fprintf(buf, "\nPARTITIONS %d", partitionInfo.Num)
+		return
+	}
+	if partitionInfo.Type == model.PartitionTypeKey {
+		colsName := make([]string, 0, len(partitionInfo.Columns))
+		for _, col := range partitionInfo.Columns {
+			colsName = append(colsName, col.L)
+		}
+		fmt.Fprintf(buf, "\nPARTITION BY %s( %s )", tables.PartitionTypeString(partitionInfo), strings.Join(colsName, ","))
 		fmt.Fprintf(buf, "\nPARTITIONS %d", partitionInfo.Num)
 		return
 	}
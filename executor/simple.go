@@ -40,6 +40,7 @@ import (
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/plugin"
 	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/privilege/conn"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/util"
@@ -750,14 +751,18 @@ func (e *SimpleExec) executeCreateUser(ctx context.Context, s *ast.CreateUserStm
 		return err
 	}
 
-	sql := new(strings.Builder)
-	if s.IsCreateRole {
-		sqlexec.MustFormatSQL(sql, `INSERT INTO %n.%n (Host, User, authentication_string, plugin, Account_locked) VALUES `, mysql.SystemDB, mysql.UserTable)
-	} else {
-		sqlexec.MustFormatSQL(sql, `INSERT INTO %n.%n (Host, User, authentication_string, plugin) VALUES `, mysql.SystemDB, mysql.UserTable)
+	opts := newPasswordOrLockOptions(s.PasswordOrLockOptions)
+	locked := s.IsCreateRole || (opts.setLocked && opts.locked)
+	userAttributes, err := opts.userAttributes()
+	if err != nil {
+		return err
 	}
+	sql := new(strings.Builder)
+	sqlexec.MustFormatSQL(sql, `INSERT INTO %n.%n (Host, User, authentication_string, plugin, Account_locked, password_expired, password_lifetime, password_last_changed, Password_reuse_history, Password_reuse_time, User_attributes) VALUES `, mysql.SystemDB, mysql.UserTable)
 
 	users := make([]*auth.UserIdentity, 0, len(s.Specs))
+	// pwds are the non-empty passwords of the users, they're recorded in the password history.
+	pwds := make(map[*auth.UserIdentity]string, len(s.Specs))
 	for _, spec := range s.Specs {
 		if len(users) > 0 {
 			sqlexec.MustFormatSQL(sql, ",")
@@ -778,21 +783,26 @@ func (e *SimpleExec) executeCreateUser(ctx context.Context, s *ast.CreateUserStm
 			e.ctx.GetSessionVars().StmtCtx.AppendNote(err)
 			continue
 		}
-		pwd, ok := spec.EncodedPassword()
+		pwd, ok := encodedPassword(spec)
 
 		if !ok {
 			return errors.Trace(ErrPasswordFormat)
 		}
+		if plainPwd, ok := plainPassword(spec); ok && !s.IsCreateRole {
+			if err := validatePasswordComplexity(e.ctx, spec.User.Username, plainPwd); err != nil {
+				return err
+			}
+		}
 		authPlugin := mysql.AuthNativePassword
 		if spec.AuthOpt != nil && spec.AuthOpt.AuthPlugin != "" {
 			authPlugin = spec.AuthOpt.AuthPlugin
 		}
-		if s.IsCreateRole {
-			sqlexec.MustFormatSQL(sql, `(%?, %?, %?, %?, %?)`, spec.User.Hostname, spec.User.Username, pwd, authPlugin, "Y")
-		} else {
-			sqlexec.MustFormatSQL(sql, `(%?, %?, %?, %?)`, spec.User.Hostname, spec.User.Username, pwd, authPlugin)
-		}
+		sqlexec.MustFormatSQL(sql, `(%?, %?, %?, %?, %?, %?, %?, current_timestamp(), %?, %?, %?)`, spec.User.Hostname, spec.User.Username, pwd, authPlugin,
+			boolToYN(locked), boolToYN(opts.expire), opts.lifetime, opts.history, opts.reuseInterval, userAttributes)
 		users = append(users, spec.User)
+		if pwd != "" && !conn.IsLDAPAuthPlugin(authPlugin) {
+			pwds[spec.User] = pwd
+		}
 	}
 	if len(users) == 0 {
 		return nil
@@ -815,6 +825,29 @@ func (e *SimpleExec) executeCreateUser(ctx context.Context, s *ast.CreateUserStm
 		}
 		return err
 	}
+	if len(pwds) > 0 && opts.reusePolicy().enabled() {
+		sql.Reset()
+		sqlexec.MustFormatSQL(sql, "INSERT INTO %n.%n (Host, User, Password) VALUES ", mysql.SystemDB, passwordHistoryTable)
+		i := 0
+		for _, user := range users {
+			pwd, ok := pwds[user]
+			if !ok {
+				continue
+			}
+			if i > 0 {
+				sqlexec.MustFormatSQL(sql, ",")
+			}
+			sqlexec.MustFormatSQL(sql, `(%?, %?, %?)`, user.Hostname, user.Username, pwd)
+			i++
+		}
+		_, err = sqlExecutor.ExecuteInternal(context.TODO(), sql.String())
+		if err != nil {
+			if _, rollbackErr := sqlExecutor.ExecuteInternal(context.TODO(), "rollback"); rollbackErr != nil {
+				return rollbackErr
+			}
+			return err
+		}
+	}
 	if len(privData) != 0 {
 		sql.Reset()
 		sqlexec.MustFormatSQL(sql, "INSERT IGNORE INTO %n.%n (Host, User, Priv) VALUES ", mysql.SystemDB, mysql.GlobalPrivTable)
@@ -859,6 +892,7 @@ func (e *SimpleExec) executeAlterUser(ctx context.Context, s *ast.AlterUserStmt)
 		return err
 	}
 
+	opts := newPasswordOrLockOptions(s.PasswordOrLockOptions)
 	failedUsers := make([]string, 0, len(s.Specs))
 	checker := privilege.GetPrivilegeManager(e.ctx)
 	if checker == nil {
@@ -922,21 +956,63 @@ func (e *SimpleExec) executeAlterUser(ctx context.Context, s *ast.AlterUserStmt)
 			spec.AuthOpt.AuthPlugin = authplugin
 		}
 		exec := e.ctx.(sqlexec.RestrictedSQLExecutor)
+		// updateUser applies the assignments of the options to the user, it returns false if the update fails.
+		updateUser := func(formatAssignments func(*strings.Builder) (bool, error)) (bool, error) {
+			sql := new(strings.Builder)
+			sqlexec.MustFormatSQL(sql, "UPDATE %n.%n SET ", mysql.SystemDB, mysql.UserTable)
+			ok, err := formatAssignments(sql)
+			if err != nil || !ok {
+				return true, err
+			}
+			sqlexec.MustFormatSQL(sql, " WHERE Host=%? AND User=%?", spec.User.Hostname, spec.User.Username)
+			stmt, err := exec.ParseWithParams(ctx, sql.String())
+			if err != nil {
+				return false, err
+			}
+			_, _, err = exec.ExecRestrictedStmt(ctx, stmt)
+			return err == nil, nil
+		}
+		updated, err := updateUser(opts.formatPolicyAssignments)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			failedUsers = append(failedUsers, spec.User.String())
+		} else if opts.passwordLocking != nil {
+			domain.GetDomain(e.ctx).PrivilegeHandle().ResetFailedLogins(spec.User.Username, spec.User.Hostname)
+		}
 		if spec.AuthOpt != nil {
-			pwd, ok := spec.EncodedPassword()
+			pwd, ok := encodedPassword(spec)
 			if !ok {
 				return errors.Trace(ErrPasswordFormat)
 			}
-			stmt, err := exec.ParseWithParams(ctx, `UPDATE %n.%n SET authentication_string=%? WHERE Host=%? and User=%?;`, mysql.SystemDB, mysql.UserTable, pwd, spec.User.Hostname, spec.User.Username)
-			if err != nil {
-				return err
+			plainPwd, ok := plainPassword(spec)
+			if ok {
+				if err := validatePasswordComplexity(e.ctx, spec.User.Username, plainPwd); err != nil {
+					return err
+				}
 			}
-			_, _, err = exec.ExecRestrictedStmt(ctx, stmt)
+			err = changePassword(ctx, exec, spec.User.Username, spec.User.Hostname, authplugin, plainPwd, pwd)
 			if err != nil {
+				if ErrCredentialsContradictToHistory.Equal(err) {
+					return err
+				}
 				failedUsers = append(failedUsers, spec.User.String())
+			} else if e.isSessionUser(spec.User.Username, spec.User.Hostname) {
+				e.ctx.GetSessionVars().PasswordExpired = false
 			}
 		}
 
+		updated, err = updateUser(opts.formatAssignments)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			failedUsers = append(failedUsers, spec.User.String())
+		} else if opts.setLocked && !opts.locked {
+			domain.GetDomain(e.ctx).PrivilegeHandle().ResetFailedLogins(spec.User.Username, spec.User.Hostname)
+		}
+
 		if len(privData) > 0 {
 			stmt, err := exec.ParseWithParams(ctx, "INSERT INTO %n.%n (Host, User, Priv) VALUES (%?,%?,%?) ON DUPLICATE KEY UPDATE Priv = values(Priv)", mysql.SystemDB, mysql.GlobalPrivTable, spec.User.Hostname, spec.User.Username, string(hack.String(privData)))
 			if err != nil {
@@ -1076,6 +1152,12 @@ func (e *SimpleExec) executeRenameUser(s *ast.RenameUserStmt) error {
 			break
 		}
 
+		// rename passwords from mysql.password_history
+		if err = renameUserHostInSystemTable(sqlExecutor, passwordHistoryTable, "User", "Host", userToUser); err != nil {
+			failedUser = oldUser.String() + " TO " + newUser.String() + " " + passwordHistoryTable + " error"
+			break
+		}
+
 		// rename privileges from mysql.db
 		if err = renameUserHostInSystemTable(sqlExecutor, mysql.DBTable, "User", "Host", userToUser); err != nil {
 			failedUser = oldUser.String() + " TO " + newUser.String() + " " + mysql.DBTable + " error"
@@ -1224,6 +1306,17 @@ func (e *SimpleExec) executeDropUser(ctx context.Context, s *ast.DropUserStmt) e
 			continue
 		}
 
+		// delete passwords from mysql.password_history
+		sql.Reset()
+		sqlexec.MustFormatSQL(sql, `DELETE FROM %n.%n WHERE Host = %? and User = %?;`, mysql.SystemDB, passwordHistoryTable, user.Hostname, user.Username)
+		if _, err := sqlExecutor.ExecuteInternal(context.TODO(), sql.String()); err != nil {
+			failedUsers = append(failedUsers, user.String())
+			if _, err := sqlExecutor.ExecuteInternal(context.TODO(), "rollback"); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// delete privileges from mysql.db
 		sql.Reset()
 		sqlexec.MustFormatSQL(sql, `DELETE FROM %n.%n WHERE Host = %? and User = %?;`, mysql.SystemDB, mysql.DBTable, user.Hostname, user.Username)
@@ -1340,6 +1433,12 @@ func (e *SimpleExec) userAuthPlugin(name string, host string) (string, error) {
 	return authplugin, nil
 }
 
+// isSessionUser checks whether the account is the one the session logins as.
+func (e *SimpleExec) isSessionUser(name string, host string) bool {
+	user := e.ctx.GetSessionVars().User
+	return user != nil && user.AuthUsername == name && user.AuthHostname == host
+}
+
 func (e *SimpleExec) executeSetPwd(ctx context.Context, s *ast.SetPwdStmt) error {
 	var u, h string
 	if s.User == nil {
@@ -1369,6 +1468,13 @@ func (e *SimpleExec) executeSetPwd(ctx context.Context, s *ast.SetPwdStmt) error
 	if err != nil {
 		return err
 	}
+	// The passwords of the LDAP users are managed by the LDAP server.
+	if conn.IsLDAPAuthPlugin(authplugin) {
+		return ErrSetPasswordAuthPlugin.GenWithStackByArgs()
+	}
+	if err := validatePasswordComplexity(e.ctx, u, s.Password); err != nil {
+		return err
+	}
 	var pwd string
 	if authplugin == mysql.AuthCachingSha2Password {
 		pwd = auth.NewSha2Password(s.Password)
@@ -1378,13 +1484,11 @@ func (e *SimpleExec) executeSetPwd(ctx context.Context, s *ast.SetPwdStmt) error
 
 	// update mysql.user
 	exec := e.ctx.(sqlexec.RestrictedSQLExecutor)
-	stmt, err := exec.ParseWithParams(ctx, `UPDATE %n.%n SET authentication_string=%? WHERE User=%? AND Host=%?;`, mysql.SystemDB, mysql.UserTable, pwd, u, h)
-	if err != nil {
+	if err := changePassword(ctx, exec, u, h, authplugin, s.Password, pwd); err != nil {
 		return err
 	}
-	_, _, err = exec.ExecRestrictedStmt(ctx, stmt)
-	if err != nil {
-		return err
+	if e.isSessionUser(u, h) {
+		e.ctx.GetSessionVars().PasswordExpired = false
 	}
 	return domain.GetDomain(e.ctx).NotifyUpdatePrivilege()
 }
@@ -16,6 +16,7 @@ package executor_test
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	. "github.com/pingcap/check"
@@ -514,6 +515,81 @@ func (s *testSuite3) TestSetPwd(c *C) {
 
 }
 
+func (s *testSerialSuite) TestPasswordComplexity(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("set global validate_password_enable = ON")
+	defer func() {
+		tk.MustExec("set global validate_password_enable = default")
+		tk.MustExec("set global validate_password_policy = default")
+		tk.MustExec("set global validate_password_check_user_name = default")
+	}()
+	tk.MustExec("drop user if exists complexity_user")
+
+	// The MEDIUM policy requires the length, mixed case, number and special characters.
+	for _, pwd := range []string{"", "Ab1!", "abcdefg1!", "ABCDEFG1!", "Abcdefgh!", "Abcdefgh1"} {
+		err := tk.ExecToErr(fmt.Sprintf("create user complexity_user identified by '%s'", pwd))
+		c.Assert(terror.ErrorEqual(err, executor.ErrNotValidPassword), IsTrue, Commentf("pwd %s err %v", pwd, err))
+	}
+	tk.MustExec("create user complexity_user identified by 'Abcdefg1!'")
+	err := tk.ExecToErr("alter user complexity_user identified by 'abc'")
+	c.Assert(terror.ErrorEqual(err, executor.ErrNotValidPassword), IsTrue, Commentf("err %v", err))
+	err = tk.ExecToErr("set password for complexity_user = 'abc'")
+	c.Assert(terror.ErrorEqual(err, executor.ErrNotValidPassword), IsTrue, Commentf("err %v", err))
+
+	// The LOW policy only checks the length.
+	tk.MustExec("set global validate_password_policy = LOW")
+	tk.MustExec("set password for complexity_user = 'abcdefgh'")
+	tk.MustExec("set global validate_password_check_user_name = ON")
+	err = tk.ExecToErr("set password for complexity_user = 'complexity_user'")
+	c.Assert(terror.ErrorEqual(err, executor.ErrNotValidPassword), IsTrue, Commentf("err %v", err))
+	err = tk.ExecToErr("set password for complexity_user = 'resu_ytixelpmoc'")
+	c.Assert(terror.ErrorEqual(err, executor.ErrNotValidPassword), IsTrue, Commentf("err %v", err))
+	tk.MustExec("drop user complexity_user")
+}
+
+func (s *testSuite3) TestPasswordHistory(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("drop user if exists history_user")
+	tk.MustExec("create user history_user identified by 'pwd1'")
+	tk.MustQuery("select password_expired, password_last_changed is not null from mysql.user where user = 'history_user'").Check(testkit.Rows("N 1"))
+
+	// The passwords can't be reused until the 2 more recent passwords are set.
+	tk.MustExec("alter user history_user password history 2")
+	tk.MustQuery("select Password_reuse_history, Password_reuse_time from mysql.user where user = 'history_user'").Check(testkit.Rows("2 <nil>"))
+	tk.MustExec("alter user history_user identified by 'pwd2'")
+	tk.MustExec("alter user history_user identified by 'pwd3'")
+	for _, pwd := range []string{"pwd2", "pwd3"} {
+		err := tk.ExecToErr(fmt.Sprintf("alter user history_user identified by '%s'", pwd))
+		c.Assert(terror.ErrorEqual(err, executor.ErrCredentialsContradictToHistory), IsTrue, Commentf("pwd %s err %v", pwd, err))
+	}
+	err := tk.ExecToErr(fmt.Sprintf("alter user history_user identified by password '%s'", auth.EncodePassword("pwd3")))
+	c.Assert(terror.ErrorEqual(err, executor.ErrCredentialsContradictToHistory), IsTrue, Commentf("err %v", err))
+	tk.MustExec("set password for history_user = 'pwd1'")
+	tk.MustQuery("select count(*) from mysql.password_history where user = 'history_user'").Check(testkit.Rows("2"))
+	tk.MustExec("set password for history_user = 'pwd2'")
+
+	// PASSWORD EXPIRE expires the password, and it's reset by the password change.
+	tk.MustExec("alter user history_user password expire")
+	tk.MustQuery("select password_expired from mysql.user where user = 'history_user'").Check(testkit.Rows("Y"))
+	tk.MustExec("alter user history_user identified by 'pwd4'")
+	tk.MustQuery("select password_expired from mysql.user where user = 'history_user'").Check(testkit.Rows("N"))
+
+	// The reuse policy of the statement applies to the password change of the same statement.
+	tk.MustExec("alter user history_user password history default")
+	tk.MustQuery("select Password_reuse_history, Password_reuse_time from mysql.user where user = 'history_user'").Check(testkit.Rows("<nil> <nil>"))
+	err = tk.ExecToErr("alter user history_user identified by 'pwd2' password reuse interval 30 day")
+	c.Assert(terror.ErrorEqual(err, executor.ErrCredentialsContradictToHistory), IsTrue, Commentf("err %v", err))
+	tk.MustExec("alter user history_user identified by 'pwd2' password reuse interval default")
+	tk.MustExec("drop user history_user")
+
+	// The password of a new user is recorded if the user has its own reuse policy.
+	tk.MustExec("create user history_user identified by 'pwd1' password history 1")
+	tk.MustQuery("select count(*) from mysql.password_history where user = 'history_user'").Check(testkit.Rows("1"))
+	err = tk.ExecToErr("alter user history_user identified by 'pwd1'")
+	c.Assert(terror.ErrorEqual(err, executor.ErrCredentialsContradictToHistory), IsTrue, Commentf("err %v", err))
+	tk.MustExec("drop user history_user")
+}
+
 func (s *testSuite3) TestKillStmt(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use test")
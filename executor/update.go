@@ -60,6 +60,11 @@ type UpdateExec struct {
 
 	stats *runtimeStatsWithSnapshot
 
+	// fkTriggers are the foreign key checks and referential actions of the updated tables, keyed by table ID.
+	fkTriggers map[int64]*fkTriggerExec
+	// checkConstraints are the check constraints of the updated tables, keyed by table ID.
+	checkConstraints map[int64]*checkConstraintExec
+
 	handles        []kv.Handle
 	tableUpdatable []bool
 	changed        []bool
@@ -186,14 +191,21 @@ func (e *UpdateExec) exec(ctx context.Context, schema *expression.Schema, row, n
 		flags := bAssignFlag[content.Start:content.End]
 
 		// Update row
-		changed, err1 := updateRecord(ctx, e.ctx, handle, oldData, newTableData, flags, tbl, false, e.memTracker)
+		var changed bool
+		ignored, err1 := runIgnorableFKTriggers(e.ctx, e.fkTriggers[content.TblID], func() (err error) {
+			changed, err = updateRecord(ctx, e.ctx, handle, oldData, newTableData, flags, tbl, false, e.checkConstraints[content.TblID], e.fkTriggers[content.TblID], e.memTracker)
+			return err
+		})
+		if ignored {
+			continue
+		}
 		if err1 == nil {
 			e.updatedRowKeys[content.Start].Set(handle, changed)
 			continue
 		}
 
 		sc := e.ctx.GetSessionVars().StmtCtx
-		if kv.ErrKeyExists.Equal(err1) && sc.DupKeyAsWarning {
+		if (kv.ErrKeyExists.Equal(err1) || ErrCheckConstraintViolated.Equal(err1)) && sc.DupKeyAsWarning {
 			sc.AppendWarning(err1)
 			continue
 		}
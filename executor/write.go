@@ -45,12 +45,13 @@ var (
 
 // updateRecord updates the row specified by the handle `h`, from `oldData` to `newData`.
 // `modified` means which columns are really modified. It's used for secondary indices.
+// `checkConstraints` are the check constraints of the table, it can be nil.
 // Length of `oldData` and `newData` equals to length of `t.WritableCols()`.
 // The return values:
 //     1. changed (bool) : does the update really change the row values. e.g. update set i = 1 where i = 1;
 //     2. err (error) : error in the update.
 func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, oldData, newData []types.Datum, modified []bool, t table.Table,
-	onDup bool, memTracker *memory.Tracker) (bool, error) {
+	onDup bool, checkConstraints *checkConstraintExec, fkTriggers *fkTriggerExec, memTracker *memory.Tracker) (bool, error) {
 	if span := opentracing.SpanFromContext(ctx); span != nil && span.Tracer() != nil {
 		span1 := span.Tracer().StartSpan("executor.updateRecord", opentracing.ChildOf(span.Context()))
 		defer span1.Finish()
@@ -171,7 +172,18 @@ func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, old
 		}
 	}
 
-	// 5. If handle changed, remove the old then add the new record, otherwise update the record.
+	// 5. Check the new record with the check constraints. If the errors are ignored, e.g. UPDATE IGNORE, the foreign
+	// key constraints are checked here too, so that the row failing them is skipped before it's written.
+	if err = checkConstraints.check(newData); err != nil {
+		return false, err
+	}
+	if sc.DupKeyAsWarning {
+		if err = fkTriggers.checkUpdateRow(ctx, oldData, newData); err != nil {
+			return false, err
+		}
+	}
+
+	// 6. If handle changed, remove the old then add the new record, otherwise update the record.
 	if handleChanged {
 		// For `UPDATE IGNORE`/`INSERT IGNORE ON DUPLICATE KEY UPDATE`
 		// we use the staging buffer so that we don't need to precheck the existence of handle or unique keys by sending
@@ -211,6 +223,16 @@ func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, old
 		}
 
 	}
+
+	// 7. Execute the referential actions of the foreign keys, and check the constraints if they're not checked yet.
+	if sc.DupKeyAsWarning {
+		err = fkTriggers.cascadeUpdateRow(ctx, oldData, newData)
+	} else {
+		err = fkTriggers.onUpdateRow(ctx, oldData, newData)
+	}
+	if err != nil {
+		return false, err
+	}
 	if onDup {
 		sc.AddAffectedRows(2)
 	} else {
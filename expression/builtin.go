@@ -39,6 +39,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tipb/go-tipb"
@@ -813,6 +814,10 @@ var funcs = map[string]functionClass{
 	ast.IsFalsity:          &isTrueOrFalseFunctionClass{baseFunctionClass{ast.IsFalsity, 1, 1}, opcode.IsFalsity, false},
 	ast.Like:               &likeFunctionClass{baseFunctionClass{ast.Like, 3, 3}},
 	ast.Regexp:             &regexpFunctionClass{baseFunctionClass{ast.Regexp, 2, 2}},
+	ast.RegexpLike:         &regexpLikeFunctionClass{baseFunctionClass{ast.RegexpLike, 2, 3}},
+	ast.RegexpInStr:        &regexpInStrFunctionClass{baseFunctionClass{ast.RegexpInStr, 2, 6}},
+	ast.RegexpSubstr:       &regexpSubstrFunctionClass{baseFunctionClass{ast.RegexpSubstr, 2, 5}},
+	ast.RegexpReplace:      &regexpReplaceFunctionClass{baseFunctionClass{ast.RegexpReplace, 3, 6}},
 	ast.Case:               &caseWhenFunctionClass{baseFunctionClass{ast.Case, 1, -1}},
 	ast.RowFunc:            &rowFunctionClass{baseFunctionClass{ast.RowFunc, 2, -1}},
 	ast.SetVar:             &setVarFunctionClass{baseFunctionClass{ast.SetVar, 2, 2}},
@@ -852,6 +857,8 @@ var funcs = map[string]functionClass{
 	ast.JSONArray:         &jsonArrayFunctionClass{baseFunctionClass{ast.JSONArray, 0, -1}},
 	ast.JSONContains:      &jsonContainsFunctionClass{baseFunctionClass{ast.JSONContains, 2, 3}},
 	ast.JSONContainsPath:  &jsonContainsPathFunctionClass{baseFunctionClass{ast.JSONContainsPath, 3, -1}},
+	ast.JSONOverlaps:      &jsonOverlapsFunctionClass{baseFunctionClass{ast.JSONOverlaps, 2, 2}},
+	ast.JSONMemberOf:      &jsonMemberOfFunctionClass{baseFunctionClass{ast.JSONMemberOf, 2, 2}},
 	ast.JSONValid:         &jsonValidFunctionClass{baseFunctionClass{ast.JSONValid, 1, 1}},
 	ast.JSONArrayAppend:   &jsonArrayAppendFunctionClass{baseFunctionClass{ast.JSONArrayAppend, 3, -1}},
 	ast.JSONArrayInsert:   &jsonArrayInsertFunctionClass{baseFunctionClass{ast.JSONArrayInsert, 3, -1}},
@@ -865,6 +872,27 @@ var funcs = map[string]functionClass{
 	ast.JSONKeys:          &jsonKeysFunctionClass{baseFunctionClass{ast.JSONKeys, 1, 2}},
 	ast.JSONLength:        &jsonLengthFunctionClass{baseFunctionClass{ast.JSONLength, 1, 2}},
 
+	// spatial functions
+	STGeomFromText:     &stGeomFromTextFunctionClass{baseFunctionClass{STGeomFromText, 1, 2}},
+	STGeometryFromText: &stGeomFromTextFunctionClass{baseFunctionClass{STGeometryFromText, 1, 2}},
+	STGeomFromWKB:      &stGeomFromWKBFunctionClass{baseFunctionClass{STGeomFromWKB, 1, 2}},
+	STGeometryFromWKB:  &stGeomFromWKBFunctionClass{baseFunctionClass{STGeometryFromWKB, 1, 2}},
+	Point:              &pointFunctionClass{baseFunctionClass{Point, 2, 2}},
+	STAsText:           &stAsTextFunctionClass{baseFunctionClass{STAsText, 1, 1}},
+	STAsWKT:            &stAsTextFunctionClass{baseFunctionClass{STAsWKT, 1, 1}},
+	STAsBinary:         &stAsBinaryFunctionClass{baseFunctionClass{STAsBinary, 1, 1}},
+	STAsWKB:            &stAsBinaryFunctionClass{baseFunctionClass{STAsWKB, 1, 1}},
+	STSRID:             &stSRIDFunctionClass{baseFunctionClass{STSRID, 1, 1}},
+	STGeometryType:     &stGeometryTypeFunctionClass{baseFunctionClass{STGeometryType, 1, 1}},
+	STX:                &stCoordinateFunctionClass{baseFunctionClass{STX, 1, 1}},
+	STY:                &stCoordinateFunctionClass{baseFunctionClass{STY, 1, 1}},
+	STDistance:         &stDistanceFunctionClass{baseFunctionClass{STDistance, 2, 2}},
+	STContains:         &stRelationFunctionClass{baseFunctionClass{STContains, 2, 2}, spatial.Contains},
+	STWithin:           &stRelationFunctionClass{baseFunctionClass{STWithin, 2, 2}, spatial.Within},
+	STIntersects:       &stRelationFunctionClass{baseFunctionClass{STIntersects, 2, 2}, spatial.Intersects},
+	STDisjoint:         &stRelationFunctionClass{baseFunctionClass{STDisjoint, 2, 2}, spatial.Disjoint},
+	STEquals:           &stRelationFunctionClass{baseFunctionClass{STEquals, 2, 2}, spatial.Equals},
+
 	// TiDB internal function.
 	ast.TiDBDecodeKey: &tidbDecodeKeyFunctionClass{baseFunctionClass{ast.TiDBDecodeKey, 1, 1}},
 	// This function is used to show tidb-server version info.
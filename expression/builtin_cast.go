@@ -48,6 +48,7 @@ var (
 	_ functionClass = &castAsTimeFunctionClass{}
 	_ functionClass = &castAsDurationFunctionClass{}
 	_ functionClass = &castAsJSONFunctionClass{}
+	_ functionClass = &castJSONAsArrayFunctionClass{}
 )
 
 var (
@@ -106,8 +107,30 @@ var (
 	_ builtinFunc = &builtinCastJSONAsTimeSig{}
 	_ builtinFunc = &builtinCastJSONAsDurationSig{}
 	_ builtinFunc = &builtinCastJSONAsJSONSig{}
+	_ builtinFunc = &builtinCastJSONAsArraySig{}
 )
 
+// castJSONAsArrayFunctionClass is `CAST(json AS type ARRAY)`, which is only allowed as the key part of a
+// multi-valued index. The elements are converted to the element type when they are written to the index.
+type castJSONAsArrayFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castJSONAsArrayFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFunc(ctx, c.funcName, args, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp = c.tp
+	sig = &builtinCastJSONAsArraySig{bf}
+	return sig, nil
+}
+
 type castAsIntFunctionClass struct {
 	baseFunctionClass
 
@@ -1625,6 +1648,28 @@ func (b *builtinCastJSONAsJSONSig) evalJSON(row chunk.Row) (res json.BinaryJSON,
 	return b.args[0].EvalJSON(b.ctx, row)
 }
 
+type builtinCastJSONAsArraySig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastJSONAsArraySig) Clone() builtinFunc {
+	newSig := &builtinCastJSONAsArraySig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalJSON returns the value as a JSON array, a non-array is regarded as an array with a single element.
+func (b *builtinCastJSONAsArraySig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if val.TypeCode == json.TypeCodeArray {
+		return val, false, nil
+	}
+	return json.CreateBinaryArray(val.ArrayElems()), false, nil
+}
+
 type builtinCastJSONAsIntSig struct {
 	baseBuiltinCastFunc
 }
@@ -1834,7 +1879,11 @@ func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldT
 	case types.ETDuration:
 		fc = &castAsDurationFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
 	case types.ETJson:
-		fc = &castAsJSONFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		if tp.Array {
+			fc = &castJSONAsArrayFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		} else {
+			fc = &castAsJSONFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		}
 	case types.ETString:
 		fc = &castAsStringFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
 	}
@@ -57,6 +57,8 @@ var (
 	_ functionClass = &jsonDepthFunctionClass{}
 	_ functionClass = &jsonKeysFunctionClass{}
 	_ functionClass = &jsonLengthFunctionClass{}
+	_ functionClass = &jsonOverlapsFunctionClass{}
+	_ functionClass = &jsonMemberOfFunctionClass{}
 
 	_ builtinFunc = &builtinJSONTypeSig{}
 	_ builtinFunc = &builtinJSONQuoteSig{}
@@ -81,6 +83,8 @@ var (
 	_ builtinFunc = &builtinJSONValidJSONSig{}
 	_ builtinFunc = &builtinJSONValidStringSig{}
 	_ builtinFunc = &builtinJSONValidOthersSig{}
+	_ builtinFunc = &builtinJSONOverlapsSig{}
+	_ builtinFunc = &builtinJSONMemberOfSig{}
 )
 
 type jsonTypeFunctionClass struct {
@@ -1543,3 +1547,109 @@ func (b *builtinJSONLengthSig) evalInt(row chunk.Row) (res int64, isNull bool, e
 	}
 	return int64(obj.GetElemCount()), false, nil
 }
+
+type jsonOverlapsFunctionClass struct {
+	baseFunctionClass
+}
+
+type builtinJSONOverlapsSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinJSONOverlapsSig) Clone() builtinFunc {
+	newSig := &builtinJSONOverlapsSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (c *jsonOverlapsFunctionClass) verifyArgs(args []Expression) error {
+	if err := c.baseFunctionClass.verifyArgs(args); err != nil {
+		return err
+	}
+	for i, arg := range args {
+		if evalType := arg.GetType().EvalType(); evalType != types.ETJson && evalType != types.ETString {
+			return json.ErrInvalidJSONData.GenWithStackByArgs(i+1, "json_overlaps")
+		}
+	}
+	return nil
+}
+
+func (c *jsonOverlapsFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETJson, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = 1
+	sig := &builtinJSONOverlapsSig{bf}
+	return sig, nil
+}
+
+// evalInt evals a builtinJSONOverlapsSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-overlaps
+func (b *builtinJSONOverlapsSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	left, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	right, isNull, err := b.args[1].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return boolToInt64(json.OverlapsBinary(left, right)), false, nil
+}
+
+type jsonMemberOfFunctionClass struct {
+	baseFunctionClass
+}
+
+type builtinJSONMemberOfSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinJSONMemberOfSig) Clone() builtinFunc {
+	newSig := &builtinJSONMemberOfSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (c *jsonMemberOfFunctionClass) verifyArgs(args []Expression) error {
+	if err := c.baseFunctionClass.verifyArgs(args); err != nil {
+		return err
+	}
+	if evalType := args[1].GetType().EvalType(); evalType != types.ETJson && evalType != types.ETString {
+		return json.ErrInvalidJSONData.GenWithStackByArgs(2, "member of")
+	}
+	return nil
+}
+
+func (c *jsonMemberOfFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETJson, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	// The value is a JSON scalar unless it's a JSON, e.g. the string 'ab' is the JSON string "ab".
+	DisableParseJSONFlag4Expr(args[0])
+	bf.tp.Flen = 1
+	sig := &builtinJSONMemberOfSig{bf}
+	return sig, nil
+}
+
+// evalInt evals `value MEMBER OF(json_array)`.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#operator_member-of
+func (b *builtinJSONMemberOfSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	target, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	obj, isNull, err := b.args[1].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return boolToInt64(json.MemberOfBinary(target, obj)), false, nil
+}
@@ -1168,3 +1168,77 @@ func (s *testEvaluatorSuite) TestJSONMergePatch(c *C) {
 		}
 	}
 }
+
+func (s *testEvaluatorSuite) TestJSONOverlaps(c *C) {
+	fc := funcs[ast.JSONOverlaps]
+	tbl := []struct {
+		input    []interface{}
+		expected interface{}
+		err      error
+	}{
+		{[]interface{}{nil, `[1]`}, nil, nil},
+		{[]interface{}{`[1]`, nil}, nil, nil},
+		{[]interface{}{`[1,3,5,7]`, `[2,5,7]`}, 1, nil},
+		{[]interface{}{`[1,3,5,7]`, `[2,6,8]`}, 0, nil},
+		{[]interface{}{`[[1,2],[3,4],5]`, `[1,[2,3],[4,5]]`}, 0, nil},
+		{[]interface{}{`[1,2]`, `2`}, 1, nil},
+		{[]interface{}{`{"a":1,"b":10,"d":10}`, `{"c":1,"e":10,"f":1,"d":10}`}, 1, nil},
+		{[]interface{}{`{"a":1,"b":10,"d":10}`, `{"a":5,"e":10,"f":1,"d":20}`}, 0, nil},
+		{[]interface{}{`5`, `5`}, 1, nil},
+		{[]interface{}{`[1,2]`, `a:1`}, nil, json.ErrInvalidJSONText},
+	}
+	for _, t := range tbl {
+		f, err := fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.input...)))
+		c.Assert(err, IsNil)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		if t.err != nil {
+			c.Assert(t.err.(*terror.Error).Equal(err), IsTrue)
+			continue
+		}
+		c.Assert(err, IsNil)
+		if t.expected == nil {
+			c.Assert(d.IsNull(), IsTrue)
+		} else {
+			c.Assert(d.GetInt64(), Equals, int64(t.expected.(int)))
+		}
+	}
+	_, err := fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(1, `[1]`)))
+	c.Assert(json.ErrInvalidJSONData.Equal(err), IsTrue)
+}
+
+func (s *testEvaluatorSuite) TestJSONMemberOf(c *C) {
+	fc := funcs[ast.JSONMemberOf]
+	tbl := []struct {
+		input    []interface{}
+		expected interface{}
+		err      error
+	}{
+		{[]interface{}{nil, `[1]`}, nil, nil},
+		{[]interface{}{1, nil}, nil, nil},
+		{[]interface{}{17, `[23, "abc", 17, "ab", 10]`}, 1, nil},
+		{[]interface{}{"ab", `[23, "abc", 17, "ab", 10]`}, 1, nil},
+		{[]interface{}{"a", `[23, "abc", 17, "ab", 10]`}, 0, nil},
+		// The string value is a JSON string rather than a JSON text.
+		{[]interface{}{"17", `[23, "abc", 17, "ab", 10]`}, 0, nil},
+		{[]interface{}{1.5, `[1.5]`}, 1, nil},
+		{[]interface{}{1, `1`}, 1, nil},
+		{[]interface{}{1, `a:1`}, nil, json.ErrInvalidJSONText},
+	}
+	for _, t := range tbl {
+		f, err := fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.input...)))
+		c.Assert(err, IsNil)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		if t.err != nil {
+			c.Assert(t.err.(*terror.Error).Equal(err), IsTrue)
+			continue
+		}
+		c.Assert(err, IsNil, Commentf("%v", t.input))
+		if t.expected == nil {
+			c.Assert(d.IsNull(), IsTrue)
+		} else {
+			c.Assert(d.GetInt64(), Equals, int64(t.expected.(int)), Commentf("%v", t.input))
+		}
+	}
+	_, err := fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(1, 1)))
+	c.Assert(json.ErrInvalidJSONData.Equal(err), IsTrue)
+}
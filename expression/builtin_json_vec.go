@@ -1266,3 +1266,73 @@ func (b *builtinJSONMergePatchSig) vecEvalJSON(input *chunk.Chunk, result *chunk
 
 	return nil
 }
+
+func (b *builtinJSONOverlapsSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONOverlapsSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	nr := input.NumRows()
+	leftCol, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(leftCol)
+	if err := b.args[0].VecEvalJSON(b.ctx, input, leftCol); err != nil {
+		return err
+	}
+	rightCol, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(rightCol)
+	if err := b.args[1].VecEvalJSON(b.ctx, input, rightCol); err != nil {
+		return err
+	}
+
+	result.ResizeInt64(nr, false)
+	result.MergeNulls(leftCol, rightCol)
+	resI64s := result.Int64s()
+	for i := 0; i < nr; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		resI64s[i] = boolToInt64(json.OverlapsBinary(leftCol.GetJSON(i), rightCol.GetJSON(i)))
+	}
+	return nil
+}
+
+func (b *builtinJSONMemberOfSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONMemberOfSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	nr := input.NumRows()
+	targetCol, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(targetCol)
+	if err := b.args[0].VecEvalJSON(b.ctx, input, targetCol); err != nil {
+		return err
+	}
+	objCol, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(objCol)
+	if err := b.args[1].VecEvalJSON(b.ctx, input, objCol); err != nil {
+		return err
+	}
+
+	result.ResizeInt64(nr, false)
+	result.MergeNulls(targetCol, objCol)
+	resI64s := result.Int64s()
+	for i := 0; i < nr; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		resI64s[i] = boolToInt64(json.MemberOfBinary(targetCol.GetJSON(i), objCol.GetJSON(i)))
+	}
+	return nil
+}
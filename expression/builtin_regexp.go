@@ -0,0 +1,562 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pingcap/parser/charset"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ functionClass = &regexpLikeFunctionClass{}
+	_ functionClass = &regexpInStrFunctionClass{}
+	_ functionClass = &regexpSubstrFunctionClass{}
+	_ functionClass = &regexpReplaceFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinRegexpLikeSig{}
+	_ builtinFunc = &builtinRegexpInStrSig{}
+	_ builtinFunc = &builtinRegexpSubstrSig{}
+	_ builtinFunc = &builtinRegexpReplaceSig{}
+)
+
+// The messages of ErrRegexp returned by the regular expression functions.
+const (
+	regexpEmptyPattern        = "Empty pattern is invalid"
+	regexpInvalidMatchType    = "Invalid match type"
+	regexpIndexOutOfBounds    = "Index out of bounds in regular expression search"
+	regexpInvalidReturnOption = "return_option must be 1 or 0"
+)
+
+// regexpMaxArgs is the max number of the arguments of the regular expression functions.
+const regexpMaxArgs = 6
+
+// regexpArgIdx is the indexes of the optional arguments, an index is -1 if the function doesn't have the argument.
+type regexpArgIdx struct {
+	repl         int
+	pos          int
+	occurrence   int
+	returnOption int
+	matchType    int
+}
+
+// regexpArgs holds the evaluated arguments of a row, the i-th argument is in strs[i] or ints[i] by its type.
+type regexpArgs struct {
+	strs [regexpMaxArgs]string
+	ints [regexpMaxArgs]int64
+}
+
+// regexpParams is the parameters of a regular expression function, the absent arguments are set to their defaults.
+type regexpParams struct {
+	expr         string
+	pattern      string
+	repl         string
+	matchType    string
+	pos          int64
+	occurrence   int64
+	returnOption int64
+}
+
+type regexpBaseFuncSig struct {
+	baseBuiltinFunc
+	argIdx regexpArgIdx
+	// memorizedRegexp is compiled only once if the pattern and the match type are constants.
+	memorizedRegexp *regexp.Regexp
+	memorizedErr    error
+}
+
+func newRegexpBaseFuncSig(bf baseBuiltinFunc, argIdx regexpArgIdx) regexpBaseFuncSig {
+	return regexpBaseFuncSig{baseBuiltinFunc: bf, argIdx: argIdx}
+}
+
+func (re *regexpBaseFuncSig) clone(from *regexpBaseFuncSig) {
+	re.cloneFrom(&from.baseBuiltinFunc)
+	re.argIdx = from.argIdx
+	// A compiled regexp is safe for the concurrent use, so it's shared.
+	re.memorizedRegexp = from.memorizedRegexp
+	re.memorizedErr = from.memorizedErr
+}
+
+func (re *regexpBaseFuncSig) hasArg(idx int) bool {
+	return idx >= 0 && idx < len(re.args)
+}
+
+// isBinary returns whether the positions are counted in bytes instead of characters.
+func (re *regexpBaseFuncSig) isBinary() bool {
+	return re.collation == charset.CollationBin
+}
+
+func (re *regexpBaseFuncSig) canMemorize() bool {
+	sc := re.ctx.GetSessionVars().StmtCtx
+	if !re.args[1].ConstItem(sc) {
+		return false
+	}
+	return !re.hasArg(re.argIdx.matchType) || re.args[re.argIdx.matchType].ConstItem(sc)
+}
+
+// buildFlags converts the match type to the flags of the Go regexp. The case sensitivity follows the collation
+// unless `c` or `i` is specified, the latter one wins if both are specified.
+func (re *regexpBaseFuncSig) buildFlags(matchType string) (string, error) {
+	caseInsensitive := collate.IsCICollation(re.collation)
+	multiLine, dotAll := false, false
+	for _, c := range matchType {
+		switch c {
+		case 'c':
+			caseInsensitive = false
+		case 'i':
+			caseInsensitive = true
+		case 'm':
+			multiLine = true
+		case 'n':
+			dotAll = true
+		case 'u':
+			// Only '\n' is recognized as the line terminator by the Go regexp, so the Unix-only line endings are
+			// always used.
+		default:
+			return "", ErrRegexp.GenWithStackByArgs(regexpInvalidMatchType)
+		}
+	}
+	var flags strings.Builder
+	if caseInsensitive {
+		flags.WriteByte('i')
+	}
+	if multiLine {
+		flags.WriteByte('m')
+	}
+	if dotAll {
+		flags.WriteByte('s')
+	}
+	if flags.Len() == 0 {
+		return "", nil
+	}
+	return "(?" + flags.String() + ")", nil
+}
+
+func (re *regexpBaseFuncSig) compile(pattern, matchType string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, ErrRegexp.GenWithStackByArgs(regexpEmptyPattern)
+	}
+	flags, err := re.buildFlags(matchType)
+	if err != nil {
+		return nil, err
+	}
+	reg, err := regexp.Compile(flags + pattern)
+	if err != nil {
+		return nil, ErrRegexp.GenWithStackByArgs(err.Error())
+	}
+	return reg, nil
+}
+
+func (re *regexpBaseFuncSig) getRegexp(pattern, matchType string) (*regexp.Regexp, error) {
+	if re.memorizedRegexp != nil || re.memorizedErr != nil {
+		return re.memorizedRegexp, re.memorizedErr
+	}
+	reg, err := re.compile(pattern, matchType)
+	if re.canMemorize() {
+		re.memorizedRegexp, re.memorizedErr = reg, err
+	}
+	return reg, err
+}
+
+// evalArgs evaluates the arguments of the row, isNull is true if any of them is NULL.
+func (re *regexpBaseFuncSig) evalArgs(row chunk.Row, args *regexpArgs) (isNull bool, err error) {
+	for i, arg := range re.args {
+		if arg.GetType().EvalType() == types.ETInt {
+			args.ints[i], isNull, err = arg.EvalInt(re.ctx, row)
+		} else {
+			args.strs[i], isNull, err = arg.EvalString(re.ctx, row)
+		}
+		if isNull || err != nil {
+			return true, err
+		}
+	}
+	return false, nil
+}
+
+func (re *regexpBaseFuncSig) params(args *regexpArgs) regexpParams {
+	p := regexpParams{
+		expr:    args.strs[0],
+		pattern: args.strs[1],
+		pos:     1,
+	}
+	if re.hasArg(re.argIdx.repl) {
+		p.repl = args.strs[re.argIdx.repl]
+	}
+	if re.hasArg(re.argIdx.pos) {
+		p.pos = args.ints[re.argIdx.pos]
+	}
+	if re.hasArg(re.argIdx.occurrence) {
+		p.occurrence = args.ints[re.argIdx.occurrence]
+	}
+	if re.hasArg(re.argIdx.returnOption) {
+		p.returnOption = args.ints[re.argIdx.returnOption]
+	}
+	if re.hasArg(re.argIdx.matchType) {
+		p.matchType = args.strs[re.argIdx.matchType]
+	}
+	return p
+}
+
+// startOffset converts the 1-based position to the byte offset in expr where the search starts.
+func (re *regexpBaseFuncSig) startOffset(expr string, pos int64) (int, error) {
+	length := int64(len(expr))
+	if !re.isBinary() {
+		length = int64(utf8.RuneCountInString(expr))
+	}
+	if pos < 1 || pos > length+1 {
+		return 0, ErrRegexp.GenWithStackByArgs(regexpIndexOutOfBounds)
+	}
+	if re.isBinary() {
+		return int(pos - 1), nil
+	}
+	offset := 0
+	for i := int64(1); i < pos; i++ {
+		_, size := utf8.DecodeRuneInString(expr[offset:])
+		offset += size
+	}
+	return offset, nil
+}
+
+// position converts the byte offset in expr to the 1-based position.
+func (re *regexpBaseFuncSig) position(expr string, offset int) int64 {
+	if re.isBinary() {
+		return int64(offset) + 1
+	}
+	return int64(utf8.RuneCountInString(expr[:offset])) + 1
+}
+
+// findMatch returns the byte offsets of the occurrence-th match in expr, nil is returned if there is no such match.
+func (re *regexpBaseFuncSig) findMatch(p *regexpParams) ([]int, error) {
+	reg, err := re.getRegexp(p.pattern, p.matchType)
+	if err != nil {
+		return nil, err
+	}
+	start, err := re.startOffset(p.expr, p.pos)
+	if err != nil {
+		return nil, err
+	}
+	occurrence := p.occurrence
+	if occurrence < 1 {
+		occurrence = 1
+	}
+	matches := reg.FindAllStringIndex(p.expr[start:], int(occurrence))
+	if int64(len(matches)) < occurrence {
+		return nil, nil
+	}
+	match := matches[occurrence-1]
+	return []int{start + match[0], start + match[1]}, nil
+}
+
+type regexpLikeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpLikeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString, types.ETString}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, argTps[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = 1
+	sig := newBuiltinRegexpLikeSig(bf)
+	if bf.collation == charset.CollationBin {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpLikeSig)
+	} else {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpLikeUTF8Sig)
+	}
+	return sig, nil
+}
+
+type builtinRegexpLikeSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpLikeSig(bf baseBuiltinFunc) *builtinRegexpLikeSig {
+	return &builtinRegexpLikeSig{newRegexpBaseFuncSig(bf, regexpArgIdx{repl: -1, pos: -1, occurrence: -1, returnOption: -1, matchType: 2})}
+}
+
+func (b *builtinRegexpLikeSig) Clone() builtinFunc {
+	newSig := &builtinRegexpLikeSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+func (b *builtinRegexpLikeSig) like(p *regexpParams) (int64, error) {
+	reg, err := b.getRegexp(p.pattern, p.matchType)
+	if err != nil {
+		return 0, err
+	}
+	return boolToInt64(reg.MatchString(p.expr)), nil
+}
+
+// evalInt evals `REGEXP_LIKE(expr, pat[, match_type])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-like
+func (b *builtinRegexpLikeSig) evalInt(row chunk.Row) (int64, bool, error) {
+	var args regexpArgs
+	if isNull, err := b.evalArgs(row, &args); isNull || err != nil {
+		return 0, true, err
+	}
+	p := b.params(&args)
+	res, err := b.like(&p)
+	return res, err != nil, err
+}
+
+type regexpInStrFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpInStrFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString, types.ETInt, types.ETInt, types.ETInt, types.ETString}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, argTps[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = mysql.MaxIntWidth
+	sig := newBuiltinRegexpInStrSig(bf)
+	if bf.collation == charset.CollationBin {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpInStrSig)
+	} else {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpInStrUTF8Sig)
+	}
+	return sig, nil
+}
+
+type builtinRegexpInStrSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpInStrSig(bf baseBuiltinFunc) *builtinRegexpInStrSig {
+	return &builtinRegexpInStrSig{newRegexpBaseFuncSig(bf, regexpArgIdx{repl: -1, pos: 2, occurrence: 3, returnOption: 4, matchType: 5})}
+}
+
+func (b *builtinRegexpInStrSig) Clone() builtinFunc {
+	newSig := &builtinRegexpInStrSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+func (b *builtinRegexpInStrSig) instr(p *regexpParams) (int64, error) {
+	if p.returnOption != 0 && p.returnOption != 1 {
+		return 0, ErrRegexp.GenWithStackByArgs(regexpInvalidReturnOption)
+	}
+	match, err := b.findMatch(p)
+	if match == nil || err != nil {
+		return 0, err
+	}
+	return b.position(p.expr, match[p.returnOption]), nil
+}
+
+// evalInt evals `REGEXP_INSTR(expr, pat[, pos[, occurrence[, return_option[, match_type]]]])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-instr
+func (b *builtinRegexpInStrSig) evalInt(row chunk.Row) (int64, bool, error) {
+	var args regexpArgs
+	if isNull, err := b.evalArgs(row, &args); isNull || err != nil {
+		return 0, true, err
+	}
+	p := b.params(&args)
+	res, err := b.instr(&p)
+	return res, err != nil, err
+}
+
+type regexpSubstrFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpSubstrFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString, types.ETInt, types.ETInt, types.ETString}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = args[0].GetType().Flen
+	SetBinFlagOrBinStr(args[0].GetType(), bf.tp)
+	sig := newBuiltinRegexpSubstrSig(bf)
+	if bf.collation == charset.CollationBin {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpSubstrSig)
+	} else {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpSubstrUTF8Sig)
+	}
+	return sig, nil
+}
+
+type builtinRegexpSubstrSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpSubstrSig(bf baseBuiltinFunc) *builtinRegexpSubstrSig {
+	return &builtinRegexpSubstrSig{newRegexpBaseFuncSig(bf, regexpArgIdx{repl: -1, pos: 2, occurrence: 3, returnOption: -1, matchType: 4})}
+}
+
+func (b *builtinRegexpSubstrSig) Clone() builtinFunc {
+	newSig := &builtinRegexpSubstrSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+func (b *builtinRegexpSubstrSig) substr(p *regexpParams) (string, bool, error) {
+	match, err := b.findMatch(p)
+	if match == nil || err != nil {
+		return "", true, err
+	}
+	return p.expr[match[0]:match[1]], false, nil
+}
+
+// evalString evals `REGEXP_SUBSTR(expr, pat[, pos[, occurrence[, match_type]]])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-substr
+func (b *builtinRegexpSubstrSig) evalString(row chunk.Row) (string, bool, error) {
+	var args regexpArgs
+	if isNull, err := b.evalArgs(row, &args); isNull || err != nil {
+		return "", true, err
+	}
+	p := b.params(&args)
+	return b.substr(&p)
+}
+
+type regexpReplaceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpReplaceFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString, types.ETString, types.ETInt, types.ETInt, types.ETString}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	// The result may be longer than expr as the replacement can be inserted at every character.
+	bf.tp.Flen = mysql.MaxBlobWidth
+	SetBinFlagOrBinStr(args[0].GetType(), bf.tp)
+	sig := newBuiltinRegexpReplaceSig(bf)
+	if bf.collation == charset.CollationBin {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpReplaceSig)
+	} else {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpReplaceUTF8Sig)
+	}
+	return sig, nil
+}
+
+type builtinRegexpReplaceSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpReplaceSig(bf baseBuiltinFunc) *builtinRegexpReplaceSig {
+	return &builtinRegexpReplaceSig{newRegexpBaseFuncSig(bf, regexpArgIdx{repl: 2, pos: 3, occurrence: 4, returnOption: -1, matchType: 5})}
+}
+
+func (b *builtinRegexpReplaceSig) Clone() builtinFunc {
+	newSig := &builtinRegexpReplaceSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// replace replaces the occurrence-th match after pos with the replacement, all the matches are replaced if
+// occurrence is 0.
+func (b *builtinRegexpReplaceSig) replace(p *regexpParams) (string, error) {
+	reg, err := b.getRegexp(p.pattern, p.matchType)
+	if err != nil {
+		return "", err
+	}
+	start, err := b.startOffset(p.expr, p.pos)
+	if err != nil {
+		return "", err
+	}
+	n := -1
+	if p.occurrence > 0 {
+		n = int(p.occurrence)
+	}
+	src := p.expr[start:]
+	matches := reg.FindAllStringSubmatchIndex(src, n)
+	if p.occurrence > 0 {
+		if int64(len(matches)) < p.occurrence {
+			return p.expr, nil
+		}
+		matches = matches[p.occurrence-1:]
+	}
+	res := make([]byte, 0, len(p.expr))
+	res = append(res, p.expr[:start]...)
+	last := 0
+	for _, match := range matches {
+		res = append(res, src[last:match[0]]...)
+		res = expandReplacement(res, p.repl, src, match)
+		last = match[1]
+	}
+	res = append(res, src[last:]...)
+	return string(res), nil
+}
+
+// evalString evals `REGEXP_REPLACE(expr, pat, repl[, pos[, occurrence[, match_type]]])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-replace
+func (b *builtinRegexpReplaceSig) evalString(row chunk.Row) (string, bool, error) {
+	var args regexpArgs
+	if isNull, err := b.evalArgs(row, &args); isNull || err != nil {
+		return "", true, err
+	}
+	p := b.params(&args)
+	res, err := b.replace(&p)
+	return res, err != nil, err
+}
+
+// expandReplacement appends the replacement of a match to dst. The same as ICU which is used by MySQL, `$n` is
+// replaced by the n-th captured group and `\` escapes the next character.
+func expandReplacement(dst []byte, repl, src string, match []int) []byte {
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+	for i := 0; i < len(repl); i++ {
+		c := repl[i]
+		switch {
+		case c == '\\' && i+1 < len(repl):
+			i++
+			dst = append(dst, repl[i])
+		case c == '$' && i+1 < len(repl) && isDigit(repl[i+1]):
+			i++
+			group := int(repl[i] - '0')
+			// The group number takes as many digits as possible while it refers to an existing group.
+			for i+1 < len(repl) && isDigit(repl[i+1]) {
+				next := group*10 + int(repl[i+1]-'0')
+				if 2*next+1 >= len(match) {
+					break
+				}
+				group = next
+				i++
+			}
+			if 2*group+1 < len(match) && match[2*group] >= 0 {
+				dst = append(dst, src[match[2*group]:match[2*group+1]]...)
+			}
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,165 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/terror"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/testutil"
+)
+
+func (s *testEvaluatorSuite) TestRegexpLike(c *C) {
+	tests := []struct {
+		args  []interface{}
+		match interface{}
+		err   error
+	}{
+		{[]interface{}{"abc", "b"}, int64(1), nil},
+		{[]interface{}{"abc", "^b"}, int64(0), nil},
+		{[]interface{}{"abc", "B"}, int64(0), nil},
+		{[]interface{}{"abc", "B", "i"}, int64(1), nil},
+		{[]interface{}{"abc", "B", "ic"}, int64(0), nil},
+		{[]interface{}{"abc", "B", "ci"}, int64(1), nil},
+		{[]interface{}{"a\nb", "^b"}, int64(0), nil},
+		{[]interface{}{"a\nb", "^b", "m"}, int64(1), nil},
+		{[]interface{}{"a\nb", "a.b"}, int64(0), nil},
+		{[]interface{}{"a\nb", "a.b", "n"}, int64(1), nil},
+		{[]interface{}{"a\nb", "a.b", "un"}, int64(1), nil},
+		{[]interface{}{"你好", "^.好$"}, int64(1), nil},
+		{[]interface{}{nil, "a"}, nil, nil},
+		{[]interface{}{"a", nil}, nil, nil},
+		{[]interface{}{"a", "a", nil}, nil, nil},
+		{[]interface{}{"a", ""}, nil, ErrRegexp},
+		{[]interface{}{"a", "("}, nil, ErrRegexp},
+		{[]interface{}{"a", "a", "x"}, nil, ErrRegexp},
+	}
+	for _, tt := range tests {
+		f, err := newFunctionForTest(s.ctx, ast.RegexpLike, s.primitiveValsToConstants(tt.args)...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		if tt.err != nil {
+			c.Assert(terror.ErrorEqual(err, tt.err), IsTrue, Commentf("%v", tt.args))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf("%v", tt.args))
+		c.Assert(d, testutil.DatumEquals, types.NewDatum(tt.match), Commentf("%v", tt.args))
+	}
+}
+
+func (s *testEvaluatorSuite) TestRegexpInStr(c *C) {
+	tests := []struct {
+		args []interface{}
+		pos  interface{}
+		err  error
+	}{
+		{[]interface{}{"dog cat dog", "dog"}, int64(1), nil},
+		{[]interface{}{"dog cat dog", "dog", 2}, int64(9), nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 2}, int64(9), nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 3}, int64(0), nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 0}, int64(1), nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 1, 1}, int64(4), nil},
+		{[]interface{}{"dog cat dog", "DOG", 1, 1, 0, "i"}, int64(1), nil},
+		{[]interface{}{"dog cat dog", "DOG", 1, 1, 0, "c"}, int64(0), nil},
+		{[]interface{}{"aa aaa aaaa", "a{4}"}, int64(8), nil},
+		{[]interface{}{"你好世界你好", "你好", 2}, int64(5), nil},
+		{[]interface{}{"你好世界你好", "你好", 2, 1, 1}, int64(7), nil},
+		{[]interface{}{"", "^$"}, int64(1), nil},
+		{[]interface{}{"abc", "c", 4}, int64(0), nil},
+		{[]interface{}{"abc", nil}, nil, nil},
+		{[]interface{}{"abc", "b", nil}, nil, nil},
+		{[]interface{}{"abc", "b", 0}, nil, ErrRegexp},
+		{[]interface{}{"abc", "b", 5}, nil, ErrRegexp},
+		{[]interface{}{"abc", "b", 1, 1, 2}, nil, ErrRegexp},
+	}
+	for _, tt := range tests {
+		f, err := newFunctionForTest(s.ctx, ast.RegexpInStr, s.primitiveValsToConstants(tt.args)...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		if tt.err != nil {
+			c.Assert(terror.ErrorEqual(err, tt.err), IsTrue, Commentf("%v", tt.args))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf("%v", tt.args))
+		c.Assert(d, testutil.DatumEquals, types.NewDatum(tt.pos), Commentf("%v", tt.args))
+	}
+}
+
+func (s *testEvaluatorSuite) TestRegexpSubstr(c *C) {
+	tests := []struct {
+		args   []interface{}
+		substr interface{}
+		err    error
+	}{
+		{[]interface{}{"abc def ghi", "[a-z]+"}, "abc", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 1, 3}, "ghi", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 6}, "ef", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 1, 4}, nil, nil},
+		{[]interface{}{"abc def ghi", "[A-Z]+", 1, 2, "i"}, "def", nil},
+		{[]interface{}{"你好世界", "世.", 2}, "世界", nil},
+		{[]interface{}{"abc", "x"}, nil, nil},
+		{[]interface{}{nil, "x"}, nil, nil},
+		{[]interface{}{"abc", "b", 1, nil}, nil, nil},
+		{[]interface{}{"abc", "b", 10}, nil, ErrRegexp},
+		{[]interface{}{"abc", "b", 1, 1, "z"}, nil, ErrRegexp},
+	}
+	for _, tt := range tests {
+		f, err := newFunctionForTest(s.ctx, ast.RegexpSubstr, s.primitiveValsToConstants(tt.args)...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		if tt.err != nil {
+			c.Assert(terror.ErrorEqual(err, tt.err), IsTrue, Commentf("%v", tt.args))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf("%v", tt.args))
+		c.Assert(d, testutil.DatumEquals, types.NewDatum(tt.substr), Commentf("%v", tt.args))
+	}
+}
+
+func (s *testEvaluatorSuite) TestRegexpReplace(c *C) {
+	tests := []struct {
+		args     []interface{}
+		replaced interface{}
+		err      error
+	}{
+		{[]interface{}{"a b c", "b", "X"}, "a X c", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X"}, "X X X", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 1, 2}, "abc X ghi", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 1, 4}, "abc def ghi", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 2}, "aX X X", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 5, 1}, "abc X ghi", nil},
+		{[]interface{}{"abc def ghi", "[A-Z]+", "X", 1, 0, "i"}, "X X X", nil},
+		{[]interface{}{"abc def", "([a-z]+) ([a-z]+)", "$2 $1"}, "def abc", nil},
+		{[]interface{}{"abc def", "([a-z]+) ([a-z]+)", `\$2 $12`}, "$2 abc2", nil},
+		{[]interface{}{"abc def", "([a-z]+) ([a-z]+)", "$3"}, "", nil},
+		{[]interface{}{"你好世界", "好.", "们"}, "你们界", nil},
+		{[]interface{}{"abc", "", "X"}, nil, ErrRegexp},
+		{[]interface{}{"abc", "b", nil}, nil, nil},
+		{[]interface{}{"abc", "b", "X", 0}, nil, ErrRegexp},
+	}
+	for _, tt := range tests {
+		f, err := newFunctionForTest(s.ctx, ast.RegexpReplace, s.primitiveValsToConstants(tt.args)...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		if tt.err != nil {
+			c.Assert(terror.ErrorEqual(err, tt.err), IsTrue, Commentf("%v", tt.args))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf("%v", tt.args))
+		c.Assert(d, testutil.DatumEquals, types.NewDatum(tt.replaced), Commentf("%v", tt.args))
+	}
+}
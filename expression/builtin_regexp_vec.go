@@ -0,0 +1,182 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// vecEvalArgs evaluates all the arguments, the buffers must be released by putBufs.
+func (re *regexpBaseFuncSig) vecEvalArgs(input *chunk.Chunk) ([]*chunk.Column, error) {
+	bufs := make([]*chunk.Column, 0, len(re.args))
+	for _, arg := range re.args {
+		buf, err := re.bufAllocator.get()
+		if err != nil {
+			re.putBufs(bufs)
+			return nil, err
+		}
+		bufs = append(bufs, buf)
+		if arg.GetType().EvalType() == types.ETInt {
+			err = arg.VecEvalInt(re.ctx, input, buf)
+		} else {
+			err = arg.VecEvalString(re.ctx, input, buf)
+		}
+		if err != nil {
+			re.putBufs(bufs)
+			return nil, err
+		}
+	}
+	return bufs, nil
+}
+
+func (re *regexpBaseFuncSig) putBufs(bufs []*chunk.Column) {
+	for _, buf := range bufs {
+		re.bufAllocator.put(buf)
+	}
+}
+
+// rowArgs reads the arguments of the i-th row from the buffers, isNull is true if any of them is NULL.
+func (re *regexpBaseFuncSig) rowArgs(bufs []*chunk.Column, i int, args *regexpArgs) (isNull bool) {
+	for j, buf := range bufs {
+		if buf.IsNull(i) {
+			return true
+		}
+		if re.args[j].GetType().EvalType() == types.ETInt {
+			args.ints[j] = buf.GetInt64(i)
+		} else {
+			args.strs[j] = buf.GetString(i)
+		}
+	}
+	return false
+}
+
+func (b *builtinRegexpLikeSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpLikeSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs...)
+	i64s := result.Int64s()
+	var args regexpArgs
+	for i := 0; i < n; i++ {
+		if b.rowArgs(bufs, i, &args) {
+			continue
+		}
+		p := b.params(&args)
+		if i64s[i], err = b.like(&p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builtinRegexpInStrSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpInStrSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs...)
+	i64s := result.Int64s()
+	var args regexpArgs
+	for i := 0; i < n; i++ {
+		if b.rowArgs(bufs, i, &args) {
+			continue
+		}
+		p := b.params(&args)
+		if i64s[i], err = b.instr(&p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builtinRegexpSubstrSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpSubstrSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ReserveString(n)
+	var args regexpArgs
+	for i := 0; i < n; i++ {
+		if b.rowArgs(bufs, i, &args) {
+			result.AppendNull()
+			continue
+		}
+		p := b.params(&args)
+		res, isNull, err := b.substr(&p)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+func (b *builtinRegexpReplaceSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpReplaceSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ReserveString(n)
+	var args regexpArgs
+	for i := 0; i < n; i++ {
+		if b.rowArgs(bufs, i, &args) {
+			result.AppendNull()
+			continue
+		}
+		p := b.params(&args)
+		res, err := b.replace(&p)
+		if err != nil {
+			return err
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
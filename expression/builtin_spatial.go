@@ -0,0 +1,580 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/spatial"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/hack"
+)
+
+// The names of the spatial functions of MySQL 8.0, the parser doesn't define them. The geometry values are binary
+// strings in the storage format of MySQL, see spatial.Encode.
+const (
+	STGeomFromText     = "st_geomfromtext"
+	STGeometryFromText = "st_geometryfromtext"
+	STGeomFromWKB      = "st_geomfromwkb"
+	STGeometryFromWKB  = "st_geometryfromwkb"
+	Point              = "point"
+	STAsText           = "st_astext"
+	STAsWKT            = "st_aswkt"
+	STAsBinary         = "st_asbinary"
+	STAsWKB            = "st_aswkb"
+	STSRID             = "st_srid"
+	STGeometryType     = "st_geometrytype"
+	STX                = "st_x"
+	STY                = "st_y"
+	STDistance         = "st_distance"
+	STContains         = "st_contains"
+	STWithin           = "st_within"
+	STIntersects       = "st_intersects"
+	STDisjoint         = "st_disjoint"
+	STEquals           = "st_equals"
+)
+
+var (
+	_ functionClass = &stGeomFromTextFunctionClass{}
+	_ functionClass = &stGeomFromWKBFunctionClass{}
+	_ functionClass = &pointFunctionClass{}
+	_ functionClass = &stAsTextFunctionClass{}
+	_ functionClass = &stAsBinaryFunctionClass{}
+	_ functionClass = &stSRIDFunctionClass{}
+	_ functionClass = &stGeometryTypeFunctionClass{}
+	_ functionClass = &stCoordinateFunctionClass{}
+	_ functionClass = &stDistanceFunctionClass{}
+	_ functionClass = &stRelationFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinSTGeomFromTextSig{}
+	_ builtinFunc = &builtinSTGeomFromWKBSig{}
+	_ builtinFunc = &builtinPointSig{}
+	_ builtinFunc = &builtinSTAsTextSig{}
+	_ builtinFunc = &builtinSTAsBinarySig{}
+	_ builtinFunc = &builtinSTSRIDSig{}
+	_ builtinFunc = &builtinSTGeometryTypeSig{}
+	_ builtinFunc = &builtinSTCoordinateSig{}
+	_ builtinFunc = &builtinSTDistanceSig{}
+	_ builtinFunc = &builtinSTRelationSig{}
+)
+
+// decodeGeometry decodes the geometry argument of the function.
+func decodeGeometry(funcName, data string) (*spatial.Geometry, error) {
+	g, err := spatial.Decode(hack.Slice(data))
+	if spatial.IsInvalidGeometry(err) {
+		return nil, spatial.ErrGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	return g, err
+}
+
+type spatialBaseFuncSig struct {
+	baseBuiltinFunc
+	// funcName is reported by ErrGISInvalidData, the synonyms such as ST_AsText and ST_AsWKT share the signatures.
+	funcName string
+}
+
+func newSpatialBaseFuncSig(bf baseBuiltinFunc, funcName string) spatialBaseFuncSig {
+	return spatialBaseFuncSig{baseBuiltinFunc: bf, funcName: funcName}
+}
+
+func (b *spatialBaseFuncSig) clone(from *spatialBaseFuncSig) {
+	b.cloneFrom(&from.baseBuiltinFunc)
+	b.funcName = from.funcName
+}
+
+// evalGeometry evaluates the i-th argument as a geometry.
+func (b *spatialBaseFuncSig) evalGeometry(i int, row chunk.Row) (*spatial.Geometry, bool, error) {
+	data, isNull, err := b.args[i].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	g, err := decodeGeometry(b.funcName, data)
+	return g, err != nil, err
+}
+
+// checkSRIDArg checks the SRID argument of the functions which create geometries.
+func checkSRIDArg(funcName string, srid int64) (uint32, error) {
+	if srid < 0 || srid > math.MaxUint32 {
+		return 0, types.ErrOverflow.GenWithStackByArgs("SRID", funcName)
+	}
+	return uint32(srid), spatial.CheckSRID(uint32(srid))
+}
+
+// newGeometryFunc creates the builtin function which returns a geometry.
+func newGeometryFunc(ctx sessionctx.Context, funcName string, args []Expression, argTps ...types.EvalType) (baseBuiltinFunc, error) {
+	bf, err := newBaseBuiltinFuncWithTp(ctx, funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return bf, err
+	}
+	bf.tp.Flen = mysql.MaxBlobWidth
+	types.SetBinChsClnFlag(bf.tp)
+	return bf, nil
+}
+
+// stGeomFromTextFunctionClass is the class of ST_GeomFromText and its synonym ST_GeometryFromText.
+type stGeomFromTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeomFromTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	if len(args) == 2 {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newGeometryFunc(ctx, c.funcName, args, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTGeomFromTextSig{newSpatialBaseFuncSig(bf, c.funcName)}
+	return sig, nil
+}
+
+type builtinSTGeomFromTextSig struct {
+	spatialBaseFuncSig
+}
+
+func (b *builtinSTGeomFromTextSig) Clone() builtinFunc {
+	newSig := &builtinSTGeomFromTextSig{}
+	newSig.clone(&b.spatialBaseFuncSig)
+	return newSig
+}
+
+// evalString evals ST_GeomFromText(wkt [, srid]).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkt-functions.html#function_st-geomfromtext
+func (b *builtinSTGeomFromTextSig) evalString(row chunk.Row) (string, bool, error) {
+	wkt, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid, isNull, err := b.evalSRIDArg(row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return geomFromText(b.funcName, wkt, srid)
+}
+
+func geomFromText(funcName, wkt string, srid uint32) (string, bool, error) {
+	g, err := spatial.ParseWKT(wkt, srid)
+	if err != nil {
+		return "", true, spatial.ErrGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	return string(spatial.Encode(g)), false, nil
+}
+
+// evalSRIDArg evaluates the optional SRID argument, which is the second one.
+func (b *spatialBaseFuncSig) evalSRIDArg(row chunk.Row) (uint32, bool, error) {
+	if len(b.args) < 2 {
+		return 0, false, nil
+	}
+	srid, isNull, err := b.args[1].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	res, err := checkSRIDArg(b.funcName, srid)
+	return res, err != nil, err
+}
+
+// stGeomFromWKBFunctionClass is the class of ST_GeomFromWKB and its synonym ST_GeometryFromWKB.
+type stGeomFromWKBFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeomFromWKBFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	if len(args) == 2 {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newGeometryFunc(ctx, c.funcName, args, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTGeomFromWKBSig{newSpatialBaseFuncSig(bf, c.funcName)}
+	return sig, nil
+}
+
+type builtinSTGeomFromWKBSig struct {
+	spatialBaseFuncSig
+}
+
+func (b *builtinSTGeomFromWKBSig) Clone() builtinFunc {
+	newSig := &builtinSTGeomFromWKBSig{}
+	newSig.clone(&b.spatialBaseFuncSig)
+	return newSig
+}
+
+// evalString evals ST_GeomFromWKB(wkb [, srid]).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkb-functions.html#function_st-geomfromwkb
+func (b *builtinSTGeomFromWKBSig) evalString(row chunk.Row) (string, bool, error) {
+	wkb, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid, isNull, err := b.evalSRIDArg(row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return geomFromWKB(b.funcName, wkb, srid)
+}
+
+func geomFromWKB(funcName, wkb string, srid uint32) (string, bool, error) {
+	g, err := spatial.ParseWKB(hack.Slice(wkb), srid)
+	if err != nil {
+		return "", true, spatial.ErrGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	return string(spatial.Encode(g)), false, nil
+}
+
+type pointFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *pointFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newGeometryFunc(ctx, c.funcName, args, types.ETReal, types.ETReal)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinPointSig{newSpatialBaseFuncSig(bf, c.funcName)}
+	return sig, nil
+}
+
+type builtinPointSig struct {
+	spatialBaseFuncSig
+}
+
+func (b *builtinPointSig) Clone() builtinFunc {
+	newSig := &builtinPointSig{}
+	newSig.clone(&b.spatialBaseFuncSig)
+	return newSig
+}
+
+// evalString evals Point(x, y).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-mysql-specific-functions.html#function_point
+func (b *builtinPointSig) evalString(row chunk.Row) (string, bool, error) {
+	x, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	y, isNull, err := b.args[1].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return encodePoint(x, y), false, nil
+}
+
+func encodePoint(x, y float64) string {
+	return string(spatial.Encode(&spatial.Geometry{Type: spatial.TypePoint, Points: []spatial.Point{{X: x, Y: y}}}))
+}
+
+// stAsTextFunctionClass is the class of ST_AsText and its synonym ST_AsWKT.
+type stAsTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = mysql.MaxBlobWidth
+	sig := &builtinSTAsTextSig{newSpatialBaseFuncSig(bf, c.funcName)}
+	return sig, nil
+}
+
+type builtinSTAsTextSig struct {
+	spatialBaseFuncSig
+}
+
+func (b *builtinSTAsTextSig) Clone() builtinFunc {
+	newSig := &builtinSTAsTextSig{}
+	newSig.clone(&b.spatialBaseFuncSig)
+	return newSig
+}
+
+// evalString evals ST_AsText(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-astext
+func (b *builtinSTAsTextSig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := b.evalGeometry(0, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.String(), false, nil
+}
+
+// stAsBinaryFunctionClass is the class of ST_AsBinary and its synonym ST_AsWKB.
+type stAsBinaryFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsBinaryFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newGeometryFunc(ctx, c.funcName, args, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTAsBinarySig{newSpatialBaseFuncSig(bf, c.funcName)}
+	return sig, nil
+}
+
+type builtinSTAsBinarySig struct {
+	spatialBaseFuncSig
+}
+
+func (b *builtinSTAsBinarySig) Clone() builtinFunc {
+	newSig := &builtinSTAsBinarySig{}
+	newSig.clone(&b.spatialBaseFuncSig)
+	return newSig
+}
+
+// evalString evals ST_AsBinary(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-asbinary
+func (b *builtinSTAsBinarySig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := b.evalGeometry(0, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return string(spatial.EncodeWKB(g)), false, nil
+}
+
+type stSRIDFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stSRIDFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = 10
+	bf.tp.Flag |= mysql.UnsignedFlag
+	sig := &builtinSTSRIDSig{newSpatialBaseFuncSig(bf, c.funcName)}
+	return sig, nil
+}
+
+type builtinSTSRIDSig struct {
+	spatialBaseFuncSig
+}
+
+func (b *builtinSTSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSRIDSig{}
+	newSig.clone(&b.spatialBaseFuncSig)
+	return newSig
+}
+
+// evalInt evals ST_SRID(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-srid
+func (b *builtinSTSRIDSig) evalInt(row chunk.Row) (int64, bool, error) {
+	g, isNull, err := b.evalGeometry(0, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return int64(g.SRID), false, nil
+}
+
+type stGeometryTypeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeometryTypeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = len(spatial.TypeMultiLineString.String())
+	sig := &builtinSTGeometryTypeSig{newSpatialBaseFuncSig(bf, c.funcName)}
+	return sig, nil
+}
+
+type builtinSTGeometryTypeSig struct {
+	spatialBaseFuncSig
+}
+
+func (b *builtinSTGeometryTypeSig) Clone() builtinFunc {
+	newSig := &builtinSTGeometryTypeSig{}
+	newSig.clone(&b.spatialBaseFuncSig)
+	return newSig
+}
+
+// evalString evals ST_GeometryType(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-geometrytype
+func (b *builtinSTGeometryTypeSig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := b.evalGeometry(0, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.Type.String(), false, nil
+}
+
+// stCoordinateFunctionClass is the class of ST_X and ST_Y.
+type stCoordinateFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stCoordinateFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTCoordinateSig{newSpatialBaseFuncSig(bf, c.funcName)}
+	return sig, nil
+}
+
+type builtinSTCoordinateSig struct {
+	spatialBaseFuncSig
+}
+
+func (b *builtinSTCoordinateSig) Clone() builtinFunc {
+	newSig := &builtinSTCoordinateSig{}
+	newSig.clone(&b.spatialBaseFuncSig)
+	return newSig
+}
+
+// evalReal evals ST_X(p) and ST_Y(p), the argument must be a Point.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-point-property-functions.html
+func (b *builtinSTCoordinateSig) evalReal(row chunk.Row) (float64, bool, error) {
+	g, isNull, err := b.evalGeometry(0, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return b.coordinate(g)
+}
+
+func (b *builtinSTCoordinateSig) coordinate(g *spatial.Geometry) (float64, bool, error) {
+	if g.Type != spatial.TypePoint {
+		return 0, true, spatial.ErrGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	if b.funcName == STX {
+		return g.Points[0].X, false, nil
+	}
+	return g.Points[0].Y, false, nil
+}
+
+type stDistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTDistanceSig{newSpatialBaseFuncSig(bf, c.funcName)}
+	return sig, nil
+}
+
+type builtinSTDistanceSig struct {
+	spatialBaseFuncSig
+}
+
+func (b *builtinSTDistanceSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSig{}
+	newSig.clone(&b.spatialBaseFuncSig)
+	return newSig
+}
+
+// evalReal evals ST_Distance(g1, g2), which is NULL if any of the geometries is empty.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html#function_st-distance
+func (b *builtinSTDistanceSig) evalReal(row chunk.Row) (float64, bool, error) {
+	g1, isNull, err := b.evalGeometry(0, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	g2, isNull, err := b.evalGeometry(1, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if g1.IsEmpty() || g2.IsEmpty() {
+		return 0, true, nil
+	}
+	return spatial.Distance(g1, g2), false, nil
+}
+
+// stRelationFunctionClass is the class of the functions which test the spatial relation of two geometries, such as
+// ST_Contains and ST_Intersects.
+type stRelationFunctionClass struct {
+	baseFunctionClass
+	relation func(g1, g2 *spatial.Geometry) bool
+}
+
+func (c *stRelationFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = 1
+	sig := &builtinSTRelationSig{newSpatialBaseFuncSig(bf, c.funcName), c.relation}
+	return sig, nil
+}
+
+type builtinSTRelationSig struct {
+	spatialBaseFuncSig
+	relation func(g1, g2 *spatial.Geometry) bool
+}
+
+func (b *builtinSTRelationSig) Clone() builtinFunc {
+	newSig := &builtinSTRelationSig{relation: b.relation}
+	newSig.clone(&b.spatialBaseFuncSig)
+	return newSig
+}
+
+// evalInt evals the relation functions, the result is NULL if any of the geometries is empty.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html
+func (b *builtinSTRelationSig) evalInt(row chunk.Row) (int64, bool, error) {
+	g1, isNull, err := b.evalGeometry(0, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	g2, isNull, err := b.evalGeometry(1, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if g1.IsEmpty() || g2.IsEmpty() {
+		return 0, true, nil
+	}
+	return boolToInt64(b.relation(g1, g2)), false, nil
+}
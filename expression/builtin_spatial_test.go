@@ -0,0 +1,176 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"encoding/hex"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/parser/terror"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/spatial"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/testutil"
+)
+
+// geomFromTextForTest returns the expression ST_GeomFromText(wkt).
+func (s *testEvaluatorSuite) geomFromTextForTest(c *C, wkt interface{}) Expression {
+	f, err := newFunctionForTest(s.ctx, STGeomFromText, s.primitiveValsToConstants([]interface{}{wkt})...)
+	c.Assert(err, IsNil)
+	return f
+}
+
+func (s *testEvaluatorSuite) TestSTGeomFromText(c *C) {
+	tests := []struct {
+		args []interface{}
+		wkt  interface{}
+		err  error
+	}{
+		{[]interface{}{"POINT(1 2)"}, "POINT(1 2)", nil},
+		{[]interface{}{"point(1 2)", 0}, "POINT(1 2)", nil},
+		{[]interface{}{"MULTIPOINT(1 1, 2 2)"}, "MULTIPOINT((1 1),(2 2))", nil},
+		{[]interface{}{"GEOMCOLLECTION()"}, "GEOMETRYCOLLECTION EMPTY", nil},
+		{[]interface{}{nil}, nil, nil},
+		{[]interface{}{"POINT(1 2)", nil}, nil, nil},
+		{[]interface{}{"POINT(1)"}, nil, spatial.ErrGISInvalidData},
+		{[]interface{}{"POLYGON((0 0,1 0,1 1,0 1))"}, nil, spatial.ErrGISInvalidData},
+		{[]interface{}{"POINT(1 2)", 4326}, nil, spatial.ErrSRSNotFound},
+		{[]interface{}{"POINT(1 2)", -1}, nil, types.ErrOverflow},
+	}
+	for _, tt := range tests {
+		for _, name := range []string{STGeomFromText, STGeometryFromText} {
+			geom, err := newFunctionForTest(s.ctx, name, s.primitiveValsToConstants(tt.args)...)
+			c.Assert(err, IsNil)
+			f, err := newFunctionForTest(s.ctx, STAsText, geom)
+			c.Assert(err, IsNil)
+			d, err := f.Eval(chunk.Row{})
+			if tt.err != nil {
+				c.Assert(terror.ErrorEqual(err, tt.err), IsTrue, Commentf("%v", tt.args))
+				continue
+			}
+			c.Assert(err, IsNil, Commentf("%v", tt.args))
+			c.Assert(d, testutil.DatumEquals, types.NewDatum(tt.wkt), Commentf("%v", tt.args))
+		}
+	}
+
+	_, err := s.geomFromTextForTest(c, "LINESTRING(0 0)").Eval(chunk.Row{})
+	c.Assert(err, ErrorMatches, ".*Invalid GIS data provided to function st_geomfromtext.*")
+}
+
+func (s *testEvaluatorSuite) TestSTGeomFromWKB(c *C) {
+	tests := []struct {
+		wkb string
+		wkt interface{}
+		err error
+	}{
+		{"0101000000000000000000f03f000000000000f0bf", "POINT(1 -1)", nil},
+		{"00000000013ff00000000000004000000000000000", "POINT(1 2)", nil},
+		{"01020000000200000000000000000000000000000000000000000000000000f03f000000000000f03f", "LINESTRING(0 0,1 1)", nil},
+		{"0101000000000000000000f03f", nil, spatial.ErrGISInvalidData},
+		{"", nil, spatial.ErrGISInvalidData},
+	}
+	for _, tt := range tests {
+		wkb, err := hex.DecodeString(tt.wkb)
+		c.Assert(err, IsNil)
+		for _, name := range []string{STGeomFromWKB, STGeometryFromWKB} {
+			geom, err := newFunctionForTest(s.ctx, name, s.primitiveValsToConstants([]interface{}{wkb})...)
+			c.Assert(err, IsNil)
+			f, err := newFunctionForTest(s.ctx, STAsText, geom)
+			c.Assert(err, IsNil)
+			d, err := f.Eval(chunk.Row{})
+			if tt.err != nil {
+				c.Assert(terror.ErrorEqual(err, tt.err), IsTrue, Commentf("%v", tt.wkb))
+				continue
+			}
+			c.Assert(err, IsNil, Commentf("%v", tt.wkb))
+			c.Assert(d, testutil.DatumEquals, types.NewDatum(tt.wkt), Commentf("%v", tt.wkb))
+		}
+	}
+}
+
+func (s *testEvaluatorSuite) TestSpatialProperties(c *C) {
+	point, err := newFunctionForTest(s.ctx, Point, s.primitiveValsToConstants([]interface{}{1.5, -2})...)
+	c.Assert(err, IsNil)
+	line := s.geomFromTextForTest(c, "LINESTRING(0 0,1 1)")
+	tests := []struct {
+		funcName string
+		arg      Expression
+		res      interface{}
+		err      error
+	}{
+		{STAsText, point, "POINT(1.5 -2)", nil},
+		{STAsWKT, line, "LINESTRING(0 0,1 1)", nil},
+		{STAsBinary, point, "\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf8\x3f\x00\x00\x00\x00\x00\x00\x00\xc0", nil},
+		{STSRID, point, int64(0), nil},
+		{STGeometryType, point, "POINT", nil},
+		{STGeometryType, s.geomFromTextForTest(c, "GEOMETRYCOLLECTION EMPTY"), "GEOMCOLLECTION", nil},
+		{STX, point, 1.5, nil},
+		{STY, point, float64(-2), nil},
+		{STX, line, nil, spatial.ErrGISInvalidData},
+		{STAsText, s.geomFromTextForTest(c, nil), nil, nil},
+		{STAsText, s.primitiveValsToConstants([]interface{}{"abc"})[0], nil, spatial.ErrGISInvalidData},
+		// The first 4 bytes are decoded as the SRID, like MySQL.
+		{STAsText, s.primitiveValsToConstants([]interface{}{"POINT(1 2)"})[0], nil, spatial.ErrSRSNotFound},
+	}
+	for _, tt := range tests {
+		f, err := newFunctionForTest(s.ctx, tt.funcName, tt.arg)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		if tt.err != nil {
+			c.Assert(terror.ErrorEqual(err, tt.err), IsTrue, Commentf("%s", tt.funcName))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf("%s", tt.funcName))
+		if tt.funcName == STAsBinary {
+			c.Assert(d.GetString(), Equals, tt.res)
+			continue
+		}
+		c.Assert(d, testutil.DatumEquals, types.NewDatum(tt.res), Commentf("%s", tt.funcName))
+	}
+}
+
+func (s *testEvaluatorSuite) TestSpatialRelations(c *C) {
+	const square = "POLYGON((0 0,10 0,10 10,0 10,0 0))"
+	tests := []struct {
+		funcName string
+		g1, g2   interface{}
+		res      interface{}
+	}{
+		{STContains, square, "POINT(5 5)", int64(1)},
+		{STContains, square, "POINT(0 5)", int64(0)},
+		{STContains, "POINT(5 5)", square, int64(0)},
+		{STWithin, "POINT(5 5)", square, int64(1)},
+		{STWithin, "LINESTRING(0 0,10 10)", square, int64(1)},
+		{STIntersects, square, "LINESTRING(5 5,20 20)", int64(1)},
+		{STIntersects, square, "POINT(11 11)", int64(0)},
+		{STDisjoint, square, "POINT(11 11)", int64(1)},
+		{STEquals, square, "POLYGON((10 10,0 10,0 0,10 0,10 10))", int64(1)},
+		{STEquals, square, "POLYGON((0 0,5 0,5 5,0 5,0 0))", int64(0)},
+		{STContains, square, "GEOMETRYCOLLECTION EMPTY", nil},
+		{STIntersects, nil, square, nil},
+		{STDistance, "POINT(0 0)", "POINT(3 4)", float64(5)},
+		{STDistance, square, "POINT(13 14)", float64(5)},
+		{STDistance, square, "POINT(1 1)", float64(0)},
+		{STDistance, "GEOMETRYCOLLECTION EMPTY", square, nil},
+		{STDistance, square, nil, nil},
+	}
+	for _, tt := range tests {
+		f, err := newFunctionForTest(s.ctx, tt.funcName, s.geomFromTextForTest(c, tt.g1), s.geomFromTextForTest(c, tt.g2))
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil, Commentf("%s(%v, %v)", tt.funcName, tt.g1, tt.g2))
+		c.Assert(d, testutil.DatumEquals, types.NewDatum(tt.res), Commentf("%s(%v, %v)", tt.funcName, tt.g1, tt.g2))
+	}
+}
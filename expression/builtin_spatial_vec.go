@@ -0,0 +1,372 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/spatial"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// vecEvalArgs evaluates all the arguments by their types, the buffers must be released by putBufs.
+func (b *spatialBaseFuncSig) vecEvalArgs(input *chunk.Chunk) ([]*chunk.Column, error) {
+	bufs := make([]*chunk.Column, 0, len(b.args))
+	for _, arg := range b.args {
+		buf, err := b.bufAllocator.get()
+		if err != nil {
+			b.putBufs(bufs)
+			return nil, err
+		}
+		bufs = append(bufs, buf)
+		switch arg.GetType().EvalType() {
+		case types.ETInt:
+			err = arg.VecEvalInt(b.ctx, input, buf)
+		case types.ETReal:
+			err = arg.VecEvalReal(b.ctx, input, buf)
+		default:
+			err = arg.VecEvalString(b.ctx, input, buf)
+		}
+		if err != nil {
+			b.putBufs(bufs)
+			return nil, err
+		}
+	}
+	return bufs, nil
+}
+
+func (b *spatialBaseFuncSig) putBufs(bufs []*chunk.Column) {
+	for _, buf := range bufs {
+		b.bufAllocator.put(buf)
+	}
+}
+
+// vecGeometries decodes the geometries of the i-th row, which are in the first len(geoms) buffers.
+func vecGeometries(funcName string, bufs []*chunk.Column, i int, geoms []*spatial.Geometry) error {
+	for j := range geoms {
+		g, err := decodeGeometry(funcName, bufs[j].GetString(i))
+		if err != nil {
+			return err
+		}
+		geoms[j] = g
+	}
+	return nil
+}
+
+// vecSRIDArg returns the optional SRID argument of the i-th row, bufs[0] is the geometry argument.
+func vecSRIDArg(funcName string, bufs []*chunk.Column, i int) (uint32, error) {
+	if len(bufs) < 2 {
+		return 0, nil
+	}
+	return checkSRIDArg(funcName, bufs[1].GetInt64(i))
+}
+
+func (b *builtinSTGeomFromTextSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTGeomFromTextSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if isAnyNull(bufs, i) {
+			result.AppendNull()
+			continue
+		}
+		srid, err := vecSRIDArg(b.funcName, bufs, i)
+		if err != nil {
+			return err
+		}
+		res, _, err := geomFromText(b.funcName, bufs[0].GetString(i), srid)
+		if err != nil {
+			return err
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+func (b *builtinSTGeomFromWKBSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTGeomFromWKBSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if isAnyNull(bufs, i) {
+			result.AppendNull()
+			continue
+		}
+		srid, err := vecSRIDArg(b.funcName, bufs, i)
+		if err != nil {
+			return err
+		}
+		res, _, err := geomFromWKB(b.funcName, bufs[0].GetString(i), srid)
+		if err != nil {
+			return err
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+func (b *builtinPointSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinPointSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	xs, ys := bufs[0].Float64s(), bufs[1].Float64s()
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if isAnyNull(bufs, i) {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(encodePoint(xs[i], ys[i]))
+	}
+	return nil
+}
+
+func (b *builtinSTAsTextSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTAsTextSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ReserveString(n)
+	geoms := make([]*spatial.Geometry, 1)
+	for i := 0; i < n; i++ {
+		if bufs[0].IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		if err := vecGeometries(b.funcName, bufs, i, geoms); err != nil {
+			return err
+		}
+		result.AppendString(geoms[0].String())
+	}
+	return nil
+}
+
+func (b *builtinSTAsBinarySig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTAsBinarySig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ReserveString(n)
+	geoms := make([]*spatial.Geometry, 1)
+	for i := 0; i < n; i++ {
+		if bufs[0].IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		if err := vecGeometries(b.funcName, bufs, i, geoms); err != nil {
+			return err
+		}
+		result.AppendBytes(spatial.EncodeWKB(geoms[0]))
+	}
+	return nil
+}
+
+func (b *builtinSTSRIDSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTSRIDSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs...)
+	i64s := result.Int64s()
+	geoms := make([]*spatial.Geometry, 1)
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		if err := vecGeometries(b.funcName, bufs, i, geoms); err != nil {
+			return err
+		}
+		i64s[i] = int64(geoms[0].SRID)
+	}
+	return nil
+}
+
+func (b *builtinSTGeometryTypeSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTGeometryTypeSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ReserveString(n)
+	geoms := make([]*spatial.Geometry, 1)
+	for i := 0; i < n; i++ {
+		if bufs[0].IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		if err := vecGeometries(b.funcName, bufs, i, geoms); err != nil {
+			return err
+		}
+		result.AppendString(geoms[0].Type.String())
+	}
+	return nil
+}
+
+func (b *builtinSTCoordinateSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTCoordinateSig) vecEvalReal(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ResizeFloat64(n, false)
+	result.MergeNulls(bufs...)
+	f64s := result.Float64s()
+	geoms := make([]*spatial.Geometry, 1)
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		if err := vecGeometries(b.funcName, bufs, i, geoms); err != nil {
+			return err
+		}
+		if f64s[i], _, err = b.coordinate(geoms[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builtinSTDistanceSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTDistanceSig) vecEvalReal(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ResizeFloat64(n, false)
+	result.MergeNulls(bufs...)
+	f64s := result.Float64s()
+	geoms := make([]*spatial.Geometry, 2)
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		if err := vecGeometries(b.funcName, bufs, i, geoms); err != nil {
+			return err
+		}
+		if geoms[0].IsEmpty() || geoms[1].IsEmpty() {
+			result.SetNull(i, true)
+			continue
+		}
+		f64s[i] = spatial.Distance(geoms[0], geoms[1])
+	}
+	return nil
+}
+
+func (b *builtinSTRelationSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTRelationSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+
+	n := input.NumRows()
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs...)
+	i64s := result.Int64s()
+	geoms := make([]*spatial.Geometry, 2)
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		if err := vecGeometries(b.funcName, bufs, i, geoms); err != nil {
+			return err
+		}
+		if geoms[0].IsEmpty() || geoms[1].IsEmpty() {
+			result.SetNull(i, true)
+			continue
+		}
+		i64s[i] = boolToInt64(b.relation(geoms[0], geoms[1]))
+	}
+	return nil
+}
+
+// isAnyNull returns whether any of the buffers is NULL at the i-th row.
+func isAnyNull(bufs []*chunk.Column, i int) bool {
+	for _, buf := range bufs {
+		if buf.IsNull(i) {
+			return true
+		}
+	}
+	return false
+}
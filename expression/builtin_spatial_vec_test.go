@@ -0,0 +1,120 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/spatial"
+)
+
+var spatialWKTs = []string{
+	"POINT(1 1)",
+	"POINT(5 5)",
+	"LINESTRING(0 0,10 10)",
+	"POLYGON((0 0,10 0,10 10,0 10,0 0))",
+	"MULTIPOINT((1 1),(20 20))",
+	"GEOMETRYCOLLECTION EMPTY",
+}
+
+func newSpatialGener(pointOnly bool) *selectStringGener {
+	var geoms []string
+	for _, wkt := range spatialWKTs {
+		g, err := spatial.ParseWKT(wkt, 0)
+		if err != nil {
+			panic(err)
+		}
+		if !pointOnly || g.Type == spatial.TypePoint {
+			geoms = append(geoms, string(spatial.Encode(g)))
+		}
+	}
+	return newSelectStringGener(geoms)
+}
+
+var (
+	wktGener        = newSelectStringGener(spatialWKTs)
+	geometryGener   = newSpatialGener(false)
+	pointGener      = newSpatialGener(true)
+	spatialRelation = []dataGenerator{geometryGener, geometryGener}
+)
+
+var vecBuiltinSpatialCases = map[string][]vecExprBenchCase{
+	STGeomFromText: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{wktGener}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt},
+			geners: []dataGenerator{wktGener, newRangeInt64Gener(0, 1)}},
+	},
+	STGeomFromWKB: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{&spatialWKBGener{geometryGener}}},
+	},
+	Point: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETReal, types.ETReal}},
+	},
+	STAsText: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{geometryGener}},
+	},
+	STAsBinary: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{geometryGener}},
+	},
+	STSRID: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{geometryGener}},
+	},
+	STGeometryType: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{geometryGener}},
+	},
+	STX: {
+		{retEvalType: types.ETReal, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{pointGener}},
+	},
+	STY: {
+		{retEvalType: types.ETReal, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{pointGener}},
+	},
+	STDistance: {
+		{retEvalType: types.ETReal, childrenTypes: []types.EvalType{types.ETString, types.ETString}, geners: spatialRelation},
+	},
+	STContains: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString}, geners: spatialRelation},
+	},
+	STWithin: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString}, geners: spatialRelation},
+	},
+	STIntersects: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString}, geners: spatialRelation},
+	},
+	STDisjoint: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString}, geners: spatialRelation},
+	},
+	STEquals: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString}, geners: spatialRelation},
+	},
+}
+
+// spatialWKBGener generates the WKB of the geometries, which strips the SRID.
+type spatialWKBGener struct {
+	geometries *selectStringGener
+}
+
+func (g *spatialWKBGener) gen() interface{} {
+	return g.geometries.gen().(string)[4:]
+}
+
+func (s *testEvaluatorSuite) TestVectorizedBuiltinSpatialFunc(c *C) {
+	testVectorizedBuiltinFunc(c, vecBuiltinSpatialCases)
+}
+
+func BenchmarkVectorizedBuiltinSpatialFunc(b *testing.B) {
+	benchmarkVectorizedBuiltinFunc(b, vecBuiltinSpatialCases)
+}
@@ -1096,6 +1096,43 @@ func (s *testEvaluatorSuite) TestExprPushDownToTiKV(c *C) {
 	c.Assert(len(remained), Equals, len(exprs))
 }
 
+func (s *testEvaluatorSuite) TestRegexpPushDown(c *C) {
+	sc := new(stmtctx.StatementContext)
+	client := new(mock.Client)
+	dg := new(dataGen4Expr2PbTest)
+	intColumn := dg.genColumn(mysql.TypeLonglong, 2)
+	stringColumn := dg.genColumn(mysql.TypeString, 5)
+	binaryStringColumn := dg.genColumn(mysql.TypeString, 7)
+	binaryStringColumn.RetType.Collate = charset.CollationBin
+
+	tests := []struct {
+		funcName string
+		retTp    byte
+		args     []Expression
+		pbCode   tipb.ScalarFuncSig
+	}{
+		{ast.RegexpLike, mysql.TypeLonglong, []Expression{stringColumn, stringColumn}, tipb.ScalarFuncSig_RegexpLikeUTF8Sig},
+		{ast.RegexpLike, mysql.TypeLonglong, []Expression{binaryStringColumn, binaryStringColumn, stringColumn}, tipb.ScalarFuncSig_RegexpLikeSig},
+		{ast.RegexpInStr, mysql.TypeLonglong, []Expression{stringColumn, stringColumn, intColumn, intColumn, intColumn, stringColumn}, tipb.ScalarFuncSig_RegexpInStrUTF8Sig},
+		{ast.RegexpInStr, mysql.TypeLonglong, []Expression{binaryStringColumn, binaryStringColumn}, tipb.ScalarFuncSig_RegexpInStrSig},
+		{ast.RegexpSubstr, mysql.TypeString, []Expression{stringColumn, stringColumn, intColumn}, tipb.ScalarFuncSig_RegexpSubstrUTF8Sig},
+		{ast.RegexpSubstr, mysql.TypeString, []Expression{binaryStringColumn, binaryStringColumn}, tipb.ScalarFuncSig_RegexpSubstrSig},
+		{ast.RegexpReplace, mysql.TypeString, []Expression{stringColumn, stringColumn, stringColumn, intColumn, intColumn}, tipb.ScalarFuncSig_RegexpReplaceUTF8Sig},
+		{ast.RegexpReplace, mysql.TypeString, []Expression{binaryStringColumn, binaryStringColumn, binaryStringColumn}, tipb.ScalarFuncSig_RegexpReplaceSig},
+	}
+	exprs := make([]Expression, 0, len(tests))
+	for _, tt := range tests {
+		function, err := NewFunction(mock.NewContext(), tt.funcName, types.NewFieldType(tt.retTp), tt.args...)
+		c.Assert(err, IsNil)
+		c.Assert(function.(*ScalarFunction).Function.PbCode(), Equals, tt.pbCode)
+		exprs = append(exprs, function)
+	}
+
+	// The regular expressions of the storage engines don't follow the semantics of MySQL, so they aren't pushed down.
+	c.Assert(CanExprsPushDown(sc, exprs, client, kv.TiKV), IsFalse)
+	c.Assert(CanExprsPushDown(sc, exprs, client, kv.TiFlash), IsFalse)
+}
+
 func (s *testEvaluatorSuite) TestExprOnlyPushDownToTiKV(c *C) {
 	sc := new(stmtctx.StatementContext)
 	client := new(mock.Client)
@@ -1224,8 +1261,10 @@ func (s *testEvaluatorSerialSuites) TestNewCollationsEnabled(c *C) {
 	colExprs = append(colExprs, columnCollation(dg.genColumn(mysql.TypeVarchar, 5), "utf8", "utf8_bin"))
 	colExprs = append(colExprs, columnCollation(dg.genColumn(mysql.TypeVarchar, 6), "utf8", "utf8_unicode_ci"))
 	colExprs = append(colExprs, columnCollation(dg.genColumn(mysql.TypeVarchar, 7), "utf8mb4", "utf8mb4_zh_pinyin_tidb_as_cs"))
-	pushed, _ := PushDownExprs(sc, colExprs, client, kv.UnSpecified)
-	c.Assert(len(pushed), Equals, len(colExprs))
+	pushed, remained := PushDownExprs(sc, colExprs, client, kv.UnSpecified)
+	// utf8mb4_0900_ai_ci is only implemented by TiDB.
+	c.Assert(len(pushed), Equals, len(colExprs)-1)
+	c.Assert(remained, DeepEquals, colExprs[3:4])
 	pbExprs, err := ExpressionsToPBList(sc, colExprs, client)
 	c.Assert(err, IsNil)
 	jsons := []string{
@@ -1251,6 +1290,38 @@ func (s *testEvaluatorSerialSuites) TestNewCollationsEnabled(c *C) {
 	c.Assert(string(js), Equals, "{\"expr\":{\"tp\":201,\"val\":\"gAAAAAAAAAA=\",\"sig\":0,\"field_type\":{\"tp\":5,\"flag\":0,\"flen\":-1,\"decimal\":-1,\"collate\":-255,\"charset\":\"utf8mb4\"},\"has_distinct\":false},\"desc\":false}")
 }
 
+func (s *testEvaluatorSerialSuites) TestTiDBOnlyCollationPushDown(c *C) {
+	sc := new(stmtctx.StatementContext)
+	client := new(mock.Client)
+	dg := new(dataGen4Expr2PbTest)
+
+	var exprs []Expression
+	for i, coll := range []string{"utf8mb4_0900_ai_ci", "utf8mb4_0900_as_cs", "utf8mb4_0900_bin"} {
+		col := columnCollation(dg.genColumn(mysql.TypeVarchar, int64(i)), "utf8mb4", coll)
+		exprs = append(exprs, col)
+		fc, err := NewFunction(mock.NewContext(), ast.EQ, types.NewFieldType(mysql.TypeLonglong), col, col)
+		c.Assert(err, IsNil)
+		exprs = append(exprs, fc)
+	}
+
+	collate.SetNewCollationEnabledForTest(true)
+	defer collate.SetNewCollationEnabledForTest(false)
+	for _, storeType := range []kv.StoreType{kv.UnSpecified, kv.TiKV, kv.TiFlash} {
+		pushed, remained := PushDownExprs(sc, exprs, client, storeType)
+		c.Assert(pushed, HasLen, 0, Commentf("%s", storeType.Name()))
+		c.Assert(remained, HasLen, len(exprs))
+	}
+	pushed, remained := PushDownExprs(sc, exprs, client, kv.TiDB)
+	c.Assert(pushed, HasLen, len(exprs))
+	c.Assert(remained, HasLen, 0)
+
+	// The strings are compared in binary without the new collations.
+	collate.SetNewCollationEnabledForTest(false)
+	pushed, remained = PushDownExprs(sc, exprs, client, kv.TiKV)
+	c.Assert(pushed, HasLen, len(exprs))
+	c.Assert(remained, HasLen, 0)
+}
+
 func (s *testEvalSerialSuite) TestPushCollationDown(c *C) {
 	collate.SetNewCollationEnabledForTest(true)
 	defer collate.SetNewCollationEnabledForTest(false)
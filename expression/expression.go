@@ -35,6 +35,7 @@ import (
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/generatedexpr"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tipb/go-tipb"
@@ -1207,6 +1208,17 @@ func canScalarFuncPushDown(scalarFunc *ScalarFunction, pc PbConverter, storeType
 }
 
 func canExprPushDown(expr Expression, pc PbConverter, storeType kv.StoreType) bool {
+	if storeType != kv.TiDB && collate.NewCollationEnabled() && types.IsString(expr.GetType().Tp) &&
+		collate.IsTiDBOnlyCollation(expr.GetType().Collate) {
+		if pc.sc.InExplainStmt {
+			storageName := storeType.Name()
+			if storeType == kv.UnSpecified {
+				storageName = "storage layer"
+			}
+			pc.sc.AppendWarning(errors.New("Expr '" + expr.String() + "' can not be pushed to " + storageName + " because it uses collation " + expr.GetType().Collate))
+		}
+		return false
+	}
 	if storeType == kv.TiFlash {
 		switch expr.GetType().Tp {
 		case mysql.TypeDuration:
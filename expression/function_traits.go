@@ -253,8 +253,14 @@ var booleanFunctions = map[string]struct{}{
 	ast.IsNull:             {},
 	ast.Like:               {},
 	ast.Regexp:             {},
+	ast.RegexpLike:         {},
 	ast.IsIPv4:             {},
 	ast.IsIPv4Compat:       {},
 	ast.IsIPv4Mapped:       {},
 	ast.IsIPv6:             {},
+	STContains:             {},
+	STWithin:               {},
+	STIntersects:           {},
+	STDisjoint:             {},
+	STEquals:               {},
 }
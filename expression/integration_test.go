@@ -48,6 +48,7 @@ import (
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/codec"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/kvcache"
@@ -5180,7 +5181,8 @@ func (s *testIntegrationSuite) TestForeignKeyVar(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 
 	tk.MustExec("SET FOREIGN_KEY_CHECKS=1")
-	tk.MustQuery("SHOW WARNINGS").Check(testkit.Rows("Warning 8047 variable 'foreign_key_checks' does not yet support value: 1"))
+	tk.MustQuery("SHOW WARNINGS").Check(testkit.Rows())
+	tk.MustQuery("SELECT @@foreign_key_checks").Check(testkit.Rows("1"))
 }
 
 func (s *testIntegrationSuite) TestUserVarMockWindFunc(c *C) {
@@ -6683,6 +6685,49 @@ func (s *testIntegrationSerialSuite) TestCollationCreateIndex(c *C) {
 	tk.MustQuery("select * from t order by a").Check(testkit.Rows("a", "A", "a", "A", "b", "B", "sa", "ß"))
 }
 
+func (s *testIntegrationSerialSuite) TestUTF8MB40900Collations(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	collate.SetNewCollationEnabledForTest(true)
+	defer collate.SetNewCollationEnabledForTest(false)
+	tk.MustExec("use test")
+	tk.MustQuery("show collation like 'utf8mb4_0900%'").Check(testkit.Rows(
+		"utf8mb4_0900_ai_ci utf8mb4 255  Yes 1",
+		"utf8mb4_0900_as_cs utf8mb4 278  Yes 1",
+		"utf8mb4_0900_bin utf8mb4 309  Yes 1",
+	))
+
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a varchar(10) collate utf8mb4_0900_ai_ci, b varchar(10) collate utf8mb4_0900_as_cs, c varchar(10) collate utf8mb4_0900_bin, key ia(a), key ib(b), key ic(c))")
+	tk.MustExec("insert into t values ('a', 'a', 'a'), ('A', 'A', 'A'), ('á', 'á', 'á'), ('a ', 'a ', 'a '), ('b', 'b', 'b'), ('ß', 'ß', 'ß'), ('ss', 'ss', 'ss')")
+	tk.MustQuery("select count(*) from t use index(ia) where a = 'a'").Check(testkit.Rows("3"))
+	tk.MustQuery("select count(*) from t ignore index(ia) where a = 'a'").Check(testkit.Rows("3"))
+	tk.MustQuery("select count(*) from t use index(ia) where a = 'ss'").Check(testkit.Rows("2"))
+	tk.MustQuery("select count(*) from t use index(ib) where b = 'a'").Check(testkit.Rows("1"))
+	tk.MustQuery("select concat(c, '|') from t use index(ic) where c = 'a '").Check(testkit.Rows("a |"))
+	tk.MustQuery("select concat(b, '|') from t order by b").Check(testkit.Rows("a|", "A|", "á|", "a |", "b|", "ss|", "ß|"))
+	tk.MustQuery("select concat(c, '|') from t use index(ic) order by c").Check(testkit.Rows("A|", "a|", "a |", "b|", "ss|", "ß|", "á|"))
+	tk.MustQuery("select count(*) from t where a like 'A%'").Check(testkit.Rows("4"))
+	// The storage engines don't implement the collations, the conditions are evaluated by TiDB.
+	tk.MustQuery("explain format = 'brief' select * from t ignore index(ia, ib, ic) where a = 'a' and b = 'a' and c = 'a'").Check(testkit.Rows(
+		`Selection 8000.00 root  eq(test.t.a, "a"), eq(test.t.b, "a"), eq(test.t.c, "a")`,
+		"└─TableReader 10000.00 root  data:TableFullScan",
+		"  └─TableFullScan 10000.00 cop[tikv] table:t keep order:false, stats:pseudo",
+	))
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a varchar(10) collate utf8mb4_0900_ai_ci unique)")
+	tk.MustExec("insert into t values ('a'), ('a ')")
+	tk.MustGetErrCode("insert into t values ('Á')", errno.ErrDupEntry)
+
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a varchar(10) collate utf8mb4_0900_bin primary key clustered, b varchar(10) collate utf8mb4_0900_ai_ci, key ib(b))")
+	tk.MustExec("insert into t values ('a ', 'A '), ('a', 'Á')")
+	tk.MustQuery("select concat(a, '|'), concat(b, '|') from t use index(ib) where b = 'a'").Check(testkit.Rows("a| Á|"))
+	tk.MustQuery("select concat(a, '|'), concat(b, '|') from t where a = 'a '").Check(testkit.Rows("a | A |"))
+	tk.MustExec("admin check table t")
+}
+
 func (s *testIntegrationSerialSuite) TestCollateConstantPropagation(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	collate.SetNewCollationEnabledForTest(true)
@@ -10393,3 +10438,111 @@ func (s *testIntegrationSuite) TestIdentity(c *C) {
 	tk.MustExec(`INSERT INTO identity VALUES (NULL);`)
 	tk.MustQuery("SELECT @@identity, LAST_INSERT_ID()").Check(testkit.Rows("3 3"))
 }
+
+func (s *testIntegrationSuite) TestRegexpFunctions(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, a varchar(32), b varbinary(32))")
+	tk.MustExec("insert into t values (1, 'dog cat dog', 'dog cat dog'), (2, '你好世界你好', '你好世界你好'), (3, null, null)")
+
+	tk.MustQuery("select id from t where regexp_like(a, '^d.g')").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t where regexp_like(a, 'DOG', 'i')").Check(testkit.Rows("1"))
+	tk.MustQuery("select id, regexp_instr(a, '你好', 2), regexp_instr(b, '你好', 2) from t order by id").Check(testkit.Rows(
+		"1 0 0", "2 5 13", "3 <nil> <nil>"))
+	tk.MustQuery("select id, regexp_substr(a, '[a-z]+', 1, 2), regexp_substr(a, '世.') from t order by id").Check(testkit.Rows(
+		"1 cat <nil>", "2 <nil> 世界", "3 <nil> <nil>"))
+	tk.MustQuery("select id, regexp_replace(a, 'dog', 'fox'), regexp_replace(a, 'dog', 'fox', 1, 2) from t order by id").Check(testkit.Rows(
+		"1 fox cat fox dog cat fox", "2 你好世界你好 你好世界你好", "3 <nil> <nil>"))
+	tk.MustQuery("select regexp_replace('abc def', '([a-z]+) ([a-z]+)', '$2 $1')").Check(testkit.Rows("def abc"))
+	tk.MustQuery("select regexp_like('a\\nb', '^b', 'm'), regexp_like('a\\nb', 'a.b'), regexp_like('a\\nb', 'a.b', 'n')").Check(testkit.Rows("1 0 1"))
+
+	for _, sql := range []string{
+		"select regexp_like(a, '') from t",
+		"select regexp_like(a, 'a', 'x') from t",
+		"select regexp_instr(a, 'a', 100) from t",
+		"select regexp_instr(a, 'a', 1, 1, 2) from t",
+	} {
+		err := tk.QueryToErr(sql)
+		c.Assert(terror.ErrorEqual(err, expression.ErrRegexp), IsTrue, Commentf("%s: %v", sql, err))
+	}
+	tk.MustGetErrCode("select regexp_like('a')", mysql.ErrWrongParamcountToNativeFct)
+
+	// The functions aren't pushed down to the coprocessor.
+	rows := tk.MustQuery("explain select id from t where regexp_like(a, 'dog') and regexp_instr(a, 'cat') > 0 and regexp_substr(a, 'd.g') = 'dog' and regexp_replace(a, 'dog', '') != ''").Rows()
+	c.Assert(rows[1][2], Equals, "root")
+	c.Assert(rows[1][0], Matches, ".*Selection.*")
+	tk.MustQuery("select id from t where regexp_like(a, 'dog') and regexp_instr(a, 'cat') > 0 and regexp_substr(a, 'd.g') = 'dog' and regexp_replace(a, 'dog', '') != ''").Check(testkit.Rows("1"))
+}
+
+func (s *testIntegrationSerialSuite) TestRegexpFunctionsWithCollation(c *C) {
+	collate.SetNewCollationEnabledForTest(true)
+	defer collate.SetNewCollationEnabledForTest(false)
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a varchar(32) collate utf8mb4_general_ci, b varchar(32) collate utf8mb4_bin)")
+	tk.MustExec("insert into t values ('Dog Cat', 'Dog Cat')")
+
+	// The case sensitivity follows the collation unless it's specified by the match type.
+	tk.MustQuery("select regexp_like(a, 'dog'), regexp_like(b, 'dog'), regexp_like(a, 'dog', 'c'), regexp_like(b, 'dog', 'i') from t").Check(testkit.Rows("1 0 0 1"))
+	tk.MustQuery("select regexp_instr(a, 'cat'), regexp_instr(b, 'cat') from t").Check(testkit.Rows("5 0"))
+	tk.MustQuery("select regexp_substr(a, 'CAT'), regexp_substr(b, 'CAT') from t").Check(testkit.Rows("Cat <nil>"))
+	tk.MustQuery("select regexp_replace(a, 'dog', 'fox'), regexp_replace(b, 'dog', 'fox') from t").Check(testkit.Rows("fox Cat Dog Cat"))
+	tk.MustQuery("select count(*) from t where regexp_like(a, 'dog')").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(*) from t where regexp_like(b, 'dog')").Check(testkit.Rows("0"))
+	tk.MustQuery("select regexp_like('ABC' collate utf8mb4_general_ci, 'abc'), regexp_like('ABC' collate utf8mb4_bin, 'abc')").Check(testkit.Rows("1 0"))
+}
+
+func (s *testIntegrationSuite) TestJSONOverlapsAndMemberOf(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, tags json)")
+	tk.MustExec(`insert into t values (1, '["x", "y"]'), (2, '["z", 3]'), (3, '"x"'), (4, null)`)
+	tk.MustQuery(`select id from t where json_overlaps(tags, '["x", 3]') order by id`).Check(testkit.Rows("1", "2", "3"))
+	tk.MustQuery(`select id from t where 'x' member of (tags) order by id`).Check(testkit.Rows("1", "3"))
+	tk.MustQuery(`select id from t where 3 member of (tags) order by id`).Check(testkit.Rows("2"))
+	tk.MustQuery(`select id, json_overlaps(tags, '"z"'), 'z' member of (tags) from t order by id`).Check(testkit.Rows(
+		"1 0 0", "2 1 1", "3 0 0", "4 <nil> <nil>"))
+	tk.MustQuery(`select json_overlaps('{"a":1,"b":2}', '{"b":2}'), cast('[1,2]' as json) member of ('[[1,2],3]'), not 1 member of ('[2]')`).Check(testkit.Rows("1 1 1"))
+	tk.MustGetErrCode("select json_overlaps(1, '[1]')", mysql.ErrInvalidJSONData)
+}
+
+func (s *testIntegrationSuite) TestSpatialFunctions(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, g blob)")
+	tk.MustExec(`insert into t values (1, st_geomfromtext('POINT(1 1)')), (2, point(20, 20)),
+		(3, st_geomfromtext('LINESTRING(5 5,15 5)')), (4, st_geomfromwkb(st_asbinary(st_geomfromtext('POLYGON((2 2,4 2,4 4,2 4,2 2))')))),
+		(5, null)`)
+
+	tk.MustQuery("select id, st_astext(g), st_geometrytype(g), st_srid(g) from t order by id").Check(testkit.Rows(
+		"1 POINT(1 1) POINT 0", "2 POINT(20 20) POINT 0", "3 LINESTRING(5 5,15 5) LINESTRING 0",
+		"4 POLYGON((2 2,4 2,4 4,2 4,2 2)) POLYGON 0", "5 <nil> <nil> <nil>"))
+	tk.MustQuery("select hex(g) from t where id = 1").Check(testkit.Rows("000000000101000000000000000000F03F000000000000F03F"))
+	tk.MustQuery("select st_x(g), st_y(g) from t where id in (1, 2) order by id").Check(testkit.Rows("1 1", "20 20"))
+
+	square := "st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))')"
+	tk.MustQuery("select id from t where st_contains(" + square + ", g) order by id").Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select id from t where st_within(g, " + square + ") order by id").Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select id from t where st_intersects(g, " + square + ") order by id").Check(testkit.Rows("1", "3", "4"))
+	tk.MustQuery("select id from t where st_disjoint(g, " + square + ") order by id").Check(testkit.Rows("2"))
+	tk.MustQuery("select id, st_distance(g, point(0, 0)) from t order by id").Check(testkit.Rows(
+		"1 1.4142135623730951", "2 28.284271247461902", "3 7.0710678118654755", "4 2.8284271247461903", "5 <nil>"))
+	tk.MustQuery("select st_equals(st_geomfromtext('LINESTRING(0 0,1 1)'), st_geomfromtext('LINESTRING(1 1,0 0)'))").Check(testkit.Rows("1"))
+	tk.MustQuery("select st_contains(" + square + ", st_geomfromtext('GEOMETRYCOLLECTION EMPTY'))").Check(testkit.Rows("<nil>"))
+
+	for _, sql := range []string{
+		"select st_geomfromtext('POINT(1)')",
+		"select st_astext('abc')",
+		"select st_x(g) from t where id = 3",
+	} {
+		err := tk.QueryToErr(sql)
+		c.Assert(terror.ErrorEqual(err, spatial.ErrGISInvalidData), IsTrue, Commentf("%s: %v", sql, err))
+	}
+	err := tk.QueryToErr("select st_geomfromtext('POINT(1 1)', 4326)")
+	c.Assert(terror.ErrorEqual(err, spatial.ErrSRSNotFound), IsTrue, Commentf("%v", err))
+	tk.MustGetErrCode("select st_distance(point(1, 1))", mysql.ErrWrongParamcountToNativeFct)
+}
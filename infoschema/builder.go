@@ -41,6 +41,8 @@ type Builder struct {
 	// TODO: store is only used by autoid allocators
 	// detach allocators from storage, use passed transaction in the feature
 	store kv.Storage
+	// sessPool is used by the cached tables to access their locks.
+	sessPool tables.SessionPool
 }
 
 // ApplyDiff applies SchemaDiff to the new InfoSchema.
@@ -60,6 +62,9 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 		return b.applyDropPolicy(diff.SchemaID), nil
 	case model.ActionAlterPlacementPolicy:
 		return b.applyAlterPolicy(m, diff)
+	case model.ActionCreateResourceGroup, model.ActionAlterResourceGroup, model.ActionDropResourceGroup:
+		// The resource groups are kept out of the information schema.
+		return nil, nil
 	}
 	roDBInfo, ok := b.is.SchemaByID(diff.SchemaID)
 	if !ok {
@@ -433,6 +438,13 @@ func (b *Builder) applyCreateTable(m *meta.Meta, dbInfo *model.DBInfo, tableID i
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	cacheStatus, err := m.GetTableCacheStatus(tableID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if tbl, err = b.tryCacheTable(tbl, cacheStatus); err != nil {
+		return nil, errors.Trace(err)
+	}
 	tableNames := b.is.schemaMap[dbInfo.Name.L]
 	tableNames.tables[tblInfo.Name.L] = tbl
 	bucketIdx := tableBucketIdx(tableID)
@@ -579,8 +591,10 @@ func (b *Builder) copySchemaTables(dbName string) *model.DBInfo {
 	return newSchemaTables.dbInfo
 }
 
-// InitWithDBInfos initializes an empty new InfoSchema with a slice of DBInfo, all placement rules, and schema version.
-func (b *Builder) InitWithDBInfos(dbInfos []*model.DBInfo, bundles []*placement.Bundle, policies []*model.PolicyInfo, schemaVersion int64) (*Builder, error) {
+// InitWithDBInfos initializes an empty new InfoSchema with a slice of DBInfo, all placement rules, the cache status of
+// the cached tables, and schema version.
+func (b *Builder) InitWithDBInfos(dbInfos []*model.DBInfo, bundles []*placement.Bundle, policies []*model.PolicyInfo,
+	cachedTables map[int64]meta.TableCacheStatusType, schemaVersion int64) (*Builder, error) {
 	info := b.is
 	info.schemaMetaVersion = schemaVersion
 	for _, bundle := range bundles {
@@ -591,8 +605,15 @@ func (b *Builder) InitWithDBInfos(dbInfos []*model.DBInfo, bundles []*placement.
 		info.setPolicy(policy)
 	}
 
+	tableFromMeta := func(allocs autoid.Allocators, tblInfo *model.TableInfo) (table.Table, error) {
+		tbl, err := tables.TableFromMeta(allocs, tblInfo)
+		if err != nil {
+			return nil, err
+		}
+		return b.tryCacheTable(tbl, cachedTables[tblInfo.ID])
+	}
 	for _, di := range dbInfos {
-		err := b.createSchemaTablesForDB(di, tables.TableFromMeta)
+		err := b.createSchemaTablesForDB(di, tableFromMeta)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -613,6 +634,19 @@ func (b *Builder) InitWithDBInfos(dbInfos []*model.DBInfo, bundles []*placement.
 	return b, nil
 }
 
+// tryCacheTable wraps the table as a cached table unless the cache is disabled. The reads of the table are served
+// from the cache only after the cache is enabled, while the writes acquire the lock of the table during switching.
+func (b *Builder) tryCacheTable(tbl table.Table, status meta.TableCacheStatusType) (table.Table, error) {
+	if status == meta.TableCacheStatusDisable {
+		return tbl, nil
+	}
+	var handle tables.StateRemote
+	if b.sessPool != nil {
+		handle = tables.NewStateRemote(b.sessPool)
+	}
+	return tables.NewCachedTable(tbl, status == meta.TableCacheStatusEnable, handle)
+}
+
 type tableFromMetaFunc func(alloc autoid.Allocators, tblInfo *model.TableInfo) (table.Table, error)
 
 func (b *Builder) createSchemaTablesForDB(di *model.DBInfo, tableFromMeta tableFromMetaFunc) error {
@@ -648,10 +682,11 @@ func RegisterVirtualTable(dbInfo *model.DBInfo, tableFromMeta tableFromMetaFunc)
 	drivers = append(drivers, &virtualTableDriver{dbInfo, tableFromMeta})
 }
 
-// NewBuilder creates a new Builder with a Handle.
-func NewBuilder(store kv.Storage) *Builder {
+// NewBuilder creates a new Builder with a Handle, sessPool can be nil if the cached tables are never locked.
+func NewBuilder(store kv.Storage, sessPool tables.SessionPool) *Builder {
 	return &Builder{
-		store: store,
+		store:    store,
+		sessPool: sessPool,
 		is: &infoSchema{
 			schemaMap:           map[string]*schemaTables{},
 			policyMap:           map[string]*model.PolicyInfo{},
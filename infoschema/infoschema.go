@@ -59,6 +59,14 @@ type InfoSchema interface {
 	RuleBundles() []*placement.Bundle
 	// AllPlacementPolicies returns all placement policies
 	AllPlacementPolicies() []*model.PolicyInfo
+	// GetTableReferredForeignKeys returns the foreign keys of other tables which refer to schema.table.
+	GetTableReferredForeignKeys(schema, table model.CIStr) []*ReferredFKInfo
+}
+
+// ReferredFKInfo describes a foreign key of a child table which refers to a parent table.
+type ReferredFKInfo struct {
+	ChildTable table.Table
+	ChildFK    *model.FKInfo
 }
 
 type sortedTables []table.Table
@@ -88,6 +96,35 @@ func (s sortedTables) searchTable(id int64) int {
 type schemaTables struct {
 	dbInfo *model.DBInfo
 	tables map[string]table.Table
+
+	// referredFKs maps a parent table name to the foreign keys referring to it.
+	// It's built lazily because schemaTables is read-only once built.
+	referredFKsOnce sync.Once
+	referredFKs     map[string][]*ReferredFKInfo
+}
+
+func (st *schemaTables) getReferredForeignKeys(tableName string) []*ReferredFKInfo {
+	st.referredFKsOnce.Do(func() {
+		st.referredFKs = make(map[string][]*ReferredFKInfo)
+		for _, tbl := range st.tables {
+			for _, fk := range tbl.Meta().ForeignKeys {
+				if fk.State != model.StatePublic {
+					continue
+				}
+				st.referredFKs[fk.RefTable.L] = append(st.referredFKs[fk.RefTable.L], &ReferredFKInfo{ChildTable: tbl, ChildFK: fk})
+			}
+		}
+		// Keep the order stable, so the cascading actions are executed in a deterministic order.
+		for _, fks := range st.referredFKs {
+			sort.Slice(fks, func(i, j int) bool {
+				if fks[i].ChildTable.Meta().ID != fks[j].ChildTable.Meta().ID {
+					return fks[i].ChildTable.Meta().ID < fks[j].ChildTable.Meta().ID
+				}
+				return fks[i].ChildFK.ID < fks[j].ChildFK.ID
+			})
+		}
+	})
+	return st.referredFKs[tableName]
 }
 
 const bucketCount = 512
@@ -291,6 +328,15 @@ func (is *infoSchema) SchemaTables(schema model.CIStr) (tables []table.Table) {
 	return
 }
 
+// GetTableReferredForeignKeys implements InfoSchema.GetTableReferredForeignKeys interface.
+func (is *infoSchema) GetTableReferredForeignKeys(schema, table model.CIStr) []*ReferredFKInfo {
+	schemaTables, ok := is.schemaMap[schema.L]
+	if !ok {
+		return nil
+	}
+	return schemaTables.getReferredForeignKeys(table.L)
+}
+
 // FindTableByPartitionID finds the partition-table info by the partitionID.
 // FindTableByPartitionID will traverse all the tables to find the partitionID partition in which partition-table.
 func (is *infoSchema) FindTableByPartitionID(partitionID int64) (table.Table, *model.DBInfo, *model.PartitionDefinition) {
@@ -81,6 +81,8 @@ const (
 	tablePlugins    = "PLUGINS"
 	// TableConstraints is the string constant of TABLE_CONSTRAINTS.
 	TableConstraints = "TABLE_CONSTRAINTS"
+	// TableCheckConstraints is the string constant of CHECK_CONSTRAINTS.
+	TableCheckConstraints = "CHECK_CONSTRAINTS"
 	tableTriggers    = "TRIGGERS"
 	// TableUserPrivileges is the string constant of infoschema user privilege table.
 	TableUserPrivileges   = "USER_PRIVILEGES"
@@ -179,6 +181,12 @@ const (
 	TableDataLockWaits = "DATA_LOCK_WAITS"
 	// TableRegionLabel is the string constant of region label table.
 	TableRegionLabel = "REGION_LABEL"
+	// TableTiDBMDLView is the string constant of the table showing the transactions blocking DDL jobs.
+	TableTiDBMDLView = "TIDB_MDL_VIEW"
+	// TableRunawayWatches is the string constant of the runaway watch list table.
+	TableRunawayWatches = "RUNAWAY_WATCHES"
+	// TableResourceGroups is the string constant of resource groups table.
+	TableResourceGroups = "RESOURCE_GROUPS"
 )
 
 const (
@@ -275,6 +283,10 @@ var tableIDMap = map[string]int64{
 	ClusterTableStatementsSummaryEvicted:    autoid.InformationSchemaDBID + 76,
 	TableRegionLabel:                        autoid.InformationSchemaDBID + 77,
 	TableTiDBHotRegionsHistory:              autoid.InformationSchemaDBID + 78,
+	TableCheckConstraints:                   autoid.InformationSchemaDBID + 79,
+	TableTiDBMDLView:                        autoid.InformationSchemaDBID + 80,
+	TableRunawayWatches:                     autoid.InformationSchemaDBID + 81,
+	TableResourceGroups:                     autoid.InformationSchemaDBID + 82,
 }
 
 type columnInfo struct {
@@ -565,6 +577,40 @@ var tableConstraintsCols = []columnInfo{
 	{name: "TABLE_SCHEMA", tp: mysql.TypeVarchar, size: 64},
 	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64},
 	{name: "CONSTRAINT_TYPE", tp: mysql.TypeVarchar, size: 64},
+	{name: "ENFORCED", tp: mysql.TypeVarchar, size: 3},
+}
+
+var tableCheckConstraintsCols = []columnInfo{
+	{name: "CONSTRAINT_CATALOG", tp: mysql.TypeVarchar, size: 64},
+	{name: "CONSTRAINT_SCHEMA", tp: mysql.TypeVarchar, size: 64},
+	{name: "CONSTRAINT_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "CHECK_CLAUSE", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+}
+
+var tableTiDBMDLViewCols = []columnInfo{
+	{name: "JOB_ID", tp: mysql.TypeLonglong, size: 21},
+	{name: "DB_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "QUERY", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "SESSION_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "TXN_START", tp: mysql.TypeTimestamp, size: 26, decimal: 6},
+	{name: "SQL_TEXT", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+}
+
+var tableRunawayWatchesCols = []columnInfo{
+	{name: "ID", tp: mysql.TypeLonglong, size: 21},
+	{name: "SQL_DIGEST", tp: mysql.TypeVarchar, size: 64},
+	{name: "SAMPLE_SQL", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "START_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "END_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "SOURCE", tp: mysql.TypeVarchar, size: 512},
+}
+
+var tableResourceGroupsCols = []columnInfo{
+	{name: "NAME", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "RU_PER_SEC", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "BURSTABLE", tp: mysql.TypeVarchar, size: 3},
+	{name: "CONSUMED_RU", tp: mysql.TypeDouble, size: 22},
 }
 
 var tableTriggersCols = []columnInfo{
@@ -1483,6 +1529,8 @@ const (
 	PrimaryConstraint = "PRIMARY"
 	// UniqueKeyType is the string constant of UNIQUE.
 	UniqueKeyType = "UNIQUE"
+	// CheckConstraintType is the string constant of CHECK.
+	CheckConstraintType = "CHECK"
 )
 
 // ServerInfo represents the basic server information of single cluster component
@@ -1780,6 +1828,10 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableSessionVar:                         sessionVarCols,
 	tablePlugins:                            pluginsCols,
 	TableConstraints:                        tableConstraintsCols,
+	TableCheckConstraints:                   tableCheckConstraintsCols,
+	TableTiDBMDLView:                        tableTiDBMDLViewCols,
+	TableRunawayWatches:                     tableRunawayWatchesCols,
+	TableResourceGroups:                     tableResourceGroupsCols,
 	tableTriggers:                           tableTriggersCols,
 	TableUserPrivileges:                     tableUserPrivilegesCols,
 	tableSchemaPrivileges:                   tableSchemaPrivilegesCols,
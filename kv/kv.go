@@ -30,6 +30,7 @@ import (
 	tikvstore "github.com/tikv/client-go/v2/kv"
 	"github.com/tikv/client-go/v2/oracle"
 	"github.com/tikv/client-go/v2/tikv"
+	"go.uber.org/atomic"
 )
 
 // UnCommitIndexKVFlag uses to indicate the index key/value is no need to commit.
@@ -337,6 +338,19 @@ type Request struct {
 	MatchStoreLabels []*metapb.StoreLabel
 	// ResourceGroupTag indicates the kv request task group.
 	ResourceGroupTag []byte
+	// ResourceGroupLimiter throttles the request by the quota of the resource group, nil means unlimited.
+	ResourceGroupLimiter ResourceGroupLimiter
+	// RunawayCooldown is set when the statement is identified as a runaway query with the COOLDOWN action,
+	// the tasks which haven't been sent yet are sent with the low priority.
+	RunawayCooldown *atomic.Bool
+}
+
+// ResourceGroupLimiter throttles the kv requests according to the request units they consume.
+type ResourceGroupLimiter interface {
+	// Wait blocks until the resource group has tokens to send a request.
+	Wait(ctx context.Context) error
+	// Consume charges the request units of a finished request, which read or write the given bytes.
+	Consume(isWrite bool, bytes int)
 }
 
 const (
@@ -68,6 +68,8 @@ const (
 	KVFilter
 	// SnapInterceptor is used for setting the interceptor for snapshot
 	SnapInterceptor
+	// ResourceGroup indicates the limiter of the resource group which the kv requests are throttled by.
+	ResourceGroup
 )
 
 // ReplicaReadType is the type of replica to read data from
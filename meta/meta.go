@@ -56,6 +56,12 @@ var (
 //		TID:1 -> int64
 //		TID:2 -> int64
 //	}
+//	CachedTables -> {
+//		TableID:1 -> cache status int64
+//	}
+//	ResourceGroups -> {
+//		name -> resource group info []byte
+//	}
 //
 
 var (
@@ -75,6 +81,8 @@ var (
 	mPolicies         = []byte("Policies")
 	mPolicyPrefix     = "Policy"
 	mPolicyGlobalID   = []byte("PolicyGlobalID")
+	mCachedTables     = []byte("CachedTables")
+	mResourceGroups   = []byte("ResourceGroups")
 	mPolicyMagicByte  = CurrentMagicByteVer
 )
 
@@ -561,6 +569,91 @@ func (m *Meta) ListDatabases() ([]*model.DBInfo, error) {
 	return dbs, nil
 }
 
+// TableCacheStatusType is the cache status of a table.
+type TableCacheStatusType int64
+
+// The cache status of a table. A table is switching when it's being cached or uncached, it's written
+// under the lock of the cached tables, but it's not read from the cache yet or any more.
+const (
+	TableCacheStatusDisable TableCacheStatusType = iota
+	TableCacheStatusEnable
+	TableCacheStatusSwitching
+)
+
+// String implements fmt.Stringer interface.
+func (t TableCacheStatusType) String() string {
+	switch t {
+	case TableCacheStatusDisable:
+		return "disable"
+	case TableCacheStatusEnable:
+		return "enable"
+	case TableCacheStatusSwitching:
+		return "switching"
+	}
+	return ""
+}
+
+// SetTableCacheStatus sets the cache status of the table.
+func (m *Meta) SetTableCacheStatus(tableID int64, status TableCacheStatusType) error {
+	if status == TableCacheStatusDisable {
+		return errors.Trace(m.txn.HDel(mCachedTables, m.jobIDKey(tableID)))
+	}
+	return errors.Trace(m.txn.HSet(mCachedTables, m.jobIDKey(tableID), []byte(strconv.FormatInt(int64(status), 10))))
+}
+
+// GetTableCacheStatus gets the cache status of the table.
+func (m *Meta) GetTableCacheStatus(tableID int64) (TableCacheStatusType, error) {
+	status, err := m.txn.HGetInt64(mCachedTables, m.jobIDKey(tableID))
+	return TableCacheStatusType(status), errors.Trace(err)
+}
+
+// ListTableCacheStatus lists the cache status of the tables which are not disabled, the keys of the returned
+// map are the table IDs.
+func (m *Meta) ListTableCacheStatus() (map[int64]TableCacheStatusType, error) {
+	res, err := m.txn.HGetAll(mCachedTables)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	statuses := make(map[int64]TableCacheStatusType, len(res))
+	for _, r := range res {
+		status, err := strconv.ParseInt(string(r.Value), 10, 64)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		statuses[int64(binary.BigEndian.Uint64(r.Field))] = TableCacheStatusType(status)
+	}
+	return statuses, nil
+}
+
+// SetResourceGroup creates or updates the resource group with the lower-case name.
+func (m *Meta) SetResourceGroup(name string, data []byte) error {
+	return errors.Trace(m.txn.HSet(mResourceGroups, []byte(name), data))
+}
+
+// DropResourceGroup drops the resource group with the lower-case name.
+func (m *Meta) DropResourceGroup(name string) error {
+	return errors.Trace(m.txn.HDel(mResourceGroups, []byte(name)))
+}
+
+// GetResourceGroup gets the resource group with the lower-case name, it returns nil if the group doesn't exist.
+func (m *Meta) GetResourceGroup(name string) ([]byte, error) {
+	value, err := m.txn.HGet(mResourceGroups, []byte(name))
+	return value, errors.Trace(err)
+}
+
+// ListResourceGroups lists all the resource groups, the keys of the returned map are the lower-case names.
+func (m *Meta) ListResourceGroups() (map[string][]byte, error) {
+	res, err := m.txn.HGetAll(mResourceGroups)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	groups := make(map[string][]byte, len(res))
+	for _, r := range res {
+		groups[string(r.Field)] = r.Value
+	}
+	return groups, nil
+}
+
 // GetDatabase gets the database value with ID.
 func (m *Meta) GetDatabase(dbID int64) (*model.DBInfo, error) {
 	dbKey := m.dbKey(dbID)
@@ -668,6 +761,7 @@ func (m *Meta) GetTable(dbID int64, tableID int64) (*model.TableInfo, error) {
 //	DDLJobList: list jobs
 //	DDLJobHistory: hash
 //	DDLJobReorg: hash
+//	DDLJobPaused: hash
 //
 // for multi DDL workers, only one can become the owner
 // to operate DDL jobs, and dispatch them to MR Jobs.
@@ -677,6 +771,7 @@ var (
 	mDDLJobAddIdxList = []byte("DDLJobAddIdxList")
 	mDDLJobHistoryKey = []byte("DDLJobHistory")
 	mDDLJobReorgKey   = []byte("DDLJobReorg")
+	mDDLJobPausedKey  = []byte("DDLJobPaused")
 )
 
 // JobListKeyType is a key type of the DDL job queue.
@@ -723,6 +818,28 @@ func (m *Meta) DeQueueDDLJob() (*model.Job, error) {
 	return m.deQueueDDLJob(m.jobListKey)
 }
 
+// RemoveDDLJobByIdx removes the DDL job with the index from the list, the jobs ahead of it are moved backward.
+// The length of jobListKeys can only be 1 or 0.
+// If its length is 1, we need to replace m.jobListKey with jobListKeys[0].
+// Otherwise, we use m.jobListKey directly.
+func (m *Meta) RemoveDDLJobByIdx(index int64, jobListKeys ...JobListKeyType) error {
+	listKey := m.jobListKey
+	if len(jobListKeys) != 0 {
+		listKey = jobListKeys[0]
+	}
+	for i := index; i > 0; i-- {
+		value, err := m.txn.LIndex(listKey, i-1)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err = m.txn.LSet(listKey, i, value); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	_, err := m.txn.LPop(listKey)
+	return errors.Trace(err)
+}
+
 func (m *Meta) getDDLJob(key []byte, index int64) (*model.Job, error) {
 	value, err := m.txn.LIndex(key, index)
 	if err != nil || value == nil {
@@ -1016,6 +1133,10 @@ var (
 	ColumnElementKey ElementKeyType = []byte("_col_")
 	// IndexElementKey is the key for index element.
 	IndexElementKey ElementKeyType = []byte("_idx_")
+	// ConstraintElementKey is the key for check constraint element.
+	ConstraintElementKey ElementKeyType = []byte("_cst_")
+	// PartitionElementKey is the key for reorganized partitions element.
+	PartitionElementKey ElementKeyType = []byte("_prt_")
 )
 
 const elementKeyLen = 5
@@ -1056,6 +1177,10 @@ func DecodeElement(b []byte) (*Element, error) {
 		tp = IndexElementKey
 	case string(ColumnElementKey):
 		tp = ColumnElementKey
+	case string(ConstraintElementKey):
+		tp = ConstraintElementKey
+	case string(PartitionElementKey):
+		tp = PartitionElementKey
 	default:
 		return nil, errors.Errorf("invalid encoded element key prefix %q", prefix)
 	}
@@ -1195,6 +1320,24 @@ func getReorgJobFieldHandle(t *structure.TxStructure, reorgJobField []byte) (kv.
 	return bs, nil
 }
 
+// SetDDLJobPaused marks the DDL job as paused, the job isn't run until it's resumed.
+func (m *Meta) SetDDLJobPaused(id int64) error {
+	err := m.txn.HSet(mDDLJobPausedKey, m.jobIDKey(id), []byte("1"))
+	return errors.Trace(err)
+}
+
+// RemoveDDLJobPaused removes the paused mark of the DDL job.
+func (m *Meta) RemoveDDLJobPaused(id int64) error {
+	err := m.txn.HDel(mDDLJobPausedKey, m.jobIDKey(id))
+	return errors.Trace(err)
+}
+
+// IsDDLJobPaused checks whether the DDL job is paused.
+func (m *Meta) IsDDLJobPaused(id int64) (bool, error) {
+	value, err := m.txn.HGet(mDDLJobPausedKey, m.jobIDKey(id))
+	return value != nil, errors.Trace(err)
+}
+
 func (m *Meta) schemaDiffKey(schemaVersion int64) []byte {
 	return []byte(fmt.Sprintf("%s:%d", mSchemaDiffPrefix, schemaVersion))
 }
@@ -443,6 +443,8 @@ func TestElement(t *testing.T) {
 	checkElement(key, errors.Errorf(`invalid encoded element key prefix "_col\x00"`))
 	checkElement(meta.IndexElementKey, nil)
 	checkElement(meta.ColumnElementKey, nil)
+	checkElement(meta.ConstraintElementKey, nil)
+	checkElement(meta.PartitionElementKey, nil)
 	key = []byte("inexistent")
 	checkElement(key, errors.Errorf("invalid encoded element key prefix %q", key[:5]))
 
@@ -653,6 +655,45 @@ func TestAddIndexJob(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRemoveDDLJobByIdx(t *testing.T) {
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+	defer func() {
+		err := store.Close()
+		require.NoError(t, err)
+	}()
+
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	defer func() {
+		err := txn.Rollback()
+		require.NoError(t, err)
+	}()
+
+	m := meta.NewMeta(txn)
+	for i := int64(1); i <= 4; i++ {
+		require.NoError(t, m.EnQueueDDLJob(&model.Job{ID: i}))
+	}
+	checkJobIDs := func(expected ...int64) {
+		n, err := m.DDLJobQueueLen()
+		require.NoError(t, err)
+		ids := make([]int64, 0, n)
+		for i := int64(0); i < n; i++ {
+			job, err := m.GetDDLJobByIdx(i)
+			require.NoError(t, err)
+			ids = append(ids, job.ID)
+		}
+		require.Equal(t, expected, ids)
+	}
+
+	require.NoError(t, m.RemoveDDLJobByIdx(2))
+	checkJobIDs(1, 2, 4)
+	require.NoError(t, m.RemoveDDLJobByIdx(0))
+	checkJobIDs(2, 4)
+	require.NoError(t, m.RemoveDDLJobByIdx(1))
+	checkJobIDs(2)
+}
+
 func BenchmarkGenGlobalIDs(b *testing.B) {
 	store, err := mockstore.NewMockStore()
 	require.NoError(b, err)
@@ -157,6 +157,10 @@ func RegisterMetrics() {
 	prometheus.MustRegister(TopSQLIgnoredCounter)
 	prometheus.MustRegister(TopSQLReportDurationHistogram)
 	prometheus.MustRegister(TopSQLReportDataHistogram)
+	prometheus.MustRegister(ResourceGroupRUCounter)
+	prometheus.MustRegister(ResourceGroupThrottledDuration)
+	prometheus.MustRegister(ResourceGroupQuotaGauge)
+	prometheus.MustRegister(RunawayQueryCounter)
 
 	tikvmetrics.InitMetrics(TiDB, TiKVClient)
 	tikvmetrics.RegisterMetrics()
@@ -0,0 +1,56 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Resource group metrics.
+var (
+	ResourceGroupRUCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "resource_group",
+			Name:      "ru_total",
+			Help:      "Counter of the request units consumed by the resource groups.",
+		}, []string{LblName, LblType})
+
+	ResourceGroupThrottledDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb",
+			Subsystem: "resource_group",
+			Name:      "throttled_duration_seconds",
+			Help:      "Bucket histogram of the time (s) the requests wait for the quotas of the resource groups.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 20), // 0.5ms ~ 262s
+		}, []string{LblName})
+
+	ResourceGroupQuotaGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb",
+			Subsystem: "resource_group",
+			Name:      "ru_per_sec",
+			Help:      "The request units per second quotas of the resource groups, 0 means unlimited.",
+		}, []string{LblName})
+
+	RunawayQueryCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "resource_group",
+			Name:      "runaway_query_total",
+			Help:      "Counter of the runaway queries identified and quarantined.",
+		}, []string{LblType})
+)
+
+// LblName is the label of the name of a resource group.
+const LblName = "name"
@@ -69,6 +69,7 @@ type pstmtPlanCacheKey struct {
 	database             string
 	connID               uint64
 	pstmtID              uint32
+	digest               string
 	schemaVersion        int64
 	sqlMode              mysql.SQLMode
 	timezoneOffset       int
@@ -82,8 +83,9 @@ type pstmtPlanCacheKey struct {
 func (key *pstmtPlanCacheKey) Hash() []byte {
 	if len(key.hash) == 0 {
 		var (
-			dbBytes    = hack.Slice(key.database)
-			bufferSize = len(dbBytes) + 8*6 + 3*8
+			dbBytes     = hack.Slice(key.database)
+			digestBytes = hack.Slice(key.digest)
+			bufferSize  = len(dbBytes) + len(digestBytes) + 8*6 + 3*8
 		)
 		if key.hash == nil {
 			key.hash = make([]byte, 0, bufferSize)
@@ -91,6 +93,7 @@ func (key *pstmtPlanCacheKey) Hash() []byte {
 		key.hash = append(key.hash, dbBytes...)
 		key.hash = codec.EncodeInt(key.hash, int64(key.connID))
 		key.hash = codec.EncodeInt(key.hash, int64(key.pstmtID))
+		key.hash = append(key.hash, digestBytes...)
 		key.hash = codec.EncodeInt(key.hash, key.schemaVersion)
 		key.hash = codec.EncodeInt(key.hash, int64(key.sqlMode))
 		key.hash = codec.EncodeInt(key.hash, int64(key.timezoneOffset))
@@ -146,6 +149,14 @@ func NewPSTMTPlanCacheKey(sessionVars *variable.SessionVars, pstmtID uint32, sch
 	return key
 }
 
+// NewNonPreparedPlanCacheKey creates a new pstmtPlanCacheKey object for a parameterized text statement.
+// The digest of the parameterized statement takes the place of the prepared statement ID.
+func NewNonPreparedPlanCacheKey(sessionVars *variable.SessionVars, digest string, schemaVersion int64) kvcache.Key {
+	key := NewPSTMTPlanCacheKey(sessionVars, 0, schemaVersion).(*pstmtPlanCacheKey)
+	key.digest = digest
+	return key
+}
+
 // FieldSlice is the slice of the types.FieldType
 type FieldSlice []types.FieldType
 
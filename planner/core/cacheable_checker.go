@@ -43,6 +43,44 @@ func Cacheable(node ast.Node, is infoschema.InfoSchema) bool {
 	return checker.cacheable
 }
 
+// NonPreparedPlanCacheable checks whether the input ast of a text-protocol statement can use the non-prepared
+// plan cache. Only simple SELECT/UPDATE/DELETE statements on a single table or joins of tables are supported,
+// and the statement must be cacheable as a prepared statement as well.
+func NonPreparedPlanCacheable(node ast.Node, is infoschema.InfoSchema) bool {
+	switch x := node.(type) {
+	case *ast.SelectStmt:
+		if x.With != nil || x.SelectIntoOpt != nil || x.From == nil || !isPlainTableRefs(x.From.TableRefs) {
+			return false
+		}
+	case *ast.UpdateStmt:
+		if x.With != nil || x.MultipleTable || x.TableRefs == nil || !isPlainTableRefs(x.TableRefs.TableRefs) {
+			return false
+		}
+	case *ast.DeleteStmt:
+		if x.With != nil || x.IsMultiTable || x.TableRefs == nil || !isPlainTableRefs(x.TableRefs.TableRefs) {
+			return false
+		}
+	default:
+		return false
+	}
+	return Cacheable(node, is)
+}
+
+// isPlainTableRefs checks whether all the data sources of the join are tables without AS OF clauses.
+func isPlainTableRefs(node ast.ResultSetNode) bool {
+	switch x := node.(type) {
+	case *ast.Join:
+		if x.Left == nil || !isPlainTableRefs(x.Left) {
+			return false
+		}
+		return x.Right == nil || isPlainTableRefs(x.Right)
+	case *ast.TableSource:
+		tn, ok := x.Source.(*ast.TableName)
+		return ok && tn.AsOf == nil
+	}
+	return false
+}
+
 // cacheableChecker checks whether a query's plan can be cached, querys that:
 //	 1. have ExistsSubqueryExpr, or
 //	 2. have VariableExpr
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -39,6 +40,7 @@ import (
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/types"
 	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/execdetails"
 	"github.com/pingcap/tidb/util/hint"
@@ -128,6 +130,20 @@ type CancelDDLJobs struct {
 	JobIDs []int64
 }
 
+// PauseDDLJobs represents a pause DDL jobs plan.
+type PauseDDLJobs struct {
+	baseSchemaProducer
+
+	JobIDs []int64
+}
+
+// ResumeDDLJobs represents a resume DDL jobs plan.
+type ResumeDDLJobs struct {
+	baseSchemaProducer
+
+	JobIDs []int64
+}
+
 // ReloadExprPushdownBlacklist reloads the data from expr_pushdown_blacklist table.
 type ReloadExprPushdownBlacklist struct {
 	baseSchemaProducer
@@ -373,6 +389,28 @@ func (e *Execute) checkPreparedPriv(ctx context.Context, sctx sessionctx.Context
 	return err
 }
 
+// recordMDLRelatedTables records the tables used by the prepared statement, which are not collected by
+// the preprocessor when it is executed.
+func recordMDLRelatedTables(sctx sessionctx.Context, is infoschema.InfoSchema, vs []visitInfo) {
+	if !variable.EnableMDL.Load() {
+		return
+	}
+	stmtCtx := sctx.GetSessionVars().StmtCtx
+	for _, v := range vs {
+		if v.table == "" || util.IsMemOrSysDB(strings.ToLower(v.db)) {
+			continue
+		}
+		tbl, err := is.TableByName(model.NewCIStr(v.db), model.NewCIStr(v.table))
+		if err != nil {
+			continue
+		}
+		if stmtCtx.MDLRelatedTableIDs == nil {
+			stmtCtx.MDLRelatedTableIDs = make(map[int64]struct{})
+		}
+		stmtCtx.MDLRelatedTableIDs[tbl.Meta().ID] = struct{}{}
+	}
+}
+
 func (e *Execute) setFoundInPlanCache(sctx sessionctx.Context, opt bool) error {
 	vars := sctx.GetSessionVars()
 	err := vars.SetSystemVar(variable.TiDBFoundInPlanCache, variable.BoolToOnOff(opt))
@@ -384,6 +422,7 @@ func (e *Execute) getPhysicalPlan(ctx context.Context, sctx sessionctx.Context,
 	stmtCtx := sessVars.StmtCtx
 	prepared := preparedStmt.PreparedAst
 	stmtCtx.UseCache = prepared.UseCache
+	recordMDLRelatedTables(sctx, is, preparedStmt.VisitInfos)
 	var cacheKey kvcache.Key
 	if prepared.UseCache {
 		cacheKey = NewPSTMTPlanCacheKey(sctx.GetSessionVars(), e.ExecID, prepared.SchemaVersion)
@@ -842,6 +881,13 @@ type Insert struct {
 	AllAssignmentsAreConstant bool
 
 	RowLen int
+
+	// FKTriggers checks the foreign keys of the inserted rows.
+	FKTriggers *FKTriggers
+	// OnDeleteFKTriggers is for the rows deleted by REPLACE.
+	OnDeleteFKTriggers *FKTriggers
+	// OnUpdateFKTriggers is for the rows updated by ON DUPLICATE KEY UPDATE.
+	OnUpdateFKTriggers *FKTriggers
 }
 
 // Update represents Update plan.
@@ -863,6 +909,9 @@ type Update struct {
 	PartitionedTable []table.PartitionedTable
 
 	tblID2Table map[int64]table.Table
+
+	// FKTriggers maps the table ID to the foreign key checks and referential actions of the updated rows.
+	FKTriggers map[int64]*FKTriggers
 }
 
 // Delete represents a delete plan.
@@ -874,6 +923,9 @@ type Delete struct {
 	SelectPlan PhysicalPlan
 
 	TblColPosInfos TblColPosInfoSlice
+
+	// FKTriggers maps the table ID to the foreign key checks and referential actions of the deleted rows.
+	FKTriggers map[int64]*FKTriggers
 }
 
 // AnalyzeInfo is used to store the database name, table name and partition name of analyze task.
@@ -990,8 +1042,9 @@ type DDL struct {
 type SelectInto struct {
 	baseSchemaProducer
 
-	TargetPlan Plan
-	IntoOpt    *ast.SelectIntoOption
+	TargetPlan  Plan
+	TargetNames types.NameSlice
+	IntoOpt     *ast.SelectIntoOption
 }
 
 // Explain represents a explain plan.
@@ -1209,16 +1262,28 @@ func (e *Explain) explainPlanInRowFormat(p Plan, taskType, driverSide, indent st
 		}
 		err = e.explainPlanInRowFormat(x.tablePlan, "cop[tikv]", "(Probe)", childIndent, true)
 	case *Insert:
+		fkPlans := e.fkTriggerPlans(x.FKTriggers, x.OnDeleteFKTriggers, x.OnUpdateFKTriggers)
 		if x.SelectPlan != nil {
-			err = e.explainPlanInRowFormat(x.SelectPlan, "root", "", childIndent, true)
+			err = e.explainPlanInRowFormat(x.SelectPlan, "root", "", childIndent, len(fkPlans) == 0)
+		}
+		if err == nil {
+			err = e.explainFKTriggerPlans(fkPlans, childIndent)
 		}
 	case *Update:
+		fkPlans := e.fkTriggerPlans(fkTriggersOrderedByTableID(x.FKTriggers)...)
 		if x.SelectPlan != nil {
-			err = e.explainPlanInRowFormat(x.SelectPlan, "root", "", childIndent, true)
+			err = e.explainPlanInRowFormat(x.SelectPlan, "root", "", childIndent, len(fkPlans) == 0)
+		}
+		if err == nil {
+			err = e.explainFKTriggerPlans(fkPlans, childIndent)
 		}
 	case *Delete:
+		fkPlans := e.fkTriggerPlans(fkTriggersOrderedByTableID(x.FKTriggers)...)
 		if x.SelectPlan != nil {
-			err = e.explainPlanInRowFormat(x.SelectPlan, "root", "", childIndent, true)
+			err = e.explainPlanInRowFormat(x.SelectPlan, "root", "", childIndent, len(fkPlans) == 0)
+		}
+		if err == nil {
+			err = e.explainFKTriggerPlans(fkPlans, childIndent)
 		}
 	case *Execute:
 		if x.Plan != nil {
@@ -1298,6 +1363,43 @@ func (e *Explain) prepareOperatorInfo(p Plan, taskType, driverSide, indent strin
 	e.Rows = append(e.Rows, row)
 }
 
+// fkTriggerPlans returns the foreign key plans to be explained,
+// they are only shown when the foreign key constraints are enforced.
+func (e *Explain) fkTriggerPlans(triggers ...*FKTriggers) []Plan {
+	if e.ctx == nil || !e.ctx.GetSessionVars().ForeignKeyChecks {
+		return nil
+	}
+	var plans []Plan
+	for _, t := range triggers {
+		plans = append(plans, t.plans()...)
+	}
+	return plans
+}
+
+// explainFKTriggerPlans explains the foreign key plans as the last children of the current plan.
+func (e *Explain) explainFKTriggerPlans(plans []Plan, childIndent string) error {
+	for i, p := range plans {
+		if err := e.explainPlanInRowFormat(p, "root", "", childIndent, i == len(plans)-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fkTriggersOrderedByTableID returns the foreign key triggers ordered by the table ID, to keep the explain output stable.
+func fkTriggersOrderedByTableID(tblID2Triggers map[int64]*FKTriggers) []*FKTriggers {
+	ids := make([]int64, 0, len(tblID2Triggers))
+	for id := range tblID2Triggers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	triggers := make([]*FKTriggers, 0, len(ids))
+	for _, id := range ids {
+		triggers = append(triggers, tblID2Triggers[id])
+	}
+	return triggers
+}
+
 func (e *Explain) getOperatorInfo(p Plan, id string) (string, string, string, string) {
 	// For `explain for connection` statement, `e.ExplainRows` will be set.
 	for _, row := range e.ExplainRows {
@@ -1430,6 +1532,15 @@ func IsPointGetWithPKOrUniqueKeyByAutoCommit(ctx sessionctx.Context, p Plan) (bo
 		// can't use max uint64, because using math.MaxUint64 can't guarantee repeatable-read
 		// and the data and index would be inconsistent!
 		isPointGet := v.IndexInfo == nil || (v.IndexInfo.Primary && v.TblInfo.IsCommonHandle)
+		// The cache of a cached table is only valid for the start ts within its lease, so max uint64 can't be used.
+		if isPointGet {
+			if is, ok := ctx.GetInfoSchema().(infoschema.InfoSchema); ok {
+				if tbl, ok := is.TableByID(v.TblInfo.ID); ok {
+					_, isCachedTable := tbl.(table.CachedTable)
+					isPointGet = !isCachedTable
+				}
+			}
+		}
 		return isPointGet, nil
 	default:
 		return false, nil
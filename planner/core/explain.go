@@ -927,6 +927,25 @@ func (p *LogicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *LogicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.Expr, p.Root)
+}
+
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.Expr, p.Root)
+}
+
+func explainJSONTable(expr expression.Expression, root *JSONTableNestedPath) string {
+	var str strings.Builder
+	str.WriteString("doc:")
+	str.WriteString(expr.ExplainInfo())
+	str.WriteString(", path:")
+	str.WriteString(root.Path.String())
+	return str.String()
+}
+
 // ExplainInfo implements Plan interface.
 func (ds *DataSource) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
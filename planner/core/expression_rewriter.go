@@ -1101,6 +1101,10 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		if er.err != nil {
 			return retNode, false
 		}
+		if v.Tp.Array && arg.GetType().EvalType() != types.ETJson {
+			er.err = ErrNotSupportedYet.GenWithStackByArgs("CAST-ing Non-JSON type to array")
+			return retNode, false
+		}
 
 		castFunction := expression.BuildCastFunction(er.sctx, arg, v.Tp)
 		if v.Tp.EvalType() == types.ETString {
@@ -0,0 +1,485 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/types"
+)
+
+// FKLookup describes how to find the rows of a table by the values of some columns.
+// It is used to find the parent rows referenced by a child row, or the child rows referencing a parent row.
+type FKLookup struct {
+	// Tbl is the table to look up. It's nil when the referenced table does not exist.
+	Tbl table.Table
+	// Cols are the looked-up columns of Tbl.
+	Cols []*table.Column
+	// IsHandle indicates that Cols are the leading columns of the clustered primary key,
+	// so the rows are looked up by the record keys directly.
+	IsHandle bool
+	// Idx is the index whose leading columns are Cols, the rows are looked up by the index keys.
+	// If both IsHandle is false and Idx is nil, the whole table is scanned.
+	Idx *model.IndexInfo
+}
+
+func buildFKLookup(tbl table.Table, colNames []model.CIStr) (*FKLookup, error) {
+	lookup := &FKLookup{Tbl: tbl, Cols: make([]*table.Column, 0, len(colNames))}
+	tblInfo := tbl.Meta()
+	for _, name := range colNames {
+		col := table.FindCol(tbl.Cols(), name.L)
+		if col == nil {
+			return nil, ErrUnknownColumn.GenWithStackByArgs(name.O, tblInfo.Name.O)
+		}
+		lookup.Cols = append(lookup.Cols, col)
+	}
+	if tblInfo.PKIsHandle {
+		if len(lookup.Cols) == 1 && mysql.HasPriKeyFlag(lookup.Cols[0].Flag) {
+			lookup.IsHandle = true
+			return lookup, nil
+		}
+	} else if tblInfo.IsCommonHandle {
+		if isFKLookupIndex(tables.FindPrimaryIndex(tblInfo), lookup.Cols) {
+			lookup.IsHandle = true
+			return lookup, nil
+		}
+	}
+	for _, idx := range tblInfo.Indices {
+		if idx.State != model.StatePublic || idx.Global || (idx.Primary && tblInfo.IsCommonHandle) {
+			continue
+		}
+		if !isFKLookupIndex(idx, lookup.Cols) {
+			continue
+		}
+		// Prefer the unique index which exactly covers the columns, because it's a point lookup.
+		if lookup.Idx == nil || (idx.Unique && len(idx.Columns) == len(lookup.Cols)) {
+			lookup.Idx = idx
+		}
+	}
+	return lookup, nil
+}
+
+// isFKLookupIndex checks whether the leading columns of the index are the looked-up columns.
+func isFKLookupIndex(idx *model.IndexInfo, cols []*table.Column) bool {
+	if idx == nil || len(idx.Columns) < len(cols) {
+		return false
+	}
+	for i, col := range cols {
+		idxCol := idx.Columns[i]
+		if idxCol.Offset != col.Offset || idxCol.Length != types.UnspecifiedLength {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *FKLookup) accessObject(tblName model.CIStr) string {
+	var buffer strings.Builder
+	buffer.WriteString("table:")
+	buffer.WriteString(tblName.O)
+	switch {
+	case l.Tbl == nil:
+	case l.IsHandle:
+		buffer.WriteString(", handle:")
+		buffer.WriteString(joinColumnNames(l.Cols))
+	case l.Idx != nil:
+		buffer.WriteString(", index:")
+		buffer.WriteString(l.Idx.Name.O)
+	}
+	return buffer.String()
+}
+
+func joinColumnNames(cols []*table.Column) string {
+	names := make([]string, 0, len(cols))
+	for _, col := range cols {
+		names = append(names, col.Name.O)
+	}
+	return strings.Join(names, ", ")
+}
+
+// FKCheck checks the foreign key constraint for the written rows.
+// For a child table, it checks the referenced parent row exists when inserting or updating a row.
+// For a parent table, it checks no child row references the row when deleting or updating a row,
+// this is the RESTRICT and NO ACTION referential action.
+type FKCheck struct {
+	basePhysicalPlan
+	FKLookup
+
+	FK *model.FKInfo
+	// ReferTable is the name of the looked-up table.
+	ReferTable model.CIStr
+	// ColOffsets are the offsets of the looked-up values in the written row.
+	ColOffsets []int
+	// CheckExist is true when checking a child row, which requires the parent row exists,
+	// and false when checking a parent row, which requires no child row exists.
+	CheckExist bool
+	// Constraint describes the constraint for the error message.
+	Constraint string
+}
+
+// AccessObject implements dataAccesser interface.
+func (p *FKCheck) AccessObject(_ bool) string {
+	return p.accessObject(p.ReferTable)
+}
+
+// OperatorInfo implements dataAccesser interface.
+func (p *FKCheck) OperatorInfo(_ bool) string {
+	if p.CheckExist {
+		return fmt.Sprintf("foreign_key:%s, check_exist", p.FK.Name.O)
+	}
+	return fmt.Sprintf("foreign_key:%s, check_not_exist", p.FK.Name.O)
+}
+
+// ExplainInfo implements Plan interface.
+func (p *FKCheck) ExplainInfo() string {
+	return p.AccessObject(false) + ", " + p.OperatorInfo(false)
+}
+
+// FKCascadeType indicates in which case the referential action is triggered.
+type FKCascadeType int8
+
+const (
+	// FKCascadeOnDelete is the referential action triggered by deleting the parent row.
+	FKCascadeOnDelete FKCascadeType = 1
+	// FKCascadeOnUpdate is the referential action triggered by updating the parent row.
+	FKCascadeOnUpdate FKCascadeType = 2
+)
+
+// FKCascade modifies the child rows when the referenced parent row is deleted or updated,
+// it's the CASCADE and SET NULL referential action.
+type FKCascade struct {
+	basePhysicalPlan
+	FKLookup
+
+	Tp FKCascadeType
+	// DBName is the database of both the parent and the child table.
+	DBName model.CIStr
+	FK     *model.FKInfo
+	// Action is ast.ReferOptionCascade or ast.ReferOptionSetNull.
+	Action ast.ReferOptionType
+	// ColOffsets are the offsets of the referenced values in the parent row.
+	ColOffsets []int
+}
+
+// AccessObject implements dataAccesser interface.
+func (p *FKCascade) AccessObject(_ bool) string {
+	return p.accessObject(p.Tbl.Meta().Name)
+}
+
+// OperatorInfo implements dataAccesser interface.
+func (p *FKCascade) OperatorInfo(_ bool) string {
+	if p.Tp == FKCascadeOnDelete {
+		return fmt.Sprintf("foreign_key:%s, on_delete:%s", p.FK.Name.O, p.Action.String())
+	}
+	return fmt.Sprintf("foreign_key:%s, on_update:%s", p.FK.Name.O, p.Action.String())
+}
+
+// ExplainInfo implements Plan interface.
+func (p *FKCascade) ExplainInfo() string {
+	return p.AccessObject(false) + ", " + p.OperatorInfo(false)
+}
+
+func fkConstraintDesc(dbName model.CIStr, childTbl *model.TableInfo, fk *model.FKInfo) string {
+	quote := func(names []model.CIStr) string {
+		strs := make([]string, 0, len(names))
+		for _, name := range names {
+			strs = append(strs, "`"+name.O+"`")
+		}
+		return strings.Join(strs, ", ")
+	}
+	return fmt.Sprintf("`%s`.`%s`, CONSTRAINT `%s` FOREIGN KEY (%s) REFERENCES `%s` (%s)",
+		dbName.O, childTbl.Name.O, fk.Name.O, quote(fk.Cols), fk.RefTable.O, quote(fk.RefCols))
+}
+
+func columnOffsets(tbl table.Table, colNames []model.CIStr) ([]int, error) {
+	offsets := make([]int, 0, len(colNames))
+	for _, name := range colNames {
+		col := table.FindCol(tbl.Cols(), name.L)
+		if col == nil {
+			return nil, ErrUnknownColumn.GenWithStackByArgs(name.O, tbl.Meta().Name.O)
+		}
+		offsets = append(offsets, col.Offset)
+	}
+	return offsets, nil
+}
+
+// buildChildFKChecks builds the checks of the foreign keys defined in the child table,
+// which verify the parent rows exist.
+func buildChildFKChecks(ctx sessionctx.Context, is infoschema.InfoSchema, dbName model.CIStr, tbl table.Table) ([]*FKCheck, error) {
+	var checks []*FKCheck
+	for _, fk := range tbl.Meta().ForeignKeys {
+		if fk.State != model.StatePublic {
+			continue
+		}
+		offsets, err := columnOffsets(tbl, fk.Cols)
+		if err != nil {
+			return nil, err
+		}
+		check := FKCheck{
+			FK:         fk,
+			ReferTable: fk.RefTable,
+			ColOffsets: offsets,
+			CheckExist: true,
+			Constraint: fkConstraintDesc(dbName, tbl.Meta(), fk),
+		}.Init(ctx)
+		// The parent table may not exist if it's created after the child table with foreign_key_checks = 0,
+		// then every row referencing it fails the check.
+		if parent, err := is.TableByName(dbName, fk.RefTable); err == nil {
+			lookup, err := buildFKLookup(parent, fk.RefCols)
+			if err != nil {
+				return nil, err
+			}
+			check.FKLookup = *lookup
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// buildParentFKTriggers builds the referential actions of the foreign keys which refer to the parent table.
+func buildParentFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, dbName model.CIStr, tbl table.Table, tp FKCascadeType) ([]*FKCheck, []*FKCascade, error) {
+	var (
+		checks   []*FKCheck
+		cascades []*FKCascade
+	)
+	for _, referred := range is.GetTableReferredForeignKeys(dbName, tbl.Meta().Name) {
+		fk := referred.ChildFK
+		offsets, err := columnOffsets(tbl, fk.RefCols)
+		if err != nil {
+			// The referenced columns don't exist, so no row can be referenced.
+			continue
+		}
+		lookup, err := buildFKLookup(referred.ChildTable, fk.Cols)
+		if err != nil {
+			return nil, nil, err
+		}
+		action := ast.ReferOptionType(fk.OnDelete)
+		if tp == FKCascadeOnUpdate {
+			action = ast.ReferOptionType(fk.OnUpdate)
+		}
+		switch action {
+		case ast.ReferOptionCascade, ast.ReferOptionSetNull:
+			cascade := FKCascade{
+				FKLookup:   *lookup,
+				Tp:         tp,
+				DBName:     dbName,
+				FK:         fk,
+				Action:     action,
+				ColOffsets: offsets,
+			}.Init(ctx)
+			cascades = append(cascades, cascade)
+		default:
+			// RESTRICT, NO ACTION and SET DEFAULT are all treated as RESTRICT, like InnoDB does.
+			check := FKCheck{
+				FKLookup:   *lookup,
+				FK:         fk,
+				ReferTable: referred.ChildTable.Meta().Name,
+				ColOffsets: offsets,
+				Constraint: fkConstraintDesc(dbName, referred.ChildTable.Meta(), fk),
+			}.Init(ctx)
+			checks = append(checks, check)
+		}
+	}
+	return checks, cascades, nil
+}
+
+// BuildOnInsertFKTriggers builds the foreign key checks for inserting rows into the table.
+func BuildOnInsertFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, dbName model.CIStr, tbl table.Table) ([]*FKCheck, error) {
+	return buildChildFKChecks(ctx, is, dbName, tbl)
+}
+
+// BuildOnUpdateFKTriggers builds the foreign key checks and referential actions for updating rows of the table.
+func BuildOnUpdateFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, dbName model.CIStr, tbl table.Table) ([]*FKCheck, []*FKCascade, error) {
+	checks, err := buildChildFKChecks(ctx, is, dbName, tbl)
+	if err != nil {
+		return nil, nil, err
+	}
+	parentChecks, cascades, err := buildParentFKTriggers(ctx, is, dbName, tbl, FKCascadeOnUpdate)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(checks, parentChecks...), cascades, nil
+}
+
+// BuildOnDeleteFKTriggers builds the foreign key checks and referential actions for deleting rows of the table.
+func BuildOnDeleteFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, dbName model.CIStr, tbl table.Table) ([]*FKCheck, []*FKCascade, error) {
+	return buildParentFKTriggers(ctx, is, dbName, tbl, FKCascadeOnDelete)
+}
+
+// FKTriggers are the foreign key checks and referential actions for the rows written into a table.
+type FKTriggers struct {
+	Checks   []*FKCheck
+	Cascades []*FKCascade
+}
+
+// Empty returns whether there is nothing to check or to cascade.
+func (t *FKTriggers) Empty() bool {
+	return t == nil || (len(t.Checks) == 0 && len(t.Cascades) == 0)
+}
+
+func (t *FKTriggers) plans() []Plan {
+	if t == nil {
+		return nil
+	}
+	plans := make([]Plan, 0, len(t.Checks)+len(t.Cascades))
+	for _, check := range t.Checks {
+		plans = append(plans, check)
+	}
+	for _, cascade := range t.Cascades {
+		plans = append(plans, cascade)
+	}
+	return plans
+}
+
+func getTableDBName(is infoschema.InfoSchema, tblInfo *model.TableInfo) (model.CIStr, bool) {
+	dbInfo, ok := is.SchemaByTable(tblInfo)
+	if !ok {
+		return model.CIStr{}, false
+	}
+	return dbInfo.Name, true
+}
+
+func hasForeignKeyRelation(is infoschema.InfoSchema, dbName model.CIStr, tblInfo *model.TableInfo) bool {
+	return len(tblInfo.ForeignKeys) > 0 || len(is.GetTableReferredForeignKeys(dbName, tblInfo.Name)) > 0
+}
+
+// filterByUpdatedCols removes the checks and referential actions which are irrelevant to the updated columns.
+func (t *FKTriggers) filterByUpdatedCols(updatedCols map[string]struct{}) {
+	isUpdated := func(cols []model.CIStr) bool {
+		for _, col := range cols {
+			if _, ok := updatedCols[col.L]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	checks := t.Checks[:0]
+	for _, check := range t.Checks {
+		if (check.CheckExist && isUpdated(check.FK.Cols)) || (!check.CheckExist && isUpdated(check.FK.RefCols)) {
+			checks = append(checks, check)
+		}
+	}
+	t.Checks = checks
+	cascades := t.Cascades[:0]
+	for _, cascade := range t.Cascades {
+		if isUpdated(cascade.FK.RefCols) {
+			cascades = append(cascades, cascade)
+		}
+	}
+	t.Cascades = cascades
+}
+
+func (p *Insert) buildOnInsertFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, dbName model.CIStr) error {
+	if !hasForeignKeyRelation(is, dbName, p.Table.Meta()) {
+		return nil
+	}
+	checks, err := BuildOnInsertFKTriggers(ctx, is, dbName, p.Table)
+	if err != nil {
+		return err
+	}
+	p.FKTriggers = &FKTriggers{Checks: checks}
+	if p.IsReplace {
+		// REPLACE deletes the conflicting rows before inserting the new one.
+		p.OnDeleteFKTriggers = &FKTriggers{}
+		p.OnDeleteFKTriggers.Checks, p.OnDeleteFKTriggers.Cascades, err = BuildOnDeleteFKTriggers(ctx, is, dbName, p.Table)
+	} else if len(p.OnDuplicate) > 0 {
+		p.OnUpdateFKTriggers = &FKTriggers{}
+		p.OnUpdateFKTriggers.Checks, p.OnUpdateFKTriggers.Cascades, err = BuildOnUpdateFKTriggers(ctx, is, dbName, p.Table)
+		updatedCols := make(map[string]struct{}, len(p.OnDuplicate))
+		for _, assign := range p.OnDuplicate {
+			updatedCols[assign.ColName.L] = struct{}{}
+		}
+		p.OnUpdateFKTriggers.filterByUpdatedCols(updatedCols)
+	}
+	return err
+}
+
+func (p *Update) buildOnUpdateFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, tblID2table map[int64]table.Table) error {
+	// The memory tables such as the ones in performance_schema have no handle, they can't have foreign keys.
+	if len(p.TblColPosInfos) == 0 {
+		return nil
+	}
+	tblID2UpdatedCols := make(map[int64]map[string]struct{}, len(p.TblColPosInfos))
+	for _, assign := range p.OrderedList {
+		tblID := p.TblColPosInfos[0].TblID
+		if len(p.TblColPosInfos) > 1 {
+			idx, found := p.TblColPosInfos.FindTblIdx(assign.Col.Index)
+			if !found {
+				continue
+			}
+			tblID = p.TblColPosInfos[idx].TblID
+		}
+		if tblID2UpdatedCols[tblID] == nil {
+			tblID2UpdatedCols[tblID] = make(map[string]struct{})
+		}
+		tblID2UpdatedCols[tblID][assign.ColName.L] = struct{}{}
+	}
+	for tid, updatedCols := range tblID2UpdatedCols {
+		tbl, ok := tblID2table[tid]
+		if !ok {
+			continue
+		}
+		dbName, ok := getTableDBName(is, tbl.Meta())
+		if !ok || !hasForeignKeyRelation(is, dbName, tbl.Meta()) {
+			continue
+		}
+		triggers := &FKTriggers{}
+		var err error
+		triggers.Checks, triggers.Cascades, err = BuildOnUpdateFKTriggers(ctx, is, dbName, tbl)
+		if err != nil {
+			return err
+		}
+		triggers.filterByUpdatedCols(updatedCols)
+		if triggers.Empty() {
+			continue
+		}
+		if p.FKTriggers == nil {
+			p.FKTriggers = make(map[int64]*FKTriggers)
+		}
+		p.FKTriggers[tid] = triggers
+	}
+	return nil
+}
+
+func (p *Delete) buildOnDeleteFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, tblID2table map[int64]table.Table) error {
+	for tid, tbl := range tblID2table {
+		dbName, ok := getTableDBName(is, tbl.Meta())
+		if !ok || !hasForeignKeyRelation(is, dbName, tbl.Meta()) {
+			continue
+		}
+		triggers := &FKTriggers{}
+		var err error
+		triggers.Checks, triggers.Cascades, err = BuildOnDeleteFKTriggers(ctx, is, dbName, tbl)
+		if err != nil {
+			return err
+		}
+		if triggers.Empty() {
+			continue
+		}
+		if p.FKTriggers == nil {
+			p.FKTriggers = make(map[int64]*FKTriggers)
+		}
+		p.FKTriggers[tid] = triggers
+	}
+	return nil
+}
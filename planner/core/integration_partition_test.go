@@ -22,9 +22,11 @@ import (
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/parser/auth"
+	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/israce"
 	"github.com/pingcap/tidb/util/testkit"
 	"github.com/pingcap/tidb/util/testutil"
@@ -974,6 +976,23 @@ PARTITION BY LIST (COL1 DIV COL3) (
 	tk.MustQuery(`select * from UK_LP17321 where col1 is null`).Check(testkit.Rows()) // without any error
 }
 
+func (s *testIntegrationPartitionSerialSuite) TestKeyPartitionCollation(c *C) {
+	defer collate.SetNewCollationEnabledForTest(false)
+	collate.SetNewCollationEnabledForTest(true)
+	tk := testkit.NewTestKitWithInit(c, s.store)
+	tk.MustExec("create database key_partition_collation")
+	tk.MustExec("use key_partition_collation")
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	tk.MustExec("create table t (a varchar(10) collate utf8mb4_general_ci primary key nonclustered, b int) partition by key(a) partitions 7")
+	tk.MustExec("insert into t values ('abc', 1), ('xyz ', 2)")
+	// Strings that are equal under the column collation must be routed to the same partition.
+	tk.MustGetErrCode("insert into t values ('ABC', 3)", mysql.ErrDupEntry)
+	tk.MustGetErrCode("insert into t values ('XYZ', 3)", mysql.ErrDupEntry)
+	tk.MustQuery("select b from t where a = 'ABC'").Check(testkit.Rows("1"))
+	tk.MustQuery("select b from t where a = 'Xyz'").Check(testkit.Rows("2"))
+	tk.MustQuery("select b from t where a in ('aBc', 'XYZ') order by b").Check(testkit.Rows("1", "2"))
+}
+
 func genListPartition(begin, end int) string {
 	buf := &bytes.Buffer{}
 	buf.WriteString("(")
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/parser"
 	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/charset"
 	"github.com/pingcap/parser/format"
 	"github.com/pingcap/parser/model"
 	"github.com/pingcap/parser/mysql"
@@ -51,6 +52,7 @@ import (
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/table/temptable"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	driver "github.com/pingcap/tidb/types/parser_driver"
 	util2 "github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
@@ -339,6 +341,9 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, &x.AsName)
+			isTableName = true
 		default:
 			err = ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -354,7 +359,7 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 				name.TblName = x.AsName
 			}
 		}
-		// `TableName` is not a select block, so we do not need to handle it.
+		// `TableName` and `JSONTable` are not select blocks, so we do not need to handle them.
 		if !isTableName && b.ctx.GetSessionVars().PlannerSelectBlockAsName != nil {
 			b.ctx.GetSessionVars().PlannerSelectBlockAsName[p.SelectBlockOffset()] = ast.HintTable{DBName: p.OutputNames()[0].DBName, TableName: p.OutputNames()[0].TblName}
 		}
@@ -683,10 +688,27 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		return nil, err
 	}
 
+	// JSON_TABLE can refer to the tables on its left, so they are the outer schema of its document,
+	// and the join is built as an apply.
+	jsonTableSource, isLateral := joinNode.Right.(*ast.TableSource)
+	if isLateral {
+		_, isLateral = jsonTableSource.Source.(*ast.JSONTable)
+	}
+	if isLateral {
+		b.outerSchemas = append(b.outerSchemas, leftPlan.Schema().Clone())
+		b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	}
 	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right)
+	if isLateral {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+	}
 	if err != nil {
 		return nil, err
 	}
+	if isLateral && joinNode.Tp == ast.RightJoin && len(extractCorColumnsBySchema4LogicalPlan(rightPlan, leftPlan.Schema())) > 0 {
+		return nil, ErrTFForbiddenJoinType.GenWithStackByArgs(jsonTableSource.AsName.O)
+	}
 
 	// The recursive part in CTE must not be on the right side of a LEFT JOIN.
 	if lc, ok := rightPlan.(*LogicalCTETable); ok && joinNode.Tp == ast.LeftJoin {
@@ -697,7 +719,19 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 	handleMap2 := b.handleHelper.popMap()
 	b.handleHelper.mergeAndPush(handleMap1, handleMap2)
 
-	joinPlan := LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+	var (
+		joinPlan   *LogicalJoin
+		resultPlan LogicalPlan
+	)
+	if isLateral && joinNode.Tp != ast.RightJoin {
+		// The apply is turned back into a join by decorrelation if the document doesn't refer to the left side.
+		b.optFlag = b.optFlag | flagBuildKeyInfo | flagDecorrelate
+		ap := LogicalApply{LogicalJoin: LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}}.Init(b.ctx, b.getSelectOffset())
+		joinPlan, resultPlan = &ap.LogicalJoin, ap
+	} else {
+		joinPlan = LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+		resultPlan = joinPlan
+	}
 	joinPlan.SetChildren(leftPlan, rightPlan)
 	joinPlan.SetSchema(expression.MergeSchema(leftPlan.Schema(), rightPlan.Schema()))
 	joinPlan.names = make([]*types.FieldName, leftPlan.Schema().Len()+rightPlan.Schema().Len())
@@ -762,11 +796,11 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		}
 	} else if joinNode.On != nil {
 		b.curClause = onClause
-		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, joinPlan, nil, false)
+		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, resultPlan, nil, false)
 		if err != nil {
 			return nil, err
 		}
-		if newPlan != joinPlan {
+		if newPlan != resultPlan {
 			return nil, errors.New("ON condition doesn't support subqueries yet")
 		}
 		onCondition := expression.SplitCNFItems(onExpr)
@@ -774,7 +808,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == InnerJoin {
 			sel := LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			sel.SetChildren(resultPlan)
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -784,7 +818,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		joinPlan.cartesianJoin = true
 	}
 
-	return joinPlan, nil
+	return resultPlan, nil
 }
 
 // buildUsingClause eliminate the redundant columns and ordering columns based
@@ -3720,6 +3754,136 @@ func (b *PlanBuilder) buildTableDual() *LogicalTableDual {
 	return LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
 }
 
+// buildJSONTable builds the JSON_TABLE table function. The document is rewritten without a schema
+// of its own, so its columns can only be found in the outer schemas: the tables on the left of
+// JSON_TABLE, see buildJoin, and the outer queries.
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName *model.CIStr) (LogicalPlan, error) {
+	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	doc, np, err := b.rewrite(ctx, jt.Expr, mockTablePlan, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if np != mockTablePlan {
+		return nil, errors.New("JSON_TABLE doesn't support correlated subqueries in the document yet")
+	}
+	p := LogicalJSONTable{Expr: expression.WrapWithCastAsJSON(b.ctx, doc)}.Init(b.ctx, b.getSelectOffset())
+	p.schema = expression.NewSchema()
+	p.Root, err = b.buildJSONTableNestedPath(p, jt.Path, jt.Columns, asName)
+	if err != nil {
+		return nil, err
+	}
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+// buildJSONTableNestedPath builds a node of the column tree, and appends its columns to the schema of p.
+func (b *PlanBuilder) buildJSONTableNestedPath(p *LogicalJSONTable, path string, cols []*ast.JSONTableColumn, asName *model.CIStr) (*JSONTableNestedPath, error) {
+	pathExpr, err := json.ParseJSONPathExpr(path)
+	if err != nil {
+		return nil, err
+	}
+	node := &JSONTableNestedPath{Path: pathExpr}
+	for _, col := range cols {
+		if col.Tp == ast.JSONTableColumnNested {
+			nested, err := b.buildJSONTableNestedPath(p, col.Path, col.Columns, asName)
+			if err != nil {
+				return nil, err
+			}
+			node.Nested = append(node.Nested, nested)
+			continue
+		}
+		column, err := b.buildJSONTableColumn(col)
+		if err != nil {
+			return nil, err
+		}
+		column.Offset = p.schema.Len()
+		node.Columns = append(node.Columns, column)
+		name := &types.FieldName{
+			TblName:     *asName,
+			ColName:     col.Name,
+			OrigTblName: *asName,
+			OrigColName: col.Name,
+		}
+		p.names = append(p.names, name)
+		p.schema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  column.RetType,
+			OrigName: name.String(),
+		})
+	}
+	return node, nil
+}
+
+func (b *PlanBuilder) buildJSONTableColumn(col *ast.JSONTableColumn) (*JSONTableColumn, error) {
+	column := &JSONTableColumn{Tp: col.Tp, Name: col.Name}
+	if col.Tp == ast.JSONTableColumnOrdinality {
+		column.RetType = types.NewFieldType(mysql.TypeLong)
+		column.RetType.Flag |= mysql.UnsignedFlag | mysql.NotNullFlag
+		column.RetType.Flen, column.RetType.Decimal = mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLong)
+		types.SetBinChsClnFlag(column.RetType)
+		return column, nil
+	}
+	var err error
+	column.RetType, err = b.buildJSONTableColumnType(col.FieldType)
+	if err != nil {
+		return nil, err
+	}
+	column.Path, err = json.ParseJSONPathExpr(col.Path)
+	if err != nil {
+		return nil, err
+	}
+	column.OnEmpty, err = b.buildJSONTableOnResponse(column, col.OnEmpty)
+	if err != nil {
+		return nil, err
+	}
+	column.OnError, err = b.buildJSONTableOnResponse(column, col.OnError)
+	return column, err
+}
+
+// buildJSONTableColumnType fills the default length, decimal and charset of the type of a column.
+func (b *PlanBuilder) buildJSONTableColumnType(tp *types.FieldType) (*types.FieldType, error) {
+	ft := tp.Clone()
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.Tp)
+	if ft.Flen == types.UnspecifiedLength {
+		ft.Flen = defaultFlen
+	}
+	if ft.Decimal == types.UnspecifiedLength {
+		ft.Decimal = defaultDecimal
+	}
+	if ft.EvalType() != types.ETString {
+		types.SetBinChsClnFlag(ft)
+		return ft, nil
+	}
+	if ft.Charset == "" {
+		ft.Charset, ft.Collate = b.ctx.GetSessionVars().GetCharsetInfo()
+	} else if ft.Collate == "" {
+		collate, err := charset.GetDefaultCollation(ft.Charset)
+		if err != nil {
+			return nil, err
+		}
+		ft.Collate = collate
+	}
+	return ft, nil
+}
+
+// buildJSONTableOnResponse builds the ON EMPTY or ON ERROR clause of a column, whose DEFAULT value
+// is converted to the column type now. An absent clause is NULL ON EMPTY or NULL ON ERROR.
+func (b *PlanBuilder) buildJSONTableOnResponse(col *JSONTableColumn, onResponse *ast.JSONTableOnResponse) (JSONTableOnResponse, error) {
+	if onResponse == nil {
+		return JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}, nil
+	}
+	res := JSONTableOnResponse{Tp: onResponse.Tp}
+	if onResponse.Tp != ast.JSONTableOnResponseDefault {
+		return res, nil
+	}
+	bj, err := json.ParseBinaryFromString(onResponse.Default)
+	if err != nil {
+		return res, err
+	}
+	res.Default, err = col.Convert(NewJSONTableStmtCtx(b.ctx), bj)
+	return res, err
+}
+
 func (ds *DataSource) newExtraHandleSchemaCol() *expression.Column {
 	tp := types.NewFieldType(mysql.TypeLonglong)
 	tp.Flag = mysql.NotNullFlag | mysql.PriKeyFlag
@@ -4131,7 +4295,8 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 
 	var result LogicalPlan = ds
 	dirty := tableHasDirtyContent(b.ctx, tableInfo)
-	if dirty || tableInfo.TempTableType == model.TempTableLocal {
+	_, isCachedTable := tbl.(table.CachedTable)
+	if dirty || tableInfo.TempTableType == model.TempTableLocal || isCachedTable {
 		us := LogicalUnionScan{handleCols: handleCols}.Init(b.ctx, b.getSelectOffset())
 		us.SetChildren(ds)
 		result = us
@@ -4686,8 +4851,12 @@ func (b *PlanBuilder) buildUpdate(ctx context.Context, update *ast.UpdateStmt) (
 		tblID2table[id], _ = b.is.TableByID(id)
 	}
 	updt.TblColPosInfos, err = buildColumns2Handle(updt.OutputNames(), tblID2Handle, tblID2table, true)
+	if err != nil {
+		return nil, err
+	}
 	updt.PartitionedTable = b.partitionedTable
 	updt.tblID2Table = tblID2table
+	err = updt.buildOnUpdateFKTriggers(b.ctx, b.is, tblID2table)
 	return updt, err
 }
 
@@ -5118,6 +5287,10 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, delete *ast.DeleteStmt) (
 		tblID2table[id], _ = b.is.TableByID(id)
 	}
 	del.TblColPosInfos, err = buildColumns2Handle(del.names, tblID2Handle, tblID2table, false)
+	if err != nil {
+		return nil, err
+	}
+	err = del.buildOnDeleteFKTriggers(b.ctx, b.is, tblID2table)
 	return del, err
 }
 
@@ -27,9 +27,11 @@ import (
 	"github.com/pingcap/tidb/planner/property"
 	"github.com/pingcap/tidb/planner/util"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/ranger"
 	"go.uber.org/zap"
@@ -43,6 +45,7 @@ var (
 	_ LogicalPlan = &LogicalApply{}
 	_ LogicalPlan = &LogicalMaxOneRow{}
 	_ LogicalPlan = &LogicalTableDual{}
+	_ LogicalPlan = &LogicalJSONTable{}
 	_ LogicalPlan = &DataSource{}
 	_ LogicalPlan = &TiKVSingleGather{}
 	_ LogicalPlan = &LogicalTableScan{}
@@ -483,6 +486,95 @@ type LogicalTableDual struct {
 	RowCount int
 }
 
+// LogicalJSONTable represents the JSON_TABLE table function. It has no children. Its document
+// can refer to the tables on its left by correlated columns, so it's the inner side of an apply.
+type LogicalJSONTable struct {
+	logicalSchemaProducer
+
+	// Expr is the JSON document.
+	Expr expression.Expression
+	// Root is the root of the column tree. Its path is the row path of the table.
+	Root *JSONTableNestedPath
+}
+
+// ExtractCorrelatedCols implements LogicalPlan interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
+// JSONTableNestedPath is a node of the column tree of JSON_TABLE. The root node is the row path
+// of the table, and the children are the NESTED PATH clauses.
+type JSONTableNestedPath struct {
+	// Path selects the rows of the node from the value of the parent row.
+	Path    json.PathExpression
+	Columns []*JSONTableColumn
+	Nested  []*JSONTableNestedPath
+}
+
+// JSONTableColumn is a column of JSON_TABLE other than NESTED PATH.
+type JSONTableColumn struct {
+	Tp   ast.JSONTableColumnType
+	Name model.CIStr
+	// Offset is the offset of the column in the schema of JSON_TABLE.
+	Offset  int
+	RetType *types.FieldType
+	// Path is the path of a PATH or EXISTS PATH column.
+	Path    json.PathExpression
+	OnEmpty JSONTableOnResponse
+	OnError JSONTableOnResponse
+}
+
+// JSONTableOnResponse is the ON EMPTY or ON ERROR clause of a PATH column.
+type JSONTableOnResponse struct {
+	Tp ast.JSONTableOnResponseType
+	// Default is the DEFAULT value converted to the column type.
+	Default types.Datum
+}
+
+// NewJSONTableStmtCtx returns the statement context for JSONTableColumn.Convert. None of its flags
+// is set, so every truncation or overflow is an error, which is handled by ON ERROR.
+func NewJSONTableStmtCtx(sctx sessionctx.Context) *stmtctx.StatementContext {
+	return &stmtctx.StatementContext{TimeZone: sctx.GetSessionVars().Location()}
+}
+
+// Convert converts a JSON value to the type of the column, as CAST(JSON_UNQUOTE(value) AS type) does.
+// Objects and arrays can only be converted to JSON.
+func (col *JSONTableColumn) Convert(sc *stmtctx.StatementContext, bj json.BinaryJSON) (types.Datum, error) {
+	var d types.Datum
+	if col.RetType.Tp == mysql.TypeJSON {
+		d.SetMysqlJSON(bj)
+		return d, nil
+	}
+	switch bj.TypeCode {
+	case json.TypeCodeObject, json.TypeCodeArray:
+		return d, ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O)
+	case json.TypeCodeLiteral:
+		if bj.Value[0] == json.LiteralNil {
+			return d, nil
+		}
+		if col.RetType.EvalType() == types.ETString {
+			d.SetString(bj.String(), col.RetType.Collate)
+		} else if bj.Value[0] == json.LiteralTrue {
+			d.SetInt64(1)
+		} else {
+			d.SetInt64(0)
+		}
+	case json.TypeCodeInt64:
+		d.SetInt64(bj.GetInt64())
+	case json.TypeCodeUint64:
+		d.SetUint64(bj.GetUint64())
+	case json.TypeCodeFloat64:
+		d.SetFloat64(bj.GetFloat64())
+	default:
+		str, err := bj.Unquote()
+		if err != nil {
+			return d, err
+		}
+		d.SetString(str, col.RetType.Collate)
+	}
+	return d.ConvertTo(sc, col.RetType)
+}
+
 // LogicalMemTable represents a memory table or virtual table
 // Some memory tables wants to take the ownership of some predications
 // e.g
@@ -533,7 +625,7 @@ type DataSource struct {
 	// pushedDownConds are the conditions that will be pushed down to coprocessor.
 	pushedDownConds []expression.Expression
 	// allConds contains all the filters on this table. For now it's maintained
-	// in predicate push down and used only in partition pruning.
+	// in predicate push down and used in partition pruning and multi-valued index access.
 	allConds []expression.Expression
 
 	statisticTable *statistics.Table
@@ -1350,11 +1350,14 @@ type StatementsSummaryExtractor struct {
 	// Enable is true means the executor should use digest to locate statement summary.
 	// Enable is false, means the executor should keep the behavior compatible with before.
 	Enable bool
+	// CoarseTimeRange is the time range which the summaries may fall in, it's used to locate the persisted
+	// history of statement summary. The predicates on time are still evaluated, so it's coarse.
+	CoarseTimeRange *TimeRange
 }
 
 // Extract implements the MemTablePredicateExtractor Extract interface
 func (e *StatementsSummaryExtractor) Extract(
-	_ sessionctx.Context,
+	sctx sessionctx.Context,
 	schema *expression.Schema,
 	names []*types.FieldName,
 	predicates []expression.Expression,
@@ -1369,6 +1372,25 @@ func (e *StatementsSummaryExtractor) Extract(
 		e.Enable = true
 		e.Digests = digests
 	}
+	// The time predicates are kept in the remained, so the returned remained are ignored.
+	timezone := sctx.GetSessionVars().StmtCtx.TimeZone
+	_, beginStart, beginEnd := e.extractTimeRange(sctx, schema, names, remained, "summary_begin_time", timezone)
+	_, endStart, endEnd := e.extractTimeRange(sctx, schema, names, remained, "summary_end_time", timezone)
+	// A summary in [begin, end] matches if begin >= beginStart, begin <= beginEnd, end >= endStart and end <= endEnd.
+	start := mathutil.MaxInt64(beginStart, endStart)
+	end := beginEnd
+	if end == 0 || (endEnd != 0 && endEnd < end) {
+		end = endEnd
+	}
+	if start != 0 || end != 0 {
+		e.CoarseTimeRange = &TimeRange{}
+		if start != 0 {
+			e.CoarseTimeRange.StartTime = time.Unix(0, start)
+		}
+		if end != 0 {
+			e.CoarseTimeRange.EndTime = time.Unix(0, end)
+		}
+	}
 	return remained
 }
 
@@ -1334,3 +1334,63 @@ func (s *extractorSuite) TestTiDBHotRegionsHistoryTableExtractor(c *C) {
 		}
 	}
 }
+
+func (s *extractorSuite) TestStatementsSummaryExtractor(c *C) {
+	se, err := session.CreateSession4Test(s.store)
+	c.Assert(err, IsNil)
+	se.GetSessionVars().StmtCtx.TimeZone = time.Local
+
+	var cases = []struct {
+		sql                string
+		digests            set.StringSet
+		startTime, endTime int64
+	}{
+		{
+			sql: "select * from information_schema.statements_summary_history",
+		},
+		{
+			sql:     "select * from information_schema.statements_summary_history where digest='abc'",
+			digests: set.NewStringSet("abc"),
+		},
+		{
+			sql:       "select * from information_schema.statements_summary_history where summary_begin_time>='2019-10-10 10:10:10'",
+			startTime: timestamp(c, "2019-10-10 10:10:10"),
+		},
+		{
+			sql:     "select * from information_schema.statements_summary_history where summary_end_time<='2019-10-11 10:10:10'",
+			endTime: timestamp(c, "2019-10-11 10:10:10"),
+		},
+		{
+			sql: `select * from information_schema.statements_summary_history where summary_begin_time>='2019-10-10 10:10:10'
+					and summary_end_time>='2019-10-10 11:10:10' and summary_begin_time<='2019-10-11 10:10:10' and digest='abc'`,
+			digests:   set.NewStringSet("abc"),
+			startTime: timestamp(c, "2019-10-10 11:10:10"),
+			endTime:   timestamp(c, "2019-10-11 10:10:10"),
+		},
+	}
+	parser := parser.New()
+	for _, ca := range cases {
+		logicalMemTable := s.getLogicalMemTable(c, se, parser, ca.sql)
+		c.Assert(logicalMemTable.Extractor, NotNil, Commentf("SQL: %v", ca.sql))
+
+		extractor := logicalMemTable.Extractor.(*plannercore.StatementsSummaryExtractor)
+		if len(ca.digests) > 0 {
+			c.Assert(extractor.Digests, DeepEquals, ca.digests, Commentf("SQL: %v", ca.sql))
+		}
+		if ca.startTime == 0 && ca.endTime == 0 {
+			c.Assert(extractor.CoarseTimeRange, IsNil, Commentf("SQL: %v", ca.sql))
+			continue
+		}
+		c.Assert(extractor.CoarseTimeRange, NotNil, Commentf("SQL: %v", ca.sql))
+		if ca.startTime == 0 {
+			c.Assert(extractor.CoarseTimeRange.StartTime.IsZero(), IsTrue, Commentf("SQL: %v", ca.sql))
+		} else {
+			c.Assert(extractor.CoarseTimeRange.StartTime.UnixNano()/int64(time.Millisecond), Equals, ca.startTime, Commentf("SQL: %v", ca.sql))
+		}
+		if ca.endTime == 0 {
+			c.Assert(extractor.CoarseTimeRange.EndTime.IsZero(), IsTrue, Commentf("SQL: %v", ca.sql))
+		} else {
+			c.Assert(extractor.CoarseTimeRange.EndTime.UnixNano()/int64(time.Millisecond), Equals, ca.endTime, Commentf("SQL: %v", ca.sql))
+		}
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/util/hint"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+var nonPreparedPlanCacheCounter = metrics.PlanCacheCounter.WithLabelValues("non-prepared")
+
+// nonPreparedPlanCacheValue is the value stored in the plan cache for a parameterized text statement.
+// Unlike prepared statements, there is no prepared object to hold the privilege information of the
+// statement, so it is kept along with the cached plans and checked on every hit.
+type nonPreparedPlanCacheValue struct {
+	visitInfos     []visitInfo
+	normalizedPlan string
+	planDigest     *parser.Digest
+	plans          []*PSTMTPlanCacheValue
+}
+
+// literalParameterizer replaces the literals in an expression with parameter markers.
+type literalParameterizer struct {
+	literals []*driver.ValueExpr
+	params   []*driver.ParamMarkerExpr
+	// hasParamMarker is set if the expression already contains parameter markers,
+	// the statement should not be parameterized again in that case.
+	hasParamMarker bool
+}
+
+// Enter implements Visitor interface.
+func (p *literalParameterizer) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
+	switch in.(type) {
+	case *driver.ParamMarkerExpr:
+		p.hasParamMarker = true
+		return in, true
+	case *ast.SetCollationExpr:
+		// The collation of the literal is given explicitly, keep it as it is.
+		return in, true
+	}
+	return in, false
+}
+
+// Leave implements Visitor interface.
+func (p *literalParameterizer) Leave(in ast.Node) (out ast.Node, ok bool) {
+	literal, isLiteral := in.(*driver.ValueExpr)
+	if !isLiteral || !isParameterizableLiteral(literal) {
+		return in, true
+	}
+	param := &driver.ParamMarkerExpr{
+		ValueExpr: *literal,
+		Order:     len(p.params),
+		InExecute: true,
+	}
+	p.literals = append(p.literals, literal)
+	p.params = append(p.params, param)
+	return param, true
+}
+
+// isParameterizableLiteral checks whether the literal keeps its type after being replaced by a
+// parameter marker, whose type is always derived from its value with the default charset.
+func isParameterizableLiteral(literal *driver.ValueExpr) bool {
+	switch literal.Kind() {
+	case types.KindInt64, types.KindUint64, types.KindFloat64, types.KindMysqlDecimal:
+		return true
+	case types.KindString:
+		return literal.Type.Charset == mysql.DefaultCharset && literal.Type.Collate == mysql.DefaultCollationName
+	}
+	return false
+}
+
+// literalRestorer puts the literals replaced by literalParameterizer back.
+type literalRestorer struct {
+	literals map[*driver.ParamMarkerExpr]*driver.ValueExpr
+}
+
+// Enter implements Visitor interface.
+func (r *literalRestorer) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
+	return in, false
+}
+
+// Leave implements Visitor interface.
+func (r *literalRestorer) Leave(in ast.Node) (out ast.Node, ok bool) {
+	if param, isParam := in.(*driver.ParamMarkerExpr); isParam {
+		if literal, found := r.literals[param]; found {
+			return literal, true
+		}
+	}
+	return in, true
+}
+
+// parameterizeExpr replaces the literals in the expression, and returns the new expression.
+func parameterizeExpr(p *literalParameterizer, expr ast.ExprNode) ast.ExprNode {
+	if expr == nil {
+		return nil
+	}
+	newExpr, _ := expr.Accept(p)
+	return newExpr.(ast.ExprNode)
+}
+
+// parameterizeStmt replaces the literals in the WHERE clause and the assignments of the statement with
+// parameter markers. It returns the replaced literals and a function to restore the statement.
+func parameterizeStmt(stmt ast.StmtNode) (literals []*driver.ValueExpr, params []*driver.ParamMarkerExpr, restore func(), ok bool) {
+	p := &literalParameterizer{}
+	switch x := stmt.(type) {
+	case *ast.SelectStmt:
+		x.Where = parameterizeExpr(p, x.Where)
+	case *ast.UpdateStmt:
+		for _, assign := range x.List {
+			assign.Expr = parameterizeExpr(p, assign.Expr)
+		}
+		x.Where = parameterizeExpr(p, x.Where)
+	case *ast.DeleteStmt:
+		x.Where = parameterizeExpr(p, x.Where)
+	}
+	r := &literalRestorer{literals: make(map[*driver.ParamMarkerExpr]*driver.ValueExpr, len(p.params))}
+	for i, param := range p.params {
+		r.literals[param] = p.literals[i]
+	}
+	restore = func() {
+		switch x := stmt.(type) {
+		case *ast.SelectStmt:
+			x.Where = restoreExpr(r, x.Where)
+		case *ast.UpdateStmt:
+			for _, assign := range x.List {
+				assign.Expr = restoreExpr(r, assign.Expr)
+			}
+			x.Where = restoreExpr(r, x.Where)
+		case *ast.DeleteStmt:
+			x.Where = restoreExpr(r, x.Where)
+		}
+	}
+	if p.hasParamMarker {
+		restore()
+		return nil, nil, nil, false
+	}
+	return p.literals, p.params, restore, true
+}
+
+func restoreExpr(r *literalRestorer, expr ast.ExprNode) ast.ExprNode {
+	if expr == nil {
+		return nil
+	}
+	newExpr, _ := expr.Accept(r)
+	return newExpr.(ast.ExprNode)
+}
+
+// GetPlanFromNonPreparedPlanCache parameterizes the literals of a simple text-protocol statement, and tries to
+// get its plan from the plan cache. If the plan is not cached, the parameterized statement is optimized and its
+// plan is put into the plan cache. The returned bool is false if the statement cannot use the plan cache.
+func GetPlanFromNonPreparedPlanCache(ctx context.Context, sctx sessionctx.Context, stmt ast.StmtNode, is infoschema.InfoSchema) (Plan, types.NameSlice, bool, error) {
+	sessVars := sctx.GetSessionVars()
+	stmtCtx := sessVars.StmtCtx
+	checkIS := is
+	if sessVars.UseDynamicPartitionPrune() {
+		checkIS = nil
+	}
+	if !NonPreparedPlanCacheable(stmt, checkIS) {
+		return nil, nil, false, nil
+	}
+	literals, params, restore, ok := parameterizeStmt(stmt)
+	if !ok {
+		return nil, nil, false, nil
+	}
+	// The statement is put back after optimization, the plan refers to the parameters through
+	// sessVars.PreparedParams instead of the ast.
+	defer restore()
+
+	var sb strings.Builder
+	if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return nil, nil, false, nil
+	}
+	digest := parser.DigestNormalized(sb.String())
+	cacheKey := NewNonPreparedPlanCacheKey(sessVars, digest.String(), is.SchemaMetaVersion())
+
+	sessVars.PreparedParams = sessVars.PreparedParams[:0]
+	tps := make([]*types.FieldType, len(literals))
+	for i, literal := range literals {
+		sessVars.PreparedParams = append(sessVars.PreparedParams, literal.Datum)
+		tps[i] = types.NewFieldType(mysql.TypeUnspecified)
+		types.DefaultParamTypeForValue(params[i].GetValue(), tps[i])
+	}
+	stmtCtx.UseCache = true
+
+	// Execute is only used to share the methods for rebuilding ranges and setting the
+	// plan cache flag with the prepared plan cache.
+	e := &Execute{}
+	if cacheValue, exists := sctx.PreparedPlanCache().Get(cacheKey); exists {
+		cached := cacheValue.(*nonPreparedPlanCacheValue)
+		if pm := privilege.GetPrivilegeManager(sctx); pm != nil {
+			if err := CheckPrivilege(sessVars.ActiveRoles, pm, cached.visitInfos); err != nil {
+				return nil, nil, true, err
+			}
+		}
+		if err := CheckTableLock(sctx, is, cached.visitInfos); err != nil {
+			return nil, nil, true, err
+		}
+		for _, cachedVal := range cached.plans {
+			if !cachedVal.UserVarTypes.Equal(tps) {
+				continue
+			}
+			planValid := true
+			for tblInfo, unionScan := range cachedVal.TblInfo2UnionScan {
+				if !unionScan && tableHasDirtyContent(sctx, tblInfo) {
+					planValid = false
+					sctx.PreparedPlanCache().Delete(cacheKey)
+					break
+				}
+			}
+			if !planValid {
+				break
+			}
+			if err := e.rebuildRange(cachedVal.Plan); err != nil {
+				logutil.BgLogger().Debug("rebuild range failed", zap.Error(err))
+				break
+			}
+			if err := e.setFoundInPlanCache(sctx, true); err != nil {
+				return nil, nil, true, err
+			}
+			if metrics.ResettablePlanCacheCounterFortTest {
+				metrics.PlanCacheCounter.WithLabelValues("non-prepared").Inc()
+			} else {
+				nonPreparedPlanCacheCounter.Inc()
+			}
+			stmtCtx.SetPlanDigest(cached.normalizedPlan, cached.planDigest)
+			return cachedVal.Plan, cachedVal.OutPutNames, true, nil
+		}
+	}
+
+	// Collect the privilege information of the statement like PREPARE does, so the privileges
+	// can be checked when the plan is hit later.
+	warns := stmtCtx.GetWarnings()
+	builder, _ := NewPlanBuilder().Init(sctx, is, &hint.BlockHintProcessor{})
+	if _, err := builder.Build(ctx, stmt); err != nil {
+		return nil, nil, true, err
+	}
+	stmtCtx.SetWarnings(warns)
+	visitInfos := builder.GetVisitInfo()
+
+	p, names, err := OptimizeAstNode(ctx, sctx, stmt, is)
+	if err != nil {
+		return nil, nil, true, err
+	}
+	_, isTableDual := p.(*PhysicalTableDual)
+	if !isTableDual && !stmtCtx.MaybeOverOptimized4PlanCache {
+		cachedVal := NewPSTMTPlanCacheValue(p, names, stmtCtx.TblInfo2UnionScan, tps)
+		normalizedPlan, planDigest := NormalizePlan(p)
+		stmtCtx.SetPlanDigest(normalizedPlan, planDigest)
+		cached := &nonPreparedPlanCacheValue{
+			visitInfos:     visitInfos,
+			normalizedPlan: normalizedPlan,
+			planDigest:     planDigest,
+		}
+		if cacheValue, exists := sctx.PreparedPlanCache().Get(cacheKey); exists {
+			cached = cacheValue.(*nonPreparedPlanCacheValue)
+		}
+		hitVal := false
+		for i, val := range cached.plans {
+			if val.UserVarTypes.Equal(tps) {
+				hitVal = true
+				cached.plans[i] = cachedVal
+				break
+			}
+		}
+		if !hitVal {
+			cached.plans = append(cached.plans, cachedVal)
+		}
+		sctx.PreparedPlanCache().Put(cacheKey, cached)
+	}
+	err = e.setFoundInPlanCache(sctx, false)
+	return p, names, true, err
+}
@@ -499,3 +499,60 @@ func (s *testPartitionPruneSuit) TestIssue26551(c *C) {
 		"TableDual 0.00 root  rows:0"))
 	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1690 BIGINT value is out of range in '(test.t.col1 * test.t.col3)'"))
 }
+
+func (s *testPartitionPruneSuit) TestKeyPartitionPruner(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("drop database if exists test_partition;")
+	tk.MustExec("create database test_partition")
+	tk.MustExec("use test_partition")
+	tk.MustExec("set @@tidb_partition_prune_mode='dynamic'")
+	tk.MustExec("create table t1 (a int, b varchar(10)) partition by key(a) partitions 5")
+	tk.MustExec("create table t2 (a int, b varchar(10)) partition by key(b) partitions 5")
+	tk.MustExec("create table t3 (a int, b varchar(10)) partition by linear key(a, b) partitions 5")
+	tk.MustExec("create table t4 (a int, b varchar(10)) partition by linear hash(a) partitions 5")
+	tk.MustExec("create table t5 (a int primary key, b varchar(10)) partition by key() partitions 5")
+	for _, tbl := range []string{"t1", "t2", "t3", "t4", "t5"} {
+		for i := 0; i < 20; i++ {
+			tk.MustExec(fmt.Sprintf("insert into %s values (%d, 'v%d')", tbl, i, i))
+		}
+	}
+
+	// Every equality condition on the partitioning columns should prune to exactly the partition holding the row.
+	conds := map[string]string{
+		"t1": "a = %d",
+		"t2": "b = 'v%d'",
+		"t3": "a = %d and b = 'v%d'",
+		"t4": "a = %d",
+		"t5": "a = %d",
+	}
+	for tbl, cond := range conds {
+		for i := 0; i < 20; i++ {
+			where := cond
+			if strings.Count(cond, "%d") == 2 {
+				where = fmt.Sprintf(cond, i, i)
+			} else {
+				where = fmt.Sprintf(cond, i)
+			}
+			part := ""
+			for p := 0; p < 5; p++ {
+				rows := tk.MustQuery(fmt.Sprintf("select count(*) from %s partition(p%d) where %s", tbl, p, where)).Rows()
+				if rows[0][0] == "1" {
+					c.Assert(part, Equals, "", Commentf("table %s, cond %s", tbl, where))
+					part = fmt.Sprintf("p%d", p)
+				}
+			}
+			c.Assert(part, Not(Equals), "", Commentf("table %s, cond %s", tbl, where))
+
+			query := fmt.Sprintf("select * from %s where %s", tbl, where)
+			plan := s.testData.ConvertRowsToStrings(tk.MustQuery("explain format = 'brief' " + query).Rows())
+			used := make([]string, 0, 1)
+			for _, row := range plan {
+				if p := s.getFieldValue("partition:", row); p != "" {
+					used = append(used, p)
+				}
+			}
+			c.Assert(used, DeepEquals, []string{part}, Commentf("the query is: %v, the plan is:\n%v", query, strings.Join(plan, "\n")))
+			tk.MustQuery(query).Check(testkit.Rows(fmt.Sprintf("%d v%d", i, i)))
+		}
+	}
+}
@@ -44,6 +44,7 @@ var (
 	_ PhysicalPlan = &PhysicalTopN{}
 	_ PhysicalPlan = &PhysicalMaxOneRow{}
 	_ PhysicalPlan = &PhysicalTableDual{}
+	_ PhysicalPlan = &PhysicalJSONTable{}
 	_ PhysicalPlan = &PhysicalUnionAll{}
 	_ PhysicalPlan = &PhysicalSort{}
 	_ PhysicalPlan = &NominalSort{}
@@ -1259,6 +1260,34 @@ func (p *PhysicalTableDual) SetOutputNames(names types.NameSlice) {
 	p.names = names
 }
 
+// PhysicalJSONTable is the physical operator of JSON_TABLE. It's always executed in TiDB.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	// Expr is the JSON document.
+	Expr expression.Expression
+	// Root is the root of the column tree. Its path is the row path of the table.
+	Root *JSONTableNestedPath
+}
+
+// Clone implements PhysicalPlan interface.
+func (p *PhysicalJSONTable) Clone() (PhysicalPlan, error) {
+	cloned := new(PhysicalJSONTable)
+	*cloned = *p
+	base, err := p.physicalSchemaProducer.cloneWithSelf(cloned)
+	if err != nil {
+		return nil, err
+	}
+	cloned.physicalSchemaProducer = *base
+	cloned.Expr = p.Expr.Clone()
+	return cloned, nil
+}
+
+// ExtractCorrelatedCols implements PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
 // PhysicalWindow is the physical operator of window function.
 type PhysicalWindow struct {
 	physicalSchemaProducer
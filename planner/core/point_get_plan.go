@@ -41,7 +41,6 @@ import (
 	driver "github.com/pingcap/tidb/types/parser_driver"
 	tidbutil "github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/logutil"
-	"github.com/pingcap/tidb/util/math"
 	"github.com/pingcap/tidb/util/plancodec"
 	"github.com/pingcap/tidb/util/stringutil"
 	"github.com/pingcap/tipb/go-tipb"
@@ -628,7 +627,8 @@ func newBatchPointGetPlan(
 		}
 	}
 	for _, idxInfo := range tbl.Indices {
-		if !idxInfo.Unique || idxInfo.State != model.StatePublic || idxInfo.Invisible ||
+		// The partition of a row can't be located by the columns of a global index.
+		if !idxInfo.Unique || idxInfo.State != model.StatePublic || idxInfo.Invisible || idxInfo.Global ||
 			!indexIsAvailableByHints(idxInfo, indexHints) {
 			continue
 		}
@@ -922,7 +922,7 @@ func tryPointGetPlan(ctx sessionctx.Context, selStmt *ast.SelectStmt, check bool
 	var err error
 
 	for _, idxInfo := range tbl.Indices {
-		if !idxInfo.Unique || idxInfo.State != model.StatePublic || idxInfo.Invisible ||
+		if !idxInfo.Unique || idxInfo.State != model.StatePublic || idxInfo.Invisible || idxInfo.Global ||
 			!indexIsAvailableByHints(idxInfo, tblName.IndexHints) {
 			continue
 		}
@@ -1388,6 +1388,10 @@ func buildPointUpdatePlan(ctx sessionctx.Context, pointPlan PhysicalPlan, dbName
 			updatePlan.PartitionedTable = append(updatePlan.PartitionedTable, pt)
 		}
 	}
+	err := updatePlan.buildOnUpdateFKTriggers(ctx, is, updatePlan.tblID2Table)
+	if err != nil {
+		return nil
+	}
 	return updatePlan
 }
 
@@ -1471,6 +1475,12 @@ func buildPointDeletePlan(ctx sessionctx.Context, pointPlan PhysicalPlan, dbName
 			},
 		},
 	}.Init(ctx)
+	is := ctx.GetInfoSchema().(infoschema.InfoSchema)
+	t, _ := is.TableByID(tbl.ID)
+	err := delPlan.buildOnDeleteFKTriggers(ctx, is, map[int64]table.Table{tbl.ID: t})
+	if err != nil {
+		return nil
+	}
 	return delPlan
 }
 
@@ -1540,10 +1550,12 @@ func getPartitionInfo(ctx sessionctx.Context, tbl *model.TableInfo, pairs []name
 		for i, pair := range pairs {
 			if partitionColName.Name.L == pair.colName {
 				val := pair.value.GetInt64()
-				pos := math.Abs(val % int64(pi.Num))
+				pos := tables.HashPartitionIdx(pi, val)
 				return &pi.Definitions[pos], i, false
 			}
 		}
+	case model.PartitionTypeKey:
+		return getKeyPartitionInfo(ctx, tbl, partitionExpr, pairs)
 	case model.PartitionTypeRange:
 		// left range columns partition for future development
 		if len(pi.Columns) == 0 {
@@ -1589,6 +1601,42 @@ func getPartitionInfo(ctx sessionctx.Context, tbl *model.TableInfo, pairs []name
 	return nil, 0, false
 }
 
+// getKeyPartitionInfo locates the partition of a key partitioned table, all the partition columns should be
+// in the pairs. The position of the pair of the first partition column is returned.
+func getKeyPartitionInfo(ctx sessionctx.Context, tbl *model.TableInfo, partitionExpr *tables.PartitionExpr, pairs []nameValuePair) (*model.PartitionDefinition, int, bool) {
+	pi := tbl.GetPartitionInfo()
+	sc := ctx.GetSessionVars().StmtCtx
+	vals := make([]types.Datum, 0, len(pi.Columns))
+	firstPos := 0
+	for i, colName := range pi.Columns {
+		pos := -1
+		for j, pair := range pairs {
+			if colName.L == pair.colName {
+				pos = j
+				break
+			}
+		}
+		if pos < 0 {
+			return nil, 0, false
+		}
+		if i == 0 {
+			firstPos = pos
+		}
+		col := model.FindColumnInfo(tbl.Columns, colName.L)
+		// The value of a binary string column is not padded in getNameValuePairs, convert it again.
+		val, err := pairs[pos].value.ConvertTo(sc, &col.FieldType)
+		if err != nil {
+			return nil, 0, false
+		}
+		vals = append(vals, val)
+	}
+	idx, err := partitionExpr.LocateKeyPartition(sc, pi, vals)
+	if err != nil {
+		return nil, 0, false
+	}
+	return &pi.Definitions[idx], firstPos, false
+}
+
 func findPartitionIdx(idxInfo *model.IndexInfo, pos int, pairs []nameValuePair) int {
 	for i, idxCol := range idxInfo.Columns {
 		if idxCol.Name.L == pairs[pos].colName {
@@ -253,6 +253,146 @@ func (s *testPrepareSerialSuite) TestPrepareCacheNow(c *C) {
 	c.Assert(rs[0][3].(string), Equals, rs[0][8].(string))
 }
 
+func (s *testPrepareSerialSuite) TestNonPreparedPlanCache(c *C) {
+	defer testleak.AfterTest(c)()
+	store, dom, err := newStoreWithBootstrap()
+	c.Assert(err, IsNil)
+	tk := testkit.NewTestKit(c, store)
+	orgEnable := core.PreparedPlanCacheEnabled()
+	defer func() {
+		dom.Close()
+		err = store.Close()
+		c.Assert(err, IsNil)
+		core.SetPreparedPlanCache(orgEnable)
+	}()
+	core.SetPreparedPlanCache(true)
+	tk.Se, err = session.CreateSession4TestWithOpt(store, &session.Opt{
+		PreparedPlanCache: kvcache.NewSimpleLRUCache(100, 0.1, math.MaxUint64),
+	})
+	c.Assert(err, IsNil)
+	tk.GetConnectionID()
+	c.Assert(tk.Se.Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil), IsTrue)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int primary key, b int, c int, index idx_b(b))")
+	tk.MustExec("insert into t values(1, 1, 1), (2, 2, 2), (3, 3, 3), (4, 1, 4)")
+
+	// The non-prepared plan cache is disabled by default.
+	tk.MustQuery("select a from t where b = 1").Sort().Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select a from t where b = 1").Sort().Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	tk.MustExec("set @@tidb_enable_non_prepared_plan_cache = 1")
+	tk.MustQuery("select a from t where b = 1").Sort().Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("select a from t where b = 2").Check(testkit.Rows("2"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select a from t where b = 3").Check(testkit.Rows("3"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select a, c from t where a > 1 and a < 3").Check(testkit.Rows("2 2"))
+	tk.MustQuery("select a, c from t where a > 2 and a < 4").Check(testkit.Rows("3 3"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// Literals of different types use different plans.
+	tk.MustQuery("select a from t where b = '1'").Sort().Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("select a from t where b = '3'").Check(testkit.Rows("3"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// Literals out of the WHERE clause are not parameterized.
+	tk.MustQuery("select a from t where b = 1 order by a limit 1").Check(testkit.Rows("1"))
+	tk.MustQuery("select a from t where b = 1 order by a limit 2").Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	// Statements with subqueries are not cacheable.
+	tk.MustQuery("select a from t where b in (select 1)").Sort().Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select a from t where b in (select 2)").Check(testkit.Rows("2"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	tk.MustExec("update t set c = 10 where b = 1")
+	tk.MustExec("update t set c = 20 where b = 2")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustExec("delete from t where c = 10")
+	tk.MustExec("delete from t where c = 3")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select * from t").Check(testkit.Rows("2 2 20"))
+
+	// The cached plan can not be used after the schema is changed.
+	tk.MustExec("alter table t add column d int")
+	tk.MustQuery("select a from t where b = 2").Check(testkit.Rows("2"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("select a from t where b = 2").Check(testkit.Rows("2"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The hits are reported in the statement summary.
+	tk.MustQuery("select a, c from t where a > 0").Check(testkit.Rows("2 20"))
+	tk.MustQuery("select a, c from t where a > 1").Check(testkit.Rows("2 20"))
+	tk.MustQuery("select exec_count, plan_cache_hits, plan_in_cache from information_schema.statements_summary where digest_text='select `a` , `c` from `t` where `a` > ?'").Check(
+		testkit.Rows("2 1 1"))
+
+	// The privileges are checked when the cached plan is hit.
+	tk.MustExec("create user 'u_npc'@'localhost'")
+	tk.MustExec("grant select on test.t to 'u_npc'@'localhost'")
+	rootSe := tk.Se
+	userSess := newSession(c, store, "test")
+	c.Assert(userSess.Auth(&auth.UserIdentity{Username: "u_npc", Hostname: "localhost"}, nil, nil), IsTrue)
+	mustExec(c, userSess, "set @@tidb_enable_non_prepared_plan_cache = 1")
+	tk.Se = userSess
+	tk.MustQuery("select a from t where b = 1").Check(testkit.Rows())
+	tk.MustQuery("select a from t where b = 2").Check(testkit.Rows("2"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.Se = rootSe
+	tk.MustExec("revoke select on test.t from 'u_npc'@'localhost'")
+	tk.Se = userSess
+	_, err = tk.Exec("select a from t where b = 2")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Matches, ".*SELECT command denied.*")
+}
+
+func (s *testPrepareSerialSuite) TestNonPreparedPlanCacheWithBinding(c *C) {
+	defer testleak.AfterTest(c)()
+	store, dom, err := newStoreWithBootstrap()
+	c.Assert(err, IsNil)
+	tk := testkit.NewTestKit(c, store)
+	orgEnable := core.PreparedPlanCacheEnabled()
+	defer func() {
+		dom.Close()
+		err = store.Close()
+		c.Assert(err, IsNil)
+		core.SetPreparedPlanCache(orgEnable)
+	}()
+	core.SetPreparedPlanCache(true)
+	tk.Se, err = session.CreateSession4TestWithOpt(store, &session.Opt{
+		PreparedPlanCache: kvcache.NewSimpleLRUCache(100, 0.1, math.MaxUint64),
+	})
+	c.Assert(err, IsNil)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int primary key, b int, c int, index idx_b(b), index idx_c(c))")
+	tk.MustExec("insert into t values(1, 1, 1), (2, 2, 2), (3, 3, 3)")
+	tk.MustExec("set @@tidb_enable_non_prepared_plan_cache = 1")
+
+	// The plan is cached before the binding is created.
+	tk.MustQuery("select a from t where b = 1 and c = 1").Check(testkit.Rows("1"))
+	tk.MustQuery("select a from t where b = 2 and c = 2").Check(testkit.Rows("2"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The binding is used instead of the cached plan.
+	tk.MustExec("create session binding for select a from t where b = 1 and c = 1 using select a from t use index(idx_c) where b = 1 and c = 1")
+	for i := 1; i <= 3; i++ {
+		tk.MustQuery(fmt.Sprintf("select a from t where b = %d and c = %d", i, i)).Check(testkit.Rows(fmt.Sprintf("%d", i)))
+		tk.MustQuery("select @@last_plan_from_binding, @@last_plan_from_cache").Check(testkit.Rows("1 0"))
+	}
+	c.Assert(tk.MustUseIndex("select a from t where b = 3 and c = 3", "idx_c"), IsTrue)
+
+	// The cache is used again after the binding is dropped.
+	tk.MustExec("drop session binding for select a from t where b = 1 and c = 1")
+	tk.MustQuery("select a from t where b = 1 and c = 1").Check(testkit.Rows("1"))
+	tk.MustQuery("select @@last_plan_from_binding, @@last_plan_from_cache").Check(testkit.Rows("0 1"))
+}
+
 func (s *testPrepareSerialSuite) TestPrepareOverMaxPreparedStmtCount(c *C) {
 	c.Skip("unstable, skip it and fix it before 20210705")
 	defer testleak.AfterTest(c)()
@@ -171,6 +171,9 @@ type preprocessor struct {
 	// len(tableAliasInJoin) may bigger than 1 because the left/right child of join may be subquery that contains `JOIN`
 	tableAliasInJoin []map[string]interface{}
 	withName         map[string]interface{}
+	// castArrayInIndex is the CAST(... ARRAY) which is the key part of the index being visited, it's the only
+	// place where CAST(... ARRAY) can be used.
+	castArrayInIndex *ast.FuncCastExpr
 
 	// values that may be returned
 	*PreprocessorReturn
@@ -270,6 +273,10 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeDrop
 		p.flag |= inCreateOrDropTable
 		p.checkDropSequenceGrammar(node)
+	case *ast.IndexPartSpecification:
+		if cast, ok := node.Expr.(*ast.FuncCastExpr); ok && cast.Tp.Array {
+			p.castArrayInIndex = cast
+		}
 	case *ast.FuncCastExpr:
 		p.checkFuncCastExpr(node)
 	case *ast.FuncCallExpr:
@@ -1398,6 +1405,20 @@ func (p *preprocessor) handleTableName(tn *ast.TableName) {
 	}
 	tn.TableInfo = tableInfo
 	tn.DBInfo = dbInfo
+	p.recordMDLRelatedTable(tn)
+}
+
+// recordMDLRelatedTable records the table used by the statement, the transaction holds the metadata
+// lock of the table until it finishes, so that DDL jobs on the table wait for the transaction.
+func (p *preprocessor) recordMDLRelatedTable(tn *ast.TableName) {
+	if p.flag&inPrepare > 0 || p.IsStaleness || !variable.EnableMDL.Load() || util.IsMemOrSysDB(tn.Schema.L) {
+		return
+	}
+	stmtCtx := p.ctx.GetSessionVars().StmtCtx
+	if stmtCtx.MDLRelatedTableIDs == nil {
+		stmtCtx.MDLRelatedTableIDs = make(map[int64]struct{})
+	}
+	stmtCtx.MDLRelatedTableIDs[tn.TableInfo.ID] = struct{}{}
 }
 
 func (p *preprocessor) checkNotInRepair(tn *ast.TableName) {
@@ -1484,7 +1505,17 @@ func (p *preprocessor) resolveCreateSequenceStmt(stmt *ast.CreateSequenceStmt) {
 }
 
 func (p *preprocessor) checkFuncCastExpr(node *ast.FuncCastExpr) {
-	if node.Tp.EvalType() == types.ETDecimal {
+	if node.Tp.Array {
+		if node != p.castArrayInIndex {
+			p.err = ErrNotSupportedYet.GenWithStackByArgs("Use of CAST( .. AS .. ARRAY) outside of functional index in CREATE(non-SELECT)/ALTER TABLE or in general expressions")
+			return
+		}
+		if node.Tp.Tp == mysql.TypeJSON {
+			p.err = ErrNotSupportedYet.GenWithStackByArgs("CAST-ing data to array of JSON")
+			return
+		}
+	}
+	if node.Tp.Tp == mysql.TypeNewDecimal {
 		if node.Tp.Flen >= node.Tp.Decimal && node.Tp.Flen <= mysql.MaxDecimalWidth && node.Tp.Decimal <= mysql.MaxDecimalScale {
 			// valid
 			return
@@ -32,7 +32,6 @@ import (
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
-	"github.com/pingcap/tidb/util/math"
 	"github.com/pingcap/tidb/util/plancodec"
 	"github.com/pingcap/tidb/util/ranger"
 	"github.com/pingcap/tidb/util/set"
@@ -156,7 +155,7 @@ func (s *partitionProcessor) findUsedPartitions(ctx sessionctx.Context, tbl tabl
 			if isNull {
 				pos = 0
 			}
-			idx := math.Abs(pos % int64(pi.Num))
+			idx := tables.HashPartitionIdx(pi, pos)
 			if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[idx].Name.L) {
 				continue
 			}
@@ -197,7 +196,7 @@ func (s *partitionProcessor) findUsedPartitions(ctx sessionctx.Context, tbl tabl
 				// if range is less than the number of partitions, there will be unused partitions we can prune out.
 				if rangeScalar < float64(numPartitions) && !highIsNull && !lowIsNull {
 					for i := posLow; i <= posHigh; i++ {
-						idx := math.Abs(i % int64(pi.Num))
+						idx := tables.HashPartitionIdx(pi, i)
 						if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[idx].Name.L) {
 							continue
 						}
@@ -340,6 +339,87 @@ func (s *partitionProcessor) processHashPartition(ds *DataSource, pi *model.Part
 	return tableDual, nil
 }
 
+// findUsedKeyPartitions finds the used partitions of a key partitioned table, only the point ranges
+// on all the partition columns can be located.
+func (s *partitionProcessor) findUsedKeyPartitions(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
+	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, error) {
+	pi := tbl.Meta().Partition
+	partTbl, ok := tbl.(partitionTable)
+	if !ok {
+		return s.convertToIntSlice(fullRange(len(pi.Definitions)), pi, partitionNames), nil
+	}
+	pe, err := partTbl.PartitionExpr()
+	if err != nil {
+		return nil, err
+	}
+	keyCols := make([]*expression.Column, 0, len(pi.Columns))
+	colLen := make([]int, 0, len(pi.Columns))
+	for i, colName := range pi.Columns {
+		idx := expression.FindFieldNameIdxByColName(names, colName.L)
+		if idx < 0 {
+			return s.convertToIntSlice(fullRange(len(pi.Definitions)), pi, partitionNames), nil
+		}
+		col := columns[idx].Clone().(*expression.Column)
+		col.Index = i
+		keyCols = append(keyCols, col)
+		colLen = append(colLen, types.UnspecifiedLength)
+	}
+	detachedResult, err := ranger.DetachCondAndBuildRangeForPartition(ctx, conds, keyCols, colLen)
+	if err != nil {
+		return nil, err
+	}
+	sc := ctx.GetSessionVars().StmtCtx
+	used := make([]int, 0, len(detachedResult.Ranges))
+	for _, r := range detachedResult.Ranges {
+		if len(r.LowVal) != len(keyCols) || !r.IsPointNullable(sc) {
+			used = []int{FullRange}
+			break
+		}
+		idx, err := pe.LocateKeyPartition(sc, pi, r.LowVal)
+		if err != nil {
+			return nil, err
+		}
+		if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[idx].Name.L) {
+			continue
+		}
+		used = append(used, idx)
+	}
+	if len(used) == 1 && used[0] == FullRange {
+		return s.convertToIntSlice(fullRange(len(pi.Definitions)), pi, partitionNames), nil
+	}
+	sort.Ints(used)
+	ret := used[:0]
+	for i := 0; i < len(used); i++ {
+		if i == 0 || used[i] != used[i-1] {
+			ret = append(ret, used[i])
+		}
+	}
+	return ret, nil
+}
+
+func (s *partitionProcessor) pruneKeyPartition(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
+	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, error) {
+	return s.findUsedKeyPartitions(ctx, tbl, partitionNames, conds, columns, names)
+}
+
+func (s *partitionProcessor) processKeyPartition(ds *DataSource, pi *model.PartitionInfo) (LogicalPlan, error) {
+	names, err := s.reconstructTableColNames(ds)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.pruneKeyPartition(ds.SCtx(), ds.table, ds.partitionNames, ds.allConds, ds.TblCols, names)
+	if err != nil {
+		// Just report warning and generate the tableDual
+		ds.SCtx().GetSessionVars().StmtCtx.AppendWarning(err)
+	}
+	if used != nil {
+		return s.makeUnionAllChildren(ds, pi, convertToRangeOr(used, pi))
+	}
+	tableDual := LogicalTableDual{RowCount: 0}.Init(ds.SCtx(), ds.blockOffset)
+	tableDual.schema = ds.Schema()
+	return tableDual, nil
+}
+
 // listPartitionPruner uses to prune partition for list partition.
 type listPartitionPruner struct {
 	*partitionProcessor
@@ -633,6 +713,8 @@ func (s *partitionProcessor) prune(ds *DataSource) (LogicalPlan, error) {
 		return s.processRangePartition(ds, pi)
 	case model.PartitionTypeHash:
 		return s.processHashPartition(ds, pi)
+	case model.PartitionTypeKey:
+		return s.processKeyPartition(ds, pi)
 	case model.PartitionTypeList:
 		return s.processListPartition(ds, pi)
 	}
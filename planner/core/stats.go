@@ -30,6 +30,8 @@ import (
 	"github.com/pingcap/tidb/planner/util"
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/ranger"
 	"go.uber.org/zap"
@@ -56,6 +58,25 @@ func (p *LogicalTableDual) DeriveStats(childStats []*property.StatsInfo, selfSch
 	return p.stats, nil
 }
 
+// jsonTableRowCount is the estimated row count of JSON_TABLE for one document.
+const jsonTableRowCount = 10
+
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalJSONTable) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.stats != nil {
+		return p.stats, nil
+	}
+	profile := &property.StatsInfo{
+		RowCount: jsonTableRowCount,
+		ColNDVs:  make(map[int64]float64, selfSchema.Len()),
+	}
+	for _, col := range selfSchema.Columns {
+		profile.ColNDVs[col.UniqueID] = jsonTableRowCount
+	}
+	p.stats = profile
+	return p.stats, nil
+}
+
 // DeriveStats implement LogicalPlan DeriveStats interface.
 func (p *LogicalMemTable) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
 	if p.stats != nil {
@@ -433,8 +454,15 @@ func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 			}
 		}
 	}
-	if isPossibleIdxMerge && sessionAndStmtPermission && needConsiderIndexMerge && isReadOnlyTxn && ds.tableInfo.TempTableType != model.TempTableLocal {
-		err := ds.generateAndPruneIndexMergePath(ds.indexMergeHints != nil)
+	canIdxMerge := isReadOnlyTxn && ds.tableInfo.TempTableType != model.TempTableLocal
+	// Multi-valued indexes can only be accessed by IndexMerge, so they don't depend on tidb_enable_index_merge.
+	var mvIndexPaths []*util.AccessPath
+	if canIdxMerge && !ds.ctx.GetSessionVars().StmtCtx.NoIndexMergeHint {
+		mvIndexPaths = ds.generateMVIndexMergePaths()
+	}
+	needOrPaths := isPossibleIdxMerge && sessionAndStmtPermission && needConsiderIndexMerge
+	if canIdxMerge && (needOrPaths || len(mvIndexPaths) > 0) {
+		err := ds.generateAndPruneIndexMergePath(ds.indexMergeHints != nil, needOrPaths, mvIndexPaths)
 		if err != nil {
 			return nil, err
 		}
@@ -445,13 +473,16 @@ func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 	return ds.stats, nil
 }
 
-func (ds *DataSource) generateAndPruneIndexMergePath(needPrune bool) error {
+func (ds *DataSource) generateAndPruneIndexMergePath(needPrune, needOrPaths bool, mvIndexPaths []*util.AccessPath) error {
 	regularPathCount := len(ds.possibleAccessPaths)
-	err := ds.generateIndexMergeOrPaths()
-	if err != nil {
-		return err
+	if needOrPaths {
+		err := ds.generateIndexMergeOrPaths()
+		if err != nil {
+			return err
+		}
 	}
-	// If without hints, it means that `enableIndexMerge` is true
+	ds.possibleAccessPaths = append(ds.possibleAccessPaths, mvIndexPaths...)
+	// If without hints, all the paths are considered in find_best_task().
 	if len(ds.indexMergeHints) == 0 {
 		return nil
 	}
@@ -585,6 +616,137 @@ func (ds *DataSource) generateIndexMergeOrPaths() error {
 	return nil
 }
 
+// generateMVIndexMergePaths generates IndexMerge paths on multi-valued indexes, each of which reads the
+// handles of one `MEMBER OF`, `JSON_CONTAINS` or `JSON_OVERLAPS` condition on the indexed JSON expression.
+// All the pushed down conditions are kept as table filters since a multi-valued index only narrows down the rows.
+func (ds *DataSource) generateMVIndexMergePaths() []*util.AccessPath {
+	var paths []*util.AccessPath
+	optimizerUseInvisibleIndexes := ds.ctx.GetSessionVars().OptimizerUseInvisibleIndexes
+	for _, idx := range ds.tableInfo.Indices {
+		if !idx.MVIndex || idx.State != model.StatePublic || (idx.Invisible && !optimizerUseInvisibleIndexes) {
+			continue
+		}
+		if !ds.isInIndexMergeHints(idx.Name.L) {
+			continue
+		}
+		colInfo := ds.tableInfo.Columns[idx.Columns[0].Offset]
+		if !colInfo.FieldType.Array {
+			continue
+		}
+		var virtualExpr *expression.ScalarFunction
+		for _, col := range ds.TblCols {
+			if col.ID == colInfo.ID {
+				virtualExpr, _ = col.VirtualExpr.(*expression.ScalarFunction)
+				break
+			}
+		}
+		if virtualExpr == nil || len(virtualExpr.GetArgs()) != 1 {
+			continue
+		}
+		// The functions on JSON are not pushed down to coprocessor, so look through all the conditions.
+		for _, cond := range ds.allConds {
+			values, ok := ds.mvIndexValues(cond, virtualExpr.GetArgs()[0])
+			if !ok {
+				continue
+			}
+			path := ds.buildMVIndexPartialPath(idx, colInfo, values)
+			if path == nil {
+				continue
+			}
+			if expression.MaybeOverOptimized4PlanCache(ds.ctx, []expression.Expression{cond}) {
+				ds.ctx.GetSessionVars().StmtCtx.MaybeOverOptimized4PlanCache = true
+			}
+			indexMergePath := &util.AccessPath{PartialIndexPaths: []*util.AccessPath{path}}
+			indexMergePath.TableFilters = append(indexMergePath.TableFilters, ds.pushedDownConds...)
+			indexMergePath.CountAfterAccess = path.CountAfterAccess
+			paths = append(paths, indexMergePath)
+		}
+	}
+	return paths
+}
+
+// mvIndexValues extracts the JSON values to look up in a multi-valued index on expr from cond.
+func (ds *DataSource) mvIndexValues(cond, expr expression.Expression) ([]json.BinaryJSON, bool) {
+	sf, ok := cond.(*expression.ScalarFunction)
+	if !ok {
+		return nil, false
+	}
+	args := sf.GetArgs()
+	var value expression.Expression
+	switch sf.FuncName.L {
+	case ast.JSONMemberOf:
+		if args[1].Equal(ds.ctx, expr) {
+			value = args[0]
+		}
+	case ast.JSONContains:
+		if len(args) == 2 && args[0].Equal(ds.ctx, expr) {
+			value = args[1]
+		}
+	case ast.JSONOverlaps:
+		if args[0].Equal(ds.ctx, expr) {
+			value = args[1]
+		} else if args[1].Equal(ds.ctx, expr) {
+			value = args[0]
+		}
+	}
+	sc := ds.ctx.GetSessionVars().StmtCtx
+	if value == nil || !value.ConstItem(sc) || len(expression.ExtractColumns(value)) > 0 {
+		return nil, false
+	}
+	j, isNull, err := value.EvalJSON(ds.ctx, chunk.Row{})
+	if err != nil || isNull {
+		return nil, false
+	}
+	switch sf.FuncName.L {
+	case ast.JSONMemberOf:
+		return []json.BinaryJSON{j}, true
+	case ast.JSONContains:
+		// A row contains all the elements of the candidate, so looking up any of them is enough.
+		elems := j.ArrayElems()
+		if len(elems) == 0 {
+			return nil, false
+		}
+		return elems[:1], true
+	}
+	return j.ArrayElems(), true
+}
+
+// buildMVIndexPartialPath builds the partial index path which looks up values in the multi-valued index idx.
+func (ds *DataSource) buildMVIndexPartialPath(idx *model.IndexInfo, colInfo *model.ColumnInfo, values []json.BinaryJSON) *util.AccessPath {
+	sc := ds.ctx.GetSessionVars().StmtCtx
+	ranges, err := ranger.BuildMVIndexRange(sc, values, &colInfo.FieldType)
+	if err != nil {
+		logutil.BgLogger().Debug("can not build ranges for multi-valued index", zap.Error(err))
+		return nil
+	}
+	elemTp := colInfo.FieldType.Clone()
+	elemTp.Array = false
+	elemCol := &expression.Column{
+		ID:       colInfo.ID,
+		RetType:  elemTp,
+		UniqueID: ds.ctx.GetSessionVars().AllocPlanColumnID(),
+	}
+	path := &util.AccessPath{
+		Index:      idx,
+		Ranges:     ranges,
+		IdxCols:    []*expression.Column{elemCol},
+		IdxColLens: []int{types.UnspecifiedLength},
+	}
+	// The other index columns are filled in by the PhysicalIndexScan.initSchema.
+	path.FullIdxCols = make([]*expression.Column, len(idx.Columns))
+	path.FullIdxColLens = make([]int, len(idx.Columns))
+	for i, idxCol := range idx.Columns {
+		path.FullIdxColLens[i] = idxCol.Length
+	}
+	path.FullIdxCols[0] = elemCol
+	count, err := ds.tableStats.HistColl.GetRowCountByIndexRanges(sc, idx.ID, ranges)
+	if err != nil {
+		count = ds.tableStats.RowCount * SelectionFactor
+	}
+	path.CountAfterAccess = math.Min(count, ds.tableStats.RowCount)
+	return path
+}
+
 // isInIndexMergeHints checks whether current index or primary key is in IndexMerge hints.
 func (ds *DataSource) isInIndexMergeHints(name string) bool {
 	if len(ds.indexMergeHints) == 0 {
@@ -646,7 +808,8 @@ func (ds *DataSource) accessPathsForConds(conditions []expression.Expression, us
 			}
 		} else {
 			path.Index = ds.possibleAccessPaths[i].Index
-			if !ds.isInIndexMergeHints(path.Index.Name.L) {
+			// The index merge reader can't read the handles of a global index now.
+			if path.Index.Global || !ds.isInIndexMergeHints(path.Index.Name.L) {
 				continue
 			}
 			err := ds.fillIndexPath(path, conditions)
@@ -162,6 +162,18 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 		useBinding = false
 	}
 
+	// The plans in the non-prepared plan cache are built without bindings, so the bindings are resolved first and the
+	// statements using them are always optimized from scratch.
+	if !useBinding && useNonPreparedPlanCache(sctx, node) {
+		p, names, ok, err := plannercore.GetPlanFromNonPreparedPlanCache(ctx, sctx, stmtNode, is)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			return p, names, nil
+		}
+	}
+
 	var names types.NameSlice
 	var bestPlan, bestPlanFromBind plannercore.Plan
 	if useBinding {
@@ -301,6 +313,24 @@ func allowInReadOnlyMode(sctx sessionctx.Context, node ast.Node) (bool, error) {
 	return IsReadOnly(node, vars), nil
 }
 
+// useNonPreparedPlanCache checks whether the text-protocol statement should try the non-prepared plan cache.
+// Statements in restricted SQL, EXPLAIN, EXECUTE and read-only mode are always optimized from scratch.
+func useNonPreparedPlanCache(sctx sessionctx.Context, node ast.Node) bool {
+	sessVars := sctx.GetSessionVars()
+	if !sessVars.EnableNonPreparedPlanCache || !plannercore.PreparedPlanCacheEnabled() {
+		return false
+	}
+	if sessVars.InRestrictedSQL || sessVars.StmtCtx.InExplainStmt || variable.RestrictedReadOnly.Load() {
+		return false
+	}
+	// The statement is the inner statement of EXECUTE if the parameters are set.
+	if sessVars.StmtCtx.UseCache || len(sessVars.PreparedParams) > 0 {
+		return false
+	}
+	_, ok := node.(ast.StmtNode)
+	return ok
+}
+
 var planBuilderPool = sync.Pool{
 	New: func() interface{} {
 		return plannercore.NewPlanBuilder()
@@ -498,12 +528,11 @@ func handleEvolveTasks(ctx context.Context, sctx sessionctx.Context, br *bindinf
 //  1. ctx is auto commit tagged.
 //  2. plan is point get by pk.
 func useMaxTS(ctx sessionctx.Context, p plannercore.Plan) bool {
-	if !plannercore.IsAutoCommitTxn(ctx) {
+	if _, ok := p.(*plannercore.PointGetPlan); !ok {
 		return false
 	}
-
-	v, ok := p.(*plannercore.PointGetPlan)
-	return ok && (v.IndexInfo == nil || (v.IndexInfo.Primary && v.TblInfo.IsCommonHandle))
+	ok, err := plannercore.IsPointGetWithPKOrUniqueKeyByAutoCommit(ctx, p)
+	return err == nil && ok
 }
 
 // OptimizeExecStmt to optimize prepare statement protocol "execute" statement
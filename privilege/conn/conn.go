@@ -0,0 +1,41 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conn defines the authentication plugins which aren't defined in the parser and the client
+// connection used by them. It has no dependencies, so it can be used by both the privilege interfaces
+// and their implementations.
+package conn
+
+// The authentication plugins which aren't defined in the parser.
+const (
+	// AuthLDAPSimple authenticates the users by binding to the LDAP server with their cleartext passwords.
+	AuthLDAPSimple = "authentication_ldap_simple"
+	// AuthLDAPSASL authenticates the users by relaying the SASL messages between the clients and the LDAP server.
+	AuthLDAPSASL = "authentication_ldap_sasl"
+)
+
+// IsLDAPAuthPlugin returns whether the plugin is an LDAP authentication plugin, whose authentication strings are
+// the DNs of the users rather than the password hashes.
+func IsLDAPAuthPlugin(plugin string) bool {
+	return plugin == AuthLDAPSimple || plugin == AuthLDAPSASL
+}
+
+// AuthConn is the client connection during the authentication. The SASL challenges of the LDAP server are sent
+// to the client and the responses are read from it.
+type AuthConn interface {
+	// WriteAuthMoreData sends the data of the authentication plugin to the client.
+	WriteAuthMoreData(data []byte) error
+	// ReadAuthData reads the response of the authentication plugin from the client.
+	ReadAuthData() ([]byte, error)
+}
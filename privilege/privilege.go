@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/parser/auth"
 	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/privilege/conn"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
 )
@@ -58,8 +59,9 @@ type Manager interface {
 	// RequestDynamicVerificationWithUser verifies a DYNAMIC privilege for a specific user.
 	RequestDynamicVerificationWithUser(privName string, grantable bool, user *auth.UserIdentity) bool
 
-	// ConnectionVerification verifies user privilege for connection.
-	ConnectionVerification(user, host string, auth, salt []byte, tlsState *tls.ConnectionState) (string, string, bool)
+	// ConnectionVerification verifies user privilege for connection. authConn is used by the authentication
+	// plugins which exchange more messages with the client, it may be nil.
+	ConnectionVerification(user, host string, auth, salt []byte, tlsState *tls.ConnectionState, authConn conn.AuthConn) (string, string, bool)
 
 	// GetAuthWithoutVerification uses to get auth name without verification.
 	GetAuthWithoutVerification(user, host string) (string, string, bool)
@@ -83,6 +85,15 @@ type Manager interface {
 	// GetAllRoles return all roles of user.
 	GetAllRoles(user, host string) []*auth.RoleIdentity
 
+	// GetResourceGroup returns the resource group bound to the user, it's empty if there is none.
+	GetResourceGroup(user, host string) string
+
+	// IsPasswordExpired returns whether the password of the user has expired.
+	IsPasswordExpired(user, host string) bool
+
+	// CheckPasswordLock returns an error if the user is locked temporarily because of the consecutive failed logins.
+	CheckPasswordLock(user, host string) error
+
 	// IsDynamicPrivilege returns if a privilege is in the list of privileges.
 	IsDynamicPrivilege(privNameInUpper string) bool
 
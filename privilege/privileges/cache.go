@@ -32,6 +32,7 @@ import (
 	"github.com/pingcap/parser/terror"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
+	binaryJson "github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/hack"
@@ -43,6 +44,13 @@ import (
 )
 
 var (
+	// resourceGroupAttrPath is the path of the resource group in the user attributes.
+	resourceGroupAttrPath, _ = binaryJson.ParseJSONPathExpr("$.resource_group")
+	// failedLoginAttemptsAttrPath and passwordLockTimeAttrPath are the paths of the failed-login tracking
+	// options in the user attributes, they're compatible with MySQL.
+	failedLoginAttemptsAttrPath, _ = binaryJson.ParseJSONPathExpr("$.Password_locking.failed_login_attempts")
+	passwordLockTimeAttrPath, _    = binaryJson.ParseJSONPathExpr("$.Password_locking.password_lock_time_days")
+
 	userTablePrivilegeMask = computePrivMask(mysql.AllGlobalPrivs)
 	dbTablePrivilegeMask   = computePrivMask(mysql.AllDBPrivs)
 	tablePrivMask          = computePrivMask(mysql.AllTablePrivs)
@@ -64,7 +72,7 @@ const (
 	References_priv,Alter_priv,Execute_priv,Index_priv,Create_view_priv,Show_view_priv,
 	Create_role_priv,Drop_role_priv,Create_tmp_table_priv,Lock_tables_priv,Create_routine_priv,
 	Alter_routine_priv,Event_priv,Shutdown_priv,Reload_priv,File_priv,Config_priv,Repl_client_priv,Repl_slave_priv,
-	account_locked,plugin FROM mysql.user`
+	account_locked,plugin,User_attributes,password_expired,password_last_changed,password_lifetime FROM mysql.user`
 	sqlLoadGlobalGrantsTable = `SELECT HIGH_PRIORITY Host,User,Priv,With_Grant_Option FROM mysql.global_grants`
 )
 
@@ -98,6 +106,19 @@ type UserRecord struct {
 	Privileges           mysql.PrivilegeType
 	AccountLocked        bool // A role record when this field is true
 	AuthPlugin           string
+	// ResourceGroup is the resource group bound to the user, it's `$.resource_group` of the user attributes.
+	ResourceGroup string
+	// PasswordExpired is true if the password is expired manually by `PASSWORD EXPIRE`.
+	PasswordExpired bool
+	// PasswordLastChanged is zero if it's unknown, the password never expires by its lifetime then.
+	PasswordLastChanged time.Time
+	// PasswordLifetime is the lifetime of the password in days, -1 means `default_password_lifetime` is used.
+	PasswordLifetime int64
+	// FailedLoginAttempts is the number of consecutive failed logins that lock the account temporarily, 0 means
+	// the failed logins aren't tracked.
+	FailedLoginAttempts int64
+	// PasswordLockTime is the number of days the account is locked for, -1 means it's locked until unlocked.
+	PasswordLockTime int64
 }
 
 // NewUserRecord return a UserRecord, only use for unit test.
@@ -640,6 +661,32 @@ func (p *MySQLPrivilege) decodeUserTableRow(row chunk.Row, fs []*ast.ResultField
 			} else {
 				value.AuthPlugin = mysql.AuthNativePassword
 			}
+		case f.ColumnAsName.L == "user_attributes":
+			if row.IsNull(i) {
+				continue
+			}
+			attrs := row.GetJSON(i)
+			group, found := attrs.Extract([]binaryJson.PathExpression{resourceGroupAttrPath})
+			if found && group.TypeCode == binaryJson.TypeCodeString {
+				value.ResourceGroup = string(group.GetString())
+			}
+			value.FailedLoginAttempts = extractJSONInt(attrs, failedLoginAttemptsAttrPath)
+			value.PasswordLockTime = extractJSONInt(attrs, passwordLockTimeAttrPath)
+		case f.ColumnAsName.L == "password_expired":
+			value.PasswordExpired = row.GetEnum(i).String() == "Y"
+		case f.ColumnAsName.L == "password_last_changed":
+			if !row.IsNull(i) {
+				t, err := row.GetTime(i).GoTime(time.Local)
+				if err != nil {
+					return err
+				}
+				value.PasswordLastChanged = t
+			}
+		case f.ColumnAsName.L == "password_lifetime":
+			value.PasswordLifetime = -1
+			if !row.IsNull(i) {
+				value.PasswordLifetime = row.GetInt64(i)
+			}
 		case f.Column.Tp == mysql.TypeEnum:
 			if row.GetEnum(i).String() != "Y" {
 				continue
@@ -657,6 +704,22 @@ func (p *MySQLPrivilege) decodeUserTableRow(row chunk.Row, fs []*ast.ResultField
 	return nil
 }
 
+func extractJSONInt(j binaryJson.BinaryJSON, path binaryJson.PathExpression) int64 {
+	v, found := j.Extract([]binaryJson.PathExpression{path})
+	if !found {
+		return 0
+	}
+	switch v.TypeCode {
+	case binaryJson.TypeCodeInt64:
+		return v.GetInt64()
+	case binaryJson.TypeCodeUint64:
+		return int64(v.GetUint64())
+	case binaryJson.TypeCodeFloat64:
+		return int64(v.GetFloat64())
+	}
+	return 0
+}
+
 func (p *MySQLPrivilege) decodeGlobalPrivTableRow(row chunk.Row, fs []*ast.ResultField) error {
 	var value globalPrivRecord
 	for i, f := range fs {
@@ -1508,12 +1571,13 @@ func (p *MySQLPrivilege) getAllRoles(user, host string) []*auth.RoleIdentity {
 
 // Handle wraps MySQLPrivilege providing thread safe access.
 type Handle struct {
-	priv atomic.Value
+	priv         atomic.Value
+	failedLogins *failedLoginTracker
 }
 
 // NewHandle returns a Handle.
 func NewHandle() *Handle {
-	return &Handle{}
+	return &Handle{failedLogins: newFailedLoginTracker()}
 }
 
 // Get the MySQLPrivilege for read.
@@ -1532,3 +1596,9 @@ func (h *Handle) Update(ctx sessionctx.Context) error {
 	h.priv.Store(&priv)
 	return nil
 }
+
+// ResetFailedLogins clears the consecutive failed logins of the account, and unlocks it if it's locked because
+// of the failed logins.
+func (h *Handle) ResetFailedLogins(user, host string) {
+	h.failedLogins.reset(user, host)
+}
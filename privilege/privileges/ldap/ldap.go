@@ -0,0 +1,206 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pingcap/errors"
+)
+
+// The default settings of the LDAP authentication plugins, they're the same as MySQL.
+const (
+	DefLDAPServerPort = 389
+	DefLDAPSearchAttr = "uid"
+)
+
+// ldapAuthImpl is the part shared by the LDAP authentication plugins. The settings are changed by the
+// `authentication_ldap_simple_*` and `authentication_ldap_sasl_*` system variables.
+type ldapAuthImpl struct {
+	sync.RWMutex
+	bindBaseDN     string
+	bindRootDN     string
+	bindRootPWD    string
+	searchAttr     string
+	ldapServerHost string
+	ldapServerPort int
+	enableTLS      bool
+	caPath         string
+	// caPool is nil if the CA isn't specified, then the system CAs are used.
+	caPool *x509.CertPool
+}
+
+func newLDAPAuthImpl() ldapAuthImpl {
+	return ldapAuthImpl{
+		searchAttr:     DefLDAPSearchAttr,
+		ldapServerPort: DefLDAPServerPort,
+	}
+}
+
+// SetBindBaseDN sets the base DN to search the users.
+func (impl *ldapAuthImpl) SetBindBaseDN(bindBaseDN string) {
+	impl.Lock()
+	defer impl.Unlock()
+	impl.bindBaseDN = bindBaseDN
+}
+
+// SetBindRootDN sets the DN to bind before searching the users, the search is anonymous if it's empty.
+func (impl *ldapAuthImpl) SetBindRootDN(bindRootDN string) {
+	impl.Lock()
+	defer impl.Unlock()
+	impl.bindRootDN = bindRootDN
+}
+
+// SetBindRootPW sets the password of the bind root DN.
+func (impl *ldapAuthImpl) SetBindRootPW(bindRootPWD string) {
+	impl.Lock()
+	defer impl.Unlock()
+	impl.bindRootPWD = bindRootPWD
+}
+
+// SetSearchAttr sets the attribute which holds the user names.
+func (impl *ldapAuthImpl) SetSearchAttr(searchAttr string) {
+	impl.Lock()
+	defer impl.Unlock()
+	impl.searchAttr = searchAttr
+}
+
+// SetLDAPServerHost sets the host of the LDAP server.
+func (impl *ldapAuthImpl) SetLDAPServerHost(host string) {
+	impl.Lock()
+	defer impl.Unlock()
+	impl.ldapServerHost = host
+}
+
+// SetLDAPServerPort sets the port of the LDAP server.
+func (impl *ldapAuthImpl) SetLDAPServerPort(port int) {
+	impl.Lock()
+	defer impl.Unlock()
+	impl.ldapServerPort = port
+}
+
+// SetEnableTLS sets whether the connections to the LDAP server are upgraded to TLS by StartTLS.
+func (impl *ldapAuthImpl) SetEnableTLS(enableTLS bool) {
+	impl.Lock()
+	defer impl.Unlock()
+	impl.enableTLS = enableTLS
+}
+
+// SetCAPath sets the path of the CA file to verify the certificate of the LDAP server.
+func (impl *ldapAuthImpl) SetCAPath(caPath string) error {
+	var caPool *x509.CertPool
+	if caPath != "" {
+		data, err := ioutil.ReadFile(caPath)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		caPool = x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(data) {
+			return errors.Errorf("no valid certificate in the CA file %s", caPath)
+		}
+	}
+	impl.Lock()
+	defer impl.Unlock()
+	impl.caPath = caPath
+	impl.caPool = caPool
+	return nil
+}
+
+// ldapSettings is a snapshot of the settings, so that an authentication isn't affected by the concurrent changes.
+type ldapSettings struct {
+	bindBaseDN  string
+	bindRootDN  string
+	bindRootPWD string
+	searchAttr  string
+	host        string
+	port        int
+	enableTLS   bool
+	caPool      *x509.CertPool
+}
+
+func (impl *ldapAuthImpl) settings() ldapSettings {
+	impl.RLock()
+	defer impl.RUnlock()
+	return ldapSettings{
+		bindBaseDN:  impl.bindBaseDN,
+		bindRootDN:  impl.bindRootDN,
+		bindRootPWD: impl.bindRootPWD,
+		searchAttr:  impl.searchAttr,
+		host:        impl.ldapServerHost,
+		port:        impl.ldapServerPort,
+		enableTLS:   impl.enableTLS,
+		caPool:      impl.caPool,
+	}
+}
+
+func (s *ldapSettings) address() string {
+	return net.JoinHostPort(s.host, strconv.Itoa(s.port))
+}
+
+func (s *ldapSettings) tlsConfig() *tls.Config {
+	return &tls.Config{
+		RootCAs:    s.caPool,
+		ServerName: s.host,
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+func (s *ldapSettings) connect() (*ldap.Conn, error) {
+	if s.host == "" {
+		return nil, errors.New("the LDAP server host isn't set")
+	}
+	conn, err := ldap.DialURL("ldap://" + s.address())
+	if err != nil {
+		return nil, errors.Annotate(err, "connect to the LDAP server")
+	}
+	if s.enableTLS {
+		if err = conn.StartTLS(s.tlsConfig()); err != nil {
+			conn.Close()
+			return nil, errors.Annotate(err, "start TLS with the LDAP server")
+		}
+	}
+	return conn, nil
+}
+
+// searchUserDN searches the DN of the user under the base DN by the search attribute. It binds as the root DN
+// before searching if the root DN is set.
+func (s *ldapSettings) searchUserDN(conn *ldap.Conn, userName string) (string, error) {
+	if s.bindRootDN != "" {
+		if err := conn.Bind(s.bindRootDN, s.bindRootPWD); err != nil {
+			return "", errors.Annotate(err, "bind as the root DN")
+		}
+	}
+	req := ldap.NewSearchRequest(s.bindBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+		fmt.Sprintf("(%s=%s)", ldap.EscapeFilter(s.searchAttr), ldap.EscapeFilter(userName)), []string{"dn"}, nil)
+	result, err := conn.Search(req)
+	if err != nil {
+		return "", errors.Annotate(err, "search the user DN")
+	}
+	switch len(result.Entries) {
+	case 0:
+		return "", errors.Errorf("LDAP user %s isn't found", userName)
+	case 1:
+		return result.Entries[0].DN, nil
+	default:
+		return "", errors.Errorf("LDAP user %s matches more than one entries", userName)
+	}
+}
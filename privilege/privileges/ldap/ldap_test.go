@@ -0,0 +1,153 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/tidb/privilege/privileges/ldap/ldaptest"
+	"github.com/stretchr/testify/require"
+)
+
+const baseDN = "ou=people,dc=example,dc=com"
+
+func newTestServer(t *testing.T, enableTLS bool) (*ldaptest.Server, []byte) {
+	var tlsConfig *tls.Config
+	var caPEM []byte
+	if enableTLS {
+		var err error
+		tlsConfig, caPEM, err = ldaptest.NewTLSConfig()
+		require.NoError(t, err)
+	}
+	server, err := ldaptest.NewServer(tlsConfig)
+	require.NoError(t, err)
+	server.AddEntry(&ldaptest.Entry{
+		DN:         "cn=admin,dc=example,dc=com",
+		Password:   "admin_pwd",
+		Attributes: map[string][]string{"cn": {"admin"}},
+	})
+	server.AddEntry(&ldaptest.Entry{
+		DN:         "uid=alice," + baseDN,
+		Password:   "alice_pwd",
+		Attributes: map[string][]string{"uid": {"alice"}, "cn": {"Alice"}},
+	})
+	return server, caPEM
+}
+
+func (impl *ldapAuthImpl) setServer(server *ldaptest.Server) {
+	impl.SetLDAPServerHost(server.Host())
+	impl.SetLDAPServerPort(server.Port())
+}
+
+func TestLDAPSimpleAuth(t *testing.T) {
+	server, _ := newTestServer(t, false)
+	defer server.Close()
+	impl := &ldapSimpleAuthImpl{newLDAPAuthImpl()}
+	require.Error(t, impl.AuthLDAPSimple("alice", "uid=alice,"+baseDN, []byte("alice_pwd")))
+	impl.setServer(server)
+
+	// The DN of the user is specified.
+	require.NoError(t, impl.AuthLDAPSimple("alice", "uid=alice,"+baseDN, []byte("alice_pwd")))
+	require.Error(t, impl.AuthLDAPSimple("alice", "uid=alice,"+baseDN, []byte("wrong_pwd")))
+	require.Error(t, impl.AuthLDAPSimple("alice", "uid=alice,"+baseDN, nil))
+
+	// The DN of the user is searched by the user name.
+	impl.SetBindBaseDN(baseDN)
+	require.NoError(t, impl.AuthLDAPSimple("alice", "", []byte("alice_pwd")))
+	require.Error(t, impl.AuthLDAPSimple("bob", "", []byte("alice_pwd")))
+	impl.SetSearchAttr("cn")
+	require.NoError(t, impl.AuthLDAPSimple("Alice", "", []byte("alice_pwd")))
+	require.Error(t, impl.AuthLDAPSimple("admin", "", []byte("admin_pwd")))
+	impl.SetSearchAttr(DefLDAPSearchAttr)
+
+	// The search binds as the root DN.
+	impl.SetBindRootDN("cn=admin,dc=example,dc=com")
+	impl.SetBindRootPW("wrong_pwd")
+	require.Error(t, impl.AuthLDAPSimple("alice", "", []byte("alice_pwd")))
+	impl.SetBindRootPW("admin_pwd")
+	require.NoError(t, impl.AuthLDAPSimple("alice", "", []byte("alice_pwd")))
+}
+
+func TestLDAPSimpleAuthWithTLS(t *testing.T) {
+	server, caPEM := newTestServer(t, true)
+	defer server.Close()
+	impl := &ldapSimpleAuthImpl{newLDAPAuthImpl()}
+	impl.setServer(server)
+	impl.SetEnableTLS(true)
+
+	// The certificate of the server isn't trusted.
+	require.Error(t, impl.AuthLDAPSimple("alice", "uid=alice,"+baseDN, []byte("alice_pwd")))
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, ioutil.WriteFile(caPath, caPEM, 0600))
+	require.NoError(t, impl.SetCAPath(caPath))
+	require.NoError(t, impl.AuthLDAPSimple("alice", "uid=alice,"+baseDN, []byte("alice_pwd")))
+	require.Error(t, impl.AuthLDAPSimple("alice", "uid=alice,"+baseDN, []byte("wrong_pwd")))
+
+	require.Error(t, impl.SetCAPath(filepath.Join(t.TempDir(), "not_exist.pem")))
+}
+
+func TestLDAPSASLAuth(t *testing.T) {
+	server, caPEM := newTestServer(t, true)
+	defer server.Close()
+	impl := &ldapSASLAuthImpl{ldapAuthImpl: newLDAPAuthImpl(), authMethod: SASLMechanismSCRAMSHA1}
+	impl.setServer(server)
+
+	auth := func(mysqlUser, saslUser, password string) error {
+		client, err := ldaptest.NewSCRAMClient(impl.GetSASLAuthMethod(), saslUser, password)
+		require.NoError(t, err)
+		conn := &ldaptest.SCRAMAuthConn{Client: client}
+		if err = impl.AuthLDAPSASL(mysqlUser, "", client.ClientFirst(), conn); err != nil {
+			return err
+		}
+		return client.VerifyServerFinal(conn.ServerFinal)
+	}
+	for _, method := range []string{SASLMechanismSCRAMSHA1, SASLMechanismSCRAMSHA256} {
+		impl.SetSASLAuthMethod(method)
+		require.NoError(t, auth("alice", "alice", "alice_pwd"))
+		require.Error(t, auth("alice", "alice", "wrong_pwd"))
+		require.Error(t, auth("bob", "bob", "alice_pwd"))
+		// The SASL user must be the user who logs in.
+		require.Error(t, auth("bob", "alice", "alice_pwd"))
+	}
+
+	// The user must exist under the base DN.
+	impl.SetBindBaseDN(baseDN)
+	require.NoError(t, auth("alice", "alice", "alice_pwd"))
+	impl.SetBindBaseDN("ou=others,dc=example,dc=com")
+	require.Error(t, auth("alice", "alice", "alice_pwd"))
+	impl.SetBindBaseDN("")
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, ioutil.WriteFile(caPath, caPEM, 0600))
+	require.NoError(t, impl.SetCAPath(caPath))
+	impl.SetEnableTLS(true)
+	require.NoError(t, auth("alice", "alice", "alice_pwd"))
+	require.Error(t, auth("alice", "alice", "wrong_pwd"))
+}
+
+func TestSCRAMUserName(t *testing.T) {
+	user, err := scramUserName([]byte("n,,n=alice,r=abc"))
+	require.NoError(t, err)
+	require.Equal(t, "alice", user)
+	user, err = scramUserName([]byte("n,,n=a=2Cb=3Dc,r=abc"))
+	require.NoError(t, err)
+	require.Equal(t, "a,b=c", user)
+	_, err = scramUserName([]byte("n,,r=abc"))
+	require.Error(t, err)
+}
@@ -0,0 +1,230 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldaptest
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505
+	"crypto/sha256"
+	"encoding/base64"
+	"hash"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const scramIterations = 4096
+
+func scramHash(mechanism string) (func() hash.Hash, error) {
+	switch mechanism {
+	case "SCRAM-SHA-1":
+		return sha1.New, nil
+	case "SCRAM-SHA-256":
+		return sha256.New, nil
+	}
+	return nil, errors.Errorf("unsupported SASL mechanism %s", mechanism)
+}
+
+func scramNonce() string {
+	b := make([]byte, 18)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func hmacSum(h func() hash.Hash, key []byte, data string) []byte {
+	mac := hmac.New(h, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hashSum(h func() hash.Hash, data []byte) []byte {
+	d := h()
+	d.Write(data)
+	return d.Sum(nil)
+}
+
+func xorBytes(a, b []byte) []byte {
+	res := make([]byte, len(a))
+	for i := range a {
+		res[i] = a[i] ^ b[i]
+	}
+	return res
+}
+
+// scramAttributes parses the `a=value` attributes of a SCRAM message.
+func scramAttributes(msg string) map[byte]string {
+	attrs := make(map[byte]string)
+	for _, attr := range strings.Split(msg, ",") {
+		if len(attr) >= 2 && attr[1] == '=' {
+			attrs[attr[0]] = attr[2:]
+		}
+	}
+	return attrs
+}
+
+// scramKeys returns the client key and the server key of SCRAM, see RFC 5802.
+func scramKeys(h func() hash.Hash, password string, salt []byte, iterations int) (clientKey, serverKey []byte) {
+	saltedPassword := pbkdf2.Key([]byte(password), salt, iterations, h().Size(), h)
+	return hmacSum(h, saltedPassword, "Client Key"), hmacSum(h, saltedPassword, "Server Key")
+}
+
+// SCRAMClient is the client side of the SCRAM SASL mechanisms, the tests use it to act as the clients of the
+// `authentication_ldap_sasl` plugin.
+type SCRAMClient struct {
+	h               func() hash.Hash
+	password        string
+	clientFirstBare string
+	nonce           string
+	authMessage     string
+	serverKey       []byte
+}
+
+// NewSCRAMClient creates a SCRAMClient of the mechanism.
+func NewSCRAMClient(mechanism, user, password string) (*SCRAMClient, error) {
+	h, err := scramHash(mechanism)
+	if err != nil {
+		return nil, err
+	}
+	c := &SCRAMClient{h: h, password: password, nonce: scramNonce()}
+	user = strings.NewReplacer("=", "=3D", ",", "=2C").Replace(user)
+	c.clientFirstBare = "n=" + user + ",r=" + c.nonce
+	return c, nil
+}
+
+// ClientFirst returns the client-first-message.
+func (c *SCRAMClient) ClientFirst() []byte {
+	return []byte("n,," + c.clientFirstBare)
+}
+
+// ClientFinal returns the client-final-message for the server-first-message.
+func (c *SCRAMClient) ClientFinal(serverFirst []byte) ([]byte, error) {
+	attrs := scramAttributes(string(serverFirst))
+	nonce := attrs['r']
+	if !strings.HasPrefix(nonce, c.nonce) {
+		return nil, errors.New("invalid SCRAM server nonce")
+	}
+	salt, err := base64.StdEncoding.DecodeString(attrs['s'])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	iterations, err := strconv.Atoi(attrs['i'])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	withoutProof := "c=biws,r=" + nonce
+	c.authMessage = c.clientFirstBare + "," + string(serverFirst) + "," + withoutProof
+	clientKey, serverKey := scramKeys(c.h, c.password, salt, iterations)
+	c.serverKey = serverKey
+	proof := xorBytes(clientKey, hmacSum(c.h, hashSum(c.h, clientKey), c.authMessage))
+	return []byte(withoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof)), nil
+}
+
+// VerifyServerFinal verifies the server signature in the server-final-message.
+func (c *SCRAMClient) VerifyServerFinal(serverFinal []byte) error {
+	signature, err := base64.StdEncoding.DecodeString(scramAttributes(string(serverFinal))['v'])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !hmac.Equal(signature, hmacSum(c.h, c.serverKey, c.authMessage)) {
+		return errors.New("invalid SCRAM server signature")
+	}
+	return nil
+}
+
+// SCRAMAuthConn acts as the client connection of the `authentication_ldap_sasl` plugin, it answers the SASL
+// challenges by the SCRAMClient.
+type SCRAMAuthConn struct {
+	Client      *SCRAMClient
+	clientFinal []byte
+	// ServerFinal is the server-final-message received by the client.
+	ServerFinal []byte
+}
+
+// WriteAuthMoreData receives a SASL challenge.
+func (c *SCRAMAuthConn) WriteAuthMoreData(data []byte) error {
+	if c.clientFinal == nil {
+		var err error
+		c.clientFinal, err = c.Client.ClientFinal(data)
+		return err
+	}
+	c.ServerFinal = data
+	return nil
+}
+
+// ReadAuthData returns the answer to the SASL challenge.
+func (c *SCRAMAuthConn) ReadAuthData() ([]byte, error) {
+	return c.clientFinal, nil
+}
+
+// scramServer is the server side of a SCRAM exchange.
+type scramServer struct {
+	h        func() hash.Hash
+	lookup   func(user string) (password string, ok bool)
+	password string
+	nonce    string
+	salt     []byte
+	// clientFirstBare and serverFirst are set after the first step.
+	clientFirstBare string
+	serverFirst     string
+}
+
+// step handles a message of the client, done is true if the exchange finishes successfully.
+func (s *scramServer) step(msg []byte) (resp []byte, done bool, err error) {
+	if s.serverFirst == "" {
+		parts := strings.SplitN(string(msg), ",", 3)
+		if len(parts) < 3 {
+			return nil, false, errors.New("malformed SCRAM client first message")
+		}
+		s.clientFirstBare = parts[2]
+		attrs := scramAttributes(s.clientFirstBare)
+		user := strings.NewReplacer("=2C", ",", "=3D", "=").Replace(attrs['n'])
+		password, ok := s.lookup(user)
+		if !ok {
+			return nil, false, errors.Errorf("unknown SCRAM user %s", user)
+		}
+		s.password = password
+		s.nonce = attrs['r'] + scramNonce()
+		s.salt = []byte(scramNonce())
+		s.serverFirst = "r=" + s.nonce + ",s=" + base64.StdEncoding.EncodeToString(s.salt) + ",i=" + strconv.Itoa(scramIterations)
+		return []byte(s.serverFirst), false, nil
+	}
+
+	clientFinal := string(msg)
+	idx := strings.LastIndex(clientFinal, ",p=")
+	if idx < 0 {
+		return nil, false, errors.New("malformed SCRAM client final message")
+	}
+	withoutProof := clientFinal[:idx]
+	if scramAttributes(withoutProof)['r'] != s.nonce {
+		return nil, false, errors.New("invalid SCRAM client nonce")
+	}
+	proof, err := base64.StdEncoding.DecodeString(clientFinal[idx+3:])
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	authMessage := s.clientFirstBare + "," + s.serverFirst + "," + withoutProof
+	clientKey, serverKey := scramKeys(s.h, s.password, s.salt, scramIterations)
+	storedKey := hashSum(s.h, clientKey)
+	clientSignature := hmacSum(s.h, storedKey, authMessage)
+	if len(proof) != len(clientSignature) || !hmac.Equal(hashSum(s.h, xorBytes(proof, clientSignature)), storedKey) {
+		return nil, false, errors.New("invalid SCRAM client proof")
+	}
+	return []byte("v=" + base64.StdEncoding.EncodeToString(hmacSum(s.h, serverKey, authMessage))), true, nil
+}
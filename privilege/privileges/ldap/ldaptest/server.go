@@ -0,0 +1,343 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ldaptest provides an in-process LDAP server for testing the LDAP authentication plugins.
+package ldaptest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// Entry is an entry in the directory of the Server.
+type Entry struct {
+	DN         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// Server is an in-process LDAP server. It supports the simple binds, the SCRAM-SHA-1 and SCRAM-SHA-256 SASL
+// binds, the equality searches and StartTLS. The SASL user names are matched against the `uid` attributes.
+type Server struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	wg        sync.WaitGroup
+
+	mu      sync.Mutex
+	entries []*Entry
+	conns   map[net.Conn]struct{}
+}
+
+// NewServer starts a Server on a random local port. StartTLS is supported if tlsConfig isn't nil.
+func NewServer(tlsConfig *tls.Config) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		listener:  listener,
+		tlsConfig: tlsConfig,
+		conns:     make(map[net.Conn]struct{}),
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// NewTLSConfig creates a TLS config with a self-signed certificate of 127.0.0.1 for the Server, the certificate
+// is returned in PEM to be used as the CA.
+func NewTLSConfig() (*tls.Config, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS12,
+	}
+	return config, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
+
+// Host returns the host of the server.
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.listener.Addr().String())
+	return host
+}
+
+// Port returns the port of the server.
+func (s *Server) Port() int {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return p
+}
+
+// AddEntry adds an entry to the directory.
+func (s *Server) AddEntry(entry *Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+}
+
+// Close stops the server and closes the connections.
+func (s *Server) Close() {
+	s.listener.Close()
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handleConn(conn)
+		}()
+	}
+}
+
+func (s *Server) findEntry(match func(*Entry) bool) *Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.entries {
+		if match(entry) {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (s *Server) lookupSASLUser(user string) (string, bool) {
+	entry := s.findEntry(func(e *Entry) bool {
+		for _, uid := range e.Attributes["uid"] {
+			if uid == user {
+				return true
+			}
+		}
+		return false
+	})
+	if entry == nil {
+		return "", false
+	}
+	return entry.Password, true
+}
+
+// ldapConn is the state of a client connection.
+type ldapConn struct {
+	net.Conn
+	scram *scramServer
+}
+
+func (s *Server) handleConn(netConn net.Conn) {
+	conn := &ldapConn{Conn: netConn}
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, netConn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID, _ := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			code, creds := s.handleBind(conn, op)
+			resp := newResult(ldap.ApplicationBindResponse, code)
+			if creds != nil {
+				resp.AppendChild(ber.NewString(ber.ClassContext, ber.TypePrimitive, 7, string(creds), "SASL Credentials"))
+			}
+			if conn.write(messageID, resp) != nil {
+				return
+			}
+		case ldap.ApplicationSearchRequest:
+			for _, entry := range s.search(op) {
+				if conn.write(messageID, newSearchEntry(entry)) != nil {
+					return
+				}
+			}
+			if conn.write(messageID, newResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess)) != nil {
+				return
+			}
+		case ldap.ApplicationExtendedRequest:
+			if s.tlsConfig == nil || len(op.Children) == 0 || op.Children[0].Data.String() != startTLSOID {
+				if conn.write(messageID, newResult(ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError)) != nil {
+					return
+				}
+				continue
+			}
+			if conn.write(messageID, newResult(ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess)) != nil {
+				return
+			}
+			tlsConn := tls.Server(conn.Conn, s.tlsConfig)
+			if tlsConn.Handshake() != nil {
+				return
+			}
+			conn.Conn = tlsConn
+		default:
+			// Unbind and the unsupported operations close the connection.
+			return
+		}
+	}
+}
+
+func (s *Server) handleBind(conn *ldapConn, op *ber.Packet) (uint16, []byte) {
+	if len(op.Children) < 3 {
+		return ldap.LDAPResultProtocolError, nil
+	}
+	dn := op.Children[1].Data.String()
+	auth := op.Children[2]
+	switch auth.Tag {
+	case 0:
+		conn.scram = nil
+		password := auth.Data.String()
+		if dn == "" && password == "" {
+			return ldap.LDAPResultSuccess, nil
+		}
+		entry := s.findEntry(func(e *Entry) bool { return strings.EqualFold(e.DN, dn) })
+		if entry == nil || password == "" || entry.Password != password {
+			return ldap.LDAPResultInvalidCredentials, nil
+		}
+		return ldap.LDAPResultSuccess, nil
+	case 3:
+		if len(auth.Children) < 2 {
+			return ldap.LDAPResultProtocolError, nil
+		}
+		if conn.scram == nil {
+			h, err := scramHash(auth.Children[0].Data.String())
+			if err != nil {
+				return ldap.LDAPResultAuthMethodNotSupported, nil
+			}
+			conn.scram = &scramServer{h: h, lookup: s.lookupSASLUser}
+		}
+		resp, done, err := conn.scram.step(auth.Children[1].Data.Bytes())
+		if err != nil {
+			conn.scram = nil
+			return ldap.LDAPResultInvalidCredentials, nil
+		}
+		if done {
+			conn.scram = nil
+			return ldap.LDAPResultSuccess, resp
+		}
+		return ldap.LDAPResultSaslBindInProgress, resp
+	}
+	return ldap.LDAPResultAuthMethodNotSupported, nil
+}
+
+// search supports the equality filters only, the scope is always the whole subtree.
+func (s *Server) search(op *ber.Packet) []*Entry {
+	if len(op.Children) < 7 {
+		return nil
+	}
+	baseDN := strings.ToLower(op.Children[0].Data.String())
+	filter := op.Children[6]
+	if filter.Tag != ldap.FilterEqualityMatch || len(filter.Children) < 2 {
+		return nil
+	}
+	attr, value := filter.Children[0].Data.String(), filter.Children[1].Data.String()
+	var entries []*Entry
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.entries {
+		if baseDN != "" && !strings.HasSuffix(strings.ToLower(entry.DN), baseDN) {
+			continue
+		}
+		for _, v := range entry.Attributes[attr] {
+			if strings.EqualFold(v, value) {
+				entries = append(entries, entry)
+				break
+			}
+		}
+	}
+	return entries
+}
+
+func (c *ldapConn) write(messageID int64, op *ber.Packet) error {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+	packet.AppendChild(op)
+	_, err := c.Write(packet.Bytes())
+	return err
+}
+
+func newResult(tag ber.Tag, code uint16) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return op
+}
+
+func newSearchEntry(entry *Entry) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, "Object Name"))
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for name, values := range entry.Attributes {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range values {
+			vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+		attr.AppendChild(vals)
+		attrs.AppendChild(attr)
+	}
+	op.AppendChild(attrs)
+	return op
+}
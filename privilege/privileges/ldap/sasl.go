@@ -0,0 +1,227 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"crypto/tls"
+	"net"
+	"strings"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/privilege/conn"
+)
+
+// The SASL mechanisms supported by the `authentication_ldap_sasl` plugin.
+const (
+	SASLMechanismSCRAMSHA1   = "SCRAM-SHA-1"
+	SASLMechanismSCRAMSHA256 = "SCRAM-SHA-256"
+)
+
+const startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// LDAPSASLAuthImpl is the implementation of the `authentication_ldap_sasl` plugin.
+var LDAPSASLAuthImpl = &ldapSASLAuthImpl{
+	ldapAuthImpl: newLDAPAuthImpl(),
+	authMethod:   SASLMechanismSCRAMSHA1,
+}
+
+type ldapSASLAuthImpl struct {
+	ldapAuthImpl
+	authMethod string
+}
+
+// SetSASLAuthMethod sets the SASL mechanism used with the clients and the LDAP server.
+func (impl *ldapSASLAuthImpl) SetSASLAuthMethod(method string) {
+	impl.Lock()
+	defer impl.Unlock()
+	impl.authMethod = method
+}
+
+// GetSASLAuthMethod returns the SASL mechanism, it's sent to the client when the authentication begins.
+func (impl *ldapSASLAuthImpl) GetSASLAuthMethod() string {
+	impl.RLock()
+	defer impl.RUnlock()
+	return impl.authMethod
+}
+
+// AuthLDAPSASL authenticates the user by relaying the SASL messages between the client and the LDAP server until
+// the LDAP server accepts or rejects them. dn is the authentication string of the user, the user DN is searched
+// by the user name if it's empty and the base DN is set. clientCredentials is the initial response of the client.
+func (impl *ldapSASLAuthImpl) AuthLDAPSASL(userName, dn string, clientCredentials []byte, authConn conn.AuthConn) error {
+	method := impl.GetSASLAuthMethod()
+	// The LDAP server authenticates the user named in the SCRAM messages, it must be the user who logs in.
+	scramUser, err := scramUserName(clientCredentials)
+	if err != nil {
+		return err
+	}
+	if scramUser != userName {
+		return errors.Errorf("the SASL user %s doesn't match the user %s", scramUser, userName)
+	}
+
+	s := impl.settings()
+	if dn == "" && s.bindBaseDN != "" {
+		if dn, err = s.lookupUserDN(userName); err != nil {
+			return err
+		}
+	}
+	conn, err := s.dialRaw()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	credentials := clientCredentials
+	for {
+		resultCode, serverCredentials, err := conn.saslBind(dn, method, credentials)
+		if err != nil {
+			return err
+		}
+		switch resultCode {
+		case ldap.LDAPResultSuccess:
+			// The client verifies the server by the last message of SCRAM.
+			if len(serverCredentials) > 0 {
+				return authConn.WriteAuthMoreData(serverCredentials)
+			}
+			return nil
+		case ldap.LDAPResultSaslBindInProgress:
+			if err = authConn.WriteAuthMoreData(serverCredentials); err != nil {
+				return err
+			}
+			if credentials, err = authConn.ReadAuthData(); err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("LDAP SASL bind failed: %s", ldap.LDAPResultCodeMap[resultCode])
+		}
+	}
+}
+
+// scramUserName extracts the user name from the client-first-message of SCRAM, see RFC 5802.
+func scramUserName(clientFirst []byte) (string, error) {
+	// The message is `gs2-cbind-flag "," [authzid] "," "n=" saslname "," "r=" c-nonce ...`.
+	parts := strings.SplitN(string(clientFirst), ",", 4)
+	if len(parts) < 4 || !strings.HasPrefix(parts[2], "n=") {
+		return "", errors.New("malformed SCRAM client first message")
+	}
+	return strings.NewReplacer("=2C", ",", "=3D", "=").Replace(parts[2][2:]), nil
+}
+
+func (s *ldapSettings) lookupUserDN(userName string) (string, error) {
+	conn, err := s.connect()
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	return s.searchUserDN(conn, userName)
+}
+
+// rawConn sends the LDAP requests which aren't supported by the LDAP client library, such as the SASL binds with
+// arbitrary mechanisms. The requests are sent one by one.
+type rawConn struct {
+	net.Conn
+	messageID int64
+}
+
+func (s *ldapSettings) dialRaw() (*rawConn, error) {
+	if s.host == "" {
+		return nil, errors.New("the LDAP server host isn't set")
+	}
+	netConn, err := net.DialTimeout("tcp", s.address(), ldap.DefaultTimeout)
+	if err != nil {
+		return nil, errors.Annotate(err, "connect to the LDAP server")
+	}
+	conn := &rawConn{Conn: netConn}
+	if s.enableTLS {
+		if err = conn.startTLS(s.tlsConfig()); err != nil {
+			conn.Close()
+			return nil, errors.Annotate(err, "start TLS with the LDAP server")
+		}
+	}
+	return conn, nil
+}
+
+// request sends the protocol operation and returns the protocol operation of the response.
+func (c *rawConn) request(op *ber.Packet) (*ber.Packet, error) {
+	c.messageID++
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Request")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, c.messageID, "MessageID"))
+	packet.AppendChild(op)
+	if err := c.SetDeadline(time.Now().Add(ldap.DefaultTimeout)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := c.Write(packet.Bytes()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := ber.ReadPacket(c.Conn)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(resp.Children) < 2 || len(resp.Children[1].Children) < 3 {
+		return nil, errors.New("malformed LDAP response")
+	}
+	if id, ok := resp.Children[0].Value.(int64); !ok || id != c.messageID {
+		return nil, errors.New("unexpected LDAP message ID")
+	}
+	return resp.Children[1], nil
+}
+
+func resultCode(op *ber.Packet) uint16 {
+	code, _ := op.Children[0].Value.(int64)
+	return uint16(code)
+}
+
+func (c *rawConn) startTLS(config *tls.Config) error {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationExtendedRequest, nil, "Start TLS")
+	op.AppendChild(ber.NewString(ber.ClassContext, ber.TypePrimitive, 0, startTLSOID, "TLS Extended Command"))
+	resp, err := c.request(op)
+	if err != nil {
+		return err
+	}
+	if code := resultCode(resp); code != ldap.LDAPResultSuccess {
+		return errors.Errorf("StartTLS failed: %s", ldap.LDAPResultCodeMap[code])
+	}
+	tlsConn := tls.Client(c.Conn, config)
+	if err = tlsConn.Handshake(); err != nil {
+		return errors.Trace(err)
+	}
+	c.Conn = tlsConn
+	return nil
+}
+
+// saslBind sends a SASL bind request and returns the result code and the credentials of the server.
+func (c *rawConn) saslBind(dn, mechanism string, credentials []byte) (uint16, []byte, error) {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationBindRequest, nil, "Bind Request")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, 3, "Version"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "User Name"))
+	auth := ber.Encode(ber.ClassContext, ber.TypeConstructed, 3, nil, "SASL Credentials")
+	auth.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, mechanism, "Mechanism"))
+	auth.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, string(credentials), "Credentials"))
+	op.AppendChild(auth)
+	resp, err := c.request(op)
+	if err != nil {
+		return 0, nil, err
+	}
+	var serverCredentials []byte
+	for _, child := range resp.Children[3:] {
+		// serverSaslCreds is [7] of the BindResponse.
+		if child.ClassType == ber.ClassContext && child.Tag == 7 {
+			serverCredentials = child.Data.Bytes()
+		}
+	}
+	return resultCode(resp), serverCredentials, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"github.com/pingcap/errors"
+)
+
+// LDAPSimpleAuthImpl is the implementation of the `authentication_ldap_simple` plugin.
+var LDAPSimpleAuthImpl = &ldapSimpleAuthImpl{newLDAPAuthImpl()}
+
+type ldapSimpleAuthImpl struct {
+	ldapAuthImpl
+}
+
+// AuthLDAPSimple authenticates the user by binding to the LDAP server as the user DN with the cleartext password.
+// dn is the authentication string of the user, the user DN is searched by the user name if it's empty.
+func (impl *ldapSimpleAuthImpl) AuthLDAPSimple(userName, dn string, password []byte) error {
+	// The LDAP servers accept the binds with an empty password as the unauthenticated binds, so they're
+	// rejected here.
+	if len(password) == 0 {
+		return errors.New("the password of the LDAP user is empty")
+	}
+	s := impl.settings()
+	conn, err := s.connect()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if dn == "" {
+		if dn, err = s.searchUserDN(conn, userName); err != nil {
+			return err
+		}
+	}
+	if err = conn.Bind(dn, string(password)); err != nil {
+		return errors.Annotatef(err, "bind as the LDAP user %s", dn)
+	}
+	return nil
+}
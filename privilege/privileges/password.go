@@ -0,0 +1,129 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// passwordExpired checks whether the password of the account has expired at now. The password lifetime of the
+// account overrides the defaultLifetime, and 0 means the password never expires.
+func (record *UserRecord) passwordExpired(now time.Time, defaultLifetime int64) bool {
+	if record.PasswordExpired {
+		return true
+	}
+	lifetime := record.PasswordLifetime
+	if lifetime < 0 {
+		lifetime = defaultLifetime
+	}
+	if lifetime <= 0 || record.PasswordLastChanged.IsZero() {
+		return false
+	}
+	return !now.Before(record.PasswordLastChanged.AddDate(0, 0, int(lifetime)))
+}
+
+func (record *UserRecord) failedLoginTracked() bool {
+	return record.FailedLoginAttempts > 0 && record.PasswordLockTime != 0
+}
+
+type failedLoginState struct {
+	failures int64
+	locked   bool
+	lockedAt time.Time
+}
+
+// failedLoginTracker tracks the consecutive failed logins of the accounts whose `failed_login_attempts` and
+// `password_lock_time_days` are set. Like MySQL, the states are kept in memory, so they're reset when the
+// TiDB restarts and they're not shared among the TiDB instances.
+type failedLoginTracker struct {
+	mu       sync.Mutex
+	accounts map[string]*failedLoginState
+}
+
+func newFailedLoginTracker() *failedLoginTracker {
+	return &failedLoginTracker{accounts: make(map[string]*failedLoginState)}
+}
+
+func failedLoginKey(user, host string) string {
+	return user + "@" + host
+}
+
+// checkLocked returns whether the account is locked temporarily at now, and the remaining days of the lock.
+// The remaining days is -1 if the account is locked until it's unlocked.
+func (t *failedLoginTracker) checkLocked(record *UserRecord, now time.Time) (remaining int64, locked bool) {
+	key := failedLoginKey(record.User, record.Host)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.accounts[key]
+	if !ok {
+		return 0, false
+	}
+	if !record.failedLoginTracked() {
+		delete(t.accounts, key)
+		return 0, false
+	}
+	if !state.locked {
+		return 0, false
+	}
+	if record.PasswordLockTime < 0 {
+		return -1, true
+	}
+	unlockTime := state.lockedAt.AddDate(0, 0, int(record.PasswordLockTime))
+	if !now.Before(unlockTime) {
+		delete(t.accounts, key)
+		return 0, false
+	}
+	return int64(math.Ceil(unlockTime.Sub(now).Hours() / 24)), true
+}
+
+// onLogin records the result of a login, the account is locked when the consecutive failures reach the limit.
+func (t *failedLoginTracker) onLogin(record *UserRecord, now time.Time, success bool) {
+	if !record.failedLoginTracked() {
+		return
+	}
+	key := failedLoginKey(record.User, record.Host)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if success {
+		delete(t.accounts, key)
+		return
+	}
+	state, ok := t.accounts[key]
+	if !ok {
+		state = &failedLoginState{}
+		t.accounts[key] = state
+	}
+	state.failures++
+	if !state.locked && state.failures >= record.FailedLoginAttempts {
+		state.locked = true
+		state.lockedAt = now
+	}
+}
+
+func (t *failedLoginTracker) reset(user, host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.accounts, failedLoginKey(user, host))
+}
+
+func formatLockDays(days int64) string {
+	if days < 0 {
+		return "unlimited"
+	}
+	return strconv.FormatInt(days, 10)
+}
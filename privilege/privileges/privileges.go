@@ -21,13 +21,17 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pingcap/parser/auth"
 	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/infoschema/perfschema"
 	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/privilege/conn"
+	"github.com/pingcap/tidb/privilege/privileges/ldap"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/logutil"
@@ -54,6 +58,9 @@ var dynamicPrivs = []string{
 	"RESTRICTED_USER_ADMIN",           // User can not have their access revoked by SUPER users.
 	"RESTRICTED_CONNECTION_ADMIN",     // Can not be killed by PROCESS/CONNECTION_ADMIN privilege
 	"RESTRICTED_REPLICA_WRITER_ADMIN", // Can write to the sever even when tidb_restriced_read_only is turned on.
+	"RESOURCE_GROUP_ADMIN",            // Can switch the session to a resource group other than the one bound to the user.
+	"DDL_ADMIN",                       // Can pause and resume the DDL jobs.
+	"EXTERNAL_STORAGE_ADMIN",          // Can access the cloud storage with the credentials of the server.
 }
 var dynamicPrivLock sync.Mutex
 
@@ -191,7 +198,8 @@ func (p *UserPrivileges) RequestVerificationWithUser(db, table, column string, p
 
 func (p *UserPrivileges) isValidHash(record *UserRecord) bool {
 	pwd := record.AuthenticationString
-	if pwd == "" {
+	// The authentication strings of the LDAP plugins are the DNs of the users.
+	if pwd == "" || conn.IsLDAPAuthPlugin(record.AuthPlugin) {
 		return true
 	}
 	if record.AuthPlugin == mysql.AuthNativePassword {
@@ -240,6 +248,10 @@ func (p *UserPrivileges) GetAuthPlugin(user, host string) (string, error) {
 	if record == nil {
 		return "", errors.New("Failed to get user record")
 	}
+	// The LDAP plugins are used even if the DNs are empty, as the DNs are searched by the user names.
+	if conn.IsLDAPAuthPlugin(record.AuthPlugin) {
+		return record.AuthPlugin, nil
+	}
 	if len(record.AuthenticationString) == 0 {
 		return "", nil
 	}
@@ -275,7 +287,7 @@ func (p *UserPrivileges) GetAuthWithoutVerification(user, host string) (u string
 }
 
 // ConnectionVerification implements the Manager interface.
-func (p *UserPrivileges) ConnectionVerification(user, host string, authentication, salt []byte, tlsState *tls.ConnectionState) (u string, h string, success bool) {
+func (p *UserPrivileges) ConnectionVerification(user, host string, authentication, salt []byte, tlsState *tls.ConnectionState, authConn conn.AuthConn) (u string, h string, success bool) {
 	if SkipWithGrant {
 		p.user = user
 		p.host = host
@@ -313,10 +325,46 @@ func (p *UserPrivileges) ConnectionVerification(user, host string, authenticatio
 		return
 	}
 
+	// Login an account locked by the consecutive failed logins is not allowed.
+	if _, locked := p.Handle.failedLogins.checkLocked(record, time.Now()); locked {
+		logutil.BgLogger().Error("try to login an account locked by the failed logins",
+			zap.String("user", user), zap.String("host", host))
+		success = false
+		return
+	}
+
 	pwd := record.AuthenticationString
 	if !p.isValidHash(record) {
 		return
 	}
+	defer func() {
+		p.Handle.failedLogins.onLogin(record, time.Now(), success)
+	}()
+
+	switch record.AuthPlugin {
+	case conn.AuthLDAPSimple:
+		if err := ldap.LDAPSimpleAuthImpl.AuthLDAPSimple(user, pwd, authentication); err != nil {
+			logutil.BgLogger().Warn("verify through LDAP simple failed", zap.String("user", user), zap.Error(err))
+			return
+		}
+		p.user = user
+		p.host = h
+		success = true
+		return
+	case conn.AuthLDAPSASL:
+		if authConn == nil {
+			logutil.BgLogger().Warn("LDAP SASL authentication requires the client connection", zap.String("user", user))
+			return
+		}
+		if err := ldap.LDAPSASLAuthImpl.AuthLDAPSASL(user, pwd, authentication, authConn); err != nil {
+			logutil.BgLogger().Warn("verify through LDAP SASL failed", zap.String("user", user), zap.Error(err))
+			return
+		}
+		p.user = user
+		p.host = h
+		success = true
+		return
+	}
 
 	// empty password
 	if len(pwd) == 0 && len(authentication) == 0 {
@@ -604,6 +652,47 @@ func (p *UserPrivileges) GetDefaultRoles(user, host string) []*auth.RoleIdentity
 	return ret
 }
 
+// GetResourceGroup returns the resource group bound to the user, it's empty if there is none.
+func (p *UserPrivileges) GetResourceGroup(user, host string) string {
+	if SkipWithGrant {
+		return ""
+	}
+	record := p.Handle.Get().connectionVerification(user, host)
+	if record == nil {
+		return ""
+	}
+	return record.ResourceGroup
+}
+
+// IsPasswordExpired implements the Manager interface.
+func (p *UserPrivileges) IsPasswordExpired(user, host string) bool {
+	if SkipWithGrant {
+		return false
+	}
+	record := p.Handle.Get().connectionVerification(user, host)
+	if record == nil {
+		return false
+	}
+	return record.passwordExpired(time.Now(), variable.PasswordManagement.DefaultLifetime.Load())
+}
+
+// CheckPasswordLock implements the Manager interface.
+func (p *UserPrivileges) CheckPasswordLock(user, host string) error {
+	if SkipWithGrant {
+		return nil
+	}
+	record := p.Handle.Get().connectionVerification(user, host)
+	if record == nil {
+		return nil
+	}
+	remaining, locked := p.Handle.failedLogins.checkLocked(record, time.Now())
+	if !locked {
+		return nil
+	}
+	return errAccountBlockedByPasswordLock.FastGenByArgs(user, host, formatLockDays(record.PasswordLockTime),
+		formatLockDays(remaining), record.FailedLoginAttempts)
+}
+
 // GetAllRoles return all roles of user.
 func (p *UserPrivileges) GetAllRoles(user, host string) []*auth.RoleIdentity {
 	if SkipWithGrant {
@@ -29,11 +29,14 @@ import (
 	"github.com/pingcap/parser/auth"
 	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/parser/terror"
+	"github.com/pingcap/tidb/ddl"
 	"github.com/pingcap/tidb/executor"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/privilege/conn"
 	"github.com/pingcap/tidb/privilege/privileges"
+	"github.com/pingcap/tidb/privilege/privileges/ldap/ldaptest"
 	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/store/mockstore"
@@ -1406,6 +1409,10 @@ func TestLoadDataPrivilege(t *testing.T) {
 	require.True(t, se.Auth(&auth.UserIdentity{Username: "test_load", Hostname: "localhost"}, nil, nil))
 	_, err = se.ExecuteInternal(context.Background(), "LOAD DATA LOCAL INFILE '/tmp/load_data_priv.csv' INTO TABLE t_load")
 	require.NoError(t, err)
+	// Loading the files in the external storage requires the FILE privilege.
+	_, err = se.ExecuteInternal(context.Background(), "LOAD DATA INFILE 'local:///tmp/load_data_priv.csv' INTO TABLE t_load")
+	require.Error(t, err)
+	require.True(t, terror.ErrorEqual(err, core.ErrSpecificAccessDenied))
 }
 
 func TestSelectIntoNoPermissions(t *testing.T) {
@@ -2456,6 +2463,76 @@ func TestGrantPlacementAdminDynamicPriv(t *testing.T) {
 	tk.MustExec("DROP DATABASE placement_db")
 }
 
+func TestDDLJobsControlPrivilege(t *testing.T) {
+	store, clean := newStore(t)
+	defer clean()
+	se := newSession(t, store, dbName)
+	mustExec(t, se, "CREATE USER ddl_super, ddl_admin, ddl_empty")
+	mustExec(t, se, "GRANT SUPER ON *.* TO ddl_super")
+	mustExec(t, se, "GRANT DDL_ADMIN ON *.* TO ddl_admin")
+
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "ddl_empty", Hostname: "localhost"}, nil, nil))
+	for _, stmt := range []string{"admin pause ddl jobs 1", "admin resume ddl jobs 1"} {
+		_, err := se.ExecuteInternal(context.Background(), stmt)
+		require.EqualError(t, err, "[planner:1227]Access denied; you need (at least one of) the SUPER or DDL_ADMIN privilege(s) for this operation")
+	}
+	// The other ADMIN statements still need the SUPER privilege.
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "ddl_admin", Hostname: "localhost"}, nil, nil))
+	mustExec(t, se, "admin pause ddl jobs 1")
+	mustExec(t, se, "admin resume ddl jobs 1")
+	_, err := se.ExecuteInternal(context.Background(), "admin cancel ddl jobs 1")
+	require.Error(t, err)
+
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "ddl_super", Hostname: "localhost"}, nil, nil))
+	mustExec(t, se, "admin pause ddl jobs 1")
+	mustExec(t, se, "admin resume ddl jobs 1")
+}
+
+func TestAlterTableTTLPrivilege(t *testing.T) {
+	store, clean := newStore(t)
+	defer clean()
+	se := newSession(t, store, dbName)
+	mustExec(t, se, "CREATE USER ttl_select, ttl_alter")
+	mustExec(t, se, "CREATE TABLE ttl_t (id int, created_at datetime)")
+	mustExec(t, se, "GRANT SELECT ON test.ttl_t TO ttl_select")
+	mustExec(t, se, "GRANT ALTER ON test.ttl_t TO ttl_alter")
+
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "ttl_select", Hostname: "localhost"}, nil, nil))
+	for _, stmt := range []string{
+		"alter table ttl_t ttl = created_at + interval 1 day",
+		"alter table ttl_t ttl_enable = 'off'",
+		"alter table ttl_t remove ttl",
+	} {
+		_, err := se.ExecuteInternal(context.Background(), stmt)
+		require.EqualError(t, err, "[planner:1142]ALTER command denied to user 'ttl_select'@'%' for table 'ttl_t'")
+	}
+
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "ttl_alter", Hostname: "localhost"}, nil, nil))
+	mustExec(t, se, "alter table ttl_t ttl = created_at + interval 1 day")
+	mustExec(t, se, "alter table ttl_t ttl_enable = 'off'")
+	mustExec(t, se, "alter table ttl_t remove ttl")
+}
+
+func TestAlterTableCachePrivilege(t *testing.T) {
+	store, clean := newStore(t)
+	defer clean()
+	se := newSession(t, store, dbName)
+	mustExec(t, se, "CREATE USER cache_select, cache_alter")
+	mustExec(t, se, "CREATE TABLE cache_t (id int)")
+	mustExec(t, se, "GRANT SELECT ON test.cache_t TO cache_select")
+	mustExec(t, se, "GRANT ALTER ON test.cache_t TO cache_alter")
+
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "cache_select", Hostname: "localhost"}, nil, nil))
+	for _, stmt := range []string{"alter table cache_t cache", "alter table cache_t nocache"} {
+		_, err := se.ExecuteInternal(context.Background(), stmt)
+		require.EqualError(t, err, "[planner:1142]ALTER command denied to user 'cache_select'@'%' for table 'cache_t'")
+	}
+
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "cache_alter", Hostname: "localhost"}, nil, nil))
+	mustExec(t, se, "alter table cache_t cache")
+	mustExec(t, se, "alter table cache_t nocache")
+}
+
 func TestPlacementPolicyStmt(t *testing.T) {
 	store, clean := newStore(t)
 	defer clean()
@@ -2485,6 +2562,33 @@ func TestPlacementPolicyStmt(t *testing.T) {
 
 }
 
+func TestResourceGroupStmt(t *testing.T) {
+	store, clean := newStore(t)
+	defer clean()
+	se := newSession(t, store, dbName)
+	stmts := []string{
+		"create resource group rg_x ru_per_sec = 100",
+		"alter resource group rg_x ru_per_sec = 200 burstable",
+		"drop resource group rg_x",
+	}
+	mustExec(t, se, "CREATE USER rg_super_user, rg_admin_user, rg_empty_user")
+	mustExec(t, se, "GRANT SUPER ON *.* TO rg_super_user")
+	mustExec(t, se, "GRANT RESOURCE_GROUP_ADMIN ON *.* TO rg_admin_user")
+
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "rg_empty_user", Hostname: "localhost"}, nil, nil))
+	for _, stmt := range stmts {
+		_, err := se.ExecuteInternal(context.Background(), stmt)
+		require.EqualError(t, err, "[planner:1227]Access denied; you need (at least one of) the SUPER or RESOURCE_GROUP_ADMIN privilege(s) for this operation")
+	}
+
+	for _, user := range []string{"rg_super_user", "rg_admin_user"} {
+		require.True(t, se.Auth(&auth.UserIdentity{Username: user, Hostname: "localhost"}, nil, nil))
+		for _, stmt := range stmts {
+			mustExec(t, se, stmt)
+		}
+	}
+}
+
 func TestDBNameCaseSensitivityInTableLevel(t *testing.T) {
 	store, clean := newStore(t)
 	defer clean()
@@ -2492,3 +2596,221 @@ func TestDBNameCaseSensitivityInTableLevel(t *testing.T) {
 	mustExec(t, se, "CREATE USER test_user")
 	mustExec(t, se, "grant select on metrics_schema.up to test_user;")
 }
+
+func TestResourceGroupOfUser(t *testing.T) {
+	t.Parallel()
+	store, clean := newStore(t)
+	defer clean()
+	se := newSession(t, store, dbName)
+	mustExec(t, se, "CREATE RESOURCE GROUP rg1 RU_PER_SEC = 0")
+	mustExec(t, se, "CREATE RESOURCE GROUP rg2 RU_PER_SEC = 0")
+	mustExec(t, se, "CREATE USER rg_user, no_rg_user, rg_admin")
+	mustExec(t, se, "GRANT RESOURCE_GROUP_ADMIN ON *.* TO rg_admin")
+	mustExec(t, se, `UPDATE mysql.user SET User_attributes = '{"resource_group": "rg1"}' WHERE User IN ('rg_user', 'rg_admin')`)
+	mustExec(t, se, "FLUSH PRIVILEGES")
+
+	pc := privilege.GetPrivilegeManager(se)
+	require.Equal(t, "rg1", pc.GetResourceGroup("rg_user", "localhost"))
+	require.Equal(t, "", pc.GetResourceGroup("no_rg_user", "localhost"))
+
+	// The session uses the resource group bound to the user by default, and only switches away from it with
+	// SUPER or RESOURCE_GROUP_ADMIN.
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "rg_user", Hostname: "localhost"}, nil, nil))
+	require.Equal(t, "rg1", se.GetSessionVars().ResourceGroupName)
+	mustExec(t, se, "SET tidb_resource_group = 'RG1'")
+	for _, group := range []string{"rg2", "default", "rg3"} {
+		_, err := se.ExecuteInternal(context.Background(), fmt.Sprintf("SET tidb_resource_group = '%s'", group))
+		require.True(t, terror.ErrorEqual(err, core.ErrSpecificAccessDenied), err)
+	}
+	require.Equal(t, "RG1", se.GetSessionVars().ResourceGroupName)
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "no_rg_user", Hostname: "localhost"}, nil, nil))
+	require.Equal(t, "", se.GetSessionVars().ResourceGroupName)
+	mustExec(t, se, "SET tidb_resource_group = 'default'")
+	_, err := se.ExecuteInternal(context.Background(), "SET tidb_resource_group = 'rg1'")
+	require.True(t, terror.ErrorEqual(err, core.ErrSpecificAccessDenied), err)
+
+	// The group must exist.
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "rg_admin", Hostname: "localhost"}, nil, nil))
+	mustExec(t, se, "SET tidb_resource_group = 'rg2'")
+	require.Equal(t, "rg2", se.GetSessionVars().ResourceGroupName)
+	mustExec(t, se, "SET tidb_resource_group = ''")
+	_, err = se.ExecuteInternal(context.Background(), "SET tidb_resource_group = 'rg3'")
+	require.True(t, terror.ErrorEqual(err, ddl.ErrResourceGroupNotExists), err)
+	require.Equal(t, "", se.GetSessionVars().ResourceGroupName)
+}
+func TestPasswordExpiration(t *testing.T) {
+	t.Parallel()
+	store, clean := newStore(t)
+	defer clean()
+	se := newSession(t, store, dbName)
+	mustExec(t, se, "CREATE USER expired_user, expired_user2 PASSWORD EXPIRE")
+	mustExec(t, se, "CREATE USER lifetime_user PASSWORD EXPIRE INTERVAL 2 DAY")
+	pc := privilege.GetPrivilegeManager(se)
+	require.True(t, pc.IsPasswordExpired("expired_user", "%"))
+	require.False(t, pc.IsPasswordExpired("lifetime_user", "%"))
+
+	// The password expires when it's older than its lifetime.
+	mustExec(t, se, "UPDATE mysql.user SET password_last_changed = DATE_SUB(NOW(), INTERVAL 3 DAY) WHERE User = 'lifetime_user'")
+	mustExec(t, se, "FLUSH PRIVILEGES")
+	require.True(t, pc.IsPasswordExpired("lifetime_user", "%"))
+	mustExec(t, se, "ALTER USER lifetime_user PASSWORD EXPIRE NEVER")
+	require.False(t, pc.IsPasswordExpired("lifetime_user", "%"))
+	mustExec(t, se, "ALTER USER lifetime_user PASSWORD EXPIRE DEFAULT")
+	require.False(t, pc.IsPasswordExpired("lifetime_user", "%"))
+
+	// The session is in the sandbox mode until the password is reset.
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "expired_user", Hostname: "localhost"}, nil, nil))
+	require.True(t, se.GetSessionVars().PasswordExpired)
+	_, err := se.Execute(context.Background(), "SELECT 1")
+	require.True(t, terror.ErrorEqual(err, session.ErrMustChangePassword))
+	// Only the password of the session user can be reset, and no other option can be changed.
+	for _, sql := range []string{
+		"ALTER USER lifetime_user IDENTIFIED BY 'new_password'",
+		"ALTER USER expired_user PASSWORD EXPIRE NEVER",
+		"ALTER USER expired_user IDENTIFIED BY 'new_password' ACCOUNT UNLOCK",
+		"ALTER USER expired_user IDENTIFIED BY 'new_password', lifetime_user IDENTIFIED BY 'new_password'",
+		"ALTER USER expired_user REQUIRE SSL",
+		"SET PASSWORD FOR lifetime_user = 'new_password'",
+	} {
+		_, err = se.Execute(context.Background(), sql)
+		require.True(t, terror.ErrorEqual(err, session.ErrMustChangePassword), sql)
+	}
+	require.True(t, se.GetSessionVars().PasswordExpired)
+	_, err = se.Execute(context.Background(), "SET PASSWORD = 'new_password'")
+	require.NoError(t, err)
+	require.False(t, se.GetSessionVars().PasswordExpired)
+	_, err = se.Execute(context.Background(), "SELECT 1")
+	require.NoError(t, err)
+	require.False(t, pc.IsPasswordExpired("expired_user", "%"))
+
+	// ALTER USER can reset the password of the session user too.
+	require.True(t, se.Auth(&auth.UserIdentity{Username: "expired_user2", Hostname: "localhost"}, nil, nil))
+	require.True(t, se.GetSessionVars().PasswordExpired)
+	_, err = se.Execute(context.Background(), "ALTER USER expired_user2 IDENTIFIED BY 'new_password'")
+	require.NoError(t, err)
+	require.False(t, se.GetSessionVars().PasswordExpired)
+	require.False(t, pc.IsPasswordExpired("expired_user2", "%"))
+}
+
+func TestFailedLoginLockout(t *testing.T) {
+	t.Parallel()
+	store, clean := newStore(t)
+	defer clean()
+	se := newSession(t, store, dbName)
+	mustExec(t, se, "CREATE USER 'lock_user'@'localhost' IDENTIFIED BY 'abc' FAILED_LOGIN_ATTEMPTS 2 PASSWORD_LOCK_TIME 1")
+	pc := privilege.GetPrivilegeManager(se)
+	user := &auth.UserIdentity{Username: "lock_user", Hostname: "localhost"}
+	salt := []byte{85, 92, 45, 22, 58, 79, 107, 6, 122, 125, 58, 80, 12, 90, 103, 32, 90, 10, 74, 82}
+	authentication := []byte{24, 180, 183, 225, 166, 6, 81, 102, 70, 248, 199, 143, 91, 204, 169, 9, 161, 171, 203, 33}
+
+	// A successful login resets the consecutive failures.
+	require.False(t, se.Auth(user, nil, nil))
+	require.True(t, se.Auth(user, authentication, salt))
+	require.False(t, se.Auth(user, nil, nil))
+	require.NoError(t, pc.CheckPasswordLock("lock_user", "localhost"))
+
+	// The account is locked when the consecutive failures reach the limit, even the password is right.
+	require.False(t, se.Auth(user, nil, nil))
+	err := pc.CheckPasswordLock("lock_user", "localhost")
+	require.EqualError(t, err, "[privilege:3955]Access denied for user 'lock_user'@'localhost'. Account is blocked for 1 day(s) (1 day(s) remaining) due to 2 consecutive failed logins.")
+	require.False(t, se.Auth(user, authentication, salt))
+
+	// ACCOUNT UNLOCK unlocks the account.
+	se1 := newSession(t, store, dbName)
+	mustExec(t, se1, "ALTER USER 'lock_user'@'localhost' ACCOUNT UNLOCK")
+	require.NoError(t, pc.CheckPasswordLock("lock_user", "localhost"))
+	require.True(t, se.Auth(user, authentication, salt))
+
+	// ACCOUNT LOCK locks the account until it's unlocked.
+	mustExec(t, se1, "ALTER USER 'lock_user'@'localhost' ACCOUNT LOCK")
+	require.False(t, se.Auth(user, authentication, salt))
+	mustExec(t, se1, "ALTER USER 'lock_user'@'localhost' ACCOUNT UNLOCK")
+	require.True(t, se.Auth(user, authentication, salt))
+
+	// PASSWORD_LOCK_TIME UNBOUNDED locks the account until it's unlocked, the other attributes are kept.
+	mustExec(t, se1, `UPDATE mysql.user SET User_attributes = JSON_SET(User_attributes, '$.resource_group', 'rg1') WHERE User = 'lock_user'`)
+	mustExec(t, se1, "ALTER USER 'lock_user'@'localhost' PASSWORD_LOCK_TIME UNBOUNDED")
+	tk := testkit.NewTestKit(t, store)
+	tk.MustQuery("SELECT User_attributes FROM mysql.user WHERE User = 'lock_user'").Check(testkit.Rows(
+		`{"Password_locking": {"failed_login_attempts": 2, "password_lock_time_days": -1}, "resource_group": "rg1"}`))
+	require.False(t, se.Auth(user, nil, nil))
+	require.False(t, se.Auth(user, nil, nil))
+	err = pc.CheckPasswordLock("lock_user", "localhost")
+	require.EqualError(t, err, "[privilege:3955]Access denied for user 'lock_user'@'localhost'. Account is blocked for unlimited day(s) (unlimited day(s) remaining) due to 2 consecutive failed logins.")
+
+	// Setting the options resets the failures, and FAILED_LOGIN_ATTEMPTS 0 disables the tracking.
+	mustExec(t, se1, "ALTER USER 'lock_user'@'localhost' FAILED_LOGIN_ATTEMPTS 0")
+	require.NoError(t, pc.CheckPasswordLock("lock_user", "localhost"))
+	require.False(t, se.Auth(user, nil, nil))
+	require.False(t, se.Auth(user, nil, nil))
+	require.True(t, se.Auth(user, authentication, salt))
+}
+
+func TestLDAPAuthentication(t *testing.T) {
+	server, err := ldaptest.NewServer(nil)
+	require.NoError(t, err)
+	defer server.Close()
+	server.AddEntry(&ldaptest.Entry{
+		DN:         "uid=ldap_user,ou=people,dc=example,dc=com",
+		Password:   "ldap_pwd",
+		Attributes: map[string][]string{"uid": {"ldap_user"}},
+	})
+	server.AddEntry(&ldaptest.Entry{
+		DN:         "uid=sasl_user,ou=people,dc=example,dc=com",
+		Password:   "sasl_pwd",
+		Attributes: map[string][]string{"uid": {"sasl_user"}},
+	})
+
+	store, clean := newStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	for _, prefix := range []string{"authentication_ldap_simple", "authentication_ldap_sasl"} {
+		tk.MustExec(fmt.Sprintf("SET GLOBAL %s_server_host = '%s'", prefix, server.Host()))
+		tk.MustExec(fmt.Sprintf("SET GLOBAL %s_server_port = %d", prefix, server.Port()))
+		defer tk.MustExec(fmt.Sprintf("SET GLOBAL %s_server_host = DEFAULT", prefix))
+	}
+	tk.MustExec("SET GLOBAL authentication_ldap_simple_bind_base_dn = 'ou=people,dc=example,dc=com'")
+	defer tk.MustExec("SET GLOBAL authentication_ldap_simple_bind_base_dn = DEFAULT")
+	tk.MustExec("SET GLOBAL authentication_ldap_sasl_auth_method_name = 'SCRAM-SHA-256'")
+	defer tk.MustExec("SET GLOBAL authentication_ldap_sasl_auth_method_name = DEFAULT")
+
+	// The DN is either specified or searched by the user name.
+	tk.MustExec("CREATE USER 'ldap_user'@'localhost' IDENTIFIED WITH authentication_ldap_simple AS 'uid=ldap_user,ou=people,dc=example,dc=com'")
+	tk.MustExec("CREATE USER 'ldap_user'@'127.0.0.1' IDENTIFIED WITH authentication_ldap_simple")
+	tk.MustQuery("SHOW CREATE USER 'ldap_user'@'localhost'").Check(testkit.Rows(
+		"CREATE USER 'ldap_user'@'localhost' IDENTIFIED WITH 'authentication_ldap_simple' AS 'uid=ldap_user,ou=people,dc=example,dc=com' REQUIRE NONE PASSWORD EXPIRE DEFAULT ACCOUNT UNLOCK"))
+	tk.MustGetErrCode("SET PASSWORD FOR 'ldap_user'@'localhost' = 'new_pwd'", mysql.ErrSetPasswordAuthPlugin)
+
+	se := newSession(t, store, dbName)
+	pc := privilege.GetPrivilegeManager(se)
+	for _, host := range []string{"localhost", "127.0.0.1"} {
+		plugin, err := pc.GetAuthPlugin("ldap_user", host)
+		require.NoError(t, err)
+		require.Equal(t, conn.AuthLDAPSimple, plugin)
+		user := &auth.UserIdentity{Username: "ldap_user", Hostname: host}
+		require.True(t, se.Auth(user, []byte("ldap_pwd"), nil))
+		require.False(t, se.Auth(user, []byte("wrong_pwd"), nil))
+		require.False(t, se.Auth(user, nil, nil))
+	}
+
+	// The SASL messages are exchanged through the client connection.
+	tk.MustExec("CREATE USER 'sasl_user'@'localhost' IDENTIFIED WITH authentication_ldap_sasl")
+	user := &auth.UserIdentity{Username: "sasl_user", Hostname: "localhost"}
+	saslAuth := func(password string) bool {
+		client, err := ldaptest.NewSCRAMClient("SCRAM-SHA-256", "sasl_user", password)
+		require.NoError(t, err)
+		conn := &ldaptest.SCRAMAuthConn{Client: client}
+		se.GetSessionVars().AuthConn = conn
+		defer func() {
+			se.GetSessionVars().AuthConn = nil
+		}()
+		if !se.Auth(user, client.ClientFirst(), nil) {
+			return false
+		}
+		require.NoError(t, client.VerifyServerFinal(conn.ServerFinal))
+		return true
+	}
+	require.True(t, saslAuth("sasl_pwd"))
+	require.False(t, saslAuth("wrong_pwd"))
+	require.False(t, se.Auth(user, []byte("n,,n=sasl_user,r=abc"), nil))
+}
@@ -0,0 +1,152 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/atomic"
+)
+
+var _ kv.ResourceGroupLimiter = &Limiter{}
+
+// Limiter is the token bucket of a resource group. The bucket is refilled by RUPerSec tokens per second and holds
+// at most RUPerSec tokens. The request units of a request are unknown until it finishes, so a request is sent once
+// the bucket has tokens, and it's charged afterwards, which may leave the bucket in debt. Every TiDB instance has its
+// own bucket, so only the requests sent by this instance are counted.
+type Limiter struct {
+	name     string
+	limited  atomic.Bool
+	consumed atomic.Float64
+
+	readCounter  prometheus.Counter
+	writeCounter prometheus.Counter
+	throttled    prometheus.Observer
+
+	mu struct {
+		sync.Mutex
+		ruPerSec float64
+		tokens   float64
+		lastTime time.Time
+	}
+}
+
+func newLimiter(name string) *Limiter {
+	l := &Limiter{
+		name:         name,
+		readCounter:  metrics.ResourceGroupRUCounter.WithLabelValues(name, "read"),
+		writeCounter: metrics.ResourceGroupRUCounter.WithLabelValues(name, "write"),
+		throttled:    metrics.ResourceGroupThrottledDuration.WithLabelValues(name),
+	}
+	l.mu.lastTime = time.Now()
+	return l
+}
+
+// Name returns the name of the resource group.
+func (l *Limiter) Name() string {
+	return l.name
+}
+
+// ConsumedRU returns the request units consumed by the resource group since the TiDB started.
+func (l *Limiter) ConsumedRU() float64 {
+	return l.consumed.Load()
+}
+
+// reset changes the quota of the resource group, the tokens in the bucket are kept.
+func (l *Limiter) reset(ruPerSec uint64, burstable bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.refill(now)
+	if l.mu.ruPerSec == 0 {
+		l.mu.tokens = float64(ruPerSec)
+	}
+	l.mu.ruPerSec = float64(ruPerSec)
+	if l.mu.tokens > l.mu.ruPerSec {
+		l.mu.tokens = l.mu.ruPerSec
+	}
+	l.limited.Store(ruPerSec > 0 && !burstable)
+	metrics.ResourceGroupQuotaGauge.WithLabelValues(l.name).Set(float64(ruPerSec))
+}
+
+// refill adds the tokens produced since the last time, it must be called with the lock held.
+func (l *Limiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.mu.lastTime); elapsed > 0 {
+		l.mu.tokens += elapsed.Seconds() * l.mu.ruPerSec
+		if l.mu.tokens > l.mu.ruPerSec {
+			l.mu.tokens = l.mu.ruPerSec
+		}
+	}
+	l.mu.lastTime = now
+}
+
+// Wait implements the kv.ResourceGroupLimiter interface.
+func (l *Limiter) Wait(ctx context.Context) error {
+	var start time.Time
+	for l.limited.Load() {
+		l.mu.Lock()
+		now := time.Now()
+		l.refill(now)
+		if l.mu.tokens > 0 || l.mu.ruPerSec == 0 {
+			l.mu.Unlock()
+			break
+		}
+		wait := time.Duration(-l.mu.tokens / l.mu.ruPerSec * float64(time.Second))
+		l.mu.Unlock()
+
+		if start.IsZero() {
+			start = now
+		}
+		// Re-check the bucket at least every second in case the quota is changed.
+		if wait > time.Second {
+			wait = time.Second
+		}
+		timer := time.NewTimer(wait + time.Millisecond)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			l.throttled.Observe(time.Since(start).Seconds())
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if !start.IsZero() {
+		l.throttled.Observe(time.Since(start).Seconds())
+	}
+	return nil
+}
+
+// Consume implements the kv.ResourceGroupLimiter interface.
+func (l *Limiter) Consume(isWrite bool, bytes int) {
+	ru := RequestUnits(isWrite, bytes)
+	l.consumed.Add(ru)
+	if isWrite {
+		l.writeCounter.Add(ru)
+	} else {
+		l.readCounter.Add(ru)
+	}
+	if !l.limited.Load() {
+		return
+	}
+	l.mu.Lock()
+	l.refill(time.Now())
+	l.mu.tokens -= ru
+	l.mu.Unlock()
+}
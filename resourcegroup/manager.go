@@ -0,0 +1,77 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"sync"
+
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/metrics"
+)
+
+// Manager keeps the limiters of the resource groups in a TiDB.
+type Manager struct {
+	mu       sync.RWMutex
+	limiters map[string]*Limiter
+}
+
+// NewManager creates a Manager with the default resource group.
+func NewManager() *Manager {
+	m := &Manager{limiters: make(map[string]*Limiter)}
+	m.Update(nil)
+	return m
+}
+
+// Update replaces the resource groups with the given ones. The limiters of the existing groups are kept, so the
+// debts of them are kept too.
+func (m *Manager) Update(groups []*GroupInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	limiters := make(map[string]*Limiter, len(groups)+1)
+	groups = append([]*GroupInfo{{Name: model.NewCIStr(DefaultGroupName)}}, groups...)
+	for _, group := range groups {
+		l, ok := m.limiters[group.Name.L]
+		if !ok {
+			l = newLimiter(group.Name.L)
+		}
+		l.reset(group.RUPerSec, group.Burstable)
+		limiters[group.Name.L] = l
+	}
+	for name := range m.limiters {
+		if _, ok := limiters[name]; !ok {
+			metrics.ResourceGroupQuotaGauge.DeleteLabelValues(name)
+		}
+	}
+	m.limiters = limiters
+}
+
+// Exists checks whether the resource group exists, the default group always exists.
+func (m *Manager) Exists(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.limiters[model.NewCIStr(name).L]
+	return ok
+}
+
+// GetLimiter gets the limiter of the resource group, the limiter of the default group is returned if the group
+// doesn't exist.
+func (m *Manager) GetLimiter(name string) *Limiter {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if l, ok := m.limiters[model.NewCIStr(name).L]; ok {
+		return l
+	}
+	return m.limiters[DefaultGroupName]
+}
@@ -0,0 +1,101 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourcegroup throttles the kv requests of the resource groups according to their request unit quotas.
+package resourcegroup
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/meta"
+)
+
+// DefaultGroupName is the name of the resource group which the sessions without a group belong to. It's reserved
+// and never throttled.
+const DefaultGroupName = "default"
+
+// PerInstanceQuotaNote is reported when the quota of a resource group is set. The quota is enforced by each TiDB
+// instance separately, so the group can consume up to RU_PER_SEC times the number of TiDB instances in the cluster.
+const PerInstanceQuotaNote = "RU_PER_SEC is enforced by each TiDB instance separately, the cluster-wide quota is RU_PER_SEC times the number of TiDB instances"
+
+// The request units (RU) of the kv requests. A read request costs 1/8 RU and 1/64 RU per KiB, and a write request
+// costs 1 RU and 1 RU per KiB.
+const (
+	readRequestRU  = 1.0 / 8
+	readKiBRU      = 1.0 / 64
+	writeRequestRU = 1.0
+	writeKiBRU     = 1.0
+)
+
+// RequestUnits returns the request units of a kv request which reads or writes the given bytes.
+func RequestUnits(isWrite bool, bytes int) float64 {
+	if isWrite {
+		return writeRequestRU + float64(bytes)/1024*writeKiBRU
+	}
+	return readRequestRU + float64(bytes)/1024*readKiBRU
+}
+
+// GroupInfo is the definition of a resource group.
+type GroupInfo struct {
+	Name model.CIStr `json:"name"`
+	// RUPerSec is the request units the group can consume per second on each TiDB instance, 0 means unlimited. It
+	// isn't coordinated across the cluster, see PerInstanceQuotaNote.
+	RUPerSec uint64 `json:"ru_per_sec"`
+	// Burstable is true if the group isn't throttled when it consumes more than its quota. The consumed request
+	// units are still accounted.
+	Burstable bool `json:"burstable"`
+}
+
+// GetResourceGroup gets the resource group by the name, it returns nil if the group doesn't exist.
+func GetResourceGroup(m *meta.Meta, name string) (*GroupInfo, error) {
+	data, err := m.GetResourceGroup(model.NewCIStr(name).L)
+	if err != nil || data == nil {
+		return nil, errors.Trace(err)
+	}
+	group := &GroupInfo{}
+	if err := json.Unmarshal(data, group); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return group, nil
+}
+
+// SetResourceGroup creates or updates the resource group.
+func SetResourceGroup(m *meta.Meta, group *GroupInfo) error {
+	data, err := json.Marshal(group)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(m.SetResourceGroup(group.Name.L, data))
+}
+
+// ListResourceGroups lists all the resource groups ordered by the names.
+func ListResourceGroups(m *meta.Meta) ([]*GroupInfo, error) {
+	res, err := m.ListResourceGroups()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	groups := make([]*GroupInfo, 0, len(res))
+	for _, data := range res {
+		group := &GroupInfo{}
+		if err := json.Unmarshal(data, group); err != nil {
+			return nil, errors.Trace(err)
+		}
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name.L < groups[j].Name.L })
+	return groups, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/resourcegroup"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestUnits(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, 0.125, resourcegroup.RequestUnits(false, 0))
+	require.Equal(t, 0.125+1.0/64, resourcegroup.RequestUnits(false, 1024))
+	require.Equal(t, 1.0, resourcegroup.RequestUnits(true, 0))
+	require.Equal(t, 3.0, resourcegroup.RequestUnits(true, 2048))
+}
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	manager := resourcegroup.NewManager()
+	manager.Update([]*resourcegroup.GroupInfo{{Name: model.NewCIStr("RG1"), RUPerSec: 10}})
+	limiter := manager.GetLimiter("rg1")
+	require.Equal(t, "rg1", limiter.Name())
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	// The group is in debt after consuming more than its quota, so the requests wait for the tokens.
+	limiter.Consume(true, 15*1024)
+	require.Equal(t, 16.0, limiter.ConsumedRU())
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+
+	// The debt is kept when the quota is changed.
+	manager.Update([]*resourcegroup.GroupInfo{{Name: model.NewCIStr("rg1"), RUPerSec: 1000}})
+	require.Same(t, limiter, manager.GetLimiter("RG1"))
+	start := time.Now()
+	require.NoError(t, limiter.Wait(context.Background()))
+	require.Greater(t, time.Since(start), time.Millisecond)
+
+	// The burstable groups are never throttled.
+	manager.Update([]*resourcegroup.GroupInfo{{Name: model.NewCIStr("rg1"), RUPerSec: 1, Burstable: true}})
+	limiter.Consume(true, 1024*1024)
+	require.NoError(t, limiter.Wait(ctx))
+
+	// The default group is used if the group doesn't exist, and it's never throttled.
+	manager.Update(nil)
+	defaultLimiter := manager.GetLimiter("rg1")
+	require.Equal(t, resourcegroup.DefaultGroupName, defaultLimiter.Name())
+	require.Same(t, defaultLimiter, manager.GetLimiter(""))
+	defaultLimiter.Consume(true, 1024*1024)
+	require.NoError(t, defaultLimiter.Wait(ctx))
+}
+
+func TestResourceGroup(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	manager := dom.ResourceGroupManager()
+
+	tk.MustExec("create resource group Batch ru_per_sec = 2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1105 " + resourcegroup.PerInstanceQuotaNote))
+	tk.MustGetErrCode("create resource group batch ru_per_sec = 2", errno.ErrResourceGroupExists)
+	tk.MustExec("create resource group if not exists batch ru_per_sec = 2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 3650 Resource Group 'batch' already exists."))
+	err := tk.ExecToErr("create resource group `Default` ru_per_sec = 2")
+	require.EqualError(t, err, "resource group 'Default' is reserved")
+	tk.MustGetErrCode("alter resource group rg ru_per_sec = 2", errno.ErrResourceGroupNotExists)
+	require.Equal(t, "batch", manager.GetLimiter("BATCH").Name())
+	tk.MustQuery("select name, ru_per_sec, burstable from information_schema.resource_groups").Check(testkit.Rows("Batch 2 NO"))
+
+	// The kv requests of the session are throttled by its resource group.
+	tk.MustExec("create table t (id int primary key, v int)")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("set tidb_resource_group = 'batch'")
+	tk.MustQuery("select @@tidb_resource_group").Check(testkit.Rows("batch"))
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		tk.MustQuery("select v from t where id = 1").Check(testkit.Rows("1"))
+		tk.MustQuery("select sum(v) from t").Check(testkit.Rows("6"))
+	}
+	require.Greater(t, time.Since(start), 2*time.Second)
+	require.Greater(t, manager.GetLimiter("batch").ConsumedRU(), 60*0.125)
+	consumed := manager.GetLimiter("batch").ConsumedRU()
+	tk.MustExec("insert into t values (4, 4)")
+	require.GreaterOrEqual(t, manager.GetLimiter("batch").ConsumedRU()-consumed, 1.0)
+
+	// The requests aren't throttled after the quota is removed.
+	tk.MustExec("alter resource group batch burstable")
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	tk.MustQuery("select name, ru_per_sec, burstable from information_schema.resource_groups").Check(testkit.Rows("Batch 2 YES"))
+	tk.MustExec("alter resource group batch ru_per_sec = 0 burstable = false")
+	tk.MustQuery("select name, ru_per_sec, burstable from information_schema.resource_groups").Check(testkit.Rows("Batch 0 NO"))
+	start = time.Now()
+	for i := 0; i < 30; i++ {
+		tk.MustQuery("select v from t where id = 1").Check(testkit.Rows("1"))
+	}
+	require.Less(t, time.Since(start), 2*time.Second)
+
+	// The sessions of a dropped group fall back to the default group.
+	tk.MustExec("drop resource group batch")
+	tk.MustGetErrCode("drop resource group batch", errno.ErrResourceGroupNotExists)
+	tk.MustExec("drop resource group if exists batch")
+	tk.MustQuery("select count(*) from information_schema.resource_groups").Check(testkit.Rows("0"))
+	require.Equal(t, resourcegroup.DefaultGroupName, manager.GetLimiter("batch").Name())
+	consumed = manager.GetLimiter(resourcegroup.DefaultGroupName).ConsumedRU()
+	tk.MustQuery("select v from t where id = 1").Check(testkit.Rows("1"))
+	require.Greater(t, manager.GetLimiter(resourcegroup.DefaultGroupName).ConsumedRU(), consumed)
+	tk.MustGetErrCode("set tidb_resource_group = 'batch'", errno.ErrResourceGroupNotExists)
+	tk.MustExec("set tidb_resource_group = 'default'")
+}
@@ -0,0 +1,143 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// The actions taken on the runaway queries.
+const (
+	// RunawayActionDryRun only logs the runaway queries.
+	RunawayActionDryRun = "DRYRUN"
+	// RunawayActionCooldown lowers the priority of the remaining requests of the runaway queries.
+	RunawayActionCooldown = "COOLDOWN"
+	// RunawayActionKill kills the runaway queries.
+	RunawayActionKill = "KILL"
+)
+
+// RunawayWatchSyncInterval is the interval to sync the runaway watch list with mysql.tidb_runaway_watch.
+var RunawayWatchSyncInterval = 3 * time.Second
+
+// WatchRecord is a SQL digest in the runaway watch list, the executions of the statements with the digest are
+// rejected until the record expires.
+type WatchRecord struct {
+	// ID is the id of the record in mysql.tidb_runaway_watch, it's 0 before the record is persisted.
+	ID        int64
+	SQLDigest string
+	SampleSQL string
+	StartTime time.Time
+	// EndTime is zero if the record never expires.
+	EndTime time.Time
+	// Source is the TiDB which identifies the runaway query, or "manual" for the records added by users.
+	Source string
+}
+
+func (r *WatchRecord) expired(now time.Time) bool {
+	return !r.EndTime.IsZero() && !now.Before(r.EndTime)
+}
+
+// RunawayManager keeps the runaway watch list of a TiDB. The list is loaded from mysql.tidb_runaway_watch
+// periodically, and the records added by the TiDB are queued until they're persisted to the table.
+type RunawayManager struct {
+	mu      sync.RWMutex
+	watches map[string]*WatchRecord
+	pending []*WatchRecord
+}
+
+// NewRunawayManager creates a RunawayManager with an empty watch list.
+func NewRunawayManager() *RunawayManager {
+	return &RunawayManager{watches: make(map[string]*WatchRecord)}
+}
+
+// AddWatch adds the record to the watch list, it's ignored if the digest is already watched.
+func (m *RunawayManager) AddWatch(record *WatchRecord) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if r, ok := m.watches[record.SQLDigest]; ok && !r.expired(time.Now()) {
+		return
+	}
+	m.watches[record.SQLDigest] = record
+	m.pending = append(m.pending, record)
+}
+
+// GetWatch returns the record of the digest, nil is returned if the digest isn't watched.
+func (m *RunawayManager) GetWatch(digest string) *WatchRecord {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	r, ok := m.watches[digest]
+	if !ok || r.expired(time.Now()) {
+		return nil
+	}
+	return r
+}
+
+// Watches returns the unexpired records in the watch list, ordered by the start time.
+func (m *RunawayManager) Watches() []*WatchRecord {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	now := time.Now()
+	records := make([]*WatchRecord, 0, len(m.watches))
+	for _, r := range m.watches {
+		if !r.expired(now) {
+			records = append(records, r)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].StartTime.Equal(records[j].StartTime) {
+			return records[i].StartTime.Before(records[j].StartTime)
+		}
+		return records[i].SQLDigest < records[j].SQLDigest
+	})
+	return records
+}
+
+// TakePendingWatches returns the records that haven't been persisted, and clears them.
+func (m *RunawayManager) TakePendingWatches() []*WatchRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pending := m.pending
+	m.pending = nil
+	return pending
+}
+
+// RequeueWatches queues the records again if they fail to be persisted.
+func (m *RunawayManager) RequeueWatches(records []*WatchRecord) {
+	if len(records) == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending = append(records, m.pending...)
+}
+
+// ResetWatches replaces the watch list with the records loaded from mysql.tidb_runaway_watch, the records that
+// haven't been persisted are kept.
+func (m *RunawayManager) ResetWatches(records []*WatchRecord) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	watches := make(map[string]*WatchRecord, len(records)+len(m.pending))
+	for _, r := range records {
+		watches[r.SQLDigest] = r
+	}
+	for _, r := range m.pending {
+		if _, ok := watches[r.SQLDigest]; !ok {
+			watches[r.SQLDigest] = r
+		}
+	}
+	m.watches = watches
+}
@@ -0,0 +1,132 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/parser"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/resourcegroup"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunawayManager(t *testing.T) {
+	t.Parallel()
+
+	m := resourcegroup.NewRunawayManager()
+	now := time.Now()
+	m.AddWatch(&resourcegroup.WatchRecord{SQLDigest: "d1", StartTime: now, EndTime: now.Add(time.Hour)})
+	m.AddWatch(&resourcegroup.WatchRecord{SQLDigest: "d2", StartTime: now.Add(-time.Hour), EndTime: now})
+	// The digest which is already watched is ignored.
+	m.AddWatch(&resourcegroup.WatchRecord{SQLDigest: "d1", StartTime: now.Add(time.Second)})
+	require.NotNil(t, m.GetWatch("d1"))
+	require.Equal(t, now.Add(time.Hour), m.GetWatch("d1").EndTime)
+	require.Nil(t, m.GetWatch("d2"))
+	require.Nil(t, m.GetWatch("d3"))
+	watches := m.Watches()
+	require.Len(t, watches, 1)
+	require.Equal(t, "d1", watches[0].SQLDigest)
+
+	// The expired digest can be watched again.
+	m.AddWatch(&resourcegroup.WatchRecord{SQLDigest: "d2", StartTime: now.Add(-time.Minute)})
+	watches = m.Watches()
+	require.Len(t, watches, 2)
+	require.Equal(t, "d2", watches[0].SQLDigest)
+	require.True(t, watches[0].EndTime.IsZero())
+
+	// The records which aren't persisted are kept after the list is reset.
+	pending := m.TakePendingWatches()
+	require.Len(t, pending, 3)
+	require.Empty(t, m.TakePendingWatches())
+	m.RequeueWatches(pending[2:])
+	m.ResetWatches([]*resourcegroup.WatchRecord{{ID: 1, SQLDigest: "d1", StartTime: now}, {ID: 2, SQLDigest: "d3", StartTime: now}})
+	require.Nil(t, m.GetWatch("d4"))
+	require.Equal(t, int64(1), m.GetWatch("d1").ID)
+	require.Equal(t, int64(2), m.GetWatch("d3").ID)
+	require.Equal(t, int64(0), m.GetWatch("d2").ID)
+	require.Len(t, m.TakePendingWatches(), 1)
+}
+
+func TestRunawayWatch(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int)")
+	tk.MustExec("insert into t values (1, 1), (2, 2)")
+	manager := dom.RunawayManager()
+
+	// The rules are set by the global variables.
+	tk.MustQuery("select @@global.tidb_runaway_action, @@global.tidb_runaway_watch_duration").Check(testkit.Rows("KILL 0"))
+	tk.MustExec("set global tidb_runaway_action = 'cooldown'")
+	tk.MustExec("set global tidb_runaway_exec_elapsed_time = 100")
+	require.Equal(t, resourcegroup.RunawayActionCooldown, variable.RunawayRules.Action.Load())
+	require.Equal(t, int64(100), variable.RunawayRules.ExecElapsedTime.Load())
+	tk.MustExec("set global tidb_runaway_action = default")
+	tk.MustExec("set global tidb_runaway_exec_elapsed_time = default")
+	require.Equal(t, resourcegroup.RunawayActionKill, variable.RunawayRules.Action.Load())
+	require.Equal(t, int64(0), variable.RunawayRules.ExecElapsedTime.Load())
+	_, err := tk.Exec("set global tidb_runaway_action = 'pause'")
+	require.Error(t, err)
+
+	// The statements with the quarantined digest are rejected.
+	_, digest := parser.NormalizeDigest("select v from t where id = 1")
+	tk.MustExec("insert into mysql.tidb_runaway_watch (sql_digest, sample_sql) values (?, 'select v from t where id = 1')", digest.String())
+	require.Eventually(t, func() bool {
+		return manager.GetWatch(digest.String()) != nil
+	}, 5*time.Second, 50*time.Millisecond)
+	tk.MustGetErrCode("select v from t where id = 2", errno.ErrRunawayQueryQuarantined)
+	tk.MustQuery("select v from t where id > 1").Check(testkit.Rows("2"))
+	stmtID, _, _, err := tk.Session().PrepareStmt("select v from t where id = ?")
+	require.NoError(t, err)
+	_, err = tk.Session().ExecutePreparedStmt(context.Background(), stmtID, types.MakeDatums(1))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "runaway watch list")
+	tk.MustQuery("select sql_digest, sample_sql, end_time, source from information_schema.runaway_watches").Check(
+		testkit.Rows(digest.String() + " select v from t where id = 1 <nil> manual"))
+
+	// The digest isn't quarantined after it's removed from the watch list.
+	tk.MustExec("delete from mysql.tidb_runaway_watch")
+	require.Eventually(t, func() bool {
+		return manager.GetWatch(digest.String()) == nil
+	}, 5*time.Second, 50*time.Millisecond)
+	tk.MustQuery("select v from t where id = 1").Check(testkit.Rows("1"))
+
+	// The digests identified by TiDB are persisted, and they're removed after they expire.
+	now := time.Now()
+	manager.AddWatch(&resourcegroup.WatchRecord{
+		SQLDigest: digest.String(),
+		SampleSQL: "select v from t where id = 2",
+		StartTime: now,
+		EndTime:   now.Add(3 * time.Second),
+		Source:    "127.0.0.1:4000",
+	})
+	tk.MustGetErrCode("select v from t where id = 1", errno.ErrRunawayQueryQuarantined)
+	require.Eventually(t, func() bool {
+		rows := tk.MustQuery("select sql_digest, source from mysql.tidb_runaway_watch where end_time is not null").Rows()
+		return len(rows) == 1 && rows[0][0] == digest.String() && rows[0][1] == "127.0.0.1:4000"
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from mysql.tidb_runaway_watch").Rows()) == 0 && manager.GetWatch(digest.String()) == nil
+	}, 10*time.Second, 100*time.Millisecond)
+	tk.MustQuery("select v from t where id = 1").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(*) from information_schema.runaway_watches").Check(testkit.Rows("0"))
+}
@@ -71,6 +71,8 @@ import (
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/plugin"
 	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/privilege/conn"
+	"github.com/pingcap/tidb/privilege/privileges/ldap"
 	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
@@ -196,12 +198,33 @@ type clientConn struct {
 }
 
 func (cc *clientConn) String() string {
-	collationStr := mysql.Collations[cc.collation]
+	collationStr := mysql.Collations[uint16(cc.collation)]
 	return fmt.Sprintf("id:%d, addr:%s status:%b, collation:%s, user:%s",
 		cc.connectionID, cc.bufReadConn.RemoteAddr(), cc.ctx.Status(), collationStr, cc.user,
 	)
 }
 
+// The client plugins of the LDAP authentication plugins.
+const (
+	authMySQLClearPassword = "mysql_clear_password"
+	authLDAPSASLClient     = "authentication_ldap_sasl_client"
+)
+
+// authMoreData is the header of the packets which carry the data of the authentication plugins.
+// https://dev.mysql.com/doc/internals/en/connection-phase-packets.html#packet-Protocol::AuthMoreData
+const authMoreData byte = 0x01
+
+// clientAuthPlugin returns the plugin the client uses to authenticate with the server plugin.
+func clientAuthPlugin(plugin string) string {
+	switch plugin {
+	case conn.AuthLDAPSimple:
+		return authMySQLClearPassword
+	case conn.AuthLDAPSASL:
+		return authLDAPSASLClient
+	}
+	return plugin
+}
+
 // authSwitchRequest is used by the server to ask the client to switch to a different authentication
 // plugin. MySQL 8.0 libmysqlclient based clients by default always try `caching_sha2_password`, even
 // when the server advertises the its default to be `mysql_native_password`. In addition to this switching
@@ -209,12 +232,17 @@ func (cc *clientConn) String() string {
 // https://dev.mysql.com/doc/internals/en/connection-phase-packets.html#packet-Protocol::AuthSwitchRequest
 // https://bugs.mysql.com/bug.php?id=93044
 func (cc *clientConn) authSwitchRequest(ctx context.Context, plugin string) ([]byte, error) {
-	enclen := 1 + len(plugin) + 1 + len(cc.salt) + 1
+	authData := cc.salt
+	if plugin == authLDAPSASLClient {
+		// The SASL client begins with the mechanism.
+		authData = []byte(ldap.LDAPSASLAuthImpl.GetSASLAuthMethod())
+	}
+	enclen := 1 + len(plugin) + 1 + len(authData) + 1
 	data := cc.alloc.AllocWithLen(4, enclen)
 	data = append(data, mysql.AuthSwitchRequest) // switch request
 	data = append(data, []byte(plugin)...)
 	data = append(data, byte(0x00)) // requires null
-	data = append(data, cc.salt...)
+	data = append(data, authData...)
 	data = append(data, 0)
 	err := cc.writePacket(data)
 	if err != nil {
@@ -443,7 +471,7 @@ func parseOldHandshakeResponseHeader(ctx context.Context, packet *handshakeRespo
 	// skip max packet size
 	offset += 3
 	// usa default CharsetID
-	packet.Collation = mysql.CollationNames["utf8mb4_general_ci"]
+	packet.Collation = uint8(mysql.CollationNames["utf8mb4_general_ci"])
 
 	return offset, nil
 }
@@ -683,6 +711,9 @@ func (cc *clientConn) readOptionalSSLRequestAndHandshakeResponse(ctx context.Con
 	newAuth, err := cc.checkAuthPlugin(ctx, &resp.AuthPlugin)
 	if err != nil {
 		logutil.Logger(ctx).Warn("failed to check the user authplugin", zap.Error(err))
+		if errAccessDenied.Equal(err) {
+			return err
+		}
 	}
 	if len(newAuth) > 0 {
 		resp.Auth = newAuth
@@ -694,7 +725,10 @@ func (cc *clientConn) readOptionalSSLRequestAndHandshakeResponse(ctx context.Con
 		if err != nil {
 			return err
 		}
-	case mysql.AuthNativePassword:
+	case conn.AuthLDAPSimple:
+		// The cleartext password is terminated by null.
+		resp.Auth = bytes.TrimSuffix(resp.Auth, []byte{0})
+	case mysql.AuthNativePassword, conn.AuthLDAPSASL:
 	default:
 		return errors.New("Unknown auth plugin")
 	}
@@ -784,9 +818,23 @@ func (cc *clientConn) openSessionAndDoAuth(authData []byte) error {
 	if err != nil {
 		return err
 	}
-	if !cc.ctx.Auth(&auth.UserIdentity{Username: cc.user, Hostname: host}, authData, cc.salt) {
+	sessionVars := cc.ctx.GetSessionVars()
+	sessionVars.AuthConn = cc
+	authenticated := cc.ctx.Auth(&auth.UserIdentity{Username: cc.user, Hostname: host}, authData, cc.salt)
+	sessionVars.AuthConn = nil
+	if !authenticated {
+		if pm := privilege.GetPrivilegeManager(cc.ctx.Session); pm != nil {
+			if err := pm.CheckPasswordLock(cc.user, host); err != nil {
+				return err
+			}
+		}
 		return errAccessDenied.FastGenByArgs(cc.user, host, hasPassword)
 	}
+	// The clients which can't handle the sandbox mode are disconnected if the passwords have expired,
+	// as `disconnect_on_expired_password` is always ON.
+	if cc.ctx.GetSessionVars().PasswordExpired && cc.capability&clientCanHandleExpiredPasswords == 0 {
+		return errMustChangePasswordLogin.GenWithStackByArgs()
+	}
 	cc.ctx.SetPort(port)
 	if cc.dbname != "" {
 		err = cc.useDB(context.Background(), cc.dbname)
@@ -816,14 +864,24 @@ func (cc *clientConn) checkAuthPlugin(ctx context.Context, authPlugin *string) (
 		*authPlugin = mysql.AuthNativePassword
 		return nil, nil
 	}
+	if userplugin == conn.AuthLDAPSimple && cc.tlsConn == nil {
+		// The client sends the cleartext password to `authentication_ldap_simple`, don't ask it to do
+		// so over an insecure connection.
+		host, _, err := cc.PeerHost("YES")
+		if err != nil {
+			return nil, err
+		}
+		return nil, errAccessDenied.FastGenByArgs(cc.user, host, "YES")
+	}
 
 	// If the authentication method send by the server (cc.authPlugin) doesn't match
 	// the plugin configured for the user account in the mysql.user.plugin column
 	// or if the authentication method send by the server doesn't match the authentication
 	// method send by the client (*authPlugin) then we need to switch the authentication
 	// method to match the one configured for that specific user.
-	if (cc.authPlugin != userplugin) || (cc.authPlugin != *authPlugin) {
-		authData, err := cc.authSwitchRequest(ctx, userplugin)
+	clientPlugin := clientAuthPlugin(userplugin)
+	if (cc.authPlugin != clientPlugin) || (cc.authPlugin != *authPlugin) {
+		authData, err := cc.authSwitchRequest(ctx, clientPlugin)
 		if err != nil {
 			return nil, err
 		}
@@ -834,6 +892,23 @@ func (cc *clientConn) checkAuthPlugin(ctx context.Context, authPlugin *string) (
 	return nil, nil
 }
 
+// WriteAuthMoreData implements the conn.AuthConn interface.
+func (cc *clientConn) WriteAuthMoreData(data []byte) error {
+	enclen := 1 + len(data)
+	buf := cc.alloc.AllocWithLen(4, enclen)
+	buf = append(buf, authMoreData)
+	buf = append(buf, data...)
+	if err := cc.writePacket(buf); err != nil {
+		return err
+	}
+	return cc.flush(context.Background())
+}
+
+// ReadAuthData implements the conn.AuthConn interface.
+func (cc *clientConn) ReadAuthData() ([]byte, error) {
+	return cc.readPacket()
+}
+
 func (cc *clientConn) PeerHost(hasPassword string) (host, port string, err error) {
 	if len(cc.peerHost) > 0 {
 		return cc.peerHost, "", nil
@@ -232,13 +232,17 @@ func (cc *clientConn) executePreparedStmtAndWriteResult(ctx context.Context, stm
 	// we should hold the ResultSet in PreparedStatement for next stmt_fetch, and only send back ColumnInfo.
 	// Tell the client cursor exists in server by setting proper serverStatus.
 	if useCursor {
-		stmt.StoreResultSet(rs)
-		err = cc.writeColumnInfo(rs.Columns(), mysql.ServerStatusCursorExists)
+		// The rows are materialized and the executor is closed here, so the statement finishes and the cursor
+		// doesn't block the other statements. Nothing is sent to the client yet, so it can be retried.
+		sessVars := cc.ctx.GetSessionVars()
+		crs, err := newCursorResultSet(ctx, rs, sessVars.MemQuotaCursorFetch, sessVars.MaxChunkSize)
 		if err != nil {
-			return false, err
+			return true, errors.Annotate(err, cc.preparedStmt2String(uint32(stmt.ID())))
 		}
-		if cl, ok := rs.(fetchNotifier); ok {
-			cl.OnFetchReturned()
+		stmt.StoreResultSet(crs)
+		err = cc.writeColumnInfo(crs.Columns(), mysql.ServerStatusCursorExists)
+		if err != nil {
+			return false, err
 		}
 		// explicitly flush columnInfo to client.
 		return false, cc.flush(ctx)
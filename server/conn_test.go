@@ -21,12 +21,15 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/parser/model"
 	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/executor"
 	"github.com/pingcap/tidb/session"
@@ -802,8 +805,10 @@ func TestTiFlashFallback(t *testing.T) {
 	tk.MustQuery("show warnings").Check(testkit.Rows("Error 9012 TiFlash server timeout"))
 
 	// test COM_STMT_FETCH (cursor mode)
+	// the rows of the cursor are read when executing, so it falls back when executing too.
 	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
-	require.Error(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0}))
+	tk.MustQuery("show warnings").Check(testkit.Rows("Error 9012 TiFlash server timeout"))
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0}))
 	tk.MustExec("set @@tidb_allow_fallback_to_tikv=''")
 	require.Error(t, cc.handleStmtExecute(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0}))
 	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/store/mockstore/unistore/BatchCopRpcErrtiflash0"))
@@ -863,6 +868,123 @@ func testFallbackWork(t *testing.T, tk *testkit.TestKit, cc *clientConn, sql str
 	tk.MustQuery("show warnings").Check(testkit.Rows("Error 9012 TiFlash server timeout"))
 }
 
+func TestCursorFetch(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	cc := &clientConn{
+		alloc: arena.NewAllocator(1024),
+		pkt: &packetIO{
+			bufWriter: bufio.NewWriter(bytes.NewBuffer(nil)),
+		},
+	}
+	ctx := context.Background()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	cc.ctx = &TiDBContext{Session: tk.Session(), stmts: make(map[int]*TiDBStatement)}
+
+	tk.MustExec("create table t(a int primary key, b varchar(20))")
+	dml := "insert into t values"
+	for i := 0; i < 50; i++ {
+		if i != 0 {
+			dml += ","
+		}
+		dml += fmt.Sprintf("(%v, 'a')", i)
+	}
+	tk.MustExec(dml)
+	tk.MustExec("set @@tidb_max_chunk_size = 32")
+	// The rows of the cursors exceed the quota, so they are spilled to the disk.
+	tk.MustExec("set @@tidb_mem_quota_cursor_fetch = 1")
+
+	getCursor := func(stmtID int) *cursorResultSet {
+		crs, ok := cc.ctx.GetStatement(stmtID).GetResultSet().(*cursorResultSet)
+		require.True(t, ok)
+		return crs
+	}
+	// Open two cursors on the same connection.
+	require.NoError(t, cc.handleStmtPrepare(ctx, "select * from t"))
+	require.NoError(t, cc.handleStmtPrepare(ctx, "select a from t where a < 20"))
+	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
+	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x2, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
+	crs1, crs2 := getCursor(1), getCursor(2)
+	for _, crs := range []*cursorResultSet{crs1, crs2} {
+		// The rows are spilled in the background.
+		require.Eventually(t, crs.rowContainer.AlreadySpilledSafeForTest, 5*time.Second, 10*time.Millisecond)
+	}
+	require.Equal(t, 50, crs1.rowContainer.NumRow())
+	require.Equal(t, 20, crs2.rowContainer.NumRow())
+
+	// The executors are closed, so the other statements can run between the fetches.
+	for i := 0; i < 5; i++ {
+		require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0xa, 0x0, 0x0, 0x0}))
+		tk.MustExec("insert into t values (?, 'b')", 100+i)
+		require.Equal(t, int32(0), atomic.LoadInt32(&crs1.closed))
+	}
+	require.Len(t, crs1.GetFetchedRows(), 0)
+	// The cursor is closed after all the rows are fetched.
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0xa, 0x0, 0x0, 0x0}))
+	require.Equal(t, int32(1), atomic.LoadInt32(&crs1.closed))
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0xa, 0x0, 0x0, 0x0}))
+
+	// The cursor is released when resetting the statement.
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x2, 0x0, 0x0, 0x0, 0xa, 0x0, 0x0, 0x0}))
+	require.Len(t, crs2.GetFetchedRows(), 10)
+	require.NoError(t, cc.handleStmtReset(ctx, []byte{0x2, 0x0, 0x0, 0x0}))
+	require.Equal(t, int32(1), atomic.LoadInt32(&crs2.closed))
+	require.Nil(t, cc.ctx.GetStatement(2).GetResultSet())
+
+	// Execute the statement again, the rows inserted above are read.
+	tk.MustExec("set @@tidb_mem_quota_cursor_fetch = default")
+	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
+	crs1 = getCursor(1)
+	require.False(t, crs1.rowContainer.AlreadySpilledSafeForTest())
+	require.Equal(t, 55, crs1.rowContainer.NumRow())
+	require.NoError(t, cc.handleStmtClose([]byte{0x1, 0x0, 0x0, 0x0}))
+	require.Equal(t, int32(1), atomic.LoadInt32(&crs1.closed))
+}
+
+func TestCursorFetchSpillWithoutTmpStorage(t *testing.T) {
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.OOMUseTmpStorage = false
+	})
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	cc := &clientConn{
+		alloc: arena.NewAllocator(1024),
+		pkt: &packetIO{
+			bufWriter: bufio.NewWriter(bytes.NewBuffer(nil)),
+		},
+	}
+	ctx := context.Background()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	cc.ctx = &TiDBContext{Session: tk.Session(), stmts: make(map[int]*TiDBStatement)}
+	tk.MustExec("create table t(a int primary key)")
+	tk.MustExec("insert into t values (1), (2), (3)")
+
+	// The rows of a cursor are always bounded, so they are spilled even if oom-use-tmp-storage is disabled.
+	tk.MustExec("set @@tidb_mem_quota_cursor_fetch = 1")
+	require.NoError(t, cc.handleStmtPrepare(ctx, "select * from t"))
+	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
+	crs, ok := cc.ctx.GetStatement(1).GetResultSet().(*cursorResultSet)
+	require.True(t, ok)
+	require.Eventually(t, crs.rowContainer.AlreadySpilledSafeForTest, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 3, crs.rowContainer.NumRow())
+	require.NoError(t, cc.handleStmtClose([]byte{0x1, 0x0, 0x0, 0x0}))
+
+	// A zero quota falls back to the default one instead of no limit.
+	tk.MustExec("set @@tidb_mem_quota_cursor_fetch = 0")
+	require.NoError(t, cc.handleStmtPrepare(ctx, "select * from t"))
+	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x2, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
+	crs, ok = cc.ctx.GetStatement(2).GetResultSet().(*cursorResultSet)
+	require.True(t, ok)
+	require.Equal(t, int64(variable.DefTiDBMemQuotaCursorFetch), crs.rowContainer.GetMemTracker().GetBytesLimit())
+	require.NoError(t, cc.handleStmtClose([]byte{0x2, 0x0, 0x0, 0x0}))
+}
+
 // For issue https://github.com/pingcap/tidb/issues/25069
 func TestShowErrors(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,136 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pingcap/parser/terror"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/memory"
+)
+
+// cursorResultSet is the ResultSet of a server-side cursor. All the rows are materialized when the statement is
+// executed, and the rows are spilled to the disk once they exceed the memory quota. So the executor is closed before
+// fetching, and the cursor neither pins the resources of the executor nor blocks the other statements on the connection.
+type cursorResultSet struct {
+	columns      []*ColumnInfo
+	fieldTypes   []*types.FieldType
+	rowContainer *chunk.RowContainer
+	maxChunkSize int
+	// chkIdx is the index of the next chunk to be read from rowContainer.
+	chkIdx int
+	rows   []chunk.Row
+	closed int32
+}
+
+// newCursorResultSet reads all the rows of rs into a cursorResultSet, rs is always closed after that.
+func newCursorResultSet(ctx context.Context, rs ResultSet, memQuota int64, maxChunkSize int) (_ *cursorResultSet, err error) {
+	defer terror.Call(rs.Close)
+
+	crs := &cursorResultSet{
+		columns:      rs.Columns(),
+		fieldTypes:   rs.FieldTypes(),
+		maxChunkSize: maxChunkSize,
+	}
+	crs.rowContainer = chunk.NewRowContainer(crs.fieldTypes, maxChunkSize)
+	memTracker := crs.rowContainer.GetMemTracker()
+	memTracker.SetLabel(memory.LabelForCursorFetch)
+	if memQuota <= 0 {
+		// A non-positive quota means no limit for the trackers, but the rows of a cursor must be bounded.
+		memQuota = variable.DefTiDBMemQuotaCursorFetch
+	}
+	memTracker.SetBytesLimit(memQuota)
+	memTracker.AttachToGlobalTracker(executor.GlobalMemoryUsageTracker)
+	// The rows may be kept for a long time between the fetches, so they are always spilled once they exceed the
+	// quota, no matter whether oom-use-tmp-storage is enabled.
+	memTracker.FallbackOldAndSetNewAction(crs.rowContainer.ActionSpill())
+	crs.rowContainer.GetDiskTracker().SetLabel(memory.LabelForCursorFetch)
+	crs.rowContainer.GetDiskTracker().AttachToGlobalTracker(executor.GlobalDiskUsageTracker)
+	defer func() {
+		if err != nil {
+			terror.Call(crs.Close)
+		}
+	}()
+
+	for {
+		req := rs.NewChunk()
+		if err = rs.Next(ctx, req); err != nil {
+			return nil, err
+		}
+		if req.NumRows() == 0 {
+			return crs, nil
+		}
+		if err = crs.rowContainer.Add(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Columns implements ResultSet Columns method.
+func (crs *cursorResultSet) Columns() []*ColumnInfo {
+	return crs.columns
+}
+
+// FieldTypes implements ResultSet FieldTypes method.
+func (crs *cursorResultSet) FieldTypes() []*types.FieldType {
+	return crs.fieldTypes
+}
+
+// NewChunk implements ResultSet NewChunk method.
+func (crs *cursorResultSet) NewChunk() *chunk.Chunk {
+	return chunk.New(crs.fieldTypes, crs.maxChunkSize, crs.maxChunkSize)
+}
+
+// Next implements ResultSet Next method, it reads the materialized rows chunk by chunk.
+func (crs *cursorResultSet) Next(_ context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if atomic.LoadInt32(&crs.closed) == 1 || crs.chkIdx >= crs.rowContainer.NumChunks() {
+		return nil
+	}
+	chk, err := crs.rowContainer.GetChunk(crs.chkIdx)
+	if err != nil {
+		return err
+	}
+	crs.chkIdx++
+	req.Append(chk, 0, chk.NumRows())
+	return nil
+}
+
+// StoreFetchedRows implements ResultSet StoreFetchedRows method.
+func (crs *cursorResultSet) StoreFetchedRows(rows []chunk.Row) {
+	crs.rows = rows
+}
+
+// GetFetchedRows implements ResultSet GetFetchedRows method.
+func (crs *cursorResultSet) GetFetchedRows() []chunk.Row {
+	return crs.rows
+}
+
+// Close implements ResultSet Close method, it releases the memory and the disk of the rows.
+func (crs *cursorResultSet) Close() error {
+	if !atomic.CompareAndSwapInt32(&crs.closed, 0, 1) {
+		return nil
+	}
+	crs.rows = nil
+	err := crs.rowContainer.Close()
+	crs.rowContainer.GetMemTracker().DetachFromGlobalTracker()
+	crs.rowContainer.GetDiskTracker().DetachFromGlobalTracker()
+	return err
+}
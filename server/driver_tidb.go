@@ -333,6 +333,15 @@ func (trs *tidbResultSet) OnFetchReturned() {
 	}
 }
 
+func (trs *tidbResultSet) FieldTypes() []*types.FieldType {
+	fields := trs.recordSet.Fields()
+	fieldTypes := make([]*types.FieldType, 0, len(fields))
+	for _, field := range fields {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	return fieldTypes
+}
+
 func (trs *tidbResultSet) Columns() []*ColumnInfo {
 	if trs.columns != nil {
 		return trs.columns
@@ -101,15 +101,21 @@ var (
 	errSecureTransportRequired = dbterror.ClassServer.NewStd(errno.ErrSecureTransportRequired)
 	errMultiStatementDisabled  = dbterror.ClassServer.NewStd(errno.ErrMultiStatementDisabled)
 	errNewAbortingConnection   = dbterror.ClassServer.NewStd(errno.ErrNewAbortingConnection)
+	errMustChangePasswordLogin = dbterror.ClassServer.NewStd(errno.ErrMustChangePasswordLogin)
 )
 
+// clientCanHandleExpiredPasswords is the capability of the clients that can handle the sandbox mode, which the
+// sessions are in if the passwords have expired. The parser doesn't define the flag yet.
+const clientCanHandleExpiredPasswords uint32 = 1 << 22
+
 // DefaultCapability is the capability of the server when it is created using the default configuration.
 // When server is configured with SSL, the server will have extra capabilities compared to DefaultCapability.
 const defaultCapability = mysql.ClientLongPassword | mysql.ClientLongFlag |
 	mysql.ClientConnectWithDB | mysql.ClientProtocol41 |
 	mysql.ClientTransactions | mysql.ClientSecureConnection | mysql.ClientFoundRows |
 	mysql.ClientMultiStatements | mysql.ClientMultiResults | mysql.ClientLocalFiles |
-	mysql.ClientConnectAtts | mysql.ClientPluginAuth | mysql.ClientInteractive
+	mysql.ClientConnectAtts | mysql.ClientPluginAuth | mysql.ClientInteractive |
+	clientCanHandleExpiredPasswords
 
 // Server is the MySQL protocol server
 type Server struct {
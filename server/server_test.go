@@ -41,6 +41,7 @@ import (
 	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/privilege/privileges/ldap/ldaptest"
 	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/versioninfo"
@@ -1660,6 +1661,77 @@ func (cli *testServerClient) runTestIssue22646(c *C) {
 	})
 }
 
+func (cli *testServerClient) runTestLDAPSimpleAuth(c *C) {
+	ldapServer, err := ldaptest.NewServer(nil)
+	c.Assert(err, IsNil)
+	defer ldapServer.Close()
+	ldapServer.AddEntry(&ldaptest.Entry{
+		DN:         "uid=ldap_auth,ou=people,dc=example,dc=com",
+		Password:   "ldap_pwd",
+		Attributes: map[string][]string{"uid": {"ldap_auth"}},
+	})
+	cli.runTests(c, nil, func(dbt *DBTest) {
+		dbt.mustExec(fmt.Sprintf("SET GLOBAL authentication_ldap_simple_server_host = '%s'", ldapServer.Host()))
+		dbt.mustExec(fmt.Sprintf("SET GLOBAL authentication_ldap_simple_server_port = %d", ldapServer.Port()))
+		dbt.mustExec("SET GLOBAL authentication_ldap_simple_bind_base_dn = 'ou=people,dc=example,dc=com'")
+		dbt.mustExec("CREATE USER 'ldap_auth'@'%' IDENTIFIED WITH authentication_ldap_simple")
+	})
+	defer cli.runTests(c, nil, func(dbt *DBTest) {
+		dbt.mustExec("SET GLOBAL authentication_ldap_simple_server_host = DEFAULT")
+		dbt.mustExec("SET GLOBAL authentication_ldap_simple_bind_base_dn = DEFAULT")
+	})
+
+	// The client is switched to mysql_clear_password, which must be allowed explicitly.
+	cli.runTests(c, func(config *mysql.Config) {
+		config.User = "ldap_auth"
+		config.Passwd = "ldap_pwd"
+		config.AllowCleartextPasswords = true
+		config.TLSConfig = "skip-verify"
+		config.DBName = ""
+	}, func(dbt *DBTest) {
+		rows := dbt.mustQuery("SELECT CURRENT_USER()")
+		c.Assert(rows.Next(), IsTrue)
+		var user string
+		c.Assert(rows.Scan(&user), IsNil)
+		c.Assert(user, Equals, "ldap_auth@%")
+		c.Assert(rows.Close(), IsNil)
+	})
+
+	for _, overrider := range []configOverrider{
+		func(config *mysql.Config) {
+			config.User = "ldap_auth"
+			config.Passwd = "wrong_pwd"
+			config.AllowCleartextPasswords = true
+			config.TLSConfig = "skip-verify"
+			config.DBName = ""
+		},
+		func(config *mysql.Config) {
+			config.User = "ldap_auth"
+			config.Passwd = "ldap_pwd"
+			config.TLSConfig = "skip-verify"
+			config.DBName = ""
+		},
+	} {
+		db, err := sql.Open("mysql", cli.getDSN(overrider))
+		c.Assert(err, IsNil)
+		_, err = db.Exec("SELECT 1")
+		c.Assert(err, NotNil)
+		c.Assert(db.Close(), IsNil)
+	}
+
+	// The server refuses to switch the client to mysql_clear_password over an insecure connection.
+	db, err := sql.Open("mysql", cli.getDSN(func(config *mysql.Config) {
+		config.User = "ldap_auth"
+		config.Passwd = "ldap_pwd"
+		config.AllowCleartextPasswords = true
+		config.DBName = ""
+	}))
+	c.Assert(err, IsNil)
+	_, err = db.Exec("SELECT 1")
+	c.Assert(err, ErrorMatches, ".*Access denied for user 'ldap_auth'.*")
+	c.Assert(db.Close(), IsNil)
+}
+
 func (cli *testServerClient) runTestIssue3682(c *C) {
 	cli.runTests(c, nil, func(dbt *DBTest) {
 		dbt.mustExec(`CREATE USER 'issue3682'@'%' IDENTIFIED BY '123';`)
@@ -902,6 +902,29 @@ func (ts *tidbTestSerialSuite) TestTLSAuto(c *C) {
 	server.Close()
 }
 
+func (ts *tidbTestSerialSuite) TestLDAPSimpleAuth(c *C) {
+	// The cleartext passwords of `authentication_ldap_simple` are only accepted over TLS.
+	cli := newTestServerClient()
+	cfg := newTestConfig()
+	cfg.Port = cli.port
+	cfg.Status.ReportStatus = false
+	cfg.Security.AutoTLS = true
+	cfg.Security.RSAKeySize = 528 // Reduces unittest runtime
+	err := os.MkdirAll(cfg.TempStoragePath, 0700)
+	c.Assert(err, IsNil)
+	server, err := NewServer(cfg, ts.tidbdrv)
+	c.Assert(err, IsNil)
+	cli.port = getPortFromTCPAddr(server.listener.Addr())
+	go func() {
+		err := server.Run()
+		c.Assert(err, IsNil)
+	}()
+	time.Sleep(time.Millisecond * 100)
+	cli.runTestLDAPSimpleAuth(c)
+
+	server.Close()
+}
+
 func (ts *tidbTestSerialSuite) TestTLSBasic(c *C) {
 	// Generate valid TLS certificates.
 	caCert, caKey, err := generateCert(0, "TiDB CA", nil, nil, "/tmp/ca-key.pem", "/tmp/ca-cert.pem")
@@ -1541,7 +1564,7 @@ func (ts *tidbTestSerialSuite) TestDefaultCharacterAndCollation(c *C) {
 		variable string
 		except   string
 	}{
-		{"collation_connection", "utf8mb4_bin"},
+		{"collation_connection", "utf8mb4_0900_ai_ci"},
 		{"character_set_connection", "utf8mb4"},
 		{"character_set_client", "utf8mb4"},
 	}
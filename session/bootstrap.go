@@ -90,6 +90,12 @@ const (
 		Create_Tablespace_Priv  ENUM('N','Y') NOT NULL DEFAULT 'N',
 		Repl_slave_priv	    	ENUM('N','Y') NOT NULL DEFAULT 'N',
 		Repl_client_priv		ENUM('N','Y') NOT NULL DEFAULT 'N',
+		User_attributes			JSON,
+		password_expired		ENUM('N','Y') NOT NULL DEFAULT 'N',
+		password_last_changed	TIMESTAMP NULL DEFAULT NULL,
+		password_lifetime		SMALLINT UNSIGNED NULL DEFAULT NULL,
+		Password_reuse_history	SMALLINT UNSIGNED NULL DEFAULT NULL,
+		Password_reuse_time		SMALLINT UNSIGNED NULL DEFAULT NULL,
 		PRIMARY KEY (Host, User));`
 	// CreateGlobalPrivTable is the SQL statement creates Global scope privilege table in system db.
 	CreateGlobalPrivTable = "CREATE TABLE IF NOT EXISTS mysql.global_priv (" +
@@ -348,6 +354,52 @@ const (
 		key idx(filter_type),
 		primary key(id)
 	);`
+	// CreateTTLJobHistory stores the history and the progress of the TTL jobs.
+	CreateTTLJobHistory = `CREATE TABLE IF NOT EXISTS mysql.tidb_ttl_job_history (
+		job_id bigint(64) NOT NULL AUTO_INCREMENT,
+		table_id bigint(64) NOT NULL,
+		table_schema varchar(64) NOT NULL,
+		table_name varchar(64) NOT NULL,
+		create_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		finish_time timestamp NULL DEFAULT NULL,
+		expire_time datetime NOT NULL,
+		total_ranges bigint(64) NOT NULL DEFAULT 0,
+		finished_ranges bigint(64) NOT NULL DEFAULT 0,
+		deleted_rows bigint(64) NOT NULL DEFAULT 0,
+		status varchar(16) NOT NULL,
+		summary text,
+		PRIMARY KEY (job_id),
+		KEY idx_table(table_id, create_time)
+	);`
+	// CreateTableCacheMetaTable stores the locks of the cached tables.
+	CreateTableCacheMetaTable = `CREATE TABLE IF NOT EXISTS mysql.table_cache_meta (
+		tid bigint(11) NOT NULL DEFAULT 0,
+		lock_type enum('NONE','READ','INTEND','WRITE') NOT NULL DEFAULT 'NONE',
+		lease bigint(20) UNSIGNED NOT NULL DEFAULT 0,
+		oldReadLease bigint(20) UNSIGNED NOT NULL DEFAULT 0,
+		PRIMARY KEY (tid)
+	);`
+	// CreateRunawayWatchTable stores the runaway watch list, the executions of the statements with the SQL digests
+	// in the list are rejected until end_time. A NULL end_time means the digest is watched forever.
+	CreateRunawayWatchTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_runaway_watch (
+		id bigint(64) NOT NULL AUTO_INCREMENT,
+		sql_digest varchar(64) NOT NULL,
+		sample_sql text,
+		start_time datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		end_time datetime NULL DEFAULT NULL,
+		source varchar(512) NOT NULL DEFAULT 'manual',
+		PRIMARY KEY (id),
+		KEY idx_sql_digest(sql_digest)
+	);`
+	// CreatePasswordHistoryTable stores the previous passwords of the accounts, which are checked against the
+	// password reuse policies when the passwords are changed.
+	CreatePasswordHistoryTable = `CREATE TABLE IF NOT EXISTS mysql.password_history (
+		Host CHAR(255) NOT NULL DEFAULT '',
+		User CHAR(32) NOT NULL DEFAULT '',
+		Password_timestamp TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		Password TEXT,
+		PRIMARY KEY (Host, User, Password_timestamp)
+	);`
 )
 
 // bootstrap initiates system DB for a store.
@@ -513,11 +565,23 @@ const (
 	version74 = 74
 	// version75 update mysql.*.host from char(60) to char(255)
 	version75 = 75
+	// version76 adds mysql.tidb_ttl_job_history table
+	version76 = 76
+	// version77 adds mysql.table_cache_meta table
+	version77 = 77
+	// version78 adds User_attributes column to mysql.user, which keeps the resource group bound to the user.
+	version78 = 78
+	// version79 adds mysql.tidb_runaway_watch table
+	version79 = 79
+	// version80 adds the password management columns to mysql.user and adds mysql.password_history table
+	version80 = 80
+	// version81 enables tidb_enable_change_multi_schema, which was disabled by default.
+	version81 = 81
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version75
+var currentBootstrapVersion int64 = version81
 
 var (
 	bootstrapVersion = []func(Session, int64){
@@ -596,6 +660,12 @@ var (
 		upgradeToVer73,
 		upgradeToVer74,
 		upgradeToVer75,
+		upgradeToVer76,
+		upgradeToVer77,
+		upgradeToVer78,
+		upgradeToVer79,
+		upgradeToVer80,
+		upgradeToVer81,
 	}
 )
 
@@ -1571,6 +1641,54 @@ func upgradeToVer75(s Session, ver int64) {
 	doReentrantDDL(s, "ALTER TABLE mysql.columns_priv MODIFY COLUMN Host CHAR(255)")
 }
 
+func upgradeToVer76(s Session, ver int64) {
+	if ver >= version76 {
+		return
+	}
+	doReentrantDDL(s, CreateTTLJobHistory)
+}
+
+func upgradeToVer77(s Session, ver int64) {
+	if ver >= version77 {
+		return
+	}
+	doReentrantDDL(s, CreateTableCacheMetaTable)
+}
+
+func upgradeToVer78(s Session, ver int64) {
+	if ver >= version78 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.user ADD COLUMN `User_attributes` JSON", infoschema.ErrColumnExists)
+}
+
+func upgradeToVer79(s Session, ver int64) {
+	if ver >= version79 {
+		return
+	}
+	doReentrantDDL(s, CreateRunawayWatchTable)
+}
+
+func upgradeToVer80(s Session, ver int64) {
+	if ver >= version80 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.user ADD COLUMN `password_expired` ENUM('N','Y') NOT NULL DEFAULT 'N'", infoschema.ErrColumnExists)
+	doReentrantDDL(s, "ALTER TABLE mysql.user ADD COLUMN `password_last_changed` TIMESTAMP NULL DEFAULT NULL", infoschema.ErrColumnExists)
+	doReentrantDDL(s, "ALTER TABLE mysql.user ADD COLUMN `password_lifetime` SMALLINT UNSIGNED NULL DEFAULT NULL", infoschema.ErrColumnExists)
+	doReentrantDDL(s, "ALTER TABLE mysql.user ADD COLUMN `Password_reuse_history` SMALLINT UNSIGNED NULL DEFAULT NULL", infoschema.ErrColumnExists)
+	doReentrantDDL(s, "ALTER TABLE mysql.user ADD COLUMN `Password_reuse_time` SMALLINT UNSIGNED NULL DEFAULT NULL", infoschema.ErrColumnExists)
+	doReentrantDDL(s, CreatePasswordHistoryTable)
+}
+
+func upgradeToVer81(s Session, ver int64) {
+	if ver >= version81 {
+		return
+	}
+	// The old default value of `tidb_enable_change_multi_schema` is OFF, the multi-schema changes are enabled by default now.
+	mustExecute(s, "UPDATE HIGH_PRIORITY mysql.global_variables SET VARIABLE_VALUE='ON' WHERE VARIABLE_NAME = 'tidb_enable_change_multi_schema' AND VARIABLE_VALUE = 'OFF'")
+}
+
 func writeOOMAction(s Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -1651,6 +1769,14 @@ func doDDLWorks(s Session) {
 	mustExecute(s, CreateGlobalGrantsTable)
 	// Create capture_plan_baselines_blacklist
 	mustExecute(s, CreateCapturePlanBaselinesBlacklist)
+	// Create tidb_ttl_job_history
+	mustExecute(s, CreateTTLJobHistory)
+	// Create table_cache_meta
+	mustExecute(s, CreateTableCacheMetaTable)
+	// Create tidb_runaway_watch
+	mustExecute(s, CreateRunawayWatchTable)
+	// Create password_history
+	mustExecute(s, CreatePasswordHistoryTable)
 }
 
 // doDMLWorks executes DML statements in bootstrap stage.
@@ -1661,7 +1787,7 @@ func doDMLWorks(s Session) {
 
 	// Insert a default user with empty password.
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO mysql.user VALUES
-		("%", "root", "", "mysql_native_password", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "N", "Y", "Y", "Y", "Y", "Y", "Y", "Y")`)
+		("%", "root", "", "mysql_native_password", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "Y", "N", "Y", "Y", "Y", "Y", "Y", "Y", "Y", null, "N", null, null, null, null)`)
 
 	// Init global system variables table.
 	values := make([]string, 0, len(variable.GetSysVars()))
@@ -1682,13 +1808,6 @@ func doDMLWorks(s Session) {
 					vVal = string(variable.Dynamic)
 				}
 			}
-			if v.Name == variable.TiDBEnableChangeMultiSchema {
-				vVal = variable.Off
-				if flag.Lookup("test.v") != nil || flag.Lookup("check.v") != nil {
-					// enable change multi schema in test case for compatibility with old cases.
-					vVal = variable.On
-				}
-			}
 			if v.Name == variable.TiDBEnableAsyncCommit && config.GetGlobalConfig().Store == "tikv" {
 				vVal = variable.On
 			}
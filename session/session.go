@@ -45,6 +45,7 @@ import (
 	"github.com/pingcap/parser/model"
 	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/parser/terror"
+	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/temptable"
 	"github.com/pingcap/tidb/util/topsql"
 	"github.com/pingcap/tipb/go-binlog"
@@ -91,6 +92,7 @@ import (
 	"github.com/pingcap/tidb/util/timeutil"
 	tikvstore "github.com/tikv/client-go/v2/kv"
 	"github.com/tikv/client-go/v2/tikv"
+	"github.com/tikv/client-go/v2/txnkv/transaction"
 	tikvutil "github.com/tikv/client-go/v2/util"
 )
 
@@ -228,6 +230,9 @@ type session struct {
 	builtinFunctionUsage telemetry.BuiltinFunctionsUsage
 	// allowed when tikv disk full happened.
 	diskFullOpt kvrpcpb.DiskFullOpt
+	// mdlTables is the IDs of the tables whose metadata locks are held by the transaction, it is not nil
+	// after the transaction acquires any metadata lock.
+	mdlTables map[int64]struct{}
 }
 
 var parserPool = &sync.Pool{New: func() interface{} { return parser.New() }}
@@ -535,10 +540,15 @@ func (s *session) doCommit(ctx context.Context) error {
 		if _, ok := temporaryTables[id]; ok {
 			continue
 		}
+		// The DDL jobs wait for the transaction before they change the locked tables twice.
+		if s.holdsMetadataLock(id) {
+			continue
+		}
 		physicalTableIDs = append(physicalTableIDs, id)
 	}
 	// Set this option for 2 phase commit to validate schema lease.
-	s.txn.SetOption(kv.SchemaChecker, domain.NewSchemaChecker(domain.GetDomain(s), s.GetInfoSchema().SchemaMetaVersion(), physicalTableIDs))
+	schemaChecker := domain.NewSchemaChecker(domain.GetDomain(s), s.GetInfoSchema().SchemaMetaVersion(), physicalTableIDs)
+	s.txn.SetOption(kv.SchemaChecker, schemaChecker)
 	s.txn.SetOption(kv.InfoSchema, s.sessionVars.TxnCtx.InfoSchema)
 	s.txn.SetOption(kv.CommitHook, func(info string, _ error) { s.sessionVars.LastTxnInfo = info })
 	if sessVars.EnableAmendPessimisticTxn {
@@ -560,10 +570,51 @@ func (s *session) doCommit(ctx context.Context) error {
 	if tables := sessVars.TxnCtx.TemporaryTables; len(tables) > 0 {
 		s.txn.SetOption(kv.KVFilter, temporaryTableKVFilter(tables))
 	}
+	// The write locks of the cached tables are kept alive until the transaction is committed.
+	if tables := sessVars.TxnCtx.CachedTables; len(tables) > 0 {
+		checker := &cachedTableLeaseChecker{
+			SchemaLeaseChecker: schemaChecker,
+			names:              make([]string, 0, len(tables)),
+			leases:             make([]uint64, len(tables)),
+		}
+		for _, tbl := range tables {
+			cached := tbl.(table.CachedTable)
+			checker.names = append(checker.names, cached.Meta().Name.O)
+			stop, err := cached.WriteLockAndKeepAlive(ctx, &checker.leases[len(checker.names)-1])
+			if err != nil {
+				return errors.Trace(err)
+			}
+			defer stop()
+		}
+		// The commit ts of async commit and 1PC is decided by TiKV, it can't be checked against the write leases.
+		s.txn.SetOption(kv.EnableAsyncCommit, false)
+		s.txn.SetOption(kv.Enable1PC, false)
+		s.txn.SetOption(kv.SchemaChecker, checker)
+	}
 
 	return s.commitTxnWithTemporaryData(tikvutil.SetSessionID(ctx, sessVars.ConnectionID), &s.txn)
 }
 
+// cachedTableLeaseChecker rejects the commit when the commit ts reaches the write lease of any cached table written
+// by the transaction. After the write lease, the readers may cache the table data without the transaction.
+type cachedTableLeaseChecker struct {
+	tikv.SchemaLeaseChecker
+	// names and leases are the names and the write leases of the cached tables.
+	names  []string
+	leases []uint64
+}
+
+// CheckBySchemaVer implements the tikv.SchemaLeaseChecker interface.
+func (c *cachedTableLeaseChecker) CheckBySchemaVer(txnTS uint64, startSchemaVer tikv.SchemaVer) (*transaction.RelatedSchemaChange, error) {
+	for i, name := range c.names {
+		if lease := atomic.LoadUint64(&c.leases[i]); txnTS >= lease {
+			return nil, kv.ErrTxnRetryable.GenWithStackByArgs(fmt.Sprintf("the write lease %d of the cached table %s is expired at the commit ts %d",
+				lease, name, txnTS))
+		}
+	}
+	return c.SchemaLeaseChecker.CheckBySchemaVer(txnTS, startSchemaVer)
+}
+
 func (s *session) commitTxnWithTemporaryData(ctx context.Context, txn kv.Transaction) error {
 	sessVars := s.sessionVars
 	txnTempTables := sessVars.TxnCtx.TemporaryTables
@@ -772,6 +823,7 @@ func (s *session) CommitTxn(ctx context.Context) error {
 	})
 	s.sessionVars.TxnCtx.Cleanup()
 	s.sessionVars.CleanupTxnReadTSIfUsed()
+	s.releaseMetadataLock()
 	return err
 }
 
@@ -791,6 +843,73 @@ func (s *session) RollbackTxn(ctx context.Context) {
 	s.sessionVars.TxnCtx.Cleanup()
 	s.sessionVars.CleanupTxnReadTSIfUsed()
 	s.sessionVars.SetInTxn(false)
+	s.releaseMetadataLock()
+}
+
+// acquireMetadataLock holds the metadata locks of the tables used by the statement until the transaction
+// finishes, so that the DDL jobs on these tables wait for the transaction instead of failing it.
+func (s *session) acquireMetadataLock(stmt *executor.ExecStmt) error {
+	tableIDs := s.sessionVars.StmtCtx.MDLRelatedTableIDs
+	if len(tableIDs) == 0 || s.sessionVars.InRestrictedSQL || !variable.EnableMDL.Load() {
+		return nil
+	}
+	if _, ok := stmt.StmtNode.(ast.DDLNode); ok {
+		return nil
+	}
+	dom := domain.GetDomain(s)
+	if dom == nil || dom.DDL() == nil {
+		return nil
+	}
+	if s.mdlTables == nil {
+		s.mdlTables = make(map[int64]struct{}, len(tableIDs))
+	}
+	schemaVer := stmt.InfoSchema.SchemaMetaVersion()
+	if err := dom.DDL().MetadataLock().Acquire(s, tableIDs, schemaVer); err != nil {
+		return err
+	}
+	newTableIDs := make([]int64, 0, len(tableIDs))
+	for id := range tableIDs {
+		if _, ok := s.mdlTables[id]; !ok {
+			newTableIDs = append(newTableIDs, id)
+		}
+	}
+	if len(newTableIDs) == 0 {
+		return nil
+	}
+	// The owner may have checked the metadata locks before the new ones are published. The tables must not be
+	// changed after the schema version of the transaction, otherwise the transaction may be two states behind.
+	checker := domain.NewSchemaChecker(dom, schemaVer, newTableIDs)
+	if _, err := checker.Check(s.sessionVars.TxnCtx.StartTS); err != nil {
+		return err
+	}
+	for _, id := range newTableIDs {
+		s.mdlTables[id] = struct{}{}
+	}
+	return nil
+}
+
+// holdsMetadataLock checks whether the transaction holds the metadata lock of the table or the partition.
+func (s *session) holdsMetadataLock(physicalID int64) bool {
+	if len(s.mdlTables) == 0 {
+		return false
+	}
+	if _, ok := s.mdlTables[physicalID]; ok {
+		return true
+	}
+	if tbl, _, _ := s.GetInfoSchema().(infoschema.InfoSchema).FindTableByPartitionID(physicalID); tbl != nil {
+		_, ok := s.mdlTables[tbl.Meta().ID]
+		return ok
+	}
+	return false
+}
+
+// releaseMetadataLock releases the metadata locks held by the transaction.
+func (s *session) releaseMetadataLock() {
+	if s.mdlTables == nil {
+		return
+	}
+	s.mdlTables = nil
+	domain.GetDomain(s).DDL().MetadataLock().Release(s)
 }
 
 func (s *session) GetClient() kv.Client {
@@ -1523,6 +1642,7 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	}
 
 	s.sessionVars.StartTime = time.Now()
+	s.setResourceGroupLimiter()
 
 	// Some executions are done in compile stage, so we reset them before compile.
 	if err := executor.ResetContextOfStmt(s, stmtNode); err != nil {
@@ -1536,6 +1656,12 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	if err := s.validateStatementReadOnlyInStaleness(stmtNode); err != nil {
 		return nil, err
 	}
+	if err := s.checkSandboxMode(stmtNode); err != nil {
+		return nil, err
+	}
+	if err := s.checkRunawayWatch(digest); err != nil {
+		return nil, err
+	}
 
 	// Uncorrelated subqueries will execute once when building plan, so we reset process info before building plan.
 	cmd32 := atomic.LoadUint32(&s.GetSessionVars().CommandValue)
@@ -1679,6 +1805,9 @@ func runStmt(ctx context.Context, se *session, s sqlexec.Statement) (rs sqlexec.
 	if err != nil {
 		return nil, err
 	}
+	if err = se.acquireMetadataLock(s.(*executor.ExecStmt)); err != nil {
+		return nil, finishStmt(ctx, se, err, s)
+	}
 	rs, err = s.Exec(ctx)
 	se.updateTelemetryMetric(s.(*executor.ExecStmt))
 	sessVars.TxnCtx.StatementCount++
@@ -1941,6 +2070,7 @@ func (s *session) ExecutePreparedStmt(ctx context.Context, stmtID uint32, args [
 	s.PrepareTxnCtx(ctx)
 	var err error
 	s.sessionVars.StartTime = time.Now()
+	s.setResourceGroupLimiter()
 	preparedPointer, ok := s.sessionVars.PreparedStmts[stmtID]
 	if !ok {
 		err = plannercore.ErrStmtNotFound
@@ -1952,6 +2082,12 @@ func (s *session) ExecutePreparedStmt(ctx context.Context, stmtID uint32, args [
 		return nil, errors.Errorf("invalid CachedPrepareStmt type")
 	}
 	executor.CountStmtNode(preparedStmt.PreparedAst.Stmt, s.sessionVars.InRestrictedSQL)
+	if err = s.checkSandboxMode(preparedStmt.PreparedAst.Stmt); err != nil {
+		return nil, err
+	}
+	if err = s.checkRunawayWatch(preparedStmt.SQLDigest); err != nil {
+		return nil, err
+	}
 	ok, err = s.IsCachedExecOk(ctx, preparedStmt)
 	if err != nil {
 		return nil, err
@@ -2024,10 +2160,72 @@ func (s *session) Txn(active bool) (kv.Transaction, error) {
 			s.txn.SetOption(kv.ReplicaRead, readReplicaType)
 		}
 		s.txn.SetOption(kv.SnapInterceptor, s.getSnapshotInterceptor())
+		if s.sessionVars.ResourceGroupLimiter != nil {
+			s.txn.SetOption(kv.ResourceGroup, s.sessionVars.ResourceGroupLimiter)
+		}
 	}
 	return &s.txn, nil
 }
 
+// setResourceGroupLimiter sets the limiter of the resource group for the statement, the internal statements are
+// not throttled.
+func (s *session) setResourceGroupLimiter() {
+	if s.sessionVars.InRestrictedSQL {
+		return
+	}
+	limiter := domain.GetDomain(s).ResourceGroupManager().GetLimiter(s.sessionVars.ResourceGroupName)
+	s.sessionVars.ResourceGroupLimiter = limiter
+	if s.txn.Valid() {
+		s.txn.SetOption(kv.ResourceGroup, limiter)
+	}
+}
+
+// checkSandboxMode rejects the statement if the password of the session user has expired, only the statements
+// which reset the password of the session user and SET statements are allowed in the sandbox mode.
+func (s *session) checkSandboxMode(stmtNode ast.StmtNode) error {
+	if !s.sessionVars.PasswordExpired || s.sessionVars.InRestrictedSQL {
+		return nil
+	}
+	switch x := stmtNode.(type) {
+	case *ast.SetStmt:
+		return nil
+	case *ast.SetPwdStmt:
+		if x.User == nil || s.isSessionUser(x.User) {
+			return nil
+		}
+	case *ast.AlterUserStmt:
+		// Only `ALTER USER USER() IDENTIFIED BY ...` or setting the password of the session user itself
+		// is allowed, the other options can't be changed before the password is reset.
+		if len(x.TLSOptions) > 0 || len(x.ResourceOptions) > 0 || len(x.PasswordOrLockOptions) > 0 {
+			break
+		}
+		if x.CurrentAuth != nil || (len(x.Specs) == 1 && x.Specs[0].AuthOpt != nil && s.isSessionUser(x.Specs[0].User)) {
+			return nil
+		}
+	}
+	return ErrMustChangePassword.GenWithStackByArgs()
+}
+
+// isSessionUser checks whether the user in the statement is the account the session logins as.
+func (s *session) isSessionUser(user *auth.UserIdentity) bool {
+	sessionUser := s.sessionVars.User
+	if sessionUser == nil {
+		return false
+	}
+	return user.CurrentUser || (user.Username == sessionUser.AuthUsername && user.Hostname == sessionUser.AuthHostname)
+}
+
+// checkRunawayWatch rejects the statement if its digest is quarantined in the runaway watch list.
+func (s *session) checkRunawayWatch(digest *parser.Digest) error {
+	if s.sessionVars.InRestrictedSQL || digest == nil {
+		return nil
+	}
+	if record := domain.GetDomain(s).RunawayManager().GetWatch(digest.String()); record != nil {
+		return ErrQueryQuarantined.GenWithStackByArgs()
+	}
+	return nil
+}
+
 // isTxnRetryable (if returns true) means the transaction could retry.
 // If the transaction is in pessimistic mode, do not retry.
 // If the session is already in transaction, enable retry or internal SQL could retry.
@@ -2212,10 +2410,12 @@ func (s *session) Auth(user *auth.UserIdentity, authentication []byte, salt []by
 
 	// Check IP or localhost.
 	var success bool
-	user.AuthUsername, user.AuthHostname, success = pm.ConnectionVerification(user.Username, user.Hostname, authentication, salt, s.sessionVars.TLSConnectionState)
+	user.AuthUsername, user.AuthHostname, success = pm.ConnectionVerification(user.Username, user.Hostname, authentication, salt, s.sessionVars.TLSConnectionState, s.sessionVars.AuthConn)
 	if success {
 		s.sessionVars.User = user
 		s.sessionVars.ActiveRoles = pm.GetDefaultRoles(user.AuthUsername, user.AuthHostname)
+		s.sessionVars.ResourceGroupName = pm.GetResourceGroup(user.AuthUsername, user.AuthHostname)
+		s.sessionVars.PasswordExpired = pm.IsPasswordExpired(user.AuthUsername, user.AuthHostname)
 		return true
 	} else if user.Hostname == variable.DefHostname {
 		return false
@@ -2223,7 +2423,7 @@ func (s *session) Auth(user *auth.UserIdentity, authentication []byte, salt []by
 
 	// Check Hostname.
 	for _, addr := range s.getHostByIP(user.Hostname) {
-		u, h, success := pm.ConnectionVerification(user.Username, addr, authentication, salt, s.sessionVars.TLSConnectionState)
+		u, h, success := pm.ConnectionVerification(user.Username, addr, authentication, salt, s.sessionVars.TLSConnectionState, s.sessionVars.AuthConn)
 		if success {
 			s.sessionVars.User = &auth.UserIdentity{
 				Username:     user.Username,
@@ -2232,6 +2432,8 @@ func (s *session) Auth(user *auth.UserIdentity, authentication []byte, salt []by
 				AuthHostname: h,
 			}
 			s.sessionVars.ActiveRoles = pm.GetDefaultRoles(u, h)
+			s.sessionVars.ResourceGroupName = pm.GetResourceGroup(u, h)
+			s.sessionVars.PasswordExpired = pm.IsPasswordExpired(u, h)
 			return true
 		}
 	}
@@ -2248,6 +2450,8 @@ func (s *session) AuthWithoutVerification(user *auth.UserIdentity) bool {
 	if success {
 		s.sessionVars.User = user
 		s.sessionVars.ActiveRoles = pm.GetDefaultRoles(user.AuthUsername, user.AuthHostname)
+		s.sessionVars.ResourceGroupName = pm.GetResourceGroup(user.AuthUsername, user.AuthHostname)
+		s.sessionVars.PasswordExpired = pm.IsPasswordExpired(user.AuthUsername, user.AuthHostname)
 		return true
 	} else if user.Hostname == variable.DefHostname {
 		return false
@@ -2264,6 +2468,8 @@ func (s *session) AuthWithoutVerification(user *auth.UserIdentity) bool {
 				AuthHostname: h,
 			}
 			s.sessionVars.ActiveRoles = pm.GetDefaultRoles(u, h)
+			s.sessionVars.ResourceGroupName = pm.GetResourceGroup(u, h)
+			s.sessionVars.PasswordExpired = pm.IsPasswordExpired(u, h)
 			return true
 		}
 	}
@@ -2549,6 +2755,8 @@ func BootstrapSession(store kv.Storage) (*domain.Domain, error) {
 	if err != nil {
 		return nil, err
 	}
+	dom.TTLJobLoop()
+	dom.RunawayWatchLoop()
 	if raw, ok := store.(kv.EtcdBackend); ok {
 		err = raw.StartGCWorker()
 		if err != nil {
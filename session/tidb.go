@@ -357,4 +357,8 @@ func ResultSetToStringSlice(ctx context.Context, s Session, rs sqlexec.RecordSet
 // Session errors.
 var (
 	ErrForUpdateCantRetry = dbterror.ClassSession.NewStd(errno.ErrForUpdateCantRetry)
+	// ErrQueryQuarantined is returned when the digest of the statement is in the runaway watch list.
+	ErrQueryQuarantined = dbterror.ClassSession.NewStd(errno.ErrRunawayQueryQuarantined)
+	// ErrMustChangePassword is returned when the session is in the sandbox mode because the password has expired.
+	ErrMustChangePassword = dbterror.ClassSession.NewStd(errno.ErrMustChangePassword)
 )
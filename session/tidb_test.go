@@ -37,6 +37,7 @@ import (
 	"github.com/pingcap/tidb/util/sqlexec"
 	"github.com/pingcap/tidb/util/testleak"
 	"github.com/tikv/client-go/v2/tikv"
+	"github.com/tikv/client-go/v2/txnkv/transaction"
 )
 
 func TestT(t *testing.T) {
@@ -242,3 +243,27 @@ func (s *testMainSuite) TestIndexUsageSyncLease(c *C) {
 	err = store.Close()
 	c.Assert(err, IsNil)
 }
+
+type succSchemaLeaseChecker struct{}
+
+func (succSchemaLeaseChecker) CheckBySchemaVer(uint64, tikv.SchemaVer) (*transaction.RelatedSchemaChange, error) {
+	return nil, nil
+}
+
+func (s *testMainSuite) TestCachedTableLeaseChecker(c *C) {
+	checker := &cachedTableLeaseChecker{
+		SchemaLeaseChecker: succSchemaLeaseChecker{},
+		names:              []string{"t1", "t2"},
+		leases:             []uint64{100, 200},
+	}
+	_, err := checker.CheckBySchemaVer(99, nil)
+	c.Assert(err, IsNil)
+	_, err = checker.CheckBySchemaVer(100, nil)
+	c.Assert(kv.ErrTxnRetryable.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*the write lease 100 of the cached table t1 is expired at the commit ts 100.*")
+
+	// The lease is 0 after the write lock fails to be kept alive.
+	atomic.StoreUint64(&checker.leases[0], 0)
+	_, err = checker.CheckBySchemaVer(1, nil)
+	c.Assert(kv.ErrTxnRetryable.Equal(err), IsTrue)
+}
@@ -119,6 +119,9 @@ type StatementContext struct {
 		errorCount     uint16
 		execDetails    execdetails.ExecDetails
 		allExecDetails []*execdetails.ExecDetails
+		// progress describes the progress of the long-running statements such as LOAD DATA, it's shown in the
+		// State column of SHOW PROCESSLIST.
+		progress string
 	}
 	// PrevAffectedRows is the affected-rows value(DDL is 0, DML is the number of affected rows).
 	PrevAffectedRows int64
@@ -139,6 +142,7 @@ type StatementContext struct {
 	MemTracker       *memory.Tracker
 	DiskTracker      *disk.Tracker
 	IsTiFlash        atomic2.Bool
+	RunawayCooldown  atomic2.Bool
 	RuntimeStatsColl *execdetails.RuntimeStatsColl
 	TableIDs         []int64
 	IndexNames       []string
@@ -185,6 +189,11 @@ type StatementContext struct {
 	OptimInfo map[int]string
 	// InVerboseExplain indicates the statement is "explain format='verbose' ...".
 	InVerboseExplain bool
+	// MDLRelatedTableIDs is the IDs of the tables used by the statement, the transaction acquires the
+	// metadata locks of them before executing the statement.
+	MDLRelatedTableIDs map[int64]struct{}
+	// ReadFromTableCache indicates whether the statement reads the data of a cached table from the memory.
+	ReadFromTableCache bool
 }
 
 // StmtHints are SessionVars related sql hints.
@@ -434,6 +443,21 @@ func (sc *StatementContext) SetMessage(msg string) {
 	sc.mu.Unlock()
 }
 
+// GetProgress returns the progress of the statement, if it doesn't report progress, it returns empty string.
+func (sc *StatementContext) GetProgress() string {
+	sc.mu.Lock()
+	progress := sc.mu.progress
+	sc.mu.Unlock()
+	return progress
+}
+
+// SetProgress sets the progress of the statement.
+func (sc *StatementContext) SetProgress(progress string) {
+	sc.mu.Lock()
+	sc.mu.progress = progress
+	sc.mu.Unlock()
+}
+
 // GetWarnings gets warnings.
 func (sc *StatementContext) GetWarnings() []SQLWarn {
 	sc.mu.Lock()
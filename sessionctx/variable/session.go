@@ -41,6 +41,7 @@ import (
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
 	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/privilege/conn"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
@@ -178,6 +179,10 @@ type TransactionContext struct {
 	// TemporaryTables is used to store transaction-specific information for global temporary tables.
 	// It can also be stored in sessionCtx with local temporary tables, but it's easier to clean this data after transaction ends.
 	TemporaryTables map[int64]tableutil.TempTable
+
+	// CachedTables is used to store the cached tables written in the transaction, the values are table.CachedTable.
+	// The write locks of these tables are acquired before the transaction commits.
+	CachedTables map[int64]interface{}
 }
 
 // GetShard returns the shard prefix for the next `count` rowids.
@@ -494,6 +499,10 @@ type SessionVars struct {
 	// TLSConnectionState is the TLS connection state (nil if not using TLS).
 	TLSConnectionState *tls.ConnectionState
 
+	// AuthConn is the client connection during the authentication (nil if the session isn't authenticating a
+	// client). The authentication plugins which exchange more messages with the client use it.
+	AuthConn conn.AuthConn
+
 	// ConnectionID is the connection id of the current session.
 	ConnectionID uint64
 
@@ -731,6 +740,9 @@ type SessionVars struct {
 	// ConstraintCheckInPlace indicates whether to check the constraint when the SQL executing.
 	ConstraintCheckInPlace bool
 
+	// ForeignKeyChecks indicates whether to check and enforce the foreign key constraints.
+	ForeignKeyChecks bool
+
 	// CommandValue indicates which command current session is doing.
 	CommandValue uint32
 
@@ -934,6 +946,9 @@ type SessionVars struct {
 	// EnableStableResultMode if stabilize query results.
 	EnableStableResultMode bool
 
+	// EnableNonPreparedPlanCache indicates whether to parameterize simple text statements and use the plan cache for them.
+	EnableNonPreparedPlanCache bool
+
 	// LocalTemporaryTables is *infoschema.LocalTemporaryTables, use interface to avoid circle dependency.
 	// It's nil if there is no local temporary table.
 	LocalTemporaryTables interface{}
@@ -950,6 +965,17 @@ type SessionVars struct {
 	// ReadStaleness indicates the staleness duration for the following query
 	ReadStaleness time.Duration
 
+	// PasswordExpired is true if the password of the session user has expired, the session is in the sandbox mode
+	// then, in which the statements other than resetting the password are rejected.
+	PasswordExpired bool
+
+	// ResourceGroupName is the resource group which the kv requests of the session are throttled by, the default
+	// group is used if it's empty or the group doesn't exist.
+	ResourceGroupName string
+
+	// ResourceGroupLimiter is the limiter of the resource group for the current statement, nil means unlimited.
+	ResourceGroupLimiter kv.ResourceGroupLimiter
+
 	// cached is used to optimze the object allocation.
 	cached struct {
 		curr int8
@@ -1205,8 +1231,9 @@ func NewSessionVars() *SessionVars {
 		ExecutorConcurrency:        DefExecutorConcurrency,
 	}
 	vars.MemQuota = MemQuota{
-		MemQuotaQuery:      config.GetGlobalConfig().MemQuotaQuery,
-		MemQuotaApplyCache: DefTiDBMemQuotaApplyCache,
+		MemQuotaQuery:       config.GetGlobalConfig().MemQuotaQuery,
+		MemQuotaApplyCache:  DefTiDBMemQuotaApplyCache,
+		MemQuotaCursorFetch: DefTiDBMemQuotaCursorFetch,
 
 		// The variables below do not take any effect anymore, it's remaining for compatibility.
 		// TODO: remove them in v4.1
@@ -1833,6 +1860,8 @@ type MemQuota struct {
 	MemQuotaQuery int64
 	// MemQuotaApplyCache defines the memory capacity for apply cache.
 	MemQuotaApplyCache int64
+	// MemQuotaCursorFetch defines the memory quota of the rows of a cursor, the rows are spilled to the disk if they exceed it.
+	MemQuotaCursorFetch int64
 
 	// The variables below do not take any effect anymore, it's remaining for compatibility.
 	// TODO: remove them in v4.1
@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/privilege/privileges/ldap"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/logutil"
@@ -671,16 +672,9 @@ var defaultSysVars = []*SysVar{
 		return nil
 	}},
 	{Scope: ScopeNone, Name: SystemTimeZone, Value: "CST"},
-	{Scope: ScopeGlobal | ScopeSession, Name: ForeignKeyChecks, Value: Off, Type: TypeBool, skipInit: true, Validation: func(vars *SessionVars, normalizedValue string, originalValue string, scope ScopeFlag) (string, error) {
-		if TiDBOptOn(normalizedValue) {
-			// TiDB does not yet support foreign keys.
-			// Return the original value in the warning, so that users are not confused.
-			vars.StmtCtx.AppendWarning(ErrUnsupportedValueForVar.GenWithStackByArgs(ForeignKeyChecks, originalValue))
-			return Off, nil
-		} else if !TiDBOptOn(normalizedValue) {
-			return Off, nil
-		}
-		return normalizedValue, ErrWrongValueForVar.GenWithStackByArgs(ForeignKeyChecks, originalValue)
+	{Scope: ScopeGlobal | ScopeSession, Name: ForeignKeyChecks, Value: BoolToOnOff(DefForeignKeyChecks), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.ForeignKeyChecks = TiDBOptOn(val)
+		return nil
 	}},
 	{Scope: ScopeNone, Name: Hostname, Value: DefHostname},
 	{Scope: ScopeSession, Name: Timestamp, Value: "", skipInit: true},
@@ -1218,6 +1212,10 @@ var defaultSysVars = []*SysVar{
 		s.MemQuotaApplyCache = tidbOptInt64(val, DefTiDBMemQuotaApplyCache)
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBMemQuotaCursorFetch, Value: strconv.Itoa(DefTiDBMemQuotaCursorFetch), Type: TypeUnsigned, MaxValue: math.MaxInt64, SetSession: func(s *SessionVars, val string) error {
+		s.MemQuotaCursorFetch = tidbOptInt64(val, DefTiDBMemQuotaCursorFetch)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBBackoffLockFast, Value: strconv.Itoa(tikvstore.DefBackoffLockFast), Type: TypeUnsigned, MinValue: 1, MaxValue: math.MaxInt32, SetSession: func(s *SessionVars, val string) error {
 		s.KVVars.BackoffLockFast = tidbOptPositiveInt32(val, tikvstore.DefBackoffLockFast)
 		return nil
@@ -1672,6 +1670,56 @@ var defaultSysVars = []*SysVar{
 		return nil
 	}},
 	{Scope: ScopeGlobal, Name: TiDBRestrictedReadOnly, Value: BoolToOnOff(DefTiDBRestrictedReadOnly), Type: TypeBool},
+	{Scope: ScopeGlobal, Name: TiDBEnableMDL, Value: BoolToOnOff(DefTiDBEnableMDL), Type: TypeBool, SetGlobal: func(s *SessionVars, val string) error {
+		EnableMDL.Store(TiDBOptOn(val))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBTTLJobEnable, Value: BoolToOnOff(DefTiDBTTLJobEnable), Type: TypeBool, SetGlobal: func(s *SessionVars, val string) error {
+		EnableTTLJob.Store(TiDBOptOn(val))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBTTLScanWorkerCount, Value: strconv.Itoa(DefTiDBTTLScanWorkerCount), Type: TypeUnsigned, MinValue: 1, MaxValue: 256, SetGlobal: func(s *SessionVars, val string) error {
+		TTLScanWorkerCount.Store(tidbOptInt64(val, DefTiDBTTLScanWorkerCount))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBTTLDeleteBatchSize, Value: strconv.Itoa(DefTiDBTTLDeleteBatchSize), Type: TypeUnsigned, MinValue: 1, MaxValue: 10240, SetGlobal: func(s *SessionVars, val string) error {
+		TTLDeleteBatchSize.Store(tidbOptInt64(val, DefTiDBTTLDeleteBatchSize))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBTTLDeleteRateLimit, Value: strconv.Itoa(DefTiDBTTLDeleteRateLimit), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt64, SetGlobal: func(s *SessionVars, val string) error {
+		TTLDeleteRateLimit.Store(tidbOptInt64(val, DefTiDBTTLDeleteRateLimit))
+		return nil
+	}},
+	{Scope: ScopeSession, Name: TiDBResourceGroup, Value: "", skipInit: true, SetSession: func(s *SessionVars, val string) error {
+		s.ResourceGroupName = val
+		return nil
+	}, GetSession: func(s *SessionVars) (string, error) {
+		return s.ResourceGroupName, nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBTableCacheLease, Value: strconv.Itoa(DefTiDBTableCacheLease), Type: TypeUnsigned, MinValue: 1, MaxValue: 10, SetGlobal: func(s *SessionVars, val string) error {
+		TableCacheLease.Store(tidbOptInt64(val, DefTiDBTableCacheLease))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBRunawayExecElapsedTime, Value: strconv.Itoa(DefTiDBRunawayExecElapsedTime), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt64, SetGlobal: func(s *SessionVars, val string) error {
+		RunawayRules.ExecElapsedTime.Store(tidbOptInt64(val, DefTiDBRunawayExecElapsedTime))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBRunawayProcessedKeys, Value: strconv.Itoa(DefTiDBRunawayProcessedKeys), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt64, SetGlobal: func(s *SessionVars, val string) error {
+		RunawayRules.ProcessedKeys.Store(tidbOptInt64(val, DefTiDBRunawayProcessedKeys))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBRunawayMemQuota, Value: strconv.Itoa(DefTiDBRunawayMemQuota), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt64, SetGlobal: func(s *SessionVars, val string) error {
+		RunawayRules.MemQuota.Store(tidbOptInt64(val, DefTiDBRunawayMemQuota))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBRunawayAction, Value: DefTiDBRunawayAction, Type: TypeEnum, PossibleValues: []string{"DRYRUN", "COOLDOWN", "KILL"}, SetGlobal: func(s *SessionVars, val string) error {
+		RunawayRules.Action.Store(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBRunawayWatchDuration, Value: strconv.Itoa(DefTiDBRunawayWatchDuration), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt32, SetGlobal: func(s *SessionVars, val string) error {
+		RunawayRules.WatchDuration.Store(tidbOptInt64(val, DefTiDBRunawayWatchDuration))
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBShardAllocateStep, Value: strconv.Itoa(DefTiDBShardAllocateStep), Type: TypeInt, MinValue: 1, MaxValue: uint64(math.MaxInt64), AutoConvertOutOfRange: true, SetSession: func(s *SessionVars, val string) error {
 		s.ShardAllocateStep = tidbOptInt64(val, DefTiDBShardAllocateStep)
 		return nil
@@ -1834,10 +1882,99 @@ var defaultSysVars = []*SysVar{
 	}},
 	{Scope: ScopeGlobal, Name: SkipNameResolve, Value: Off, Type: TypeBool},
 	{Scope: ScopeGlobal, Name: DefaultAuthPlugin, Value: mysql.AuthNativePassword, Type: TypeEnum, PossibleValues: []string{mysql.AuthNativePassword, mysql.AuthCachingSha2Password}},
+	{Scope: ScopeGlobal, Name: DefaultPasswordLifetime, Value: "0", Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxUint16, AutoConvertOutOfRange: true, SetGlobal: func(s *SessionVars, val string) error {
+		PasswordManagement.DefaultLifetime.Store(tidbOptInt64(val, 0))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: PasswordHistory, Value: "0", Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxUint32, AutoConvertOutOfRange: true, SetGlobal: func(s *SessionVars, val string) error {
+		PasswordManagement.History.Store(tidbOptInt64(val, 0))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: PasswordReuseInterval, Value: "0", Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxUint32, AutoConvertOutOfRange: true, SetGlobal: func(s *SessionVars, val string) error {
+		PasswordManagement.ReuseInterval.Store(tidbOptInt64(val, 0))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: ValidatePasswordEnable, Value: Off, Type: TypeBool},
+	{Scope: ScopeGlobal, Name: ValidatePasswordPolicy, Value: "MEDIUM", Type: TypeEnum, PossibleValues: []string{"LOW", "MEDIUM", "STRONG"}},
+	{Scope: ScopeGlobal, Name: ValidatePasswordCheckUserName, Value: Off, Type: TypeBool},
+	{Scope: ScopeGlobal, Name: ValidatePasswordLength, Value: "8", Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt32, AutoConvertOutOfRange: true},
+	{Scope: ScopeGlobal, Name: ValidatePasswordMixedCaseCount, Value: "1", Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt32, AutoConvertOutOfRange: true},
+	{Scope: ScopeGlobal, Name: ValidatePasswordNumberCount, Value: "1", Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt32, AutoConvertOutOfRange: true},
+	{Scope: ScopeGlobal, Name: ValidatePasswordSpecialCharCount, Value: "1", Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt32, AutoConvertOutOfRange: true},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSimpleServerHost, Value: "", Type: TypeStr, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSimpleAuthImpl.SetLDAPServerHost(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSimpleServerPort, Value: strconv.Itoa(ldap.DefLDAPServerPort), Type: TypeUnsigned, MinValue: 1, MaxValue: math.MaxUint16, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSimpleAuthImpl.SetLDAPServerPort(int(tidbOptInt64(val, ldap.DefLDAPServerPort)))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSimpleTLS, Value: Off, Type: TypeBool, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSimpleAuthImpl.SetEnableTLS(TiDBOptOn(val))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSimpleCAPath, Value: "", Type: TypeStr, SetGlobal: func(s *SessionVars, val string) error {
+		return ldap.LDAPSimpleAuthImpl.SetCAPath(val)
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSimpleBindBaseDN, Value: "", Type: TypeStr, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSimpleAuthImpl.SetBindBaseDN(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSimpleBindRootDN, Value: "", Type: TypeStr, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSimpleAuthImpl.SetBindRootDN(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSimpleBindRootPWD, Value: "", Type: TypeStr, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSimpleAuthImpl.SetBindRootPW(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSimpleUserSearchAttr, Value: ldap.DefLDAPSearchAttr, Type: TypeStr, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSimpleAuthImpl.SetSearchAttr(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSASLServerHost, Value: "", Type: TypeStr, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSASLAuthImpl.SetLDAPServerHost(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSASLServerPort, Value: strconv.Itoa(ldap.DefLDAPServerPort), Type: TypeUnsigned, MinValue: 1, MaxValue: math.MaxUint16, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSASLAuthImpl.SetLDAPServerPort(int(tidbOptInt64(val, ldap.DefLDAPServerPort)))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSASLTLS, Value: Off, Type: TypeBool, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSASLAuthImpl.SetEnableTLS(TiDBOptOn(val))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSASLCAPath, Value: "", Type: TypeStr, SetGlobal: func(s *SessionVars, val string) error {
+		return ldap.LDAPSASLAuthImpl.SetCAPath(val)
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSASLBindBaseDN, Value: "", Type: TypeStr, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSASLAuthImpl.SetBindBaseDN(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSASLBindRootDN, Value: "", Type: TypeStr, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSASLAuthImpl.SetBindRootDN(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSASLBindRootPWD, Value: "", Type: TypeStr, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSASLAuthImpl.SetBindRootPW(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSASLUserSearchAttr, Value: ldap.DefLDAPSearchAttr, Type: TypeStr, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSASLAuthImpl.SetSearchAttr(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: AuthenticationLDAPSASLAuthMethodName, Value: ldap.SASLMechanismSCRAMSHA1, Type: TypeEnum, PossibleValues: []string{ldap.SASLMechanismSCRAMSHA1, ldap.SASLMechanismSCRAMSHA256}, SetGlobal: func(s *SessionVars, val string) error {
+		ldap.LDAPSASLAuthImpl.SetSASLAuthMethod(val)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableOrderedResultMode, Value: BoolToOnOff(DefTiDBEnableOrderedResultMode), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.EnableStableResultMode = TiDBOptOn(val)
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableNonPreparedPlanCache, Value: BoolToOnOff(DefTiDBEnableNonPreparedPlanCache), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnableNonPreparedPlanCache = TiDBOptOn(val)
+		return nil
+	}},
 }
 
 // FeedbackProbability points to the FeedbackProbability in statistics package.
@@ -1982,6 +2119,54 @@ const (
 	MasterVerifyChecksum = "master_verify_checksum"
 	// ValidatePasswordCheckUserName is the name for 'validate_password_check_user_name' system variable.
 	ValidatePasswordCheckUserName = "validate_password_check_user_name"
+	// ValidatePasswordEnable is the name for 'validate_password_enable' system variable.
+	ValidatePasswordEnable = "validate_password_enable"
+	// ValidatePasswordPolicy is the name for 'validate_password_policy' system variable.
+	ValidatePasswordPolicy = "validate_password_policy"
+	// ValidatePasswordMixedCaseCount is the name for 'validate_password_mixed_case_count' system variable.
+	ValidatePasswordMixedCaseCount = "validate_password_mixed_case_count"
+	// ValidatePasswordSpecialCharCount is the name for 'validate_password_special_char_count' system variable.
+	ValidatePasswordSpecialCharCount = "validate_password_special_char_count"
+	// DefaultPasswordLifetime is the name for 'default_password_lifetime' system variable.
+	DefaultPasswordLifetime = "default_password_lifetime"
+	// PasswordHistory is the name for 'password_history' system variable.
+	PasswordHistory = "password_history"
+	// PasswordReuseInterval is the name for 'password_reuse_interval' system variable.
+	PasswordReuseInterval = "password_reuse_interval"
+	// AuthenticationLDAPSimpleServerHost is the name for 'authentication_ldap_simple_server_host' system variable.
+	AuthenticationLDAPSimpleServerHost = "authentication_ldap_simple_server_host"
+	// AuthenticationLDAPSimpleServerPort is the name for 'authentication_ldap_simple_server_port' system variable.
+	AuthenticationLDAPSimpleServerPort = "authentication_ldap_simple_server_port"
+	// AuthenticationLDAPSimpleTLS is the name for 'authentication_ldap_simple_tls' system variable.
+	AuthenticationLDAPSimpleTLS = "authentication_ldap_simple_tls"
+	// AuthenticationLDAPSimpleCAPath is the name for 'authentication_ldap_simple_ca_path' system variable.
+	AuthenticationLDAPSimpleCAPath = "authentication_ldap_simple_ca_path"
+	// AuthenticationLDAPSimpleBindBaseDN is the name for 'authentication_ldap_simple_bind_base_dn' system variable.
+	AuthenticationLDAPSimpleBindBaseDN = "authentication_ldap_simple_bind_base_dn"
+	// AuthenticationLDAPSimpleBindRootDN is the name for 'authentication_ldap_simple_bind_root_dn' system variable.
+	AuthenticationLDAPSimpleBindRootDN = "authentication_ldap_simple_bind_root_dn"
+	// AuthenticationLDAPSimpleBindRootPWD is the name for 'authentication_ldap_simple_bind_root_pwd' system variable.
+	AuthenticationLDAPSimpleBindRootPWD = "authentication_ldap_simple_bind_root_pwd"
+	// AuthenticationLDAPSimpleUserSearchAttr is the name for 'authentication_ldap_simple_user_search_attr' system variable.
+	AuthenticationLDAPSimpleUserSearchAttr = "authentication_ldap_simple_user_search_attr"
+	// AuthenticationLDAPSASLServerHost is the name for 'authentication_ldap_sasl_server_host' system variable.
+	AuthenticationLDAPSASLServerHost = "authentication_ldap_sasl_server_host"
+	// AuthenticationLDAPSASLServerPort is the name for 'authentication_ldap_sasl_server_port' system variable.
+	AuthenticationLDAPSASLServerPort = "authentication_ldap_sasl_server_port"
+	// AuthenticationLDAPSASLTLS is the name for 'authentication_ldap_sasl_tls' system variable.
+	AuthenticationLDAPSASLTLS = "authentication_ldap_sasl_tls"
+	// AuthenticationLDAPSASLCAPath is the name for 'authentication_ldap_sasl_ca_path' system variable.
+	AuthenticationLDAPSASLCAPath = "authentication_ldap_sasl_ca_path"
+	// AuthenticationLDAPSASLBindBaseDN is the name for 'authentication_ldap_sasl_bind_base_dn' system variable.
+	AuthenticationLDAPSASLBindBaseDN = "authentication_ldap_sasl_bind_base_dn"
+	// AuthenticationLDAPSASLBindRootDN is the name for 'authentication_ldap_sasl_bind_root_dn' system variable.
+	AuthenticationLDAPSASLBindRootDN = "authentication_ldap_sasl_bind_root_dn"
+	// AuthenticationLDAPSASLBindRootPWD is the name for 'authentication_ldap_sasl_bind_root_pwd' system variable.
+	AuthenticationLDAPSASLBindRootPWD = "authentication_ldap_sasl_bind_root_pwd"
+	// AuthenticationLDAPSASLUserSearchAttr is the name for 'authentication_ldap_sasl_user_search_attr' system variable.
+	AuthenticationLDAPSASLUserSearchAttr = "authentication_ldap_sasl_user_search_attr"
+	// AuthenticationLDAPSASLAuthMethodName is the name for 'authentication_ldap_sasl_auth_method_name' system variable.
+	AuthenticationLDAPSASLAuthMethodName = "authentication_ldap_sasl_auth_method_name"
 	// SuperReadOnly is the name for 'super_read_only' system variable.
 	SuperReadOnly = "super_read_only"
 	// SQLNotes is the name for 'sql_notes' system variable.
@@ -110,6 +110,8 @@ const (
 	// "tidb_mem_quota_query":				control the memory quota of a query.
 	TiDBMemQuotaQuery      = "tidb_mem_quota_query" // Bytes.
 	TiDBMemQuotaApplyCache = "tidb_mem_quota_apply_cache"
+	// "tidb_mem_quota_cursor_fetch": the memory quota of the rows of a cursor, the rows are spilled to the disk if they exceed it.
+	TiDBMemQuotaCursorFetch = "tidb_mem_quota_cursor_fetch"
 	// TODO: remove them below sometime, it should have only one Quota(TiDBMemQuotaQuery).
 	TiDBMemQuotaHashJoin          = "tidb_mem_quota_hashjoin"          // Bytes.
 	TiDBMemQuotaMergeJoin         = "tidb_mem_quota_mergejoin"         // Bytes.
@@ -584,6 +586,9 @@ const (
 
 	// TiDBEnableOrderedResultMode indicates if stabilize query results.
 	TiDBEnableOrderedResultMode = "tidb_enable_ordered_result_mode"
+
+	// TiDBEnableNonPreparedPlanCache indicates whether to use the plan cache for non-prepared text statements.
+	TiDBEnableNonPreparedPlanCache = "tidb_enable_non_prepared_plan_cache"
 )
 
 // TiDB vars that have only global scope
@@ -601,6 +606,36 @@ const (
 	TiDBGCScanLockMode = "tidb_gc_scan_lock_mode"
 	// TiDBEnableEnhancedSecurity restricts SUPER users from certain operations.
 	TiDBEnableEnhancedSecurity = "tidb_enable_enhanced_security"
+	// TiDBEnableMDL indicates whether DDL jobs wait for the in-flight transactions which use the table,
+	// instead of failing these transactions when they commit.
+	TiDBEnableMDL = "tidb_enable_metadata_lock"
+	// TiDBTTLJobEnable indicates whether the expired rows of the tables with TTL are deleted in the background.
+	TiDBTTLJobEnable = "tidb_ttl_job_enable"
+	// TiDBTTLScanWorkerCount is the count of the workers which delete the expired rows of a table in parallel.
+	TiDBTTLScanWorkerCount = "tidb_ttl_scan_worker_count"
+	// TiDBTTLDeleteBatchSize is the count of the expired rows deleted in a statement.
+	TiDBTTLDeleteBatchSize = "tidb_ttl_delete_batch_size"
+	// TiDBTTLDeleteRateLimit is the max count of the expired rows deleted per second by a TiDB, 0 means unlimited.
+	TiDBTTLDeleteRateLimit = "tidb_ttl_delete_rate_limit"
+	// TiDBResourceGroup is the resource group which the kv requests of the session are throttled by, it's the group
+	// bound to the user by default. Switching to the other groups requires SUPER or RESOURCE_GROUP_ADMIN.
+	TiDBResourceGroup = "tidb_resource_group"
+	// TiDBTableCacheLease is the lease in seconds of the data of the cached tables in the memory of TiDB,
+	// the writes to a cached table wait for the lease to expire.
+	TiDBTableCacheLease = "tidb_table_cache_lease"
+	// TiDBRunawayExecElapsedTime is the execution time in milliseconds after which a query is identified as runaway,
+	// 0 means no limit.
+	TiDBRunawayExecElapsedTime = "tidb_runaway_exec_elapsed_time"
+	// TiDBRunawayProcessedKeys is the count of processed keys after which a query is identified as runaway,
+	// 0 means no limit.
+	TiDBRunawayProcessedKeys = "tidb_runaway_processed_keys"
+	// TiDBRunawayMemQuota is the memory usage in bytes after which a query is identified as runaway, 0 means no limit.
+	TiDBRunawayMemQuota = "tidb_runaway_mem_quota"
+	// TiDBRunawayAction is the action taken on the runaway queries, it's one of DRYRUN, COOLDOWN and KILL.
+	TiDBRunawayAction = "tidb_runaway_action"
+	// TiDBRunawayWatchDuration is the duration in seconds for which the digest of a runaway query is quarantined,
+	// the executions of the statements with the digest are rejected. 0 means the digest isn't quarantined.
+	TiDBRunawayWatchDuration = "tidb_runaway_watch_duration"
 )
 
 // Default TiDB system variable values.
@@ -652,6 +687,7 @@ const (
 	DefMaxPreparedStmtCount               = -1
 	DefWaitTimeout                        = 0
 	DefTiDBMemQuotaApplyCache             = 32 << 20 // 32MB.
+	DefTiDBMemQuotaCursorFetch            = 64 << 20 // 64MB.
 	DefTiDBMemQuotaHashJoin               = 32 << 30 // 32GB.
 	DefTiDBMemQuotaMergeJoin              = 32 << 30 // 32GB.
 	DefTiDBMemQuotaSort                   = 32 << 30 // 32GB.
@@ -664,6 +700,7 @@ const (
 	DefTiDBRetryLimit                     = 10
 	DefTiDBDisableTxnAutoRetry            = true
 	DefTiDBConstraintCheckInPlace         = false
+	DefForeignKeyChecks                   = false
 	DefTiDBHashJoinConcurrency            = ConcurrencyUnset
 	DefTiDBProjectionConcurrency          = ConcurrencyUnset
 	DefBroadcastJoinThresholdSize         = 100 * 1024 * 1024
@@ -681,7 +718,7 @@ const (
 	DefTiDBDDLReorgBatchSize              = 256
 	DefTiDBDDLErrorCountLimit             = 512
 	DefTiDBMaxDeltaSchemaCount            = 1024
-	DefTiDBChangeMultiSchema              = false
+	DefTiDBChangeMultiSchema              = true
 	DefTiDBPointGetCache                  = false
 	DefTiDBEnableAlterPlacement           = false
 	DefTiDBEnableAutoIncrementInGenerated = false
@@ -744,6 +781,18 @@ const (
 	DefTMPTableSize                       = 16777216
 	DefTiDBEnableLocalTxn                 = false
 	DefTiDBEnableOrderedResultMode        = false
+	DefTiDBEnableNonPreparedPlanCache     = false
+	DefTiDBEnableMDL                      = false
+	DefTiDBTTLJobEnable                   = true
+	DefTiDBTTLScanWorkerCount             = 4
+	DefTiDBTTLDeleteBatchSize             = 100
+	DefTiDBTTLDeleteRateLimit             = 0
+	DefTiDBTableCacheLease                = 3
+	DefTiDBRunawayExecElapsedTime         = 0
+	DefTiDBRunawayProcessedKeys           = 0
+	DefTiDBRunawayMemQuota                = 0
+	DefTiDBRunawayAction                  = "KILL"
+	DefTiDBRunawayWatchDuration           = 0
 )
 
 // Process global variables.
@@ -777,8 +826,53 @@ var (
 	}
 	EnableLocalTxn     = atomic.NewBool(DefTiDBEnableLocalTxn)
 	RestrictedReadOnly = atomic.NewBool(DefTiDBRestrictedReadOnly)
+	EnableMDL          = atomic.NewBool(DefTiDBEnableMDL)
+	EnableTTLJob       = atomic.NewBool(DefTiDBTTLJobEnable)
+	TTLScanWorkerCount = atomic.NewInt64(DefTiDBTTLScanWorkerCount)
+	TTLDeleteBatchSize = atomic.NewInt64(DefTiDBTTLDeleteBatchSize)
+	TTLDeleteRateLimit = atomic.NewInt64(DefTiDBTTLDeleteRateLimit)
+	TableCacheLease    = atomic.NewInt64(DefTiDBTableCacheLease)
+	// RunawayRules are the rules to identify and handle the runaway queries.
+	RunawayRules = RunawayQueryRules{
+		ExecElapsedTime: atomic.NewInt64(DefTiDBRunawayExecElapsedTime),
+		ProcessedKeys:   atomic.NewInt64(DefTiDBRunawayProcessedKeys),
+		MemQuota:        atomic.NewInt64(DefTiDBRunawayMemQuota),
+		Action:          atomic.NewString(DefTiDBRunawayAction),
+		WatchDuration:   atomic.NewInt64(DefTiDBRunawayWatchDuration),
+	}
+	// PasswordManagement are the global password management policies.
+	PasswordManagement = PasswordManagementPolicies{
+		DefaultLifetime: atomic.NewInt64(0),
+		History:         atomic.NewInt64(0),
+		ReuseInterval:   atomic.NewInt64(0),
+	}
 )
 
+// PasswordManagementPolicies is the variable for the global password expiration and reuse policies, the
+// per-account settings in mysql.user override them.
+type PasswordManagementPolicies struct {
+	// DefaultLifetime is the number of days before the passwords expire, 0 means they never expire.
+	DefaultLifetime *atomic.Int64
+	// History is the number of the most recent passwords that can't be reused.
+	History *atomic.Int64
+	// ReuseInterval is the number of days in which the passwords can't be reused.
+	ReuseInterval *atomic.Int64
+}
+
+// RunawayQueryRules is the variable for identifying and handling the runaway queries.
+type RunawayQueryRules struct {
+	// ExecElapsedTime is the execution time limit in milliseconds.
+	ExecElapsedTime *atomic.Int64
+	// ProcessedKeys is the processed keys limit.
+	ProcessedKeys *atomic.Int64
+	// MemQuota is the memory usage limit in bytes.
+	MemQuota *atomic.Int64
+	// Action is the action taken on the runaway queries.
+	Action *atomic.String
+	// WatchDuration is the duration in seconds for which the digest of a runaway query is quarantined.
+	WatchDuration *atomic.Int64
+}
+
 // TopSQL is the variable for control top sql feature.
 type TopSQL struct {
 	// Enable top-sql or not.
@@ -46,7 +46,7 @@ func (h *Handle) HandleDDLEvent(t *util.Event) error {
 				return err
 			}
 		}
-	case model.ActionAddTablePartition, model.ActionTruncateTablePartition:
+	case model.ActionAddTablePartition, model.ActionTruncateTablePartition, model.ActionReorganizePartition:
 		for _, def := range t.PartInfo.Definitions {
 			if err := h.insertTableStats2KV(t.TableInfo, def.ID); err != nil {
 				return err
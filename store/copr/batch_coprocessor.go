@@ -584,7 +584,7 @@ func (b *batchCopIterator) handleTaskOnce(ctx context.Context, bo *backoff.Backo
 
 	req := tikvrpc.NewRequest(task.cmdType, &copReq, kvrpcpb.Context{
 		IsolationLevel:   isolationLevelToPB(b.req.IsolationLevel),
-		Priority:         priorityToPB(b.req.Priority),
+		Priority:         priorityToPB(requestPriority(b.req)),
 		NotFillCache:     b.req.NotFillCache,
 		RecordTimeStat:   true,
 		RecordScanStat:   true,
@@ -594,6 +594,11 @@ func (b *batchCopIterator) handleTaskOnce(ctx context.Context, bo *backoff.Backo
 	req.StoreTp = tikvrpc.TiFlash
 
 	logutil.BgLogger().Debug("send batch request to ", zap.String("req info", req.String()), zap.Int("cop task len", len(task.regionInfos)))
+	if b.req.ResourceGroupLimiter != nil {
+		if err := b.req.ResourceGroupLimiter.Wait(ctx); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	resp, retry, cancel, err := sender.SendReqToAddr(bo, task.ctx, task.regionInfos, req, readTimeoutUltraLong)
 	// If there are store errors, we should retry for all regions.
 	if retry {
@@ -664,6 +669,10 @@ func (b *batchCopIterator) handleBatchCopResponse(bo *Backoffer, response *copro
 		return
 	}
 
+	if b.req.ResourceGroupLimiter != nil {
+		b.req.ResourceGroupLimiter.Consume(false, len(response.Data))
+	}
+
 	resp := batchCopResponse{
 		pbResp: response,
 		detail: new(CopRuntimeStats),
@@ -707,7 +707,7 @@ func (worker *copIteratorWorker) handleTaskOnce(bo *Backoffer, task *copTask, ch
 
 	req := tikvrpc.NewReplicaReadRequest(task.cmdType, &copReq, options.GetTiKVReplicaReadType(worker.req.ReplicaRead), &worker.replicaReadSeed, kvrpcpb.Context{
 		IsolationLevel:   isolationLevelToPB(worker.req.IsolationLevel),
-		Priority:         priorityToPB(worker.req.Priority),
+		Priority:         priorityToPB(requestPriority(worker.req)),
 		NotFillCache:     worker.req.NotFillCache,
 		RecordTimeStat:   true,
 		RecordScanStat:   true,
@@ -727,6 +727,12 @@ func (worker *copIteratorWorker) handleTaskOnce(bo *Backoffer, task *copTask, ch
 	if len(worker.req.MatchStoreLabels) > 0 {
 		ops = append(ops, tikv.WithMatchLabels(worker.req.MatchStoreLabels))
 	}
+	limiter := worker.req.ResourceGroupLimiter
+	if limiter != nil {
+		if err := limiter.Wait(bo.GetCtx()); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	resp, rpcCtx, storeAddr, err := worker.kvclient.SendReqCtx(bo.TiKVBackoffer(), req, task.region, tikv.ReadTimeoutMedium, getEndPointType(task.storeType), task.storeAddr, ops...)
 	err = derr.ToTiDBErr(err)
 	if err != nil {
@@ -736,6 +742,9 @@ func (worker *copIteratorWorker) handleTaskOnce(bo *Backoffer, task *copTask, ch
 		}
 		return nil, errors.Trace(err)
 	}
+	if limiter != nil {
+		limiter.Consume(false, copResponseBytes(resp))
+	}
 
 	// Set task.storeAddr field so its task.String() method have the store address information.
 	task.storeAddr = storeAddr
@@ -755,6 +764,19 @@ func (worker *copIteratorWorker) handleTaskOnce(bo *Backoffer, task *copTask, ch
 	return worker.handleCopResponse(bo, rpcCtx, &copResponse{pbResp: resp.Resp.(*coprocessor.Response)}, cacheKey, cacheValue, task, ch, nil, costTime)
 }
 
+// copResponseBytes returns the size of the data in the coprocessor response, it's used to charge the request units.
+func copResponseBytes(resp *tikvrpc.Response) int {
+	switch r := resp.Resp.(type) {
+	case *coprocessor.Response:
+		return len(r.Data)
+	case *tikvrpc.CopStreamResponse:
+		if r.Response != nil {
+			return len(r.Response.Data)
+		}
+	}
+	return 0
+}
+
 const (
 	minLogBackoffTime   = 100
 	minLogKVProcessTime = 100
@@ -1217,6 +1239,15 @@ func (e *rateLimitAction) isEnabled() bool {
 	return atomic.LoadUint32(&e.enabled) > 0
 }
 
+// requestPriority returns the priority to send the tasks of the request with, the runaway query in cooldown is
+// lowered to the low priority even if some of its tasks have been sent.
+func requestPriority(req *kv.Request) int {
+	if req.RunawayCooldown != nil && req.RunawayCooldown.Load() {
+		return kv.PriorityLow
+	}
+	return req.Priority
+}
+
 // priorityToPB converts priority type to wire type.
 func priorityToPB(pri int) kvrpcpb.CommandPri {
 	switch pri {
@@ -30,30 +30,52 @@ type tikvSnapshot struct {
 	*txnsnapshot.KVSnapshot
 	// customRetrievers stores all custom retrievers, it is sorted
 	interceptor kv.SnapshotInterceptor
+	// limiter throttles the point gets by the quota of the resource group.
+	limiter kv.ResourceGroupLimiter
 }
 
 // NewSnapshot creates a kv.Snapshot with txnsnapshot.KVSnapshot.
 func NewSnapshot(snapshot *txnsnapshot.KVSnapshot) kv.Snapshot {
-	return &tikvSnapshot{snapshot, nil}
+	return &tikvSnapshot{snapshot, nil, nil}
 }
 
 // BatchGet gets all the keys' value from kv-server and returns a map contains key/value pairs.
 // The map will not contain nonexistent keys.
 func (s *tikvSnapshot) BatchGet(ctx context.Context, keys []kv.Key) (map[string][]byte, error) {
 	if s.interceptor != nil {
-		return s.interceptor.OnBatchGet(ctx, NewSnapshot(s.KVSnapshot), keys)
+		return s.interceptor.OnBatchGet(ctx, &tikvSnapshot{s.KVSnapshot, nil, s.limiter}, keys)
+	}
+	if s.limiter != nil {
+		if err := s.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
 	}
 	data, err := s.KVSnapshot.BatchGet(ctx, toTiKVKeys(keys))
+	if s.limiter != nil {
+		bytes := 0
+		for k, v := range data {
+			bytes += len(k) + len(v)
+		}
+		s.limiter.Consume(false, bytes)
+	}
 	return data, extractKeyErr(err)
 }
 
 // Get gets the value for key k from snapshot.
 func (s *tikvSnapshot) Get(ctx context.Context, k kv.Key) ([]byte, error) {
 	if s.interceptor != nil {
-		return s.interceptor.OnGet(ctx, NewSnapshot(s.KVSnapshot), k)
+		return s.interceptor.OnGet(ctx, &tikvSnapshot{s.KVSnapshot, nil, s.limiter}, k)
+	}
+	if s.limiter != nil {
+		if err := s.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
 	}
 
 	data, err := s.KVSnapshot.Get(ctx, k)
+	if s.limiter != nil {
+		s.limiter.Consume(false, len(k)+len(data))
+	}
 	return data, extractKeyErr(err)
 }
 
@@ -117,6 +139,8 @@ func (s *tikvSnapshot) SetOption(opt int, val interface{}) {
 		s.KVSnapshot.SetReadReplicaScope(val.(string))
 	case kv.SnapInterceptor:
 		s.interceptor = val.(kv.SnapshotInterceptor)
+	case kv.ResourceGroup:
+		s.limiter, _ = val.(kv.ResourceGroupLimiter)
 	}
 }
 
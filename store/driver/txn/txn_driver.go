@@ -38,6 +38,8 @@ type tikvTxn struct {
 	*tikv.KVTxn
 	idxNameCache        map[int64]*model.TableInfo
 	snapshotInterceptor kv.SnapshotInterceptor
+	// limiter throttles the reads and the commit by the quota of the resource group.
+	limiter kv.ResourceGroupLimiter
 }
 
 // NewTiKVTxn returns a new Transaction.
@@ -48,7 +50,7 @@ func NewTiKVTxn(txn *tikv.KVTxn) kv.Transaction {
 	totalLimit := atomic.LoadUint64(&kv.TxnTotalSizeLimit)
 	txn.GetUnionStore().SetEntrySizeLimit(entryLimit, totalLimit)
 
-	return &tikvTxn{txn, make(map[int64]*model.TableInfo), nil}
+	return &tikvTxn{txn, make(map[int64]*model.TableInfo), nil, nil}
 }
 
 func (txn *tikvTxn) GetTableInfo(id int64) *model.TableInfo {
@@ -70,13 +72,19 @@ func (txn *tikvTxn) LockKeys(ctx context.Context, lockCtx *kv.LockCtx, keysInput
 }
 
 func (txn *tikvTxn) Commit(ctx context.Context) error {
+	if txn.limiter != nil && !txn.IsReadOnly() {
+		if err := txn.limiter.Wait(ctx); err != nil {
+			return err
+		}
+		defer txn.limiter.Consume(true, txn.Size())
+	}
 	err := txn.KVTxn.Commit(ctx)
 	return txn.extractKeyErr(err)
 }
 
 // GetSnapshot returns the Snapshot binding to this transaction.
 func (txn *tikvTxn) GetSnapshot() kv.Snapshot {
-	return &tikvSnapshot{txn.KVTxn.GetSnapshot(), txn.snapshotInterceptor}
+	return &tikvSnapshot{txn.KVTxn.GetSnapshot(), txn.snapshotInterceptor, txn.limiter}
 }
 
 // Iter creates an Iterator positioned on the first entry that k <= entry's key.
@@ -227,6 +235,8 @@ func (txn *tikvTxn) SetOption(opt int, val interface{}) {
 		txn.KVTxn.SetKVFilter(val.(tikv.KVFilter))
 	case kv.SnapInterceptor:
 		txn.snapshotInterceptor = val.(kv.SnapshotInterceptor)
+	case kv.ResourceGroup:
+		txn.limiter, _ = val.(kv.ResourceGroupLimiter)
 	}
 }
 
@@ -689,6 +689,8 @@ func GetZeroValue(col *model.ColumnInfo) types.Datum {
 		d.SetMysqlEnum(types.Enum{}, col.Collate)
 	case mysql.TypeJSON:
 		d.SetMysqlJSON(json.CreateBinary(nil))
+	case mysql.TypeGeometry:
+		d.SetMysqlGeometry([]byte{})
 	}
 	return d
 }
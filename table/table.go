@@ -100,6 +100,8 @@ var (
 	ErrRowDoesNotMatchGivenPartitionSet = dbterror.ClassTable.NewStd(mysql.ErrRowDoesNotMatchGivenPartitionSet)
 	// ErrTempTableFull returns a table is full error, it's used by temporary table now.
 	ErrTempTableFull = dbterror.ClassTable.NewStd(mysql.ErrRecordFileFull)
+	// ErrInvalidJSONValueForFuncIndex returns when an element of the JSON array can't be stored in a multi-valued index.
+	ErrInvalidJSONValueForFuncIndex = dbterror.ClassTable.NewStd(mysql.ErrInvalidJSONValueForFuncIndex)
 )
 
 // RecordIterFunc is used for low-level record iteration.
@@ -239,6 +241,21 @@ type PartitionedTable interface {
 	GetAllPartitionIDs() []int64
 }
 
+// CachedTable is a Table whose data is loaded into the memory of TiDB to serve the reads.
+// The data in the memory is valid under a read lease, and the writes to the table wait for the lease to expire.
+type CachedTable interface {
+	Table
+
+	// TryReadFromCache returns the data of the table in the memory if it can be read at the ts, otherwise nil.
+	TryReadFromCache(ts uint64) kv.MemBuffer
+	// UpdateLockForRead tries to acquire the read lock of the table and load its data into the memory in the background.
+	UpdateLockForRead(store kv.Storage)
+	// WriteLockAndKeepAlive acquires the write lock of the table before a transaction writing the table commits,
+	// the lock is kept alive until the returned function is called. The write lease is stored in leasePtr, it's
+	// set to 0 if the lock fails to be kept alive. The transaction must commit before the write lease.
+	WriteLockAndKeepAlive(ctx context.Context, leasePtr *uint64) (func(), error)
+}
+
 // TableFromMeta builds a table.Table from *model.TableInfo.
 // Currently, it is assigned to tables.TableFromMeta in tidb package's init function.
 var TableFromMeta func(allocators autoid.Allocators, tblInfo *model.TableInfo) (Table, error)
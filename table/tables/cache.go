@@ -0,0 +1,232 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// cachedTableSizeLimit is the max size of the data of a cached table, the table isn't cached if it's larger.
+var cachedTableSizeLimit = 64 * 1024 * 1024
+
+var _ table.CachedTable = &cachedTable{}
+
+type cacheData struct {
+	// start is the ts at which the data is loaded, the data can serve the reads in [start, lease).
+	start uint64
+	lease uint64
+	kv.MemBuffer
+}
+
+type cachedTable struct {
+	TableCommon
+	enabled   bool
+	handle    StateRemote
+	cacheData atomic.Value
+	// renewing is 1 when the data is being loaded or the read lease is being renewed in the background.
+	renewing int32
+	// oversize is 1 when the data exceeds cachedTableSizeLimit, the data isn't loaded again until the table is
+	// written by this TiDB, which may shrink it.
+	oversize int32
+}
+
+// NewCachedTable wraps tbl as a cached table. The reads are served from the memory only when the cache is enabled,
+// while the writes always acquire the write lock before commit. A nil handle means the table is never locked.
+func NewCachedTable(tbl table.Table, enabled bool, handle StateRemote) (table.Table, error) {
+	tc, ok := tbl.(*TableCommon)
+	if !ok {
+		return nil, errors.Errorf("table %s can't be cached", tbl.Meta().Name.O)
+	}
+	return &cachedTable{TableCommon: *tc, enabled: enabled, handle: handle}, nil
+}
+
+func cachedTableLeaseDuration() time.Duration {
+	return time.Duration(variable.TableCacheLease.Load()) * time.Second
+}
+
+// TryReadFromCache implements the table.CachedTable interface.
+func (c *cachedTable) TryReadFromCache(ts uint64) kv.MemBuffer {
+	if !c.enabled {
+		return nil
+	}
+	data, ok := c.cacheData.Load().(*cacheData)
+	if !ok || ts < data.start {
+		return nil
+	}
+	lease := atomic.LoadUint64(&data.lease)
+	if ts >= lease {
+		return nil
+	}
+	// Renew the lease in the background before it expires, so the following reads still hit the cache.
+	if oracle.GetTimeFromTS(lease).Sub(oracle.GetTimeFromTS(ts)) < cachedTableLeaseDuration()/2 &&
+		atomic.CompareAndSwapInt32(&c.renewing, 0, 1) {
+		go func() {
+			defer atomic.StoreInt32(&c.renewing, 0)
+			newLease, err := c.handle.RenewReadLease(context.Background(), c.meta.ID, lease, cachedTableLeaseDuration())
+			if err != nil {
+				logutil.BgLogger().Warn("renew the read lease of the cached table failed", zap.String("table", c.meta.Name.O), zap.Error(err))
+				return
+			}
+			if newLease > lease {
+				atomic.StoreUint64(&data.lease, newLease)
+			}
+		}()
+	}
+	return data.MemBuffer
+}
+
+// UpdateLockForRead implements the table.CachedTable interface.
+func (c *cachedTable) UpdateLockForRead(store kv.Storage) {
+	if !c.enabled || c.handle == nil || atomic.LoadInt32(&c.oversize) == 1 ||
+		!atomic.CompareAndSwapInt32(&c.renewing, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&c.renewing, 0)
+		if err := c.loadDataFromOriginalTable(store); err != nil {
+			logutil.BgLogger().Warn("load the data of the cached table failed", zap.String("table", c.meta.Name.O), zap.Error(err))
+		}
+	}()
+}
+
+func (c *cachedTable) loadDataFromOriginalTable(store kv.Storage) error {
+	lease, err := c.handle.LockForRead(context.Background(), c.meta.ID, cachedTableLeaseDuration())
+	if err != nil || lease == 0 {
+		return err
+	}
+	// The data is loaded by the snapshot after the lock, so all the writes committed before are visible.
+	txn, err := store.Begin()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The transaction only reads the snapshot, it's never committed.
+	defer func() {
+		if err := txn.Rollback(); err != nil {
+			logutil.BgLogger().Warn("rollback the txn loading the cached table failed", zap.String("table", c.meta.Name.O), zap.Error(err))
+		}
+	}()
+	if txn.StartTS() >= lease {
+		return nil
+	}
+	prefix := tablecodec.GenTablePrefix(c.meta.ID)
+	iter, err := txn.GetSnapshot().Iter(prefix, prefix.PrefixNext())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer iter.Close()
+	buffer := txn.GetMemBuffer()
+	size := 0
+	for iter.Valid() {
+		size += len(iter.Key()) + len(iter.Value())
+		if size > cachedTableSizeLimit {
+			atomic.StoreInt32(&c.oversize, 1)
+			return errors.Errorf("the size of table %s exceeds the limit %d of cached tables", c.meta.Name.O, cachedTableSizeLimit)
+		}
+		if err = buffer.Set(iter.Key(), iter.Value()); err != nil {
+			return errors.Trace(err)
+		}
+		if err = iter.Next(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	c.cacheData.Store(&cacheData{start: txn.StartTS(), lease: lease, MemBuffer: buffer})
+	return nil
+}
+
+// WriteLockAndKeepAlive implements the table.CachedTable interface.
+func (c *cachedTable) WriteLockAndKeepAlive(ctx context.Context, leasePtr *uint64) (func(), error) {
+	if c.handle == nil {
+		atomic.StoreUint64(leasePtr, math.MaxUint64)
+		return func() {}, nil
+	}
+	lease, err := c.handle.LockForWrite(ctx, c.meta.ID, cachedTableLeaseDuration())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	atomic.StoreUint64(leasePtr, lease)
+	// The write may shrink the table, so try to load it again.
+	atomic.StoreInt32(&c.oversize, 0)
+	exit := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(cachedTableLeaseDuration() / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				lease, err := c.handle.LockForWrite(ctx, c.meta.ID, cachedTableLeaseDuration())
+				if err != nil {
+					// The lock may be lost, the transaction can't commit anymore.
+					logutil.BgLogger().Warn("renew the write lease of the cached table failed", zap.String("table", c.meta.Name.O), zap.Error(err))
+					atomic.StoreUint64(leasePtr, 0)
+					return
+				}
+				atomic.StoreUint64(leasePtr, lease)
+			case <-exit:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(exit)
+		wg.Wait()
+	}, nil
+}
+
+// AddRecord implements the table.Table interface.
+func (c *cachedTable) AddRecord(sctx sessionctx.Context, r []types.Datum, opts ...table.AddRecordOption) (kv.Handle, error) {
+	c.addToTxnCtx(sctx)
+	return c.TableCommon.AddRecord(sctx, r, opts...)
+}
+
+// UpdateRecord implements the table.Table interface.
+func (c *cachedTable) UpdateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, oldData, newData []types.Datum, touched []bool) error {
+	c.addToTxnCtx(sctx)
+	return c.TableCommon.UpdateRecord(ctx, sctx, h, oldData, newData, touched)
+}
+
+// RemoveRecord implements the table.Table interface.
+func (c *cachedTable) RemoveRecord(sctx sessionctx.Context, h kv.Handle, r []types.Datum) error {
+	c.addToTxnCtx(sctx)
+	return c.TableCommon.RemoveRecord(sctx, h, r)
+}
+
+// addToTxnCtx records the table in the transaction, whose write lock is acquired before commit.
+func (c *cachedTable) addToTxnCtx(sctx sessionctx.Context) {
+	txnCtx := sctx.GetSessionVars().TxnCtx
+	if txnCtx.CachedTables == nil {
+		txnCtx.CachedTables = make(map[int64]interface{})
+	}
+	if _, ok := txnCtx.CachedTables[c.meta.ID]; !ok {
+		txnCtx.CachedTables[c.meta.ID] = c
+	}
+}
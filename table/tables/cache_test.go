@@ -0,0 +1,217 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestCacheTable(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@global.tidb_table_cache_lease = 1")
+	defer tk.MustExec("set @@global.tidb_table_cache_lease = default")
+	tk.MustExec("create table t (id int primary key, k int, v int, key(k))")
+	tk.MustExec("insert into t values (1, 10, 100), (2, 20, 200), (3, 30, 300)")
+	ident := ast.Ident{Schema: model.NewCIStr("test"), Name: model.NewCIStr("t")}
+	tk.MustExec("alter table t cache")
+	tbl, err := dom.InfoSchema().TableByName(ident.Schema, ident.Name)
+	require.NoError(t, err)
+	_, ok := tbl.(table.CachedTable)
+	require.True(t, ok)
+
+	// The data is loaded in the background, so the reads are served from the cache eventually.
+	readFromCache := func(sql string, rows ...string) {
+		for i := 0; i < 100; i++ {
+			tk.MustQuery(sql).Check(testkit.Rows(rows...))
+			if tk.Session().GetSessionVars().StmtCtx.ReadFromTableCache {
+				return
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		require.Failf(t, "the reads are not served from the cache", sql)
+	}
+	readFromCache("select * from t", "1 10 100", "2 20 200", "3 30 300")
+	readFromCache("select k from t where k > 10", "20", "30")
+	readFromCache("select v from t use index(k) where k = 20", "200")
+	readFromCache("select * from t where id = 2", "2 20 200")
+	readFromCache("select * from t where id in (1, 3)", "1 10 100", "3 30 300")
+
+	// The writes wait for the read leases to expire, so the stale data is never read from the cache.
+	tk.MustExec("update t set v = 201 where id = 2")
+	tk.MustQuery("select v from t where id = 2").Check(testkit.Rows("201"))
+	readFromCache("select * from t", "1 10 100", "2 20 201", "3 30 300")
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (4, 40, 400)")
+	tk.MustExec("delete from t where id = 1")
+	tk.MustQuery("select * from t").Check(testkit.Rows("2 20 201", "3 30 300", "4 40 400"))
+	tk.MustExec("commit")
+	readFromCache("select * from t", "2 20 201", "3 30 300", "4 40 400")
+
+	tk.MustGetErrCode("alter table t add column c int", errno.ErrOptOnCacheTable)
+	tk.MustGetErrCode("create index v on t(v)", errno.ErrOptOnCacheTable)
+	tk.MustGetErrCode("drop index k on t", errno.ErrOptOnCacheTable)
+	tk.MustGetErrCode("truncate table t", errno.ErrOptOnCacheTable)
+	tk.MustGetErrCode("alter table t comment = 'x' nocache", errno.ErrOptOnCacheTable)
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `k` int(11) DEFAULT NULL,\n" +
+		"  `v` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  KEY `k` (`k`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /* CACHED ON */"))
+	// Caching a cached table is a no-op.
+	tk.MustExec("alter table t cache")
+
+	tk.MustExec("alter table t nocache")
+	tbl, err = dom.InfoSchema().TableByName(ident.Schema, ident.Name)
+	require.NoError(t, err)
+	_, ok = tbl.(table.CachedTable)
+	require.False(t, ok)
+	tk.MustQuery("select * from t").Check(testkit.Rows("2 20 201", "3 30 300", "4 40 400"))
+	require.False(t, tk.Session().GetSessionVars().StmtCtx.ReadFromTableCache)
+	tk.MustExec("alter table t add column c int")
+	tk.MustExec("alter table t nocache")
+
+	tk.MustExec("create table p (id int) partition by hash(id) partitions 2")
+	tk.MustGetErrCode("alter table p cache", errno.ErrOptOnCacheTable)
+	tk.MustExec("set @@tidb_enable_global_temporary_table = 1")
+	tk.MustExec("create global temporary table tmp (id int) on commit delete rows")
+	tk.MustGetErrCode("alter table tmp cache", errno.ErrOptOnTemporaryTable)
+	tk.MustExec("create view vt as select * from t")
+	tk.MustGetErrCode("alter table vt cache", errno.ErrWrongObject)
+}
+
+// countingStateRemote grants all the locks and counts the read locks. The write locks fail if writeFail is set.
+type countingStateRemote struct {
+	store      kv.Storage
+	readLocked int32
+	writeFail  int32
+}
+
+func (h *countingStateRemote) lease(leaseDuration time.Duration) (uint64, error) {
+	ts, err := h.store.CurrentVersion(kv.GlobalTxnScope)
+	if err != nil {
+		return 0, err
+	}
+	return oracle.GoTimeToTS(oracle.GetTimeFromTS(ts.Ver).Add(leaseDuration)), nil
+}
+
+func (h *countingStateRemote) LockForRead(_ context.Context, _ int64, leaseDuration time.Duration) (uint64, error) {
+	atomic.AddInt32(&h.readLocked, 1)
+	return h.lease(leaseDuration)
+}
+
+func (h *countingStateRemote) RenewReadLease(_ context.Context, _ int64, _ uint64, leaseDuration time.Duration) (uint64, error) {
+	return h.lease(leaseDuration)
+}
+
+func (h *countingStateRemote) LockForWrite(_ context.Context, _ int64, leaseDuration time.Duration) (uint64, error) {
+	if atomic.LoadInt32(&h.writeFail) != 0 {
+		return 0, errors.New("mock write lock failure")
+	}
+	return h.lease(leaseDuration)
+}
+
+func TestCacheTableOversize(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(64))")
+	tk.MustExec("insert into t values (1, repeat('a', 64)), (2, repeat('b', 64))")
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	defer tables.SetCachedTableSizeLimitForTest(100)()
+
+	handle := &countingStateRemote{store: store}
+	cached, err := tables.NewCachedTable(tbl, true, handle)
+	require.NoError(t, err)
+	c := cached.(table.CachedTable)
+	readLocked := func() int32 { return atomic.LoadInt32(&handle.readLocked) }
+	c.UpdateLockForRead(store)
+	require.Eventually(t, func() bool { return readLocked() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// The oversize table isn't locked for read again.
+	for i := 0; i < 20; i++ {
+		time.Sleep(10 * time.Millisecond)
+		c.UpdateLockForRead(store)
+	}
+	require.Equal(t, int32(1), readLocked())
+	ts, err := store.CurrentVersion(kv.GlobalTxnScope)
+	require.NoError(t, err)
+	require.Nil(t, c.TryReadFromCache(ts.Ver))
+
+	// The write may shrink the table, so it's loaded again.
+	var lease uint64
+	unlock, err := c.WriteLockAndKeepAlive(context.Background(), &lease)
+	require.NoError(t, err)
+	unlock()
+	tk.MustExec("delete from t where id = 2")
+	c.UpdateLockForRead(store)
+	require.Eventually(t, func() bool { return readLocked() == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		ts, err := store.CurrentVersion(kv.GlobalTxnScope)
+		require.NoError(t, err)
+		return c.TryReadFromCache(ts.Ver) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCacheTableWriteLease(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@global.tidb_table_cache_lease = 1")
+	defer tk.MustExec("set @@global.tidb_table_cache_lease = default")
+	tk.MustExec("create table t (id int primary key)")
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+
+	handle := &countingStateRemote{store: store}
+	cached, err := tables.NewCachedTable(tbl, true, handle)
+	require.NoError(t, err)
+	var lease uint64
+	unlock, err := cached.(table.CachedTable).WriteLockAndKeepAlive(context.Background(), &lease)
+	require.NoError(t, err)
+	defer unlock()
+	ts, err := store.CurrentVersion(kv.GlobalTxnScope)
+	require.NoError(t, err)
+	require.Greater(t, atomic.LoadUint64(&lease), ts.Ver)
+
+	// The lease is renewed before it expires.
+	first := atomic.LoadUint64(&lease)
+	require.Eventually(t, func() bool { return atomic.LoadUint64(&lease) > first }, 5*time.Second, 10*time.Millisecond)
+
+	// The lease is cleared once the renewal fails, so the transaction can't commit.
+	atomic.StoreInt32(&handle.writeFail, 1)
+	require.Eventually(t, func() bool { return atomic.LoadUint64(&lease) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
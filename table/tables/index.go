@@ -133,19 +133,68 @@ func (c *index) Meta() *model.IndexInfo {
 // GenIndexKey generates storage key for index values. Returned distinct indicates whether the
 // indexed values should be distinct in storage (i.e. whether handle is encoded in the key).
 func (c *index) GenIndexKey(sc *stmtctx.StatementContext, indexedValues []types.Datum, h kv.Handle, buf []byte) (key []byte, distinct bool, err error) {
-	idxTblID := c.phyTblID
+	return tablecodec.GenIndexKey(sc, c.tblInfo, c.idxInfo, c.idxTblID(), indexedValues, h, buf)
+}
+
+// idxTblID returns the table ID in the index keys, it is the logical table ID for a global index.
+func (c *index) idxTblID() int64 {
 	if c.idxInfo.Global {
-		idxTblID = c.tblInfo.ID
+		return c.tblInfo.ID
 	}
-	return tablecodec.GenIndexKey(sc, c.tblInfo, c.idxInfo, idxTblID, indexedValues, h, buf)
+	return c.phyTblID
+}
+
+// mvIndexValues returns the indexed values of the entries of a multi-valued index. There is an entry for every
+// element of the JSON array of the multi-valued key part, and a single entry if the array is NULL.
+func (c *index) mvIndexValues(indexedValues []types.Datum) ([][]types.Datum, error) {
+	for i, idxCol := range c.idxInfo.Columns {
+		col := c.tblInfo.Columns[idxCol.Offset]
+		if !col.FieldType.Array {
+			continue
+		}
+		if indexedValues[i].IsNull() {
+			return [][]types.Datum{indexedValues}, nil
+		}
+		elems := indexedValues[i].GetMysqlJSON().ArrayElems()
+		vals := make([][]types.Datum, 0, len(elems))
+		for _, elem := range elems {
+			d, err := types.ConvertJSONToArrayElement(elem, &col.FieldType)
+			if err != nil {
+				return nil, table.ErrInvalidJSONValueForFuncIndex.GenWithStackByArgs(c.idxInfo.Name.O)
+			}
+			val := make([]types.Datum, len(indexedValues))
+			copy(val, indexedValues)
+			val[i] = d
+			vals = append(vals, val)
+		}
+		return vals, nil
+	}
+	return [][]types.Datum{indexedValues}, nil
 }
 
 // Create creates a new entry in the kvIndex data.
 // If the index is unique and there is an existing entry with the same key,
 // Create will return the existing entry's handle as the first return value, ErrKeyExists as the second return value.
 func (c *index) Create(sctx sessionctx.Context, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle, handleRestoreData []types.Datum, opts ...table.CreateIdxOptFunc) (kv.Handle, error) {
+	if !c.idxInfo.MVIndex {
+		return c.create(sctx, txn, indexedValues, h, handleRestoreData, opts...)
+	}
+	vals, err := c.mvIndexValues(indexedValues)
+	if err != nil {
+		return nil, err
+	}
+	// A multi-valued index is never unique, so there is no existing handle to return.
+	for _, val := range vals {
+		if _, err := c.create(sctx, txn, val, h, handleRestoreData, opts...); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (c *index) create(sctx sessionctx.Context, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle, handleRestoreData []types.Datum, opts ...table.CreateIdxOptFunc) (kv.Handle, error) {
 	if c.Meta().Unique {
-		txn.CacheTableInfo(c.phyTblID, c.tblInfo)
+		txn.CacheTableInfo(c.idxTblID(), c.tblInfo)
 	}
 	var opt table.CreateIdxOpt
 	for _, fn := range opts {
@@ -229,6 +278,22 @@ func (c *index) Create(sctx sessionctx.Context, txn kv.Transaction, indexedValue
 
 // Delete removes the entry for handle h and indexedValues from KV index.
 func (c *index) Delete(sc *stmtctx.StatementContext, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle) error {
+	if !c.idxInfo.MVIndex {
+		return c.delete(sc, txn, indexedValues, h)
+	}
+	vals, err := c.mvIndexValues(indexedValues)
+	if err != nil {
+		return err
+	}
+	for _, val := range vals {
+		if err := c.delete(sc, txn, val, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *index) delete(sc *stmtctx.StatementContext, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle) error {
 	key, distinct, err := c.GenIndexKey(sc, indexedValues, h, nil)
 	if err != nil {
 		return err
@@ -40,6 +40,7 @@ import (
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/mock"
@@ -51,6 +52,44 @@ const (
 	btreeDegree = 32
 )
 
+// PartitionTypeString returns the name of the partition type, including the LINEAR modifier.
+func PartitionTypeString(pi *model.PartitionInfo) string {
+	if pi.IsLinear {
+		return "LINEAR " + pi.Type.String()
+	}
+	return pi.Type.String()
+}
+
+// LinearHashMask returns the mask of the powers-of-two algorithm for the LINEAR partitions,
+// which is the smallest power of two not less than num minus one.
+func LinearHashMask(num uint64) uint64 {
+	mask := uint64(1)
+	for mask < num {
+		mask <<= 1
+	}
+	return mask - 1
+}
+
+// HashPartitionIdx returns the index of the partition which the hash value belongs to.
+// The LINEAR partitions use the powers-of-two algorithm of MySQL, see
+// https://dev.mysql.com/doc/refman/8.0/en/partitioning-linear-hash.html
+func HashPartitionIdx(pi *model.PartitionInfo, v int64) int {
+	num := pi.Num
+	if pi.IsLinear {
+		mask := LinearHashMask(num)
+		idx := uint64(v) & mask
+		if idx >= num {
+			idx &= mask >> 1
+		}
+		return int(idx)
+	}
+	ret := v % int64(num)
+	if ret < 0 {
+		ret = -ret
+	}
+	return int(ret)
+}
+
 // Both partition and partitionedTable implement the table.Table interface.
 var _ table.PhysicalTable = &partition{}
 var _ table.Table = &partitionedTable{}
@@ -81,6 +120,13 @@ type partitionedTable struct {
 	partitions      map[int64]*partition
 	evalBufferTypes []*types.FieldType
 	evalBufferPool  sync.Pool
+
+	// reorgPartitionTable is the other layout of the table when some of its partitions are being
+	// reorganized, the rows written into reorgPartitions are written into it too.
+	reorgPartitionTable *partitionedTable
+	reorgPartitions     map[int64]struct{}
+	// reorgDeleteOnly means only the deletions are written into reorgPartitionTable.
+	reorgDeleteOnly bool
 }
 
 func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Table, error) {
@@ -110,9 +156,77 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Tabl
 		partitions[p.ID] = &t
 	}
 	ret.partitions = partitions
+	if err := initReorgPartitionTable(ret, tblInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return ret, nil
 }
 
+// initReorgPartitionTable initializes the other layout of the table if some of its partitions are being reorganized.
+// Before the new partitions replace the old ones, the old partitions are in the definitions, the rows written into
+// them are written into the new partitions too. After that, the rows written into the new partitions are written into
+// the old ones too, so the TiDB servers that still use the old layout read the same data.
+func initReorgPartitionTable(t *partitionedTable, tblInfo *model.TableInfo) error {
+	pi := tblInfo.GetPartitionInfo()
+	if len(pi.AddingDefinitions) == 0 || len(pi.DroppingDefinitions) == 0 {
+		return nil
+	}
+	from, to := pi.DroppingDefinitions, pi.AddingDefinitions
+	if _, ok := t.partitions[from[0].ID]; !ok {
+		from, to = to, from
+	}
+	t.reorgPartitions = make(map[int64]struct{}, len(from))
+	for _, def := range from {
+		t.reorgPartitions[def.ID] = struct{}{}
+	}
+	for _, st := range pi.States {
+		if st.ID == to[0].ID {
+			t.reorgDeleteOnly = st.State == model.StateDeleteOnly
+		}
+	}
+
+	nt := tblInfo.Clone()
+	np := *pi
+	np.Definitions = ReplacePartitionDefinitions(pi.Definitions, from, to)
+	np.AddingDefinitions = nil
+	np.DroppingDefinitions = nil
+	np.States = nil
+	nt.Partition = &np
+	var tc TableCommon
+	initTableCommon(&tc, nt, nt.ID, t.Columns, t.allocs)
+	reorgTbl, err := newPartitionedTable(&tc, nt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	t.reorgPartitionTable = reorgTbl.(*partitionedTable)
+	return nil
+}
+
+// ReplacePartitionDefinitions returns the partition definitions with the `from` partitions replaced by the `to` partitions,
+// the `to` partitions are placed at the position of the first `from` partition.
+func ReplacePartitionDefinitions(defs, from, to []model.PartitionDefinition) []model.PartitionDefinition {
+	fromIDs := make(map[int64]struct{}, len(from))
+	for _, def := range from {
+		fromIDs[def.ID] = struct{}{}
+	}
+	newDefs := make([]model.PartitionDefinition, 0, len(defs)-len(from)+len(to))
+	inserted := false
+	for _, def := range defs {
+		if _, ok := fromIDs[def.ID]; !ok {
+			newDefs = append(newDefs, def)
+			continue
+		}
+		if !inserted {
+			newDefs = append(newDefs, to...)
+			inserted = true
+		}
+	}
+	if !inserted {
+		newDefs = append(newDefs, to...)
+	}
+	return newDefs
+}
+
 func newPartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
 	ctx := mock.NewContext()
 	dbName := model.NewCIStr(ctx.GetSessionVars().CurrentDB)
@@ -126,6 +240,8 @@ func newPartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
 		return generateRangePartitionExpr(ctx, pi, columns, names)
 	case model.PartitionTypeHash:
 		return generateHashPartitionExpr(ctx, pi, columns, names)
+	case model.PartitionTypeKey:
+		return generateKeyPartitionExpr(pi, columns, names)
 	case model.PartitionTypeList:
 		return generateListPartitionExpr(ctx, tblInfo, columns, names)
 	}
@@ -149,6 +265,8 @@ type PartitionExpr struct {
 	// InValues: x in (1,2); x in (3,4); x in (5,6), used for list partition.
 	InValues []expression.Expression
 	*ForListPruning
+	// Used in the key partition pruning process.
+	*ForKeyPruning
 }
 
 func initEvalBufferType(t *partitionedTable) {
@@ -898,6 +1016,105 @@ func generateHashPartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
 	}, nil
 }
 
+// ForKeyPruning is used for key partition pruning.
+type ForKeyPruning struct {
+	// KeyPartCols is the columns of the key partition.
+	KeyPartCols []*expression.Column
+	fieldTypes  []*types.FieldType
+}
+
+func generateKeyPartitionExpr(pi *model.PartitionInfo, columns []*expression.Column, names types.NameSlice) (*PartitionExpr, error) {
+	// The caller should assure partition info is not nil.
+	keyPrune := &ForKeyPruning{
+		KeyPartCols: make([]*expression.Column, 0, len(pi.Columns)),
+		fieldTypes:  make([]*types.FieldType, 0, len(pi.Columns)),
+	}
+	offset := make([]int, 0, len(pi.Columns))
+	for _, colName := range pi.Columns {
+		idx := expression.FindFieldNameIdxByColName(names, colName.L)
+		if idx < 0 {
+			// If it got an error here, ddl may hang forever, so this error log is important.
+			logutil.BgLogger().Error("wrong table partition key column", zap.String("column", colName.O))
+			return nil, errors.Trace(table.ErrUnknownColumn.GenWithStackByArgs(colName.O))
+		}
+		keyPrune.KeyPartCols = append(keyPrune.KeyPartCols, columns[idx])
+		keyPrune.fieldTypes = append(keyPrune.fieldTypes, columns[idx].RetType)
+		offset = append(offset, idx)
+	}
+	return &PartitionExpr{
+		ColumnOffset:  offset,
+		ForKeyPruning: keyPrune,
+	}, nil
+}
+
+// LocateKeyPartition returns the index of the partition which the values of the key partition columns belong to.
+// The values should have been converted to the types of the columns.
+func (kp *ForKeyPruning) LocateKeyPartition(sc *stmtctx.StatementContext, pi *model.PartitionInfo, vals []types.Datum) (int, error) {
+	h, err := keyPartitionHashV1(sc, kp.fieldTypes, vals)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return HashPartitionIdx(pi, int64(h)), nil
+}
+
+// keyPartitionHashV1 is the hash function of the KEY partitions. The rows are placed into the partitions by it, so
+// it must never be changed; a different hash function must come with a new version stored in the table meta.
+//
+// The values are mixed as my_hash_sort_bin of MySQL starting from nr1 = 1 and nr2 = 4, and a NULL value changes nr1
+// only. Integers are hashed by their little-endian storage bytes of MySQL, so they are placed into the same partition
+// as MySQL. Strings are hashed by their collation keys, so the values that are equal under the collation are always
+// placed into the same partition. The other types are hashed by their memory-comparable encoding.
+func keyPartitionHashV1(sc *stmtctx.StatementContext, fts []*types.FieldType, vals []types.Datum) (uint32, error) {
+	nr1, nr2 := uint64(1), uint64(4)
+	var buf []byte
+	for i := range vals {
+		if vals[i].IsNull() {
+			nr1 ^= (nr1 << 1) | 1
+			continue
+		}
+		var err error
+		buf, err = keyPartitionHashBytes(sc, fts[i], &vals[i], buf[:0])
+		if err != nil {
+			return 0, err
+		}
+		for _, b := range buf {
+			nr1 ^= (((nr1 & 63) + nr2) * uint64(b)) + (nr1 << 8)
+			nr2 += 3
+		}
+	}
+	return uint32(nr1), nil
+}
+
+// keyPartitionHashBytes appends the bytes of the value which are hashed by keyPartitionHashV1 to buf.
+func keyPartitionHashBytes(sc *stmtctx.StatementContext, ft *types.FieldType, d *types.Datum, buf []byte) ([]byte, error) {
+	switch d.Kind() {
+	case types.KindInt64, types.KindUint64:
+		size := 8
+		switch ft.Tp {
+		case mysql.TypeTiny, mysql.TypeYear:
+			size = 1
+		case mysql.TypeShort:
+			size = 2
+		case mysql.TypeInt24:
+			size = 3
+		case mysql.TypeLong:
+			size = 4
+		}
+		v := d.GetUint64()
+		if ft.Tp == mysql.TypeYear && v != 0 {
+			// YEAR is stored as the offset from 1900 in MySQL.
+			v -= 1900
+		}
+		for i := 0; i < size; i++ {
+			buf = append(buf, byte(v>>(8*i)))
+		}
+		return buf, nil
+	case types.KindString, types.KindBytes:
+		return append(buf, collate.GetCollator(ft.Collate).Key(d.GetString())...), nil
+	}
+	return codec.EncodeKey(sc, buf, *d)
+}
+
 // PartitionExpr returns the partition expression.
 func (t *partitionedTable) PartitionExpr() (*PartitionExpr, error) {
 	return t.partitionExpr, nil
@@ -922,6 +1139,8 @@ func (t *partitionedTable) locatePartition(ctx sessionctx.Context, pi *model.Par
 		}
 	case model.PartitionTypeHash:
 		idx, err = t.locateHashPartition(ctx, pi, r)
+	case model.PartitionTypeKey:
+		idx, err = t.locateKeyPartition(ctx, pi, r)
 	case model.PartitionTypeList:
 		idx, err = t.locateListPartition(ctx, pi, r)
 	}
@@ -1038,7 +1257,6 @@ func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, pi *mode
 	return pos, nil
 }
 
-// TODO: supports linear hashing
 func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
 	if col, ok := t.partitionExpr.Expr.(*expression.Column); ok {
 		var data types.Datum
@@ -1052,12 +1270,7 @@ func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model
 				return 0, err
 			}
 		}
-		ret := data.GetInt64()
-		ret = ret % int64(t.meta.Partition.Num)
-		if ret < 0 {
-			ret = -ret
-		}
-		return int(ret), nil
+		return HashPartitionIdx(pi, data.GetInt64()), nil
 	}
 	evalBuffer := t.evalBufferPool.Get().(*chunk.MutRow)
 	defer t.evalBufferPool.Put(evalBuffer)
@@ -1069,11 +1282,15 @@ func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model
 	if isNull {
 		return 0, nil
 	}
-	ret = ret % int64(t.meta.Partition.Num)
-	if ret < 0 {
-		ret = -ret
+	return HashPartitionIdx(pi, ret), nil
+}
+
+func (t *partitionedTable) locateKeyPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
+	vals := make([]types.Datum, 0, len(t.partitionExpr.ColumnOffset))
+	for _, offset := range t.partitionExpr.ColumnOffset {
+		vals = append(vals, r[offset])
 	}
-	return int(ret), nil
+	return t.partitionExpr.LocateKeyPartition(ctx.GetSessionVars().StmtCtx, pi, vals)
 }
 
 // GetPartition returns a Table, which is actually a partition.
@@ -1126,7 +1343,59 @@ func partitionedTableAddRecord(ctx sessionctx.Context, t *partitionedTable, r []
 		}
 	}
 	tbl := t.GetPartition(pid)
-	return tbl.AddRecord(ctx, r, opts...)
+	recordID, err = tbl.AddRecord(ctx, r, opts...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = t.addReorgRecord(ctx, pid, recordID, r, opts); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return recordID, nil
+}
+
+// addReorgRecord writes the row added into the partition `pid` into the reorganized layout with the same handle.
+func (t *partitionedTable) addReorgRecord(ctx sessionctx.Context, pid int64, h kv.Handle, r []types.Datum, opts []table.AddRecordOption) error {
+	if _, ok := t.reorgPartitions[pid]; !ok || t.reorgDeleteOnly {
+		return nil
+	}
+	reorgTbl := t.reorgPartitionTable
+	newPid, err := reorgTbl.locatePartition(ctx, reorgTbl.meta.GetPartitionInfo(), r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	reorgOpts := make([]table.AddRecordOption, 0, len(opts))
+	for _, opt := range opts {
+		if opt == table.IsUpdate {
+			continue
+		}
+		reorgOpts = append(reorgOpts, opt)
+	}
+	if !t.meta.PKIsHandle && !t.meta.IsCommonHandle {
+		// Pass the handle as the _tidb_rowid, so the row keeps its handle in the new partition.
+		cols := len(t.Cols())
+		row := make([]types.Datum, 0, cols+1)
+		row = append(row, r[:cols]...)
+		r = append(row, types.NewIntDatum(h.IntValue()))
+	}
+	_, err = reorgTbl.GetPartition(newPid).AddRecord(ctx, r, reorgOpts...)
+	return errors.Trace(err)
+}
+
+// removeReorgRecord removes the row removed from the partition `pid` from the reorganized layout.
+func (t *partitionedTable) removeReorgRecord(ctx sessionctx.Context, pid int64, h kv.Handle, r []types.Datum) error {
+	if _, ok := t.reorgPartitions[pid]; !ok {
+		return nil
+	}
+	reorgTbl := t.reorgPartitionTable
+	newPid, err := reorgTbl.locatePartition(ctx, reorgTbl.meta.GetPartitionInfo(), r)
+	if err != nil {
+		if table.ErrNoPartitionForGivenValue.Equal(err) {
+			// The row can't be placed in the new partitions, so it was never written into them.
+			return nil
+		}
+		return errors.Trace(err)
+	}
+	return errors.Trace(reorgTbl.GetPartition(newPid).RemoveRecord(ctx, h, r))
 }
 
 // partitionTableWithGivenSets is used for this kind of grammar: partition (p0,p1)
@@ -1170,7 +1439,10 @@ func (t *partitionedTable) RemoveRecord(ctx sessionctx.Context, h kv.Handle, r [
 	}
 
 	tbl := t.GetPartition(pid)
-	return tbl.RemoveRecord(ctx, h, r)
+	if err = tbl.RemoveRecord(ctx, h, r); err != nil {
+		return errors.Trace(err)
+	}
+	return t.removeReorgRecord(ctx, pid, h, r)
 }
 
 func (t *partitionedTable) GetAllPartitionIDs() []int64 {
@@ -1214,27 +1486,55 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx sessionctx.Context,
 
 	// The old and new data locate in different partitions.
 	// Remove record from old partition and add record to new partition.
+	newHandle := h
 	if from != to {
-		_, err = t.GetPartition(to).AddRecord(ctx, newData)
+		// The entries of the old record in the global indexes would conflict with the new record,
+		// so the old record has to be removed first.
+		removeFirst := hasGlobalIndex(t.meta)
+		if removeFirst {
+			if err = t.GetPartition(from).RemoveRecord(ctx, h, currData); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		newHandle, err = t.GetPartition(to).AddRecord(ctx, newData)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		// UpdateRecord should be side effect free, but there're two steps here.
-		// What would happen if step1 succeed but step2 meets error? It's hard
-		// to rollback.
-		// So this special order is chosen: add record first, errors such as
-		// 'Key Already Exists' will generally happen during step1, errors are
-		// unlikely to happen in step2.
-		err = t.GetPartition(from).RemoveRecord(ctx, h, currData)
-		if err != nil {
-			logutil.BgLogger().Error("update partition record fails", zap.String("message", "new record inserted while old record is not removed"), zap.Error(err))
+		if !removeFirst {
+			// UpdateRecord should be side effect free, but there're two steps here.
+			// What would happen if step1 succeed but step2 meets error? It's hard
+			// to rollback.
+			// So this special order is chosen: add record first, errors such as
+			// 'Key Already Exists' will generally happen during step1, errors are
+			// unlikely to happen in step2.
+			err = t.GetPartition(from).RemoveRecord(ctx, h, currData)
+			if err != nil {
+				logutil.BgLogger().Error("update partition record fails", zap.String("message", "new record inserted while old record is not removed"), zap.Error(err))
+				return errors.Trace(err)
+			}
+		}
+	} else {
+		tbl := t.GetPartition(to)
+		if err = tbl.UpdateRecord(gctx, ctx, h, currData, newData, touched); err != nil {
 			return errors.Trace(err)
 		}
-		return nil
 	}
 
-	tbl := t.GetPartition(to)
-	return tbl.UpdateRecord(gctx, ctx, h, currData, newData, touched)
+	// The rows in the reorganized layout are always replaced as a whole, because the old and new rows
+	// may locate in different partitions there.
+	if err = t.removeReorgRecord(ctx, from, h, currData); err != nil {
+		return errors.Trace(err)
+	}
+	return t.addReorgRecord(ctx, to, newHandle, newData, nil)
+}
+
+func hasGlobalIndex(tblInfo *model.TableInfo) bool {
+	for _, idxInfo := range tblInfo.Indices {
+		if idxInfo.Global {
+			return true
+		}
+	}
+	return false
 }
 
 // FindPartitionByName finds partition in table meta by name.
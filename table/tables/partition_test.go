@@ -19,10 +19,12 @@ import (
 	"testing"
 
 	"github.com/pingcap/parser/model"
+	parsermysql "github.com/pingcap/parser/mysql"
 	"github.com/pingcap/tidb/ddl"
 	mysql "github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/sessionctx/binloginfo"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/testkit"
@@ -626,3 +628,44 @@ func TestIssue24746(t *testing.T) {
 	err = tk.ExecToErr("insert into t_24746 partition (p1) values(4,'ERROR, not allowed to read from partition p0',4) on duplicate key update a = a + 1, b = 'ERROR, not allowed to read from p0!'")
 	require.True(t, table.ErrRowDoesNotMatchGivenPartitionSet.Equal(err))
 }
+
+func TestKeyPartitionHash(t *testing.T) {
+	t.Parallel()
+	// The rows of the KEY partitions are placed by the hash, the values must never change.
+	longType := types.NewFieldType(parsermysql.TypeLong)
+	bigintType := types.NewFieldType(parsermysql.TypeLonglong)
+	yearType := types.NewFieldType(parsermysql.TypeYear)
+	varcharType := types.NewFieldType(parsermysql.TypeVarchar)
+	varcharType.Collate = "utf8mb4_bin"
+	tests := []struct {
+		fts  []*types.FieldType
+		vals []types.Datum
+		hash uint32
+	}{
+		{[]*types.FieldType{longType}, types.MakeDatums(0), 1},
+		{[]*types.FieldType{longType}, types.MakeDatums(1), 84215044},
+		{[]*types.FieldType{longType}, types.MakeDatums(-1), 1062574091},
+		{[]*types.FieldType{bigintType}, types.MakeDatums(1), 84215044},
+		{[]*types.FieldType{longType}, types.MakeDatums(nil), 2},
+		{[]*types.FieldType{longType, longType}, types.MakeDatums(1, nil), 252645133},
+		{[]*types.FieldType{longType, longType}, types.MakeDatums(1, 2), 757935404},
+		{[]*types.FieldType{yearType}, types.MakeDatums(2021), 860},
+		{[]*types.FieldType{varcharType}, types.MakeDatums("abc"), 49572422},
+		{[]*types.FieldType{bigintType, varcharType}, types.MakeDatums(5, "abc"), 852020014},
+	}
+	sc := &stmtctx.StatementContext{}
+	for _, tt := range tests {
+		hash, err := tables.KeyPartitionHashV1(sc, tt.fts, tt.vals)
+		require.NoError(t, err)
+		require.Equal(t, tt.hash, hash, "%v", tt.vals)
+	}
+
+	pi := &model.PartitionInfo{Type: model.PartitionTypeKey, Num: 5}
+	require.Equal(t, 4, tables.HashPartitionIdx(pi, 84215044))
+	pi.IsLinear = true
+	// The mask of 5 partitions is 7, 84215044 & 7 = 4 and 1062574091 & 7 = 3.
+	require.Equal(t, 4, tables.HashPartitionIdx(pi, 84215044))
+	require.Equal(t, 3, tables.HashPartitionIdx(pi, 1062574091))
+	// 252645133 & 7 = 5 is not less than 5, so it's under the half mask 3, which is 1.
+	require.Equal(t, 1, tables.HashPartitionIdx(pi, 252645133))
+}
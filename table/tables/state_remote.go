@@ -0,0 +1,228 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"context"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/terror"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// CachedTableLockType is the type of the lock on a cached table.
+type CachedTableLockType int
+
+const (
+	// CachedTableLockNone means the cached table is not locked.
+	CachedTableLockNone CachedTableLockType = iota
+	// CachedTableLockRead means the data of the cached table is cached by some TiDBs until the lease.
+	CachedTableLockRead
+	// CachedTableLockIntend means a writer is waiting for the read lease to expire, the read lease can't be renewed.
+	CachedTableLockIntend
+	// CachedTableLockWrite means the cached table is being written until the lease.
+	CachedTableLockWrite
+)
+
+var cachedTableLockTypeNames = []string{"NONE", "READ", "INTEND", "WRITE"}
+
+// String implements the fmt.Stringer interface.
+func (l CachedTableLockType) String() string {
+	return cachedTableLockTypeNames[l]
+}
+
+func parseCachedTableLockType(s string) (CachedTableLockType, error) {
+	for i, name := range cachedTableLockTypeNames {
+		if name == s {
+			return CachedTableLockType(i), nil
+		}
+	}
+	return CachedTableLockNone, errors.Errorf("unknown cached table lock type %s", s)
+}
+
+// SessionPool is the pool of the internal sessions used to access the lock meta of the cached tables.
+type SessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+// StateRemote controls the locks of the cached tables, which are shared by all the TiDBs of the cluster.
+// The leases are TSOs, a reader can serve the reads whose start ts is before the read lease from its cache,
+// while a writer must commit before the write lease.
+type StateRemote interface {
+	// LockForRead acquires the read lock, it returns the read lease, or 0 if the table is locked by a writer.
+	LockForRead(ctx context.Context, tid int64, leaseDuration time.Duration) (uint64, error)
+	// RenewReadLease renews the read lease acquired before, it returns the new lease, or 0 if the lease
+	// can't be renewed because a writer is waiting for it.
+	RenewReadLease(ctx context.Context, tid int64, oldLease uint64, leaseDuration time.Duration) (uint64, error)
+	// LockForWrite acquires the write lock after all the read leases expire, it returns the write lease.
+	LockForWrite(ctx context.Context, tid int64, leaseDuration time.Duration) (uint64, error)
+}
+
+type cachedTableLock struct {
+	lockType     CachedTableLockType
+	lease        uint64
+	oldReadLease uint64
+}
+
+type sqlStateRemote struct {
+	sessPool SessionPool
+}
+
+// NewStateRemote creates a StateRemote which stores the locks in the mysql.table_cache_meta table.
+func NewStateRemote(sessPool SessionPool) StateRemote {
+	return &sqlStateRemote{sessPool: sessPool}
+}
+
+func leaseFromTS(ts uint64, leaseDuration time.Duration) uint64 {
+	return oracle.GoTimeToTS(oracle.GetTimeFromTS(ts).Add(leaseDuration))
+}
+
+// LockForRead implements the StateRemote interface.
+func (h *sqlStateRemote) LockForRead(ctx context.Context, tid int64, leaseDuration time.Duration) (lease uint64, err error) {
+	err = h.runInTxn(ctx, tid, func(se sqlexec.SQLExecutor, now uint64, lock *cachedTableLock) error {
+		if (lock.lockType == CachedTableLockIntend || lock.lockType == CachedTableLockWrite) && now < lock.lease {
+			return nil
+		}
+		lease = leaseFromTS(now, leaseDuration)
+		if lock.lockType == CachedTableLockRead && lock.lease > lease {
+			lease = lock.lease
+		}
+		return h.updateLock(ctx, se, tid, CachedTableLockRead, lease, 0)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return lease, nil
+}
+
+// RenewReadLease implements the StateRemote interface.
+func (h *sqlStateRemote) RenewReadLease(ctx context.Context, tid int64, oldLease uint64, leaseDuration time.Duration) (lease uint64, err error) {
+	err = h.runInTxn(ctx, tid, func(se sqlexec.SQLExecutor, now uint64, lock *cachedTableLock) error {
+		if lock.lockType != CachedTableLockRead || now >= oldLease {
+			return nil
+		}
+		lease = leaseFromTS(now, leaseDuration)
+		if lock.lease > lease {
+			lease = lock.lease
+		}
+		return h.updateLock(ctx, se, tid, CachedTableLockRead, lease, 0)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return lease, nil
+}
+
+// LockForWrite implements the StateRemote interface.
+func (h *sqlStateRemote) LockForWrite(ctx context.Context, tid int64, leaseDuration time.Duration) (uint64, error) {
+	for {
+		var lease uint64
+		var wait time.Duration
+		err := h.runInTxn(ctx, tid, func(se sqlexec.SQLExecutor, now uint64, lock *cachedTableLock) error {
+			lease = leaseFromTS(now, leaseDuration)
+			if lock.lockType != CachedTableLockRead && lock.lease > lease {
+				lease = lock.lease
+			}
+			oldReadLease := uint64(0)
+			switch lock.lockType {
+			case CachedTableLockRead:
+				oldReadLease = lock.lease
+			case CachedTableLockIntend:
+				oldReadLease = lock.oldReadLease
+			}
+			if now < oldReadLease {
+				// Forbid the readers to renew the lease, and wait for the lease to expire.
+				wait = oracle.GetTimeFromTS(oldReadLease).Sub(oracle.GetTimeFromTS(now))
+				return h.updateLock(ctx, se, tid, CachedTableLockIntend, lease, oldReadLease)
+			}
+			return h.updateLock(ctx, se, tid, CachedTableLockWrite, lease, 0)
+		})
+		if err != nil {
+			return 0, err
+		}
+		if wait == 0 {
+			return lease, nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, errors.Trace(ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (h *sqlStateRemote) updateLock(ctx context.Context, se sqlexec.SQLExecutor, tid int64, lockType CachedTableLockType, lease, oldReadLease uint64) error {
+	_, err := execSQL(ctx, se, "update mysql.table_cache_meta set lock_type = %?, lease = %?, oldReadLease = %? where tid = %?",
+		lockType.String(), lease, oldReadLease, tid)
+	return err
+}
+
+// runInTxn runs fn in a pessimistic transaction which locks the row of the table, now is the start ts of the transaction.
+func (h *sqlStateRemote) runInTxn(ctx context.Context, tid int64, fn func(se sqlexec.SQLExecutor, now uint64, lock *cachedTableLock) error) (err error) {
+	res, err := h.sessPool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer h.sessPool.Put(res)
+	se := res.(sqlexec.SQLExecutor)
+
+	if _, err = execSQL(ctx, se, "begin pessimistic"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, rollbackErr := execSQL(ctx, se, "rollback")
+			terror.Log(rollbackErr)
+			return
+		}
+		_, err = execSQL(ctx, se, "commit")
+	}()
+	txn, err := res.(sessionctx.Context).Txn(true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rows, err := execSQL(ctx, se, "select lock_type, lease, oldReadLease from mysql.table_cache_meta where tid = %? for update", tid)
+	if err != nil {
+		return err
+	}
+	lock := &cachedTableLock{lockType: CachedTableLockNone}
+	if len(rows) == 0 {
+		if _, err = execSQL(ctx, se, "insert into mysql.table_cache_meta (tid) values (%?)", tid); err != nil {
+			return err
+		}
+	} else {
+		if lock.lockType, err = parseCachedTableLockType(rows[0].GetEnum(0).String()); err != nil {
+			return err
+		}
+		lock.lease = rows[0].GetUint64(1)
+		lock.oldReadLease = rows[0].GetUint64(2)
+	}
+	return fn(se, txn.StartTS(), lock)
+}
+
+func execSQL(ctx context.Context, se sqlexec.SQLExecutor, sql string, args ...interface{}) ([]chunk.Row, error) {
+	rs, err := se.ExecuteInternal(ctx, sql, args...)
+	if err != nil || rs == nil {
+		return nil, errors.Trace(err)
+	}
+	defer terror.Call(rs.Close)
+	return sqlexec.DrainRecordSet(ctx, rs, 8)
+}
@@ -623,6 +623,8 @@ func Unflatten(datum types.Datum, ft *types.FieldType, loc *time.Location) (type
 		byteSize := (ft.Flen + 7) >> 3
 		datum.SetUint64(0)
 		datum.SetMysqlBit(types.NewBinaryLiteralFromUint(val, byteSize))
+	case mysql.TypeGeometry:
+		datum.SetMysqlGeometry(datum.GetBytes())
 	}
 	return datum, nil
 }
@@ -39,6 +39,10 @@ import (
 	"github.com/pingcap/tidb/ddl"
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/executor"
+	// Enable LOAD DATA from the external storage.
+	_ "github.com/pingcap/tidb/executor/importer"
+	// Enable the parquet format of SELECT INTO OUTFILE.
+	_ "github.com/pingcap/tidb/executor/parquet"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/metrics"
 	plannercore "github.com/pingcap/tidb/planner/core"
@@ -63,6 +67,7 @@ import (
 	"github.com/pingcap/tidb/util/profile"
 	"github.com/pingcap/tidb/util/sem"
 	"github.com/pingcap/tidb/util/signal"
+	"github.com/pingcap/tidb/util/stmtsummary"
 	"github.com/pingcap/tidb/util/sys/linux"
 	storageSys "github.com/pingcap/tidb/util/sys/storage"
 	"github.com/pingcap/tidb/util/systimemon"
@@ -187,6 +192,7 @@ func main() {
 	printInfo()
 	setupBinlogClient()
 	setupMetrics()
+	setupStmtSummary()
 
 	storage, dom := createStoreAndDomain()
 	svr := createServer(storage, dom)
@@ -660,6 +666,14 @@ func setupMetrics() {
 	pushMetric(cfg.Status.MetricsAddr, time.Duration(cfg.Status.MetricsInterval)*time.Second)
 }
 
+func setupStmtSummary() {
+	cfg := config.GetGlobalConfig().StmtSummary
+	if cfg.EnablePersistent {
+		err := stmtsummary.StmtSummaryByDigestMap.SetupPersistence(cfg.Filename, cfg.FileMaxSize, cfg.FileMaxDays, cfg.FileMaxBackups)
+		terror.MustNil(err)
+	}
+}
+
 func setupTracing() {
 	cfg := config.GetGlobalConfig()
 	tracingCfg := cfg.OpenTracing.ToTracingConfig()
@@ -689,6 +703,7 @@ func cleanup(svr *server.Server, storage kv.Storage, dom *domain.Domain, gracefu
 	closeDomainAndStorage(storage, dom)
 	disk.CleanUp()
 	topsql.Close()
+	stmtsummary.StmtSummaryByDigestMap.Close()
 }
 
 func stringToList(repairString string) []string {
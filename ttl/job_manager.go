@@ -0,0 +1,389 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/parser/terror"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/owner"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/store/helper"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/tikv/client-go/v2/tikv"
+	"go.uber.org/zap"
+)
+
+const (
+	// OwnerKey is the TTL owner path that is saved to etcd.
+	OwnerKey = "/tidb/ttl/owner"
+	// Prompt is the prompt for TTL owner manager.
+	Prompt = "ttl"
+)
+
+// JobInterval is the interval between two rounds of the TTL jobs.
+var JobInterval = time.Hour
+
+// The status of the TTL jobs in mysql.tidb_ttl_job_history.
+const (
+	jobStatusRunning   = "running"
+	jobStatusFinished  = "finished"
+	jobStatusFailed    = "failed"
+	jobStatusCancelled = "cancelled"
+)
+
+var errNotOwner = errors.New("TTL owner is changed")
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+// JobManager runs the TTL jobs of the tables. Only the TTL owner runs the jobs, a job scans the table by
+// the ranges of its regions in parallel, and deletes the expired rows in small batches.
+type JobManager struct {
+	store        kv.Storage
+	ownerManager owner.Manager
+	sessPool     sessionPool
+	infoSchema   func() infoschema.InfoSchema
+	limiter      rateLimiter
+}
+
+// NewJobManager creates a JobManager.
+func NewJobManager(store kv.Storage, ownerManager owner.Manager, sessPool sessionPool, infoSchema func() infoschema.InfoSchema) *JobManager {
+	return &JobManager{
+		store:        store,
+		ownerManager: ownerManager,
+		sessPool:     sessPool,
+		infoSchema:   infoSchema,
+	}
+}
+
+// RunJobs runs a round of the TTL jobs for all the tables with enabled TTL one by one.
+func (m *JobManager) RunJobs(ctx context.Context) error {
+	type ttlTable struct {
+		dbName  model.CIStr
+		tblInfo *model.TableInfo
+	}
+	var tables []ttlTable
+	is := m.infoSchema()
+	for _, db := range is.AllSchemas() {
+		for _, tbl := range is.SchemaTables(db.Name) {
+			if info := tbl.Meta().TTLInfo; info != nil && info.Enable {
+				tables = append(tables, ttlTable{dbName: db.Name, tblInfo: tbl.Meta()})
+			}
+		}
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].tblInfo.ID < tables[j].tblInfo.ID })
+
+	for _, tbl := range tables {
+		if !m.ownerManager.IsOwner() {
+			return errNotOwner
+		}
+		if err := m.runJob(ctx, tbl.dbName, tbl.tblInfo); err != nil {
+			logutil.BgLogger().Warn("[ttl] run TTL job failed", zap.String("table", tbl.tblInfo.Name.O), zap.Error(err))
+			if ctx.Err() != nil || errors.Cause(err) == errNotOwner {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// ttlJob is a round of deleting the expired rows of a table.
+type ttlJob struct {
+	id           int64
+	dbName       model.CIStr
+	tblInfo      *model.TableInfo
+	expireTime   string
+	handleColumn string
+	ranges       []scanRange
+
+	finishedRanges int64
+	deletedRows    int64
+	// progressMu makes the progress written to the history table one by one.
+	progressMu sync.Mutex
+}
+
+func (m *JobManager) runJob(ctx context.Context, dbName model.CIStr, tblInfo *model.TableInfo) error {
+	ttlInfo := tblInfo.TTLInfo
+	col := model.FindColumnInfo(tblInfo.Columns, ttlInfo.ColumnName.L)
+	if col == nil || col.State != model.StatePublic || !IsValidColumn(col) {
+		return errors.Errorf("invalid TTL column '%s'", ttlInfo.ColumnName.O)
+	}
+	job := &ttlJob{dbName: dbName, tblInfo: tblInfo}
+	err := m.withSession(func(se sqlexec.SQLExecutor) error {
+		rows, err := execute(ctx, se, fmt.Sprintf("SELECT NOW() - INTERVAL %d %s", ttlInfo.Interval, ast.TimeUnitType(ttlInfo.IntervalTimeUnit)))
+		if err != nil {
+			return err
+		}
+		job.expireTime = rows[0].GetTime(0).String()
+		job.handleColumn, job.ranges, err = m.splitScanRanges(tblInfo)
+		if err != nil {
+			return err
+		}
+		if _, err = execute(ctx, se, `INSERT INTO mysql.tidb_ttl_job_history
+			(table_id, table_schema, table_name, expire_time, total_ranges, status) VALUES (%?, %?, %?, %?, %?, %?)`,
+			tblInfo.ID, dbName.O, tblInfo.Name.O, job.expireTime, len(job.ranges), jobStatusRunning); err != nil {
+			return err
+		}
+		job.id = int64(se.(sessionctx.Context).GetSessionVars().StmtCtx.LastInsertID)
+		return nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logutil.BgLogger().Info("[ttl] start TTL job", zap.Int64("jobID", job.id), zap.String("table", tblInfo.Name.O),
+		zap.String("expireTime", job.expireTime), zap.Int("ranges", len(job.ranges)))
+
+	err = m.scanRanges(ctx, job)
+	status, summary := jobStatusFinished, ""
+	if err != nil {
+		status, summary = jobStatusFailed, err.Error()
+		if errors.Cause(err) == errNotOwner || ctx.Err() != nil {
+			status = jobStatusCancelled
+		}
+	}
+	err1 := m.withSession(func(se sqlexec.SQLExecutor) error {
+		_, err := execute(context.Background(), se, `UPDATE mysql.tidb_ttl_job_history SET status = %?, summary = %?,
+			finish_time = NOW(), finished_ranges = %?, deleted_rows = %? WHERE job_id = %?`,
+			status, summary, atomic.LoadInt64(&job.finishedRanges), atomic.LoadInt64(&job.deletedRows), job.id)
+		return err
+	})
+	logutil.BgLogger().Info("[ttl] finish TTL job", zap.Int64("jobID", job.id), zap.String("status", status),
+		zap.Int64("deletedRows", atomic.LoadInt64(&job.deletedRows)), zap.Error(err))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(err1)
+}
+
+// scanRanges deletes the expired rows in the ranges of the job with the scan workers.
+func (m *JobManager) scanRanges(ctx context.Context, job *ttlJob) error {
+	workerCnt := int(variable.TTLScanWorkerCount.Load())
+	if workerCnt > len(job.ranges) {
+		workerCnt = len(job.ranges)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	rangeCh := make(chan scanRange, len(job.ranges))
+	for _, r := range job.ranges {
+		rangeCh <- r
+	}
+	close(rangeCh)
+
+	var (
+		wg       sync.WaitGroup
+		errMu    sync.Mutex
+		firstErr error
+	)
+	for i := 0; i < workerCnt; i++ {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				wg.Done()
+				util.Recover(metrics.LabelDomain, "ttlScanWorker", nil, false)
+			}()
+			err := m.withSession(func(se sqlexec.SQLExecutor) error {
+				for r := range rangeCh {
+					if err := m.deleteExpiredRows(ctx, se, job, r); err != nil {
+						return err
+					}
+					m.updateProgress(ctx, se, job)
+				}
+				return nil
+			})
+			if err != nil {
+				errMu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				errMu.Unlock()
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Trace(firstErr)
+}
+
+// deleteExpiredRows deletes the expired rows in the range in batches.
+func (m *JobManager) deleteExpiredRows(ctx context.Context, se sqlexec.SQLExecutor, job *ttlJob, r scanRange) error {
+	var sb strings.Builder
+	sb.WriteString("DELETE FROM %n.%n WHERE %n < %?")
+	args := []interface{}{job.dbName.O, job.tblInfo.Name.O, job.tblInfo.TTLInfo.ColumnName.O, job.expireTime}
+	if r.start != nil {
+		sb.WriteString(" AND %n >= %?")
+		args = append(args, job.handleColumn, *r.start)
+	}
+	if r.end != nil {
+		sb.WriteString(" AND %n < %?")
+		args = append(args, job.handleColumn, *r.end)
+	}
+	sb.WriteString(" LIMIT %?")
+	for {
+		if !m.ownerManager.IsOwner() {
+			return errNotOwner
+		}
+		batchSize := variable.TTLDeleteBatchSize.Load()
+		if err := m.limiter.wait(ctx, batchSize, variable.TTLDeleteRateLimit.Load()); err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := execute(ctx, se, sb.String(), append(args, batchSize)...); err != nil {
+			return errors.Trace(err)
+		}
+		deleted := int64(se.(sessionctx.Context).GetSessionVars().StmtCtx.AffectedRows())
+		atomic.AddInt64(&job.deletedRows, deleted)
+		if deleted < batchSize {
+			return nil
+		}
+	}
+}
+
+// updateProgress writes the progress of the job after a range is done.
+func (m *JobManager) updateProgress(ctx context.Context, se sqlexec.SQLExecutor, job *ttlJob) {
+	job.progressMu.Lock()
+	defer job.progressMu.Unlock()
+	finished := atomic.AddInt64(&job.finishedRanges, 1)
+	_, err := execute(ctx, se, "UPDATE mysql.tidb_ttl_job_history SET finished_ranges = %?, deleted_rows = %? WHERE job_id = %?",
+		finished, atomic.LoadInt64(&job.deletedRows), job.id)
+	if err != nil {
+		logutil.BgLogger().Warn("[ttl] update the progress of TTL job failed", zap.Int64("jobID", job.id), zap.Error(err))
+	}
+}
+
+// scanRange is a range of the int handles, nil means unbounded.
+type scanRange struct {
+	start *int64
+	end   *int64
+}
+
+// splitScanRanges splits the table into the ranges of its regions. The ranges are split only for the
+// non-partitioned tables with signed int handles, other tables are scanned as a whole.
+func (m *JobManager) splitScanRanges(tblInfo *model.TableInfo) (string, []scanRange, error) {
+	wholeTable := []scanRange{{}}
+	s, ok := m.store.(helper.Storage)
+	if !ok || tblInfo.Partition != nil || tblInfo.IsCommonHandle {
+		return "", wholeTable, nil
+	}
+	handleColumn := model.ExtraHandleName.O
+	if tblInfo.PKIsHandle {
+		pkInfo := tblInfo.GetPkColInfo()
+		if mysql.HasUnsignedFlag(pkInfo.Flag) {
+			return "", wholeTable, nil
+		}
+		handleColumn = pkInfo.Name.O
+	}
+	startKey, endKey := tablecodec.GetTableHandleKeyRange(tblInfo.ID)
+	regions, err := s.GetRegionCache().LoadRegionsInKeyRange(tikv.NewBackofferWithVars(context.Background(), 20000, nil), startKey, endKey)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	recordPrefix := tablecodec.GenTableRecordPrefix(tblInfo.ID)
+	ranges := make([]scanRange, 0, len(regions))
+	for i, region := range regions {
+		var r scanRange
+		if i > 0 {
+			r.start = decodeHandleBound(region.StartKey(), recordPrefix)
+		}
+		if i < len(regions)-1 {
+			r.end = decodeHandleBound(region.EndKey(), recordPrefix)
+		}
+		ranges = append(ranges, r)
+	}
+	return handleColumn, ranges, nil
+}
+
+// decodeHandleBound decodes the int handle from the region boundary in the record range of the table.
+func decodeHandleBound(key, recordPrefix []byte) *int64 {
+	if !bytes.HasPrefix(key, recordPrefix) {
+		return nil
+	}
+	// The boundary may be a prefix of the record key, pad it like the smallest key with the prefix.
+	suffix := make([]byte, 8)
+	copy(suffix, key[len(recordPrefix):])
+	_, handle, err := codec.DecodeInt(suffix)
+	if err != nil {
+		return nil
+	}
+	return &handle
+}
+
+func (m *JobManager) withSession(fn func(se sqlexec.SQLExecutor) error) error {
+	res, err := m.sessPool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer m.sessPool.Put(res)
+	return fn(res.(sqlexec.SQLExecutor))
+}
+
+func execute(ctx context.Context, se sqlexec.SQLExecutor, sql string, args ...interface{}) ([]chunk.Row, error) {
+	rs, err := se.ExecuteInternal(ctx, sql, args...)
+	if err != nil || rs == nil {
+		return nil, errors.Trace(err)
+	}
+	defer terror.Call(rs.Close)
+	return sqlexec.DrainRecordSet(ctx, rs, 8)
+}
+
+// rateLimiter limits the rows deleted per second by the TTL jobs of a TiDB.
+type rateLimiter struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+// wait waits until n rows can be deleted under the limit, a non-positive limit means unlimited.
+func (l *rateLimiter) wait(ctx context.Context, n, limit int64) error {
+	if limit <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wakeAt := l.next
+	l.next = l.next.Add(time.Duration(n) * time.Second / time.Duration(limit))
+	l.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(wakeAt)):
+		return nil
+	}
+}
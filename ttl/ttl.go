@@ -0,0 +1,40 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ttl deletes the expired rows of the tables with TTL definitions in the background.
+package ttl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/mysql"
+)
+
+// DefinitionString returns the TTL definition of a table, in the form of `column` + INTERVAL n UNIT.
+func DefinitionString(info *model.TTLInfo) string {
+	return fmt.Sprintf("`%s` + INTERVAL %d %s", strings.ReplaceAll(info.ColumnName.O, "`", "``"), info.Interval,
+		ast.TimeUnitType(info.IntervalTimeUnit))
+}
+
+// IsValidColumn checks whether the column can be used to decide if a row is expired.
+func IsValidColumn(col *model.ColumnInfo) bool {
+	switch col.Tp {
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		return true
+	}
+	return false
+}
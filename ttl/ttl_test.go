@@ -0,0 +1,168 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttl_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/owner"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/ttl"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTLOptions(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrCode("create table t (id int, v int) ttl = v + interval 1 day", errno.ErrUnsupportedColumnInTTLConfig)
+	tk.MustGetErrCode("create table t (id int, created_at datetime) ttl = c + interval 1 day", errno.ErrKeyColumnDoesNotExits)
+	tk.MustGetErrCode("create table t (id int, created_at datetime) ttl = created_at + interval 0 day", errno.ErrInvalidTTLDefinition)
+	tk.MustGetErrCode("create table t (id int, created_at datetime) ttl = created_at + interval 1 day_hour", errno.ErrInvalidTTLDefinition)
+	tk.MustGetErrCode("create table t (id int, created_at datetime) ttl_enable = 'on'", errno.ErrSetTTLOptionForNonTTLTable)
+	tk.MustGetErrCode("create temporary table t (id int, created_at datetime) ttl = created_at + interval 1 day", errno.ErrOptOnTemporaryTable)
+
+	tk.MustExec("create table t (id int, created_at datetime) ttl = created_at + interval 30 day ttl_enable = 'off'")
+	showCreate := "%[1]s CREATE TABLE `%[1]s` (\n" +
+		"  `id` int(11) DEFAULT NULL,\n" +
+		"  `created_at` datetime DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL %[2]s */ /*T![ttl] TTL_ENABLE='%[3]s' */"
+	tk.MustQuery("show create table t").Check(testkit.Rows(fmt.Sprintf(showCreate, "t", "30 DAY", "OFF")))
+	// The TTL of the existing table is kept.
+	tk.MustExec("create table if not exists t (id int, created_at datetime) ttl = created_at + interval 1 hour")
+	tk.MustQuery("show create table t").Check(testkit.Rows(fmt.Sprintf(showCreate, "t", "30 DAY", "OFF")))
+
+	tk.MustExec("alter table t ttl_enable = 'on'")
+	tk.MustQuery("show create table t").Check(testkit.Rows(fmt.Sprintf(showCreate, "t", "30 DAY", "ON")))
+	tk.MustExec("alter table t ttl_enable = 'off' ttl = created_at + interval 1 year")
+	tk.MustQuery("show create table t").Check(testkit.Rows(fmt.Sprintf(showCreate, "t", "1 YEAR", "OFF")))
+	// Setting the TTL keeps it disabled.
+	tk.MustExec("alter table t ttl = created_at + interval 2 week")
+	tk.MustQuery("show create table t").Check(testkit.Rows(fmt.Sprintf(showCreate, "t", "2 WEEK", "OFF")))
+	tk.MustGetErrCode("alter table t ttl = id + interval 1 day", errno.ErrUnsupportedColumnInTTLConfig)
+	tk.MustGetErrCode("alter table t drop column created_at", errno.ErrTTLColumnCannotDrop)
+
+	// The TTL follows the renamed column, and the column can't be modified to a type the TTL doesn't support.
+	tk.MustGetErrCode("alter table t modify column created_at int", errno.ErrUnsupportedColumnInTTLConfig)
+	tk.MustGetErrCode("alter table t change column created_at c varchar(20)", errno.ErrUnsupportedColumnInTTLConfig)
+	tk.MustExec("alter table t rename column created_at to c")
+	tk.MustExec("alter table t change column c created_at timestamp null")
+	tk.MustExec("alter table t modify column created_at datetime")
+	tk.MustQuery("show create table t").Check(testkit.Rows(fmt.Sprintf(showCreate, "t", "2 WEEK", "OFF")))
+	tk.MustExec("alter table t rename column created_at to c")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) DEFAULT NULL,\n" +
+		"  `c` datetime DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`c` + INTERVAL 2 WEEK */ /*T![ttl] TTL_ENABLE='OFF' */"))
+	tk.MustExec("alter table t rename column c to created_at")
+
+	// The TTL is copied by CREATE TABLE ... LIKE, but not to the temporary tables.
+	tk.MustExec("create table t1 like t")
+	tk.MustQuery("show create table t1").Check(testkit.Rows(fmt.Sprintf(showCreate, "t1", "2 WEEK", "OFF")))
+	tk.MustExec("create temporary table t2 like t")
+	tk.MustQuery("show create table t2").Check(testkit.Rows("t2 CREATE TEMPORARY TABLE `t2` (\n" +
+		"  `id` int(11) DEFAULT NULL,\n" +
+		"  `created_at` datetime DEFAULT NULL\n" +
+		") ENGINE=memory DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	// The partitions can only be exchanged with the tables which have the same TTL.
+	tk.MustExec("set @@tidb_enable_exchange_partition = 1")
+	tk.MustExec("create table pt (id int, created_at datetime) partition by range (id) (partition p0 values less than (10))")
+	tk.MustExec("create table nt (id int, created_at datetime) ttl = created_at + interval 2 week ttl_enable = 'off'")
+	tk.MustGetErrCode("alter table pt exchange partition p0 with table nt", errno.ErrTablesDifferentMetadata)
+	tk.MustExec("alter table pt ttl = created_at + interval 2 week ttl_enable = 'off'")
+	tk.MustExec("alter table pt exchange partition p0 with table nt")
+	tk.MustQuery("show create table nt").Check(testkit.Rows(fmt.Sprintf(showCreate, "nt", "2 WEEK", "OFF")))
+
+	tk.MustExec("alter table t remove ttl")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) DEFAULT NULL,\n" +
+		"  `created_at` datetime DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustGetErrCode("alter table t ttl_enable = 'on'", errno.ErrSetTTLOptionForNonTTLTable)
+	tk.MustExec("alter table t drop column created_at")
+}
+
+func TestTTLJob(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, created_at datetime, v int)")
+
+	tk.MustExec("alter table t ttl = created_at + interval 1 day")
+
+	insertRows := func() {
+		for i := 0; i < 100; i++ {
+			if i%2 == 0 {
+				tk.MustExec("insert into t values (?, now() - interval 2 day, ?)", i, i)
+			} else {
+				tk.MustExec("insert into t values (?, now(), ?)", i, i)
+			}
+		}
+	}
+	insertRows()
+	tk.MustQuery("split table t by (25), (50), (75)").Check(testkit.Rows("3 1"))
+	tk.MustExec("set @@global.tidb_ttl_delete_batch_size = 7")
+	defer tk.MustExec("set @@global.tidb_ttl_delete_batch_size = default")
+
+	manager := ttl.NewJobManager(store, owner.NewMockManager(context.Background(), "ttl"), dom.SysSessionPool(), dom.InfoSchema)
+	// The TTL jobs are only run by the owner.
+	require.EqualError(t, manager.RunJobs(context.Background()), "TTL owner is changed")
+	tk.MustQuery("select count(*) from mysql.tidb_ttl_job_history").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("100"))
+
+	ownerManager := owner.NewMockManager(context.Background(), "ttl")
+	require.NoError(t, ownerManager.CampaignOwner())
+	manager = ttl.NewJobManager(store, ownerManager, dom.SysSessionPool(), dom.InfoSchema)
+	require.NoError(t, manager.RunJobs(context.Background()))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("50"))
+	tk.MustQuery("select count(*) from t where id % 2 = 0").Check(testkit.Rows("0"))
+	tk.MustQuery("select table_schema, table_name, total_ranges, finished_ranges, deleted_rows, status from mysql.tidb_ttl_job_history").
+		Check(testkit.Rows("test t 4 4 50 finished"))
+
+	// The TTL definition is kept after the table is truncated.
+	tk.MustExec("truncate table t")
+	insertRows()
+	require.NoError(t, manager.RunJobs(context.Background()))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("50"))
+
+	// The TTL definition follows the renamed column.
+	tk.MustExec("alter table t rename column created_at to c")
+	tk.MustExec("delete from t")
+	for i := 0; i < 10; i++ {
+		tk.MustExec("insert into t values (?, now() - interval 2 day, ?)", i, i)
+	}
+	require.NoError(t, manager.RunJobs(context.Background()))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("0"))
+	tk.MustExec("alter table t rename column c to created_at")
+
+	// The expired rows are kept if the TTL is disabled or removed.
+	tk.MustExec("delete from t")
+	insertRows()
+	tk.MustExec("alter table t ttl_enable = 'off'")
+	require.NoError(t, manager.RunJobs(context.Background()))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("100"))
+	tk.MustExec("alter table t remove ttl")
+	require.NoError(t, manager.RunJobs(context.Background()))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("100"))
+	tk.MustQuery("select count(*) from mysql.tidb_ttl_job_history").Check(testkit.Rows("3"))
+	tk.MustExec("alter table t drop column created_at")
+}
@@ -22,6 +22,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/parser/mysql"
@@ -754,3 +755,34 @@ func ToString(value interface{}) (string, error) {
 		return "", errors.Errorf("cannot convert %v(type %T) to string", value, value)
 	}
 }
+
+// ConvertJSONToArrayElement converts an element of a JSON array to a value of the element type of tp, which is
+// the type of a `CAST(... AS type ARRAY)` expression. Only JSON numbers and strings can be converted, and the
+// conversion is strict: an invalid or truncated value is an error.
+func ConvertJSONToArrayElement(j json.BinaryJSON, tp *FieldType) (Datum, error) {
+	var d Datum
+	switch j.TypeCode {
+	case json.TypeCodeInt64:
+		d.SetInt64(j.GetInt64())
+	case json.TypeCodeUint64:
+		d.SetUint64(j.GetUint64())
+	case json.TypeCodeFloat64:
+		d.SetFloat64(j.GetFloat64())
+	case json.TypeCodeString:
+		d.SetString(string(j.GetString()), tp.Collate)
+	default:
+		return d, ErrTruncatedWrongVal.GenWithStackByArgs(tp.CompactStr(), j.String())
+	}
+	elemTp := tp.Clone()
+	elemTp.Array = false
+	// The element is converted as if it's inserted into a column of the element type.
+	sc := &stmtctx.StatementContext{TimeZone: time.UTC, InInsertStmt: true}
+	res, err := d.ConvertTo(sc, elemTp)
+	if err != nil {
+		return res, errors.Trace(err)
+	}
+	if sc.WarningCount() > 0 {
+		return res, errors.Trace(sc.GetWarnings()[0].Err)
+	}
+	return res, nil
+}
@@ -1204,3 +1204,43 @@ func TestConvertDecimalStrToUint(t *testing.T) {
 		}
 	}
 }
+
+func TestConvertJSONToArrayElement(t *testing.T) {
+	t.Parallel()
+
+	unsignedTp := NewFieldType(mysql.TypeLonglong)
+	unsignedTp.Flag |= mysql.UnsignedFlag
+	unsignedTp.Array = true
+	charTp := NewFieldType(mysql.TypeVarString)
+	charTp.Flen, charTp.Charset, charTp.Collate = 3, charset.CharsetUTF8MB4, charset.CollationUTF8MB4
+	charTp.Array = true
+
+	cases := []struct {
+		input  string
+		tp     *FieldType
+		result interface{}
+		succ   bool
+	}{
+		{`1`, unsignedTp, uint64(1), true},
+		{`"12"`, unsignedTp, uint64(12), true},
+		{`-1`, unsignedTp, nil, false},
+		{`"x"`, unsignedTp, nil, false},
+		{`[1]`, unsignedTp, nil, false},
+		{`null`, unsignedTp, nil, false},
+		{`"abc"`, charTp, "abc", true},
+		{`12`, charTp, "12", true},
+		{`"abcd"`, charTp, nil, false},
+		{`{"a": 1}`, charTp, nil, false},
+	}
+	for _, ca := range cases {
+		j, err := json.ParseBinaryFromString(ca.input)
+		require.NoError(t, err)
+		d, err := ConvertJSONToArrayElement(j, ca.tp)
+		if !ca.succ {
+			require.Error(t, err, ca.input)
+		} else {
+			require.NoError(t, err, ca.input)
+			require.Equal(t, ca.result, d.GetValue(), ca.input)
+		}
+	}
+}
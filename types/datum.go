@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/parser/types"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/hack"
 )
 
@@ -55,6 +56,7 @@ const (
 	KindMaxValue      byte = 16
 	KindRaw           byte = 17
 	KindMysqlJSON     byte = 18
+	KindMysqlGeometry byte = 19 // Used for spatial column values, which are in the storage format of MySQL.
 )
 
 // Datum is a data box holds different kind of data.
@@ -343,6 +345,13 @@ func (d *Datum) SetMysqlJSON(b json.BinaryJSON) {
 	d.b = b.Value
 }
 
+// SetMysqlGeometry sets the geometry value, which is in the storage format of MySQL, see spatial.Encode.
+func (d *Datum) SetMysqlGeometry(b []byte) {
+	d.k = KindMysqlGeometry
+	d.b = b
+	d.collation = charset.CollationBin
+}
+
 // GetMysqlTime gets types.Time value
 func (d *Datum) GetMysqlTime() Time {
 	return d.x.(Time)
@@ -406,6 +415,8 @@ func (d Datum) String() string {
 		t = "KindMysqlSet"
 	case KindMysqlJSON:
 		t = "KindMysqlJSON"
+	case KindMysqlGeometry:
+		t = "KindMysqlGeometry"
 	case KindMysqlTime:
 		t = "KindMysqlTime"
 	default:
@@ -431,7 +442,7 @@ func (d *Datum) GetValue() interface{} {
 		return d.GetFloat64()
 	case KindString:
 		return d.GetString()
-	case KindBytes:
+	case KindBytes, KindMysqlGeometry:
 		return d.GetBytes()
 	case KindMysqlDecimal:
 		return d.GetMysqlDecimal()
@@ -581,7 +592,7 @@ func (d *Datum) CompareDatum(sc *stmtctx.StatementContext, ad *Datum) (int, erro
 		return d.compareFloat64(sc, ad.GetFloat64())
 	case KindString:
 		return d.compareString(sc, ad.GetString(), d.collation)
-	case KindBytes:
+	case KindBytes, KindMysqlGeometry:
 		return d.compareBytes(sc, ad.GetBytes())
 	case KindMysqlDecimal:
 		return d.compareMysqlDecimal(sc, ad.GetMysqlDecimal())
@@ -679,7 +690,7 @@ func (d *Datum) compareString(sc *stmtctx.StatementContext, s string, retCollati
 		return -1, nil
 	case KindMaxValue:
 		return 1, nil
-	case KindString, KindBytes:
+	case KindString, KindBytes, KindMysqlGeometry:
 		return CompareString(d.GetString(), s, d.collation), nil
 	case KindMysqlDecimal:
 		dec := new(MyDecimal)
@@ -828,6 +839,10 @@ func (d *Datum) ConvertTo(sc *stmtctx.StatementContext, target *FieldType) (Datu
 	if d.k == KindNull {
 		return Datum{}, nil
 	}
+	if target.Array {
+		// The value of a CAST(... ARRAY) column is a JSON array, its elements are converted when they're indexed.
+		return d.convertToMysqlJSON(sc, target)
+	}
 	switch target.Tp { // TODO: implement mysql types convert when "CAST() AS" syntax are supported.
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
 		unsigned := mysql.HasUnsignedFlag(target.Flag)
@@ -858,6 +873,8 @@ func (d *Datum) ConvertTo(sc *stmtctx.StatementContext, target *FieldType) (Datu
 		return d.convertToMysqlSet(sc, target)
 	case mysql.TypeJSON:
 		return d.convertToMysqlJSON(sc, target)
+	case mysql.TypeGeometry:
+		return d.convertToMysqlGeometry(sc, target)
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -947,7 +964,7 @@ func (d *Datum) convertToString(sc *stmtctx.StatementContext, target *FieldType)
 		s = strconv.FormatFloat(d.GetFloat64(), 'f', -1, 32)
 	case KindFloat64:
 		s = strconv.FormatFloat(d.GetFloat64(), 'f', -1, 64)
-	case KindString, KindBytes:
+	case KindString, KindBytes, KindMysqlGeometry:
 		s = d.GetString()
 	case KindMysqlTime:
 		s = d.GetMysqlTime().String()
@@ -1578,6 +1595,28 @@ func (d *Datum) convertToMysqlJSON(sc *stmtctx.StatementContext, target *FieldTy
 	return ret, errors.Trace(err)
 }
 
+// convertToMysqlGeometry checks the value is a geometry in the storage format of MySQL, whose type is allowed by
+// the target, the value isn't converted.
+func (d *Datum) convertToMysqlGeometry(sc *stmtctx.StatementContext, target *FieldType) (ret Datum, err error) {
+	switch d.k {
+	case KindString, KindBytes, KindMysqlGeometry:
+	default:
+		return ret, ErrCantCreateGeometryObject
+	}
+	g, err := spatial.Decode(d.GetBytes())
+	if err != nil {
+		if spatial.IsInvalidGeometry(err) {
+			return ret, ErrCantCreateGeometryObject
+		}
+		return ret, errors.Trace(err)
+	}
+	if target.GeomType != mysql.GeomTypeGeometry && byte(g.Type) != target.GeomType {
+		return ret, ErrCantCreateGeometryObject
+	}
+	ret.SetMysqlGeometry(d.GetBytes())
+	return ret, nil
+}
+
 // ToBool converts to a bool.
 // We will use 1 for true, and 0 for false.
 func (d *Datum) ToBool(sc *stmtctx.StatementContext) (int64, error) {
@@ -1815,7 +1854,7 @@ func (d *Datum) ToString() (string, error) {
 		return strconv.FormatFloat(d.GetFloat64(), 'f', -1, 64), nil
 	case KindString:
 		return d.GetString(), nil
-	case KindBytes:
+	case KindBytes, KindMysqlGeometry:
 		return d.GetString(), nil
 	case KindMysqlTime:
 		return d.GetMysqlTime().String(), nil
@@ -1841,7 +1880,7 @@ func (d *Datum) ToString() (string, error) {
 // ToBytes gets the bytes representation of the datum.
 func (d *Datum) ToBytes() ([]byte, error) {
 	switch d.k {
-	case KindString, KindBytes:
+	case KindString, KindBytes, KindMysqlGeometry:
 		return d.GetBytes(), nil
 	default:
 		str, err := d.ToString()
@@ -1966,6 +2005,12 @@ func NewJSONDatum(j json.BinaryJSON) (d Datum) {
 	return d
 }
 
+// NewMysqlGeometryDatum creates a new Datum from a geometry value in the storage format of MySQL.
+func NewMysqlGeometryDatum(b []byte) (d Datum) {
+	d.SetMysqlGeometry(b)
+	return d
+}
+
 // NewBinaryLiteralDatum creates a new BinaryLiteral Datum for a BinaryLiteral value.
 func NewBinaryLiteralDatum(b BinaryLiteral) (d Datum) {
 	d.SetBinaryLiteral(b)
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/stretchr/testify/require"
 )
@@ -536,6 +537,42 @@ func TestStringToMysqlBit(t *testing.T) {
 	}
 }
 
+func TestConvertToMysqlGeometry(t *testing.T) {
+	t.Parallel()
+	point, err := spatial.ParseWKT("POINT(1 2)", 0)
+	require.NoError(t, err)
+	line, err := spatial.ParseWKT("LINESTRING(0 0,1 1)", 0)
+	require.NoError(t, err)
+
+	sc := new(stmtctx.StatementContext)
+	geomTp := NewFieldType(mysql.TypeGeometry)
+	pointTp := NewFieldType(mysql.TypeGeometry)
+	pointTp.GeomType = mysql.GeomTypePoint
+
+	tests := []struct {
+		in  Datum
+		tp  *FieldType
+		err bool
+	}{
+		{NewBytesDatum(spatial.Encode(point)), pointTp, false},
+		{NewBytesDatum(spatial.Encode(line)), geomTp, false},
+		{NewMysqlGeometryDatum(spatial.Encode(point)), geomTp, false},
+		{NewBytesDatum(spatial.Encode(line)), pointTp, true},
+		{NewStringDatum("abc"), geomTp, true},
+		{NewIntDatum(1), geomTp, true},
+	}
+	for _, tt := range tests {
+		d, err := tt.in.ConvertTo(sc, tt.tp)
+		if tt.err {
+			require.True(t, ErrCantCreateGeometryObject.Equal(err))
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, KindMysqlGeometry, d.Kind())
+		require.Equal(t, tt.in.GetBytes(), d.GetBytes())
+	}
+}
+
 func BenchmarkCompareDatum(b *testing.B) {
 	vals, vals1 := prepareCompareDatums()
 	sc := new(stmtctx.StatementContext)
@@ -108,10 +108,12 @@ func IsNonBinaryStr(ft *FieldType) bool {
 
 // NeedRestoredData returns if a type needs restored data.
 // If the type is char and the collation is _bin, NeedRestoredData() returns false.
+// If the key is the original string under the collation, NeedRestoredData() returns false.
 func NeedRestoredData(ft *FieldType) bool {
 	if collate.NewCollationEnabled() &&
 		IsNonBinaryStr(ft) &&
-		!(collate.IsBinCollation(ft.Collate) && !IsTypeVarchar(ft.Tp)) {
+		!(collate.IsBinCollation(ft.Collate) && !IsTypeVarchar(ft.Tp)) &&
+		!collate.CanUseRawMemAsKey(collate.GetCollator(ft.Collate)) {
 		return true
 	}
 	return false
@@ -143,6 +145,7 @@ var kind2Str = map[byte]string{
 	KindMaxValue:      "max_value",
 	KindRaw:           "raw",
 	KindMysqlJSON:     "json",
+	KindMysqlGeometry: "geometry",
 }
 
 // TypeStr converts tp to a string.
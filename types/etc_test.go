@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/parser/charset"
 	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/parser/terror"
+	"github.com/pingcap/tidb/util/collate"
 	"github.com/stretchr/testify/require"
 )
 
@@ -337,3 +338,28 @@ func TestIsTypeNumeric(t *testing.T) {
 	res = IsTypeNumeric('t')
 	require.False(t, res)
 }
+
+func TestNeedRestoredData(t *testing.T) {
+	collate.SetNewCollationEnabledForTest(true)
+	defer collate.SetNewCollationEnabledForTest(false)
+
+	tests := []struct {
+		tp      byte
+		collate string
+		expect  bool
+	}{
+		{mysql.TypeString, "utf8mb4_bin", false},
+		{mysql.TypeVarchar, "utf8mb4_bin", true},
+		{mysql.TypeString, "utf8mb4_general_ci", true},
+		{mysql.TypeVarchar, "utf8mb4_0900_ai_ci", true},
+		{mysql.TypeString, "utf8mb4_0900_bin", false},
+		{mysql.TypeVarchar, "utf8mb4_0900_bin", false},
+		{mysql.TypeVarchar, charset.CollationBin, false},
+		{mysql.TypeLonglong, charset.CollationBin, false},
+	}
+	for _, tt := range tests {
+		ft := NewFieldType(tt.tp)
+		ft.Collate = tt.collate
+		require.Equal(t, tt.expect, NeedRestoredData(ft), "%d %s", tt.tp, tt.collate)
+	}
+}
@@ -228,6 +228,12 @@ func (bj BinaryJSON) Extract(pathExprList []PathExpression) (ret BinaryJSON, fou
 	return
 }
 
+// ExtractAll returns all the values matched by pathExpr in bj, in the order of the document.
+// Unlike Extract, a single match is not distinguished from an array of matches.
+func (bj BinaryJSON) ExtractAll(pathExpr PathExpression) []BinaryJSON {
+	return bj.extractTo(nil, pathExpr)
+}
+
 func (bj BinaryJSON) extractTo(buf []BinaryJSON, pathExpr PathExpression) []BinaryJSON {
 	if len(pathExpr.legs) == 0 {
 		return append(buf, bj)
@@ -1021,6 +1027,74 @@ func ContainsBinary(obj, target BinaryJSON) bool {
 	}
 }
 
+// OverlapsBinary is to implement JSON_OVERLAPS. Two arrays overlap if they have a common element, an array and a
+// non-array value overlap if the value is an element of the array, two objects overlap if they have a common
+// key-value pair, and the other values overlap if they're equal.
+func OverlapsBinary(left, right BinaryJSON) bool {
+	if left.TypeCode != TypeCodeArray && right.TypeCode == TypeCodeArray {
+		left, right = right, left
+	}
+	switch left.TypeCode {
+	case TypeCodeArray:
+		if right.TypeCode == TypeCodeArray {
+			for i := 0; i < right.GetElemCount(); i++ {
+				if arrayHasElem(left, right.arrayGetElem(i)) {
+					return true
+				}
+			}
+			return false
+		}
+		return arrayHasElem(left, right)
+	case TypeCodeObject:
+		if right.TypeCode != TypeCodeObject {
+			return false
+		}
+		for i := 0; i < right.GetElemCount(); i++ {
+			if val, exists := left.objectSearchKey(right.objectGetKey(i)); exists && CompareBinary(val, right.objectGetVal(i)) == 0 {
+				return true
+			}
+		}
+		return false
+	default:
+		return CompareBinary(left, right) == 0
+	}
+}
+
+// MemberOfBinary is to implement `value MEMBER OF(json_array)`. The value is compared with the elements of the
+// array, a non-array is regarded as an array with a single element.
+func MemberOfBinary(target, obj BinaryJSON) bool {
+	if obj.TypeCode != TypeCodeArray {
+		return CompareBinary(obj, target) == 0
+	}
+	return arrayHasElem(obj, target)
+}
+
+// ArrayElems returns the elements of an array, a non-array is regarded as an array with a single element.
+func (bj BinaryJSON) ArrayElems() []BinaryJSON {
+	if bj.TypeCode != TypeCodeArray {
+		return []BinaryJSON{bj}
+	}
+	elems := make([]BinaryJSON, 0, bj.GetElemCount())
+	for i := 0; i < bj.GetElemCount(); i++ {
+		elems = append(elems, bj.arrayGetElem(i))
+	}
+	return elems
+}
+
+// CreateBinaryArray creates a BinaryJSON array of elems.
+func CreateBinaryArray(elems []BinaryJSON) BinaryJSON {
+	return buildBinaryArray(elems)
+}
+
+func arrayHasElem(array, elem BinaryJSON) bool {
+	for i := 0; i < array.GetElemCount(); i++ {
+		if CompareBinary(array.arrayGetElem(i), elem) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // GetElemDepth for JSON_DEPTH
 // Returns the maximum depth of a JSON document
 // rules referenced by MySQL JSON_DEPTH function
@@ -85,6 +85,35 @@ func TestBinaryJSONExtract(t *testing.T) {
 	}
 }
 
+func TestBinaryJSONExtractAll(t *testing.T) {
+	t.Parallel()
+
+	bj := mustParseBinaryFromString(t, `{"a": [1, [2, 3], {"aa": "bb"}], "b": true}`)
+
+	var tests = []struct {
+		pathExpr string
+		expected []string
+	}{
+		{"$.a", []string{`[1, [2, 3], {"aa": "bb"}]`}},
+		{"$.a[*]", []string{`1`, `[2, 3]`, `{"aa": "bb"}`}},
+		{"$.a[1]", []string{`[2, 3]`}},
+		{"$.a[1][*]", []string{`2`, `3`}},
+		{"$.*", []string{`[1, [2, 3], {"aa": "bb"}]`, `true`}},
+		{"$.c", nil},
+		{"$.a[5]", nil},
+	}
+
+	for _, test := range tests {
+		pe, err := ParseJSONPathExpr(test.pathExpr)
+		require.NoError(t, err)
+		result := bj.ExtractAll(pe)
+		require.Len(t, result, len(test.expected), test.pathExpr)
+		for i, expected := range test.expected {
+			require.Equal(t, mustParseBinaryFromString(t, expected).String(), result[i].String())
+		}
+	}
+}
+
 func TestBinaryJSONType(t *testing.T) {
 	t.Parallel()
 
@@ -367,6 +396,80 @@ func BenchmarkBinaryMarshal(b *testing.B) {
 	}
 }
 
+func TestBinaryJSONOverlaps(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		left     string
+		right    string
+		expected bool
+	}{
+		{`[1,3,5,7]`, `[2,5,7]`, true},
+		{`[1,3,5,7]`, `[2,6,8]`, false},
+		{`[[1,2],[3,4]]`, `[1,2]`, false},
+		{`[[1,2],[3,4]]`, `[[1,2]]`, true},
+		{`[1,2]`, `1`, true},
+		{`1`, `[1,2]`, true},
+		{`[1,2]`, `"1"`, false},
+		{`{"a":1,"b":10,"d":10}`, `{"c":1,"e":10,"f":1,"d":10}`, true},
+		{`{"a":1,"b":10,"d":10}`, `{"a":5,"e":10,"f":1,"d":20}`, false},
+		{`{"a":1}`, `1`, false},
+		{`[{"a":1}]`, `{"a":1}`, true},
+		{`5`, `5`, true},
+		{`5`, `6`, false},
+		{`[]`, `[]`, false},
+	}
+
+	for _, test := range tests {
+		left := mustParseBinaryFromString(t, test.left)
+		right := mustParseBinaryFromString(t, test.right)
+		require.Equal(t, test.expected, OverlapsBinary(left, right), "%s %s", test.left, test.right)
+	}
+}
+
+func TestBinaryJSONMemberOf(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		target   string
+		obj      string
+		expected bool
+	}{
+		{`17`, `[23,"abc",17,"ab",10]`, true},
+		{`"ab"`, `[23,"abc",17,"ab",10]`, true},
+		{`"a"`, `[23,"abc",17,"ab",10]`, false},
+		{`[4,5]`, `[23,[4,5],17]`, true},
+		{`4`, `[23,[4,5],17]`, false},
+		{`{"a":1}`, `{"a":1}`, true},
+		{`1`, `1`, true},
+	}
+
+	for _, test := range tests {
+		target := mustParseBinaryFromString(t, test.target)
+		obj := mustParseBinaryFromString(t, test.obj)
+		require.Equal(t, test.expected, MemberOfBinary(target, obj), "%s %s", test.target, test.obj)
+	}
+}
+
+func TestBinaryJSONArrayElems(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected string
+	}{
+		{`[1, "a", [2], {"b": 3}]`, `[1, "a", [2], {"b": 3}]`},
+		{`[]`, `[]`},
+		{`1`, `[1]`},
+		{`{"a": 1}`, `[{"a": 1}]`},
+	}
+
+	for _, test := range tests {
+		bj := mustParseBinaryFromString(t, test.input)
+		require.Equal(t, test.expected, CreateBinaryArray(bj.ArrayElems()).String(), test.input)
+	}
+}
+
 func TestBinaryJSONContains(t *testing.T) {
 	t.Parallel()
 
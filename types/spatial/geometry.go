@@ -0,0 +1,171 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"errors"
+
+	mysql "github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+// Type is the geometry type, the values are the type codes of WKB.
+type Type uint32
+
+const (
+	// TypePoint indicates the geometry is a Point.
+	TypePoint Type = 1
+	// TypeLineString indicates the geometry is a LineString.
+	TypeLineString Type = 2
+	// TypePolygon indicates the geometry is a Polygon.
+	TypePolygon Type = 3
+	// TypeMultiPoint indicates the geometry is a MultiPoint.
+	TypeMultiPoint Type = 4
+	// TypeMultiLineString indicates the geometry is a MultiLineString.
+	TypeMultiLineString Type = 5
+	// TypeMultiPolygon indicates the geometry is a MultiPolygon.
+	TypeMultiPolygon Type = 6
+	// TypeGeometryCollection indicates the geometry is a GeometryCollection.
+	TypeGeometryCollection Type = 7
+)
+
+// String returns the name of the type, which is the result of ST_GeometryType.
+func (t Type) String() string {
+	switch t {
+	case TypePoint:
+		return "POINT"
+	case TypeLineString:
+		return "LINESTRING"
+	case TypePolygon:
+		return "POLYGON"
+	case TypeMultiPoint:
+		return "MULTIPOINT"
+	case TypeMultiLineString:
+		return "MULTILINESTRING"
+	case TypeMultiPolygon:
+		return "MULTIPOLYGON"
+	case TypeGeometryCollection:
+		return "GEOMCOLLECTION"
+	}
+	return "UNKNOWN"
+}
+
+var (
+	// ErrGISInvalidData means the geometry value, WKT or WKB given to a function is invalid.
+	ErrGISInvalidData = dbterror.ClassTypes.NewStd(mysql.ErrGISInvalidData)
+	// ErrSRSNotFound means the SRID isn't supported, only SRID 0 (the Cartesian plane) is supported now.
+	ErrSRSNotFound = dbterror.ClassTypes.NewStd(mysql.ErrSRSNotFound)
+
+	// errInvalidGeometry is returned when decoding or parsing fails, the functions report it as
+	// ErrGISInvalidData with their names.
+	errInvalidGeometry = errors.New("invalid geometry")
+)
+
+// IsInvalidGeometry returns whether the error is returned because the WKT, WKB or geometry value is malformed.
+func IsInvalidGeometry(err error) bool {
+	return err == errInvalidGeometry
+}
+
+// CheckSRID returns ErrSRSNotFound if the SRID isn't supported.
+func CheckSRID(srid uint32) error {
+	if srid != 0 {
+		return ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	return nil
+}
+
+// Point is a point in the Cartesian plane.
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Geometry is a geometry value. Which fields are used depends on the type.
+type Geometry struct {
+	SRID uint32
+	Type Type
+	// Points are the point of a Point, or the points of a LineString or MultiPoint.
+	Points []Point
+	// Rings are the rings of a Polygon, the first one is the exterior ring.
+	Rings [][]Point
+	// Geometries are the members of a MultiLineString, MultiPolygon or GeometryCollection.
+	Geometries []*Geometry
+}
+
+// IsEmpty returns whether the geometry has no points, only a GeometryCollection can be empty.
+func (g *Geometry) IsEmpty() bool {
+	switch g.Type {
+	case TypeGeometryCollection:
+		for _, member := range g.Geometries {
+			if !member.IsEmpty() {
+				return false
+			}
+		}
+		return true
+	case TypeMultiLineString, TypeMultiPolygon:
+		return len(g.Geometries) == 0
+	case TypePolygon:
+		return len(g.Rings) == 0
+	}
+	return len(g.Points) == 0
+}
+
+// validate checks the constraints which the WKT and WKB syntax can't express.
+func (g *Geometry) validate() error {
+	switch g.Type {
+	case TypePoint:
+		if len(g.Points) != 1 {
+			return errInvalidGeometry
+		}
+	case TypeLineString:
+		if len(g.Points) < 2 {
+			return errInvalidGeometry
+		}
+	case TypePolygon:
+		if len(g.Rings) == 0 {
+			return errInvalidGeometry
+		}
+		for _, ring := range g.Rings {
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+				return errInvalidGeometry
+			}
+		}
+	case TypeMultiPoint:
+		if len(g.Points) == 0 {
+			return errInvalidGeometry
+		}
+	case TypeMultiLineString, TypeMultiPolygon:
+		if len(g.Geometries) == 0 {
+			return errInvalidGeometry
+		}
+	}
+	for _, p := range g.Points {
+		if !isFinite(p.X) || !isFinite(p.Y) {
+			return errInvalidGeometry
+		}
+	}
+	for _, ring := range g.Rings {
+		for _, p := range ring {
+			if !isFinite(p.X) || !isFinite(p.Y) {
+				return errInvalidGeometry
+			}
+		}
+	}
+	return nil
+}
+
+func isFinite(f float64) bool {
+	return f-f == 0
+}
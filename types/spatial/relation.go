@@ -0,0 +1,493 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"math"
+	"sort"
+)
+
+/*
+	The relations are computed in the Cartesian plane (SRID 0). A geometry is decomposed into its points, its line
+	segments and its polygons, and the relations of two geometries are derived from the relations of these parts.
+
+	To test whether a line segment is covered by a geometry, the segment is split at the points where it meets the
+	boundaries of the geometry; each piece is then either totally covered or totally uncovered, so testing its midpoint
+	is enough. The areas of polygons are tested by points slightly offset from the pieces of their rings.
+
+	The callers must not pass empty geometries, the functions return NULL for them.
+*/
+
+// Intersects returns whether the two geometries have at least one point in common.
+func Intersects(a, b *Geometry) bool {
+	ca, cb := decompose(a), decompose(b)
+	for _, p := range cb.points {
+		if ca.covers(p) {
+			return true
+		}
+	}
+	for _, s := range cb.segments {
+		if ca.intersectsSegment(s) {
+			return true
+		}
+	}
+	for _, poly := range cb.polygons {
+		for _, ring := range poly {
+			for i := 1; i < len(ring); i++ {
+				if ca.intersectsSegment(segment{ring[i-1], ring[i]}) {
+					return true
+				}
+			}
+		}
+		// a may lie inside the polygon without touching its rings.
+		for _, p := range ca.points {
+			if pointInPolygon(p, poly) != outside {
+				return true
+			}
+		}
+		for _, s := range ca.segments {
+			if pointInPolygon(s[0], poly) != outside {
+				return true
+			}
+		}
+		for _, polyA := range ca.polygons {
+			if pointInPolygon(polyA[0][0], poly) != outside {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Disjoint returns whether the two geometries have no point in common.
+func Disjoint(a, b *Geometry) bool {
+	return !Intersects(a, b)
+}
+
+// Contains returns whether no point of b lies outside a, and at least one point of the interior of b lies in the
+// interior of a. For example, a polygon doesn't contain a point on its boundary.
+func Contains(a, b *Geometry) bool {
+	ca, cb := decompose(a), decompose(b)
+	if !ca.coversAll(cb) {
+		return false
+	}
+	for _, p := range cb.interiorSamples(ca) {
+		if ca.interiorContains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Within returns whether a is within b, which means b contains a.
+func Within(a, b *Geometry) bool {
+	return Contains(b, a)
+}
+
+// Equals returns whether the two geometries are spatially equal, that is they cover each other.
+func Equals(a, b *Geometry) bool {
+	ca, cb := decompose(a), decompose(b)
+	return ca.coversAll(cb) && cb.coversAll(ca)
+}
+
+// Distance returns the minimum Cartesian distance between the two geometries, it is 0 if they intersect.
+func Distance(a, b *Geometry) float64 {
+	if Intersects(a, b) {
+		return 0
+	}
+	ca, cb := decompose(a), decompose(b)
+	sa, sb := ca.allSegments(), cb.allSegments()
+	dist := math.Inf(1)
+	for _, p := range ca.points {
+		for _, q := range cb.points {
+			dist = math.Min(dist, p.distance(q))
+		}
+		for _, s := range sb {
+			dist = math.Min(dist, s.distance(p))
+		}
+	}
+	for _, s := range sa {
+		for _, q := range cb.points {
+			dist = math.Min(dist, s.distance(q))
+		}
+		for _, t := range sb {
+			// The segments don't intersect, so the minimum is reached at an endpoint.
+			dist = math.Min(dist, math.Min(math.Min(s.distance(t[0]), s.distance(t[1])),
+				math.Min(t.distance(s[0]), t.distance(s[1]))))
+		}
+	}
+	return dist
+}
+
+type segment [2]Point
+
+type location int
+
+const (
+	outside location = iota
+	boundary
+	inside
+)
+
+// components are the parts of a geometry.
+type components struct {
+	points   []Point
+	segments []segment
+	polygons [][][]Point
+	// lineEnds counts the endpoints of the LineStrings, the points counted odd times are the boundary of them.
+	lineEnds map[Point]int
+}
+
+func decompose(g *Geometry) *components {
+	c := &components{lineEnds: make(map[Point]int)}
+	c.add(g)
+	return c
+}
+
+func (c *components) add(g *Geometry) {
+	switch g.Type {
+	case TypePoint, TypeMultiPoint:
+		c.points = append(c.points, g.Points...)
+	case TypeLineString:
+		for i := 1; i < len(g.Points); i++ {
+			c.segments = append(c.segments, segment{g.Points[i-1], g.Points[i]})
+		}
+		if first, last := g.Points[0], g.Points[len(g.Points)-1]; first != last {
+			c.lineEnds[first]++
+			c.lineEnds[last]++
+		}
+	case TypePolygon:
+		c.polygons = append(c.polygons, g.Rings)
+	default:
+		for _, member := range g.Geometries {
+			c.add(member)
+		}
+	}
+}
+
+// allSegments returns the segments of the LineStrings and the rings.
+func (c *components) allSegments() []segment {
+	segments := append([]segment(nil), c.segments...)
+	for _, poly := range c.polygons {
+		for _, ring := range poly {
+			for i := 1; i < len(ring); i++ {
+				segments = append(segments, segment{ring[i-1], ring[i]})
+			}
+		}
+	}
+	return segments
+}
+
+// covers returns whether the point lies in the geometry, including its boundary.
+func (c *components) covers(p Point) bool {
+	for _, q := range c.points {
+		if p == q {
+			return true
+		}
+	}
+	for _, s := range c.segments {
+		if s.contains(p) {
+			return true
+		}
+	}
+	for _, poly := range c.polygons {
+		if pointInPolygon(p, poly) != outside {
+			return true
+		}
+	}
+	return false
+}
+
+// interiorContains returns whether the point lies in the interior of the geometry.
+func (c *components) interiorContains(p Point) bool {
+	for _, q := range c.points {
+		if p == q {
+			return true
+		}
+	}
+	for _, s := range c.segments {
+		if s.contains(p) && c.lineEnds[p]%2 == 0 {
+			return true
+		}
+	}
+	for _, poly := range c.polygons {
+		if pointInPolygon(p, poly) == inside {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *components) intersectsSegment(s segment) bool {
+	if c.covers(s[0]) || c.covers(s[1]) {
+		return true
+	}
+	for _, t := range c.allSegments() {
+		if segmentsIntersect(s, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// split splits the segment at the points where it meets the segments and rings of the geometry.
+func (c *components) split(s segment) []segment {
+	ts := []float64{0, 1}
+	d := s[1].sub(s[0])
+	for _, t := range c.allSegments() {
+		e := t[1].sub(t[0])
+		denom := cross(d, e)
+		if denom != 0 {
+			f := t[0].sub(s[0])
+			u, v := cross(f, e)/denom, cross(f, d)/denom
+			if u > 0 && u < 1 && v >= 0 && v <= 1 {
+				ts = append(ts, u)
+			}
+			continue
+		}
+		// The parallel segments meet at the endpoints of the overlapped part.
+		if cross(t[0].sub(s[0]), d) != 0 || d == (Point{}) {
+			continue
+		}
+		for _, q := range t {
+			if u := dot(q.sub(s[0]), d) / dot(d, d); u > 0 && u < 1 {
+				ts = append(ts, u)
+			}
+		}
+	}
+	sort.Float64s(ts)
+	pieces := make([]segment, 0, len(ts)-1)
+	for i := 1; i < len(ts); i++ {
+		if ts[i] > ts[i-1] {
+			pieces = append(pieces, segment{s.at(ts[i-1]), s.at(ts[i])})
+		}
+	}
+	if len(pieces) == 0 {
+		pieces = append(pieces, s)
+	}
+	return pieces
+}
+
+// coversSegment returns whether every point of the segment lies in the geometry.
+func (c *components) coversSegment(s segment) bool {
+	for _, piece := range c.split(s) {
+		if !c.covers(piece[0]) || !c.covers(piece[1]) || !c.covers(piece.midpoint()) {
+			return false
+		}
+	}
+	return true
+}
+
+// coversAll returns whether every point of other lies in c.
+func (c *components) coversAll(other *components) bool {
+	for _, p := range other.points {
+		if !c.covers(p) {
+			return false
+		}
+	}
+	for _, s := range other.segments {
+		if !c.coversSegment(s) {
+			return false
+		}
+	}
+	for _, poly := range other.polygons {
+		if !c.coversPolygon(poly) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *components) coversPolygon(poly [][]Point) bool {
+	for _, ring := range poly {
+		for i := 1; i < len(ring); i++ {
+			s := segment{ring[i-1], ring[i]}
+			if !c.coversSegment(s) {
+				return false
+			}
+			// The area next to the ring must be covered, or the polygon may be a hole of c.
+			for _, piece := range c.split(s) {
+				if p, ok := piece.sideIn(poly); ok && !c.covers(p) {
+					return false
+				}
+			}
+		}
+	}
+	// The rings of c mustn't cross the interior of the polygon, or the polygon may contain a hole of c.
+	polyComponents := &components{polygons: [][][]Point{poly}}
+	for _, s := range c.allSegments()[len(c.segments):] {
+		for _, piece := range polyComponents.split(s) {
+			if pointInPolygon(piece.midpoint(), poly) != inside {
+				continue
+			}
+			p, q := piece.offsets()
+			if !c.covers(p) || !c.covers(q) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// interiorSamples returns the points of the interior of the geometry, which are used to check whether its interior
+// intersects the interior of other.
+func (c *components) interiorSamples(other *components) []Point {
+	samples := append([]Point(nil), c.points...)
+	for _, s := range c.segments {
+		for _, piece := range other.split(s) {
+			samples = append(samples, piece.midpoint())
+		}
+		for _, p := range s {
+			if c.lineEnds[p]%2 == 0 {
+				samples = append(samples, p)
+			}
+		}
+	}
+	for _, poly := range c.polygons {
+		for _, ring := range poly {
+			for i := 1; i < len(ring); i++ {
+				for _, piece := range other.split(segment{ring[i-1], ring[i]}) {
+					if p, ok := piece.sideIn(poly); ok {
+						samples = append(samples, p)
+					}
+				}
+			}
+		}
+	}
+	return samples
+}
+
+// pointInPolygon locates the point by the crossing number of the exterior ring and the holes.
+func pointInPolygon(p Point, poly [][]Point) location {
+	for i, ring := range poly {
+		switch pointInRing(p, ring) {
+		case boundary:
+			return boundary
+		case inside:
+			if i > 0 {
+				return outside
+			}
+		case outside:
+			if i == 0 {
+				return outside
+			}
+		}
+	}
+	return inside
+}
+
+func pointInRing(p Point, ring []Point) location {
+	in := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if (segment{a, b}).contains(p) {
+			return boundary
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			in = !in
+		}
+	}
+	if in {
+		return inside
+	}
+	return outside
+}
+
+func segmentsIntersect(s, t segment) bool {
+	d1, d2 := orientation(t[0], t[1], s[0]), orientation(t[0], t[1], s[1])
+	d3, d4 := orientation(s[0], s[1], t[0]), orientation(s[0], s[1], t[1])
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true
+	}
+	return t.contains(s[0]) || t.contains(s[1]) || s.contains(t[0]) || s.contains(t[1])
+}
+
+func orientation(a, b, c Point) float64 {
+	return cross(b.sub(a), c.sub(a))
+}
+
+func cross(a, b Point) float64 {
+	return a.X*b.Y - a.Y*b.X
+}
+
+func dot(a, b Point) float64 {
+	return a.X*b.X + a.Y*b.Y
+}
+
+func (p Point) sub(q Point) Point {
+	return Point{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+func (p Point) distance(q Point) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+// contains returns whether the point lies on the segment.
+func (s segment) contains(p Point) bool {
+	if orientation(s[0], s[1], p) != 0 {
+		return false
+	}
+	return math.Min(s[0].X, s[1].X) <= p.X && p.X <= math.Max(s[0].X, s[1].X) &&
+		math.Min(s[0].Y, s[1].Y) <= p.Y && p.Y <= math.Max(s[0].Y, s[1].Y)
+}
+
+func (s segment) at(t float64) Point {
+	switch t {
+	case 0:
+		return s[0]
+	case 1:
+		return s[1]
+	}
+	return Point{X: s[0].X + (s[1].X-s[0].X)*t, Y: s[0].Y + (s[1].Y-s[0].Y)*t}
+}
+
+func (s segment) midpoint() Point {
+	return Point{X: (s[0].X + s[1].X) / 2, Y: (s[0].Y + s[1].Y) / 2}
+}
+
+func (s segment) distance(p Point) float64 {
+	d := s[1].sub(s[0])
+	l := dot(d, d)
+	if l == 0 {
+		return p.distance(s[0])
+	}
+	t := math.Max(0, math.Min(1, dot(p.sub(s[0]), d)/l))
+	return p.distance(s.at(t))
+}
+
+// offsets returns the points on both sides of the midpoint, which are slightly offset from the segment.
+func (s segment) offsets() (Point, Point) {
+	m := s.midpoint()
+	d := s[1].sub(s[0])
+	l := math.Hypot(d.X, d.Y)
+	if l == 0 {
+		return m, m
+	}
+	delta := math.Max(l*1e-7, (math.Abs(m.X)+math.Abs(m.Y))*1e-12) / l
+	n := Point{X: -d.Y * delta, Y: d.X * delta}
+	return Point{X: m.X + n.X, Y: m.Y + n.Y}, Point{X: m.X - n.X, Y: m.Y - n.Y}
+}
+
+// sideIn returns the offset point of the ring piece which lies in the interior of the polygon.
+func (s segment) sideIn(poly [][]Point) (Point, bool) {
+	p, q := s.offsets()
+	if pointInPolygon(p, poly) == inside {
+		return p, true
+	}
+	if pointInPolygon(q, poly) == inside {
+		return q, true
+	}
+	return Point{}, false
+}
@@ -0,0 +1,110 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	square     = "POLYGON((0 0,10 0,10 10,0 10,0 0))"
+	squareHole = "POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))"
+	hole       = "POLYGON((4 4,6 4,6 6,4 6,4 4))"
+)
+
+func TestRelations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b       string
+		intersects bool
+		contains   bool
+		within     bool
+		equals     bool
+	}{
+		{"POINT(1 1)", "POINT(1 1)", true, true, true, true},
+		{"POINT(1 1)", "POINT(1 2)", false, false, false, false},
+		{square, "POINT(5 5)", true, true, false, false},
+		{square, "POINT(0 5)", true, false, false, false},
+		{square, "POINT(11 5)", false, false, false, false},
+		{squareHole, "POINT(5 5)", false, false, false, false},
+		{squareHole, "POINT(4 5)", true, false, false, false},
+		{square, "LINESTRING(1 1,9 9)", true, true, false, false},
+		{square, "LINESTRING(0 0,10 0)", true, false, false, false},
+		{square, "LINESTRING(5 5,15 5)", true, false, false, false},
+		{squareHole, "LINESTRING(1 5,9 5)", true, false, false, false},
+		{squareHole, "LINESTRING(1 1,9 1)", true, true, false, false},
+		{"LINESTRING(0 0,10 10)", "LINESTRING(0 10,10 0)", true, false, false, false},
+		{"LINESTRING(0 0,10 10)", "LINESTRING(2 2,5 5)", true, true, false, false},
+		{"LINESTRING(0 0,10 10)", "POINT(0 0)", true, false, false, false},
+		{"LINESTRING(0 0,5 5,10 10)", "LINESTRING(10 10,0 0)", true, true, true, true},
+		{"LINESTRING(0 0,10 0)", "LINESTRING(0 1,10 1)", false, false, false, false},
+		{square, square, true, true, true, true},
+		{square, "POLYGON((10 0,0 0,0 10,10 10,10 0))", true, true, true, true},
+		{square, "POLYGON((1 1,9 1,9 9,1 9,1 1))", true, true, false, false},
+		{square, "POLYGON((0 0,5 0,5 5,0 5,0 0))", true, true, false, false},
+		{square, "POLYGON((5 5,15 5,15 15,5 15,5 5))", true, false, false, false},
+		{square, "POLYGON((10 0,20 0,20 10,10 10,10 0))", true, false, false, false},
+		{square, "POLYGON((20 20,30 20,30 30,20 20))", false, false, false, false},
+		{squareHole, hole, true, false, false, false},
+		{squareHole, "POLYGON((3 3,7 3,7 7,3 7,3 3))", true, false, false, false},
+		{squareHole, "POLYGON((1 1,3 1,3 3,1 3,1 1))", true, true, false, false},
+		{"POLYGON((1 1,2 1,2 2,1 2,1 1))", square, true, false, true, false},
+		{"MULTIPOLYGON(((0 0,5 0,5 10,0 10,0 0)),((5 0,10 0,10 10,5 10,5 0)))", square, true, true, true, true},
+		{"MULTIPOINT((1 1),(20 20))", "POINT(20 20)", true, true, false, false},
+		{"GEOMETRYCOLLECTION(POINT(20 20)," + square + ")", "MULTIPOINT((5 5),(20 20))", true, true, false, false},
+	}
+	for _, tt := range tests {
+		a, b := mustParseWKT(t, tt.a), mustParseWKT(t, tt.b)
+		require.Equal(t, tt.intersects, Intersects(a, b), "%s intersects %s", tt.a, tt.b)
+		require.Equal(t, tt.intersects, Intersects(b, a), "%s intersects %s", tt.b, tt.a)
+		require.Equal(t, !tt.intersects, Disjoint(a, b), "%s disjoint %s", tt.a, tt.b)
+		require.Equal(t, tt.contains, Contains(a, b), "%s contains %s", tt.a, tt.b)
+		require.Equal(t, tt.contains, Within(b, a), "%s within %s", tt.b, tt.a)
+		require.Equal(t, tt.within, Within(a, b), "%s within %s", tt.a, tt.b)
+		require.Equal(t, tt.equals, Equals(a, b), "%s equals %s", tt.a, tt.b)
+		require.Equal(t, tt.equals, Equals(b, a), "%s equals %s", tt.b, tt.a)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b     string
+		distance float64
+	}{
+		{"POINT(0 0)", "POINT(3 4)", 5},
+		{"POINT(0 5)", "LINESTRING(0 0,10 0)", 5},
+		{"POINT(-3 -4)", "LINESTRING(0 0,10 0)", 5},
+		{"LINESTRING(0 1,10 1)", "LINESTRING(0 0,10 0)", 1},
+		{"LINESTRING(0 0,10 10)", "LINESTRING(0 10,10 0)", 0},
+		{square, "POINT(5 5)", 0},
+		{square, "POINT(13 14)", 5},
+		{squareHole, "POINT(5 5)", 1},
+		{square, "POLYGON((20 0,30 0,30 10,20 10,20 0))", 10},
+		{"MULTIPOINT((100 100),(0 12))", square, 2},
+		{"GEOMETRYCOLLECTION(POINT(1 1),POINT(4 5))", "POINT(1 1)", 0},
+	}
+	for _, tt := range tests {
+		a, b := mustParseWKT(t, tt.a), mustParseWKT(t, tt.b)
+		require.InDelta(t, tt.distance, Distance(a, b), 1e-9, "%s %s", tt.a, tt.b)
+		require.InDelta(t, tt.distance, Distance(b, a), 1e-9, "%s %s", tt.b, tt.a)
+	}
+	require.False(t, math.IsInf(Distance(mustParseWKT(t, "POINT(1 1)"), mustParseWKT(t, "POINT(1e300 1e300)")), 0))
+}
@@ -0,0 +1,246 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+/*
+	The geometry values are stored in the same layout as MySQL: a 4-byte little-endian SRID, followed by the
+	WKB (Well-Known Binary) of the geometry. The WKB written by TiDB is always little-endian.
+
+	WKB        ::= byte_order type body
+	byte_order ::= 0x00 (big-endian) | 0x01 (little-endian)
+	type       ::= uint32, see Type
+	body       ::= point                            // Point
+	             | uint32 point*                    // LineString, MultiPoint is uint32 WKB*
+	             | uint32 (uint32 point*)*          // Polygon
+	             | uint32 WKB*                      // MultiPoint, MultiLineString, MultiPolygon, GeometryCollection
+	point      ::= float64 float64
+*/
+
+const (
+	wkbBigEndian    byte = 0x00
+	wkbLittleEndian byte = 0x01
+
+	sridLen  = 4
+	pointLen = 16
+	// headerLen is the length of the byte order and the type.
+	headerLen = 5
+)
+
+// maxNestingLevel limits the nesting of the GeometryCollections, as MySQL does.
+const maxNestingLevel = 100
+
+// Encode encodes the geometry in the storage format of MySQL.
+func Encode(g *Geometry) []byte {
+	buf := make([]byte, sridLen, 64)
+	binary.LittleEndian.PutUint32(buf, g.SRID)
+	return appendWKB(buf, g)
+}
+
+// EncodeWKB encodes the geometry as WKB without the SRID.
+func EncodeWKB(g *Geometry) []byte {
+	return appendWKB(make([]byte, 0, 64), g)
+}
+
+func appendWKB(buf []byte, g *Geometry) []byte {
+	buf = append(buf, wkbLittleEndian)
+	buf = appendUint32(buf, uint32(g.Type))
+	switch g.Type {
+	case TypePoint:
+		buf = appendPoint(buf, g.Points[0])
+	case TypeLineString:
+		buf = appendPoints(buf, g.Points)
+	case TypePolygon:
+		buf = appendUint32(buf, uint32(len(g.Rings)))
+		for _, ring := range g.Rings {
+			buf = appendPoints(buf, ring)
+		}
+	case TypeMultiPoint:
+		buf = appendUint32(buf, uint32(len(g.Points)))
+		for _, p := range g.Points {
+			buf = append(buf, wkbLittleEndian)
+			buf = appendUint32(buf, uint32(TypePoint))
+			buf = appendPoint(buf, p)
+		}
+	default:
+		buf = appendUint32(buf, uint32(len(g.Geometries)))
+		for _, member := range g.Geometries {
+			buf = appendWKB(buf, member)
+		}
+	}
+	return buf
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func appendPoint(buf []byte, p Point) []byte {
+	var b [pointLen]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(p.X))
+	binary.LittleEndian.PutUint64(b[8:], math.Float64bits(p.Y))
+	return append(buf, b[:]...)
+}
+
+func appendPoints(buf []byte, points []Point) []byte {
+	buf = appendUint32(buf, uint32(len(points)))
+	for _, p := range points {
+		buf = appendPoint(buf, p)
+	}
+	return buf
+}
+
+// Decode decodes a geometry value in the storage format of MySQL.
+func Decode(data []byte) (*Geometry, error) {
+	if len(data) < sridLen {
+		return nil, errInvalidGeometry
+	}
+	srid := binary.LittleEndian.Uint32(data)
+	if err := CheckSRID(srid); err != nil {
+		return nil, err
+	}
+	return ParseWKB(data[sridLen:], srid)
+}
+
+// ParseWKB parses the WKB of a geometry.
+func ParseWKB(wkb []byte, srid uint32) (*Geometry, error) {
+	d := wkbDecoder{data: wkb}
+	g, err := d.decode(0)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.data) != 0 {
+		return nil, errInvalidGeometry
+	}
+	g.SRID = srid
+	return g, nil
+}
+
+type wkbDecoder struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func (d *wkbDecoder) decode(level int) (*Geometry, error) {
+	if level > maxNestingLevel || len(d.data) < headerLen {
+		return nil, errInvalidGeometry
+	}
+	switch d.data[0] {
+	case wkbBigEndian:
+		d.order = binary.BigEndian
+	case wkbLittleEndian:
+		d.order = binary.LittleEndian
+	default:
+		return nil, errInvalidGeometry
+	}
+	d.data = d.data[1:]
+	g := &Geometry{Type: Type(d.uint32())}
+	var err error
+	switch g.Type {
+	case TypePoint:
+		g.Points, err = d.points(1)
+	case TypeLineString:
+		g.Points, err = d.pointList()
+	case TypePolygon:
+		var n uint32
+		if n, err = d.count(sridLen); err != nil {
+			return nil, err
+		}
+		g.Rings = make([][]Point, 0, n)
+		for i := uint32(0); i < n; i++ {
+			ring, err := d.pointList()
+			if err != nil {
+				return nil, err
+			}
+			g.Rings = append(g.Rings, ring)
+		}
+	case TypeMultiPoint, TypeMultiLineString, TypeMultiPolygon, TypeGeometryCollection:
+		err = d.decodeMembers(g, level)
+	default:
+		return nil, errInvalidGeometry
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g, g.validate()
+}
+
+func (d *wkbDecoder) decodeMembers(g *Geometry, level int) error {
+	n, err := d.count(headerLen)
+	if err != nil {
+		return err
+	}
+	memberType := g.Type - TypeMultiPoint + TypePoint
+	for i := uint32(0); i < n; i++ {
+		member, err := d.decode(level + 1)
+		if err != nil {
+			return err
+		}
+		if g.Type != TypeGeometryCollection && member.Type != memberType {
+			return errInvalidGeometry
+		}
+		if g.Type == TypeMultiPoint {
+			g.Points = append(g.Points, member.Points[0])
+		} else {
+			g.Geometries = append(g.Geometries, member)
+		}
+	}
+	return nil
+}
+
+func (d *wkbDecoder) uint32() uint32 {
+	v := d.order.Uint32(d.data)
+	d.data = d.data[4:]
+	return v
+}
+
+// count reads the number of the elements, and checks the data is long enough for them.
+func (d *wkbDecoder) count(minElemLen int) (uint32, error) {
+	if len(d.data) < 4 {
+		return 0, errInvalidGeometry
+	}
+	n := d.uint32()
+	if uint64(n)*uint64(minElemLen) > uint64(len(d.data)) {
+		return 0, errInvalidGeometry
+	}
+	return n, nil
+}
+
+func (d *wkbDecoder) pointList() ([]Point, error) {
+	n, err := d.count(pointLen)
+	if err != nil {
+		return nil, err
+	}
+	return d.points(n)
+}
+
+func (d *wkbDecoder) points(n uint32) ([]Point, error) {
+	if uint64(len(d.data)) < uint64(n)*pointLen {
+		return nil, errInvalidGeometry
+	}
+	points := make([]Point, n)
+	for i := range points {
+		points[i].X = math.Float64frombits(d.order.Uint64(d.data))
+		points[i].Y = math.Float64frombits(d.order.Uint64(d.data[8:]))
+		d.data = d.data[pointLen:]
+	}
+	return points, nil
+}
@@ -0,0 +1,356 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseWKT parses the WKT (Well-Known Text) of a geometry, the keywords are case-insensitive.
+func ParseWKT(wkt string, srid uint32) (*Geometry, error) {
+	p := wktParser{str: wkt}
+	g, err := p.parseGeometry(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpaces(); p.pos != len(p.str) {
+		return nil, errInvalidGeometry
+	}
+	g.SRID = srid
+	return g, nil
+}
+
+type wktParser struct {
+	str string
+	pos int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.str) && isSpace(p.str[p.pos]) {
+		p.pos++
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+// consume skips the spaces and the expected char, it returns false if the next char isn't the expected one.
+func (p *wktParser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.str) && p.str[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) peek(c byte) bool {
+	p.skipSpaces()
+	return p.pos < len(p.str) && p.str[p.pos] == c
+}
+
+func (p *wktParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.str) && isLetter(p.str[p.pos]) {
+		p.pos++
+	}
+	return strings.ToUpper(p.str[start:p.pos])
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func (p *wktParser) parseGeometry(level int) (*Geometry, error) {
+	if level > maxNestingLevel {
+		return nil, errInvalidGeometry
+	}
+	g := &Geometry{}
+	switch p.word() {
+	case "POINT":
+		g.Type = TypePoint
+	case "LINESTRING":
+		g.Type = TypeLineString
+	case "POLYGON":
+		g.Type = TypePolygon
+	case "MULTIPOINT":
+		g.Type = TypeMultiPoint
+	case "MULTILINESTRING":
+		g.Type = TypeMultiLineString
+	case "MULTIPOLYGON":
+		g.Type = TypeMultiPolygon
+	case "GEOMETRYCOLLECTION", "GEOMCOLLECTION":
+		g.Type = TypeGeometryCollection
+		return g, p.parseCollection(g, level)
+	default:
+		return nil, errInvalidGeometry
+	}
+	if !p.consume('(') {
+		return nil, errInvalidGeometry
+	}
+	var err error
+	switch g.Type {
+	case TypePoint:
+		var pt Point
+		if pt, err = p.parsePoint(); err == nil {
+			g.Points = []Point{pt}
+		}
+	case TypeLineString:
+		g.Points, err = p.parsePoints()
+	case TypePolygon:
+		g.Rings, err = p.parseRings()
+	case TypeMultiPoint:
+		g.Points, err = p.parseMultiPoint()
+	case TypeMultiLineString:
+		err = p.parseMembers(g, TypeLineString)
+	case TypeMultiPolygon:
+		err = p.parseMembers(g, TypePolygon)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(')') {
+		return nil, errInvalidGeometry
+	}
+	return g, g.validate()
+}
+
+// parseCollection parses the members of a GeometryCollection, "EMPTY" and "()" mean an empty collection.
+func (p *wktParser) parseCollection(g *Geometry, level int) error {
+	if p.skipSpaces(); strings.HasPrefix(strings.ToUpper(p.str[p.pos:]), "EMPTY") {
+		p.pos += len("EMPTY")
+		return nil
+	}
+	if !p.consume('(') {
+		return errInvalidGeometry
+	}
+	if p.consume(')') {
+		return nil
+	}
+	for {
+		member, err := p.parseGeometry(level + 1)
+		if err != nil {
+			return err
+		}
+		g.Geometries = append(g.Geometries, member)
+		if !p.consume(',') {
+			break
+		}
+	}
+	if !p.consume(')') {
+		return errInvalidGeometry
+	}
+	return nil
+}
+
+func (p *wktParser) parseNumber() (float64, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.str) {
+		c := p.str[p.pos]
+		if !(c >= '0' && c <= '9' || c == '.' || c == '-' || c == '+' || c == 'e' || c == 'E') {
+			break
+		}
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.str[start:p.pos], 64)
+	if err != nil {
+		return 0, errInvalidGeometry
+	}
+	return f, nil
+}
+
+func (p *wktParser) parsePoint() (pt Point, err error) {
+	if pt.X, err = p.parseNumber(); err != nil {
+		return pt, err
+	}
+	// The coordinates must be separated by spaces.
+	if p.pos == len(p.str) || !isSpace(p.str[p.pos]) {
+		return pt, errInvalidGeometry
+	}
+	pt.Y, err = p.parseNumber()
+	return pt, err
+}
+
+// parsePoints parses the comma separated points, the parentheses aren't included.
+func (p *wktParser) parsePoints() ([]Point, error) {
+	var points []Point
+	for {
+		pt, err := p.parsePoint()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, pt)
+		if !p.consume(',') {
+			return points, nil
+		}
+	}
+}
+
+func (p *wktParser) parseRings() ([][]Point, error) {
+	var rings [][]Point
+	for {
+		if !p.consume('(') {
+			return nil, errInvalidGeometry
+		}
+		ring, err := p.parsePoints()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(')') {
+			return nil, errInvalidGeometry
+		}
+		rings = append(rings, ring)
+		if !p.consume(',') {
+			return rings, nil
+		}
+	}
+}
+
+// parseMultiPoint accepts both "MULTIPOINT(1 1,2 2)" and "MULTIPOINT((1 1),(2 2))".
+func (p *wktParser) parseMultiPoint() ([]Point, error) {
+	if !p.peek('(') {
+		return p.parsePoints()
+	}
+	var points []Point
+	for {
+		if !p.consume('(') {
+			return nil, errInvalidGeometry
+		}
+		pt, err := p.parsePoint()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(')') {
+			return nil, errInvalidGeometry
+		}
+		points = append(points, pt)
+		if !p.consume(',') {
+			return points, nil
+		}
+	}
+}
+
+// parseMembers parses the LineStrings or Polygons of a MultiLineString or MultiPolygon.
+func (p *wktParser) parseMembers(g *Geometry, memberType Type) error {
+	for {
+		if !p.consume('(') {
+			return errInvalidGeometry
+		}
+		member := &Geometry{Type: memberType}
+		var err error
+		if memberType == TypeLineString {
+			member.Points, err = p.parsePoints()
+		} else {
+			member.Rings, err = p.parseRings()
+		}
+		if err != nil {
+			return err
+		}
+		if !p.consume(')') {
+			return errInvalidGeometry
+		}
+		if err = member.validate(); err != nil {
+			return err
+		}
+		g.Geometries = append(g.Geometries, member)
+		if !p.consume(',') {
+			return nil
+		}
+	}
+}
+
+// String returns the WKT of the geometry in the format of MySQL ST_AsText.
+func (g *Geometry) String() string {
+	var sb strings.Builder
+	g.writeWKT(&sb, true)
+	return sb.String()
+}
+
+func (g *Geometry) writeWKT(sb *strings.Builder, withType bool) {
+	switch g.Type {
+	case TypePoint:
+		if withType {
+			sb.WriteString("POINT")
+		}
+		writePoints(sb, g.Points)
+	case TypeLineString:
+		if withType {
+			sb.WriteString("LINESTRING")
+		}
+		writePoints(sb, g.Points)
+	case TypePolygon:
+		if withType {
+			sb.WriteString("POLYGON")
+		}
+		sb.WriteByte('(')
+		for i, ring := range g.Rings {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writePoints(sb, ring)
+		}
+		sb.WriteByte(')')
+	case TypeMultiPoint:
+		sb.WriteString("MULTIPOINT(")
+		for i, pt := range g.Points {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writePoints(sb, []Point{pt})
+		}
+		sb.WriteByte(')')
+	case TypeMultiLineString, TypeMultiPolygon:
+		sb.WriteString(g.Type.String())
+		sb.WriteByte('(')
+		for i, member := range g.Geometries {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			member.writeWKT(sb, false)
+		}
+		sb.WriteByte(')')
+	case TypeGeometryCollection:
+		sb.WriteString("GEOMETRYCOLLECTION")
+		if len(g.Geometries) == 0 {
+			sb.WriteString(" EMPTY")
+			return
+		}
+		sb.WriteByte('(')
+		for i, member := range g.Geometries {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			member.writeWKT(sb, true)
+		}
+		sb.WriteByte(')')
+	}
+}
+
+func writePoints(sb *strings.Builder, points []Point) {
+	sb.WriteByte('(')
+	for i, pt := range points {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(pt.X, 'f', -1, 64))
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.FormatFloat(pt.Y, 'f', -1, 64))
+	}
+	sb.WriteByte(')')
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseWKT(t *testing.T, wkt string) *Geometry {
+	g, err := ParseWKT(wkt, 0)
+	require.NoError(t, err, wkt)
+	return g
+}
+
+func TestParseWKT(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		wkt      string
+		expected string
+		typ      Type
+	}{
+		{"POINT(1 2)", "POINT(1 2)", TypePoint},
+		{" point ( -1.5   2e2 ) ", "POINT(-1.5 200)", TypePoint},
+		{"LINESTRING(0 0,1 1, 2 0)", "LINESTRING(0 0,1 1,2 0)", TypeLineString},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,1 2,2 2,1 1))", "POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,1 2,2 2,1 1))", TypePolygon},
+		{"MULTIPOINT(1 1,2 2)", "MULTIPOINT((1 1),(2 2))", TypeMultiPoint},
+		{"MULTIPOINT((1 1),(2 2))", "MULTIPOINT((1 1),(2 2))", TypeMultiPoint},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", "MULTILINESTRING((0 0,1 1),(2 2,3 3))", TypeMultiLineString},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))", "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))", TypeMultiPolygon},
+		{"GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))", "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))", TypeGeometryCollection},
+		{"GeomCollection(GeometryCollection(Point(1 1)))", "GEOMETRYCOLLECTION(GEOMETRYCOLLECTION(POINT(1 1)))", TypeGeometryCollection},
+		{"GEOMETRYCOLLECTION EMPTY", "GEOMETRYCOLLECTION EMPTY", TypeGeometryCollection},
+		{"GEOMETRYCOLLECTION()", "GEOMETRYCOLLECTION EMPTY", TypeGeometryCollection},
+	}
+	for _, tt := range tests {
+		g := mustParseWKT(t, tt.wkt)
+		require.Equal(t, tt.expected, g.String(), tt.wkt)
+		require.Equal(t, tt.typ, g.Type, tt.wkt)
+	}
+
+	invalid := []string{
+		"",
+		"POINT",
+		"POINT()",
+		"POINT(1)",
+		"POINT(1,2)",
+		"POINT(1 2 3)",
+		"POINT(1 2",
+		"POINT(1 2) x",
+		"LINESTRING(0 0)",
+		"POLYGON((0 0,1 0,1 1))",
+		"POLYGON((0 0,1 0,1 1,0 1))",
+		"MULTIPOINT()",
+		"MULTIPOLYGON((0 0,1 0,1 1,0 0))",
+		"GEOMETRYCOLLECTION(POINT(1 1),)",
+		"CIRCLE(0 0,1)",
+	}
+	for _, wkt := range invalid {
+		_, err := ParseWKT(wkt, 0)
+		require.True(t, IsInvalidGeometry(err), wkt)
+	}
+}
+
+func TestWKB(t *testing.T) {
+	t.Parallel()
+
+	// MySQL returns the same bytes for SELECT HEX(ST_GeomFromText('POINT(1 -1)')).
+	g := mustParseWKT(t, "POINT(1 -1)")
+	require.Equal(t, "000000000101000000000000000000f03f000000000000f0bf", hex.EncodeToString(Encode(g)))
+	require.Equal(t, "0101000000000000000000f03f000000000000f0bf", hex.EncodeToString(EncodeWKB(g)))
+
+	for _, wkt := range []string{
+		"POINT(1 2)",
+		"LINESTRING(0 0,1 1,2 0)",
+		"POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,1 2,2 2,1 1))",
+		"MULTIPOINT((1 1),(2 2))",
+		"MULTILINESTRING((0 0,1 1),(2 2,3 3))",
+		"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))",
+		"GEOMETRYCOLLECTION(POINT(1 1),GEOMETRYCOLLECTION EMPTY,LINESTRING(0 0,1 1))",
+		"GEOMETRYCOLLECTION EMPTY",
+	} {
+		decoded, err := Decode(Encode(mustParseWKT(t, wkt)))
+		require.NoError(t, err, wkt)
+		require.Equal(t, wkt, decoded.String())
+	}
+
+	// Big-endian WKB is accepted.
+	be, err := hex.DecodeString("00000000013ff00000000000004000000000000000")
+	require.NoError(t, err)
+	g, err = ParseWKB(be, 0)
+	require.NoError(t, err)
+	require.Equal(t, "POINT(1 2)", g.String())
+
+	_, err = Decode(append([]byte{0xE6, 0x10, 0, 0}, EncodeWKB(g)...))
+	require.True(t, ErrSRSNotFound.Equal(err))
+
+	for _, data := range []string{
+		"",
+		"000000",
+		"00000000",
+		"000000000101000000000000000000f03f",
+		"000000000101000000000000000000f03f000000000000f0bf00",
+		"000000000201000000000000000000f03f000000000000f0bf",
+		"0000000001020000000100000000000000000000000000000000000000",
+		"000000000104000000ffffffff",
+		"000000000108000000",
+		"000000000101000000000000000000f87f0000000000000000",
+	} {
+		b, err := hex.DecodeString(data)
+		require.NoError(t, err)
+		_, err = Decode(b)
+		require.True(t, IsInvalidGeometry(err), data)
+	}
+}
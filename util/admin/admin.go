@@ -111,10 +111,15 @@ func IsJobRollbackable(job *model.Job) bool {
 		}
 	case model.ActionAddTablePartition:
 		return job.SchemaState == model.StateNone || job.SchemaState == model.StateReplicaOnly
+	case model.ActionReorganizePartition:
+		return job.SchemaState != model.StateDeleteReorganization
+	case model.ActionMultiSchemaChange:
+		// The multi-schema change job is in the public state after all its sub-jobs are committed.
+		return job.SchemaState != model.StatePublic
 	case model.ActionDropColumn, model.ActionDropColumns, model.ActionDropTablePartition,
 		model.ActionRebaseAutoID, model.ActionShardRowID,
 		model.ActionTruncateTable, model.ActionAddForeignKey,
-		model.ActionDropForeignKey, model.ActionRenameTable,
+		model.ActionDropForeignKey, model.ActionDropCheckConstraint, model.ActionRenameTable,
 		model.ActionModifyTableCharsetAndCollate, model.ActionTruncateTablePartition,
 		model.ActionModifySchemaCharsetAndCollate, model.ActionRepairTable, model.ActionModifyTableAutoIdCache:
 		return job.SchemaState == model.StateNone
@@ -124,7 +129,9 @@ func IsJobRollbackable(job *model.Job) bool {
 
 // MayNeedBackfill returns whether the action type may need to backfill the data.
 func MayNeedBackfill(tp model.ActionType) bool {
-	return tp == model.ActionAddIndex || tp == model.ActionAddPrimaryKey || tp == model.ActionModifyColumn
+	return tp == model.ActionAddIndex || tp == model.ActionAddPrimaryKey || tp == model.ActionModifyColumn ||
+		tp == model.ActionAddCheckConstraint || tp == model.ActionAlterCheckConstraint || tp == model.ActionReorganizePartition ||
+		tp == model.ActionMultiSchemaChange
 }
 
 // CancelJobs cancels the DDL jobs.
@@ -170,6 +177,11 @@ func CancelJobs(txn kv.Transaction, ids []int64) ([]error, error) {
 			}
 
 			job.State = model.JobStateCancelling
+			// A paused job is resumed to handle the cancellation.
+			if err := t.RemoveDDLJobPaused(job.ID); err != nil {
+				errs[i] = errors.Trace(err)
+				continue
+			}
 			// Make sure RawArgs isn't overwritten.
 			err := json.Unmarshal(job.RawArgs, &job.Args)
 			if err != nil {
@@ -193,6 +205,67 @@ func CancelJobs(txn kv.Transaction, ids []int64) ([]error, error) {
 	return errs, nil
 }
 
+// PauseJobs pauses the DDL jobs. A paused job stays in the DDL job queue and isn't run until it's resumed,
+// the progress of its backfilling is kept, so it continues from where it's paused after resuming.
+// Note that the paused job blocks every job queued behind it in the same queue until it's resumed or cancelled,
+// no matter which tables these jobs are on.
+func PauseJobs(txn kv.Transaction, ids []int64) ([]error, error) {
+	return updatePausedJobs(txn, ids, true)
+}
+
+// ResumeJobs resumes the paused DDL jobs.
+func ResumeJobs(txn kv.Transaction, ids []int64) ([]error, error) {
+	return updatePausedJobs(txn, ids, false)
+}
+
+func updatePausedJobs(txn kv.Transaction, ids []int64, pause bool) ([]error, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	errs := make([]error, len(ids))
+	t := meta.NewMeta(txn)
+	jobs, err := GetDDLJobs(txn)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i, id := range ids {
+		var job *model.Job
+		for _, j := range jobs {
+			if j.ID == id {
+				job = j
+				break
+			}
+		}
+		if job == nil {
+			errs[i] = ErrDDLJobNotFound.GenWithStackByArgs(id)
+			continue
+		}
+		paused, err := t.IsDDLJobPaused(id)
+		if err != nil {
+			errs[i] = errors.Trace(err)
+			continue
+		}
+		if !pause {
+			if !paused {
+				errs[i] = ErrCannotResumeDDLJob.GenWithStackByArgs(id)
+				continue
+			}
+			errs[i] = errors.Trace(t.RemoveDDLJobPaused(id))
+			continue
+		}
+		if paused {
+			continue
+		}
+		if job.IsDone() || job.IsSynced() || job.IsCancelled() || job.IsCancelling() || job.IsRollingback() || job.IsRollbackDone() {
+			errs[i] = ErrCannotPauseDDLJob.GenWithStackByArgs(id)
+			continue
+		}
+		errs[i] = errors.Trace(t.SetDDLJobPaused(id))
+	}
+	return errs, nil
+}
+
 func getDDLJobsInQueue(t *meta.Meta, jobListKey meta.JobListKeyType) ([]*model.Job, error) {
 	cnt, err := t.DDLJobQueueLen(jobListKey)
 	if err != nil {
@@ -497,6 +570,10 @@ var (
 	ErrCancelFinishedDDLJob = dbterror.ClassAdmin.NewStd(errno.ErrCancelFinishedDDLJob)
 	// ErrCannotCancelDDLJob returns when cancel a almost finished ddl job, because cancel in now may cause data inconsistency.
 	ErrCannotCancelDDLJob = dbterror.ClassAdmin.NewStd(errno.ErrCannotCancelDDLJob)
+	// ErrCannotPauseDDLJob returns when pause a finished or rolling back ddl job.
+	ErrCannotPauseDDLJob = dbterror.ClassAdmin.NewStd(errno.ErrCannotPauseDDLJob)
+	// ErrCannotResumeDDLJob returns when resume a ddl job which isn't paused.
+	ErrCannotResumeDDLJob = dbterror.ClassAdmin.NewStd(errno.ErrCannotResumeDDLJob)
 	// ErrAdminCheckTable returns when the table records is inconsistent with the index values.
 	ErrAdminCheckTable = dbterror.ClassAdmin.NewStd(errno.ErrAdminCheckTable)
 )
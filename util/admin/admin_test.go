@@ -279,6 +279,69 @@ func TestCancelJobs(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPauseAndResumeJobs(t *testing.T) {
+	t.Parallel()
+
+	store, clean := newMockStore(t)
+	defer clean()
+
+	txn, err := store.Begin()
+	require.NoError(t, err)
+
+	m := meta.NewMeta(txn)
+	job := &model.Job{
+		ID:       1,
+		SchemaID: 1,
+		TableID:  2,
+		Type:     model.ActionAddIndex,
+		State:    model.JobStateRunning,
+	}
+	doneJob := &model.Job{
+		ID:       2,
+		SchemaID: 1,
+		Type:     model.ActionCreateTable,
+		State:    model.JobStateDone,
+	}
+	require.NoError(t, m.EnQueueDDLJob(job, meta.AddIndexJobListKey))
+	require.NoError(t, m.EnQueueDDLJob(doneJob))
+
+	errs, err := PauseJobs(txn, []int64{job.ID, doneJob.ID, 3})
+	require.NoError(t, err)
+	require.NoError(t, errs[0])
+	require.Regexp(t, ".*This job:2 is finished or rolling back, so can't be paused", errs[1].Error())
+	require.Regexp(t, ".*DDL Job:3 not found", errs[2].Error())
+	paused, err := m.IsDDLJobPaused(job.ID)
+	require.NoError(t, err)
+	require.True(t, paused)
+
+	// Pausing a paused job is a no-op.
+	errs, err = PauseJobs(txn, []int64{job.ID})
+	require.NoError(t, err)
+	require.NoError(t, errs[0])
+
+	errs, err = ResumeJobs(txn, []int64{job.ID, doneJob.ID})
+	require.NoError(t, err)
+	require.NoError(t, errs[0])
+	require.Regexp(t, ".*This job:2 isn't paused, so can't be resumed", errs[1].Error())
+	paused, err = m.IsDDLJobPaused(job.ID)
+	require.NoError(t, err)
+	require.False(t, paused)
+
+	// Cancelling a paused job resumes it.
+	errs, err = PauseJobs(txn, []int64{job.ID})
+	require.NoError(t, err)
+	require.NoError(t, errs[0])
+	errs, err = CancelJobs(txn, []int64{job.ID})
+	require.NoError(t, err)
+	require.NoError(t, errs[0])
+	paused, err = m.IsDDLJobPaused(job.ID)
+	require.NoError(t, err)
+	require.False(t, paused)
+
+	err = txn.Rollback()
+	require.NoError(t, err)
+}
+
 func TestGetHistoryDDLJobs(t *testing.T) {
 	t.Parallel()
 
@@ -556,7 +556,7 @@ func (c *Chunk) AppendDatum(colIdx int, d *types.Datum) {
 		c.AppendFloat32(colIdx, d.GetFloat32())
 	case types.KindFloat64:
 		c.AppendFloat64(colIdx, d.GetFloat64())
-	case types.KindString, types.KindBytes, types.KindBinaryLiteral, types.KindRaw, types.KindMysqlBit:
+	case types.KindString, types.KindBytes, types.KindBinaryLiteral, types.KindRaw, types.KindMysqlBit, types.KindMysqlGeometry:
 		c.AppendBytes(colIdx, d.GetBytes())
 	case types.KindMysqlDecimal:
 		c.AppendMyDecimal(colIdx, d.GetMysqlDecimal())
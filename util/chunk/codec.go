@@ -170,6 +170,10 @@ func bytesToI64Slice(b []byte) (i64s []int64) {
 const varElemLen = -1
 
 func getFixedLen(colType *types.FieldType) int {
+	if colType.Array {
+		// The values of a CAST(... ARRAY) column are JSON arrays.
+		return varElemLen
+	}
 	switch colType.Tp {
 	case mysql.TypeFloat:
 		return 4
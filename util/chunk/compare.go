@@ -28,6 +28,9 @@ type CompareFunc = func(l Row, lCol int, r Row, rCol int) int
 
 // GetCompareFunc gets a compare function for the field type.
 func GetCompareFunc(tp *types.FieldType) CompareFunc {
+	if tp.Array {
+		return cmpJSON
+	}
 	switch tp.Tp {
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear:
 		if mysql.HasUnsignedFlag(tp.Flag) {
@@ -39,7 +42,7 @@ func GetCompareFunc(tp *types.FieldType) CompareFunc {
 	case mysql.TypeDouble:
 		return cmpFloat64
 	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
-		mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		return genCmpStringFunc(tp.Collate)
 	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
 		return cmpTime
@@ -195,7 +198,7 @@ func Compare(row Row, colIdx int, ad *types.Datum) int {
 		return types.CompareFloat64(row.GetFloat64(colIdx), ad.GetFloat64())
 	case types.KindString:
 		return types.CompareString(row.GetString(colIdx), ad.GetString(), ad.Collation())
-	case types.KindBytes, types.KindBinaryLiteral, types.KindMysqlBit:
+	case types.KindBytes, types.KindBinaryLiteral, types.KindMysqlBit, types.KindMysqlGeometry:
 		return bytes.Compare(row.GetBytes(colIdx), ad.GetBytes())
 	case types.KindMysqlDecimal:
 		l, r := row.GetMyDecimal(colIdx), ad.GetMysqlDecimal()
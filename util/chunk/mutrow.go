@@ -82,6 +82,9 @@ func MutRowFromTypes(types []*types.FieldType) MutRow {
 }
 
 func zeroValForType(tp *types.FieldType) interface{} {
+	if tp.Array {
+		return json.CreateBinary(nil)
+	}
 	switch tp.Tp {
 	case mysql.TypeFloat:
 		return float32(0)
@@ -94,7 +97,7 @@ func zeroValForType(tp *types.FieldType) interface{} {
 		return int64(0)
 	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar:
 		return ""
-	case mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		return []byte{}
 	case mysql.TypeDuration:
 		return types.ZeroDuration
@@ -295,7 +298,7 @@ func (mr MutRow) SetDatum(colIdx int, d types.Datum) {
 		binary.LittleEndian.PutUint64(mr.c.columns[colIdx].data, d.GetUint64())
 	case types.KindFloat32:
 		binary.LittleEndian.PutUint32(mr.c.columns[colIdx].data, math.Float32bits(d.GetFloat32()))
-	case types.KindString, types.KindBytes, types.KindBinaryLiteral:
+	case types.KindString, types.KindBytes, types.KindBinaryLiteral, types.KindMysqlGeometry:
 		setMutRowBytes(col, d.GetBytes())
 	case types.KindMysqlTime:
 		*(*types.Time)(unsafe.Pointer(&col.data[0])) = d.GetMysqlTime()
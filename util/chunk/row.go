@@ -127,6 +127,12 @@ func (r Row) GetDatumRow(fields []*types.FieldType) []types.Datum {
 // GetDatum implements the chunk.Row interface.
 func (r Row) GetDatum(colIdx int, tp *types.FieldType) types.Datum {
 	var d types.Datum
+	if tp.Array {
+		if !r.IsNull(colIdx) {
+			d.SetMysqlJSON(r.GetJSON(colIdx))
+		}
+		return d
+	}
 	switch tp.Tp {
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
 		if !r.IsNull(colIdx) {
@@ -192,6 +198,10 @@ func (r Row) GetDatum(colIdx int, tp *types.FieldType) types.Datum {
 		if !r.IsNull(colIdx) {
 			d.SetMysqlJSON(r.GetJSON(colIdx))
 		}
+	case mysql.TypeGeometry:
+		if !r.IsNull(colIdx) {
+			d.SetMysqlGeometry(r.GetBytes(colIdx))
+		}
 	}
 	return d
 }
@@ -47,6 +47,7 @@ const (
 	varintFlag       byte = 8
 	uvarintFlag      byte = 9
 	jsonFlag         byte = 10
+	geometryFlag     byte = 11
 	maxFlag          byte = 250
 )
 
@@ -69,6 +70,8 @@ func preRealloc(b []byte, vals []types.Datum, comparable bool) []byte {
 			size += 1
 		case types.KindMysqlJSON:
 			size += 2 + len(vals[i].GetBytes())
+		case types.KindMysqlGeometry:
+			size += sizeBytes(vals[i].GetBytes(), false)
 		case types.KindMysqlDecimal:
 			size += 1 + types.MyDecimalStructSize
 		default:
@@ -128,6 +131,10 @@ func encode(sc *stmtctx.StatementContext, b []byte, vals []types.Datum, comparab
 			j := vals[i].GetMysqlJSON()
 			b = append(b, j.TypeCode)
 			b = append(b, j.Value...)
+		case types.KindMysqlGeometry:
+			// The geometries can't be indexed, so they needn't be comparable.
+			b = append(b, geometryFlag)
+			b = EncodeCompactBytes(b, vals[i].GetBytes())
 		case types.KindNull:
 			b = append(b, NilFlag)
 		case types.KindMinNotNull:
@@ -166,6 +173,8 @@ func EstimateValueSize(sc *stmtctx.StatementContext, val types.Datum) (int, erro
 		l = valueSizeOfUnsignedInt(val)
 	case types.KindMysqlJSON:
 		l = 2 + len(val.GetMysqlJSON().Value)
+	case types.KindMysqlGeometry:
+		l = valueSizeOfBytes(val.GetBytes()) + 1
 	case types.KindNull, types.KindMinNotNull, types.KindMaxValue:
 		l = 1
 	default:
@@ -324,7 +333,8 @@ func encodeHashChunkRowIdx(sc *stmtctx.StatementContext, row chunk.Row, tp *type
 			f = 0
 		}
 		b = (*[unsafe.Sizeof(f)]byte)(unsafe.Pointer(&f))[:]
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
+		mysql.TypeGeometry:
 		flag = compactBytesFlag
 		b = row.GetBytes(idx)
 		b = ConvertByCollation(b, tp)
@@ -471,7 +481,8 @@ func HashChunkSelected(sc *stmtctx.StatementContext, h []hash.Hash64, chk *chunk
 			_, _ = h[i].Write(buf)
 			_, _ = h[i].Write(b)
 		}
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
+		mysql.TypeGeometry:
 		for i := 0; i < rows; i++ {
 			if sel != nil && !sel[i] {
 				continue
@@ -847,6 +858,10 @@ func DecodeOne(b []byte) (remain []byte, d types.Datum, err error) {
 		j := json.BinaryJSON{TypeCode: b[0], Value: b[1:size]}
 		d.SetMysqlJSON(j)
 		b = b[size:]
+	case geometryFlag:
+		var v []byte
+		b, v, err = DecodeCompactBytes(b)
+		d.SetMysqlGeometry(v)
 	case NilFlag:
 	default:
 		return b, d, errors.Errorf("invalid encoded key flag %v", flag)
@@ -942,7 +957,7 @@ func peek(b []byte) (length int, err error) {
 		l = 8
 	case bytesFlag:
 		l, err = peekBytes(b)
-	case compactBytesFlag:
+	case compactBytesFlag, geometryFlag:
 		l, err = peekCompactBytes(b)
 	case decimalFlag:
 		l, err = types.DecimalPeak(b)
@@ -1083,7 +1098,7 @@ func (decoder *Decoder) DecodeOne(b []byte, colIdx int, ft *types.FieldType) (re
 			return nil, errors.Trace(err)
 		}
 		chk.AppendBytes(colIdx, decoder.buf)
-	case compactBytesFlag:
+	case compactBytesFlag, geometryFlag:
 		var v []byte
 		b, v, err = DecodeCompactBytes(b)
 		if err != nil {
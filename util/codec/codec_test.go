@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/stretchr/testify/require"
 )
@@ -841,6 +842,34 @@ func TestJSON(t *testing.T) {
 	}
 }
 
+func TestGeometry(t *testing.T) {
+	t.Parallel()
+
+	tbl := []string{
+		"POINT(1 2)",
+		"POLYGON((0 0,10 0,10 10,0 10,0 0))",
+	}
+
+	originalDatums := make([]types.Datum, 0, len(tbl))
+	for _, wkt := range tbl {
+		g, err := spatial.ParseWKT(wkt, 0)
+		require.NoError(t, err)
+		originalDatums = append(originalDatums, types.NewMysqlGeometryDatum(spatial.Encode(g)))
+	}
+
+	buf := make([]byte, 0, 4096)
+	buf, err := encode(nil, buf, originalDatums, false)
+	require.NoError(t, err)
+
+	decodedDatums, err := Decode(buf, 2)
+	require.NoError(t, err)
+
+	for i := range decodedDatums {
+		require.Equal(t, types.KindMysqlGeometry, decodedDatums[i].Kind())
+		require.Equal(t, originalDatums[i].GetBytes(), decodedDatums[i].GetBytes())
+	}
+}
+
 func TestCut(t *testing.T) {
 	t.Parallel()
 
@@ -295,7 +295,7 @@ func decodeRune(s string, si int) (r rune, newIndex int) {
 // IsCICollation returns if the collation is case-sensitive
 func IsCICollation(collate string) bool {
 	return collate == "utf8_general_ci" || collate == "utf8mb4_general_ci" ||
-		collate == "utf8_unicode_ci" || collate == "utf8mb4_unicode_ci"
+		collate == "utf8_unicode_ci" || collate == "utf8mb4_unicode_ci" || collate == "utf8mb4_0900_ai_ci"
 }
 
 // IsBinCollation returns if the collation is 'xx_bin'
@@ -304,6 +304,19 @@ func IsBinCollation(collate string) bool {
 		collate == "utf8_bin" || collate == "utf8mb4_bin"
 }
 
+// IsTiDBOnlyCollation returns if the collation is only implemented by TiDB,
+// the expressions using it can't be evaluated by the storage engines.
+func IsTiDBOnlyCollation(collate string) bool {
+	return collate == "utf8mb4_0900_ai_ci" || collate == "utf8mb4_0900_as_cs" || collate == "utf8mb4_0900_bin"
+}
+
+// CanUseRawMemAsKey returns if the key of a string is the string itself under the collator,
+// which means the collation is binary and doesn't pad the trailing spaces.
+func CanUseRawMemAsKey(c Collator) bool {
+	_, ok := c.(*binCollator)
+	return ok
+}
+
 func init() {
 	newCollatorMap = make(map[string]Collator)
 	newCollatorIDMap = make(map[int]Collator)
@@ -328,4 +341,11 @@ func init() {
 	newCollatorIDMap[CollationName2ID("utf8_unicode_ci")] = &unicodeCICollator{}
 	newCollatorMap["utf8mb4_zh_pinyin_tidb_as_cs"] = &zhPinyinTiDBASCSCollator{}
 	newCollatorIDMap[CollationName2ID("utf8mb4_zh_pinyin_tidb_as_cs")] = &zhPinyinTiDBASCSCollator{}
+
+	newCollatorMap["utf8mb4_0900_ai_ci"] = &unicode0900AICICollator{}
+	newCollatorIDMap[CollationName2ID("utf8mb4_0900_ai_ci")] = &unicode0900AICICollator{}
+	newCollatorMap["utf8mb4_0900_as_cs"] = &unicode0900ASCSCollator{}
+	newCollatorIDMap[CollationName2ID("utf8mb4_0900_as_cs")] = &unicode0900ASCSCollator{}
+	newCollatorMap["utf8mb4_0900_bin"] = &binCollator{}
+	newCollatorIDMap[CollationName2ID("utf8mb4_0900_bin")] = &binCollator{}
 }
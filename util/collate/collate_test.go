@@ -152,6 +152,96 @@ func TestUnicodeCICollator(t *testing.T) {
 	testKeyTable(keyTable, "utf8mb4_unicode_ci", t)
 }
 
+func TestUnicode0900AICICollator(t *testing.T) {
+	SetNewCollationEnabledForTest(true)
+	defer SetNewCollationEnabledForTest(false)
+
+	compareTable := []compareTable{
+		{"a", "b", -1},
+		{"a", "A", 0},
+		{"a", "á", 0},
+		{"abc", "ab", 1},
+		{"a", "a ", -1},
+		{"a ", "a  ", -1},
+		{"😜", "😃", 1},
+		{"ß", "s", 1},
+		{"ß", "ss", 0},
+		{"Å", "A\u030A", 0},
+		{"中", "丁", 1},
+		{"𠀀", "中", 1},
+	}
+	keyTable := []keyTable{
+		{"a", []byte{0x1F, 0xA2}},
+		{"A", []byte{0x1F, 0xA2}},
+		{"a ", []byte{0x1F, 0xA2, 0x02, 0x09}},
+		{"ß", []byte{0x21, 0xD2, 0x21, 0xD2}},
+		{"中", []byte{0xFB, 0x40, 0xCE, 0x2D}},
+		{"㐀", []byte{0xFB, 0x80, 0xB4, 0x00}},
+		{"\u0378", []byte{0xFB, 0xC0, 0x83, 0x78}},
+		{"\U00017000", []byte{0xFB, 0x00, 0x80, 0x00}},
+	}
+
+	testCompareTable(compareTable, "utf8mb4_0900_ai_ci", t)
+	testKeyTable(keyTable, "utf8mb4_0900_ai_ci", t)
+}
+
+func TestUnicode0900ASCSCollator(t *testing.T) {
+	SetNewCollationEnabledForTest(true)
+	defer SetNewCollationEnabledForTest(false)
+
+	compareTable := []compareTable{
+		{"a", "b", -1},
+		{"a", "A", -1},
+		{"A", "á", -1},
+		{"ab", "áb", -1},
+		{"áa", "ab", -1},
+		{"a", "a ", -1},
+		{"ß", "ss", 1},
+		{"Å", "A\u030A", 0},
+	}
+	keyTable := []keyTable{
+		{"a", []byte{0x1F, 0xA2, 0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x02}},
+		{"A", []byte{0x1F, 0xA2, 0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x08}},
+		{"á", []byte{0x1F, 0xA2, 0x00, 0x00, 0x00, 0x20, 0x00, 0x24, 0x00, 0x00, 0x00, 0x02, 0x00, 0x02}},
+	}
+
+	testCompareTable(compareTable, "utf8mb4_0900_as_cs", t)
+	testKeyTable(keyTable, "utf8mb4_0900_as_cs", t)
+}
+
+func TestUnicode0900BinCollator(t *testing.T) {
+	SetNewCollationEnabledForTest(true)
+	defer SetNewCollationEnabledForTest(false)
+
+	compareTable := []compareTable{
+		{"a", "A", 1},
+		{"a", "a ", -1},
+		{"a ", "a  ", -1},
+	}
+	keyTable := []keyTable{
+		{"a ", []byte{0x61, 0x20}},
+	}
+
+	testCompareTable(compareTable, "utf8mb4_0900_bin", t)
+	testKeyTable(keyTable, "utf8mb4_0900_bin", t)
+}
+
+func TestUnicode0900Pattern(t *testing.T) {
+	SetNewCollationEnabledForTest(true)
+	defer SetNewCollationEnabledForTest(false)
+
+	p := GetCollator("utf8mb4_0900_ai_ci").Pattern()
+	p.Compile("a_c%", '\\')
+	require.True(t, p.DoMatch("ÁBC"))
+	require.True(t, p.DoMatch("abcd"))
+	require.False(t, p.DoMatch("abd"))
+
+	p = GetCollator("utf8mb4_0900_as_cs").Pattern()
+	p.Compile("a_c%", '\\')
+	require.True(t, p.DoMatch("aBc"))
+	require.False(t, p.DoMatch("ÁBC"))
+}
+
 func TestSetNewCollateEnabled(t *testing.T) {
 	defer SetNewCollationEnabledForTest(false)
 
@@ -185,6 +275,9 @@ func TestGetCollator(t *testing.T) {
 	require.IsType(t, &unicodeCICollator{}, GetCollator("utf8mb4_unicode_ci"))
 	require.IsType(t, &unicodeCICollator{}, GetCollator("utf8_unicode_ci"))
 	require.IsType(t, &zhPinyinTiDBASCSCollator{}, GetCollator("utf8mb4_zh_pinyin_tidb_as_cs"))
+	require.IsType(t, &unicode0900AICICollator{}, GetCollator("utf8mb4_0900_ai_ci"))
+	require.IsType(t, &unicode0900ASCSCollator{}, GetCollator("utf8mb4_0900_as_cs"))
+	require.IsType(t, &binCollator{}, GetCollator("utf8mb4_0900_bin"))
 	require.IsType(t, &binPaddingCollator{}, GetCollator("default_test"))
 	require.IsType(t, &binCollator{}, GetCollatorByID(63))
 	require.IsType(t, &binPaddingCollator{}, GetCollatorByID(87))
@@ -195,6 +288,9 @@ func TestGetCollator(t *testing.T) {
 	require.IsType(t, &unicodeCICollator{}, GetCollatorByID(224))
 	require.IsType(t, &unicodeCICollator{}, GetCollatorByID(192))
 	require.IsType(t, &zhPinyinTiDBASCSCollator{}, GetCollatorByID(2048))
+	require.IsType(t, &unicode0900AICICollator{}, GetCollatorByID(255))
+	require.IsType(t, &unicode0900ASCSCollator{}, GetCollatorByID(278))
+	require.IsType(t, &binCollator{}, GetCollatorByID(309))
 	require.IsType(t, &binPaddingCollator{}, GetCollatorByID(9999))
 
 	SetNewCollationEnabledForTest(false)
@@ -0,0 +1,241 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build ignore
+
+// This program generates unicode_0900_data.go from the DUCET of UCA 9.0.0, which the utf8mb4_0900
+// collations are based on. Run it in util/collate:
+//
+//	go run generator/unicode_0900_data.go [-allkeys path/to/allkeys.txt]
+//
+// The table is downloaded unless it's given by -allkeys.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	ucaVersion = "9.0.0"
+	allkeysURL = "http://www.unicode.org/Public/UCA/" + ucaVersion + "/allkeys.txt"
+	outputFile = "unicode_0900_data.go"
+	// maxElements is the max number of the collation elements of a code point, the count is stored in 5 bits.
+	maxElements = 1<<5 - 1
+)
+
+const header = `// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by go generate in util/collate/generator; DO NOT EDIT.
+
+package collate
+
+`
+
+var allkeys = flag.String("allkeys", "", "the path of allkeys.txt of UCA "+ucaVersion+", it's downloaded from "+allkeysURL+" if empty")
+
+type entry struct {
+	r   rune
+	ces []uint64
+}
+
+func openAllkeys() (io.ReadCloser, error) {
+	if *allkeys != "" {
+		return os.Open(*allkeys)
+	}
+	resp, err := http.Get(allkeysURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("download %s: %s", allkeysURL, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// parseAllkeys returns the code points which have explicit weights in the order of the table. The entries
+// of the contractions are skipped, since the utf8mb4_0900 collations don't support them.
+func parseAllkeys(r io.Reader) ([]entry, error) {
+	var (
+		entries []entry
+		version string
+	)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "@version") {
+			version = strings.TrimSpace(strings.TrimPrefix(line, "@version"))
+			continue
+		}
+		if line[0] == '@' {
+			continue
+		}
+		parts := strings.SplitN(line, ";", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid line %q", sc.Text())
+		}
+		cps := strings.Fields(parts[0])
+		if len(cps) != 1 {
+			continue
+		}
+		cp, err := strconv.ParseUint(cps[0], 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		// Each collation element looks like `[.0000.0000.0000]` or `[*0000.0000.0000]`.
+		var ces []uint64
+		for _, ce := range strings.Split(strings.TrimSpace(parts[1]), "]") {
+			ce = strings.TrimSpace(ce)
+			if ce == "" {
+				continue
+			}
+			weights := strings.Split(ce[2:], ".")
+			if len(weights) < 3 {
+				return nil, fmt.Errorf("invalid collation element in line %q", sc.Text())
+			}
+			var ceValue uint64
+			for _, weight := range weights[:3] {
+				w, err := strconv.ParseUint(weight, 16, 16)
+				if err != nil {
+					return nil, err
+				}
+				ceValue = ceValue<<16 | w
+			}
+			ces = append(ces, ceValue)
+		}
+		if len(ces) > maxElements {
+			return nil, fmt.Errorf("too many collation elements in line %q", sc.Text())
+		}
+		entries = append(entries, entry{r: rune(cp), ces: ces})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if version != ucaVersion {
+		return nil, fmt.Errorf("the version of allkeys.txt is %q, %q is expected", version, ucaVersion)
+	}
+	return entries, nil
+}
+
+func writeValues(buf *bytes.Buffer, n, perLine int, value func(i int) string) {
+	for i := 0; i < n; i += perLine {
+		buf.WriteString("\t\t")
+		for j := i; j < i+perLine && j < n; j++ {
+			if j > i {
+				buf.WriteByte(' ')
+			}
+			buf.WriteString(value(j))
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+}
+
+func hex(v uint64) string {
+	if v == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("0x%X", v)
+}
+
+func generate(entries []entry) ([]byte, error) {
+	bmp := make([]uint32, 0x10000)
+	supplementary := make(map[rune]uint32)
+	var (
+		supplementaryCodePoints []rune
+		elements                []uint64
+	)
+	for _, e := range entries {
+		pos := uint32(len(elements))<<5 | uint32(len(e.ces))
+		elements = append(elements, e.ces...)
+		if e.r < 0x10000 {
+			bmp[e.r] = pos
+		} else {
+			supplementary[e.r] = pos
+			supplementaryCodePoints = append(supplementaryCodePoints, e.r)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	fmt.Fprintf(&buf, "/* Data from allkeys.txt(%s). Unicode version '%s'. */\n", allkeysURL, ucaVersion)
+	buf.WriteString("var (\n")
+	buf.WriteString("\t// uca900BMPIndex is the position of the collation elements of the BMP code points in uca900Elements,\n")
+	buf.WriteString("\t// it's `offset<<5 | count`. 0 means the code point has no explicit weights.\n")
+	buf.WriteString("\tuca900BMPIndex = []uint32{\n")
+	writeValues(&buf, len(bmp), 32, func(i int) string { return hex(uint64(bmp[i])) })
+	buf.WriteString("\t}\n\n")
+	buf.WriteString("\t// uca900SupplementaryIndex is uca900BMPIndex of the supplementary code points.\n")
+	buf.WriteString("\tuca900SupplementaryIndex = map[rune]uint32{\n")
+	writeValues(&buf, len(supplementaryCodePoints), 8, func(i int) string {
+		r := supplementaryCodePoints[i]
+		return fmt.Sprintf("0x%X: %s", r, hex(uint64(supplementary[r])))
+	})
+	buf.WriteString("\t}\n\n")
+	buf.WriteString("\t// uca900Elements are the collation elements, each one is `primary<<32 | secondary<<16 | tertiary`.\n")
+	buf.WriteString("\tuca900Elements = []uint64{\n")
+	writeValues(&buf, len(elements), 16, func(i int) string { return hex(elements[i]) })
+	buf.WriteString("\t}\n)\n")
+	return format.Source(buf.Bytes())
+}
+
+func main() {
+	flag.Parse()
+	r, err := openAllkeys()
+	if err != nil {
+		log.Fatalln("open allkeys.txt", err)
+	}
+	entries, err := parseAllkeys(r)
+	r.Close()
+	if err != nil {
+		log.Fatalln("parse allkeys.txt", err)
+	}
+	data, err := generate(entries)
+	if err != nil {
+		log.Fatalln("generate", err)
+	}
+	if err := ioutil.WriteFile(outputFile, data, 0644); err != nil {
+		log.Fatalln("write", outputFile, err)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate go run generator/unicode_0900_data.go
+
+package collate
+
+import (
+	"unicode"
+
+	"github.com/pingcap/tidb/util/stringutil"
+)
+
+// The levels of the collation elements which are compared by the utf8mb4_0900 collations.
+const (
+	uca900Primary = iota
+	uca900Secondary
+	uca900Tertiary
+)
+
+var (
+	// uca900CoreHan are the unified ideographs of the CJK Unified Ideographs and CJK Compatibility Ideographs blocks
+	// in Unicode 9.0.0, their implicit weights start from 0xFB40.
+	uca900CoreHan = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x4E00, Hi: 0x9FD5, Stride: 1},
+			{Lo: 0xFA0E, Hi: 0xFA0F, Stride: 1},
+			{Lo: 0xFA11, Hi: 0xFA13, Stride: 2},
+			{Lo: 0xFA14, Hi: 0xFA14, Stride: 1},
+			{Lo: 0xFA1F, Hi: 0xFA21, Stride: 2},
+			{Lo: 0xFA23, Hi: 0xFA24, Stride: 1},
+			{Lo: 0xFA27, Hi: 0xFA29, Stride: 1},
+		},
+	}
+	// uca900OtherHan are the other unified ideographs in Unicode 9.0.0, their implicit weights start from 0xFB80.
+	uca900OtherHan = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x3400, Hi: 0x4DB5, Stride: 1},
+		},
+		R32: []unicode.Range32{
+			{Lo: 0x20000, Hi: 0x2A6D6, Stride: 1},
+			{Lo: 0x2A700, Hi: 0x2B734, Stride: 1},
+			{Lo: 0x2B740, Hi: 0x2B81D, Stride: 1},
+			{Lo: 0x2B820, Hi: 0x2CEA1, Stride: 1},
+		},
+	}
+	// uca900Tangut are the Tangut ideographs and components in Unicode 9.0.0, their implicit weights start from 0xFB00.
+	uca900Tangut = &unicode.RangeTable{
+		R32: []unicode.Range32{
+			{Lo: 0x17000, Hi: 0x187EC, Stride: 1},
+			{Lo: 0x18800, Hi: 0x18AF2, Stride: 1},
+		},
+	}
+)
+
+// uca900Iter iterates the collation elements of a string.
+type uca900Iter struct {
+	str string
+	idx int
+	// ces are the remaining collation elements of the current code point.
+	ces      []uint64
+	implicit [2]uint64
+}
+
+func newUCA900Iter(str string) uca900Iter {
+	return uca900Iter{str: str}
+}
+
+// next returns the next non-ignorable weight of the level, 0 means the end of the string.
+func (it *uca900Iter) next(level int) uint16 {
+	for {
+		for len(it.ces) > 0 {
+			w := uca900Weight(it.ces[0], level)
+			it.ces = it.ces[1:]
+			if w != 0 {
+				return w
+			}
+		}
+		if it.idx >= len(it.str) {
+			return 0
+		}
+		var r rune
+		r, it.idx = decodeRune(it.str, it.idx)
+		it.ces = it.collationElements(r)
+	}
+}
+
+func (it *uca900Iter) collationElements(r rune) []uint64 {
+	var pos uint32
+	if r < 0x10000 {
+		pos = uca900BMPIndex[r]
+	} else {
+		pos = uca900SupplementaryIndex[r]
+	}
+	if pos != 0 {
+		return uca900Elements[pos>>5 : pos>>5+pos&0x1F]
+	}
+	it.implicit[0], it.implicit[1] = uca900ImplicitWeights(r)
+	return it.implicit[:]
+}
+
+// uca900ImplicitWeights returns the implicit collation elements of a code point which has no explicit weights,
+// see https://www.unicode.org/reports/tr10/tr10-34.html#Implicit_Weights.
+func uca900ImplicitWeights(r rune) (uint64, uint64) {
+	var aaaa, bbbb uint64
+	switch {
+	case unicode.Is(uca900Tangut, r):
+		aaaa, bbbb = 0xFB00, uint64(r-0x17000)|0x8000
+	case unicode.Is(uca900CoreHan, r):
+		aaaa, bbbb = 0xFB40+uint64(r>>15), uint64(r&0x7FFF)|0x8000
+	case unicode.Is(uca900OtherHan, r):
+		aaaa, bbbb = 0xFB80+uint64(r>>15), uint64(r&0x7FFF)|0x8000
+	default:
+		aaaa, bbbb = 0xFBC0+uint64(r>>15), uint64(r&0x7FFF)|0x8000
+	}
+	return aaaa<<32 | 0x20<<16 | 0x02, bbbb << 32
+}
+
+func uca900Weight(ce uint64, level int) uint16 {
+	return uint16(ce >> (32 - 16*level))
+}
+
+// compareUCA900 compares the weights of the levels one by one. Trailing spaces are significant, since the
+// utf8mb4_0900 collations are NO PAD.
+func compareUCA900(a, b string, levels int) int {
+	for level := uca900Primary; level < levels; level++ {
+		ai, bi := newUCA900Iter(a), newUCA900Iter(b)
+		for {
+			aw, bw := ai.next(level), bi.next(level)
+			if aw != bw {
+				return sign(int(aw) - int(bw))
+			}
+			if aw == 0 {
+				break
+			}
+		}
+	}
+	return 0
+}
+
+// keyUCA900 appends the weights of the levels, the levels are separated by 0x0000 which is less than any weight.
+func keyUCA900(str string, levels int) []byte {
+	buf := make([]byte, 0, len(str)*2*levels)
+	for level := uca900Primary; level < levels; level++ {
+		if level > uca900Primary {
+			buf = append(buf, 0, 0)
+		}
+		it := newUCA900Iter(str)
+		for w := it.next(level); w != 0; w = it.next(level) {
+			buf = append(buf, byte(w>>8), byte(w))
+		}
+	}
+	return buf
+}
+
+// unicode0900AICICollator implements utf8mb4_0900_ai_ci, which compares the primary weights of UCA 9.0.0.
+type unicode0900AICICollator struct {
+}
+
+// Compare implements Collator interface.
+func (uc *unicode0900AICICollator) Compare(a, b string) int {
+	return compareUCA900(a, b, uca900Secondary)
+}
+
+// Key implements Collator interface.
+func (uc *unicode0900AICICollator) Key(str string) []byte {
+	return keyUCA900(str, uca900Secondary)
+}
+
+// Pattern implements Collator interface.
+func (uc *unicode0900AICICollator) Pattern() WildcardPattern {
+	return &unicode0900Pattern{levels: uca900Secondary}
+}
+
+// unicode0900ASCSCollator implements utf8mb4_0900_as_cs, which compares the primary, secondary and tertiary
+// weights of UCA 9.0.0.
+type unicode0900ASCSCollator struct {
+}
+
+// Compare implements Collator interface.
+func (uc *unicode0900ASCSCollator) Compare(a, b string) int {
+	return compareUCA900(a, b, uca900Tertiary+1)
+}
+
+// Key implements Collator interface.
+func (uc *unicode0900ASCSCollator) Key(str string) []byte {
+	return keyUCA900(str, uca900Tertiary+1)
+}
+
+// Pattern implements Collator interface.
+func (uc *unicode0900ASCSCollator) Pattern() WildcardPattern {
+	return &unicode0900Pattern{levels: uca900Tertiary + 1}
+}
+
+type unicode0900Pattern struct {
+	patChars []rune
+	patTypes []byte
+	levels   int
+}
+
+// Compile implements WildcardPattern interface.
+func (p *unicode0900Pattern) Compile(patternStr string, escape byte) {
+	p.patChars, p.patTypes = stringutil.CompilePatternInner(patternStr, escape)
+}
+
+// DoMatch implements WildcardPattern interface.
+func (p *unicode0900Pattern) DoMatch(str string) bool {
+	return stringutil.DoMatchInner(str, p.patChars, p.patTypes, func(a, b rune) bool {
+		return a == b || compareUCA900(string(a), string(b), p.levels) == 0
+	})
+}
@@ -15,14 +15,19 @@
 package expensivequery
 
 import (
+	"crypto/tls"
 	"testing"
 	"time"
 
+	"github.com/pingcap/tidb/resourcegroup"
+	"github.com/pingcap/tidb/session/txninfo"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/memory"
 	"github.com/pingcap/tidb/util/testbridge"
 	"github.com/stretchr/testify/assert"
+	tikvutil "github.com/tikv/client-go/v2/util"
 	"go.uber.org/goleak"
 )
 
@@ -74,3 +79,89 @@ func TestLogFormat(t *testing.T) {
 	logFields = genLogFields(costTime, info)
 	assert.Equal(t, "select * from table where `a` > ?", logFields[6].String)
 }
+
+type mockSessionManager struct {
+	killed []uint64
+}
+
+func (msm *mockSessionManager) ShowProcessList() map[uint64]*util.ProcessInfo { return nil }
+
+func (msm *mockSessionManager) ShowTxnList() []*txninfo.TxnInfo { return nil }
+
+func (msm *mockSessionManager) GetProcessInfo(id uint64) (*util.ProcessInfo, bool) { return nil, false }
+
+func (msm *mockSessionManager) Kill(connectionID uint64, query bool) {
+	msm.killed = append(msm.killed, connectionID)
+}
+
+func (msm *mockSessionManager) KillAllConnections() {}
+
+func (msm *mockSessionManager) UpdateTLSConfig(cfg *tls.Config) {}
+
+func (msm *mockSessionManager) ServerID() uint64 { return 1 }
+
+func TestRunawayQuery(t *testing.T) {
+	rules := variable.RunawayRules
+	defer func() {
+		rules.ExecElapsedTime.Store(variable.DefTiDBRunawayExecElapsedTime)
+		rules.ProcessedKeys.Store(variable.DefTiDBRunawayProcessedKeys)
+		rules.MemQuota.Store(variable.DefTiDBRunawayMemQuota)
+		rules.Action.Store(variable.DefTiDBRunawayAction)
+		rules.WatchDuration.Store(variable.DefTiDBRunawayWatchDuration)
+	}()
+
+	mem := new(memory.Tracker)
+	mem.Consume(1 << 20)
+	sc := &stmtctx.StatementContext{MemTracker: mem}
+	sc.MergeScanDetail(&tikvutil.ScanDetail{ProcessedKeys: 1000})
+	info := &util.ProcessInfo{
+		ID:      1,
+		Digest:  "digest",
+		Info:    "select * from t",
+		StmtCtx: sc,
+		StatsInfo: func(interface{}) map[string]uint64 {
+			return nil
+		},
+	}
+
+	// No rule is set by default.
+	assert.Equal(t, "", runawayRule(time.Hour, info))
+	rules.ExecElapsedTime.Store(1000)
+	assert.Equal(t, "", runawayRule(time.Millisecond*999, info))
+	assert.Equal(t, "tidb_runaway_exec_elapsed_time=1000", runawayRule(time.Second, info))
+	rules.ExecElapsedTime.Store(0)
+	rules.ProcessedKeys.Store(1001)
+	assert.Equal(t, "", runawayRule(time.Hour, info))
+	rules.ProcessedKeys.Store(1000)
+	assert.Equal(t, "tidb_runaway_processed_keys=1000", runawayRule(time.Hour, info))
+	rules.ProcessedKeys.Store(0)
+	rules.MemQuota.Store(1<<20 + 1)
+	assert.Equal(t, "", runawayRule(time.Hour, info))
+	rules.MemQuota.Store(1 << 20)
+	assert.Equal(t, "tidb_runaway_mem_quota=1048576", runawayRule(time.Hour, info))
+
+	sm := &mockSessionManager{}
+	runaway := resourcegroup.NewRunawayManager()
+	eqh := NewExpensiveQueryHandle(nil).SetRunawayManager(runaway)
+	rules.Action.Store(resourcegroup.RunawayActionDryRun)
+	eqh.handleRunaway(sm, time.Second, info, "rule")
+	assert.Empty(t, sm.killed)
+	assert.False(t, sc.RunawayCooldown.Load())
+	assert.Nil(t, runaway.GetWatch("digest"))
+
+	rules.Action.Store(resourcegroup.RunawayActionCooldown)
+	eqh.handleRunaway(sm, time.Second, info, "rule")
+	assert.Empty(t, sm.killed)
+	assert.True(t, sc.RunawayCooldown.Load())
+
+	// The digest of the killed query is quarantined for the watch duration.
+	rules.Action.Store(resourcegroup.RunawayActionKill)
+	rules.WatchDuration.Store(60)
+	eqh.handleRunaway(sm, time.Second, info, "rule")
+	assert.Equal(t, []uint64{1}, sm.killed)
+	record := runaway.GetWatch("digest")
+	assert.NotNil(t, record)
+	assert.Equal(t, "select * from t", record.SampleSQL)
+	assert.Equal(t, time.Minute, record.EndTime.Sub(record.StartTime))
+	assert.Len(t, runaway.TakePendingWatches(), 1)
+}
@@ -23,6 +23,9 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/parser"
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/resourcegroup"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/logutil"
@@ -32,8 +35,9 @@ import (
 
 // Handle is the handler for expensive query.
 type Handle struct {
-	exitCh chan struct{}
-	sm     atomic.Value
+	exitCh  chan struct{}
+	sm      atomic.Value
+	runaway *resourcegroup.RunawayManager
 }
 
 // NewExpensiveQueryHandle builds a new expensive query handler.
@@ -48,6 +52,12 @@ func (eqh *Handle) SetSessionManager(sm util.SessionManager) *Handle {
 	return eqh
 }
 
+// SetRunawayManager sets the RunawayManager which the digests of the runaway queries are quarantined in.
+func (eqh *Handle) SetRunawayManager(m *resourcegroup.RunawayManager) *Handle {
+	eqh.runaway = m
+	return eqh
+}
+
 // Run starts a expensive query checker goroutine at the start time of the server.
 func (eqh *Handle) Run() {
 	threshold := atomic.LoadUint64(&variable.ExpensiveQueryTimeThreshold)
@@ -74,6 +84,12 @@ func (eqh *Handle) Run() {
 				if info.MaxExecutionTime > 0 && costTime > time.Duration(info.MaxExecutionTime)*time.Millisecond {
 					sm.Kill(info.ID, true)
 				}
+
+				if !info.IdentifiedRunaway.Load() {
+					if rule := runawayRule(costTime, info); rule != "" && info.IdentifiedRunaway.CAS(false, true) {
+						eqh.handleRunaway(sm, costTime, info, rule)
+					}
+				}
 			}
 			threshold = atomic.LoadUint64(&variable.ExpensiveQueryTimeThreshold)
 
@@ -109,6 +125,57 @@ func (eqh *Handle) LogOnQueryExceedMemQuota(connID uint64) {
 	logExpensiveQuery(time.Since(info.Time), info)
 }
 
+// runawayRule returns the rule of variable.RunawayRules which the query breaks, an empty string is returned if
+// the query isn't a runaway query.
+func runawayRule(costTime time.Duration, info *util.ProcessInfo) string {
+	if info.StmtCtx == nil {
+		return ""
+	}
+	if limit := variable.RunawayRules.ExecElapsedTime.Load(); limit > 0 && costTime >= time.Duration(limit)*time.Millisecond {
+		return fmt.Sprintf("%s=%d", variable.TiDBRunawayExecElapsedTime, limit)
+	}
+	if limit := variable.RunawayRules.ProcessedKeys.Load(); limit > 0 {
+		if scanDetail := info.StmtCtx.GetExecDetails().ScanDetail; scanDetail != nil && scanDetail.ProcessedKeys >= limit {
+			return fmt.Sprintf("%s=%d", variable.TiDBRunawayProcessedKeys, limit)
+		}
+	}
+	if limit := variable.RunawayRules.MemQuota.Load(); limit > 0 {
+		if memTracker := info.StmtCtx.MemTracker; memTracker != nil && memTracker.BytesConsumed() >= limit {
+			return fmt.Sprintf("%s=%d", variable.TiDBRunawayMemQuota, limit)
+		}
+	}
+	return ""
+}
+
+// handleRunaway takes the action on the runaway query, and quarantines its digest if the watch duration is set.
+func (eqh *Handle) handleRunaway(sm util.SessionManager, costTime time.Duration, info *util.ProcessInfo, rule string) {
+	action := variable.RunawayRules.Action.Load()
+	logFields := append(genLogFields(costTime, info), zap.String("rule", rule), zap.String("action", action))
+	logutil.BgLogger().Warn("runaway_query", logFields...)
+	metrics.RunawayQueryCounter.WithLabelValues(strings.ToLower(action)).Inc()
+	switch action {
+	case resourcegroup.RunawayActionKill:
+		sm.Kill(info.ID, true)
+	case resourcegroup.RunawayActionCooldown:
+		info.StmtCtx.RunawayCooldown.Store(true)
+	}
+
+	duration := variable.RunawayRules.WatchDuration.Load()
+	if duration <= 0 || eqh.runaway == nil || info.Digest == "" {
+		return
+	}
+	cfg := config.GetGlobalConfig()
+	now := time.Now()
+	eqh.runaway.AddWatch(&resourcegroup.WatchRecord{
+		SQLDigest: info.Digest,
+		SampleSQL: info.Info,
+		StartTime: now,
+		EndTime:   now.Add(time.Duration(duration) * time.Second),
+		Source:    fmt.Sprintf("%s:%d", cfg.AdvertiseAddress, cfg.Port),
+	})
+	metrics.RunawayQueryCounter.WithLabelValues("watch").Inc()
+}
+
 func genLogFields(costTime time.Duration, info *util.ProcessInfo) []zap.Field {
 	logFields := make([]zap.Field, 0, 20)
 	logFields = append(logFields, zap.String("cost_time", strconv.FormatFloat(costTime.Seconds(), 'f', -1, 64)+"s"))
@@ -552,4 +552,6 @@ const (
 	LabelForSimpleTask int = -18
 	// LabelForCTEStorage represents the label of CTE storage
 	LabelForCTEStorage int = -19
+	// LabelForCursorFetch represents the label of the rows of a server-side cursor
+	LabelForCursorFetch int = -20
 )
@@ -79,6 +79,7 @@ func TestPlanIDChanged(t *testing.T) {
 		{typeCTE, 50},
 		{typeCTEDefinition, 51},
 		{typeCTETable, 52},
+		{typeJSONTable, 55},
 	}
 
 	for _, testcase := range testCases {
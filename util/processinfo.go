@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/util/execdetails"
 	"github.com/tikv/client-go/v2/oracle"
+	atomic2 "go.uber.org/atomic"
 )
 
 // ProcessInfo is a struct used for show processlist statement.
@@ -52,6 +53,7 @@ type ProcessInfo struct {
 	State                     uint16
 	Command                   byte
 	ExceedExpensiveTimeThresh bool
+	IdentifiedRunaway         atomic2.Bool
 	RedactSQL                 bool
 }
 
@@ -76,6 +78,14 @@ func (pi *ProcessInfo) ToRowForShow(full bool) []interface{} {
 	} else {
 		host = pi.Host
 	}
+	state := serverStatus2Str(pi.State)
+	if pi.StmtCtx != nil {
+		if progress := pi.StmtCtx.GetProgress(); progress != "" && state != "" {
+			state = fmt.Sprintf("%s; %s", state, progress)
+		} else if progress != "" {
+			state = progress
+		}
+	}
 	return []interface{}{
 		pi.ID,
 		pi.User,
@@ -83,7 +93,7 @@ func (pi *ProcessInfo) ToRowForShow(full bool) []interface{} {
 		db,
 		mysql.Command2Str[pi.Command],
 		t,
-		serverStatus2Str(pi.State),
+		state,
 		info,
 	}
 }
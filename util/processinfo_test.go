@@ -17,7 +17,9 @@ package util_test
 import (
 	"testing"
 
+	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/util"
 	"github.com/stretchr/testify/assert"
 )
@@ -62,3 +64,19 @@ func TestGlobalConnID(t *testing.T) {
 	}
 	assert.Equal(t, (uint64(2002)<<41)|(uint64(123)<<1)|1, connID2.ID())
 }
+
+func TestProcessInfoProgress(t *testing.T) {
+	t.Parallel()
+	pi := util.ProcessInfo{
+		ID:      1,
+		State:   mysql.ServerStatusAutocommit,
+		StmtCtx: &stmtctx.StatementContext{},
+	}
+	assert.Equal(t, "autocommit", pi.ToRowForShow(false)[6])
+
+	pi.StmtCtx.SetProgress("loading file 1/2")
+	assert.Equal(t, "autocommit; loading file 1/2", pi.ToRowForShow(false)[6])
+
+	pi.State = 0
+	assert.Equal(t, "loading file 1/2", pi.ToRowForShow(false)[6])
+}
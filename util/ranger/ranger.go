@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/codec"
 )
 
@@ -448,6 +449,21 @@ func UnionRanges(sc *stmtctx.StatementContext, ranges []*Range, mergeConsecutive
 	return ranges, nil
 }
 
+// BuildMVIndexRange builds the point ranges of a multi-valued index, whose first key part is `CAST(... AS tp ARRAY)`,
+// for the JSON values. The values are converted to the element type as they are when the index entries are written,
+// an error is returned if one of them can't be converted.
+func BuildMVIndexRange(sc *stmtctx.StatementContext, values []json.BinaryJSON, tp *types.FieldType) ([]*Range, error) {
+	ranges := make([]*Range, 0, len(values))
+	for _, value := range values {
+		d, err := types.ConvertJSONToArrayElement(value, tp)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ranges = append(ranges, &Range{LowVal: []types.Datum{d}, HighVal: []types.Datum{d}})
+	}
+	return UnionRanges(sc, ranges, false)
+}
+
 func hasPrefix(lengths []int) bool {
 	for _, l := range lengths {
 		if l != types.UnspecifiedLength {
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/kv"
@@ -33,6 +34,7 @@ import (
 	"github.com/pingcap/tidb/testkit"
 	"github.com/pingcap/tidb/testkit/testdata"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/ranger"
 	"github.com/stretchr/testify/require"
@@ -1780,3 +1782,27 @@ func TestPrefixIndexAppendPointRanges(t *testing.T) {
 		testKit.MustQuery(tt).Check(testkit.Rows(output[i].Result...))
 	}
 }
+
+func TestMVIndexRange(t *testing.T) {
+	t.Parallel()
+
+	tp := types.NewFieldType(mysql.TypeLonglong)
+	tp.Flag |= mysql.UnsignedFlag
+	tp.Array = true
+	sc := &stmtctx.StatementContext{}
+
+	values := make([]json.BinaryJSON, 0, 4)
+	for _, s := range []string{`3`, `1`, `"1"`, `2`} {
+		j, err := json.ParseBinaryFromString(s)
+		require.NoError(t, err)
+		values = append(values, j)
+	}
+	ranges, err := ranger.BuildMVIndexRange(sc, values, tp)
+	require.NoError(t, err)
+	require.Equal(t, "[[1,1] [2,2] [3,3]]", fmt.Sprintf("%v", ranges))
+
+	j, err := json.ParseBinaryFromString(`-1`)
+	require.NoError(t, err)
+	_, err = ranger.BuildMVIndexRange(sc, []json.BinaryJSON{j}, tp)
+	require.Error(t, err)
+}
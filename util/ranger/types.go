@@ -220,7 +220,7 @@ func formatDatum(d types.Datum, isLeftSide bool) string {
 		if d.GetUint64() == math.MaxUint64 && !isLeftSide {
 			return "+inf"
 		}
-	case types.KindBytes:
+	case types.KindBytes, types.KindMysqlGeometry:
 		return fmt.Sprintf("0x%X", d.GetValue())
 	case types.KindString, types.KindMysqlEnum, types.KindMysqlSet,
 		types.KindMysqlJSON, types.KindBinaryLiteral, types.KindMysqlBit:
@@ -172,6 +172,8 @@ func (decoder *DatumMapDecoder) decodeColDatum(col *ColInfo, colData []byte) (ty
 		j.TypeCode = colData[0]
 		j.Value = colData[1:]
 		d.SetMysqlJSON(j)
+	case mysql.TypeGeometry:
+		d.SetMysqlGeometry(colData)
 	default:
 		return d, errors.Errorf("unknown type %d", col.Ft.Tp)
 	}
@@ -290,7 +292,7 @@ func (decoder *ChunkDecoder) decodeColToChunk(colIdx int, col *ColInfo, colData
 		}
 		chk.AppendFloat64(colIdx, fVal)
 	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeString,
-		mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		chk.AppendBytes(colIdx, colData)
 	case mysql.TypeNewDecimal:
 		_, dec, _, frac, err := codec.DecodeDecimal(colData)
@@ -493,7 +495,7 @@ func fieldType2Flag(tp byte, signed bool) (flag byte) {
 	case mysql.TypeFloat, mysql.TypeDouble:
 		flag = FloatFlag
 	case mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
-		mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString:
+		mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeGeometry:
 		flag = BytesFlag
 	case mysql.TypeDatetime, mysql.TypeDate, mysql.TypeTimestamp:
 		flag = UintFlag
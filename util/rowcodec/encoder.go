@@ -164,7 +164,7 @@ func encodeValueDatum(sc *stmtctx.StatementContext, d *types.Datum, buffer []byt
 		buffer = encodeInt(buffer, d.GetInt64())
 	case types.KindUint64:
 		buffer = encodeUint(buffer, d.GetUint64())
-	case types.KindString, types.KindBytes:
+	case types.KindString, types.KindBytes, types.KindMysqlGeometry:
 		buffer = append(buffer, d.GetBytes()...)
 	case types.KindMysqlTime:
 		// for mysql datetime, timestamp and date type
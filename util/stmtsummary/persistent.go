@@ -0,0 +1,350 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stmtsummary
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/parser/terror"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+// backupTimeFormat is the format of the time in the names of the rotated files.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// persistedRecord is a summary in one interval persisted to the file, each record takes one line.
+type persistedRecord struct {
+	BeginTime int64    `json:"begin_time"`
+	EndTime   int64    `json:"end_time"`
+	Digest    string   `json:"digest"`
+	AuthUsers []string `json:"auth_users"`
+	// Columns are the values of the columns of STATEMENTS_SUMMARY_HISTORY, the time values are in RFC3339 format.
+	Columns map[string]interface{} `json:"columns"`
+}
+
+// expiredSummary is a summary whose interval ends, it's waiting to be persisted.
+type expiredSummary struct {
+	ssElement *stmtSummaryByDigestElement
+	ssbd      *stmtSummaryByDigest
+}
+
+func newPersistedRecord(ssElement *stmtSummaryByDigestElement, ssbd *stmtSummaryByDigest) *persistedRecord {
+	ssElement.Lock()
+	defer ssElement.Unlock()
+
+	record := &persistedRecord{
+		BeginTime: ssElement.beginTime,
+		EndTime:   ssElement.endTime,
+		Digest:    ssbd.digest,
+		AuthUsers: make([]string, 0, len(ssElement.authUsers)),
+		Columns:   make(map[string]interface{}, len(columnValueFactoryMap)),
+	}
+	for user := range ssElement.authUsers {
+		record.AuthUsers = append(record.AuthUsers, user)
+	}
+	for name, factory := range columnValueFactoryMap {
+		value := factory(ssElement, ssbd)
+		if t, ok := value.(types.Time); ok {
+			goTime, err := t.GoTime(time.Local)
+			if err != nil {
+				value = nil
+			} else {
+				value = goTime.Format(time.RFC3339Nano)
+			}
+		}
+		record.Columns[name] = value
+	}
+	return record
+}
+
+// toDatum converts the persisted value of the column to the datum, the same as the one read from the memory.
+func (record *persistedRecord) toDatum(col *model.ColumnInfo) types.Datum {
+	switch x := record.Columns[col.Name.O].(type) {
+	case bool:
+		return types.NewDatum(x)
+	case string:
+		if col.Tp == mysql.TypeTimestamp || col.Tp == mysql.TypeDatetime {
+			if t, err := time.Parse(time.RFC3339Nano, x); err == nil {
+				return types.NewDatum(types.NewTime(types.FromGoTime(t.In(time.Local)), mysql.TypeTimestamp, 0))
+			}
+			return types.Datum{}
+		}
+		return types.NewDatum(x)
+	case json.Number:
+		switch col.Tp {
+		case mysql.TypeDouble, mysql.TypeFloat, mysql.TypeNewDecimal:
+			if f, err := x.Float64(); err == nil {
+				return types.NewDatum(f)
+			}
+		default:
+			if i, err := x.Int64(); err == nil {
+				return types.NewDatum(i)
+			}
+			// The value of the unsigned column may overflow int64.
+			if u, err := strconv.ParseUint(x.String(), 10, 64); err == nil {
+				return types.NewDatum(u)
+			}
+		}
+	}
+	return types.Datum{}
+}
+
+// stmtSummaryPersister appends the expired summaries to the file, and rotates the file when it's too large.
+// The rotated files are named like `tidb-statements-2006-01-02T15-04-05.000.log`, and are removed when
+// they are too many or too old.
+type stmtSummaryPersister struct {
+	sync.Mutex
+	// wg waits for the summaries being persisted in the background.
+	wg         sync.WaitGroup
+	filename   string
+	maxSize    int64
+	maxDays    int
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func newStmtSummaryPersister(filename string, maxSizeInMB, maxDays, maxBackups int) (*stmtSummaryPersister, error) {
+	p := &stmtSummaryPersister{
+		filename:   filename,
+		maxSize:    int64(maxSizeInMB) * 1024 * 1024,
+		maxDays:    maxDays,
+		maxBackups: maxBackups,
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := p.openFile(); err != nil {
+		return nil, err
+	}
+	p.removeExpiredBackups()
+	return p, nil
+}
+
+func (p *stmtSummaryPersister) openFile() error {
+	file, err := os.OpenFile(p.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		terror.Log(file.Close())
+		return errors.Trace(err)
+	}
+	p.file = file
+	p.size = info.Size()
+	return nil
+}
+
+// persistAsync persists the summaries in the background.
+func (p *stmtSummaryPersister) persistAsync(summaries []expiredSummary, intervalSeconds int64) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		records := make([]*persistedRecord, 0, len(summaries))
+		for _, summary := range summaries {
+			summary.ssElement.onExpire(intervalSeconds)
+			records = append(records, newPersistedRecord(summary.ssElement, summary.ssbd))
+		}
+		if err := p.write(records); err != nil {
+			logutil.BgLogger().Warn("persist statement summary failed", zap.Error(err))
+		}
+	}()
+}
+
+func (p *stmtSummaryPersister) write(records []*persistedRecord) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.file == nil {
+		return errors.New("the file of statement summary is closed")
+	}
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		line = append(line, '\n')
+		if p.size > 0 && p.size+int64(len(line)) > p.maxSize {
+			if err = p.rotate(); err != nil {
+				return err
+			}
+		}
+		n, err := p.file.Write(line)
+		p.size += int64(n)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// rotate renames the current file as a backup and opens a new one.
+func (p *stmtSummaryPersister) rotate() error {
+	if err := p.file.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	p.file = nil
+	ext := filepath.Ext(p.filename)
+	rotateTime := time.Now()
+	backup := strings.TrimSuffix(p.filename, ext) + "-" + rotateTime.Format(backupTimeFormat) + ext
+	// Never overwrite the files rotated in the same millisecond.
+	for _, err := os.Stat(backup); err == nil; _, err = os.Stat(backup) {
+		rotateTime = rotateTime.Add(time.Millisecond)
+		backup = strings.TrimSuffix(p.filename, ext) + "-" + rotateTime.Format(backupTimeFormat) + ext
+	}
+	if err := os.Rename(p.filename, backup); err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.openFile(); err != nil {
+		return err
+	}
+	p.removeExpiredBackups()
+	return nil
+}
+
+type backupFile struct {
+	path string
+	time time.Time
+}
+
+// backups returns the rotated files sorted by the rotated time.
+func (p *stmtSummaryPersister) backups() ([]backupFile, error) {
+	dir := filepath.Dir(p.filename)
+	ext := filepath.Ext(p.filename)
+	prefix := strings.TrimSuffix(filepath.Base(p.filename), ext) + "-"
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backups := make([]backupFile, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		t, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext), time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{path: filepath.Join(dir, name), time: t})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].time.Before(backups[j].time)
+	})
+	return backups, nil
+}
+
+func (p *stmtSummaryPersister) removeExpiredBackups() {
+	backups, err := p.backups()
+	if err != nil {
+		logutil.BgLogger().Warn("list the rotated files of statement summary failed", zap.Error(err))
+		return
+	}
+	deadline := time.Now().Add(-time.Duration(p.maxDays) * 24 * time.Hour)
+	for i, backup := range backups {
+		tooMany := p.maxBackups > 0 && i < len(backups)-p.maxBackups
+		tooOld := p.maxDays > 0 && backup.time.Before(deadline)
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(backup.path); err != nil {
+			logutil.BgLogger().Warn("remove the rotated file of statement summary failed", zap.String("file", backup.path), zap.Error(err))
+		}
+	}
+}
+
+// read reads the records whose interval overlaps [beginTime, endTime], a zero time means no limit.
+func (p *stmtSummaryPersister) read(beginTime, endTime time.Time) ([]*persistedRecord, error) {
+	p.Lock()
+	backups, err := p.backups()
+	p.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(backups)+1)
+	for _, backup := range backups {
+		files = append(files, backup.path)
+	}
+	files = append(files, p.filename)
+
+	var records []*persistedRecord
+	for _, path := range files {
+		err = readPersistedFile(path, func(record *persistedRecord) {
+			if !beginTime.IsZero() && record.EndTime < beginTime.Unix() {
+				return
+			}
+			if !endTime.IsZero() && record.BeginTime > endTime.Unix() {
+				return
+			}
+			records = append(records, record)
+		})
+		if err != nil {
+			return records, err
+		}
+	}
+	return records, nil
+}
+
+func readPersistedFile(path string, fn func(record *persistedRecord)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		// The file may be removed by the rotation.
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Trace(err)
+	}
+	defer terror.Call(file.Close)
+
+	decoder := json.NewDecoder(file)
+	decoder.UseNumber()
+	for {
+		record := &persistedRecord{}
+		if err = decoder.Decode(record); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			// The last line may be incomplete if TiDB exits unexpectedly, skip the rest of the file.
+			logutil.BgLogger().Warn("parse the file of statement summary failed", zap.String("file", path), zap.Error(err))
+			return nil
+		}
+		fn(record)
+	}
+}
+
+// close waits for the summaries being persisted and closes the file.
+func (p *stmtSummaryPersister) close() {
+	p.wg.Wait()
+	p.Lock()
+	defer p.Unlock()
+	if p.file != nil {
+		terror.Log(p.file.Close())
+		p.file = nil
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stmtsummary
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pingcap/parser/auth"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newPersistentReaderForTest(ssMap *stmtSummaryByDigestMap) *stmtSummaryReader {
+	columns := []struct {
+		name string
+		tp   byte
+		flag uint
+	}{
+		{SummaryBeginTimeStr, mysql.TypeTimestamp, mysql.NotNullFlag},
+		{SummaryEndTimeStr, mysql.TypeTimestamp, mysql.NotNullFlag},
+		{DigestStr, mysql.TypeVarchar, 0},
+		{SchemaNameStr, mysql.TypeVarchar, 0},
+		{ExecCountStr, mysql.TypeLonglong, mysql.NotNullFlag | mysql.UnsignedFlag},
+		{SumLatencyStr, mysql.TypeLonglong, mysql.NotNullFlag | mysql.UnsignedFlag},
+		{MaxRocksdbBlockReadByteStr, mysql.TypeLong, mysql.NotNullFlag | mysql.UnsignedFlag},
+		{AvgWriteKeysStr, mysql.TypeDouble, mysql.NotNullFlag | mysql.UnsignedFlag},
+		{BackoffTypesStr, mysql.TypeVarchar, 0},
+		{FirstSeenStr, mysql.TypeTimestamp, mysql.NotNullFlag},
+		{PlanInCacheStr, mysql.TypeTiny, mysql.NotNullFlag},
+		{QuerySampleTextStr, mysql.TypeBlob, 0},
+		{PrevSampleTextStr, mysql.TypeBlob, 0},
+	}
+	cols := make([]*model.ColumnInfo, len(columns))
+	for i, col := range columns {
+		cols[i] = &model.ColumnInfo{
+			ID:     int64(i),
+			Name:   model.NewCIStr(col.name),
+			Offset: i,
+		}
+		cols[i].Tp = col.tp
+		cols[i].Flag = col.flag
+	}
+	reader := NewStmtSummaryReader(nil, true, cols, "")
+	reader.ssMap = ssMap
+	return reader
+}
+
+// Test persisting the expired summaries and reading them from the files.
+func TestPersistentHistory(t *testing.T) {
+	t.Parallel()
+	ssMap := newStmtSummaryByDigestMap()
+	filename := filepath.Join(t.TempDir(), "tidb-statements.log")
+	require.NoError(t, ssMap.SetupPersistence(filename, 64, 0, 0))
+	now := time.Now().Unix()
+
+	ssMap.beginTimeForCurInterval = now + 10
+	stmtExecInfo1 := generateAnyExecInfo()
+	ssMap.AddStatement(stmtExecInfo1)
+	ssMap.AddStatement(stmtExecInfo1)
+	stmtExecInfo2 := generateAnyExecInfo()
+	stmtExecInfo2.Digest = "digest2"
+	stmtExecInfo2.User = "user2"
+	ssMap.AddStatement(stmtExecInfo2)
+
+	reader := newPersistentReaderForTest(ssMap)
+	var expected [][]types.Datum
+	for _, value := range ssMap.summaryMap.Values() {
+		ssbd := value.(*stmtSummaryByDigest)
+		ssElement := ssbd.history.Back().Value.(*stmtSummaryByDigestElement)
+		ssElement.beginTime = now - 1900
+		ssElement.endTime = now - 100
+		expected = append(expected, reader.getStmtByDigestElementRow(ssElement, ssbd))
+	}
+	// The interval ends, so the summaries are persisted.
+	ssMap.beginTimeForCurInterval = now - 1900
+	ssMap.AddStatement(stmtExecInfo1)
+	ssMap.persister.wg.Wait()
+
+	rows := reader.GetStmtSummaryHistoryRows()
+	require.Len(t, rows, 3)
+	for i, row := range rows[:2] {
+		require.Equal(t, len(expected[i]), len(row))
+		for j := range row {
+			require.Equal(t, expected[i][j].IsNull(), row[j].IsNull(), "column %s", reader.columns[j].Name.O)
+			expectedStr, err := expected[i][j].ToString()
+			require.NoError(t, err)
+			str, err := row[j].ToString()
+			require.NoError(t, err)
+			require.Equal(t, expectedStr, str, "column %s", reader.columns[j].Name.O)
+		}
+	}
+	require.Equal(t, int64(1), rows[2][4].GetInt64())
+
+	// Filter the persisted summaries by the time range.
+	reader.SetTimeRange(time.Unix(now-50, 0), time.Time{})
+	require.Len(t, reader.GetStmtSummaryHistoryRows(), 1)
+	reader.SetTimeRange(time.Unix(now-200, 0), time.Unix(now-1800, 0))
+	require.Len(t, reader.GetStmtSummaryHistoryRows(), 3)
+	reader.SetTimeRange(time.Time{}, time.Time{})
+
+	// Filter the persisted summaries by the digests and the users.
+	reader.SetChecker(NewStmtSummaryChecker(map[string]struct{}{"digest2": {}}))
+	require.Len(t, reader.GetStmtSummaryHistoryRows(), 1)
+	reader.SetChecker(nil)
+	reader.user = &auth.UserIdentity{Username: "user2"}
+	reader.hasProcessPriv = false
+	require.Len(t, reader.GetStmtSummaryHistoryRows(), 1)
+
+	// The summaries in the current interval are persisted when closing, and the history survives the restart.
+	ssMap.Close()
+	ssMap = newStmtSummaryByDigestMap()
+	require.NoError(t, ssMap.SetupPersistence(filename, 64, 0, 0))
+	defer ssMap.Close()
+	reader = newPersistentReaderForTest(ssMap)
+	require.Len(t, reader.GetStmtSummaryHistoryRows(), 3)
+}
+
+// Test rotating the file and removing the rotated files.
+func TestPersistentRotation(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "tidb-statements.log")
+	persister, err := newStmtSummaryPersister(filename, 64, 0, 2)
+	require.NoError(t, err)
+	defer persister.close()
+	persister.maxSize = 200
+
+	for i := 0; i < 10; i++ {
+		record := &persistedRecord{
+			BeginTime: int64(i * 10),
+			EndTime:   int64(i*10 + 10),
+			Digest:    "digest",
+			Columns:   map[string]interface{}{DigestStr: "digest"},
+		}
+		require.NoError(t, persister.write([]*persistedRecord{record}))
+	}
+	backups, err := persister.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+
+	// Only the records in the retained files can be read.
+	records, err := persister.read(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Greater(t, len(records), 0)
+	require.Less(t, len(records), 10)
+	require.Equal(t, int64(90), records[len(records)-1].BeginTime)
+	for i := 1; i < len(records); i++ {
+		require.Less(t, records[i-1].BeginTime, records[i].BeginTime)
+	}
+	records, err = persister.read(time.Unix(85, 0), time.Unix(95, 0))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	// The rotated files older than the max days are removed.
+	persister.maxBackups = 0
+	persister.maxDays = 1
+	old := filepath.Join(dir, "tidb-statements-"+time.Now().Add(-48*time.Hour).Format(backupTimeFormat)+".log")
+	require.NoError(t, os.WriteFile(old, []byte("{}\n"), 0644))
+	backups, err = persister.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 3)
+	persister.removeExpiredBackups()
+	backups, err = persister.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	require.NotEqual(t, old, backups[0].path)
+}
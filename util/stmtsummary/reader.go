@@ -40,6 +40,9 @@ type stmtSummaryReader struct {
 	ssMap                *stmtSummaryByDigestMap
 	columnValueFactories []columnValueFactory
 	checker              *stmtSummaryChecker
+	// beginTime and endTime limit the time range of the persisted history to read, zero means no limit.
+	beginTime time.Time
+	endTime   time.Time
 }
 
 // NewStmtSummaryReader return a new statement summaries reader.
@@ -100,6 +103,9 @@ func (ssr *stmtSummaryReader) GetStmtSummaryCurrentRows() [][]types.Datum {
 // GetStmtSummaryHistoryRows gets all history statement summaries rows.
 func (ssr *stmtSummaryReader) GetStmtSummaryHistoryRows() [][]types.Datum {
 	ssMap := ssr.ssMap
+	if persister := ssMap.getPersister(); persister != nil {
+		return ssr.getPersistedHistoryRows(persister)
+	}
 	ssMap.Lock()
 	values := ssMap.summaryMap.Values()
 	other := ssMap.other
@@ -123,10 +129,52 @@ func (ssr *stmtSummaryReader) GetStmtSummaryHistoryRows() [][]types.Datum {
 	return rows
 }
 
+// getPersistedHistoryRows reads the expired summaries from the files, and the current summaries from the memory.
+func (ssr *stmtSummaryReader) getPersistedHistoryRows(persister *stmtSummaryPersister) [][]types.Datum {
+	records, err := persister.read(ssr.beginTime, ssr.endTime)
+	if err != nil {
+		logutil.BgLogger().Warn("read the persisted statement summary failed", zap.Error(err))
+	}
+	rows := make([][]types.Datum, 0, len(records))
+	for _, record := range records {
+		if ssr.checker != nil && !ssr.checker.isDigestValid(record.Digest) {
+			continue
+		}
+		if ssr.user != nil && !ssr.hasProcessPriv && !hasAuthUser(record.AuthUsers, ssr.user.Username) {
+			continue
+		}
+		row := make([]types.Datum, len(ssr.columns))
+		for i, col := range ssr.columns {
+			if col.Name.O == util.ClusterTableInstanceColumnName {
+				row[i] = types.NewDatum(ssr.instanceAddr)
+			} else {
+				row[i] = record.toDatum(col)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return append(rows, ssr.GetStmtSummaryCurrentRows()...)
+}
+
+func hasAuthUser(authUsers []string, user string) bool {
+	for _, authUser := range authUsers {
+		if authUser == user {
+			return true
+		}
+	}
+	return false
+}
+
 func (ssr *stmtSummaryReader) SetChecker(checker *stmtSummaryChecker) {
 	ssr.checker = checker
 }
 
+// SetTimeRange limits the time range of the persisted history to read, a zero time means no limit.
+func (ssr *stmtSummaryReader) SetTimeRange(beginTime, endTime time.Time) {
+	ssr.beginTime = beginTime
+	ssr.endTime = endTime
+}
+
 func (ssr *stmtSummaryReader) getStmtByDigestRow(ssbd *stmtSummaryByDigest, beginTimeForCurInterval int64) []types.Datum {
 	var ssElement *stmtSummaryByDigestElement
 
@@ -74,6 +74,9 @@ type stmtSummaryByDigestMap struct {
 
 	// other stores summary of evicted data.
 	other *stmtSummaryByDigestEvicted
+
+	// persister persists the summaries to the files once their intervals end, it's nil if persistence is disabled.
+	persister *stmtSummaryPersister
 }
 
 // StmtSummaryByDigestMap is a global map containing all statement summaries.
@@ -281,6 +284,8 @@ func (ssMap *stmtSummaryByDigestMap) AddStatement(sei *StmtExecInfo) {
 	// Calculate hash value in advance, to reduce the time holding the lock.
 	key.Hash()
 
+	var persister *stmtSummaryPersister
+	var expired []expiredSummary
 	// Enclose the block in a function to ensure the lock will always be released.
 	summary, beginTime := func() (*stmtSummaryByDigest, int64) {
 		ssMap.Lock()
@@ -295,6 +300,10 @@ func (ssMap *stmtSummaryByDigestMap) AddStatement(sei *StmtExecInfo) {
 		}
 
 		if ssMap.beginTimeForCurInterval+intervalSeconds <= now {
+			if ssMap.persister != nil && ssMap.beginTimeForCurInterval > 0 {
+				persister = ssMap.persister
+				expired = ssMap.collectSummaries(ssMap.beginTimeForCurInterval)
+			}
 			// `beginTimeForCurInterval` is a multiple of intervalSeconds, so that when the interval is a multiple
 			// of 60 (or 600, 1800, 3600, etc), begin time shows 'XX:XX:00', not 'XX:XX:01'~'XX:XX:59'.
 			ssMap.beginTimeForCurInterval = now / intervalSeconds * intervalSeconds
@@ -313,12 +322,84 @@ func (ssMap *stmtSummaryByDigestMap) AddStatement(sei *StmtExecInfo) {
 		summary.isInternal = summary.isInternal && sei.IsInternal
 		return summary, beginTime
 	}()
+	if len(expired) > 0 {
+		persister.persistAsync(expired, intervalSeconds)
+	}
 	// Lock a single entry, not the whole cache.
 	if summary != nil {
 		summary.add(sei, beginTime, intervalSeconds, historySize)
 	}
 }
 
+// collectSummaries collects the summaries in the interval beginning at `beginTime`, including the evicted one.
+// It must be called with ssMap locked.
+func (ssMap *stmtSummaryByDigestMap) collectSummaries(beginTime int64) []expiredSummary {
+	values := ssMap.summaryMap.Values()
+	summaries := make([]expiredSummary, 0, len(values)+1)
+	for _, value := range values {
+		ssbd := value.(*stmtSummaryByDigest)
+		ssbd.Lock()
+		if ssbd.initialized && ssbd.history.Len() > 0 {
+			ssElement := ssbd.history.Back().Value.(*stmtSummaryByDigestElement)
+			if ssElement.beginTime == beginTime {
+				summaries = append(summaries, expiredSummary{ssElement: ssElement, ssbd: ssbd})
+			}
+		}
+		ssbd.Unlock()
+	}
+	ssMap.other.Lock()
+	if ssMap.other.history.Len() > 0 {
+		seElement := ssMap.other.history.Back().Value.(*stmtSummaryByDigestEvictedElement)
+		if seElement.beginTime == beginTime {
+			summaries = append(summaries, expiredSummary{ssElement: seElement.otherSummary, ssbd: new(stmtSummaryByDigest)})
+		}
+	}
+	ssMap.other.Unlock()
+	return summaries
+}
+
+// SetupPersistence persists the summaries to the files once their intervals end, so that the history survives
+// the restarts and isn't limited by the history size.
+func (ssMap *stmtSummaryByDigestMap) SetupPersistence(filename string, maxSizeInMB, maxDays, maxBackups int) error {
+	persister, err := newStmtSummaryPersister(filename, maxSizeInMB, maxDays, maxBackups)
+	if err != nil {
+		return err
+	}
+	ssMap.Lock()
+	defer ssMap.Unlock()
+	if ssMap.persister != nil {
+		ssMap.persister.close()
+	}
+	ssMap.persister = persister
+	return nil
+}
+
+// Close persists the summaries in the current interval and closes the files, it's called when TiDB exits.
+func (ssMap *stmtSummaryByDigestMap) Close() {
+	ssMap.Lock()
+	persister := ssMap.persister
+	ssMap.persister = nil
+	var summaries []expiredSummary
+	if persister != nil && ssMap.beginTimeForCurInterval > 0 {
+		summaries = ssMap.collectSummaries(ssMap.beginTimeForCurInterval)
+	}
+	ssMap.Unlock()
+
+	if persister == nil {
+		return
+	}
+	if len(summaries) > 0 {
+		persister.persistAsync(summaries, ssMap.refreshInterval())
+	}
+	persister.close()
+}
+
+func (ssMap *stmtSummaryByDigestMap) getPersister() *stmtSummaryPersister {
+	ssMap.Lock()
+	defer ssMap.Unlock()
+	return ssMap.persister
+}
+
 // Clear removes all statement summaries.
 func (ssMap *stmtSummaryByDigestMap) Clear() {
 	ssMap.Lock()